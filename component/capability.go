@@ -0,0 +1,131 @@
+package component
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Capability names for the optional interfaces a component may implement.
+// These match the interface names.
+const (
+	CapabilityAuthenticator      = "Authenticator"
+	CapabilityAuthRefresher      = "AuthRefresher"
+	CapabilityDestroyer          = "Destroyer"
+	CapabilityWorkspaceDestroyer = "WorkspaceDestroyer"
+	CapabilityExecer             = "Execer"
+	CapabilityPortForwarder      = "PortForwarder"
+	CapabilityPlatformAccess     = "PlatformAccess"
+	CapabilityLogPlatform        = "LogPlatform"
+	CapabilityGeneration         = "Generation"
+	CapabilityStatus             = "Status"
+	CapabilityRemediator         = "Remediator"
+	CapabilityTroubleshooter     = "Troubleshooter"
+	CapabilitySnapshotter        = "Snapshotter"
+	CapabilitySigner             = "Signer"
+	CapabilityValidator          = "Validator"
+	CapabilityConfigDefaulter    = "ConfigDefaulter"
+	CapabilityODRProfiler        = "ODRProfiler"
+	CapabilityRegistryPuller     = "RegistryPuller"
+)
+
+// capabilityTypes maps each capability to the interface it requires. This
+// is used for values that don't implement CapabilityReporter.
+var capabilityTypes = map[string]reflect.Type{
+	CapabilityAuthenticator:      reflect.TypeOf((*Authenticator)(nil)).Elem(),
+	CapabilityAuthRefresher:      reflect.TypeOf((*AuthRefresher)(nil)).Elem(),
+	CapabilityDestroyer:          reflect.TypeOf((*Destroyer)(nil)).Elem(),
+	CapabilityWorkspaceDestroyer: reflect.TypeOf((*WorkspaceDestroyer)(nil)).Elem(),
+	CapabilityExecer:             reflect.TypeOf((*Execer)(nil)).Elem(),
+	CapabilityPortForwarder:      reflect.TypeOf((*PortForwarder)(nil)).Elem(),
+	CapabilityPlatformAccess:     reflect.TypeOf((*PlatformAccess)(nil)).Elem(),
+	CapabilityLogPlatform:        reflect.TypeOf((*LogPlatform)(nil)).Elem(),
+	CapabilityGeneration:         reflect.TypeOf((*Generation)(nil)).Elem(),
+	CapabilityStatus:             reflect.TypeOf((*Status)(nil)).Elem(),
+	CapabilityRemediator:         reflect.TypeOf((*Remediator)(nil)).Elem(),
+	CapabilityTroubleshooter:     reflect.TypeOf((*Troubleshooter)(nil)).Elem(),
+	CapabilitySnapshotter:        reflect.TypeOf((*Snapshotter)(nil)).Elem(),
+	CapabilitySigner:             reflect.TypeOf((*Signer)(nil)).Elem(),
+	CapabilityValidator:          reflect.TypeOf((*Validator)(nil)).Elem(),
+	CapabilityConfigDefaulter:    reflect.TypeOf((*ConfigDefaulter)(nil)).Elem(),
+	CapabilityODRProfiler:        reflect.TypeOf((*ODRProfiler)(nil)).Elem(),
+	CapabilityRegistryPuller:     reflect.TypeOf((*RegistryPuller)(nil)).Elem(),
+}
+
+// CapabilityReporter is implemented by plugin clients to report the
+// optional capabilities that the plugin implements. Plugin clients embed
+// every optional interface so a type assertion alone isn't enough to know
+// whether a capability is available.
+type CapabilityReporter interface {
+	Capabilities() []string
+}
+
+// Capabilities returns the sorted list of optional capabilities that v
+// implements. If v implements CapabilityReporter that list is used,
+// otherwise the capabilities are determined by type assertions.
+func Capabilities(v interface{}) []string {
+	var result []string
+	if r, ok := v.(CapabilityReporter); ok {
+		result = append(result, r.Capabilities()...)
+	} else if v != nil {
+		typ := reflect.TypeOf(v)
+		for name, iface := range capabilityTypes {
+			if typ.Implements(iface) {
+				result = append(result, name)
+			}
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// RequireCapability returns an *ErrCapabilityRequired if v doesn't
+// implement the given capability. The plugin name is only used for the
+// error message and may be empty.
+func RequireCapability(v interface{}, plugin, capability string) error {
+	available := Capabilities(v)
+	for _, c := range available {
+		if c == capability {
+			return nil
+		}
+	}
+
+	return &ErrCapabilityRequired{
+		Capability: capability,
+		Plugin:     plugin,
+		Available:  available,
+	}
+}
+
+// ErrCapabilityRequired is returned when an operation requires an optional
+// capability that the plugin doesn't implement. Hosts can check for this
+// with errors.As to render a helpful message, such as suggesting a plugin
+// upgrade, rather than a generic failure.
+type ErrCapabilityRequired struct {
+	// Capability is the capability that was required, such as
+	// CapabilityDestroyer.
+	Capability string
+
+	// Plugin is the name of the plugin, if known.
+	Plugin string
+
+	// Available is the list of optional capabilities that the plugin
+	// does implement.
+	Available []string
+}
+
+func (e *ErrCapabilityRequired) Error() string {
+	name := "plugin"
+	if e.Plugin != "" {
+		name = fmt.Sprintf("plugin %q", e.Plugin)
+	}
+
+	msg := fmt.Sprintf("%s does not support required capability: %s", name, e.Capability)
+	if len(e.Available) > 0 {
+		msg += fmt.Sprintf(" (supported: %s)", strings.Join(e.Available, ", "))
+	}
+
+	return msg
+}
@@ -0,0 +1,57 @@
+package component
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCapabilityReporter []string
+
+func (r testCapabilityReporter) Capabilities() []string { return r }
+
+type testStatusImpl struct{}
+
+func (testStatusImpl) StatusFunc() interface{} { return nil }
+
+func TestCapabilities(t *testing.T) {
+	require := require.New(t)
+
+	require.Empty(Capabilities(nil))
+	require.Equal([]string{CapabilityStatus}, Capabilities(testStatusImpl{}))
+	require.Equal(
+		[]string{CapabilityDestroyer, CapabilityStatus},
+		Capabilities(testCapabilityReporter{CapabilityStatus, CapabilityDestroyer}),
+	)
+}
+
+func TestRequireCapability(t *testing.T) {
+	require := require.New(t)
+
+	v := testCapabilityReporter{CapabilityStatus}
+	require.NoError(RequireCapability(v, "test", CapabilityStatus))
+
+	err := RequireCapability(v, "test", CapabilityDestroyer)
+	require.Error(err)
+
+	var capErr *ErrCapabilityRequired
+	require.True(errors.As(err, &capErr))
+	require.Equal(CapabilityDestroyer, capErr.Capability)
+	require.Equal("test", capErr.Plugin)
+	require.Equal([]string{CapabilityStatus}, capErr.Available)
+	require.Equal(
+		`plugin "test" does not support required capability: Destroyer (supported: Status)`,
+		err.Error(),
+	)
+
+	err = RequireCapability(nil, "", CapabilityDestroyer)
+	require.Equal("plugin does not support required capability: Destroyer", err.Error())
+}
+
+func TestTypeFromString(t *testing.T) {
+	require.Equal(t, BuilderType, TypeFromString("Builder"))
+	require.Equal(t, TaskLauncherType, TypeFromString("TaskLauncher"))
+	require.Equal(t, InvalidType, TypeFromString("maxType"))
+	require.Equal(t, InvalidType, TypeFromString("nope"))
+}
@@ -0,0 +1,71 @@
+package component
+
+import (
+	protobuf "google.golang.org/protobuf/proto"
+
+	proto "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// DiffDeclaredResources returns the changes between the resources declared
+// by two operations. Resources are matched by platform, type, and name. A
+// matched resource is changed if its state, state JSON, or display hint
+// differs.
+//
+// Added and changed resources are in the order of current, removed
+// resources are in the order of previous.
+func DiffDeclaredResources(previous, current []*proto.DeclaredResource) *proto.ResourceChangelog {
+	prev := map[resourceKey]*proto.DeclaredResource{}
+	for _, r := range previous {
+		if r != nil {
+			prev[keyForResource(r)] = r
+		}
+	}
+
+	var result proto.ResourceChangelog
+	seen := map[resourceKey]struct{}{}
+	for _, r := range current {
+		if r == nil {
+			continue
+		}
+
+		key := keyForResource(r)
+		seen[key] = struct{}{}
+
+		old, ok := prev[key]
+		if !ok {
+			result.Added = append(result.Added, r)
+			continue
+		}
+
+		if old.StateJson != r.StateJson ||
+			old.CategoryDisplayHint != r.CategoryDisplayHint ||
+			!protobuf.Equal(old.State, r.State) {
+			result.Changed = append(result.Changed, &proto.ResourceChangelog_Change{
+				Previous: old,
+				Current:  r,
+			})
+		}
+	}
+
+	for _, r := range previous {
+		if r == nil {
+			continue
+		}
+
+		if _, ok := seen[keyForResource(r)]; !ok {
+			result.Removed = append(result.Removed, r)
+		}
+	}
+
+	return &result
+}
+
+type resourceKey struct {
+	platform string
+	typ      string
+	name     string
+}
+
+func keyForResource(r *proto.DeclaredResource) resourceKey {
+	return resourceKey{platform: r.Platform, typ: r.Type, name: r.Name}
+}
@@ -0,0 +1,42 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	proto "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+func TestDiffDeclaredResources(t *testing.T) {
+	require := require.New(t)
+
+	previous := []*proto.DeclaredResource{
+		{Name: "lb", Type: "load-balancer", Platform: "aws", StateJson: `{"a":1}`},
+		{Name: "svc", Type: "service", Platform: "aws"},
+		{Name: "old", Type: "service", Platform: "aws"},
+	}
+
+	current := []*proto.DeclaredResource{
+		{Name: "lb", Type: "load-balancer", Platform: "aws", StateJson: `{"a":2}`},
+		{Name: "svc", Type: "service", Platform: "aws"},
+		{Name: "new", Type: "service", Platform: "aws"},
+		{Name: "svc", Type: "task", Platform: "aws"},
+	}
+
+	result := DiffDeclaredResources(previous, current)
+	require.Len(result.Added, 2)
+	require.Equal("new", result.Added[0].Name)
+	require.Equal("task", result.Added[1].Type)
+	require.Len(result.Removed, 1)
+	require.Equal("old", result.Removed[0].Name)
+	require.Len(result.Changed, 1)
+	require.Equal(`{"a":1}`, result.Changed[0].Previous.StateJson)
+	require.Equal(`{"a":2}`, result.Changed[0].Current.StateJson)
+
+	// No previous resources means everything was added
+	result = DiffDeclaredResources(nil, current)
+	require.Len(result.Added, 4)
+	require.Empty(result.Removed)
+	require.Empty(result.Changed)
+}
@@ -11,8 +11,13 @@
 package component
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/opaqueany"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/docs"
 	proto "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
 
@@ -66,7 +71,9 @@ type TaskLauncher interface {
 	// WatchTaskFunc is called after Start but before Stop to block and
 	// watch a single task. It should stream output to the given UI and
 	// return the exit status after it exits. It is given the state resulting
-	// from StartTaskFunc so that it can look up the resource.
+	// from StartTaskFunc so that it can look up the resource. If the host
+	// provides a *TaskWatchInfo, output and state changes should be streamed
+	// to it while the task runs so they're visible live.
 	WatchTaskFunc() interface{}
 }
 
@@ -88,6 +95,24 @@ type BuilderODR interface {
 	BuildODRFunc() interface{}
 }
 
+// BuilderMulti is an optional interface that builder type plugins can
+// implement to build artifacts for multiple platforms at once, such as a
+// multi-arch container image.
+type BuilderMulti interface {
+	// BuildManyFunc should return the method handle for the multi-platform
+	// "build" operation. The function has the same arguments available as
+	// BuildFunc and should return a *MultiArtifact.
+	BuildManyFunc() interface{}
+}
+
+// MultiArtifact is the result of a BuilderMulti build.
+type MultiArtifact struct {
+	// Artifacts are keyed by platform in "os/arch" or "os/arch/variant"
+	// form, such as "linux/amd64" or "linux/arm/v7". Each artifact must be
+	// a proto message just like the result of BuildFunc.
+	Artifacts map[string]Artifact
+}
+
 // Registry is responsible for managing artifacts.
 type Registry interface {
 	// PushFunc should return the method handle to the function for the "push"
@@ -96,6 +121,17 @@ type Registry interface {
 	PushFunc() interface{}
 }
 
+// RegistryODR is an optional interface that registry type plugins can
+// implement. If the plugin is running in an ondemand runner context, then
+// this function will be called to perform the push instead of PushFunc. This
+// lets the plugin use the credentials of the runner, such as in-cluster
+// credentials, and skip paths that need a docker daemon.
+type RegistryODR interface {
+	// PushODRFunc should return the method handle for the "push" operation
+	// that occurs in the context of an ondemand runner.
+	PushODRFunc() interface{}
+}
+
 // RegistryAccess is an optional interface that a registry plugin can implement.
 // If it does, this function is called before the build plugin and the results
 // are made available to the build plugin. This allows the build plugin to be doing
@@ -105,6 +141,22 @@ type RegistryAccess interface {
 	AccessInfoFunc() interface{}
 }
 
+// RegistryPuller is an optional interface that a registry plugin can implement
+// to fetch or validate an artifact it previously pushed. This lets on-demand
+// runners and platforms ensure an artifact is available before a deploy.
+type RegistryPuller interface {
+	// PullFunc should return the method handle for the "pull" operation. The
+	// pull function has access to the artifact returned by PushFunc and should
+	// fetch it from the registry, returning the local artifact.
+	PullFunc() interface{}
+
+	// VerifyFunc should return the method handle for the "verify" operation.
+	// The verify function has access to the artifact returned by PushFunc and
+	// should return an error if the artifact doesn't exist in the registry
+	// or can't be accessed. Any other results are ignored.
+	VerifyFunc() interface{}
+}
+
 // Platform is responsible for deploying artifacts.
 type Platform interface {
 	// DeployFunc should return the method handle for the "deploy" operation.
@@ -113,9 +165,42 @@ type Platform interface {
 	//
 	//   artifact, artifact registry
 	//
+	// Platforms that update deployments in place can also request a
+	// *PreviousDeployment to compute the changes from the previous
+	// deployment. Older hosts don't give it, so it should be requested
+	// as an optional argument.
 	DeployFunc() interface{}
 }
 
+// PreviousDeployment is available to deploy functions to get the previous
+// deployment of the app. Deploy functions should request it as an optional
+// argument with an argmapper.Struct field tagged
+// `argmapper:",typeOnly,optional"`. If there is no previous deployment or
+// the host doesn't provide it, all fields are empty.
+type PreviousDeployment struct {
+	// ID is the ID of the previous deployment.
+	ID string
+
+	// Sequence is the sequence number of the previous deployment.
+	Sequence uint64
+
+	// Deployment is the value the deploy function returned for the previous
+	// deployment. Use UnmarshalTo to decode it into the deployment type of
+	// the platform. This is nil if there is no previous deployment.
+	Deployment *opaqueany.Any
+}
+
+// PlatformODR is an optional interface that platform type plugins can
+// implement. If the plugin is running in an ondemand runner context, then
+// this function will be called to perform the deploy instead of DeployFunc.
+// This lets the plugin use the credentials of the runner, such as in-cluster
+// credentials.
+type PlatformODR interface {
+	// DeployODRFunc should return the method handle for the "deploy"
+	// operation that occurs in the context of an ondemand runner.
+	DeployODRFunc() interface{}
+}
+
 // PlatformReleaser is an optional interface that a Platform can implement
 // to provide default Release functionality. This only takes effect if
 // no release is configured.
@@ -134,13 +219,31 @@ type ReleaseManager interface {
 }
 
 // Destroyer is responsible for destroying resources associated with this
-// implementation. This can be implemented by all of the component types
-// and will be called to perform cleanup on any created resources.
+// implementation. This can be implemented by all of the component types,
+// such as a builder that cleans up a build cache or a registry that
+// removes a temporary repository, and will be called to perform cleanup
+// on any created resources.
 type Destroyer interface {
 	// DestroyFunc should return the method handle for the destroy operation.
 	DestroyFunc() interface{}
 }
 
+// DestroyHooks is an optional interface that a Destroyer can implement to
+// run logic immediately before and after the destroy operation. For example,
+// a plugin may drain connections before destroying a deployment or remove
+// it from service discovery afterwards.
+//
+// The hook functions have the same types available as DestroyFunc. Either
+// function may return nil if that hook isn't needed.
+type DestroyHooks interface {
+	// PreDestroyFunc is called before DestroyFunc. If this returns an
+	// error, the destroy operation is aborted.
+	PreDestroyFunc() interface{}
+
+	// PostDestroyFunc is called after DestroyFunc completes successfully.
+	PostDestroyFunc() interface{}
+}
+
 // Exec is responsible for starting the exec plugin to allow a deployment
 // plugin to provide it's own exec functionality. By default, the waypoint exec
 // functionality is achieved by creating a session on a long running instance of
@@ -161,6 +264,71 @@ type Execer interface {
 	ExecFunc() interface{}
 }
 
+// PortForwarder is an optional interface that a Platform can implement to
+// forward connections from a local listener on the Waypoint client to a
+// port of a deployment, such as for "waypoint port-forward".
+type PortForwarder interface {
+	// PortForwardFunc should return the method handle for a port forward
+	// operation. This function has the following types available:
+	//  - hclog.Logger
+	//  - context.Context
+	//  - The Deployment type implemented by the plugin
+	//  - *component.PortForwardInfo
+	//  - UI
+	//
+	// The function should forward every connection received from the
+	// PortForwardInfo to the port until the connections channel is
+	// closed or the context is done.
+	PortForwardFunc() interface{}
+}
+
+// PlatformAccess is an optional interface that a Platform can implement to
+// expose how to connect to the platform a deployment is on, such as the
+// kubeconfig context or the ECS cluster ARN. The result is made available
+// to later plugins, such as the release manager, like RegistryAccess does
+// for builders.
+type PlatformAccess interface {
+	// AccessInfoFunc should return the method handle for a function that
+	// returns the access information. The function has the same arguments
+	// available as DeployFunc and its result can be any value that can be
+	// encoded as a proto.Message, which is given to later plugins as a
+	// component.AccessInfo.
+	AccessInfoFunc() interface{}
+}
+
+// Troubleshooter is an optional interface that a Platform can implement to
+// collect provider-specific diagnostics for a deployment, such as the
+// recent logs, events and resource descriptions of a failing deployment.
+type Troubleshooter interface {
+	// TroubleshootFunc should return the method handle for the
+	// troubleshoot operation. The function has the same arguments
+	// available as StatusFunc and should return a
+	// *proto.DiagnosticsBundle. Bundles are streamed to the host so they
+	// may be larger than a single gRPC message.
+	TroubleshootFunc() interface{}
+}
+
+// Snapshotter is an optional interface that a Platform can implement to
+// take part in snapshots and restores of the Waypoint server. Plugins that
+// manage data, such as databases or volumes, can include a snapshot of the
+// data of a deployment so it can be restored with the rest of the server.
+// The data is opaque to the host and is streamed between the plugin and
+// the host, so it isn't limited by the size of a gRPC message.
+type Snapshotter interface {
+	// SnapshotFunc should return the method handle for the snapshot
+	// operation. The function has the same arguments available as
+	// StatusFunc and a *component.SnapshotWriter that it should write the
+	// data of the snapshot to. The snapshot is complete once the function
+	// returns without an error.
+	SnapshotFunc() interface{}
+
+	// RestoreFunc should return the method handle for the restore
+	// operation. The function has the same arguments available as
+	// StatusFunc and a *component.SnapshotReader to read the data written
+	// by a previous snapshot from.
+	RestoreFunc() interface{}
+}
+
 // LogPlatform is responsible for starting the logs plugin that allows a plugin
 // to read logs for a deployment in its own way.
 // This function has the following types available:
@@ -192,13 +360,20 @@ type WorkspaceDestroyer interface {
 	// previously on the workspace. This may be called multiple times so it should
 	// be idempotent. This will be called after all individual DestroyFuncs are
 	// complete.
+	//
+	// Plugins using the framework/resource package can accept a
+	// *resource.WorkspaceState to get the state of all the resource
+	// managers that created resources in the workspace.
 	DestroyWorkspaceFunc() interface{}
 }
 
 // Authenticator is responsible for authenticating different types of plugins.
 type Authenticator interface {
 	// AuthFunc should return the method for getting credentials for a
-	// plugin. This should return AuthResult.
+	// plugin. This should return AuthResult. To prompt for credentials,
+	// use terminal.Prompt or terminal.Confirm with the UI returned by
+	// terminal.LocalInputUI(ui, jobInfo.Local) so that remote runs fail
+	// with terminal.ErrNonInteractive rather than waiting for input.
 	AuthFunc() interface{}
 
 	// ValidateAuthFunc should return the method for validating authentication
@@ -206,12 +381,33 @@ type Authenticator interface {
 	ValidateAuthFunc() interface{}
 }
 
+// AuthRefresher is an optional interface that an Authenticator can
+// implement if its credentials expire. Hosts use the ExpiresAt time of the
+// AuthResult to refresh credentials before they expire during long-running
+// operations such as builds and deploys.
+type AuthRefresher interface {
+	// RefreshAuthFunc should return the method for refreshing credentials
+	// that are about to expire. This should return AuthResult with the new
+	// expiry time. Unlike AuthFunc this is called without user
+	// interaction, so it must not prompt for input.
+	RefreshAuthFunc() interface{}
+}
+
 // See Args.Source in the protobuf protocol.
 type Source struct {
 	App  string
 	Path string
 }
 
+// BuildContext is the local directory that a builder builds from. This is
+// available as an argument to BuildFunc and is derived from the Source.
+// Use the framework/buildcontext package to archive the directory while
+// honoring .waypointignore and .dockerignore files.
+type BuildContext struct {
+	// Path is the root directory of the build context.
+	Path string
+}
+
 // AuthResult is the return value expected from Authenticator.AuthFunc.
 type AuthResult struct {
 	// Authenticated when true means that the plugin should now be authenticated
@@ -220,6 +416,23 @@ type AuthResult struct {
 	// help text or some other information, but it didn't authenticate. However,
 	// this is not an error.
 	Authenticated bool
+
+	// ExpiresAt is when the credentials expire. This is zero if they don't
+	// expire or the expiry isn't known. If this is set and the plugin
+	// implements AuthRefresher, the host may refresh the credentials before
+	// this time.
+	ExpiresAt time.Time
+}
+
+// ExpiresWithin returns true if the credentials expire within d from now,
+// including if they already expired. This is false if the expiry isn't
+// known.
+func (r *AuthResult) ExpiresWithin(d time.Duration) bool {
+	if r == nil || r.ExpiresAt.IsZero() {
+		return false
+	}
+
+	return time.Until(r.ExpiresAt) < d
 }
 
 type LabelSet struct {
@@ -252,6 +465,27 @@ type JobInfo struct {
 	App string
 }
 
+// Context is available to plugins to get richer information than JobInfo
+// about the context in which an operation is executing. This is available
+// to all operation functions if the host provides it.
+type Context struct {
+	// JobInfo is the information about the job.
+	JobInfo
+
+	// AppLabels are the labels of the app being targeted for the current
+	// operation. This is empty for project-scoped operations.
+	AppLabels map[string]string
+
+	// RunnerId is the ID of the runner executing the job. This is empty if
+	// the job isn't executed by a runner.
+	RunnerId string
+
+	// ServerVersion is the version of the Waypoint server the job is for.
+	// This is empty if it isn't known, such as for local operations
+	// without a server.
+	ServerVersion string
+}
+
 // DeploymentInfo is available to some plugins to get information about the
 // deployment.
 //
@@ -297,12 +531,78 @@ type Release interface {
 // StatusReport can be implemented by Platform and PlatformReleaser to query
 // the target platform and build a report of the current deployments health. If
 // this isn't implemented, no status report will be built for the deployments.
+//
+// Registries can also implement this to report the status of a pushed
+// artifact, such as a pending vulnerability scan or its replication.
 type Status interface {
 	// StatusReportFunc should return a proto.StatusReport that details the
 	// result of the most recent health check for a deployment.
 	StatusFunc() interface{}
 }
 
+// Remediator is an optional interface that a plugin implementing Status can
+// implement to perform the actions it suggested in its status report. See
+// the SuggestedActions field of proto.StatusReport_Resource.
+type Remediator interface {
+	// RemediateFunc should return the method handle for the "remediate"
+	// operation. The function has a *Remediation available with the action
+	// to perform, along with the same values as StatusFunc.
+	RemediateFunc() interface{}
+}
+
+// Remediation is given to the RemediateFunc with the suggested action to
+// perform.
+type Remediation struct {
+	// Action is the suggested action the host wants to perform.
+	Action *proto.StatusReport_SuggestedAction
+
+	// Resource is the resource from the status report that the action was
+	// suggested for.
+	Resource *proto.StatusReport_Resource
+}
+
+// Signer is an optional interface that a Builder or Registry can implement
+// to sign artifacts and verify those signatures, such as by integrating with
+// cosign or notation. This lets the host enforce that only signed artifacts
+// are deployed.
+type Signer interface {
+	// SignFunc should return the method handle for the "sign" operation.
+	// The function has the artifact available and should return a
+	// *proto.Signature.
+	SignFunc() interface{}
+
+	// VerifySignatureFunc should return the method handle for verifying
+	// a signature. The function has the artifact and the *proto.Signature
+	// available and should return an error if the signature isn't valid.
+	VerifySignatureFunc() interface{}
+}
+
+// Validator is an optional interface that a Builder, Registry, Platform, or
+// ReleaseManager can implement to validate its configuration against the
+// live environment before any operation runs, such as checking that a
+// configured subnet exists.
+type Validator interface {
+	// ValidateFunc should return the method handle for the "validate"
+	// operation. The function has the same arguments available as the
+	// component's main operation, such as the Source and JobInfo, and
+	// should return a *proto.ValidationResult. A nil result means the
+	// configuration is valid. An error should only be returned if the
+	// validation itself could not be performed.
+	ValidateFunc() interface{}
+}
+
+// Healthy is an optional interface that any component can implement to
+// report its internal health to the host and to orchestrators probing the
+// plugin process with the standard gRPC health service. For example, a
+// component can report that it is degraded because its auth token expired.
+// Components that don't implement this are always reported as healthy.
+type Healthy interface {
+	// Healthy should return nil if the component is healthy or an error
+	// describing why it isn't. This is called for every health check so it
+	// should be fast, such as by checking state cached by the component.
+	Healthy(context.Context) error
+}
+
 // Template can be implemented by Artifact, Deployment, and Release. This
 // will expose this information as available variables in the HCL configuration
 // as well as functions in the `template`-prefixed family, such as `templatefile`.
@@ -320,6 +620,18 @@ type Template interface {
 	TemplateData() map[string]interface{}
 }
 
+// TemplateSchema can be implemented along with Template to declare the
+// keys, types, and descriptions of the template data. The schema is used
+// to generate documentation and the data returned by TemplateData is
+// validated against it at runtime, logging a warning for any drift.
+type TemplateSchema interface {
+	Template
+
+	// TemplateSchema returns the schema of the template data. Like
+	// TemplateData, this will be called on nil or empty values.
+	TemplateSchema() *docs.TemplateSchema
+}
+
 // Generation can be implemented by Platform and PlatformReleaser to explicitly
 // specify a "generation" for a deploy or release. If this isn't implemented,
 // Waypoint generates a random new generation per operation and assumes
@@ -342,10 +654,24 @@ type Generation interface {
 	// the unique generation for the operation.
 	//
 	// The returned function will have access to all of the same parameters
-	// as the operation function itself such as Deploy or Release.
+	// as the operation function itself such as Deploy or Release, as well
+	// as a *PreviousGeneration if the host provides it.
 	GenerationFunc() interface{}
 }
 
+// PreviousGeneration is available to generation functions to get the
+// generation of the previous deployment without querying the platform.
+// For example, a platform can return the previous ID to mutate the
+// existing resources if the configuration allows it.
+type PreviousGeneration struct {
+	// ID is the generation ID of the previous deployment. This is empty if
+	// this is the first deployment.
+	ID []byte
+
+	// Sequence is the sequence number of the previous deployment.
+	Sequence uint64
+}
+
 // RunningTask is returned from StartTask. It contains the state the plugin can
 // use later to stop the task.
 type RunningTask interface{}
@@ -387,6 +713,16 @@ type OutParameter interface {
 type DeclaredResourcesResp struct {
 	// Resources that a plugin declares have been created and are under its management.
 	DeclaredResources []*proto.DeclaredResource
+
+	// PreviousDeclaredResources are the resources declared by the previous
+	// operation, such as those of a *DeclaredResources argument. If this is
+	// set by the plugin, the changes since the previous operation are
+	// computed with DiffDeclaredResources and returned to core.
+	PreviousDeclaredResources []*proto.DeclaredResource
+
+	// Changelog is set by the SDK for core when the plugin set
+	// PreviousDeclaredResources. Plugins should not set this.
+	Changelog *proto.ResourceChangelog
 }
 
 // IsOutParameter causes DeclaredResourcesResp to implement the OutParameter interface, which
@@ -0,0 +1,278 @@
+package component
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SensitiveValue is the value that replaces the old and new values of
+// sensitive fields in a ConfigDiff.
+const SensitiveValue = "(sensitive)"
+
+// ConfigDiff is the set of changes to a configuration structure between
+// two calls to configure a component. See ConfigurableNotifyDiff.
+type ConfigDiff struct {
+	// Changes are the fields whose values changed, in the order they're
+	// defined in the structure.
+	Changes []*ConfigChange
+}
+
+// ConfigChange is a change to a single top-level field of a configuration
+// structure.
+type ConfigChange struct {
+	// Field is the name of the field from its "hcl" tag, or the Go field
+	// name if it has no tag.
+	Field string
+
+	// Old and New are the previous and new values. These are set to
+	// SensitiveValue if the field is sensitive.
+	Old, New interface{}
+
+	// Sensitive is true if the field is tagged with `sensitive:"true"` or
+	// is a Secret.
+	Sensitive bool
+}
+
+// Changed returns true if the field with the given name changed.
+func (d *ConfigDiff) Changed(field string) bool {
+	return d.Change(field) != nil
+}
+
+// Change returns the change for the field with the given name, or nil if
+// the field didn't change.
+func (d *ConfigDiff) Change(field string) *ConfigChange {
+	if d == nil {
+		return nil
+	}
+
+	for _, c := range d.Changes {
+		if c.Field == field {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// Empty returns true if nothing changed.
+func (d *ConfigDiff) Empty() bool {
+	return d == nil || len(d.Changes) == 0
+}
+
+// CopyConfig returns a deep copy of the configuration structure v, for use
+// with DiffConfig. v must be a pointer. Maps, slices, pointers and
+// interfaces are copied with the concrete types of their values, and
+// unexported fields are copied as-is.
+func CopyConfig(v interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("configuration must be a pointer, got %T", v)
+	}
+
+	return copyValue(rv, map[copyKey]reflect.Value{}).Interface(), nil
+}
+
+// copyKey identifies a pointer that was already copied, so that values
+// that are referenced more than once are copied once.
+type copyKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// copyValue returns a deep copy of v. seen holds the copies of the
+// pointers copied so far.
+func copyValue(v reflect.Value, seen map[copyKey]reflect.Value) reflect.Value {
+	t := v.Type()
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(t)
+		}
+
+		key := copyKey{ptr: v.Pointer(), typ: t}
+		if result, ok := seen[key]; ok {
+			return result
+		}
+
+		result := reflect.New(t.Elem())
+		seen[key] = result
+		result.Elem().Set(copyValue(v.Elem(), seen))
+		return result
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return reflect.Zero(t)
+		}
+
+		result := reflect.New(t).Elem()
+		result.Set(copyValue(v.Elem(), seen))
+		return result
+
+	case reflect.Struct:
+		// Copy the whole structure first so unexported fields are kept,
+		// then replace the exported fields with their copies.
+		result := reflect.New(t).Elem()
+		result.Set(v)
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath != "" {
+				continue
+			}
+
+			result.Field(i).Set(copyValue(v.Field(i), seen))
+		}
+
+		return result
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(t)
+		}
+
+		result := reflect.MakeSlice(t, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(copyValue(v.Index(i), seen))
+		}
+
+		return result
+
+	case reflect.Array:
+		result := reflect.New(t).Elem()
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(copyValue(v.Index(i), seen))
+		}
+
+		return result
+
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(t)
+		}
+
+		result := reflect.MakeMapWithSize(t, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), copyValue(iter.Value(), seen))
+		}
+
+		return result
+
+	default:
+		return v
+	}
+}
+
+// DiffConfig returns the changes between two values of the same
+// configuration structure. Only top-level fields are compared; a change
+// within a block is reported as a change of the whole block. Unexported
+// fields are ignored.
+func DiffConfig(old, new interface{}) *ConfigDiff {
+	oldV := reflect.Indirect(reflect.ValueOf(old))
+	newV := reflect.Indirect(reflect.ValueOf(new))
+
+	var result ConfigDiff
+	if !newV.IsValid() || newV.Kind() != reflect.Struct {
+		return &result
+	}
+
+	// A missing old value is treated as the zero value so that every
+	// field that is set is reported.
+	if !oldV.IsValid() || oldV.Type() != newV.Type() {
+		oldV = reflect.Zero(newV.Type())
+	}
+
+	t := newV.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		o, n := oldV.Field(i).Interface(), newV.Field(i).Interface()
+		if reflect.DeepEqual(o, n) {
+			continue
+		}
+
+		change := &ConfigChange{
+			Field:     configFieldName(f),
+			Old:       o,
+			New:       n,
+			Sensitive: sensitiveField(f),
+		}
+		if change.Sensitive {
+			change.Old, change.New = SensitiveValue, SensitiveValue
+		}
+
+		result.Changes = append(result.Changes, change)
+	}
+
+	return &result
+}
+
+// ConfigField is the value of a single top-level field of a configuration
+// structure. See ConfigFields.
+type ConfigField struct {
+	// Name is the name of the field from its "hcl" tag, or the Go field
+	// name if it has no tag.
+	Name string
+
+	// Value is the value of the field. This is set to SensitiveValue if
+	// the field is sensitive.
+	Value interface{}
+
+	// Sensitive is true if the field is tagged with `sensitive:"true"` or
+	// is a Secret.
+	Sensitive bool
+}
+
+// ConfigFields returns the top-level fields of the configuration structure
+// v in the order they're defined. This is used to display the effective
+// configuration of a component. Unexported fields are ignored.
+func ConfigFields(v interface{}) []*ConfigField {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if !rv.IsValid() || rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var result []*ConfigField
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		field := &ConfigField{
+			Name:      configFieldName(f),
+			Value:     rv.Field(i).Interface(),
+			Sensitive: sensitiveField(f),
+		}
+		if field.Sensitive {
+			field.Value = SensitiveValue
+		}
+
+		result = append(result, field)
+	}
+
+	return result
+}
+
+// sensitiveField returns true if the field is tagged with
+// `sensitive:"true"` or is a Secret.
+func sensitiveField(f reflect.StructField) bool {
+	return f.Tag.Get("sensitive") == "true" ||
+		f.Type == secretType ||
+		f.Type == reflect.PtrTo(secretType)
+}
+
+var secretType = reflect.TypeOf(Secret(""))
+
+func configFieldName(f reflect.StructField) string {
+	if tag, ok := f.Tag.Lookup("hcl"); ok {
+		if name := strings.Split(tag, ",")[0]; name != "" {
+			return name
+		}
+	}
+
+	return f.Name
+}
@@ -1,5 +1,9 @@
 package component
 
+import (
+	proto "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
 // ConfigSourcer can be implemented by plugins that support sourcing
 // dynamic configuration for running applications.
 //
@@ -22,6 +26,11 @@ type ConfigSourcer interface {
 	// Read is called for ALL defined configuration variables for this source.
 	// If ANY change, Stop is called followed by Read again. Only one sourcer
 	// is active for a set of configs.
+	//
+	// Values may set a Ttl if they're only valid for a limited time, such
+	// as dynamic credentials. The entrypoint reads the value again once the
+	// Ttl elapses. The framework/configcache package can be used to avoid
+	// reading values that haven't expired yet.
 	ReadFunc() interface{}
 
 	// StopFunc returns a function for stopping configuration sourcing.
@@ -32,6 +41,30 @@ type ConfigSourcer interface {
 	StopFunc() interface{}
 }
 
+// ConfigSourcerWatcher is an optional interface that a ConfigSourcer can
+// implement to push configuration changes as they happen rather than
+// relying on the entrypoint to periodically call Read. This is useful for
+// sources such as Vault or Consul that can notify on change.
+type ConfigSourcerWatcher interface {
+	// WatchFunc returns the function for watching configuration.
+	//
+	// The returned function has the same arguments available as ReadFunc
+	// plus a *ConfigWatcher. It should send the full set of values to
+	// ConfigWatcher.Output each time any of them change and block until
+	// the context is cancelled. Returning (with or without an error) ends
+	// the watch and the entrypoint falls back to calling Read.
+	WatchFunc() interface{}
+}
+
+// ConfigWatcher is given to a WatchFunc to send updated configuration
+// values back to the entrypoint.
+type ConfigWatcher struct {
+	// Output is where updated values should be sent. Each send should
+	// contain a value for every requested variable, exactly like the
+	// result of Read.
+	Output chan []*proto.ConfigSource_Value
+}
+
 // ConfigRequest is sent to ReadFunc for ConfigSourcer to represent a
 // single configuration variable that was requested. The ReadFunc parameters
 // should have a `[]*ConfigRequest` parameter for these.
@@ -3,6 +3,9 @@ package component
 import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/hashicorp/waypoint-plugin-sdk/docs"
 )
 
@@ -18,6 +21,11 @@ type Configurable interface {
 
 // Documented can be optionally implemented by any component to
 // return documentation about the component.
+//
+// Config sourcers can document the fields of ConfigRequest.Config with
+// docs.RequestFromStruct or SetRequestField. The mappers that the plugin
+// provides are added to the documentation automatically, so AddMapper is
+// only needed to describe them.
 type Documented interface {
 	// Documentation returns a completed docs.Documentation struct
 	// describing the components configuration.
@@ -35,22 +43,86 @@ type ConfigurableNotify interface {
 	ConfigSet(interface{}) error
 }
 
+// ConfigurableNotifyDiff is an optional interface that can be implemented
+// by any component to receive the changes to the configuration each time
+// it is decoded. This can be used to rebuild clients or invalidate caches
+// only when relevant fields change.
+//
+// ConfigSetDiff replaces ConfigSet: components that implement both are
+// only notified with ConfigSetDiff, so they don't handle the same
+// configuration twice. On the first configuration, the changes are
+// relative to the value returned by Config before decoding.
+type ConfigurableNotifyDiff interface {
+	Configurable
+
+	// ConfigSetDiff is called with the value of the configuration and the
+	// changes since it was last set after decoding is complete successfully.
+	ConfigSetDiff(v interface{}, diff *ConfigDiff) error
+}
+
+// ConfigurableSchema can be optionally implemented by any component to
+// describe its configuration with an explicit hcldec spec rather than a
+// structure. This is useful for dynamic configuration that a structure
+// can't describe, such as a map of arbitrary blocks.
+type ConfigurableSchema interface {
+	// ConfigSchema returns the spec used to decode the configuration. If
+	// this returns nil, then the component is configured as a Configurable
+	// if it implements that, or as if it isn't configurable otherwise.
+	ConfigSchema() (hcldec.Spec, error)
+
+	// ConfigSetValue is called with the value decoded with the spec
+	// returned by ConfigSchema.
+	ConfigSetValue(cty.Value) error
+}
+
+// ConfigDefaulter is an optional interface that a Builder, Registry,
+// Platform, or ReleaseManager can implement to fill in computed defaults
+// for its configuration, such as deriving a region from the environment.
+//
+// DefaultsFunc is called after the component is configured and before any
+// operation. After it runs, the effective configuration is reported back
+// to the host for display.
+type ConfigDefaulter interface {
+	// DefaultsFunc should return the method handle for the "defaults"
+	// operation. The function has the same arguments available as the
+	// component's main operation, such as the Source and JobInfo. It
+	// should set the defaults on the configuration structure the
+	// component already holds and only return an error.
+	DefaultsFunc() interface{}
+}
+
 // Configure configures c with the provided configuration.
 //
-// If c does not implement Configurable AND body is non-empty, then it is
-// an error. If body is empty in that case, it is not an error.
+// If c implements ConfigurableSchema and returns a spec, the configuration
+// is decoded with that spec. Otherwise, if c does not implement Configurable
+// AND body is non-empty, then it is an error. If body is empty in that case,
+// it is not an error.
 func Configure(c interface{}, body hcl.Body, ctx *hcl.EvalContext) hcl.Diagnostics {
+	if cs, ok := c.(ConfigurableSchema); ok {
+		spec, err := cs.ConfigSchema()
+		if err != nil {
+			return errorDiags(err)
+		}
+
+		if spec != nil {
+			v, diag := hcldec.Decode(body, spec, ctx)
+			if diag.HasErrors() {
+				return diag
+			}
+
+			if err := cs.ConfigSetValue(v); err != nil {
+				return errorDiags(err)
+			}
+
+			return diag
+		}
+	}
+
 	if c, ok := c.(Configurable); ok {
 		// Get the configuration value
 		v, err := c.Config()
 		if err != nil {
-			return hcl.Diagnostics{
-				&hcl.Diagnostic{
-					Severity: hcl.DiagError,
-					Summary:  err.Error(),
-					Detail:   "",
-				},
-			}
+			return errorDiags(err)
 		}
 
 		// If the configuration structure is nil then we behave as if the
@@ -59,22 +131,30 @@ func Configure(c interface{}, body hcl.Body, ctx *hcl.EvalContext) hcl.Diagnosti
 			return nil
 		}
 
+		// Keep the previous value so we can compute the changes.
+		var old interface{}
+		if _, ok := c.(ConfigurableNotifyDiff); ok {
+			old, err = CopyConfig(v)
+			if err != nil {
+				return errorDiags(err)
+			}
+		}
+
 		// Decode
 		if diag := gohcl.DecodeBody(body, ctx, v); len(diag) > 0 {
 			return diag
 		}
 
 		// If decoding worked and we have a notification implementation, then
-		// notify with the value.
-		if cn, ok := c.(ConfigurableNotify); ok {
+		// notify with the value. ConfigSetDiff takes precedence over
+		// ConfigSet.
+		if cn, ok := c.(ConfigurableNotifyDiff); ok {
+			if err := cn.ConfigSetDiff(v, DiffConfig(old, v)); err != nil {
+				return errorDiags(err)
+			}
+		} else if cn, ok := c.(ConfigurableNotify); ok {
 			if err := cn.ConfigSet(v); err != nil {
-				return hcl.Diagnostics{
-					&hcl.Diagnostic{
-						Severity: hcl.DiagError,
-						Summary:  err.Error(),
-						Detail:   "",
-					},
-				}
+				return errorDiags(err)
 			}
 		}
 
@@ -88,6 +168,17 @@ func Configure(c interface{}, body hcl.Body, ctx *hcl.EvalContext) hcl.Diagnosti
 	return diag
 }
 
+// errorDiags returns diagnostics with a single error for err.
+func errorDiags(err error) hcl.Diagnostics {
+	return hcl.Diagnostics{
+		&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  err.Error(),
+			Detail:   "",
+		},
+	}
+}
+
 // Documentation returns the documentation for the given component.
 //
 // If c does not implement Documented, nil is returned.
@@ -2,11 +2,14 @@ package component
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hcldec"
 	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/hashicorp/hcl/v2/hclsimple"
 	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestConfigure(t *testing.T) {
@@ -140,6 +143,197 @@ func TestConfigure_notify(t *testing.T) {
 	})
 }
 
+func TestConfigure_notifyDiff(t *testing.T) {
+	require := require.New(t)
+
+	var c implNotifyDiff
+	configure := func(src string) {
+		f, diag := hclparse.NewParser().ParseHCL([]byte(src), "test.hcl")
+		require.False(diag.HasErrors())
+		diag = Configure(&c, f.Body, nil)
+		require.False(diag.HasErrors())
+	}
+
+	configure(`name = "foo"`)
+	require.Len(c.Diff.Changes, 1)
+	require.Equal(&ConfigChange{Field: "name", Old: "", New: "foo"}, c.Diff.Changes[0])
+
+	configure(`name = "foo"`)
+	require.True(c.Diff.Empty())
+
+	configure(`name = "bar"`)
+	require.True(c.Diff.Changed("name"))
+	require.Equal("foo", c.Diff.Change("name").Old)
+}
+
+func TestConfigure_notifyBoth(t *testing.T) {
+	require := require.New(t)
+
+	// Only ConfigSetDiff is called if both are implemented
+	var c implNotifyBoth
+	f, diag := hclparse.NewParser().ParseHCL([]byte(`name = "foo"`), "test.hcl")
+	require.False(diag.HasErrors())
+	diag = Configure(&c, f.Body, nil)
+	require.False(diag.HasErrors())
+	require.True(c.Diff.Changed("name"))
+	require.False(c.Notified)
+}
+
+func TestConfigure_schema(t *testing.T) {
+	t.Run("spec", func(t *testing.T) {
+		require := require.New(t)
+
+		src := `
+service "web" {
+  port = 8080
+}
+`
+		f, diag := hclparse.NewParser().ParseHCL([]byte(src), "test.hcl")
+		require.False(diag.HasErrors())
+
+		c := &implSchema{Spec: &hcldec.BlockMapSpec{
+			TypeName:   "service",
+			LabelNames: []string{"name"},
+			Nested: hcldec.ObjectSpec{
+				"port": &hcldec.AttrSpec{Name: "port", Type: cty.Number, Required: true},
+			},
+		}}
+		diag = Configure(c, f.Body, nil)
+		require.False(diag.HasErrors())
+
+		port := c.Value.Index(cty.StringVal("web")).GetAttr("port")
+		require.True(port.RawEquals(cty.NumberIntVal(8080)))
+		require.Empty(c.config.Name)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		require := require.New(t)
+
+		src := `port = "foo"`
+		f, diag := hclparse.NewParser().ParseHCL([]byte(src), "test.hcl")
+		require.False(diag.HasErrors())
+
+		c := &implSchema{Spec: hcldec.ObjectSpec{
+			"port": &hcldec.AttrSpec{Name: "port", Type: cty.Number},
+		}}
+		diag = Configure(c, f.Body, nil)
+		require.True(diag.HasErrors())
+		require.Equal(cty.NilVal, c.Value)
+	})
+
+	t.Run("nil spec", func(t *testing.T) {
+		require := require.New(t)
+
+		src := `name = "foo"`
+		f, diag := hclparse.NewParser().ParseHCL([]byte(src), "test.hcl")
+		require.False(diag.HasErrors())
+
+		c := &implSchema{}
+		diag = Configure(c, f.Body, nil)
+		require.False(diag.HasErrors())
+		require.Equal("foo", c.config.Name)
+		require.Equal(cty.NilVal, c.Value)
+	})
+}
+
+func TestDiffConfig(t *testing.T) {
+	require := require.New(t)
+
+	type config struct {
+		Region   string            `hcl:"region,optional"`
+		Token    string            `hcl:"token,optional" sensitive:"true"`
+		Labels   map[string]string `hcl:"labels,optional"`
+		Untagged int
+
+		internal string
+	}
+
+	old := &config{Region: "us-east-1", Token: "a", Labels: map[string]string{"a": "b"}}
+	new := &config{Region: "us-east-1", Token: "b", Untagged: 1, internal: "x"}
+
+	diff := DiffConfig(old, new)
+	require.Equal([]*ConfigChange{
+		{Field: "token", Old: SensitiveValue, New: SensitiveValue, Sensitive: true},
+		{Field: "labels", Old: map[string]string{"a": "b"}, New: map[string]string(nil)},
+		{Field: "Untagged", Old: 0, New: 1},
+	}, diff.Changes)
+	require.False(diff.Changed("region"))
+
+	// No old value compares to the zero value
+	diff = DiffConfig(nil, new)
+	require.True(diff.Changed("region"))
+}
+
+func TestCopyConfig(t *testing.T) {
+	require := require.New(t)
+
+	type block struct {
+		Name string
+	}
+
+	type config struct {
+		Timeout time.Duration
+		Labels  map[string]string
+		Blocks  []*block
+		Extra   interface{}
+		Shared  *block
+		Same    *block
+
+		internal string
+	}
+
+	shared := &block{Name: "shared"}
+	v := &config{
+		Timeout:  time.Minute,
+		Labels:   map[string]string{"a": "b"},
+		Blocks:   []*block{{Name: "one"}},
+		Extra:    map[string]interface{}{"count": 3},
+		Shared:   shared,
+		Same:     shared,
+		internal: "x",
+	}
+
+	raw, err := CopyConfig(v)
+	require.NoError(err)
+	result := raw.(*config)
+	require.Equal(v, result)
+
+	// Concrete types are kept
+	require.IsType(0, result.Extra.(map[string]interface{})["count"])
+
+	// Pointers referenced twice are copied once
+	require.True(result.Shared == result.Same)
+
+	// Changing the copy doesn't change the original
+	result.Labels["a"] = "c"
+	result.Blocks[0].Name = "two"
+	result.Shared.Name = "changed"
+	require.Equal("b", v.Labels["a"])
+	require.Equal("one", v.Blocks[0].Name)
+	require.Equal("shared", v.Shared.Name)
+	require.True(DiffConfig(v, result).Changed("Labels"))
+
+	_, err = CopyConfig(config{})
+	require.Error(err)
+}
+
+func TestConfigFields(t *testing.T) {
+	require := require.New(t)
+
+	type config struct {
+		Region string `hcl:"region,optional"`
+		Token  string `hcl:"token,optional" sensitive:"true"`
+
+		internal string
+	}
+
+	require.Equal([]*ConfigField{
+		{Name: "region", Value: "us-east-1"},
+		{Name: "token", Value: SensitiveValue, Sensitive: true},
+	}, ConfigFields(&config{Region: "us-east-1", Token: "a", internal: "x"}))
+	require.Nil(ConfigFields(nil))
+}
+
 type testConfig struct {
 	Name string `hcl:"name,attr"`
 }
@@ -162,7 +356,43 @@ func (c *implNotify) ConfigSet(interface{}) error {
 	return nil
 }
 
+type implNotifyDiff struct {
+	impl
+	Diff *ConfigDiff
+}
+
+func (c *implNotifyDiff) ConfigSetDiff(v interface{}, diff *ConfigDiff) error {
+	c.Diff = diff
+	return nil
+}
+
+type implNotifyBoth struct {
+	implNotifyDiff
+	Notified bool
+}
+
+func (c *implNotifyBoth) ConfigSet(interface{}) error {
+	c.Notified = true
+	return nil
+}
+
+type implSchema struct {
+	impl
+	Spec  hcldec.Spec
+	Value cty.Value
+}
+
+func (c *implSchema) ConfigSchema() (hcldec.Spec, error) { return c.Spec, nil }
+
+func (c *implSchema) ConfigSetValue(v cty.Value) error {
+	c.Value = v
+	return nil
+}
+
 var (
-	_ Configurable       = (*implNotify)(nil)
-	_ ConfigurableNotify = (*implNotify)(nil)
+	_ Configurable           = (*implNotify)(nil)
+	_ ConfigurableNotify     = (*implNotify)(nil)
+	_ ConfigurableNotifyDiff = (*implNotifyDiff)(nil)
+	_ ConfigurableNotify     = (*implNotifyBoth)(nil)
+	_ ConfigurableSchema     = (*implSchema)(nil)
 )
@@ -0,0 +1,88 @@
+package component
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Contract is a protobuf message type that components exchange, such as
+// the artifact a builder outputs and a registry or platform accepts, with
+// a human-readable name. Plugins register their contracts with
+// sdk.WithContracts and the contracts of each component are reported in
+// its ComponentInfo.
+type Contract struct {
+	// Name is the human-readable name, such as "DockerImage".
+	Name string
+
+	// Type is the full name of the protobuf message, such as "docker.Image".
+	Type string
+}
+
+// NewContract returns the Contract for the type of msg with the given name.
+// msg is usually a nil pointer of the message type, such as
+// (*docker.Image)(nil).
+func NewContract(name string, msg proto.Message) Contract {
+	return Contract{
+		Name: name,
+		Type: string(msg.ProtoReflect().Descriptor().FullName()),
+	}
+}
+
+func (c Contract) String() string {
+	if c.Name == "" || c.Name == c.Type {
+		return c.Type
+	}
+
+	return fmt.Sprintf("%s (%s)", c.Name, c.Type)
+}
+
+// CheckChain checks that the component next can accept the output of the
+// component prev, such as a registry that follows a builder. Hosts can call
+// this when they configure the components of an app to report a mismatch
+// before any operation runs, rather than failing with an unsatisfied
+// argument when the operation of next is called.
+//
+// The check is only done if both components report contracts. Otherwise
+// there is nothing to compare and nil is returned.
+func CheckChain(prev, next *ComponentInfo) error {
+	if len(prev.Outputs) == 0 || len(next.Accepts) == 0 {
+		return nil
+	}
+
+	for _, out := range prev.Outputs {
+		for _, in := range next.Accepts {
+			if out.Type == in.Type {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf(
+		"%s outputs %s, but %s accepts %s",
+		componentLabel(prev), contractList(prev.Outputs),
+		componentLabel(next), contractList(next.Accepts),
+	)
+}
+
+// componentLabel returns the name of the component for error messages,
+// such as `Builder "docker"`.
+func componentLabel(c *ComponentInfo) string {
+	name := c.RegisteredName
+	if name == "" {
+		name = c.Name
+	}
+
+	return fmt.Sprintf("%s %q", c.Type, name)
+}
+
+// contractList returns the contracts joined with "or".
+func contractList(cs []Contract) string {
+	var parts []string
+	for _, c := range cs {
+		parts = append(parts, c.String())
+	}
+
+	return strings.Join(parts, " or ")
+}
@@ -0,0 +1,55 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckChain(t *testing.T) {
+	image := Contract{Name: "DockerImage", Type: "docker.Image"}
+	binary := Contract{Name: "Binary", Type: "exec.Binary"}
+
+	builder := &ComponentInfo{
+		Type:           BuilderType,
+		Name:           "*docker.Builder",
+		RegisteredName: "docker",
+		Outputs:        []Contract{image},
+	}
+
+	cases := []struct {
+		Name  string
+		Next  *ComponentInfo
+		Error string
+	}{
+		{
+			"accepted",
+			&ComponentInfo{Type: RegistryType, Accepts: []Contract{binary, image}},
+			"",
+		},
+
+		{
+			"no contracts",
+			&ComponentInfo{Type: RegistryType},
+			"",
+		},
+
+		{
+			"mismatch",
+			&ComponentInfo{Type: PlatformType, Name: "*exec.Platform", Accepts: []Contract{binary}},
+			`Builder "docker" outputs DockerImage (docker.Image), but Platform "*exec.Platform" accepts Binary (exec.Binary)`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			err := CheckChain(builder, tt.Next)
+			if tt.Error == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.EqualError(t, err, tt.Error)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package component
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	proto "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// EventLogger is a vehicle for plugins to record structured lifecycle
+// events, such as "created load balancer", and return them to core - an
+// "OutParameter". It can be accepted as an argument to Build, Deploy and
+// Release functions. The events are returned with the operation result so
+// that operations have an auditable trail beyond the plugin logs.
+//
+// EventLogger is safe for concurrent use.
+type EventLogger struct {
+	mu     sync.Mutex
+	events []*proto.EventLog_Event
+}
+
+// Log records an event. metadata may be nil.
+func (l *EventLogger) Log(
+	level proto.EventLog_Level,
+	category, msg string,
+	metadata map[string]string,
+) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, &proto.EventLog_Event{
+		Time:     timestamppb.New(time.Now()),
+		Level:    level,
+		Category: category,
+		Message:  msg,
+		Metadata: metadata,
+	})
+}
+
+// Info records an event with the info level.
+func (l *EventLogger) Info(category, msg string, metadata map[string]string) {
+	l.Log(proto.EventLog_INFO, category, msg, metadata)
+}
+
+// Warn records an event with the warn level.
+func (l *EventLogger) Warn(category, msg string, metadata map[string]string) {
+	l.Log(proto.EventLog_WARN, category, msg, metadata)
+}
+
+// Error records an event with the error level.
+func (l *EventLogger) Error(category, msg string, metadata map[string]string) {
+	l.Log(proto.EventLog_ERROR, category, msg, metadata)
+}
+
+// Events returns the events recorded so far in the order they were logged.
+func (l *EventLogger) Events() []*proto.EventLog_Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]*proto.EventLog_Event(nil), l.events...)
+}
+
+// IsOutParameter causes EventLogger to implement the OutParameter interface, which
+// will prevent it from being added as an arg to any plugin advertised dynamic function spec.
+func (l *EventLogger) isOutParameter() {}
+
+// EventTrail is implemented by the artifacts, deployments and releases
+// returned by plugin clients to provide the events the plugin recorded
+// with an EventLogger during the operation.
+type EventTrail interface {
+	Events() []*proto.EventLog_Event
+}
+
+var (
+	_ OutParameter = (*EventLogger)(nil)
+	_ EventTrail   = (*EventLogger)(nil)
+)
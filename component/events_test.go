@@ -0,0 +1,38 @@
+package component
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	proto "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+func TestEventLogger(t *testing.T) {
+	require := require.New(t)
+
+	var l EventLogger
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Info("scaling", "added instance", nil)
+		}()
+	}
+	wg.Wait()
+	l.Error("network", "failed to attach", map[string]string{"subnet": "a"})
+
+	events := l.Events()
+	require.Len(events, 11)
+	last := events[10]
+	require.Equal(proto.EventLog_ERROR, last.Level)
+	require.Equal("network", last.Category)
+	require.Equal("failed to attach", last.Message)
+	require.Equal(map[string]string{"subnet": "a"}, last.Metadata)
+
+	// The result is a copy
+	events[0] = nil
+	require.NotNil(l.Events()[0])
+}
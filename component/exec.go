@@ -33,6 +33,21 @@ type ExecSessionInfo struct {
 	// as well as any variable derived from external systems like vault
 	// or kubernetes.
 	Environment []string
+
+	// a channel that is sent the environment of the session when it
+	// changes, such as when configuration is updated. Each value replaces
+	// Environment entirely.
+	EnvironmentUpdates <-chan []string
+
+	// a channel that is sent signals from the user to forward to the
+	// session, by name such as "SIGINT" or "SIGTERM".
+	Signals <-chan string
+
+	// Exit reports the exit code of the session to the user as soon as it
+	// is known, before the exec function returns. This returns an error if
+	// the client doesn't support it, in which case only the ExecResult
+	// returned by the exec function is used.
+	Exit func(code int) error
 }
 
 // WindowSize provides information about the size of the terminal
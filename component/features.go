@@ -0,0 +1,96 @@
+package component
+
+import (
+	"sort"
+)
+
+// Feature is an optional feature of the plugin protocol. The host and the
+// plugin exchange the features they support when they connect and only
+// the features that both support are enabled. This lets either side adopt
+// new behavior while remaining compatible with older peers, without
+// probing for each RPC.
+type Feature string
+
+const (
+	// FeatureLargeValues means that argument values that are too large to
+	// send inline with a call are streamed over the broker instead. The SDK
+	// implements this on both sides, so plugins don't need to declare it.
+	FeatureLargeValues Feature = "large-values"
+
+	// FeatureTerminalConfirm means that the host answers the confirm
+	// events of the terminal UI. Otherwise confirmations are prompted for
+	// with an input event. The SDK implements this on both sides.
+	FeatureTerminalConfirm Feature = "terminal-confirm"
+
+	// FeatureStatusOnly means that the plugin is serving in status-only
+	// mode, so only the components that support status are available and
+	// only their status-related RPCs can be called. The SDK declares this
+	// for plugins served with sdk.MainStatusOnly.
+	FeatureStatusOnly Feature = "status-only"
+)
+
+// Features is the set of features that were negotiated with the host. Any
+// component function can request a *Features argument to check whether a
+// feature is enabled. The set is empty if the host doesn't support any of
+// the features of the plugin or predates negotiation.
+type Features struct {
+	enabled []Feature
+}
+
+// NewFeatures returns the set of the given features. Duplicates are
+// ignored.
+func NewFeatures(fs ...Feature) *Features {
+	seen := map[Feature]struct{}{}
+	var result Features
+	for _, f := range fs {
+		if _, ok := seen[f]; ok {
+			continue
+		}
+
+		seen[f] = struct{}{}
+		result.enabled = append(result.enabled, f)
+	}
+
+	sort.Slice(result.enabled, func(i, j int) bool {
+		return result.enabled[i] < result.enabled[j]
+	})
+
+	return &result
+}
+
+// Enabled returns true if the feature f is in the set. This is safe to
+// call on a nil *Features, which has no features.
+func (s *Features) Enabled(f Feature) bool {
+	if s == nil {
+		return false
+	}
+
+	for _, v := range s.enabled {
+		if v == f {
+			return true
+		}
+	}
+
+	return false
+}
+
+// List returns the features in the set, sorted by name.
+func (s *Features) List() []Feature {
+	if s == nil {
+		return nil
+	}
+
+	return append([]Feature(nil), s.enabled...)
+}
+
+// Intersect returns the set of the features that are in s and in fs.
+func (s *Features) Intersect(fs ...Feature) *Features {
+	var result []Feature
+	for _, f := range fs {
+		if s.Enabled(f) {
+			result = append(result, f)
+		}
+	}
+
+	return NewFeatures(result...)
+}
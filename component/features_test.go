@@ -0,0 +1,23 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatures(t *testing.T) {
+	require := require.New(t)
+
+	var empty *Features
+	require.False(empty.Enabled(FeatureLargeValues))
+	require.Empty(empty.List())
+
+	fs := NewFeatures(FeatureTerminalConfirm, FeatureLargeValues, FeatureTerminalConfirm)
+	require.Equal([]Feature{FeatureLargeValues, FeatureTerminalConfirm}, fs.List())
+	require.True(fs.Enabled(FeatureTerminalConfirm))
+	require.False(fs.Enabled("other"))
+
+	result := fs.Intersect(FeatureTerminalConfirm, "other")
+	require.Equal([]Feature{FeatureTerminalConfirm}, result.List())
+}
@@ -0,0 +1,97 @@
+package component
+
+import "context"
+
+// PluginInfo describes the components that a plugin binary serves for a
+// single protocol version. Hosts can retrieve it by dispensing the "info"
+// plugin, which is always available, instead of dispensing every
+// component type and handling the errors.
+type PluginInfo struct {
+	// Name and Version are set by the plugin with sdk.WithPluginInfo or
+	// at build time. These are empty if the plugin doesn't set them.
+	Name    string
+	Version string
+
+	// Commit is the VCS commit the plugin was built from. This is empty
+	// if it is unknown.
+	Commit string
+
+	// SDKVersion is the version of the SDK the plugin was built with, such
+	// as "v0.1.0". This is empty if it is unknown, such as for plugins
+	// built with older SDKs.
+	SDKVersion string
+
+	// ProtocolVersion is the protocol version the components are
+	// served for.
+	ProtocolVersion int
+
+	// Components are the components that are served.
+	Components []*ComponentInfo
+
+	// Mappers are the mappers the plugin provides, other than the
+	// built-in mappers of the SDK.
+	Mappers []*MapperInfo
+}
+
+// ComponentInfo describes a single component served by a plugin.
+type ComponentInfo struct {
+	// Type is the component type, such as BuilderType.
+	Type Type
+
+	// Name is the Go type name of the implementation.
+	Name string
+
+	// RegisteredName is the name the component is served with if it was
+	// registered with sdk.WithNamedComponent. This is empty for components
+	// registered with sdk.WithComponents.
+	RegisteredName string
+
+	// Capabilities is the sorted list of optional capabilities the
+	// component implements. See Capabilities.
+	Capabilities []string
+
+	// Outputs and Accepts are the contracts of the values the main
+	// operation of the component returns and accepts from the previous
+	// component, such as the artifact a builder returns from its build
+	// function. Only the types the plugin registered as contracts are
+	// listed. See CheckChain.
+	Outputs []Contract
+	Accepts []Contract
+
+	// ConfigDigest is a digest of the configuration schema of the
+	// component, which changes when the configuration it accepts changes.
+	// Tooling can compare it to detect schema changes without fetching
+	// the schema. This is empty if the component isn't configurable.
+	ConfigDigest string
+}
+
+// MapperInfo describes a mapper provided by a plugin.
+type MapperInfo struct {
+	// Name is the name of the mapper function.
+	Name string
+
+	// Inputs and Outputs are the types the mapper converts from and to.
+	// Protobuf messages are listed by their full name and other values
+	// by their primitive type, such as "STRING".
+	Inputs  []string
+	Outputs []string
+}
+
+// PluginInfoProvider is implemented by the client of the "info" plugin.
+type PluginInfoProvider interface {
+	// PluginInfo returns the information for the negotiated protocol
+	// version.
+	PluginInfo(ctx context.Context) (*PluginInfo, error)
+}
+
+// TypeFromString returns the Type with the given string value, such as
+// "Builder". InvalidType is returned if there is no such type.
+func TypeFromString(v string) Type {
+	for t := InvalidType + 1; t < maxType; t++ {
+		if t.String() == v {
+			return t
+		}
+	}
+
+	return InvalidType
+}
@@ -1,6 +1,11 @@
 package component
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -15,6 +20,39 @@ type LogViewer struct {
 
 	// New LogEvents should be sent to this channel.
 	Output chan LogEvent
+
+	// ResumeToken continues a previous view of the logs. It is the
+	// ResumeToken of the LogPosition of the events the previous view
+	// received. Those events are not sent again and StartingAt is moved
+	// forward to the position if it is earlier.
+	ResumeToken string
+
+	mu          sync.Mutex
+	broadcaster *LogBroadcaster
+}
+
+// Broadcast returns a LogBroadcaster that publishes every event sent to
+// Output until ctx is done. This lets multiple consumers receive the
+// events, such as one that persists them and one that displays them.
+// Output is created if it is nil.
+//
+// Calling Broadcast again returns the same broadcaster and snapshotSize
+// is ignored. Once Broadcast is called, Output must not be read from
+// directly.
+func (lv *LogViewer) Broadcast(ctx context.Context, snapshotSize int) *LogBroadcaster {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	if lv.broadcaster == nil {
+		if lv.Output == nil {
+			lv.Output = make(chan LogEvent)
+		}
+
+		lv.broadcaster = NewLogBroadcaster(snapshotSize)
+		go lv.broadcaster.Run(ctx, lv.Output)
+	}
+
+	return lv.broadcaster
 }
 
 // LogEvent represents a single log entry.
@@ -22,4 +60,131 @@ type LogEvent struct {
 	Partition string
 	Timestamp time.Time
 	Message   string
+
+	// Severity is the severity of the entry. This is LogSeverityUnknown
+	// if the platform doesn't know it, such as for unstructured output.
+	Severity LogSeverity
+
+	// Source is the output stream the entry was written to. This is
+	// LogSourceUnknown if the platform doesn't know it.
+	Source LogSource
+
+	// Metadata are any additional structured fields of the entry, such as
+	// the fields of a JSON log line.
+	Metadata map[string]string
+}
+
+// LogSeverity is the severity of a LogEvent. Severities are ordered from
+// least to most severe, so events can be filtered with a comparison such
+// as ev.Severity >= LogSeverityWarn.
+type LogSeverity int
+
+const (
+	LogSeverityUnknown LogSeverity = iota
+	LogSeverityTrace
+	LogSeverityDebug
+	LogSeverityInfo
+	LogSeverityWarn
+	LogSeverityError
+)
+
+func (s LogSeverity) String() string {
+	switch s {
+	case LogSeverityTrace:
+		return "trace"
+	case LogSeverityDebug:
+		return "debug"
+	case LogSeverityInfo:
+		return "info"
+	case LogSeverityWarn:
+		return "warn"
+	case LogSeverityError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// LogSource is the output stream a LogEvent was written to.
+type LogSource int
+
+const (
+	LogSourceUnknown LogSource = iota
+	LogSourceStdout
+	LogSourceStderr
+)
+
+func (s LogSource) String() string {
+	switch s {
+	case LogSourceStdout:
+		return "stdout"
+	case LogSourceStderr:
+		return "stderr"
+	default:
+		return "unknown"
+	}
+}
+
+// LogPosition is the position of a consumer in a stream of log events,
+// used to resume the stream with LogViewer.ResumeToken. Call Advance with
+// every event the consumer handles.
+//
+// Resuming assumes the platform sends events in timestamp order.
+type LogPosition struct {
+	// Time is the timestamp of the latest event received.
+	Time time.Time
+
+	// Count is the number of events received with exactly that timestamp.
+	Count int
+}
+
+// ParseLogResumeToken parses the result of LogPosition.ResumeToken. An
+// empty token is the zero position, the start of the stream.
+func ParseLogResumeToken(token string) (LogPosition, error) {
+	if token == "" {
+		return LogPosition{}, nil
+	}
+
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return LogPosition{}, fmt.Errorf("invalid log resume token: %q", token)
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return LogPosition{}, fmt.Errorf("invalid log resume token: %q", token)
+	}
+
+	count, err := strconv.Atoi(parts[1])
+	if err != nil || count < 0 {
+		return LogPosition{}, fmt.Errorf("invalid log resume token: %q", token)
+	}
+
+	return LogPosition{Time: time.Unix(0, nanos), Count: count}, nil
+}
+
+// Advance moves the position past ev. Events earlier than the position
+// and events without a timestamp are ignored.
+func (p *LogPosition) Advance(ev LogEvent) {
+	switch {
+	case ev.Timestamp.IsZero():
+		// There is no position to resume from
+
+	case ev.Timestamp.After(p.Time):
+		p.Time = ev.Timestamp
+		p.Count = 1
+
+	case ev.Timestamp.Equal(p.Time):
+		p.Count++
+	}
+}
+
+// ResumeToken returns the token for this position to set as
+// LogViewer.ResumeToken. This is empty for the zero position.
+func (p LogPosition) ResumeToken() string {
+	if p.Count == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%d.%d", p.Time.UnixNano(), p.Count)
 }
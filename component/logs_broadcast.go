@@ -0,0 +1,267 @@
+package component
+
+import (
+	"context"
+	"sync"
+)
+
+// LogBufferPolicy determines what a LogBroadcaster does when a
+// subscriber's buffer is full.
+type LogBufferPolicy int
+
+const (
+	// LogBufferBlock waits until the subscriber has room. No events are
+	// lost, but a slow subscriber slows down every other subscriber and
+	// the plugin writing the events.
+	LogBufferBlock LogBufferPolicy = iota
+
+	// LogBufferDropNewest drops the new event.
+	LogBufferDropNewest
+
+	// LogBufferDropOldest drops the oldest buffered event to make room
+	// for the new event.
+	LogBufferDropOldest
+)
+
+// LogBroadcaster sends each LogEvent it receives to any number of
+// subscribers, such as one that persists events to disk and one that
+// streams them to the UI. It also keeps the most recent events so they
+// can be retrieved with Snapshot. LogBroadcaster is safe for concurrent
+// use.
+//
+// Use LogViewer.Broadcast to broadcast the events sent to a LogViewer.
+type LogBroadcaster struct {
+	mu     sync.RWMutex
+	subs   map[*LogSubscription]struct{}
+	closed bool
+
+	doneCh   chan struct{}
+	doneOnce sync.Once
+
+	snapshotMu   sync.Mutex
+	snapshot     []LogEvent
+	snapshotSize int
+}
+
+// NewLogBroadcaster returns a LogBroadcaster that keeps the last
+// snapshotSize events for Snapshot.
+func NewLogBroadcaster(snapshotSize int) *LogBroadcaster {
+	return &LogBroadcaster{
+		subs:         map[*LogSubscription]struct{}{},
+		doneCh:       make(chan struct{}),
+		snapshotSize: snapshotSize,
+	}
+}
+
+// Subscribe returns a new subscription that receives every event published
+// after this call. size is the number of events buffered for the subscriber
+// and policy determines what happens when the buffer is full.
+//
+// If the broadcaster is already closed, the returned subscription's Events
+// channel is closed.
+func (b *LogBroadcaster) Subscribe(size int, policy LogBufferPolicy) *LogSubscription {
+	sub := &LogSubscription{
+		b:      b,
+		policy: policy,
+		events: make(chan LogEvent, size),
+		doneCh: make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		sub.stop()
+		close(sub.events)
+		return sub
+	}
+
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+// Publish sends the event to every subscriber and adds it to the snapshot.
+// Publishing to a closed broadcaster does nothing.
+func (b *LogBroadcaster) Publish(ev LogEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return
+	}
+
+	b.record(ev)
+	for sub := range b.subs {
+		sub.send(ev)
+	}
+}
+
+// Snapshot returns up to the last n events published, oldest first. If n
+// is zero or negative, every event kept is returned.
+func (b *LogBroadcaster) Snapshot(n int) []LogEvent {
+	b.snapshotMu.Lock()
+	defer b.snapshotMu.Unlock()
+
+	events := b.snapshot
+	if n > 0 && len(events) > n {
+		events = events[len(events)-n:]
+	}
+
+	result := make([]LogEvent, len(events))
+	copy(result, events)
+	return result
+}
+
+// Run publishes every event received from ch until ch is closed or ctx is
+// done and then closes the broadcaster.
+func (b *LogBroadcaster) Run(ctx context.Context, ch <-chan LogEvent) {
+	defer b.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case ev, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			b.Publish(ev)
+		}
+	}
+}
+
+// Close closes the Events channel of every subscriber. Events published
+// after Close are dropped.
+func (b *LogBroadcaster) Close() {
+	// Unblock Publish if it is waiting on a subscriber before we take the
+	// write lock, otherwise we could wait forever.
+	b.doneOnce.Do(func() { close(b.doneCh) })
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.closed = true
+	for sub := range b.subs {
+		close(sub.events)
+	}
+	b.subs = nil
+}
+
+func (b *LogBroadcaster) record(ev LogEvent) {
+	if b.snapshotSize <= 0 {
+		return
+	}
+
+	b.snapshotMu.Lock()
+	defer b.snapshotMu.Unlock()
+
+	if len(b.snapshot) >= b.snapshotSize {
+		b.snapshot = append(b.snapshot[:0], b.snapshot[1:]...)
+	}
+	b.snapshot = append(b.snapshot, ev)
+}
+
+// LogSubscription is a subscription to a LogBroadcaster.
+type LogSubscription struct {
+	b      *LogBroadcaster
+	policy LogBufferPolicy
+	events chan LogEvent
+
+	doneCh   chan struct{}
+	doneOnce sync.Once
+
+	droppedMu sync.Mutex
+	dropped   uint64
+}
+
+// Events returns the channel events are sent to. This is closed when the
+// subscription or the broadcaster is closed.
+func (s *LogSubscription) Events() <-chan LogEvent {
+	return s.events
+}
+
+// Dropped returns the number of events dropped because the buffer was full.
+func (s *LogSubscription) Dropped() uint64 {
+	s.droppedMu.Lock()
+	defer s.droppedMu.Unlock()
+	return s.dropped
+}
+
+// Close stops the subscription and closes its Events channel.
+func (s *LogSubscription) Close() {
+	s.stop()
+
+	b := s.b
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[s]; ok {
+		delete(b.subs, s)
+		close(s.events)
+	}
+}
+
+// stop unblocks any send waiting on this subscription.
+func (s *LogSubscription) stop() {
+	s.doneOnce.Do(func() { close(s.doneCh) })
+}
+
+// send sends the event according to the buffer policy. This must be called
+// with the broadcaster read lock held so the events channel isn't closed.
+func (s *LogSubscription) send(ev LogEvent) {
+	switch s.policy {
+	case LogBufferDropNewest:
+		select {
+		case s.events <- ev:
+		default:
+			s.drop()
+		}
+
+	case LogBufferDropOldest:
+		// With no buffer there is nothing to drop to make room.
+		if cap(s.events) == 0 {
+			select {
+			case s.events <- ev:
+			default:
+				s.drop()
+			}
+
+			return
+		}
+
+		for {
+			select {
+			case s.events <- ev:
+				return
+			default:
+			}
+
+			// Make room. The subscriber may have read the event in the
+			// meantime in which case we just try again.
+			select {
+			case <-s.events:
+				s.drop()
+			default:
+			}
+		}
+
+	default:
+		select {
+		case s.events <- ev:
+		case <-s.doneCh:
+		case <-s.b.doneCh:
+		}
+	}
+}
+
+func (s *LogSubscription) drop() {
+	s.droppedMu.Lock()
+	defer s.droppedMu.Unlock()
+	s.dropped++
+}
@@ -0,0 +1,117 @@
+package component
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogBroadcaster(t *testing.T) {
+	require := require.New(t)
+
+	b := NewLogBroadcaster(2)
+	sub1 := b.Subscribe(10, LogBufferBlock)
+	sub2 := b.Subscribe(10, LogBufferBlock)
+
+	for _, msg := range []string{"a", "b", "c"} {
+		b.Publish(LogEvent{Message: msg})
+	}
+	b.Close()
+
+	for _, sub := range []*LogSubscription{sub1, sub2} {
+		var msgs []string
+		for ev := range sub.Events() {
+			msgs = append(msgs, ev.Message)
+		}
+		require.Equal([]string{"a", "b", "c"}, msgs)
+	}
+
+	require.Equal([]LogEvent{{Message: "b"}, {Message: "c"}}, b.Snapshot(0))
+	require.Equal([]LogEvent{{Message: "c"}}, b.Snapshot(1))
+
+	// Subscribing after close returns a closed subscription
+	_, ok := <-b.Subscribe(1, LogBufferBlock).Events()
+	require.False(ok)
+}
+
+func TestLogBroadcaster_policies(t *testing.T) {
+	require := require.New(t)
+
+	b := NewLogBroadcaster(0)
+	newest := b.Subscribe(2, LogBufferDropNewest)
+	oldest := b.Subscribe(2, LogBufferDropOldest)
+
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		b.Publish(LogEvent{Message: msg})
+	}
+	b.Close()
+
+	collect := func(sub *LogSubscription) []string {
+		var result []string
+		for ev := range sub.Events() {
+			result = append(result, ev.Message)
+		}
+		return result
+	}
+
+	require.Equal([]string{"a", "b"}, collect(newest))
+	require.Equal(uint64(2), newest.Dropped())
+	require.Equal([]string{"c", "d"}, collect(oldest))
+	require.Equal(uint64(2), oldest.Dropped())
+	require.Empty(b.Snapshot(0))
+}
+
+func TestLogBroadcaster_closeBlocked(t *testing.T) {
+	require := require.New(t)
+
+	b := NewLogBroadcaster(0)
+	sub := b.Subscribe(0, LogBufferBlock)
+
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		b.Publish(LogEvent{Message: "a"})
+	}()
+
+	// Closing the subscription unblocks the publisher even though nothing
+	// ever reads from it.
+	time.Sleep(10 * time.Millisecond)
+	sub.Close()
+
+	select {
+	case <-doneCh:
+	case <-time.After(time.Second):
+		t.Fatal("publish didn't return")
+	}
+
+	_, ok := <-sub.Events()
+	require.False(ok)
+}
+
+func TestLogViewerBroadcast(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lv := &LogViewer{}
+	b := lv.Broadcast(ctx, 10)
+	require.Equal(b, lv.Broadcast(ctx, 5))
+	require.NotNil(lv.Output)
+
+	disk := b.Subscribe(10, LogBufferBlock)
+	ui := b.Subscribe(10, LogBufferDropOldest)
+
+	lv.Output <- LogEvent{Message: "hello"}
+
+	require.Equal("hello", (<-disk.Events()).Message)
+	require.Equal("hello", (<-ui.Events()).Message)
+	require.Equal([]LogEvent{{Message: "hello"}}, b.Snapshot(0))
+
+	// Cancelling the context closes the broadcaster
+	cancel()
+	_, ok := <-disk.Events()
+	require.False(ok)
+}
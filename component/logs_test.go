@@ -0,0 +1,43 @@
+package component
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogPosition(t *testing.T) {
+	require := require.New(t)
+
+	t0 := time.Unix(100, 5)
+	t1 := t0.Add(time.Second)
+
+	var pos LogPosition
+	require.Empty(pos.ResumeToken())
+
+	pos.Advance(LogEvent{Timestamp: t0})
+	pos.Advance(LogEvent{Timestamp: t1})
+	pos.Advance(LogEvent{Timestamp: t1})
+	require.Equal(LogPosition{Time: t1, Count: 2}, pos)
+
+	// Earlier events and events without a timestamp don't move it
+	pos.Advance(LogEvent{Timestamp: t0})
+	pos.Advance(LogEvent{})
+	require.Equal(LogPosition{Time: t1, Count: 2}, pos)
+
+	parsed, err := ParseLogResumeToken(pos.ResumeToken())
+	require.NoError(err)
+	require.True(parsed.Time.Equal(t1))
+	require.Equal(2, parsed.Count)
+
+	// Empty is the start of the stream
+	parsed, err = ParseLogResumeToken("")
+	require.NoError(err)
+	require.Equal(LogPosition{}, parsed)
+
+	for _, token := range []string{"nope", "1", "a.1", "1.b", "1.-1"} {
+		_, err = ParseLogResumeToken(token)
+		require.Error(err, token)
+	}
+}
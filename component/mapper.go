@@ -0,0 +1,42 @@
+package component
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/status"
+)
+
+// ErrMapperFailed is returned when a mapper of a plugin fails, such as a
+// mapper that converts configuration to a client. Hosts can check for this
+// with errors.As to report which conversion failed.
+//
+// Any diagnostics the mapper returned are available from the error with
+// diag.FromError.
+type ErrMapperFailed struct {
+	// Results are the result types that were requested from the mapper.
+	Results []string
+
+	// Args are the types of the arguments that were given to the mapper.
+	Args []string
+
+	// Message is the error returned by the mapper.
+	Message string
+
+	// Err is the error returned by the plugin.
+	Err error
+}
+
+func (e *ErrMapperFailed) Error() string {
+	return fmt.Sprintf("error mapping to %s: %s", strings.Join(e.Results, ", "), e.Message)
+}
+
+func (e *ErrMapperFailed) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus returns the status of the error returned by the plugin so that
+// the status details, such as diagnostics, are available.
+func (e *ErrMapperFailed) GRPCStatus() *status.Status {
+	return status.Convert(e.Err)
+}
@@ -0,0 +1,201 @@
+package mocks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+)
+
+// The types below combine the mocks of a component with the mocks of the
+// optional interfaces it commonly implements. Each embedded mock has its own
+// Mock field, so expectations are set on the embedded mock, such as
+// m.Status.On("StatusFunc"), or with ExpectFunc which finds it.
+
+// PlatformWithDestroy is a Platform that implements component.Destroyer.
+type PlatformWithDestroy struct {
+	Platform
+	Destroyer
+}
+
+// PlatformWithStatus is a Platform that implements component.Status.
+type PlatformWithStatus struct {
+	Platform
+	Status
+}
+
+// PlatformWithStatusAndDestroy is a Platform that implements
+// component.Status and component.Destroyer.
+type PlatformWithStatusAndDestroy struct {
+	Platform
+	Status
+	Destroyer
+}
+
+// PlatformWithGeneration is a Platform that implements component.Generation.
+type PlatformWithGeneration struct {
+	Platform
+	Generation
+}
+
+// PlatformWithExec is a Platform that implements component.Execer.
+type PlatformWithExec struct {
+	Platform
+	Execer
+}
+
+// PlatformWithPortForward is a Platform that implements
+// component.PortForwarder.
+type PlatformWithPortForward struct {
+	Platform
+	PortForwarder
+}
+
+// PlatformWithAccess is a Platform that implements component.PlatformAccess.
+type PlatformWithAccess struct {
+	Platform
+	PlatformAccess
+}
+
+// PlatformWithTroubleshoot is a Platform that implements
+// component.Troubleshooter.
+type PlatformWithTroubleshoot struct {
+	Platform
+	Troubleshooter
+}
+
+// PlatformWithSnapshot is a Platform that implements component.Snapshotter.
+type PlatformWithSnapshot struct {
+	Platform
+	Snapshotter
+}
+
+// PlatformWithWorkspaceDestroy is a Platform that implements
+// component.Destroyer and component.WorkspaceDestroyer.
+type PlatformWithWorkspaceDestroy struct {
+	Platform
+	Destroyer
+	WorkspaceDestroyer
+}
+
+// RegistryWithAccess is a Registry that implements component.RegistryAccess.
+type RegistryWithAccess struct {
+	Registry
+	RegistryAccess
+}
+
+// ReleaseManagerWithStatusAndDestroy is a ReleaseManager that implements
+// component.Status and component.Destroyer.
+type ReleaseManagerWithStatusAndDestroy struct {
+	ReleaseManager
+	Status
+	Destroyer
+}
+
+// ExpectFunc sets the expectation that the method named method of v is
+// called and returns f. v is one of the mocks in this package, including
+// the composite mocks, and method is one of the "Func" methods, such as
+// "DeployFunc". This panics if v has no such method.
+//
+// The expectation is set on the embedded mock that implements method and
+// the call is returned so it can be refined, such as with Once.
+func ExpectFunc(v interface{}, method string, f interface{}) *mock.Call {
+	m := mockFor(reflect.ValueOf(v), method)
+	if m == nil {
+		panic(fmt.Sprintf("mocks: %T has no mocked method %q", v, method))
+	}
+
+	return m.On(method).Return(f)
+}
+
+// AssertExpectations asserts that the expectations of v, and of all the
+// mocks embedded in it, were met.
+func AssertExpectations(t mock.TestingT, v interface{}) bool {
+	ok := true
+	for _, m := range mocksOf(reflect.ValueOf(v)) {
+		if !m.AssertExpectations(t) {
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+var mockType = reflect.TypeOf(mock.Mock{})
+
+// mockFor returns the Mock field of the mock within v that implements
+// method, or nil if there is none.
+func mockFor(v reflect.Value, method string) *mock.Mock {
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct || !v.CanAddr() {
+		return nil
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.Anonymous {
+			continue
+		}
+
+		if field.Type == mockType {
+			if _, ok := v.Addr().Type().MethodByName(method); ok {
+				return v.Field(i).Addr().Interface().(*mock.Mock)
+			}
+
+			continue
+		}
+
+		if m := mockFor(v.Field(i).Addr(), method); m != nil {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// mocksOf returns the Mock fields of v and all the mocks embedded in it.
+func mocksOf(v reflect.Value) []*mock.Mock {
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct || !v.CanAddr() {
+		return nil
+	}
+
+	var result []*mock.Mock
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.Anonymous {
+			continue
+		}
+
+		if field.Type == mockType {
+			result = append(result, v.Field(i).Addr().Interface().(*mock.Mock))
+			continue
+		}
+
+		result = append(result, mocksOf(v.Field(i).Addr())...)
+	}
+
+	return result
+}
+
+var (
+	_ component.Destroyer          = (*PlatformWithDestroy)(nil)
+	_ component.Status             = (*PlatformWithStatus)(nil)
+	_ component.Status             = (*PlatformWithStatusAndDestroy)(nil)
+	_ component.Destroyer          = (*PlatformWithStatusAndDestroy)(nil)
+	_ component.Generation         = (*PlatformWithGeneration)(nil)
+	_ component.Execer             = (*PlatformWithExec)(nil)
+	_ component.PortForwarder      = (*PlatformWithPortForward)(nil)
+	_ component.PlatformAccess     = (*PlatformWithAccess)(nil)
+	_ component.Troubleshooter     = (*PlatformWithTroubleshoot)(nil)
+	_ component.Snapshotter        = (*PlatformWithSnapshot)(nil)
+	_ component.WorkspaceDestroyer = (*PlatformWithWorkspaceDestroy)(nil)
+	_ component.RegistryAccess     = (*RegistryWithAccess)(nil)
+	_ component.Status             = (*ReleaseManagerWithStatusAndDestroy)(nil)
+	_ component.Destroyer          = (*ReleaseManagerWithStatusAndDestroy)(nil)
+	_ component.Platform           = (*PlatformWithStatusAndDestroy)(nil)
+	_ component.Registry           = (*RegistryWithAccess)(nil)
+	_ component.ReleaseManager     = (*ReleaseManagerWithStatusAndDestroy)(nil)
+)
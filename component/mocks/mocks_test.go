@@ -35,3 +35,54 @@ func TestMock_allTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestExpectFunc(t *testing.T) {
+	require := require.New(t)
+
+	m := &PlatformWithStatusAndDestroy{}
+	ExpectFunc(m, "DeployFunc", 1)
+	ExpectFunc(m, "StatusFunc", 2)
+	ExpectFunc(m, "DestroyFunc", 3).Once()
+
+	// The expectations are set on the embedded mock for the method
+	require.Len(m.Platform.ExpectedCalls, 1)
+	require.Len(m.Status.ExpectedCalls, 1)
+	require.Len(m.Destroyer.ExpectedCalls, 1)
+
+	var v interface{} = m
+	require.Equal(1, v.(component.Platform).DeployFunc())
+	require.Equal(2, v.(component.Status).StatusFunc())
+	require.Equal(3, v.(component.Destroyer).DestroyFunc())
+	require.True(AssertExpectations(t, m))
+
+	// Single mocks are supported too
+	b := &Builder{}
+	ExpectFunc(b, "BuildFunc", 4)
+	require.Equal(4, b.BuildFunc())
+
+	require.Panics(func() { ExpectFunc(m, "PushFunc", 5) })
+}
+
+func TestAssertExpectations(t *testing.T) {
+	require := require.New(t)
+
+	m := &RegistryWithAccess{}
+	ExpectFunc(m, "PushFunc", 1)
+	ExpectFunc(m, "AccessInfoFunc", 2)
+	m.PushFunc()
+
+	// AccessInfoFunc was never called
+	var mockT testingT
+	require.False(AssertExpectations(&mockT, m))
+	require.True(mockT.failed)
+}
+
+type testingT struct {
+	failed bool
+}
+
+func (t *testingT) Logf(string, ...interface{}) {}
+
+func (t *testingT) Errorf(string, ...interface{}) { t.failed = true }
+
+func (t *testingT) FailNow() { t.failed = true }
@@ -0,0 +1,237 @@
+// Package ociref parses and normalizes Docker/OCI image references.
+//
+// Plugins frequently pass image references between builders, registries,
+// and platforms. Using this package (and the OCIRef proto message) instead
+// of bare strings ensures that every plugin agrees on what "nginx" means:
+// "docker.io/library/nginx:latest".
+package ociref
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+const (
+	// DefaultRegistry is the registry used for references that don't
+	// specify one.
+	DefaultRegistry = "docker.io"
+
+	// DefaultTag is the tag used for references that specify neither a
+	// tag nor a digest.
+	DefaultTag = "latest"
+
+	// officialRepoPrefix is the prefix used for single component
+	// repositories on Docker Hub.
+	officialRepoPrefix = "library/"
+)
+
+var (
+	// repoComponentRe matches a single path component of a repository.
+	repoComponentRe = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*$`)
+
+	// tagRe matches a valid tag.
+	tagRe = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+
+	// digestRe matches a valid digest.
+	digestRe = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]{32,}$`)
+
+	// registryRe matches a valid registry host with an optional port.
+	registryRe = regexp.MustCompile(`^(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*(?::[0-9]+)?$`)
+)
+
+// Reference is a parsed image reference.
+type Reference struct {
+	// Registry is the registry host, optionally with a port.
+	Registry string
+
+	// Repository is the path of the image within the registry.
+	Repository string
+
+	// Tag is the image tag. This may be empty if Digest is set.
+	Tag string
+
+	// Digest is the content digest of the image, such as "sha256:...".
+	Digest string
+}
+
+// Parse parses an image reference string such as "nginx",
+// "gcr.io/project/app:v1", or "localhost:5000/app@sha256:...". The result
+// is normalized; see Normalize.
+func Parse(s string) (*Reference, error) {
+	if s == "" {
+		return nil, fmt.Errorf("image reference is empty")
+	}
+
+	var ref Reference
+	rest := s
+
+	// Digest is everything after the "@"
+	if idx := strings.Index(rest, "@"); idx >= 0 {
+		ref.Digest = rest[idx+1:]
+		rest = rest[:idx]
+	}
+
+	// Tag is everything after the last ":" as long as it isn't part of
+	// the registry host, which would have a "/" after it.
+	if idx := strings.LastIndex(rest, ":"); idx >= 0 && !strings.Contains(rest[idx+1:], "/") {
+		ref.Tag = rest[idx+1:]
+		rest = rest[:idx]
+	}
+
+	// The first component is the registry if it looks like a host.
+	if idx := strings.Index(rest, "/"); idx >= 0 {
+		first := rest[:idx]
+		if first == "localhost" || strings.ContainsAny(first, ".:") {
+			ref.Registry = first
+			rest = rest[idx+1:]
+		}
+	}
+
+	ref.Repository = rest
+	ref.Normalize()
+
+	if err := ref.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid image reference %q: %w", s, err)
+	}
+
+	return &ref, nil
+}
+
+// MustParse is like Parse but panics on error. This is meant for use
+// with constant values, such as in tests.
+func MustParse(s string) *Reference {
+	ref, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return ref
+}
+
+// Normalize fills in defaults so that equivalent references are equal.
+// Docker Hub references use the "docker.io" registry and single component
+// repositories on Docker Hub get the "library/" prefix. If neither a tag
+// nor a digest is set, the tag is set to "latest".
+func (r *Reference) Normalize() {
+	switch r.Registry {
+	case "", "index.docker.io", "registry-1.docker.io":
+		r.Registry = DefaultRegistry
+	}
+
+	if r.Registry == DefaultRegistry && r.Repository != "" &&
+		!strings.Contains(r.Repository, "/") {
+		r.Repository = officialRepoPrefix + r.Repository
+	}
+
+	if r.Tag == "" && r.Digest == "" {
+		r.Tag = DefaultTag
+	}
+}
+
+// Validate checks that all the fields of the reference are valid.
+func (r *Reference) Validate() error {
+	if r.Registry == "" {
+		return fmt.Errorf("registry is required")
+	}
+	if !registryRe.MatchString(r.Registry) {
+		return fmt.Errorf("invalid registry %q", r.Registry)
+	}
+
+	if r.Repository == "" {
+		return fmt.Errorf("repository is required")
+	}
+	for _, part := range strings.Split(r.Repository, "/") {
+		if !repoComponentRe.MatchString(part) {
+			return fmt.Errorf("invalid repository %q", r.Repository)
+		}
+	}
+
+	if r.Tag == "" && r.Digest == "" {
+		return fmt.Errorf("tag or digest is required")
+	}
+	if r.Tag != "" && !tagRe.MatchString(r.Tag) {
+		return fmt.Errorf("invalid tag %q", r.Tag)
+	}
+	if r.Digest != "" && !digestRe.MatchString(r.Digest) {
+		return fmt.Errorf("invalid digest %q", r.Digest)
+	}
+
+	return nil
+}
+
+// Name returns the fully qualified name of the image without the tag or
+// digest, such as "docker.io/library/nginx".
+func (r *Reference) Name() string {
+	return r.Registry + "/" + r.Repository
+}
+
+// String returns the fully qualified reference, such as
+// "docker.io/library/nginx:latest". If both a tag and digest are set,
+// both are included.
+func (r *Reference) String() string {
+	var b strings.Builder
+	b.WriteString(r.Name())
+	if r.Tag != "" {
+		b.WriteString(":" + r.Tag)
+	}
+	if r.Digest != "" {
+		b.WriteString("@" + r.Digest)
+	}
+
+	return b.String()
+}
+
+// Familiar returns the shortest form of the reference that Docker
+// understands, such as "nginx:latest" for Docker Hub official images.
+func (r *Reference) Familiar() string {
+	name := r.Name()
+	if r.Registry == DefaultRegistry {
+		name = strings.TrimPrefix(r.Repository, officialRepoPrefix)
+	}
+
+	var b strings.Builder
+	b.WriteString(name)
+	if r.Tag != "" {
+		b.WriteString(":" + r.Tag)
+	}
+	if r.Digest != "" {
+		b.WriteString("@" + r.Digest)
+	}
+
+	return b.String()
+}
+
+// Proto returns the proto representation of this reference.
+func (r *Reference) Proto() *pb.OCIRef {
+	return &pb.OCIRef{
+		Registry:   r.Registry,
+		Repository: r.Repository,
+		Tag:        r.Tag,
+		Digest:     r.Digest,
+	}
+}
+
+// FromProto returns the reference for the given proto message. The
+// result is normalized and validated.
+func FromProto(v *pb.OCIRef) (*Reference, error) {
+	if v == nil {
+		return nil, fmt.Errorf("image reference is nil")
+	}
+
+	ref := &Reference{
+		Registry:   v.Registry,
+		Repository: v.Repository,
+		Tag:        v.Tag,
+		Digest:     v.Digest,
+	}
+	ref.Normalize()
+
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
+
+	return ref, nil
+}
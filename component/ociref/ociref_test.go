@@ -0,0 +1,133 @@
+package ociref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDigest = "sha256:4f5b6b3f2d5c8a6e2d8e4c5e7b1a4c2f9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b"
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected *Reference
+		String   string
+		Familiar string
+		Err      string
+	}{
+		{
+			"nginx",
+			&Reference{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"},
+			"docker.io/library/nginx:latest",
+			"nginx:latest",
+			"",
+		},
+
+		{
+			"hashicorp/waypoint:0.1",
+			&Reference{Registry: "docker.io", Repository: "hashicorp/waypoint", Tag: "0.1"},
+			"docker.io/hashicorp/waypoint:0.1",
+			"hashicorp/waypoint:0.1",
+			"",
+		},
+
+		{
+			"index.docker.io/library/nginx:1.21",
+			&Reference{Registry: "docker.io", Repository: "library/nginx", Tag: "1.21"},
+			"docker.io/library/nginx:1.21",
+			"nginx:1.21",
+			"",
+		},
+
+		{
+			"gcr.io/project/app",
+			&Reference{Registry: "gcr.io", Repository: "project/app", Tag: "latest"},
+			"gcr.io/project/app:latest",
+			"gcr.io/project/app:latest",
+			"",
+		},
+
+		{
+			"localhost:5000/app:dev",
+			&Reference{Registry: "localhost:5000", Repository: "app", Tag: "dev"},
+			"localhost:5000/app:dev",
+			"localhost:5000/app:dev",
+			"",
+		},
+
+		{
+			"localhost/app",
+			&Reference{Registry: "localhost", Repository: "app", Tag: "latest"},
+			"localhost/app:latest",
+			"localhost/app:latest",
+			"",
+		},
+
+		{
+			"app@" + testDigest,
+			&Reference{Registry: "docker.io", Repository: "library/app", Digest: testDigest},
+			"docker.io/library/app@" + testDigest,
+			"app@" + testDigest,
+			"",
+		},
+
+		{
+			"registry.example.com:443/team/app:v2@" + testDigest,
+			&Reference{Registry: "registry.example.com:443", Repository: "team/app", Tag: "v2", Digest: testDigest},
+			"registry.example.com:443/team/app:v2@" + testDigest,
+			"registry.example.com:443/team/app:v2@" + testDigest,
+			"",
+		},
+
+		{"", nil, "", "", "empty"},
+		{"Nginx", nil, "", "", "invalid repository"},
+		{"nginx:bad/tag!", nil, "", "", "invalid registry"},
+		{"nginx:-bad", nil, "", "", "invalid tag"},
+		{"nginx@sha256:short", nil, "", "", "invalid digest"},
+		{"gcr.io/", nil, "", "", "repository is required"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Input, func(t *testing.T) {
+			require := require.New(t)
+
+			ref, err := Parse(tt.Input)
+			if tt.Err != "" {
+				require.Error(err)
+				require.Contains(err.Error(), tt.Err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.Expected, ref)
+			require.Equal(tt.String, ref.String())
+			require.Equal(tt.Familiar, ref.Familiar())
+
+			// Round trip
+			again, err := Parse(ref.String())
+			require.NoError(err)
+			require.Equal(ref, again)
+		})
+	}
+}
+
+func TestProto(t *testing.T) {
+	require := require.New(t)
+
+	ref := MustParse("gcr.io/project/app:v1")
+	actual, err := FromProto(ref.Proto())
+	require.NoError(err)
+	require.Equal(ref, actual)
+
+	// Protos are normalized
+	p := ref.Proto()
+	p.Registry = ""
+	p.Repository = "nginx"
+	p.Tag = ""
+	actual, err = FromProto(p)
+	require.NoError(err)
+	require.Equal("docker.io/library/nginx:latest", actual.String())
+
+	_, err = FromProto(nil)
+	require.Error(err)
+}
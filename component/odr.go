@@ -0,0 +1,107 @@
+package component
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ODRProfiler is an optional interface that builder, registry and platform
+// plugins can implement to declare what they need from an ondemand runner.
+// Waypoint uses the requirements of the components of a job to choose or
+// build a runner profile for it, rather than requiring a profile to be
+// configured for each project.
+//
+// ODRRequirements is called after the component is configured, so the
+// requirements can depend on the configuration.
+type ODRProfiler interface {
+	ODRRequirements() (*ODRRequirements, error)
+}
+
+// ODRRequirements are the requirements of a component for the ondemand
+// runner that runs its operations. The zero value has no requirements.
+type ODRRequirements struct {
+	// Image is the container image the runner must use, such as an image
+	// with the tools the plugin calls. If this is empty, any runner image
+	// with the plugin can be used.
+	Image string
+
+	// CPU is the minimum CPU the runner needs, in millicores.
+	CPU int64
+
+	// Memory is the minimum memory the runner needs, in megabytes.
+	Memory int64
+
+	// Environment are the names of the environment variables the runner
+	// must set, such as "AWS_REGION".
+	Environment []string
+
+	// Credentials are the types of credentials the runner must have, such
+	// as "aws", "gcp" or "kubernetes". The names are the same as the names
+	// of the plugins that use them.
+	Credentials []string
+
+	// Privileged is true if the runner must run privileged containers,
+	// such as for builds that need a docker daemon.
+	Privileged bool
+}
+
+// MergeODRRequirements returns the requirements that satisfy all of reqs,
+// such as the requirements of the builder, registry and platform of an
+// app. Nil requirements are ignored. This returns an error if the
+// requirements conflict, which is only the case if they require different
+// images.
+func MergeODRRequirements(reqs ...*ODRRequirements) (*ODRRequirements, error) {
+	var result ODRRequirements
+	env := map[string]struct{}{}
+	creds := map[string]struct{}{}
+	for _, req := range reqs {
+		if req == nil {
+			continue
+		}
+
+		if req.Image != "" {
+			if result.Image != "" && result.Image != req.Image {
+				return nil, fmt.Errorf(
+					"conflicting ondemand runner images: %q and %q",
+					result.Image, req.Image)
+			}
+
+			result.Image = req.Image
+		}
+
+		if req.CPU > result.CPU {
+			result.CPU = req.CPU
+		}
+		if req.Memory > result.Memory {
+			result.Memory = req.Memory
+		}
+
+		for _, v := range req.Environment {
+			env[v] = struct{}{}
+		}
+		for _, v := range req.Credentials {
+			creds[v] = struct{}{}
+		}
+
+		result.Privileged = result.Privileged || req.Privileged
+	}
+
+	result.Environment = sortedKeys(env)
+	result.Credentials = sortedKeys(creds)
+	return &result, nil
+}
+
+// sortedKeys returns the sorted keys of m, or nil if it is empty.
+func sortedKeys(m map[string]struct{}) []string {
+	if len(m) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,62 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeODRRequirements(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		require := require.New(t)
+
+		result, err := MergeODRRequirements(nil)
+		require.NoError(err)
+		require.Equal(&ODRRequirements{}, result)
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		require := require.New(t)
+
+		result, err := MergeODRRequirements(
+			&ODRRequirements{
+				Image:       "builder:1",
+				CPU:         500,
+				Memory:      2048,
+				Environment: []string{"B", "A"},
+				Privileged:  true,
+			},
+			nil,
+			&ODRRequirements{
+				CPU:         1000,
+				Memory:      512,
+				Environment: []string{"A"},
+				Credentials: []string{"aws"},
+			},
+			&ODRRequirements{
+				Image:       "builder:1",
+				Credentials: []string{"kubernetes", "aws"},
+			},
+		)
+		require.NoError(err)
+		require.Equal(&ODRRequirements{
+			Image:       "builder:1",
+			CPU:         1000,
+			Memory:      2048,
+			Environment: []string{"A", "B"},
+			Credentials: []string{"aws", "kubernetes"},
+			Privileged:  true,
+		}, result)
+	})
+
+	t.Run("conflicting images", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := MergeODRRequirements(
+			&ODRRequirements{Image: "a"},
+			&ODRRequirements{Image: "b"},
+		)
+		require.Error(err)
+		require.Contains(err.Error(), "conflicting")
+	})
+}
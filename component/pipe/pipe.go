@@ -0,0 +1,96 @@
+// Package pipe streams intermediate artifacts, such as an image tarball,
+// from one component to another without writing them to local disk.
+//
+// A builder that produces a large artifact publishes a Source with the
+// Publisher it requests as an argument and stores the returned reference
+// in the artifact it returns:
+//
+//	func (b *Builder) build(ctx context.Context, pub *pipe.Publisher) (*Image, error) {
+//		r, err := exportImage(ctx)
+//		if err != nil {
+//			return nil, err
+//		}
+//
+//		return &Image{Data: pub.Publish(&pipe.Source{R: r})}, nil
+//	}
+//
+// The host maps the reference to a Reader right after the build returns
+// and gives it to the registry as a Source. A registry push function
+// requests a *Reader as an argument and reads the artifact from it:
+//
+//	func (r *Registry) push(ctx context.Context, data *pipe.Reader) (*Image, error) {
+//		defer data.Close()
+//		return upload(ctx, data)
+//	}
+//
+// The data is streamed over the plugin connection as it is read, so the
+// source is only read as fast as the reader consumes it. The data is
+// checksummed and Read returns ErrChecksum if what was received doesn't
+// match what was sent. Closing the Reader before the end cancels the
+// stream and closes the source.
+//
+// The host must connect to a published pipe within a few seconds, so a
+// Source should be published just before the function returns. A Source
+// can only be read once.
+package pipe
+
+import (
+	"errors"
+	"io"
+
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// ErrChecksum is returned by Reader.Read if the checksum of the data that
+// was read doesn't match the checksum of the data that was sent.
+var ErrChecksum = errors.New("pipe: checksum mismatch")
+
+// Source is the data to send over a pipe.
+type Source struct {
+	// R is read until EOF to get the data. If R is an io.Closer, it is
+	// closed when the data has been sent or the pipe is canceled.
+	R io.Reader
+}
+
+// Reader reads the data of a Source on the other side of a pipe.
+type Reader struct {
+	rc io.ReadCloser
+}
+
+// NewReader returns a Reader for rc. This is used by the SDK to create
+// the Reader for a pipe, and by plugins to test functions that read one.
+func NewReader(rc io.ReadCloser) *Reader {
+	return &Reader{rc: rc}
+}
+
+// Read implements io.Reader.
+func (r *Reader) Read(p []byte) (int, error) {
+	return r.rc.Read(p)
+}
+
+// Close closes the reader. If the data has not been read to the end, this
+// cancels the pipe.
+func (r *Reader) Close() error {
+	return r.rc.Close()
+}
+
+// Publisher publishes a Source so another component can read it. A
+// Publisher is available as an argument to component functions.
+type Publisher struct {
+	publish func(*Source) uint32
+}
+
+// NewPublisher returns a Publisher that serves a Source with f and
+// returns its stream ID. This is used by the SDK.
+func NewPublisher(f func(*Source) uint32) *Publisher {
+	return &Publisher{publish: f}
+}
+
+// Publish serves src and returns the reference to store in the value
+// returned by the component. The host must connect to it within a few
+// seconds, so this should be called just before returning.
+func (p *Publisher) Publish(src *Source) *pb.Args_Pipe {
+	return &pb.Args_Pipe{StreamId: p.publish(src)}
+}
+
+var _ io.ReadCloser = (*Reader)(nil)
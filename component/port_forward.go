@@ -0,0 +1,54 @@
+package component
+
+import (
+	"context"
+	"io"
+	"net"
+)
+
+// PortForwardInfo contains the information required by a port forward
+// operation. A PortForwardInfo value is passed to a plugin's
+// PortForwardFunc() to forward the connections the client accepts.
+type PortForwardInfo struct {
+	// The port of the deployment to forward connections to.
+	Port int
+
+	// Connections is sent every connection accepted by the client. The
+	// plugin should copy data between each connection and the port and
+	// close the connection when either side is done. The channel is
+	// closed when the client stops accepting connections.
+	Connections <-chan io.ReadWriteCloser
+}
+
+// AcceptConnections accepts connections from ln and sends them on the
+// returned channel, for use as PortForwardInfo.Connections. The channel is
+// closed once ln is closed or ctx is done, and ln is closed when ctx is
+// done.
+func AcceptConnections(ctx context.Context, ln net.Listener) <-chan io.ReadWriteCloser {
+	ch := make(chan io.ReadWriteCloser)
+
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	go func() {
+		defer close(ch)
+
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			select {
+			case ch <- conn:
+			case <-ctx.Done():
+				conn.Close()
+				return
+			}
+		}
+	}()
+
+	return ch
+}
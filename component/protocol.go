@@ -0,0 +1,9 @@
+package component
+
+// ProtocolVersion is the plugin protocol version that was negotiated with
+// the host. Any component function can request a *ProtocolVersion argument
+// to adapt its behavior for older hosts.
+type ProtocolVersion struct {
+	// Version is the negotiated protocol version.
+	Version int
+}
@@ -0,0 +1,151 @@
+package component
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// Secret is a sensitive string, such as a password or API token. Secrets
+// print as SensitiveValue with fmt, so they're redacted when they're
+// logged with the logger the SDK gives to plugins, dumped in debug logs or
+// written to the terminal UI. They are also redacted when marshaled to
+// JSON. Use Reveal to get the actual value.
+//
+// Secrets map to and from the Secret protobuf message so that secret
+// material is flagged as such between the plugin and the host. Secret
+// messages in results and resource state are redacted in their JSON form.
+//
+// Secret is a string type so that configuration fields of this type can
+// be decoded from HCL like any other string attribute. Prefer Reveal to a
+// conversion to get the value, so that uses of the value are easy to find.
+//
+// The zero value is an empty secret.
+type Secret string
+
+// NewSecret returns a secret with the value v.
+func NewSecret(v string) Secret {
+	return Secret(v)
+}
+
+// Reveal returns the actual value of the secret. Take care not to log or
+// output the result.
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// Empty returns true if the secret has no value.
+func (s Secret) Empty() bool {
+	return s == ""
+}
+
+// String implements fmt.Stringer and returns SensitiveValue.
+func (s Secret) String() string {
+	return SensitiveValue
+}
+
+// GoString implements fmt.GoStringer and returns SensitiveValue, so that
+// the secret is also redacted with "%#v".
+func (s Secret) GoString() string {
+	return SensitiveValue
+}
+
+// MarshalJSON implements json.Marshaler and always encodes SensitiveValue.
+// This means that secrets don't survive a JSON round trip.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(SensitiveValue)
+}
+
+// UnmarshalJSON implements json.Unmarshaler so that secrets can be decoded
+// from JSON strings.
+func (s *Secret) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*string)(s))
+}
+
+// Proto returns the Secret protobuf message for the secret.
+func (s Secret) Proto() *pb.Secret {
+	return &pb.Secret{Value: string(s)}
+}
+
+// SecretFromProto returns the secret in the Secret protobuf message. A nil
+// message is an empty secret.
+func SecretFromProto(v *pb.Secret) Secret {
+	return NewSecret(v.GetValue())
+}
+
+// RedactSecrets returns msg with the values of any Secret messages it
+// contains replaced with SensitiveValue. This is used before converting
+// messages to JSON for display. If msg contains no secrets it is returned
+// as-is, otherwise a copy is redacted and msg is not modified.
+//
+// Secrets inside google.protobuf.Any values are not redacted.
+func RedactSecrets(msg proto.Message) proto.Message {
+	if msg == nil {
+		return nil
+	}
+
+	found := false
+	walkSecrets(msg.ProtoReflect(), func(protoreflect.Message, protoreflect.FieldDescriptor) {
+		found = true
+	})
+	if !found {
+		return msg
+	}
+
+	result := proto.Clone(msg)
+	walkSecrets(result.ProtoReflect(), func(m protoreflect.Message, fd protoreflect.FieldDescriptor) {
+		m.Set(fd, protoreflect.ValueOfString(SensitiveValue))
+	})
+
+	return result
+}
+
+var secretDescriptor = (&pb.Secret{}).ProtoReflect().Descriptor()
+
+// walkSecrets calls fn with the value field of each non-empty Secret
+// message within m.
+func walkSecrets(
+	m protoreflect.Message,
+	fn func(protoreflect.Message, protoreflect.FieldDescriptor),
+) {
+	if m.Descriptor().FullName() == secretDescriptor.FullName() {
+		fd := m.Descriptor().Fields().ByName("value")
+		if fd != nil && m.Get(fd).String() != "" {
+			fn(m, fd)
+		}
+
+		return
+	}
+
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			if fd.Message() == nil {
+				break
+			}
+
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				walkSecrets(list.Get(i).Message(), fn)
+			}
+
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				break
+			}
+
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				walkSecrets(v.Message(), fn)
+				return true
+			})
+
+		case fd.Message() != nil:
+			walkSecrets(v.Message(), fn)
+		}
+
+		return true
+	})
+}
@@ -0,0 +1,217 @@
+package component
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+func TestSecret(t *testing.T) {
+	require := require.New(t)
+
+	s := NewSecret("hunter2")
+	require.Equal("hunter2", s.Reveal())
+	require.False(s.Empty())
+	require.True(Secret("").Empty())
+
+	type config struct {
+		Token Secret
+	}
+
+	for _, format := range []string{"%s", "%v", "%+v", "%#v", "%q"} {
+		require.NotContains(fmt.Sprintf(format, s), "hunter2", format)
+		require.NotContains(fmt.Sprintf(format, config{Token: s}), "hunter2", format)
+	}
+
+	data, err := json.Marshal(config{Token: s})
+	require.NoError(err)
+	require.JSONEq(`{"Token": "(sensitive)"}`, string(data))
+
+	// Plugin logs are JSON formatted.
+	var buf bytes.Buffer
+	for _, jsonFormat := range []bool{true, false} {
+		buf.Reset()
+		hclog.New(&hclog.LoggerOptions{Output: &buf, JSONFormat: jsonFormat}).
+			Info("auth", "token", s, "config", config{Token: s})
+		require.NotContains(buf.String(), "hunter2")
+		require.Contains(buf.String(), SensitiveValue)
+	}
+
+	var c config
+	require.NoError(json.Unmarshal([]byte(`{"Token": "hunter2"}`), &c))
+	require.Equal("hunter2", c.Token.Reveal())
+
+	require.Equal(s, SecretFromProto(s.Proto()))
+	require.True(SecretFromProto(nil).Empty())
+}
+
+func TestConfigDiff_secret(t *testing.T) {
+	require := require.New(t)
+
+	type config struct {
+		Token Secret
+	}
+
+	diff := DiffConfig(&config{}, &config{Token: NewSecret("hunter2")})
+	require.Len(diff.Changes, 1)
+	require.True(diff.Changes[0].Sensitive)
+	require.Equal(SensitiveValue, diff.Changes[0].New)
+}
+
+func TestConfigure_secret(t *testing.T) {
+	require := require.New(t)
+
+	c := &implSecret{}
+	src := `token = "hunter2"`
+	configure := func() {
+		f, diag := hclparse.NewParser().ParseHCL([]byte(src), "test.hcl")
+		require.False(diag.HasErrors())
+		require.False(Configure(c, f.Body, nil).HasErrors())
+	}
+
+	configure()
+	require.Equal("hunter2", c.Set.Token.Reveal())
+	require.True(c.Diff.Changed("token"))
+	require.True(c.Diff.Change("token").Sensitive)
+
+	// Configuring the same secret again is not a change
+	configure()
+	require.True(c.Diff.Empty())
+
+	src = `token = "hunter3"`
+	configure()
+	require.Equal("hunter3", c.Set.Token.Reveal())
+	require.True(c.Diff.Changed("token"))
+}
+
+type secretConfig struct {
+	Token Secret `hcl:"token,optional"`
+}
+
+// implSecret records the configuration and changes it is notified with.
+type implSecret struct {
+	config secretConfig
+	Set    secretConfig
+	Diff   *ConfigDiff
+}
+
+func (c *implSecret) Config() (interface{}, error) { return &c.config, nil }
+
+func (c *implSecret) ConfigSetDiff(v interface{}, diff *ConfigDiff) error {
+	c.Set = *v.(*secretConfig)
+	c.Diff = diff
+	return nil
+}
+
+func TestRedactSecrets(t *testing.T) {
+	require := require.New(t)
+
+	// No secrets returns the message as-is.
+	msg := &pb.OCIRef{Repository: "library/nginx"}
+	require.True(msg == RedactSecrets(msg))
+
+	secret := &pb.Secret{Value: "hunter2"}
+	require.Equal(SensitiveValue, RedactSecrets(secret).(*pb.Secret).Value)
+	require.Equal("hunter2", secret.Value)
+
+	// Secrets are redacted in nested fields, lists and maps.
+	mt := testSecretsType(t)
+	creds := dynamicpb.NewMessage(mt.Descriptor())
+	fields := mt.Descriptor().Fields()
+	secretValue := func(v string) protoreflect.Value {
+		return protoreflect.ValueOfMessage((&pb.Secret{Value: v}).ProtoReflect())
+	}
+	creds.Set(fields.ByName("name"), protoreflect.ValueOfString("web"))
+	creds.Set(fields.ByName("token"), secretValue("a"))
+	list := creds.Mutable(fields.ByName("keys")).List()
+	list.Append(secretValue("b"))
+	list.Append(secretValue(""))
+	creds.Mutable(fields.ByName("named")).Map().Set(
+		protoreflect.ValueOfString("c").MapKey(), secretValue("c"))
+
+	result := RedactSecrets(creds).ProtoReflect()
+	value := func(v protoreflect.Value) string {
+		return v.Message().Get(secretDescriptor.Fields().ByName("value")).String()
+	}
+	require.Equal("web", result.Get(fields.ByName("name")).String())
+	require.Equal(SensitiveValue, value(result.Get(fields.ByName("token"))))
+	require.Equal(SensitiveValue, value(result.Get(fields.ByName("keys")).List().Get(0)))
+	require.Equal("", value(result.Get(fields.ByName("keys")).List().Get(1)))
+	require.Equal(SensitiveValue, value(result.Get(fields.ByName("named")).Map().Get(
+		protoreflect.ValueOfString("c").MapKey())))
+
+	// The original isn't modified.
+	require.Equal("a", value(creds.Get(fields.ByName("token"))))
+}
+
+// testSecretsType returns a message type with Secret fields.
+func testSecretsType(t *testing.T) protoreflect.MessageType {
+	secret := proto.String(".hashicorp.waypoint.sdk.Secret")
+	field := func(name string, number int32, label descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    label.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+			TypeName: secret,
+		}
+	}
+
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	named := field("named", 4, repeated)
+	named.TypeName = proto.String(".secrettest.Creds.NamedEntry")
+
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("secrettest.proto"),
+		Package:    proto.String("secrettest"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{pb.File_plugin_proto.Path()},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Creds"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{
+					Name:     proto.String("name"),
+					JsonName: proto.String("name"),
+					Number:   proto.Int32(1),
+					Label:    optional.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				},
+				field("token", 2, optional),
+				field("keys", 3, repeated),
+				named,
+			},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name:    proto.String("NamedEntry"),
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("key"),
+						JsonName: proto.String("key"),
+						Number:   proto.Int32(1),
+						Label:    optional.Enum(),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+					},
+					field("value", 2, optional),
+				},
+			}},
+		}},
+	}, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+
+	return dynamicpb.NewMessageType(fd.Messages().ByName("Creds"))
+}
@@ -0,0 +1,46 @@
+package component
+
+import (
+	"io"
+)
+
+// SnapshotWriter is given to the SnapshotFunc of a Snapshotter to write the
+// data of the snapshot to. The data is streamed to the host as it is
+// written, so snapshots may be larger than the memory of the plugin.
+type SnapshotWriter struct {
+	w io.Writer
+}
+
+// NewSnapshotWriter returns a SnapshotWriter that writes to w. This is used
+// by the SDK, and by plugins to test their snapshot functions.
+func NewSnapshotWriter(w io.Writer) *SnapshotWriter {
+	return &SnapshotWriter{w: w}
+}
+
+// Write implements io.Writer.
+func (w *SnapshotWriter) Write(p []byte) (int, error) {
+	return w.w.Write(p)
+}
+
+// SnapshotReader is given to the RestoreFunc of a Snapshotter to read the
+// data of the snapshot to restore. This is the data that was written to
+// the SnapshotWriter of a previous snapshot.
+type SnapshotReader struct {
+	r io.Reader
+}
+
+// NewSnapshotReader returns a SnapshotReader that reads from r. This is
+// used by the SDK, and by plugins to test their restore functions.
+func NewSnapshotReader(r io.Reader) *SnapshotReader {
+	return &SnapshotReader{r: r}
+}
+
+// Read implements io.Reader.
+func (r *SnapshotReader) Read(p []byte) (int, error) {
+	return r.r.Read(p)
+}
+
+var (
+	_ io.Writer = (*SnapshotWriter)(nil)
+	_ io.Reader = (*SnapshotReader)(nil)
+)
@@ -0,0 +1,137 @@
+package component
+
+import (
+	"fmt"
+	"strconv"
+
+	proto "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// UnhealthyStatus are the health states of resources that aren't healthy.
+// This can be used as the Health of a StatusFilter to only report the
+// resources that need attention.
+var UnhealthyStatus = []proto.StatusReport_Health{
+	proto.StatusReport_DOWN,
+	proto.StatusReport_MISSING,
+	proto.StatusReport_PARTIAL,
+}
+
+// StatusFilter selects the resources of a status report. Hosts can give
+// this as an argument to a status function to only get some resources or
+// to page through the resources of large reports. The resource manager in
+// framework/resource applies the filter automatically if it is one of the
+// arguments to Manager.StatusReport. Plugins that build reports themselves
+// can use Apply.
+//
+// Older hosts don't give a filter, so status functions should request it
+// as an optional argument with an argmapper.Struct field tagged
+// `argmapper:",typeOnly,optional"`.
+//
+// A nil filter selects all resources.
+type StatusFilter struct {
+	// Types are the resource types to include. If empty, all types are
+	// included.
+	Types []string
+
+	// Health are the health states to include. If empty, resources of any
+	// health are included.
+	Health []proto.StatusReport_Health
+
+	// PageSize is the maximum number of resources in a report. If this is
+	// zero, all resources are returned.
+	PageSize int
+
+	// PageToken is the NextPageToken of the previous page. This is empty
+	// for the first page.
+	PageToken string
+}
+
+// Match returns true if the filter includes the resource, ignoring the
+// pagination.
+func (f *StatusFilter) Match(r *proto.StatusReport_Resource) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Types) > 0 && !containsString(f.Types, r.Type) {
+		return false
+	}
+
+	if len(f.Health) > 0 {
+		found := false
+		for _, h := range f.Health {
+			if h == r.Health {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Filter returns the resources that the filter includes, ignoring the
+// pagination. The order of the resources is kept.
+func (f *StatusFilter) Filter(rs []*proto.StatusReport_Resource) []*proto.StatusReport_Resource {
+	if f == nil {
+		return rs
+	}
+
+	var result []*proto.StatusReport_Resource
+	for _, r := range rs {
+		if f.Match(r) {
+			result = append(result, r)
+		}
+	}
+
+	return result
+}
+
+// Apply filters the resources of the report in place and sets its Page.
+// The resources must be in a deterministic order so that the pages are
+// consistent between calls. The overall health of the report is not
+// changed, so it still describes all of the resources.
+func (f *StatusFilter) Apply(report *proto.StatusReport) error {
+	if f == nil || report == nil {
+		return nil
+	}
+
+	rs := f.Filter(report.Resources)
+	page := &proto.StatusReport_Page{Total: uint32(len(rs))}
+
+	offset := 0
+	if f.PageToken != "" {
+		v, err := strconv.Atoi(f.PageToken)
+		if err != nil || v < 0 {
+			return fmt.Errorf("invalid status page token: %q", f.PageToken)
+		}
+
+		offset = v
+	}
+	if offset > len(rs) {
+		offset = len(rs)
+	}
+	rs = rs[offset:]
+
+	if f.PageSize > 0 && len(rs) > f.PageSize {
+		rs = rs[:f.PageSize]
+		page.NextPageToken = strconv.Itoa(offset + f.PageSize)
+	}
+
+	report.Resources = rs
+	report.Page = page
+	return nil
+}
+
+func containsString(vs []string, v string) bool {
+	for _, s := range vs {
+		if s == v {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,51 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	proto "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+func TestStatusFilter(t *testing.T) {
+	require := require.New(t)
+
+	var resources []*proto.StatusReport_Resource
+	for _, name := range []string{"a", "b", "c"} {
+		resources = append(resources, &proto.StatusReport_Resource{
+			Name:   name,
+			Type:   "pod",
+			Health: proto.StatusReport_READY,
+		})
+	}
+	resources = append(resources, &proto.StatusReport_Resource{
+		Name:   "d",
+		Type:   "service",
+		Health: proto.StatusReport_DOWN,
+	})
+
+	var nilFilter *StatusFilter
+	require.Len(nilFilter.Filter(resources), 4)
+	require.NoError(nilFilter.Apply(&proto.StatusReport{}))
+
+	f := &StatusFilter{Health: UnhealthyStatus}
+	require.Len(f.Filter(resources), 1)
+
+	f = &StatusFilter{Types: []string{"pod"}, PageSize: 2}
+	report := &proto.StatusReport{Resources: resources}
+	require.NoError(f.Apply(report))
+	require.Len(report.Resources, 2)
+	require.Equal(uint32(3), report.Page.Total)
+	require.Equal("2", report.Page.NextPageToken)
+
+	f.PageToken = report.Page.NextPageToken
+	report = &proto.StatusReport{Resources: resources}
+	require.NoError(f.Apply(report))
+	require.Len(report.Resources, 1)
+	require.Equal("c", report.Resources[0].Name)
+	require.Empty(report.Page.NextPageToken)
+
+	f.PageToken = "-1"
+	require.Error(f.Apply(&proto.StatusReport{}))
+}
@@ -1,5 +1,11 @@
 package component
 
+import (
+	"io"
+
+	proto "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
 // TaskLaunchInfo is used by TaskLauncher's StartTaskFunc operation.
 // This type provides the details about how the new task should be configured.
 type TaskLaunchInfo struct {
@@ -21,6 +27,41 @@ type TaskLaunchInfo struct {
 	// If the image defines an entrypoint, then the arguments will be passed as
 	// arguments to that program.
 	Arguments []string
+
+	// Resources are the compute resources requested for the task. If this
+	// is nil, the plugin should use its own defaults.
+	Resources *TaskResources
+
+	// Placement constrains where the task may be scheduled. If this is nil,
+	// the task may be scheduled anywhere.
+	Placement *TaskPlacement
+}
+
+// TaskResources are the compute resources requested for a task. Zero values
+// mean the plugin default.
+type TaskResources struct {
+	// CPUMillicores is the requested CPU, where 1000 is one core.
+	CPUMillicores int64
+
+	// MemoryMB is the requested memory in megabytes.
+	MemoryMB int64
+
+	// GPUCount is the number of GPUs the task requires.
+	GPUCount int32
+
+	// GPUType is the platform-specific GPU type, such as "nvidia-tesla-t4".
+	// If this is empty, any GPU type may be used.
+	GPUType string
+}
+
+// TaskPlacement constrains where a task may be scheduled.
+type TaskPlacement struct {
+	// NodeLabels are labels that the node running the task must have.
+	NodeLabels map[string]string
+
+	// Zones are the zones the task may run in. If this is empty, the task
+	// may run in any zone.
+	Zones []string
 }
 
 // TaskResult is the result value that must be returned by the RunTask
@@ -28,3 +69,36 @@ type TaskLaunchInfo struct {
 type TaskResult struct {
 	ExitCode int
 }
+
+// TaskWatchInfo is available to TaskLauncher's WatchTaskFunc operation to
+// stream the output and state of the task to the host while it runs.
+type TaskWatchInfo struct {
+	Output io.Writer // the output from the task (stdout)
+	Error  io.Writer // the error output from the task (stderr)
+
+	// StateFunc is called to report a state transition of the task. Use
+	// SetState rather than calling this directly since it may be nil.
+	StateFunc func(*TaskState) error
+}
+
+// SetState reports a state transition of the task to the host. This does
+// nothing if StateFunc is nil.
+func (i *TaskWatchInfo) SetState(s *TaskState) error {
+	if i.StateFunc == nil {
+		return nil
+	}
+
+	return i.StateFunc(s)
+}
+
+// TaskState is a state transition of a watched task.
+type TaskState struct {
+	State proto.TaskWatch_State
+
+	// Message is an optional human-readable detail, such as why a task
+	// is still pending.
+	Message string
+
+	// ExitCode is the exit code of the task if State is EXITED.
+	ExitCode int
+}
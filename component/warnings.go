@@ -0,0 +1,69 @@
+package component
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
+)
+
+// Warnings is a vehicle for plugins to report non-fatal issues, such as
+// "image not pinned by digest", to core - an "OutParameter". It can be
+// accepted as an argument to Build, Deploy and Release functions. The
+// warnings are returned with the operation result and core displays them
+// after the operation without failing it.
+//
+// Warning diagnostics returned by these functions, see the diag package,
+// are added to the warnings as well. If the operation fails, the warnings
+// are attached to the error instead and available with diag.FromError.
+//
+// Warnings is safe for concurrent use.
+type Warnings struct {
+	mu    sync.Mutex
+	diags diag.Diagnostics
+}
+
+// Add adds a warning with the given summary.
+func (w *Warnings) Add(summary string) {
+	w.AddDiagnostics(diag.Diagnostic{Summary: summary})
+}
+
+// Addf adds a warning with the summary formatted with fmt.Sprintf.
+func (w *Warnings) Addf(format string, args ...interface{}) {
+	w.Add(fmt.Sprintf(format, args...))
+}
+
+// AddDiagnostics adds the diagnostics as warnings. The severity of the
+// diagnostics is ignored, they are always warnings.
+func (w *Warnings) AddDiagnostics(ds ...diag.Diagnostic) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, d := range ds {
+		d.Severity = diag.Warning
+		w.diags = append(w.diags, d)
+	}
+}
+
+// Warnings returns the warnings added so far in the order they were added.
+func (w *Warnings) Warnings() diag.Diagnostics {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append(diag.Diagnostics(nil), w.diags...)
+}
+
+// IsOutParameter causes Warnings to implement the OutParameter interface, which
+// will prevent it from being added as an arg to any plugin advertised dynamic function spec.
+func (w *Warnings) isOutParameter() {}
+
+// WarningTrail is implemented by the artifacts, deployments and releases
+// returned by plugin clients to provide the warnings the plugin reported
+// during the operation.
+type WarningTrail interface {
+	Warnings() diag.Diagnostics
+}
+
+var (
+	_ OutParameter = (*Warnings)(nil)
+	_ WarningTrail = (*Warnings)(nil)
+)
@@ -0,0 +1,24 @@
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
+)
+
+func TestWarnings(t *testing.T) {
+	var w Warnings
+	require.Empty(t, w.Warnings())
+
+	w.Add("a")
+	w.Addf("b %d", 1)
+	w.AddDiagnostics(diag.Diagnostic{Severity: diag.Error, Summary: "c", Path: []string{"x"}})
+
+	require.Equal(t, diag.Diagnostics{
+		{Severity: diag.Warning, Summary: "a"},
+		{Severity: diag.Warning, Summary: "b 1"},
+		{Severity: diag.Warning, Summary: "c", Path: []string{"x"}},
+	}, w.Warnings())
+}
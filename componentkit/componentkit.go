@@ -0,0 +1,199 @@
+package componentkit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
+	sdkplugin "github.com/hashicorp/waypoint-plugin-sdk/internal/plugin"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// Args are the arguments of a function call that the client sends to the
+// plugin. The callback given to Base.Func can request this to get them.
+type Args = funcspec.Args
+
+// Internal is an argument that is available to every function. The
+// callback given to Base.Func should request it and close its Cleanup
+// when the call is done, since mappers register cleanup functions with it,
+// such as stopping the servers for the terminal.UI.
+type Internal = pluginargs.Internal
+
+// ServerSettings are the settings for the gRPC servers of a plugin, such
+// as the maximum message size. The settings from sdk.WithMaxMessageSize and
+// sdk.WithCompression are set on a field of this type.
+type ServerSettings = pluginargs.ServerSettings
+
+// FeatureSet holds the features of the plugin and the features negotiated
+// with the host. The set from sdk.WithFeatures is set on a field of this
+// type.
+type FeatureSet = pluginargs.FeatureSet
+
+// Base contains the shared logic for the servers and clients of custom
+// component types. This should be embedded in both.
+type Base struct {
+	Broker          *plugin.GRPCBroker
+	Logger          hclog.Logger
+	Mappers         []*argmapper.Func
+	ProtocolVersion *component.ProtocolVersion
+	Features        *FeatureSet
+	TracerProvider  trace.TracerProvider
+
+	UnaryInterceptors  []grpc.UnaryServerInterceptor
+	StreamInterceptors []grpc.StreamServerInterceptor
+	ServerSettings     *pluginargs.ServerSettings
+
+	// cache caches the results of the mappers for the calls with the
+	// Base until PurgeMappers is called, cachedMappers are the Mappers
+	// wrapped with it.
+	cacheOnce     sync.Once
+	cache         *pluginargs.MapperCache
+	cachedMappers []*argmapper.Func
+}
+
+// Internal returns a new Internal for a function call. The results of the
+// mappers are cached for the calls with the Base until PurgeMappers is
+// called, so a server should use one Base for the lifetime of the
+// component.
+func (b *Base) Internal() *Internal {
+	cache, mappers := b.mapperCache()
+	result := &Internal{
+		Broker:      b.Broker,
+		Mappers:     mappers,
+		Cleanup:     &pluginargs.Cleanup{},
+		MapperCache: cache,
+		Features:    b.Features,
+
+		TracerProvider:     b.TracerProvider,
+		UnaryInterceptors:  b.UnaryInterceptors,
+		StreamInterceptors: b.StreamInterceptors,
+		ServerSettings:     b.ServerSettings,
+	}
+	if b.ProtocolVersion != nil {
+		result.ProtocolVersion = b.ProtocolVersion.Version
+	}
+
+	return result
+}
+
+// PurgeMappers removes the cached results of the mappers. Servers that
+// implement configuration must call this when the component is configured,
+// since the results may depend on the previous configuration.
+func (b *Base) PurgeMappers() {
+	cache, _ := b.mapperCache()
+	cache.Purge()
+}
+
+func (b *Base) mapperCache() (*pluginargs.MapperCache, []*argmapper.Func) {
+	b.cacheOnce.Do(func() {
+		b.cache = pluginargs.NewMapperCache()
+		b.cachedMappers = b.cache.Wrap(b.Mappers)
+	})
+
+	return b.cache, b.cachedMappers
+}
+
+// Spec returns the spec of the function fn of a component. This is used
+// by servers to implement the spec RPC of an operation. If fn is nil, this
+// returns an error with codes.Unimplemented.
+func (b *Base) Spec(fn interface{}) (*pb.FuncSpec, error) {
+	return funcspec.Spec(fn,
+		argmapper.Logger(b.Logger),
+		argmapper.ConverterFunc(b.Mappers...),
+		argmapper.Typed(b.Internal()),
+	)
+}
+
+// Call calls the function fn of a component with the arguments sent by
+// the client and returns the result. This is used by servers to implement
+// the RPC of an operation. ctx and any callArgs are available to fn in
+// addition to the arguments from the client.
+func (b *Base) Call(
+	ctx context.Context,
+	fn interface{},
+	args *pb.FuncSpec_Args,
+	callArgs ...argmapper.Arg,
+) (interface{}, error) {
+	internal := b.Internal()
+	defer internal.Cleanup.Close()
+
+	return sdkplugin.CallDynamicFunc(ctx, fn, args.Args, append([]argmapper.Arg{
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Typed(internal),
+		argmapper.Typed(ctx),
+	}, callArgs...)...)
+}
+
+// Func returns the function the host calls for the function with the
+// given spec. This is used by clients to implement the methods like
+// ScanFunc of a component. cb is called with the Args to send to the
+// plugin and can request a context.Context and *Internal. The result of
+// cb is the result of the function.
+func (b *Base) Func(spec *pb.FuncSpec, cb interface{}) interface{} {
+	// We don't want to be a mapper
+	spec.Result = nil
+
+	return funcspec.Func(spec, cb,
+		argmapper.Logger(b.Logger),
+		argmapper.Typed(b.Internal()),
+	)
+}
+
+// FuncErr returns a function that returns err. Clients return this from
+// the methods like ScanFunc of a component if they can't get the spec, so
+// that the error is returned when the function is called.
+func FuncErr(err error) interface{} {
+	return func(context.Context) (interface{}, error) {
+		return nil, err
+	}
+}
+
+// ImplementsFunc is the signature of the RPCs that report whether a plugin
+// implements an optional capability, such as IsNotifier.
+type ImplementsFunc func(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ImplementsResp, error)
+
+// Implements calls the RPC f to check if the plugin implements an optional
+// capability. Plugins built before the capability was added don't have
+// the RPC at all, which is treated as not implementing it.
+func Implements(ctx context.Context, f ImplementsFunc) (bool, error) {
+	resp, err := f(ctx, &empty.Empty{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return resp.Implements, nil
+}
+
+// Capabilities records the optional capabilities that a plugin reported
+// while creating a client. Clients can embed this to implement
+// component.CapabilityReporter.
+type Capabilities []string
+
+// Add records the capability if ok is true.
+func (c *Capabilities) Add(name string, ok bool) {
+	if ok {
+		*c = append(*c, name)
+	}
+}
+
+func (c Capabilities) Capabilities() []string {
+	return append([]string(nil), c...)
+}
+
+var (
+	_ component.CapabilityReporter = Capabilities(nil)
+)
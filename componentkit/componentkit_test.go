@@ -0,0 +1,117 @@
+package componentkit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal-shared/protomappers"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+func TestBase_roundTrip(t *testing.T) {
+	require := require.New(t)
+
+	var mappers []*argmapper.Func
+	for _, raw := range protomappers.All {
+		f, err := argmapper.NewFunc(raw)
+		require.NoError(err)
+		mappers = append(mappers, f)
+	}
+
+	// The function of the component, which requests a mapped argument
+	fn := func(ctx context.Context, src *component.Source) *testproto.Data {
+		return &testproto.Data{Value: src.App}
+	}
+
+	server := &Base{Logger: hclog.L(), Mappers: mappers}
+	spec, err := server.Spec(fn)
+	require.NoError(err)
+
+	// The client calls the server directly instead of over gRPC
+	client := &Base{Logger: hclog.L(), Mappers: mappers}
+	f := client.Func(spec, func(
+		ctx context.Context,
+		args Args,
+		internal *Internal,
+	) (*testproto.Data, error) {
+		defer internal.Cleanup.Close()
+
+		result, err := server.Call(ctx, fn, &pb.FuncSpec_Args{Args: args})
+		if err != nil {
+			return nil, err
+		}
+
+		return result.(*testproto.Data), nil
+	}).(*argmapper.Func)
+
+	result := f.Call(
+		argmapper.ConverterFunc(mappers...),
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(&pb.Args_Source{App: "foo"}),
+	)
+	require.NoError(result.Err())
+	require.Equal("foo", result.Out(0).(*testproto.Data).Value)
+}
+
+func TestBase_mapperCache(t *testing.T) {
+	require := require.New(t)
+
+	type config struct{}
+	type client struct{}
+
+	calls := 0
+	mapper, err := argmapper.NewFunc(func(*config) *client {
+		calls++
+		return &client{}
+	})
+	require.NoError(err)
+
+	// Calls with the same Base share the mapper results
+	base := &Base{Logger: hclog.L(), Mappers: []*argmapper.Func{mapper}}
+	cfg := &config{}
+	for i := 0; i < 3; i++ {
+		internal := base.Internal()
+		require.Same(base.Internal().MapperCache, internal.MapperCache)
+		result := internal.Mappers[0].Call(argmapper.Typed(cfg))
+		require.NoError(result.Err())
+	}
+	require.Equal(1, calls)
+}
+
+func TestBase_specNil(t *testing.T) {
+	_, err := (&Base{Logger: hclog.L()}).Spec(nil)
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestImplements(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	ok, err := Implements(ctx, func(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ImplementsResp, error) {
+		return &pb.ImplementsResp{Implements: true}, nil
+	})
+	require.NoError(err)
+	require.True(ok)
+
+	// Older plugins don't have the RPC
+	ok, err = Implements(ctx, func(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ImplementsResp, error) {
+		return nil, status.Error(codes.Unimplemented, "unknown method")
+	})
+	require.NoError(err)
+	require.False(ok)
+
+	_, err = Implements(ctx, func(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ImplementsResp, error) {
+		return nil, status.Error(codes.Unavailable, "down")
+	})
+	require.Error(err)
+}
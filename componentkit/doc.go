@@ -0,0 +1,84 @@
+// Package componentkit contains the building blocks the SDK uses for its
+// own component types, such as Builder and Platform, so that projects
+// embedding this plugin framework can define their own component types,
+// such as a "Scanner" or "Notifier". Functions of custom component types
+// are served with the same mapper and function spec machinery as the
+// built-in types, so they can request the same arguments, such as a
+// terminal.UI, and use the same mappers.
+//
+// A custom component type needs:
+//
+//   - An interface for the component with methods like ScanFunc that
+//     return the function for an operation.
+//
+//   - A gRPC service for the component type with, for each operation, an
+//     RPC that returns the *proto.FuncSpec of the function and an RPC that
+//     calls it with *proto.FuncSpec_Args.
+//
+//   - A plugin that registers a server for the service and creates
+//     clients. The server uses Base.Spec and Base.Call and the client uses
+//     Base.Func. The plugin is served with sdk.WithComponentType.
+//
+// The plugin must be a pointer to a struct. Like the plugins of the
+// built-in types, the component, mappers and logger are set on its
+// fields, so it should look like:
+//
+//	type ScannerPlugin struct {
+//		plugin.NetRPCUnsupportedPlugin
+//
+//		Impl    Scanner           // Impl is the concrete implementation
+//		Mappers []*argmapper.Func // Mappers
+//		Logger  hclog.Logger      // Logger
+//	}
+//
+//	func (p *ScannerPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+//		RegisterScannerServer(s, &scannerServer{
+//			Base: &componentkit.Base{Broker: broker, Logger: p.Logger, Mappers: p.Mappers},
+//			Impl: p.Impl,
+//		})
+//		return nil
+//	}
+//
+// If the plugin is served with sdk.WithTracerProvider, the tracer provider
+// is also set on a field of type trace.TracerProvider, and the interceptors
+// from sdk.WithUnaryInterceptor and sdk.WithStreamInterceptor are set on
+// fields of type []grpc.UnaryServerInterceptor and
+// []grpc.StreamServerInterceptor, as are the settings from
+// sdk.WithMaxMessageSize and sdk.WithCompression on a field of type
+// *componentkit.ServerSettings. Set them on the Base so they are used for
+// the servers that mappers start, such as for the terminal.UI.
+//
+// The server implements the RPCs of an operation with the Base:
+//
+//	func (s *scannerServer) ScanSpec(ctx context.Context, _ *empty.Empty) (*proto.FuncSpec, error) {
+//		return s.Spec(s.Impl.ScanFunc())
+//	}
+//
+//	func (s *scannerServer) Scan(ctx context.Context, args *proto.FuncSpec_Args) (*ScanResult, error) {
+//		result, err := s.Call(ctx, s.Impl.ScanFunc(), args)
+//		if err != nil {
+//			return nil, err
+//		}
+//
+//		return result.(*ScanResult), nil
+//	}
+//
+// The client returns a function for the host to call with Base.Func:
+//
+//	func (c *scannerClient) ScanFunc() interface{} {
+//		spec, err := c.client.ScanSpec(context.Background(), &empty.Empty{})
+//		if err != nil {
+//			return componentkit.FuncErr(err)
+//		}
+//
+//		return c.Func(spec, func(ctx context.Context, args componentkit.Args, internal *componentkit.Internal) (*ScanResult, error) {
+//			defer internal.Cleanup.Close()
+//			return c.client.Scan(ctx, &proto.FuncSpec_Args{Args: args})
+//		})
+//	}
+//
+// Optional capabilities of a component type follow the same pattern with
+// an additional RPC, such as IsNotifier, that the client checks with
+// Implements. Clients can record the capabilities they found with
+// Capabilities.
+package componentkit
@@ -0,0 +1,248 @@
+package datadir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Backend stores the persisted data of a Project outside of the local
+// disk, such as in S3, so it survives on runners that don't keep their
+// disk between runs. Plugins and hosts still use the local directories of
+// a Dir; a Project with a backend is restored from it with Restore and
+// saved to it with Save. The cache is never stored in a backend.
+//
+// Keys are slash-separated paths relative to the data directory. Remote
+// storage is supported by implementing this interface, for example with
+// an S3 bucket and a key prefix. LocalBackend is the implementation for a
+// local directory.
+type Backend interface {
+	// List returns the keys of all the stored objects.
+	List(ctx context.Context) ([]string, error)
+
+	// Get returns the data of the object with the given key. If it doesn't
+	// exist, the error matches os.ErrNotExist with errors.Is.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Put stores the data read from r as the object with the given key,
+	// replacing any existing object.
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Delete deletes the object with the given key. Deleting an object
+	// that doesn't exist is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// LocalBackend is a Backend that stores objects as files in a local
+// directory, such as a shared volume.
+type LocalBackend struct {
+	root string
+}
+
+// NewLocalBackend returns a LocalBackend for the directory at root.
+func NewLocalBackend(root string) *LocalBackend {
+	return &LocalBackend{root: root}
+}
+
+// List impl Backend
+func (b *LocalBackend) List(ctx context.Context) ([]string, error) {
+	keys, err := listFiles(b.root)
+	if errors.Is(err, os.ErrNotExist) {
+		err = nil
+	}
+
+	return keys, err
+}
+
+// Get impl Backend
+func (b *LocalBackend) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := keyPath(b.root, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(path)
+}
+
+// Put impl Backend
+func (b *LocalBackend) Put(ctx context.Context, key string, r io.Reader) error {
+	path, err := keyPath(b.root, key)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(path, r)
+}
+
+// Delete impl Backend
+func (b *LocalBackend) Delete(ctx context.Context, key string) error {
+	path, err := keyPath(b.root, key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// Restore copies the objects in the backend of the project to its data
+// directory, replacing local files with the same path. Local files that
+// aren't in the backend are kept. This does nothing if the project has no
+// backend.
+func (p *Project) Restore(ctx context.Context) error {
+	if p.backend == nil {
+		return nil
+	}
+
+	keys, err := p.backend.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		path, err := keyPath(p.DataDir(), key)
+		if err != nil {
+			return err
+		}
+
+		if err := p.restore(ctx, key, path); err != nil {
+			return fmt.Errorf("error restoring %q: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Project) restore(ctx context.Context, key, path string) error {
+	r, err := p.backend.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return writeFile(path, r)
+}
+
+// Save copies the files in the data directory of the project to its
+// backend and deletes the objects in the backend that no longer exist
+// locally, so the backend mirrors the data directory. This does nothing
+// if the project has no backend.
+func (p *Project) Save(ctx context.Context) error {
+	if p.backend == nil {
+		return nil
+	}
+
+	local, err := listFiles(p.DataDir())
+	if err != nil {
+		return err
+	}
+
+	saved := map[string]struct{}{}
+	for _, key := range local {
+		if err := p.save(ctx, key); err != nil {
+			return fmt.Errorf("error saving %q: %w", key, err)
+		}
+		saved[key] = struct{}{}
+	}
+
+	remote, err := p.backend.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, key := range remote {
+		if _, ok := saved[key]; ok {
+			continue
+		}
+
+		if err := p.backend.Delete(ctx, key); err != nil {
+			return fmt.Errorf("error deleting %q: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Project) save(ctx context.Context, key string) error {
+	f, err := os.Open(filepath.Join(p.DataDir(), filepath.FromSlash(key)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return p.backend.Put(ctx, key, f)
+}
+
+// listFiles returns the slash-separated paths of the regular files in the
+// directory at root, relative to root.
+func listFiles(root string) ([]string, error) {
+	var result []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		result = append(result, filepath.ToSlash(rel))
+		return nil
+	})
+
+	return result, err
+}
+
+// keyPath returns the path in the directory at root for key, which must
+// not refer to a path outside of root.
+func keyPath(root, key string) (string, error) {
+	rel := filepath.Clean(filepath.FromSlash(key))
+	if key == "" ||
+		filepath.IsAbs(rel) ||
+		rel == "." ||
+		rel == ".." ||
+		strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+
+	return filepath.Join(root, rel), nil
+}
+
+// writeFile writes the data read from r to the file at path, creating
+// any parent directories. The data is written to a temporary file first
+// so a failed write doesn't leave a partial file behind.
+func writeFile(path string, r io.Reader) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, ".datadir-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+var _ Backend = (*LocalBackend)(nil)
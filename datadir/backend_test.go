@@ -0,0 +1,80 @@
+package datadir
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBackend(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	b := NewLocalBackend(filepath.Join(t.TempDir(), "backend"))
+
+	// An empty backend doesn't need to exist
+	keys, err := b.List(ctx)
+	require.NoError(err)
+	require.Empty(keys)
+
+	require.NoError(b.Put(ctx, "a/b.txt", strings.NewReader("hello")))
+	keys, err = b.List(ctx)
+	require.NoError(err)
+	require.Equal([]string{"a/b.txt"}, keys)
+
+	r, err := b.Get(ctx, "a/b.txt")
+	require.NoError(err)
+	data, err := io.ReadAll(r)
+	r.Close()
+	require.NoError(err)
+	require.Equal("hello", string(data))
+
+	require.NoError(b.Delete(ctx, "a/b.txt"))
+	require.NoError(b.Delete(ctx, "a/b.txt"))
+	_, err = b.Get(ctx, "a/b.txt")
+	require.True(errors.Is(err, os.ErrNotExist))
+
+	require.Error(b.Put(ctx, "../escape", strings.NewReader("hello")))
+}
+
+func TestProject_backend(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	b := NewLocalBackend(t.TempDir())
+	require.NoError(b.Put(ctx, "stale.txt", strings.NewReader("old")))
+
+	p, err := NewProject(t.TempDir(), WithBackend(b))
+	require.NoError(err)
+	require.NoError(os.MkdirAll(filepath.Join(p.DataDir(), "app"), 0755))
+	require.NoError(os.WriteFile(filepath.Join(p.DataDir(), "app", "state"), []byte("1"), 0644))
+
+	// Save mirrors the data directory
+	require.NoError(p.Save(ctx))
+	keys, err := b.List(ctx)
+	require.NoError(err)
+	sort.Strings(keys)
+	require.Equal([]string{"app/state"}, keys)
+
+	// A new project on another runner restores the data
+	p2, err := NewProject(t.TempDir(), WithBackend(b))
+	require.NoError(err)
+	require.NoError(p2.Restore(ctx))
+	data, err := os.ReadFile(filepath.Join(p2.DataDir(), "app", "state"))
+	require.NoError(err)
+	require.Equal("1", string(data))
+}
+
+func TestProject_noBackend(t *testing.T) {
+	p, err := NewProject(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, p.Restore(context.Background()))
+	require.NoError(t, p.Save(context.Background()))
+}
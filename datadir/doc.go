@@ -15,4 +15,10 @@
 // rather than direct filesystem manipulation. This gives us more room to
 // introduce improvements in the future that broadly impact the application
 // without having to make those changes in many places.
+//
+// The directories are always on the local disk. A Project can store its
+// persisted data in a Backend such as S3 with WithBackend, so runners that
+// don't keep their disk can restore it. On shared runners, Usage reports
+// the disk space used and EvictCache or WithCacheLimit keep the cache
+// within a size.
 package datadir
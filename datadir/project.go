@@ -11,17 +11,48 @@ import (
 // This means that the data is shared by all applications in the project.
 type Project struct {
 	Dir
+
+	backend    Backend
+	cacheLimit int64
+}
+
+// ProjectOption is used to configure NewProject.
+type ProjectOption func(*Project)
+
+// WithBackend sets the Backend that stores the persisted data of the
+// project. See Project.Restore and Project.Save.
+func WithBackend(b Backend) ProjectOption {
+	return func(p *Project) { p.backend = b }
+}
+
+// WithCacheLimit limits the size of the cache of the project, including
+// the caches of its apps and components, to maxSize bytes. The least
+// recently used files are evicted when the project is created; see
+// EvictCache.
+func WithCacheLimit(maxSize int64) ProjectOption {
+	return func(p *Project) { p.cacheLimit = maxSize }
 }
 
 // NewProject creates the directory structure for a project. This will
 // create the physical directories on disk if they do not already exist.
-func NewProject(path string) (*Project, error) {
+func NewProject(path string, opts ...ProjectOption) (*Project, error) {
 	dir, err := newRootDir(path)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Project{Dir: dir}, nil
+	p := &Project{Dir: dir}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if p.cacheLimit > 0 {
+		if _, err := EvictCache(p, p.cacheLimit); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
 }
 
 // App returns the Dir implementation scoped to a specific app.
@@ -0,0 +1,143 @@
+package datadir
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DiskUsage is the disk space used by a Dir, in bytes.
+type DiskUsage struct {
+	Cache int64
+	Data  int64
+}
+
+// Usage returns the disk space used by the files in d. Shared runners can
+// use this to report or enforce quotas.
+func Usage(d Dir) (*DiskUsage, error) {
+	cache, err := dirSize(d.CacheDir())
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := dirSize(d.DataDir())
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiskUsage{Cache: cache, Data: data}, nil
+}
+
+// EvictCache deletes the least recently used files in the cache directory
+// of d until it uses at most maxSize bytes, and returns the number of
+// bytes deleted. Directories left empty are deleted as well.
+//
+// Files are ordered by modification time, so plugins that reuse a cached
+// file should update its modification time, such as with os.Chtimes, to
+// keep it from being evicted before files that are no longer used.
+func EvictCache(d Dir, maxSize int64) (int64, error) {
+	root := d.CacheDir()
+
+	type entry struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	var entries []entry
+	var total int64
+	err := filepath.WalkDir(root, func(path string, de fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !de.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := de.Info()
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, entry{
+			path:    path,
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+		total += info.Size()
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if total <= maxSize {
+		return 0, nil
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+
+	var evicted int64
+	for _, e := range entries {
+		if total-evicted <= maxSize {
+			break
+		}
+
+		if err := os.Remove(e.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return evicted, err
+		}
+		evicted += e.size
+
+		removeEmptyParents(root, filepath.Dir(e.path))
+	}
+
+	return evicted, nil
+}
+
+// dirSize returns the total size of the regular files in the directory at
+// path. A directory that doesn't exist has a size of zero.
+func dirSize(path string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(path, func(path string, de fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !de.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := de.Info()
+		if err != nil {
+			return err
+		}
+
+		total += info.Size()
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+
+	return total, err
+}
+
+// removeEmptyParents removes dir and its parents up to but not including
+// root while they are empty.
+func removeEmptyParents(root, dir string) {
+	for dir != root && len(dir) > len(root) {
+		// Remove fails if the directory isn't empty
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+
+		dir = filepath.Dir(dir)
+	}
+}
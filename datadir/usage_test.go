@@ -0,0 +1,77 @@
+package datadir
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsage(t *testing.T) {
+	require := require.New(t)
+
+	d := NewBasicDir(t.TempDir(), t.TempDir())
+	writeTestFile(t, filepath.Join(d.CacheDir(), "a", "b"), 10, time.Now())
+	writeTestFile(t, filepath.Join(d.DataDir(), "c"), 5, time.Now())
+
+	usage, err := Usage(d)
+	require.NoError(err)
+	require.Equal(&DiskUsage{Cache: 10, Data: 5}, usage)
+
+	// Missing directories are empty
+	usage, err = Usage(NewBasicDir(filepath.Join(t.TempDir(), "nope"), filepath.Join(t.TempDir(), "nope")))
+	require.NoError(err)
+	require.Equal(&DiskUsage{}, usage)
+}
+
+func TestEvictCache(t *testing.T) {
+	require := require.New(t)
+
+	d := NewBasicDir(t.TempDir(), t.TempDir())
+	now := time.Now()
+	writeTestFile(t, filepath.Join(d.CacheDir(), "old", "a"), 10, now.Add(-2*time.Hour))
+	writeTestFile(t, filepath.Join(d.CacheDir(), "b"), 10, now.Add(-time.Hour))
+	writeTestFile(t, filepath.Join(d.CacheDir(), "c"), 10, now)
+
+	// Under the limit
+	evicted, err := EvictCache(d, 30)
+	require.NoError(err)
+	require.Equal(int64(0), evicted)
+
+	evicted, err = EvictCache(d, 15)
+	require.NoError(err)
+	require.Equal(int64(20), evicted)
+
+	_, err = os.Stat(filepath.Join(d.CacheDir(), "old"))
+	require.True(os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(d.CacheDir(), "b"))
+	require.True(os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(d.CacheDir(), "c"))
+	require.NoError(err)
+}
+
+func TestNewProject_cacheLimit(t *testing.T) {
+	require := require.New(t)
+
+	path := t.TempDir()
+	p, err := NewProject(path)
+	require.NoError(err)
+	writeTestFile(t, filepath.Join(p.CacheDir(), "a"), 10, time.Now())
+
+	p, err = NewProject(path, WithCacheLimit(5))
+	require.NoError(err)
+
+	usage, err := Usage(p)
+	require.NoError(err)
+	require.Equal(int64(0), usage.Cache)
+}
+
+func writeTestFile(t *testing.T, path string, size int, modTime time.Time) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0644))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
@@ -0,0 +1,97 @@
+package sdk
+
+import (
+	"io"
+	"net"
+	"sync"
+)
+
+// debugProxy accepts TCP connections for a debug server and forwards them
+// to the address go-plugin listens on. go-plugin only listens on a unix
+// socket or on localhost, which can't be reached from outside a container.
+type debugProxy struct {
+	ln   net.Listener
+	wg   sync.WaitGroup
+	done chan struct{}
+}
+
+// newDebugProxy listens on the TCP address addr.
+func newDebugProxy(addr string) (*debugProxy, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &debugProxy{ln: ln, done: make(chan struct{})}, nil
+}
+
+// Addr returns the address Waypoint should connect to. Unspecified IPs
+// are replaced with 127.0.0.1 since they can't be dialed.
+func (p *debugProxy) Addr() ReattachConfigAddr {
+	addr := p.ln.Addr().(*net.TCPAddr)
+	if addr.IP == nil || addr.IP.IsUnspecified() {
+		addr = &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: addr.Port}
+	}
+
+	return ReattachConfigAddr{
+		Network: addr.Network(),
+		String:  addr.String(),
+	}
+}
+
+// Serve accepts connections and forwards them to target until the proxy
+// is closed.
+func (p *debugProxy) Serve(target net.Addr) {
+	defer close(p.done)
+	for {
+		conn, err := p.ln.Accept()
+		if err != nil {
+			p.wg.Wait()
+			return
+		}
+
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.forward(conn, target)
+		}()
+	}
+}
+
+// forward copies data between conn and a new connection to target until
+// either side is closed.
+func (p *debugProxy) forward(conn net.Conn, target net.Addr) {
+	defer conn.Close()
+
+	upstream, err := net.Dial(target.Network(), target.String())
+	if err != nil {
+		return
+	}
+	defer upstream.Close()
+
+	doneCh := make(chan struct{}, 2)
+	go func() {
+		io.Copy(upstream, conn)
+		doneCh <- struct{}{}
+	}()
+	go func() {
+		io.Copy(conn, upstream)
+		doneCh <- struct{}{}
+	}()
+
+	<-doneCh
+}
+
+// closeAfter returns a channel that is closed once closeCh is closed and
+// the proxy has stopped, so that the address can be reused right away.
+func (p *debugProxy) closeAfter(closeCh <-chan struct{}) chan struct{} {
+	result := make(chan struct{})
+	go func() {
+		<-closeCh
+		p.ln.Close()
+		<-p.done
+		close(result)
+	}()
+
+	return result
+}
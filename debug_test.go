@@ -0,0 +1,122 @@
+package sdk
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal-shared/pluginclient"
+)
+
+func TestDebugServe_addr(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config, closeCh, err := DebugServe(ctx,
+		WithDebugAddr("0.0.0.0:0"),
+	)
+	require.NoError(err)
+	require.Equal("tcp", config.Addr.Network)
+
+	addr, err := net.ResolveTCPAddr("tcp", config.Addr.String)
+	require.NoError(err)
+	require.True(addr.IP.IsLoopback())
+
+	// Connect to the plugin through the debug address
+	clientConfig := pluginclient.ClientConfig(hclog.L(), false)
+	clientConfig.Managed = false
+	clientConfig.Reattach = &plugin.ReattachConfig{
+		Protocol:        plugin.Protocol(config.Protocol),
+		ProtocolVersion: config.ProtocolVersion,
+		Pid:             config.Pid,
+		Test:            config.Test,
+		Addr:            addr,
+	}
+	client := plugin.NewClient(clientConfig)
+	rpcClient, err := client.Client()
+	require.NoError(err)
+
+	require.NoError(rpcClient.Ping())
+
+	// The address is free once the server is closed
+	rpcClient.Close()
+	cancel()
+	<-closeCh
+
+	ln, err := net.Listen("tcp", addr.String())
+	require.NoError(err)
+	ln.Close()
+}
+
+func TestDebugServe_addrInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	_, closeCh, err := DebugServe(context.Background(), WithDebugAddr(ln.Addr().String()))
+	require.Error(t, err)
+	<-closeCh
+}
+
+func TestDebugServe_dispenseNamed(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config, closeCh, err := DebugServe(ctx,
+		WithComponents(&mocks.Builder{}),
+		WithNamedComponent("a", &mocks.Builder{}),
+		WithNamedComponent("b", &mocks.Builder{}),
+		WithDebugAddr("127.0.0.1:0"),
+	)
+	require.NoError(err)
+	defer func() {
+		cancel()
+		<-closeCh
+	}()
+
+	addr, err := net.ResolveTCPAddr("tcp", config.Addr.String)
+	require.NoError(err)
+
+	clientConfig := pluginclient.ClientConfig(hclog.L(), false)
+	clientConfig.Managed = false
+	clientConfig.Reattach = &plugin.ReattachConfig{
+		Protocol:        plugin.Protocol(config.Protocol),
+		ProtocolVersion: config.ProtocolVersion,
+		Pid:             config.Pid,
+		Test:            config.Test,
+		Addr:            addr,
+	}
+	clientConfig.Plugins = clientConfig.VersionedPlugins[config.ProtocolVersion]
+	client := plugin.NewClient(clientConfig)
+	defer client.Kill()
+
+	// Components can be dispensed by name concurrently
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		name := []string{"", "a", "b"}[i%3]
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = pluginclient.Dispense(client, component.BuilderType, name)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(err)
+	}
+
+	_, err = pluginclient.Dispense(client, component.BuilderType, "unknown")
+	require.Error(err)
+}
@@ -0,0 +1,209 @@
+// Package diag contains structured diagnostics that plugin functions can
+// return in addition to an error.
+//
+// A plugin function may return Diagnostics (or []Diagnostic) as one of its
+// results. If the diagnostics contain at least one error, the call fails
+// and the diagnostics are sent to the host as gRPC status details, which
+// the host can extract with FromError to render each warning and error
+// along with the field it is about. Otherwise, warnings are sent to the
+// host in the trailer of the call and can be extracted with FromTrailer.
+package diag
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// Severity is the severity of a Diagnostic.
+type Severity int
+
+const (
+	Error Severity = iota
+	Warning
+)
+
+// Diagnostic is a single error or warning.
+type Diagnostic struct {
+	Severity Severity
+
+	// Summary is a short description of the problem, such as
+	// "subnet does not exist".
+	Summary string
+
+	// Detail is an optional longer description of the problem.
+	Detail string
+
+	// Path is the path to the configuration field the diagnostic is
+	// about, such as []string{"network", "subnet"}. This is empty if it
+	// isn't about a specific field.
+	Path []string
+
+	// Remediation is an optional hint on how to fix the problem.
+	Remediation string
+}
+
+// String returns a single line description of the diagnostic.
+func (d Diagnostic) String() string {
+	var sb strings.Builder
+	if len(d.Path) > 0 {
+		sb.WriteString(strings.Join(d.Path, "."))
+		sb.WriteString(": ")
+	}
+
+	sb.WriteString(d.Summary)
+	if d.Detail != "" {
+		sb.WriteString(": ")
+		sb.WriteString(d.Detail)
+	}
+
+	return sb.String()
+}
+
+// Diagnostics is a list of diagnostics.
+type Diagnostics []Diagnostic
+
+// Errorf appends an error diagnostic with the given summary.
+func (ds Diagnostics) Errorf(format string, args ...interface{}) Diagnostics {
+	return append(ds, Diagnostic{Severity: Error, Summary: fmt.Sprintf(format, args...)})
+}
+
+// Warnf appends a warning diagnostic with the given summary.
+func (ds Diagnostics) Warnf(format string, args ...interface{}) Diagnostics {
+	return append(ds, Diagnostic{Severity: Warning, Summary: fmt.Sprintf(format, args...)})
+}
+
+// HasErrors returns true if any of the diagnostics is an error.
+func (ds Diagnostics) HasErrors() bool {
+	for _, d := range ds {
+		if d.Severity == Error {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Err returns an error for the diagnostics if there are any errors. The
+// returned error is a gRPC status error with the diagnostics attached as
+// details so it can be returned directly from an RPC. This returns nil if
+// there are no errors.
+func (ds Diagnostics) Err() error {
+	if !ds.HasErrors() {
+		return nil
+	}
+
+	var msgs []string
+	for _, d := range ds {
+		if d.Severity == Error {
+			msgs = append(msgs, d.String())
+		}
+	}
+
+	st := status.New(codes.Unknown, strings.Join(msgs, "\n"))
+	if withDetails, err := st.WithDetails(ds.Proto()); err == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
+
+// Proto returns the proto representation of the diagnostics.
+func (ds Diagnostics) Proto() *pb.ValidationResult {
+	result := &pb.ValidationResult{}
+	for _, d := range ds {
+		result.Diagnostics = append(result.Diagnostics, &pb.ValidationResult_Diagnostic{
+			Severity:    pb.ValidationResult_Severity(d.Severity),
+			Summary:     d.Summary,
+			Detail:      d.Detail,
+			Field:       strings.Join(d.Path, "."),
+			Remediation: d.Remediation,
+		})
+	}
+
+	return result
+}
+
+// Trailer returns the gRPC trailer metadata that sends the diagnostics to
+// the host. This is used to return warnings from calls that succeed and
+// that have no other way to report them.
+func (ds Diagnostics) Trailer() metadata.MD {
+	if len(ds) == 0 {
+		return nil
+	}
+
+	data, err := proto.Marshal(ds.Proto())
+	if err != nil {
+		return nil
+	}
+
+	return metadata.Pairs(TrailerKey, string(data))
+}
+
+// TrailerKey is the gRPC trailer key that Trailer uses for diagnostics.
+const TrailerKey = "waypoint-diagnostics-bin"
+
+// FromProto returns the diagnostics for the proto representation.
+func FromProto(v *pb.ValidationResult) Diagnostics {
+	if v == nil {
+		return nil
+	}
+
+	var result Diagnostics
+	for _, d := range v.Diagnostics {
+		var path []string
+		if d.Field != "" {
+			path = strings.Split(d.Field, ".")
+		}
+
+		result = append(result, Diagnostic{
+			Severity:    Severity(d.Severity),
+			Summary:     d.Summary,
+			Detail:      d.Detail,
+			Path:        path,
+			Remediation: d.Remediation,
+		})
+	}
+
+	return result
+}
+
+// FromError returns the diagnostics attached to an error returned by a
+// plugin call. This returns nil if the error has no diagnostics.
+func FromError(err error) Diagnostics {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+
+	var result Diagnostics
+	for _, detail := range st.Details() {
+		if v, ok := detail.(*pb.ValidationResult); ok {
+			result = append(result, FromProto(v)...)
+		}
+	}
+
+	return result
+}
+
+// FromTrailer returns the diagnostics sent in the trailer of a plugin call
+// with Trailer. This returns nil if there are none.
+func FromTrailer(md metadata.MD) Diagnostics {
+	var result Diagnostics
+	for _, raw := range md.Get(TrailerKey) {
+		var v pb.ValidationResult
+		if err := proto.Unmarshal([]byte(raw), &v); err != nil {
+			continue
+		}
+
+		result = append(result, FromProto(&v)...)
+	}
+
+	return result
+}
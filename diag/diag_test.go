@@ -0,0 +1,63 @@
+package diag
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDiagnostics_Err(t *testing.T) {
+	require := require.New(t)
+
+	var ds Diagnostics
+	require.NoError(ds.Err())
+
+	ds = ds.Warnf("deprecated field")
+	require.False(ds.HasErrors())
+	require.NoError(ds.Err())
+
+	ds = append(ds, Diagnostic{
+		Severity:    Error,
+		Summary:     "subnet does not exist",
+		Detail:      "subnet-123 was not found",
+		Path:        []string{"network", "subnet"},
+		Remediation: "create the subnet or use an existing one",
+	})
+	require.True(ds.HasErrors())
+
+	err := ds.Err()
+	require.Error(err)
+	require.Equal(codes.Unknown, status.Code(err))
+	require.Equal("network.subnet: subnet does not exist: subnet-123 was not found",
+		status.Convert(err).Message())
+
+	// The warnings and errors are all available from the error
+	require.Equal(ds, FromError(err))
+}
+
+func TestFromError(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(FromError(nil))
+	require.Nil(FromError(errors.New("plain")))
+	require.Nil(FromError(status.Error(codes.Internal, "no details")))
+}
+
+func TestDiagnostics_Trailer(t *testing.T) {
+	require := require.New(t)
+
+	var ds Diagnostics
+	require.Nil(ds.Trailer())
+	require.Nil(FromTrailer(nil))
+
+	ds = ds.Warnf("deprecated field")
+	ds = append(ds, Diagnostic{
+		Severity: Warning,
+		Summary:  "slow",
+		Path:     []string{"network", "subnet"},
+	})
+	require.Equal(ds, FromTrailer(ds.Trailer()))
+}
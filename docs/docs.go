@@ -27,6 +27,10 @@ type Details struct {
 	// Mappers is the list of mappers that the plugin makes available for
 	// type conversion.
 	Mappers []Mapper
+
+	// TemplateVersion is the version of the template schema of the
+	// output, or zero if the output doesn't declare a schema.
+	TemplateVersion int
 }
 
 // Mapper indicates the available mappers and what types they convert
@@ -40,6 +44,10 @@ type Mapper struct {
 
 	// Description is a simple explanation of how the mapper converts the values.
 	Description string
+
+	// Name is the name of the mapper function. This is set for the mappers
+	// that the plugin advertises to the host and may be empty otherwise.
+	Name string
 }
 
 // FieldDocs documents a specific attribute the plugin has available.
@@ -77,6 +85,22 @@ type FieldDocs struct {
 	// in that category.
 	SubFields []*FieldDocs
 
+	// ValidValues is the set of values the attribute may be set to. If
+	// empty, any value of the attribute type is allowed.
+	ValidValues []string
+
+	// Range is the inclusive range of values for a numeric attribute. If
+	// nil, any value is allowed.
+	Range *FieldRange
+
+	// ConflictsWith is the list of attributes that can't be set when this
+	// attribute is set.
+	ConflictsWith []string
+
+	// RequiredWith is the list of attributes that must also be set when
+	// this attribute is set.
+	RequiredWith []string
+
 	discoveredFields map[string]*FieldDocs
 }
 
@@ -90,6 +114,8 @@ type Documentation struct {
 	templateFields map[string]*FieldDocs
 	requestFields  map[string]*FieldDocs
 	mappers        []Mapper
+
+	templateVersion int
 }
 
 // DocField contains a field that can be set - i.e. a Documentation or a SubFieldDoc
@@ -121,6 +147,15 @@ func New(opts ...Option) (*Documentation, error) {
 // FromConfig populates the Documentation value by reading the struct
 // members on the value. This is typically passed the value that is returned
 // by the plugin's Config function.
+//
+// Fields can be documented with struct tags instead of calling SetField:
+//
+//	Region string `hcl:"region" docs:"the region to deploy to" default:"us-east-1" env:"AWS_REGION"`
+//
+// The "docs" tag sets the synopsis (or hides the field if it is "hidden"),
+// "docs-summary" sets the summary, "default" sets the default value, and
+// "env" sets the environment variable. Calling SetField for a field
+// overrides the values from its tags.
 func FromConfig(v interface{}) Option {
 	return func(d *Documentation) error {
 		return fromConfig(v, d.fields)
@@ -181,6 +216,13 @@ func fromConfig(v interface{}, target map[string]*FieldDocs) error {
 		field := &FieldDocs{
 			Field: parts[0],
 			Type:  cleanupType(f.Type.String()),
+
+			// Documentation can be given with tags. SetField can still
+			// be used to override these.
+			Synopsis: docTags,
+			Summary:  f.Tag.Get("docs-summary"),
+			Default:  f.Tag.Get("default"),
+			EnvVar:   f.Tag.Get("env"),
 		}
 
 		for _, p := range parts[1:] {
@@ -255,8 +297,29 @@ type (
 
 	// Example gives the example usage, as it would be specified in HCL.
 	Example string
+
+	// ValidValues sets the ValidValues of the field
+	ValidValues []string
+
+	// ConflictsWith sets the ConflictsWith of the field
+	ConflictsWith []string
+
+	// RequiredWith sets the RequiredWith of the field
+	RequiredWith []string
 )
 
+// FieldRange is the inclusive range of values for a numeric field. Use
+// Range to set it on a field.
+type FieldRange struct {
+	Min float64
+	Max float64
+}
+
+// Range sets the inclusive range of values for a numeric field.
+func Range(min, max float64) *FieldRange {
+	return &FieldRange{Min: min, Max: max}
+}
+
 type docOption interface {
 	docOption() bool
 }
@@ -266,6 +329,10 @@ func (o Default) docOption() bool       { return true }
 func (o EnvVar) docOption() bool        { return true }
 func (o Category) docOption() bool      { return true }
 func (o Example) docOption() bool       { return true }
+func (o ValidValues) docOption() bool   { return true }
+func (o ConflictsWith) docOption() bool { return true }
+func (o RequiredWith) docOption() bool  { return true }
+func (o *FieldRange) docOption() bool   { return true }
 
 // Summary creates a SummaryString by doing some light space editing
 // and joining of the given array of strings. This is a convenience function
@@ -309,6 +376,11 @@ func (d *Documentation) Output(x string) {
 	d.output = x
 }
 
+// TemplateVersion sets the TemplateVersion field of the Documentation
+func (d *Documentation) TemplateVersion(x int) {
+	d.templateVersion = x
+}
+
 // AddMapper adds a new Mapper value to the mappers in Documentation
 func (d *Documentation) AddMapper(input, output, description string) {
 	d.mappers = append(d.mappers, Mapper{
@@ -318,6 +390,12 @@ func (d *Documentation) AddMapper(input, output, description string) {
 	})
 }
 
+// AddMapperDocs adds m to the mappers in Documentation. Unlike AddMapper,
+// this keeps every field of m, such as the Name.
+func (d *Documentation) AddMapperDocs(m Mapper) {
+	d.mappers = append(d.mappers, m)
+}
+
 func applyOpts(field *FieldDocs, opts []docOption) {
 	for _, o := range opts {
 		switch v := o.(type) {
@@ -329,6 +407,14 @@ func applyOpts(field *FieldDocs, opts []docOption) {
 			field.EnvVar = string(v)
 		case Example:
 			field.Example = string(v)
+		case ValidValues:
+			field.ValidValues = []string(v)
+		case *FieldRange:
+			field.Range = v
+		case ConflictsWith:
+			field.ConflictsWith = []string(v)
+		case RequiredWith:
+			field.RequiredWith = []string(v)
 		case *SubFieldDoc:
 			if len(field.discoveredFields) > 0 {
 				v.merge(field.discoveredFields)
@@ -511,6 +597,8 @@ func (d *Documentation) Details() *Details {
 		Input:       d.input,
 		Output:      d.output,
 		Mappers:     d.mappers,
+
+		TemplateVersion: d.templateVersion,
 	}
 }
 
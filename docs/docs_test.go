@@ -27,3 +27,67 @@ func TestHiddenDocsFields(t *testing.T) {
 
 	require.Equal(expectedFields, actualFields)
 }
+
+func TestSetField_validation(t *testing.T) {
+	require := require.New(t)
+
+	d, err := New()
+	require.NoError(err)
+	require.NoError(d.SetField("port", "the port",
+		Range(1, 65535),
+		ConflictsWith{"socket"},
+	))
+	require.NoError(d.SetField("protocol", "the protocol",
+		ValidValues{"tcp", "udp"},
+		RequiredWith{"port"},
+	))
+
+	require.Equal([]*FieldDocs{
+		{
+			Field:         "port",
+			Synopsis:      "the port",
+			Range:         &FieldRange{Min: 1, Max: 65535},
+			ConflictsWith: []string{"socket"},
+		},
+		{
+			Field:        "protocol",
+			Synopsis:     "the protocol",
+			ValidValues:  []string{"tcp", "udp"},
+			RequiredWith: []string{"port"},
+		},
+	}, d.Fields())
+}
+
+func TestFromConfig_tags(t *testing.T) {
+	require := require.New(t)
+
+	type config struct {
+		Region string `hcl:"region,optional" docs:"the region, such as us-east-1" docs-summary:"longer" default:"us-east-1" env:"AWS_REGION"`
+		Name   string `hcl:"name"`
+	}
+
+	d, err := New(FromConfig(&config{}))
+	require.NoError(err)
+	require.Equal([]*FieldDocs{
+		{
+			Field:    "name",
+			Type:     "string",
+			Optional: false,
+		},
+		{
+			Field:    "region",
+			Type:     "string",
+			Synopsis: "the region, such as us-east-1",
+			Summary:  "longer",
+			Optional: true,
+			Default:  "us-east-1",
+			EnvVar:   "AWS_REGION",
+		},
+	}, d.Fields())
+
+	// SetField overrides the tags
+	require.NoError(d.SetField("region", "the region", Default("us-west-2")))
+	require.Equal("the region", d.Fields()[1].Synopsis)
+	require.Equal("us-west-2", d.Fields()[1].Default)
+	require.Equal("longer", d.Fields()[1].Summary)
+}
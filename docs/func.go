@@ -14,7 +14,9 @@ import (
 // This currently extracts:
 //
 //   - Template fields from the result type if the result type is
-//     a concrete type (not an interface value).
+//     a concrete type (not an interface value). If the result type
+//     implements component.TemplateSchema, the declared fields and
+//     version are used.
 func FromFunc(v interface{}) Option {
 	return func(d *Documentation) error {
 		v := reflect.ValueOf(v)
@@ -50,6 +52,12 @@ func funcExtractTemplateFields(d *Documentation, t reflect.Type) error {
 	// type or an error. If it is an error we'll catch it below.
 	out := t.Out(0)
 	for {
+		// If the output type declares its schema, that is the most
+		// accurate documentation.
+		if out.Implements(templateSchemaType) {
+			return funcExtractTemplateFieldsFromSchema(d, out)
+		}
+
 		// If the output type implements our template interface already
 		// then we can just use that now.
 		if out.Implements(templateType) {
@@ -116,9 +124,36 @@ func funcExtractTemplateFieldsFromImpl(d *Documentation, t reflect.Type) error {
 	return nil
 }
 
+// funcExtractTemplateFieldsFromSchema extracts the template fields from a
+// type that implements templateSchemaType.
+func funcExtractTemplateFieldsFromSchema(d *Documentation, t reflect.Type) error {
+	out := reflect.New(t).Elem().MethodByName("TemplateSchema").Call([]reflect.Value{})
+	schema := out[0].Interface().(*TemplateSchema)
+	if schema == nil {
+		return nil
+	}
+
+	for _, f := range schema.Fields {
+		d.templateFields[f.Name] = &FieldDocs{
+			Field:    f.Name,
+			Type:     f.Type,
+			Synopsis: f.Synopsis,
+		}
+	}
+	d.TemplateVersion(schema.Version)
+
+	return nil
+}
+
 // templateType is the type implemented by results that support
 // template data. We don't use component.TemplateData directly because
 // we are avoiding circular imports.
 var templateType = reflect.TypeOf((*interface {
 	TemplateData() map[string]interface{}
 })(nil)).Elem()
+
+// templateSchemaType is the type implemented by results that declare the
+// schema of their template data. See component.TemplateSchema.
+var templateSchemaType = reflect.TypeOf((*interface {
+	TemplateSchema() *TemplateSchema
+})(nil)).Elem()
@@ -52,6 +52,26 @@ func TestFromFunc(t *testing.T) {
 			},
 			"",
 		},
+
+		{
+			"struct implementing schema",
+			func() (*testTemplateSchemaStruct, error) {
+				return nil, nil
+			},
+			[]*FieldDocs{
+				{
+					Field:    "port",
+					Type:     "int",
+					Synopsis: "the port",
+				},
+				{
+					Field:    "url",
+					Type:     "string",
+					Synopsis: "the url",
+				},
+			},
+			"",
+		},
 	}
 
 	for _, tt := range cases {
@@ -79,3 +99,23 @@ func (t *testTemplateStruct) TemplateData() map[string]interface{} {
 		"full_name": "",
 	}
 }
+
+type testTemplateSchemaStruct struct{ testTemplateStruct }
+
+func (t *testTemplateSchemaStruct) TemplateSchema() *TemplateSchema {
+	return &TemplateSchema{
+		Version: 2,
+		Fields: []*TemplateField{
+			{Name: "url", Type: TemplateTypeString, Synopsis: "the url"},
+			{Name: "port", Type: TemplateTypeInt, Synopsis: "the port"},
+		},
+	}
+}
+
+func TestFromFunc_templateVersion(t *testing.T) {
+	require := require.New(t)
+
+	d, err := New(FromFunc(func() *testTemplateSchemaStruct { return nil }))
+	require.NoError(err)
+	require.Equal(2, d.Details().TemplateVersion)
+}
@@ -0,0 +1,139 @@
+package docs
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Types for TemplateField.Type. The type of a template data value is
+// checked against these when the data is validated.
+const (
+	TemplateTypeString = "string"
+	TemplateTypeInt    = "int"
+	TemplateTypeFloat  = "float"
+	TemplateTypeBool   = "bool"
+	TemplateTypeList   = "list"
+	TemplateTypeMap    = "map"
+	TemplateTypeAny    = "any"
+)
+
+// TemplateSchema declares the keys of the template data of a result type.
+// See component.TemplateSchema.
+type TemplateSchema struct {
+	// Version is the version of the schema. This should be incremented
+	// whenever a key is removed or its type changes, since that can break
+	// user templates.
+	Version int
+
+	// Fields are the keys of the template data.
+	Fields []*TemplateField
+}
+
+// TemplateField is a single key of the template data.
+type TemplateField struct {
+	// Name is the key in the template data.
+	Name string
+
+	// Type is one of the TemplateType constants. If this is empty or
+	// TemplateTypeAny, the type of the value isn't checked.
+	Type string
+
+	// Synopsis is a short description of the value.
+	Synopsis string
+}
+
+// Validate checks data against the schema and returns a description of
+// each difference: declared keys that are missing, keys that aren't
+// declared, and values that don't match the declared type. Nil values
+// match any type. The result is sorted by key.
+func (s *TemplateSchema) Validate(data map[string]interface{}) []string {
+	if s == nil {
+		return nil
+	}
+
+	declared := map[string]*TemplateField{}
+	for _, f := range s.Fields {
+		declared[f.Name] = f
+	}
+
+	var keys []string
+	for k := range declared {
+		keys = append(keys, k)
+	}
+	for k := range data {
+		if _, ok := declared[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var result []string
+	for _, k := range keys {
+		f, ok := declared[k]
+		if !ok {
+			result = append(result, fmt.Sprintf(
+				"key %q is not declared in the template schema", k))
+			continue
+		}
+
+		v, ok := data[k]
+		if !ok {
+			result = append(result, fmt.Sprintf(
+				"key %q is declared in the template schema but missing", k))
+			continue
+		}
+
+		if actual := templateValueType(v); !templateTypeMatches(f.Type, actual) {
+			result = append(result, fmt.Sprintf(
+				"key %q has type %s but the template schema declares %s", k, actual, f.Type))
+		}
+	}
+
+	return result
+}
+
+// templateValueType returns the TemplateType constant for the value v.
+func templateValueType(v interface{}) string {
+	if v == nil {
+		return TemplateTypeAny
+	}
+
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.String:
+		return TemplateTypeString
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return TemplateTypeInt
+
+	case reflect.Float32, reflect.Float64:
+		return TemplateTypeFloat
+
+	case reflect.Bool:
+		return TemplateTypeBool
+
+	case reflect.Slice, reflect.Array:
+		return TemplateTypeList
+
+	case reflect.Map, reflect.Struct, reflect.Ptr:
+		return TemplateTypeMap
+
+	default:
+		return TemplateTypeAny
+	}
+}
+
+func templateTypeMatches(declared, actual string) bool {
+	switch {
+	case declared == "", declared == TemplateTypeAny, actual == TemplateTypeAny:
+		return true
+
+	case declared == TemplateTypeFloat && actual == TemplateTypeInt:
+		// Integers are valid floats.
+		return true
+
+	default:
+		return declared == actual
+	}
+}
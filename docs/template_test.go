@@ -0,0 +1,71 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateSchemaValidate(t *testing.T) {
+	s := &TemplateSchema{
+		Fields: []*TemplateField{
+			{Name: "url", Type: TemplateTypeString},
+			{Name: "port", Type: TemplateTypeInt},
+			{Name: "weight", Type: TemplateTypeFloat},
+			{Name: "labels", Type: TemplateTypeMap},
+			{Name: "extra"},
+		},
+	}
+
+	cases := []struct {
+		Name     string
+		Data     map[string]interface{}
+		Expected []string
+	}{
+		{
+			"matches",
+			map[string]interface{}{
+				"url":    "http://localhost",
+				"port":   8080,
+				"weight": 1,
+				"labels": map[string]string{},
+				"extra":  []string{},
+			},
+			nil,
+		},
+
+		{
+			"nil values",
+			map[string]interface{}{
+				"url":    nil,
+				"port":   nil,
+				"weight": nil,
+				"labels": nil,
+				"extra":  nil,
+			},
+			nil,
+		},
+
+		{
+			"drift",
+			map[string]interface{}{
+				"url":     "http://localhost",
+				"port":    "8080",
+				"weight":  1.5,
+				"labels":  map[string]string{},
+				"address": "localhost",
+			},
+			[]string{
+				`key "address" is not declared in the template schema`,
+				`key "extra" is declared in the template schema but missing`,
+				`key "port" has type string but the template schema declares int`,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require.Equal(t, tt.Expected, s.Validate(tt.Data))
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package filesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Dir is a Service for the files in a local directory, such as the data
+// directory of a datadir.Dir. Paths can't refer to files outside of the
+// directory, including through symlinks inside it.
+type Dir struct {
+	root string
+}
+
+// NewDir returns a Dir for the directory at root.
+func NewDir(root string) *Dir {
+	return &Dir{root: root}
+}
+
+// Upload impl Service
+func (d *Dir) Upload(ctx context.Context, path string, mode os.FileMode, r io.Reader) error {
+	dst, err := d.path(path)
+	if err != nil {
+		return err
+	}
+
+	if mode == 0 {
+		mode = 0644
+	}
+
+	dir := filepath.Dir(dst)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a failed upload doesn't leave a
+	// partial file behind.
+	f, err := os.CreateTemp(dir, ".filesync-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := io.Copy(f, &ctxReader{ctx: ctx, r: r}); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(mode.Perm()); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), dst)
+}
+
+// Download impl Service
+func (d *Dir) Download(ctx context.Context, path string, w io.Writer) error {
+	src, err := d.path(path)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, &ctxReader{ctx: ctx, r: f})
+	return err
+}
+
+// path returns the local path for the slash-separated path p, which must
+// be inside the directory. Symlinks in the path must not point outside of
+// the directory either.
+func (d *Dir) path(p string) (string, error) {
+	rel := filepath.Clean(filepath.FromSlash(p))
+	if p == "" ||
+		filepath.IsAbs(rel) ||
+		rel == "." ||
+		outside(rel) {
+		return "", fmt.Errorf("invalid path %q: must be a relative path inside the directory", p)
+	}
+
+	dst := filepath.Join(d.root, rel)
+	if err := d.checkLinks(dst); err != nil {
+		return "", fmt.Errorf("invalid path %q: %w", p, err)
+	}
+
+	return dst, nil
+}
+
+// checkLinks returns an error if symlinks make dst, a path inside the
+// directory, refer to a file outside of it.
+func (d *Dir) checkLinks(dst string) error {
+	root, err := resolve(d.root)
+	if err != nil {
+		return err
+	}
+	dst, err = resolve(dst)
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(root, dst)
+	if err != nil || outside(rel) {
+		return errors.New("symlinks must not point outside the directory")
+	}
+
+	return nil
+}
+
+// resolve returns path with its symlinks evaluated. Only the part of path
+// that exists can contain symlinks, the rest is kept as-is since it is
+// created by Upload.
+func resolve(path string) (string, error) {
+	existing, rest := path, ""
+	for {
+		resolved, err := filepath.EvalSymlinks(existing)
+		if err == nil {
+			return filepath.Join(resolved, rest), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		// A symlink whose target doesn't exist can't be checked.
+		if _, err := os.Lstat(existing); err == nil {
+			return "", fmt.Errorf("symlink %q has a missing target", existing)
+		}
+
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return path, nil
+		}
+
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = parent
+	}
+}
+
+// outside returns true if the relative path rel refers to a file outside
+// of the directory it is relative to.
+func outside(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// ctxReader is an io.Reader that stops when ctx is done.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return r.r.Read(p)
+}
+
+var _ Service = (*Dir)(nil)
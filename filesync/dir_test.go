@@ -0,0 +1,138 @@
+package filesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDir(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	root := t.TempDir()
+	d := NewDir(root)
+
+	require.NoError(d.Upload(ctx, "a/b/c.txt", 0600, strings.NewReader("hello")))
+
+	fi, err := os.Stat(filepath.Join(root, "a", "b", "c.txt"))
+	require.NoError(err)
+	require.Equal(os.FileMode(0600), fi.Mode().Perm())
+
+	var buf bytes.Buffer
+	require.NoError(d.Download(ctx, "a/b/c.txt", &buf))
+	require.Equal("hello", buf.String())
+
+	err = d.Download(ctx, "nope.txt", &buf)
+	require.True(errors.Is(err, os.ErrNotExist))
+}
+
+func TestDir_newRoot(t *testing.T) {
+	require := require.New(t)
+
+	// The directory is created by the first upload
+	root := filepath.Join(t.TempDir(), "data")
+	d := NewDir(root)
+	require.NoError(d.Upload(context.Background(), "x.txt", 0, strings.NewReader("hello")))
+
+	data, err := os.ReadFile(filepath.Join(root, "x.txt"))
+	require.NoError(err)
+	require.Equal("hello", string(data))
+}
+
+func TestDir_invalidPath(t *testing.T) {
+	ctx := context.Background()
+	d := NewDir(t.TempDir())
+
+	for _, p := range []string{"", ".", "..", "../x", "a/../../x", "/etc/passwd"} {
+		t.Run(p, func(t *testing.T) {
+			require.Error(t, d.Upload(ctx, p, 0, strings.NewReader("hello")))
+			require.Error(t, d.Download(ctx, p, &bytes.Buffer{}))
+		})
+	}
+}
+
+func TestDir_symlinks(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	root := t.TempDir()
+	outsideDir := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(outsideDir, "secret.txt"), []byte("secret"), 0644))
+	require.NoError(os.Mkdir(filepath.Join(root, "inside"), 0755))
+
+	links := map[string]string{
+		"dir":     outsideDir,
+		"file":    filepath.Join(outsideDir, "secret.txt"),
+		"missing": filepath.Join(outsideDir, "missing"),
+		"ok":      filepath.Join(root, "inside"),
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(root, name)); err != nil {
+			t.Skipf("symlinks not supported: %s", err)
+		}
+	}
+
+	d := NewDir(root)
+
+	// Links pointing outside the directory are rejected
+	for _, p := range []string{"dir/x.txt", "dir/secret.txt", "file", "missing/x.txt"} {
+		require.Error(d.Upload(ctx, p, 0, strings.NewReader("hello")), p)
+		require.Error(d.Download(ctx, p, &bytes.Buffer{}), p)
+	}
+
+	entries, err := os.ReadDir(outsideDir)
+	require.NoError(err)
+	require.Len(entries, 1)
+
+	// Links inside the directory work
+	require.NoError(d.Upload(ctx, "ok/a/x.txt", 0, strings.NewReader("hello")))
+	data, err := os.ReadFile(filepath.Join(root, "inside", "a", "x.txt"))
+	require.NoError(err)
+	require.Equal("hello", string(data))
+}
+
+func TestDir_failedUpload(t *testing.T) {
+	require := require.New(t)
+
+	root := t.TempDir()
+	d := NewDir(root)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Error(d.Upload(ctx, "x.txt", 0, strings.NewReader("hello")))
+
+	// No partial or temporary files are left
+	entries, err := os.ReadDir(root)
+	require.NoError(err)
+	require.Empty(entries)
+}
+
+func TestClient_files(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	local := t.TempDir()
+	src := filepath.Join(local, "src.txt")
+	require.NoError(os.WriteFile(src, []byte("hello"), 0644))
+
+	c := NewClient(NewDir(t.TempDir()))
+	require.NoError(c.UploadFile(ctx, "remote.txt", src))
+
+	dst := filepath.Join(local, "out", "dst.txt")
+	require.NoError(c.DownloadFile(ctx, "remote.txt", dst))
+	data, err := os.ReadFile(dst)
+	require.NoError(err)
+	require.Equal("hello", string(data))
+
+	// A failed download doesn't leave a file behind
+	require.Error(c.DownloadFile(ctx, "nope.txt", dst+".nope"))
+	_, err = os.Stat(dst + ".nope")
+	require.True(os.IsNotExist(err))
+}
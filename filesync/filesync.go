@@ -0,0 +1,84 @@
+// Package filesync transfers files between plugins and the host, such as
+// uploading a build context to the host's data directory or downloading
+// manifests the host rendered. The files are streamed over the plugin
+// connection, so this works even when the plugin and the host don't share
+// a filesystem, such as with remote runners.
+//
+// Plugin functions request a *Client as an argument:
+//
+//	func (b *Builder) build(ctx context.Context, files *filesync.Client) (*Artifact, error) {
+//		return nil, files.UploadFile(ctx, "context.tar", "/tmp/context.tar")
+//	}
+//
+// The host provides the directory files are synced with as a Service,
+// usually a Dir.
+package filesync
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Service transfers files to and from a directory. Paths are
+// slash-separated and relative to the directory.
+type Service interface {
+	// Upload writes the data read from r to the file at path with the
+	// given permission bits, creating any parent directories.
+	Upload(ctx context.Context, path string, mode os.FileMode, r io.Reader) error
+
+	// Download writes the data of the file at path to w. If the file
+	// doesn't exist, the error matches os.ErrNotExist with errors.Is.
+	Download(ctx context.Context, path string, w io.Writer) error
+}
+
+// Client is the Service available to plugins with helpers for local
+// files.
+type Client struct {
+	Service
+}
+
+// NewClient returns a Client for s. This is used by the SDK, and by
+// plugins to test functions that take a Client with a Dir.
+func NewClient(s Service) *Client {
+	return &Client{Service: s}
+}
+
+// UploadFile uploads the local file at src to path, keeping its
+// permission bits.
+func (c *Client) UploadFile(ctx context.Context, path, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	return c.Upload(ctx, path, fi.Mode().Perm(), f)
+}
+
+// DownloadFile downloads the file at path to the local file dst, creating
+// any parent directories.
+func (c *Client) DownloadFile(ctx context.Context, path, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Download(ctx, path, f); err != nil {
+		f.Close()
+		os.Remove(dst)
+		return err
+	}
+
+	return f.Close()
+}
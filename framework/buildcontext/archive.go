@@ -0,0 +1,141 @@
+package buildcontext
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+)
+
+// Archive writes a tar archive of the build context to w and returns the
+// digest of the archive in "sha256:<hex>" form. Paths excluded by the
+// context's ignore file are not included. See ReadMatcher.
+//
+// Archives are deterministic: modification times, ownership, and other
+// metadata that varies between checkouts are cleared so that the digest
+// only changes when the names, modes, or contents of files change.
+func Archive(w io.Writer, bc *component.BuildContext) (string, error) {
+	if bc == nil || bc.Path == "" {
+		return "", fmt.Errorf("build context path must be set")
+	}
+
+	m, err := ReadMatcher(bc.Path)
+	if err != nil {
+		return "", err
+	}
+
+	return ArchiveWithMatcher(w, bc.Path, m)
+}
+
+// ArchiveWithMatcher is like Archive but uses the given matcher for the
+// directory dir instead of reading an ignore file.
+func ArchiveWithMatcher(w io.Writer, dir string, m *Matcher) (string, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	tw := tar.NewWriter(io.MultiWriter(w, h))
+
+	// WalkDir visits entries in lexical order which keeps the output stable.
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if m.Excluded(rel) {
+			// If nothing can be re-included then we can skip the whole
+			// directory. Otherwise we have to walk it to look for exceptions.
+			if d.IsDir() && !m.HasExceptions() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		return writeEntry(tw, p, rel, d)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := tw.Close(); err != nil {
+		return "", err
+	}
+
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func writeEntry(tw *tar.Writer, p, rel string, d fs.DirEntry) error {
+	info, err := d.Info()
+	if err != nil {
+		return err
+	}
+
+	var link string
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		link, err = os.Readlink(p)
+		if err != nil {
+			return err
+		}
+
+	case info.IsDir(), info.Mode().IsRegular():
+
+	default:
+		// Sockets, devices, and other special files can't be part of a
+		// build context.
+		return nil
+	}
+
+	hdr, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+
+	hdr.Name = rel
+	if info.IsDir() {
+		hdr.Name += "/"
+	}
+
+	// Clear everything that varies between machines or checkouts.
+	hdr.ModTime = time.Unix(0, 0)
+	hdr.AccessTime = time.Time{}
+	hdr.ChangeTime = time.Time{}
+	hdr.Uid, hdr.Gid = 0, 0
+	hdr.Uname, hdr.Gname = "", ""
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(tw, f)
+	return err
+}
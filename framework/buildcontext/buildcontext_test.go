@@ -0,0 +1,150 @@
+package buildcontext
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+)
+
+func TestMatcher(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Patterns []string
+		Path     string
+		Excluded bool
+	}{
+		{"no patterns", nil, "main.go", false},
+		{"exact", []string{"main.go"}, "main.go", true},
+		{"leading slash", []string{"/main.go"}, "main.go", true},
+		{"star", []string{"*.log"}, "debug.log", true},
+		{"star no separator", []string{"*.log"}, "logs/debug.log", false},
+		{"double star", []string{"**/*.log"}, "logs/debug.log", true},
+		{"double star root", []string{"**/*.log"}, "debug.log", true},
+		{"parent dir", []string{"node_modules"}, "node_modules/a/b.js", true},
+		{"question", []string{"file?.txt"}, "file1.txt", true},
+		{"class", []string{"file[0-9].txt"}, "filea.txt", false},
+		{"comment", []string{"# main.go"}, "main.go", false},
+		{"exception", []string{"*.md", "!README.md"}, "README.md", false},
+		{"exception order", []string{"!README.md", "*.md"}, "README.md", true},
+		{"exception in dir", []string{"docs", "!docs/keep.md"}, "docs/keep.md", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			m, err := NewMatcher(tt.Patterns)
+			require.NoError(err)
+			require.Equal(tt.Excluded, m.Excluded(tt.Path))
+		})
+	}
+}
+
+func TestMatcher_invalid(t *testing.T) {
+	_, err := NewMatcher([]string{"!"})
+	require.Error(t, err)
+
+	_, err = NewMatcher([]string{"file[0-9"})
+	require.Error(t, err)
+}
+
+func TestArchive(t *testing.T) {
+	require := require.New(t)
+
+	dir := testContext(t, map[string]string{
+		"main.go":            "package main",
+		"README.md":          "hello",
+		"docs/guide.md":      "guide",
+		"node_modules/a.js":  "a",
+		".dockerignore":      "*.md\n",
+		".waypointignore":    "node_modules\ndocs\n",
+		"build/out/artifact": "binary",
+	})
+
+	var buf bytes.Buffer
+	digest, err := Archive(&buf, &component.BuildContext{Path: dir})
+	require.NoError(err)
+	require.Contains(digest, "sha256:")
+
+	// .waypointignore takes precedence over .dockerignore
+	require.Equal([]string{
+		".dockerignore",
+		".waypointignore",
+		"README.md",
+		"build/",
+		"build/out/",
+		"build/out/artifact",
+		"main.go",
+	}, testNames(t, &buf))
+
+	// Archives are deterministic, even if times change.
+	now := time.Now().Add(time.Hour)
+	require.NoError(os.Chtimes(filepath.Join(dir, "main.go"), now, now))
+	var again bytes.Buffer
+	digest2, err := Archive(&again, &component.BuildContext{Path: dir})
+	require.NoError(err)
+	require.Equal(digest, digest2)
+
+	// But changing content changes the digest.
+	require.NoError(os.WriteFile(filepath.Join(dir, "main.go"), []byte("package foo"), 0644))
+	digest3, err := Archive(io.Discard, &component.BuildContext{Path: dir})
+	require.NoError(err)
+	require.NotEqual(digest, digest3)
+}
+
+func TestArchive_exceptions(t *testing.T) {
+	require := require.New(t)
+
+	dir := testContext(t, map[string]string{
+		"docs/guide.md": "guide",
+		"docs/keep.md":  "keep",
+		"main.go":       "package main",
+	})
+
+	m, err := NewMatcher([]string{"docs", "!docs/keep.md"})
+	require.NoError(err)
+
+	var buf bytes.Buffer
+	_, err = ArchiveWithMatcher(&buf, dir, m)
+	require.NoError(err)
+	require.Equal([]string{"docs/keep.md", "main.go"}, testNames(t, &buf))
+}
+
+func TestArchive_noPath(t *testing.T) {
+	_, err := Archive(io.Discard, &component.BuildContext{})
+	require.Error(t, err)
+}
+
+func testContext(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, contents := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(contents), 0644))
+	}
+
+	return dir
+}
+
+func testNames(t *testing.T, r io.Reader) []string {
+	var result []string
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		result = append(result, hdr.Name)
+	}
+
+	return result
+}
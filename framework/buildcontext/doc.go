@@ -0,0 +1,10 @@
+// Package buildcontext contains helpers for builder plugins to archive the
+// local build context so it can be sent to a remote or on-demand build
+// environment.
+//
+// The archive honors the first ignore file found in the context directory,
+// checking .waypointignore before .dockerignore. Ignore files use the same
+// syntax as .dockerignore. The archive is deterministic: the same files with
+// the same contents always produce the same bytes, so the returned digest
+// can be used to detect unchanged contexts and skip uploads.
+package buildcontext
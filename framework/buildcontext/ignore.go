@@ -0,0 +1,198 @@
+package buildcontext
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFiles are the ignore files that are checked in the root of the
+// build context, in order. Only the first one that exists is used.
+var IgnoreFiles = []string{".waypointignore", ".dockerignore"}
+
+// Matcher decides whether paths in the build context are excluded. The
+// zero value excludes nothing.
+type Matcher struct {
+	patterns   []*pattern
+	exceptions bool
+}
+
+type pattern struct {
+	raw    string
+	re     *regexp.Regexp
+	negate bool
+}
+
+// NewMatcher returns a Matcher for the given patterns using .dockerignore
+// semantics. Patterns are matched against slash-separated paths relative to
+// the root of the context. A pattern that starts with "!" re-includes paths
+// excluded by an earlier pattern, and the last matching pattern wins.
+func NewMatcher(patterns []string) (*Matcher, error) {
+	var m Matcher
+	for _, raw := range patterns {
+		p := strings.TrimSpace(raw)
+		if p == "" || strings.HasPrefix(p, "#") {
+			continue
+		}
+
+		negate := false
+		if p[0] == '!' {
+			negate = true
+			p = strings.TrimSpace(p[1:])
+			if p == "" {
+				return nil, fmt.Errorf("invalid ignore pattern %q: \"!\" must be followed by a pattern", raw)
+			}
+		}
+
+		p = path.Clean(filepath.ToSlash(p))
+		p = strings.TrimPrefix(p, "/")
+		if p == "." || p == "" {
+			continue
+		}
+
+		re, err := patternRegexp(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern %q: %w", raw, err)
+		}
+
+		m.patterns = append(m.patterns, &pattern{raw: raw, re: re, negate: negate})
+		if negate {
+			m.exceptions = true
+		}
+	}
+
+	return &m, nil
+}
+
+// ReadMatcher reads the first ignore file in IgnoreFiles that exists in
+// dir and returns a Matcher for it. If none of the files exist, this
+// returns a Matcher that excludes nothing.
+func ReadMatcher(dir string) (*Matcher, error) {
+	for _, name := range IgnoreFiles {
+		f, err := os.Open(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		patterns, err := readPatterns(f)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", name, err)
+		}
+
+		return NewMatcher(patterns)
+	}
+
+	return &Matcher{}, nil
+}
+
+// Excluded returns true if the given slash-separated path, relative to the
+// root of the context, is excluded. A path is also excluded if one of its
+// parent directories matches a pattern.
+func (m *Matcher) Excluded(p string) bool {
+	p = strings.TrimPrefix(path.Clean(p), "/")
+
+	excluded := false
+	for _, pat := range m.patterns {
+		if pat.matches(p) {
+			excluded = !pat.negate
+		}
+	}
+
+	return excluded
+}
+
+// HasExceptions returns true if any pattern re-includes paths with "!".
+// When this is false an excluded directory can be skipped entirely.
+func (m *Matcher) HasExceptions() bool {
+	return m.exceptions
+}
+
+// matches returns true if the pattern matches the path or any of its
+// parent directories.
+func (p *pattern) matches(target string) bool {
+	for {
+		if p.re.MatchString(target) {
+			return true
+		}
+
+		idx := strings.LastIndex(target, "/")
+		if idx < 0 {
+			return false
+		}
+		target = target[:idx]
+	}
+}
+
+// patternRegexp converts a cleaned ignore pattern to an anchored regular
+// expression. "**" matches any number of directories, "*" and "?" never
+// match a separator, and character classes are passed through.
+func patternRegexp(p string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch c {
+		case '*':
+			if i+1 < len(p) && p[i+1] == '*' {
+				i++
+
+				// "**/" also matches no directories at all.
+				if i+1 < len(p) && p[i+1] == '/' {
+					i++
+					sb.WriteString("(.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+				continue
+			}
+			sb.WriteString("[^/]*")
+
+		case '?':
+			sb.WriteString("[^/]")
+
+		case '[':
+			end := strings.IndexByte(p[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated character class")
+			}
+			class := p[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end
+
+		case '\\':
+			if i+1 < len(p) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(string(p[i])))
+			}
+
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+func readPatterns(r io.Reader) ([]string, error) {
+	var result []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		result = append(result, scanner.Text())
+	}
+
+	return result, scanner.Err()
+}
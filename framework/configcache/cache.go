@@ -0,0 +1,262 @@
+package configcache
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// ReadFunc reads the values for the given requests. The result must have
+// exactly one value for each request, in the same order.
+type ReadFunc func([]*component.ConfigRequest) ([]*pb.ConfigSource_Value, error)
+
+// Cache caches config values until their TTL expires. The zero value is
+// ready to use. A Cache is safe for concurrent use.
+type Cache struct {
+	// DefaultTTL is the TTL used for values that don't set one. If this
+	// is zero, values without a TTL are not cached.
+	DefaultTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	calls   map[string]*call
+
+	// now is used for tests
+	now func() time.Time
+}
+
+type entry struct {
+	value   *pb.ConfigSource_Value
+	expires time.Time
+}
+
+// call is a read of a value that is in progress. Concurrent reads of the
+// same value wait for it rather than reading the value again.
+type call struct {
+	done  chan struct{}
+	value *pb.ConfigSource_Value
+	err   error
+}
+
+// errReadIncomplete is the error of the reads that waited on a ReadFunc
+// that panicked.
+var errReadIncomplete = errors.New("config read did not complete")
+
+// Read returns the values for the given requests. Any values that are
+// cached and not expired are returned from the cache and f is called once
+// with the remaining requests. Values that f returns with a TTL (or all
+// successful values if DefaultTTL is set) are cached. Values with an error
+// are never cached.
+//
+// If another Read is already reading a value, this waits for its result
+// rather than reading the value again. f is called without holding any
+// lock, so reads of different values don't wait for each other, but f
+// must not read the values it was called for from the same Cache. The
+// values returned are copies that the caller may modify.
+func (c *Cache) Read(
+	reqs []*component.ConfigRequest,
+	f ReadFunc,
+) ([]*pb.ConfigSource_Value, error) {
+	c.mu.Lock()
+	now := c.timeNow()
+	result := make([]*pb.ConfigSource_Value, len(reqs))
+
+	// Find the values we have to read and the values that are being read
+	var missing []*component.ConfigRequest
+	var missingIdx []int
+	var calls []*call
+	waiting := map[int]*call{}
+	for i, req := range reqs {
+		k := key(req)
+		if e, ok := c.entries[k]; ok && now.Before(e.expires) {
+			v := proto.Clone(e.value).(*pb.ConfigSource_Value)
+			v.Ttl = durationpb.New(e.expires.Sub(now))
+			result[i] = v
+			continue
+		}
+
+		if cl, ok := c.calls[k]; ok {
+			waiting[i] = cl
+			continue
+		}
+
+		cl := &call{done: make(chan struct{})}
+		if c.calls == nil {
+			c.calls = map[string]*call{}
+		}
+		c.calls[k] = cl
+
+		missing = append(missing, req)
+		missingIdx = append(missingIdx, i)
+		calls = append(calls, cl)
+	}
+	c.mu.Unlock()
+
+	if len(missing) > 0 {
+		values, err := c.read(now, missing, calls, f)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, v := range values {
+			if i >= len(missing) {
+				break
+			}
+
+			result[missingIdx[i]] = v
+		}
+	}
+
+	for i, cl := range waiting {
+		<-cl.done
+		if cl.err != nil {
+			return nil, cl.err
+		}
+		if cl.value != nil {
+			result[i] = proto.Clone(cl.value).(*pb.ConfigSource_Value)
+		}
+	}
+
+	return result, nil
+}
+
+// read calls f for the requests and completes their calls, caching the
+// values that have a TTL. The calls are completed even if f panics so that
+// other reads don't wait forever.
+func (c *Cache) read(
+	now time.Time,
+	reqs []*component.ConfigRequest,
+	calls []*call,
+	f ReadFunc,
+) (values []*pb.ConfigSource_Value, err error) {
+	completed := false
+	defer func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		for i, req := range reqs {
+			k := key(req)
+			cl := calls[i]
+
+			// The call is no longer registered if the cache was purged,
+			// in which case the value is returned but not cached.
+			current := c.calls[k] == cl
+			if current {
+				delete(c.calls, k)
+			}
+
+			switch {
+			case !completed:
+				cl.err = errReadIncomplete
+			case err != nil:
+				cl.err = err
+			case i < len(values) && values[i] != nil:
+				cl.value = proto.Clone(values[i]).(*pb.ConfigSource_Value)
+			}
+			close(cl.done)
+
+			if cl.value == nil || !current {
+				continue
+			}
+
+			ttl := c.ttl(cl.value)
+			if ttl <= 0 {
+				continue
+			}
+
+			if c.entries == nil {
+				c.entries = map[string]*entry{}
+			}
+
+			c.entries[k] = &entry{
+				value:   cl.value,
+				expires: now.Add(ttl),
+			}
+		}
+	}()
+
+	values, err = f(reqs)
+	completed = true
+	return values, err
+}
+
+// NextExpiry returns the earliest time any cached value expires. This can
+// be used by a WatchFunc to know when to read again. This returns the zero
+// time if nothing is cached.
+func (c *Cache) NextExpiry() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var result time.Time
+	for _, e := range c.entries {
+		if result.IsZero() || e.expires.Before(result) {
+			result = e.expires
+		}
+	}
+
+	return result
+}
+
+// Purge removes all values from the cache. This should be called from the
+// ConfigSourcer StopFunc since the config may change after a stop. Values
+// that are being read when this is called are not cached.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+	c.calls = nil
+}
+
+// ttl returns how long v should be cached for.
+func (c *Cache) ttl(v *pb.ConfigSource_Value) time.Duration {
+	if v == nil {
+		return 0
+	}
+
+	if _, ok := v.Result.(*pb.ConfigSource_Value_Error); ok {
+		return 0
+	}
+
+	if v.Ttl != nil {
+		return v.Ttl.AsDuration()
+	}
+
+	return c.DefaultTTL
+}
+
+func (c *Cache) timeNow() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+
+	return time.Now()
+}
+
+// key returns a unique key for a request. Requests with the same name but
+// different configuration are cached separately.
+func key(req *component.ConfigRequest) string {
+	keys := make([]string, 0, len(req.Config))
+	for k := range req.Config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(req.Name)
+	for _, k := range keys {
+		b.WriteByte(0)
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(req.Config[k])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,203 @@
+package configcache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+func TestCache(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	c := &Cache{now: func() time.Time { return now }}
+
+	var calls [][]string
+	read := func(reqs []*component.ConfigRequest) ([]*pb.ConfigSource_Value, error) {
+		var names []string
+		var result []*pb.ConfigSource_Value
+		for _, req := range reqs {
+			names = append(names, req.Name)
+
+			v := &pb.ConfigSource_Value{
+				Name:   req.Name,
+				Result: &pb.ConfigSource_Value_Value{Value: req.Name},
+			}
+			switch req.Name {
+			case "short":
+				v.Ttl = durationpb.New(time.Minute)
+			case "long":
+				v.Ttl = durationpb.New(time.Hour)
+			case "error":
+				v.Ttl = durationpb.New(time.Hour)
+				v.Result = &pb.ConfigSource_Value_Error{Error: &status.Status{Message: "bad"}}
+			}
+
+			result = append(result, v)
+		}
+
+		calls = append(calls, names)
+		return result, nil
+	}
+
+	reqs := []*component.ConfigRequest{
+		{Name: "short"},
+		{Name: "long"},
+		{Name: "none"},
+		{Name: "error"},
+	}
+
+	// First read gets everything
+	values, err := c.Read(reqs, read)
+	require.NoError(err)
+	require.Len(values, 4)
+	require.Equal([]string{"short", "long", "none", "error"}, calls[0])
+	require.Equal(now.Add(time.Minute), c.NextExpiry())
+
+	// Second read only gets the uncached values
+	now = now.Add(30 * time.Second)
+	values, err = c.Read(reqs, read)
+	require.NoError(err)
+	require.Len(values, 4)
+	require.Equal([]string{"none", "error"}, calls[1])
+	for i, v := range values {
+		require.Equal(reqs[i].Name, v.Name)
+	}
+	require.Equal(30*time.Second, values[0].Ttl.AsDuration())
+
+	// After the short TTL it is read again
+	now = now.Add(time.Minute)
+	_, err = c.Read(reqs, read)
+	require.NoError(err)
+	require.Equal([]string{"short", "none", "error"}, calls[2])
+
+	// Purging clears everything
+	c.Purge()
+	require.True(c.NextExpiry().IsZero())
+	_, err = c.Read(reqs, read)
+	require.NoError(err)
+	require.Equal([]string{"short", "long", "none", "error"}, calls[3])
+}
+
+func TestCache_defaultTTL(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	c := &Cache{DefaultTTL: time.Minute, now: func() time.Time { return now }}
+
+	var count int
+	read := func(reqs []*component.ConfigRequest) ([]*pb.ConfigSource_Value, error) {
+		count++
+		return []*pb.ConfigSource_Value{{Name: reqs[0].Name}}, nil
+	}
+
+	reqs := []*component.ConfigRequest{{Name: "foo", Config: map[string]string{"a": "b"}}}
+	_, err := c.Read(reqs, read)
+	require.NoError(err)
+	_, err = c.Read(reqs, read)
+	require.NoError(err)
+	require.Equal(1, count)
+
+	// Different config is a different value
+	reqs = []*component.ConfigRequest{{Name: "foo", Config: map[string]string{"a": "c"}}}
+	_, err = c.Read(reqs, read)
+	require.NoError(err)
+	require.Equal(2, count)
+}
+
+func TestCache_copies(t *testing.T) {
+	require := require.New(t)
+
+	c := &Cache{DefaultTTL: time.Minute}
+	read := func(reqs []*component.ConfigRequest) ([]*pb.ConfigSource_Value, error) {
+		return []*pb.ConfigSource_Value{{
+			Name:   reqs[0].Name,
+			Result: &pb.ConfigSource_Value_Value{Value: "secret"},
+		}}, nil
+	}
+
+	// Modifying the values returned doesn't modify the cache
+	reqs := []*component.ConfigRequest{{Name: "foo"}}
+	values, err := c.Read(reqs, read)
+	require.NoError(err)
+	values[0].Result = &pb.ConfigSource_Value_Value{Value: "changed"}
+
+	values, err = c.Read(reqs, read)
+	require.NoError(err)
+	require.Equal("secret", values[0].GetValue())
+	values[0].Result = &pb.ConfigSource_Value_Value{Value: "changed"}
+
+	values, err = c.Read(reqs, read)
+	require.NoError(err)
+	require.Equal("secret", values[0].GetValue())
+}
+
+func TestCache_concurrent(t *testing.T) {
+	require := require.New(t)
+
+	c := &Cache{DefaultTTL: time.Minute}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var calls []string
+	read := func(reqs []*component.ConfigRequest) ([]*pb.ConfigSource_Value, error) {
+		mu.Lock()
+		calls = append(calls, reqs[0].Name)
+		mu.Unlock()
+
+		if reqs[0].Name == "slow" {
+			close(started)
+			<-release
+		}
+
+		return []*pb.ConfigSource_Value{{
+			Name:   reqs[0].Name,
+			Result: &pb.ConfigSource_Value_Value{Value: reqs[0].Name},
+		}}, nil
+	}
+
+	type result struct {
+		values []*pb.ConfigSource_Value
+		err    error
+	}
+	slow := []*component.ConfigRequest{{Name: "slow"}}
+	results := make(chan result, 2)
+	readSlow := func() {
+		values, err := c.Read(slow, read)
+		results <- result{values, err}
+	}
+
+	go readSlow()
+	<-started
+
+	// Other values are read while the slow value is being read
+	values, err := c.Read([]*component.ConfigRequest{{Name: "fast"}}, read)
+	require.NoError(err)
+	require.Equal("fast", values[0].GetValue())
+
+	// Reads of the slow value wait for the read in progress
+	go readSlow()
+	close(release)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case r := <-results:
+			require.NoError(r.err)
+			require.Equal("slow", r.values[0].GetValue())
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for read")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal([]string{"slow", "fast"}, calls)
+}
@@ -0,0 +1,9 @@
+// Package configcache contains helpers for ConfigSourcer plugins to cache
+// values that are expensive to read, such as dynamic credentials, for as long
+// as they're valid.
+//
+// Values specify how long they're valid for with the Ttl field. The cache
+// only calls the underlying read function for values that are missing or
+// expired, so sourcers don't need to manage background goroutines or globals
+// to avoid reading on every call from the entrypoint.
+package configcache
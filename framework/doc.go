@@ -2,7 +2,8 @@
 // plugins. The framework is split into sub-packages for specific functionality,
 // whereas this root package contains the highest-level functionality.
 //
-// Note: the root package is still a work-in-progress. For now, please use the
-// sub-packages. We are building the building blocks for this package first
-// via the sub-packages.
+// Plugin builds a complete platform plugin from the resources of a
+// resource.Manager: deploy, destroy, status, configuration and
+// documentation are all generated. Plugins that need more control can use
+// the sub-packages directly.
 package framework
@@ -0,0 +1,9 @@
+// Package multitarget contains helpers for plugins that deploy the same
+// resources to several targets, such as regions or clusters, at once.
+//
+// A Manager creates one resource.Manager per target and fans lifecycle
+// operations out across them in parallel. The declared resources, status
+// reports and state of every target are combined so that the plugin can
+// return them as if they came from a single resource manager. Resource
+// names are labeled with the name of their target, see ResourceName.
+package multitarget
@@ -0,0 +1,578 @@
+package multitarget
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/opaqueany"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/framework/resource"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// Target is a single place that resources are deployed to, such as a
+// region or cluster.
+type Target struct {
+	// Name uniquely identifies the target, such as "us-east-1". This is
+	// used to label resources and to key the state so it must not change
+	// between operations.
+	Name string
+
+	// Config is any plugin-specific configuration for the target, such as
+	// the credentials or endpoint to use. This is not stored in the state,
+	// so it is nil for targets that were removed, see Manager.LoadState.
+	Config interface{}
+}
+
+// ManagerFunc returns the options for the resource manager of a target.
+// This is usually the same set of resources for every target; the create,
+// destroy and status functions of the resources can accept a *Target
+// argument to act on the right target.
+type ManagerFunc func(t *Target) []resource.ManagerOption
+
+// Manager manages the resources of several targets. Every target has its
+// own resource.Manager and lifecycle operations run on all targets in
+// parallel.
+//
+// Create a Manager with NewManager and a set of options.
+type Manager struct {
+	targets  []*Target
+	removed  []*Target
+	f        ManagerFunc
+	managers map[string]*resource.Manager
+	dcrs     map[string]*component.DeclaredResourcesResp
+	dtrs     map[string]*component.DestroyedResourcesResp
+	logger   hclog.Logger
+	dcr      *component.DeclaredResourcesResp
+	dtr      *component.DestroyedResourcesResp
+}
+
+// NewManager creates a new manager for the given targets. f is called once
+// per target to configure the resource manager of that target.
+//
+// Callers should call Validate on the result to check for errors.
+func NewManager(targets []*Target, f ManagerFunc, opts ...ManagerOption) *Manager {
+	m := &Manager{
+		targets:  targets,
+		f:        f,
+		managers: map[string]*resource.Manager{},
+		dcrs:     map[string]*component.DeclaredResourcesResp{},
+		dtrs:     map[string]*component.DestroyedResourcesResp{},
+		logger:   hclog.L(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	for _, t := range targets {
+		m.addManager(t)
+	}
+
+	return m
+}
+
+// addManager creates the resource manager of the target t.
+func (m *Manager) addManager(t *Target) {
+	// Each target declares its resources to its own response so we
+	// can label them before adding them to the combined response.
+	dcr := &component.DeclaredResourcesResp{}
+	dtr := &component.DestroyedResourcesResp{}
+	m.dcrs[t.Name] = dcr
+	m.dtrs[t.Name] = dtr
+
+	// Our options are first so that f can override the logger.
+	managerOpts := []resource.ManagerOption{
+		resource.WithLogger(m.logger.With("target", t.Name)),
+	}
+	managerOpts = append(managerOpts, m.f(t)...)
+	managerOpts = append(managerOpts,
+		resource.WithDeclaredResourcesResp(dcr),
+		resource.WithDestroyedResourcesResp(dtr),
+	)
+
+	m.managers[t.Name] = resource.NewManager(managerOpts...)
+}
+
+// Validate checks that the targets are named uniquely and that the
+// resource manager of every target is configured correctly. This will
+// always be called prior to any lifecycle operation.
+func (m *Manager) Validate() error {
+	var result error
+
+	seen := map[string]struct{}{}
+	for _, t := range m.targets {
+		if t.Name == "" {
+			result = multierror.Append(result, fmt.Errorf("target name must be set"))
+			continue
+		}
+		if _, ok := seen[t.Name]; ok {
+			result = multierror.Append(result, fmt.Errorf("duplicate target %q", t.Name))
+			continue
+		}
+		seen[t.Name] = struct{}{}
+
+		if err := m.managers[t.Name].Validate(); err != nil {
+			result = multierror.Append(result, multierror.Prefix(err, t.Name+": "))
+		}
+	}
+
+	return result
+}
+
+// Manager returns the resource manager of the target with the given name.
+// This will return nil if the target is not known.
+func (m *Manager) Manager(target string) *resource.Manager {
+	return m.managers[target]
+}
+
+// LoadState loads the serialized state from Proto. Targets that aren't in
+// the state are left as-is.
+//
+// If the state contains a target that this manager doesn't have, because
+// it was removed from the configuration, its state is kept so that its
+// resources aren't orphaned. They are destroyed by the next DestroyAll, or
+// after the next successful CreateAll. The resource manager of a removed
+// target is created with a *Target that only has the Name set.
+func (m *Manager) LoadState(v *opaqueany.Any) error {
+	var s pb.Framework_MultiTargetState
+	if err := component.ProtoAnyUnmarshal(v, &s); err != nil {
+		return err
+	}
+
+	// Sort the names so removed targets are in a stable order.
+	names := make([]string, 0, len(s.Targets))
+	for name := range s.Targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		state := s.Targets[name]
+		rm, ok := m.managers[name]
+		if !ok {
+			m.logger.Warn("state contains a target that is no longer configured, "+
+				"its resources will be destroyed", "target", name)
+
+			t := &Target{Name: name}
+			m.addManager(t)
+			m.removed = append(m.removed, t)
+			rm = m.managers[name]
+		}
+
+		if err := rm.LoadState(state); err != nil {
+			return fmt.Errorf("failed to deserialize state of target %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// State returns the serialized state for this manager, which contains the
+// state of the resource manager of every target. Like
+// resource.Manager.State, this is an opaque type that plugin authors should
+// serialize directly into their return values.
+func (m *Manager) State() *opaqueany.Any {
+	result := pb.Framework_MultiTargetState{
+		Targets: map[string]*opaqueany.Any{},
+	}
+	for name, rm := range m.managers {
+		result.Targets[name] = rm.State()
+	}
+
+	v, err := component.ProtoAny(&result)
+	if err != nil {
+		// This should never happen, see resource.Manager.State.
+		panic(err)
+	}
+
+	return v
+}
+
+// CreateAll creates the resources of all targets in parallel. The args,
+// along with the *Target being created, are available to the create
+// functions.
+//
+// If any target fails, the targets that were created successfully are
+// destroyed again so that the result is all or nothing. The resource
+// manager of a failed target already rolls itself back.
+func (m *Manager) CreateAll(args ...interface{}) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	errs := m.each(func(t *Target, rm *resource.Manager) error {
+		return rm.CreateAll(withTarget(args, t)...)
+	})
+
+	var resultErr error
+	for _, err := range errs {
+		if err != nil {
+			resultErr = multierror.Append(resultErr, err)
+		}
+	}
+
+	if resultErr != nil {
+		m.logger.Info("error during creation, starting rollback of other targets", "err", resultErr)
+
+		var rollbackErr error
+		for _, err := range m.eachOf(succeeded(m.targets, errs), func(t *Target, rm *resource.Manager) error {
+			return rm.DestroyAll(withTarget(args, t)...)
+		}) {
+			if err != nil {
+				rollbackErr = multierror.Append(rollbackErr, err)
+			}
+		}
+
+		if rollbackErr != nil {
+			m.logger.Warn("error during rollback", "err", rollbackErr)
+			resultErr = multierror.Append(resultErr, fmt.Errorf(
+				"Error during rollback: %w", rollbackErr))
+		} else {
+			m.logger.Info("rollback successful")
+		}
+	}
+
+	// The targets that were removed are only destroyed once the
+	// configured targets exist. Failing to destroy them doesn't fail the
+	// operation since their state is kept to try again later.
+	if resultErr == nil {
+		if err := m.destroyRemoved(args); err != nil {
+			m.logger.Warn("error destroying removed targets", "err", err)
+		}
+	}
+
+	if m.dcr != nil {
+		for _, t := range m.targets {
+			for _, r := range m.dcrs[t.Name].DeclaredResources {
+				r.Name = ResourceName(t.Name, r.Name)
+				m.dcr.DeclaredResources = append(m.dcr.DeclaredResources, r)
+			}
+			m.dcrs[t.Name].DeclaredResources = nil
+		}
+	}
+	m.collectDestroyed()
+
+	return resultErr
+}
+
+// DestroyAll destroys the resources of all targets in parallel, including
+// the targets that were removed, see LoadState. The args, along with the
+// *Target being destroyed, are available to the destroy functions. Every
+// target is destroyed even if another fails.
+func (m *Manager) DestroyAll(args ...interface{}) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	var result error
+	for _, err := range m.each(func(t *Target, rm *resource.Manager) error {
+		return rm.DestroyAll(withTarget(args, t)...)
+	}) {
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	if err := m.destroyRemoved(args); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	m.collectDestroyed()
+
+	return result
+}
+
+// destroyRemoved destroys the resources of the targets that were removed
+// in parallel. The targets that are destroyed successfully are forgotten,
+// the others are kept so their state is still available.
+func (m *Manager) destroyRemoved(args []interface{}) error {
+	if len(m.removed) == 0 {
+		return nil
+	}
+
+	var result error
+	var remaining []*Target
+	errs := m.eachOf(m.removed, func(t *Target, rm *resource.Manager) error {
+		return rm.DestroyAll(withTarget(args, t)...)
+	})
+	for i, t := range m.removed {
+		if errs[i] != nil {
+			result = multierror.Append(result, errs[i])
+			remaining = append(remaining, t)
+			continue
+		}
+
+		// Collect the destroyed resources of the target now, since the
+		// target is forgotten along with its now empty state.
+		m.collectDestroyedOf(t)
+		delete(m.managers, t.Name)
+		delete(m.dcrs, t.Name)
+		delete(m.dtrs, t.Name)
+	}
+	m.removed = remaining
+
+	return result
+}
+
+// StatusAll returns the status of the resources of all targets, queried in
+// parallel. The args, along with the *Target being queried, are available
+// to the status functions. The reports are in the order of the targets and
+// their names are labeled with the target name, see ResourceName.
+func (m *Manager) StatusAll(args ...interface{}) ([]*pb.StatusReport_Resource, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	reports := make([][]*pb.StatusReport_Resource, len(m.targets))
+	var result error
+	for _, err := range m.each(func(t *Target, rm *resource.Manager) error {
+		rs, err := rm.StatusAll(withTarget(args, t)...)
+		if err != nil {
+			return err
+		}
+
+		reports[m.index(t)] = rs
+		return nil
+	}) {
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	if result != nil {
+		return nil, result
+	}
+
+	var resources []*pb.StatusReport_Resource
+	for i, t := range m.targets {
+		resources = append(resources, labelStatus(t.Name, reports[i])...)
+	}
+
+	return resources, nil
+}
+
+// StatusReport returns a report of the resources of all targets. If every
+// target reports the same health, the overall health is that health.
+// Otherwise the overall health is PARTIAL and the health message lists the
+// health of each target. Like resource.Manager.StatusReport, plugins may
+// modify the report before returning it.
+//
+// If one of the args is a *component.StatusFilter, it is applied to the
+// combined report rather than the report of each target, so pages span
+// targets.
+func (m *Manager) StatusReport(args ...interface{}) (*pb.StatusReport, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	filter := resource.StatusFilterArg(args)
+	targetArgs := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		if _, ok := arg.(*component.StatusFilter); !ok {
+			targetArgs = append(targetArgs, arg)
+		}
+	}
+
+	reports := make([]*pb.StatusReport, len(m.targets))
+	var result error
+	for _, err := range m.each(func(t *Target, rm *resource.Manager) error {
+		r, err := rm.StatusReport(withTarget(targetArgs, t)...)
+		if err != nil {
+			return err
+		}
+
+		reports[m.index(t)] = r
+		return nil
+	}) {
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	if result != nil {
+		return nil, fmt.Errorf("failed generating target statuses: %s", result)
+	}
+
+	var resources []*pb.StatusReport_Resource
+	for i, t := range m.targets {
+		resources = append(resources, labelStatus(t.Name, reports[i].Resources)...)
+	}
+
+	health, healthMessage := m.healthSummary(reports)
+	report := &pb.StatusReport{
+		External:      true,
+		GeneratedTime: timestamppb.Now(),
+		Resources:     resources,
+		Health:        health,
+		HealthMessage: healthMessage,
+	}
+	if err := filter.Apply(report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// healthSummary returns the overall health of the reports, which are in the
+// order of the targets.
+func (m *Manager) healthSummary(reports []*pb.StatusReport) (pb.StatusReport_Health, string) {
+	if len(reports) == 0 {
+		return pb.StatusReport_UNKNOWN, "No targets"
+	}
+
+	same := true
+	for _, r := range reports[1:] {
+		if r.Health != reports[0].Health {
+			same = false
+			break
+		}
+	}
+	if same {
+		return reports[0].Health, fmt.Sprintf(
+			"All %d targets are reporting %s", len(reports), reports[0].Health)
+	}
+
+	parts := make([]string, len(reports))
+	for i, r := range reports {
+		parts[i] = fmt.Sprintf("%s: %s", m.targets[i].Name, r.Health)
+	}
+
+	return pb.StatusReport_PARTIAL, strings.Join(parts, ", ")
+}
+
+// collectDestroyed moves the destroyed resources of every target to the
+// combined response, if there is one.
+func (m *Manager) collectDestroyed() {
+	if m.dtr == nil {
+		return
+	}
+
+	for _, t := range m.targets {
+		m.collectDestroyedOf(t)
+	}
+	for _, t := range m.removed {
+		m.collectDestroyedOf(t)
+	}
+}
+
+// collectDestroyedOf moves the destroyed resources of the target t to the
+// combined response, if there is one.
+func (m *Manager) collectDestroyedOf(t *Target) {
+	if m.dtr == nil {
+		return
+	}
+
+	for _, r := range m.dtrs[t.Name].DestroyedResources {
+		r.Name = ResourceName(t.Name, r.Name)
+		m.dtr.DestroyedResources = append(m.dtr.DestroyedResources, r)
+	}
+	m.dtrs[t.Name].DestroyedResources = nil
+}
+
+// each calls f for every target in parallel and returns the errors in the
+// order of the targets. Errors are prefixed with the target name.
+func (m *Manager) each(f func(*Target, *resource.Manager) error) []error {
+	return m.eachOf(m.targets, f)
+}
+
+func (m *Manager) eachOf(targets []*Target, f func(*Target, *resource.Manager) error) []error {
+	errs := make([]error, len(targets))
+
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func(i int, t *Target) {
+			defer wg.Done()
+			if err := f(t, m.managers[t.Name]); err != nil {
+				errs[i] = multierror.Prefix(err, t.Name+": ")
+			}
+		}(i, t)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// index returns the index of t in the targets.
+func (m *Manager) index(t *Target) int {
+	for i, v := range m.targets {
+		if v == t {
+			return i
+		}
+	}
+
+	panic("unknown target " + t.Name)
+}
+
+// withTarget returns a copy of args with t added. The args are copied
+// since they are shared by the calls for every target.
+func withTarget(args []interface{}, t *Target) []interface{} {
+	result := make([]interface{}, 0, len(args)+1)
+	result = append(result, args...)
+	return append(result, t)
+}
+
+// succeeded returns the targets that have no error.
+func succeeded(targets []*Target, errs []error) []*Target {
+	var result []*Target
+	for i, t := range targets {
+		if errs[i] == nil {
+			result = append(result, t)
+		}
+	}
+
+	return result
+}
+
+// labelStatus labels the names of the reports and the declared resources
+// they reference with the target name.
+func labelStatus(target string, reports []*pb.StatusReport_Resource) []*pb.StatusReport_Resource {
+	for _, r := range reports {
+		r.Name = ResourceName(target, r.Name)
+		if r.DeclaredResource != nil {
+			r.DeclaredResource.Name = ResourceName(target, r.DeclaredResource.Name)
+		}
+	}
+
+	return reports
+}
+
+// ResourceName returns the name that the resource with the given name in
+// the given target has in the declared resources, destroyed resources and
+// status reports of a Manager, such as "us-east-1/service".
+func ResourceName(target, name string) string {
+	return target + "/" + name
+}
+
+// ManagerOption is used to configure NewManager.
+type ManagerOption func(*Manager)
+
+// WithLogger specifies the logger to use. The resource manager of every
+// target uses this logger with the target name added. If this is not set
+// then this will use the default hclog logger.
+func WithLogger(l hclog.Logger) ManagerOption {
+	return func(m *Manager) {
+		m.logger = l
+	}
+}
+
+// WithDeclaredResourcesResp specifies a declared resource response that
+// the Manager will populate with the labeled declared resources of every
+// target after creating resources.
+func WithDeclaredResourcesResp(dcr *component.DeclaredResourcesResp) ManagerOption {
+	return func(m *Manager) {
+		m.dcr = dcr
+	}
+}
+
+// WithDestroyedResourcesResp specifies a destroyed resource response that
+// the Manager will populate with the labeled destroyed resources of every
+// target after destroying resources.
+func WithDestroyedResourcesResp(dtr *component.DestroyedResourcesResp) ManagerOption {
+	return func(m *Manager) {
+		m.dtr = dtr
+	}
+}
@@ -0,0 +1,209 @@
+package multitarget
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/framework/resource"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+func testTargets() []*Target {
+	return []*Target{
+		{Name: "us-east-1", Config: int32(1)},
+		{Name: "eu-west-1", Config: int32(2)},
+	}
+}
+
+func TestManager(t *testing.T) {
+	require := require.New(t)
+
+	var mu sync.Mutex
+	var destroyed []string
+	f := func(target *Target) []resource.ManagerOption {
+		return []resource.ManagerOption{
+			resource.WithResource(resource.NewResource(
+				resource.WithName("service"),
+				resource.WithPlatform("test"),
+				resource.WithState(&testproto.Data{}),
+				resource.WithCreate(func(s *testproto.Data, t *Target, v string) error {
+					s.Value = v
+					s.Number = t.Config.(int32)
+					return nil
+				}),
+				resource.WithDestroy(func(s *testproto.Data, t *Target) error {
+					mu.Lock()
+					defer mu.Unlock()
+					destroyed = append(destroyed, t.Name+"="+s.Value)
+					return nil
+				}),
+				resource.WithStatus(func(s *testproto.Data, t *Target, sr *resource.StatusResponse) error {
+					health := pb.StatusReport_READY
+					if s.Number == 2 {
+						health = pb.StatusReport_DOWN
+					}
+
+					sr.Resources = append(sr.Resources, &pb.StatusReport_Resource{
+						Name:   "task",
+						Health: health,
+					})
+					return nil
+				}),
+			)),
+		}
+	}
+
+	// Create
+	var dcr component.DeclaredResourcesResp
+	m := NewManager(testTargets(), f, WithDeclaredResourcesResp(&dcr))
+	require.NoError(m.CreateAll("v1"))
+
+	var names []string
+	for _, r := range dcr.DeclaredResources {
+		names = append(names, r.Name)
+	}
+	require.Equal([]string{"us-east-1/service", "eu-west-1/service"}, names)
+
+	// Status
+	report, err := m.StatusReport()
+	require.NoError(err)
+	require.Equal(pb.StatusReport_PARTIAL, report.Health)
+	require.Equal("us-east-1: READY, eu-west-1: DOWN", report.HealthMessage)
+	require.Len(report.Resources, 2)
+	require.Equal("us-east-1/task", report.Resources[0].Name)
+	require.Equal("us-east-1/service", report.Resources[0].DeclaredResource.Name)
+	require.Equal("eu-west-1/task", report.Resources[1].Name)
+
+	// Filters apply to the combined report
+	report, err = m.StatusReport(&component.StatusFilter{
+		Health:   component.UnhealthyStatus,
+		PageSize: 1,
+	})
+	require.NoError(err)
+	require.Equal(pb.StatusReport_PARTIAL, report.Health)
+	require.Len(report.Resources, 1)
+	require.Equal("eu-west-1/task", report.Resources[0].Name)
+	require.Equal(uint32(1), report.Page.Total)
+	require.Empty(report.Page.NextPageToken)
+
+	// Load the state into a new manager and destroy
+	var dtr component.DestroyedResourcesResp
+	m2 := NewManager(testTargets(), f, WithDestroyedResourcesResp(&dtr))
+	require.NoError(m2.LoadState(m.State()))
+	require.Equal(int32(2),
+		m2.Manager("eu-west-1").Resource("service").State().(*testproto.Data).Number)
+	require.NoError(m2.DestroyAll())
+
+	sort.Strings(destroyed)
+	require.Equal([]string{"eu-west-1=v1", "us-east-1=v1"}, destroyed)
+	require.Len(dtr.DestroyedResources, 2)
+	require.Equal("us-east-1/service", dtr.DestroyedResources[0].Name)
+}
+
+func TestManagerCreateAll_rollback(t *testing.T) {
+	require := require.New(t)
+
+	var mu sync.Mutex
+	var destroyed []string
+	m := NewManager(testTargets(), func(target *Target) []resource.ManagerOption {
+		return []resource.ManagerOption{
+			resource.WithResource(resource.NewResource(
+				resource.WithName("service"),
+				resource.WithState(&testproto.Data{}),
+				resource.WithCreate(func(s *testproto.Data, t *Target) error {
+					if t.Name == "eu-west-1" {
+						return errors.New("no capacity")
+					}
+
+					s.Value = t.Name
+					return nil
+				}),
+				resource.WithDestroy(func(s *testproto.Data, t *Target) error {
+					// The failed target rolls back its own resource
+					// which has no state.
+					if s.Value == "" {
+						return nil
+					}
+
+					mu.Lock()
+					defer mu.Unlock()
+					destroyed = append(destroyed, s.Value)
+					return nil
+				}),
+			)),
+		}
+	})
+
+	err := m.CreateAll()
+	require.Error(err)
+	require.Contains(err.Error(), "eu-west-1: ")
+	require.Contains(err.Error(), "no capacity")
+
+	// The target that succeeded is rolled back
+	require.Equal([]string{"us-east-1"}, destroyed)
+}
+
+func TestManagerValidate(t *testing.T) {
+	m := NewManager([]*Target{{Name: "a"}, {Name: "a"}},
+		func(*Target) []resource.ManagerOption { return nil })
+
+	err := m.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `duplicate target "a"`)
+}
+
+func TestManagerLoadState_removedTarget(t *testing.T) {
+	require := require.New(t)
+
+	var destroyed []string
+	f := func(*Target) []resource.ManagerOption {
+		return []resource.ManagerOption{
+			resource.WithResource(resource.NewResource(
+				resource.WithName("service"),
+				resource.WithState(&testproto.Data{}),
+				resource.WithCreate(func(s *testproto.Data, t *Target) error {
+					s.Value = t.Name
+					return nil
+				}),
+				resource.WithDestroy(func(s *testproto.Data, t *Target) error {
+					destroyed = append(destroyed, t.Name+"="+s.Value)
+					return nil
+				}),
+			)),
+		}
+	}
+
+	m := NewManager(testTargets(), f)
+	require.NoError(m.CreateAll())
+	state := m.State()
+
+	// The state of the removed target is kept until it is destroyed
+	var dtr component.DestroyedResourcesResp
+	m2 := NewManager([]*Target{{Name: "us-east-1"}}, f, WithDestroyedResourcesResp(&dtr))
+	require.NoError(m2.LoadState(state))
+	require.NotNil(m2.Manager("eu-west-1"))
+
+	m3 := NewManager([]*Target{{Name: "us-east-1"}}, f)
+	require.NoError(m3.LoadState(m2.State()))
+	require.Equal("eu-west-1",
+		m3.Manager("eu-west-1").Resource("service").State().(*testproto.Data).Value)
+
+	// Destroying destroys the removed target too
+	require.NoError(m2.DestroyAll())
+	sort.Strings(destroyed)
+	require.Equal([]string{"eu-west-1=eu-west-1", "us-east-1=us-east-1"}, destroyed)
+	require.Len(dtr.DestroyedResources, 2)
+	require.Nil(m2.Manager("eu-west-1"))
+
+	// Creating the configured targets destroys the removed targets
+	destroyed = nil
+	require.NoError(m3.CreateAll())
+	require.Equal([]string{"eu-west-1=eu-west-1"}, destroyed)
+	require.Nil(m3.Manager("eu-west-1"))
+}
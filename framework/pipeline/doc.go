@@ -0,0 +1,23 @@
+// Package pipeline contains helpers for declaring an operation, such as a
+// build or deploy, as a list of ordered, named steps rather than a single
+// function.
+//
+// Each step is a function that has its arguments injected, like the
+// operation functions of a component. The outputs of a step are available
+// to the steps after it. Steps are shown in the terminal UI as they run, a
+// failed step is named in the error, and steps can be retried:
+//
+//	p := pipeline.New(
+//		pipeline.WithStep("Pulling image", pull),
+//		pipeline.WithStep("Pushing image", push,
+//			pipeline.WithRetry(3, 5*time.Second)),
+//	)
+//
+//	values, err := p.Run(ctx, ui, src, artifact)
+//	if err != nil {
+//		return nil, err
+//	}
+//
+//	var result *Image
+//	values.Get(&result)
+package pipeline
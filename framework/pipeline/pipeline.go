@@ -0,0 +1,254 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+)
+
+// Pipeline is an operation made of ordered, named steps.
+//
+// Create a Pipeline with New and a set of options.
+type Pipeline struct {
+	steps  []*step
+	logger hclog.Logger
+}
+
+// Option is used to configure a Pipeline with New.
+type Option func(*Pipeline)
+
+// StepOption is used to configure a step with WithStep.
+type StepOption func(*step)
+
+type step struct {
+	name       string
+	f          interface{}
+	attempts   int
+	retryDelay time.Duration
+}
+
+// New creates a new pipeline.
+//
+// Callers should call Validate on the result to check for errors.
+func New(opts ...Option) *Pipeline {
+	p := &Pipeline{logger: hclog.L()}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// WithLogger sets the logger for the pipeline.
+func WithLogger(l hclog.Logger) Option {
+	return func(p *Pipeline) { p.logger = l }
+}
+
+// WithStep adds a step to the pipeline. Steps run in the order they're
+// added. The name is shown in the UI while the step runs.
+//
+// f is the function of the step. It can accept the arguments given to Run,
+// the outputs of the previous steps, a context.Context, the terminal.UI
+// and the terminal.Step of this step. The outputs of f, except for a final
+// error, are available to the steps after it and in the Values returned by
+// Run. An output replaces any earlier value of the same type.
+func WithStep(name string, f interface{}, opts ...StepOption) Option {
+	return func(p *Pipeline) {
+		s := &step{name: name, f: f, attempts: 1}
+		for _, opt := range opts {
+			opt(s)
+		}
+
+		p.steps = append(p.steps, s)
+	}
+}
+
+// WithRetry sets the number of times to attempt a step before it fails,
+// waiting delay between attempts. The step is not retried if the context
+// is canceled. The default is a single attempt.
+func WithRetry(attempts int, delay time.Duration) StepOption {
+	return func(s *step) {
+		s.attempts = attempts
+		s.retryDelay = delay
+	}
+}
+
+// Validate checks that the pipeline is configured correctly. This is always
+// called by Run, but users may call this earlier to catch errors sooner.
+func (p *Pipeline) Validate() error {
+	seen := map[string]struct{}{}
+	for i, s := range p.steps {
+		if s.name == "" {
+			return fmt.Errorf("step %d: name must be set", i)
+		}
+		if _, ok := seen[s.name]; ok {
+			return fmt.Errorf("duplicate step %q", s.name)
+		}
+		seen[s.name] = struct{}{}
+
+		if s.attempts < 1 {
+			return fmt.Errorf("step %q: attempts must be at least 1", s.name)
+		}
+		if _, err := argmapper.NewFunc(s.f); err != nil {
+			return fmt.Errorf("step %q: %w", s.name, err)
+		}
+	}
+
+	return nil
+}
+
+// Run runs the steps in order and stops at the first step that fails. The
+// error of a failed step is a *StepError. args are available to every
+// step. The returned Values contain args and the outputs of all the steps
+// that ran, even if a step failed.
+//
+// Each step is shown as a step of a terminal.StepGroup on ui.
+func (p *Pipeline) Run(ctx context.Context, ui terminal.UI, args ...interface{}) (*Values, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	values := &Values{}
+	for _, arg := range args {
+		values.set(arg)
+	}
+
+	sg := ui.StepGroup()
+	defer sg.Wait()
+
+	for _, s := range p.steps {
+		if err := p.runStep(ctx, ui, sg, s, values); err != nil {
+			return values, &StepError{Step: s.name, Err: err}
+		}
+	}
+
+	return values, nil
+}
+
+func (p *Pipeline) runStep(
+	ctx context.Context,
+	ui terminal.UI,
+	sg terminal.StepGroup,
+	s *step,
+	values *Values,
+) error {
+	L := p.logger.With("step", s.name)
+
+	ts := sg.Add(s.name)
+	defer ts.Abort()
+
+	f, err := argmapper.NewFunc(s.f, argmapper.Logger(L))
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		L.Debug("running step", "attempt", attempt)
+
+		callArgs := []argmapper.Arg{
+			argmapper.Logger(L),
+			argmapper.Typed(ctx, ui, ts),
+		}
+		for _, v := range values.values {
+			callArgs = append(callArgs, argmapper.Typed(v))
+		}
+
+		result := f.Call(callArgs...)
+		err = result.Err()
+		if err == nil {
+			for i := 0; i < result.Len(); i++ {
+				values.set(result.Out(i))
+			}
+
+			// Clear the retry message and status of earlier attempts
+			if attempt > 1 {
+				ts.Update(s.name)
+				ts.Status(terminal.StatusOK)
+			}
+
+			ts.Done()
+			return nil
+		}
+
+		if attempt >= s.attempts || ctx.Err() != nil {
+			return err
+		}
+
+		L.Warn("step failed, retrying", "attempt", attempt, "error", err)
+		ts.Update("%s (retrying, attempt %d of %d)", s.name, attempt+1, s.attempts)
+		ts.Status(terminal.StatusWarn)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.retryDelay):
+		}
+	}
+}
+
+// StepError is the error returned by Run when a step fails.
+type StepError struct {
+	// Step is the name of the step that failed.
+	Step string
+
+	// Err is the error of the last attempt of the step.
+	Err error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("step %q failed: %s", e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// Values are the values available to the steps of a pipeline.
+type Values struct {
+	values []interface{}
+}
+
+// Get sets target, which must be a non-nil pointer, to the value with the
+// type that target points to. This returns false if there is no such value.
+// Like errors.As, target can point to an interface type to get a value
+// that implements it.
+func (v *Values) Get(target interface{}) bool {
+	tv := reflect.ValueOf(target)
+	if tv.Kind() != reflect.Ptr || tv.IsNil() {
+		panic("pipeline: target must be a non-nil pointer")
+	}
+
+	elem := tv.Type().Elem()
+	for i := len(v.values) - 1; i >= 0; i-- {
+		value := reflect.ValueOf(v.values[i])
+		if value.Type().AssignableTo(elem) {
+			tv.Elem().Set(value)
+			return true
+		}
+	}
+
+	return false
+}
+
+// set adds value, replacing any value of the same type.
+func (v *Values) set(value interface{}) {
+	if value == nil {
+		return
+	}
+
+	t := reflect.TypeOf(value)
+	for i, existing := range v.values {
+		if reflect.TypeOf(existing) == t {
+			v.values[i] = value
+			return
+		}
+	}
+
+	v.values = append(v.values, value)
+}
@@ -0,0 +1,185 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+)
+
+type (
+	testSource struct{ Name string }
+	testImage  struct{ Ref string }
+	testPushed struct{ Digest string }
+)
+
+func TestPipelineRun(t *testing.T) {
+	require := require.New(t)
+
+	var order []string
+	p := New(
+		WithStep("build", func(ctx context.Context, src *testSource, ts terminal.Step) *testImage {
+			require.NotNil(ctx)
+			require.NotNil(ts)
+			order = append(order, "build")
+			return &testImage{Ref: src.Name + ":latest"}
+		}),
+		WithStep("push", func(img *testImage, ui terminal.UI) (*testPushed, error) {
+			require.NotNil(ui)
+			order = append(order, "push")
+			return &testPushed{Digest: "sha256:" + img.Ref}, nil
+		}),
+	)
+	require.NoError(p.Validate())
+
+	ui := terminal.NonInteractiveUI(context.Background())
+	values, err := p.Run(context.Background(), ui, &testSource{Name: "app"})
+	require.NoError(err)
+	require.Equal([]string{"build", "push"}, order)
+
+	var pushed *testPushed
+	require.True(values.Get(&pushed))
+	require.Equal("sha256:app:latest", pushed.Digest)
+
+	var src *testSource
+	require.True(values.Get(&src))
+
+	var missing *time.Timer
+	require.False(values.Get(&missing))
+}
+
+func TestPipelineRun_error(t *testing.T) {
+	require := require.New(t)
+
+	expected := errors.New("registry unavailable")
+	var ranAfter bool
+	p := New(
+		WithStep("build", func() *testImage { return &testImage{Ref: "app"} }),
+		WithStep("push", func(*testImage) error { return expected }),
+		WithStep("release", func() error {
+			ranAfter = true
+			return nil
+		}),
+	)
+
+	ui := terminal.NonInteractiveUI(context.Background())
+	values, err := p.Run(context.Background(), ui)
+	require.Error(err)
+	require.False(ranAfter)
+
+	// The error names the step and wraps the step error
+	var stepErr *StepError
+	require.True(errors.As(err, &stepErr))
+	require.Equal("push", stepErr.Step)
+	require.ErrorIs(err, expected)
+	require.Contains(err.Error(), `step "push" failed`)
+
+	// Outputs of the steps that ran are still available
+	var img *testImage
+	require.True(values.Get(&img))
+}
+
+func TestPipelineRun_retry(t *testing.T) {
+	require := require.New(t)
+
+	var attempts int
+	p := New(
+		WithStep("push", func() error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("try again")
+			}
+
+			return nil
+		}, WithRetry(3, time.Millisecond)),
+	)
+
+	ui := terminal.NonInteractiveUI(context.Background())
+	_, err := p.Run(context.Background(), ui)
+	require.NoError(err)
+	require.Equal(3, attempts)
+
+	// Fails once the attempts are used up
+	attempts = -10
+	_, err = p.Run(context.Background(), ui)
+	require.Error(err)
+	require.Equal(-7, attempts)
+}
+
+func TestPipelineRun_retryCanceled(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var attempts int
+	p := New(
+		WithStep("push", func() error {
+			attempts++
+			cancel()
+			return errors.New("try again")
+		}, WithRetry(5, time.Hour)),
+	)
+
+	ui := terminal.NonInteractiveUI(context.Background())
+	_, err := p.Run(ctx, ui)
+	require.Error(err)
+	require.Equal(1, attempts)
+}
+
+func TestPipelineValidate(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Opts  []Option
+		Error string
+	}{
+		{
+			"valid",
+			[]Option{WithStep("a", func() {}), WithStep("b", func() {})},
+			"",
+		},
+
+		{
+			"no name",
+			[]Option{WithStep("", func() {})},
+			"name must be set",
+		},
+
+		{
+			"duplicate name",
+			[]Option{WithStep("a", func() {}), WithStep("a", func() {})},
+			`duplicate step "a"`,
+		},
+
+		{
+			"not a function",
+			[]Option{WithStep("a", 42)},
+			`step "a"`,
+		},
+
+		{
+			"no attempts",
+			[]Option{WithStep("a", func() {}, WithRetry(0, 0))},
+			"attempts must be at least 1",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			err := New(tt.Opts...).Validate()
+			if tt.Error == "" {
+				require.NoError(err)
+				return
+			}
+
+			require.Error(err)
+			require.Contains(err.Error(), tt.Error)
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/opaqueany"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/docs"
+	"github.com/hashicorp/waypoint-plugin-sdk/framework/resource"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+)
+
+// ResourceStateField is the name of the field of the deployment message of
+// a Plugin that stores the state of the resource manager. The field must
+// be of type opaqueany.Any:
+//
+//	message Deployment {
+//	  string url = 1;
+//	  opaqueany.Any resource_state = 2;
+//	}
+const ResourceStateField = "resource_state"
+
+// Plugin is a platform plugin whose deployments are a set of resources
+// managed by a resource.Manager. The Platform, Destroyer, Status,
+// Configurable and Documented implementations are generated from the
+// resources:
+//
+//   - deploy creates all the resources with Manager.CreateAll and stores
+//     the state of the manager in the deployment
+//   - destroy loads the state from the deployment and destroys all the
+//     resources with Manager.DestroyAll
+//   - status loads the state from the deployment and returns the report
+//     of Manager.StatusReport
+//
+// The create, destroy and status functions of the resources have the
+// following arguments available, along with the values of the resources
+// they depend on: context.Context, hclog.Logger, terminal.UI,
+// *component.Source and *component.JobInfo. The create functions also have
+// the artifact if Artifact is set.
+//
+// A Plugin is served like any other component:
+//
+//	sdk.Main(sdk.WithComponents(&framework.Plugin{...}))
+type Plugin struct {
+	// Configuration is a pointer to the configuration structure of the
+	// plugin. The resources can read the decoded configuration from it.
+	// This is optional.
+	Configuration interface{}
+
+	// Artifact is the type of artifact the plugin deploys, such as
+	// (*docker.Image)(nil). This is optional; if it is nil then deploy
+	// doesn't require an artifact.
+	Artifact proto.Message
+
+	// Deployment is the type of deployment the plugin returns, such as
+	// (*Deployment)(nil). It must have a ResourceStateField field. This
+	// is required.
+	Deployment proto.Message
+
+	// Resources returns the options for the resource manager, such as
+	// resource.WithResource for each resource. This is called for every
+	// operation so it must return new resources each time. This is
+	// required.
+	Resources func() []resource.ManagerOption
+
+	// Docs returns the documentation of the plugin. If this is nil then
+	// the documentation is generated from Configuration.
+	Docs func() (*docs.Documentation, error)
+}
+
+// Validate checks that the plugin is configured correctly. This is called
+// by every operation but plugins can call it earlier, such as in a test,
+// to catch errors sooner.
+func (p *Plugin) Validate() error {
+	if err := p.validateTypes(); err != nil {
+		return err
+	}
+
+	return p.manager().Validate()
+}
+
+// validateTypes checks the fields needed to generate the functions.
+func (p *Plugin) validateTypes() error {
+	if p.Resources == nil {
+		return fmt.Errorf("Resources must be set")
+	}
+	if p.Deployment == nil {
+		return fmt.Errorf("Deployment must be set")
+	}
+
+	_, err := stateField(p.Deployment)
+	return err
+}
+
+// Config implements component.Configurable
+func (p *Plugin) Config() (interface{}, error) {
+	return p.Configuration, nil
+}
+
+// Documentation implements component.Documented
+func (p *Plugin) Documentation() (*docs.Documentation, error) {
+	if p.Docs != nil {
+		return p.Docs()
+	}
+
+	var opts []docs.Option
+	if p.Configuration != nil {
+		opts = append(opts, docs.FromConfig(p.Configuration))
+	}
+
+	return docs.New(opts...)
+}
+
+// DeployFunc implements component.Platform
+func (p *Plugin) DeployFunc() interface{} {
+	if err := p.validateTypes(); err != nil {
+		return funcErr(err)
+	}
+
+	in := inputs(declaredResourcesType)
+	if p.Artifact != nil {
+		in = append(in, reflect.TypeOf(p.Artifact))
+	}
+
+	return makeFunc(in, reflect.TypeOf(p.Deployment), func(args []reflect.Value) (interface{}, error) {
+		return p.deploy(args)
+	})
+}
+
+// DestroyFunc implements component.Destroyer
+func (p *Plugin) DestroyFunc() interface{} {
+	if err := p.validateTypes(); err != nil {
+		return funcErr(err)
+	}
+
+	in := inputs(destroyedResourcesType, reflect.TypeOf(p.Deployment))
+	return makeFunc(in, nil, func(args []reflect.Value) (interface{}, error) {
+		return nil, p.destroy(args)
+	})
+}
+
+// StatusFunc implements component.Status
+func (p *Plugin) StatusFunc() interface{} {
+	if err := p.validateTypes(); err != nil {
+		return funcErr(err)
+	}
+
+	in := inputs(reflect.TypeOf(p.Deployment))
+	return makeFunc(in, statusReportType, func(args []reflect.Value) (interface{}, error) {
+		return p.status(args)
+	})
+}
+
+func (p *Plugin) deploy(args []reflect.Value) (interface{}, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	dcr := args[len(commonTypes)].Interface().(*component.DeclaredResourcesResp)
+	m := p.manager(
+		resource.WithLogger(logger(args[1])),
+		resource.WithDeclaredResourcesResp(dcr),
+	)
+
+	// The artifact is passed to the create functions along with the
+	// common arguments.
+	if err := m.CreateAll(values(args[:len(commonTypes)], args[len(commonTypes)+1:])...); err != nil {
+		return nil, err
+	}
+
+	result := p.Deployment.ProtoReflect().New()
+	fd, err := stateField(p.Deployment)
+	if err != nil {
+		return nil, err
+	}
+	result.Set(fd, protoreflect.ValueOfMessage(m.State().ProtoReflect()))
+
+	return result.Interface(), nil
+}
+
+func (p *Plugin) destroy(args []reflect.Value) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	dtr := args[len(commonTypes)].Interface().(*component.DestroyedResourcesResp)
+	m, err := p.loadManager(args[len(commonTypes)+1],
+		resource.WithLogger(logger(args[1])),
+		resource.WithDestroyedResourcesResp(dtr),
+	)
+	if err != nil {
+		return err
+	}
+
+	return m.DestroyAll(values(args[:len(commonTypes)])...)
+}
+
+func (p *Plugin) status(args []reflect.Value) (interface{}, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	m, err := p.loadManager(args[len(commonTypes)],
+		resource.WithLogger(logger(args[1])),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.StatusReport(values(args[:len(commonTypes)])...)
+}
+
+// manager returns a new resource manager with the resources of the plugin.
+// Our options are first so that Resources can override them.
+func (p *Plugin) manager(opts ...resource.ManagerOption) *resource.Manager {
+	return resource.NewManager(append(opts, p.Resources()...)...)
+}
+
+// loadManager returns a new resource manager with the state stored in the
+// deployment.
+func (p *Plugin) loadManager(
+	deployment reflect.Value,
+	opts ...resource.ManagerOption,
+) (*resource.Manager, error) {
+	m := p.manager(opts...)
+
+	fd, err := stateField(p.Deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	d := deployment.Interface().(proto.Message).ProtoReflect()
+	if !d.IsValid() || !d.Has(fd) {
+		// No state, so there are no resources. The manager handles this
+		// the same as a manager that never created anything.
+		return m, nil
+	}
+
+	state, ok := d.Get(fd).Message().Interface().(*opaqueany.Any)
+	if !ok {
+		return nil, fmt.Errorf("field %q of the deployment must be an opaqueany.Any", ResourceStateField)
+	}
+	if err := m.LoadState(state); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// makeFunc returns a function with the inputs in and, if out isn't nil, the
+// output out followed by an error. f is called with the arguments.
+func makeFunc(
+	in []reflect.Type,
+	out reflect.Type,
+	f func([]reflect.Value) (interface{}, error),
+) interface{} {
+	outs := []reflect.Type{errorType}
+	if out != nil {
+		outs = []reflect.Type{out, errorType}
+	}
+
+	ft := reflect.FuncOf(in, outs, false)
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		result, err := f(args)
+
+		errV := reflect.Zero(errorType)
+		if err != nil {
+			errV = reflect.ValueOf(&err).Elem()
+		}
+		if out == nil {
+			return []reflect.Value{errV}
+		}
+
+		resultV := reflect.Zero(out)
+		if result != nil && err == nil {
+			resultV = reflect.ValueOf(result)
+		}
+
+		return []reflect.Value{resultV, errV}
+	}).Interface()
+}
+
+// stateField returns the ResourceStateField of the deployment message.
+func stateField(deployment proto.Message) (protoreflect.FieldDescriptor, error) {
+	desc := deployment.ProtoReflect().Descriptor()
+	fd := desc.Fields().ByName(ResourceStateField)
+	if fd == nil {
+		return nil, fmt.Errorf(
+			"deployment %s must have a %q field", desc.FullName(), ResourceStateField)
+	}
+
+	anyName := (&opaqueany.Any{}).ProtoReflect().Descriptor().FullName()
+	if fd.Message() == nil || fd.Message().FullName() != anyName || fd.IsList() || fd.IsMap() {
+		return nil, fmt.Errorf(
+			"field %q of deployment %s must be an %s", ResourceStateField, desc.FullName(), anyName)
+	}
+
+	return fd, nil
+}
+
+// logger returns the logger argument, or the default logger if it is nil.
+func logger(v reflect.Value) hclog.Logger {
+	if l, ok := v.Interface().(hclog.Logger); ok && l != nil {
+		return l
+	}
+
+	return hclog.L()
+}
+
+// inputs returns commonTypes followed by extra.
+func inputs(extra ...reflect.Type) []reflect.Type {
+	result := append([]reflect.Type(nil), commonTypes...)
+	return append(result, extra...)
+}
+
+// values returns the interface values of the given lists of values to pass
+// to the resource manager. Nil interfaces are skipped since they have no
+// type to match.
+func values(lists ...[]reflect.Value) []interface{} {
+	var result []interface{}
+	for _, vs := range lists {
+		for _, v := range vs {
+			if v.Kind() == reflect.Interface && v.IsNil() {
+				continue
+			}
+
+			result = append(result, v.Interface())
+		}
+	}
+
+	return result
+}
+
+// funcErr returns a function that returns err. This is used as the
+// function of an operation when the plugin isn't configured correctly so
+// that the error is reported when the operation runs.
+func funcErr(err error) interface{} {
+	return func() error { return err }
+}
+
+var (
+	// commonTypes are the first arguments of every generated function.
+	// The logger must be the second argument.
+	commonTypes = []reflect.Type{
+		reflect.TypeOf((*context.Context)(nil)).Elem(),
+		reflect.TypeOf((*hclog.Logger)(nil)).Elem(),
+		reflect.TypeOf((*terminal.UI)(nil)).Elem(),
+		reflect.TypeOf((*component.Source)(nil)),
+		reflect.TypeOf((*component.JobInfo)(nil)),
+	}
+
+	declaredResourcesType  = reflect.TypeOf((*component.DeclaredResourcesResp)(nil))
+	destroyedResourcesType = reflect.TypeOf((*component.DestroyedResourcesResp)(nil))
+	statusReportType       = reflect.TypeOf((*pb.StatusReport)(nil))
+	errorType              = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+var (
+	_ component.Configurable = (*Plugin)(nil)
+	_ component.Documented   = (*Plugin)(nil)
+	_ component.Platform     = (*Plugin)(nil)
+	_ component.Destroyer    = (*Plugin)(nil)
+	_ component.Status       = (*Plugin)(nil)
+)
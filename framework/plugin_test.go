@@ -0,0 +1,198 @@
+package framework
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/framework/resource"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+)
+
+type testConfig struct {
+	Name string `hcl:"name"`
+}
+
+func TestPlugin(t *testing.T) {
+	require := require.New(t)
+
+	var config testConfig
+	var destroyed []string
+	p := &Plugin{
+		Configuration: &config,
+		Artifact:      (*testproto.A)(nil),
+		Deployment:    (*testproto.Deployment)(nil),
+		Resources: func() []resource.ManagerOption {
+			return []resource.ManagerOption{
+				resource.WithResource(resource.NewResource(
+					resource.WithName("A"),
+					resource.WithState(&testproto.Data{}),
+					resource.WithCreate(func(
+						s *testproto.Data,
+						src *component.Source,
+						a *testproto.A,
+					) error {
+						s.Value = config.Name + "-" + src.App
+						s.Number = a.Value
+						return nil
+					}),
+					resource.WithDestroy(func(s *testproto.Data) error {
+						destroyed = append(destroyed, s.Value)
+						return nil
+					}),
+					resource.WithStatus(func(s *testproto.Data, sr *resource.StatusResponse) error {
+						sr.Resources = append(sr.Resources, &pb.StatusReport_Resource{
+							Name:   s.Value,
+							Health: pb.StatusReport_READY,
+						})
+						return nil
+					}),
+				)),
+			}
+		},
+	}
+	require.NoError(p.Validate())
+
+	// Configure
+	v, err := p.Config()
+	require.NoError(err)
+	v.(*testConfig).Name = "web"
+
+	args := []argmapper.Arg{
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(hclog.L()),
+		argmapper.Typed(terminal.NonInteractiveUI(context.Background())),
+		argmapper.Typed(&component.Source{App: "app"}),
+		argmapper.Typed(&component.JobInfo{}),
+	}
+
+	// Deploy
+	var dcr component.DeclaredResourcesResp
+	result := callFunc(t, p.DeployFunc(), append(args,
+		argmapper.Typed(&dcr),
+		argmapper.Typed(&testproto.A{Value: 42}),
+	)...)
+	require.NoError(result.Err())
+	deployment, ok := result.Out(0).(*testproto.Deployment)
+	require.True(ok)
+	require.NotNil(deployment.ResourceState)
+	require.Len(dcr.DeclaredResources, 1)
+
+	// Status
+	result = callFunc(t, p.StatusFunc(), append(args, argmapper.Typed(deployment))...)
+	require.NoError(result.Err())
+	report := result.Out(0).(*pb.StatusReport)
+	require.Len(report.Resources, 1)
+	require.Equal("web-app", report.Resources[0].Name)
+
+	// Destroy
+	var dtr component.DestroyedResourcesResp
+	result = callFunc(t, p.DestroyFunc(), append(args,
+		argmapper.Typed(&dtr),
+		argmapper.Typed(deployment),
+	)...)
+	require.NoError(result.Err())
+	require.Equal([]string{"web-app"}, destroyed)
+	require.Len(dtr.DestroyedResources, 1)
+}
+
+func TestPlugin_destroyNoState(t *testing.T) {
+	require := require.New(t)
+
+	var destroyed bool
+	p := &Plugin{
+		Deployment: (*testproto.Deployment)(nil),
+		Resources: func() []resource.ManagerOption {
+			return []resource.ManagerOption{
+				resource.WithResource(resource.NewResource(
+					resource.WithName("A"),
+					resource.WithState(&testproto.Data{}),
+					resource.WithCreate(func(s *testproto.Data) error { return nil }),
+					resource.WithDestroy(func(s *testproto.Data) error {
+						destroyed = true
+						return nil
+					}),
+				)),
+			}
+		},
+	}
+
+	// A deployment without state has nothing to destroy
+	result := callFunc(t, p.DestroyFunc(),
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(hclog.L()),
+		argmapper.Typed(terminal.NonInteractiveUI(context.Background())),
+		argmapper.Typed(&component.Source{}),
+		argmapper.Typed(&component.JobInfo{}),
+		argmapper.Typed(&component.DestroyedResourcesResp{}),
+		argmapper.Typed(&testproto.Deployment{}),
+	)
+	require.NoError(result.Err())
+	require.False(destroyed)
+}
+
+func TestPluginValidate(t *testing.T) {
+	resources := func() []resource.ManagerOption { return nil }
+
+	cases := []struct {
+		Name   string
+		Plugin *Plugin
+		Error  string
+	}{
+		{
+			"no resources",
+			&Plugin{Deployment: (*testproto.Deployment)(nil)},
+			"Resources must be set",
+		},
+
+		{
+			"no deployment",
+			&Plugin{Resources: resources},
+			"Deployment must be set",
+		},
+
+		{
+			"no state field",
+			&Plugin{Resources: resources, Deployment: (*testproto.Data)(nil)},
+			`must have a "resource_state" field`,
+		},
+
+		{
+			"valid",
+			&Plugin{Resources: resources, Deployment: (*testproto.Deployment)(nil)},
+			"",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			err := tt.Plugin.Validate()
+			if tt.Error == "" {
+				require.NoError(err)
+				return
+			}
+
+			require.Error(err)
+			require.Contains(err.Error(), tt.Error)
+
+			// The generated functions return the error when called
+			result := callFunc(t, tt.Plugin.DeployFunc())
+			require.Error(result.Err())
+			require.Contains(result.Err().Error(), tt.Error)
+		})
+	}
+}
+
+func callFunc(t *testing.T, f interface{}, args ...argmapper.Arg) argmapper.Result {
+	fn, err := argmapper.NewFunc(f)
+	require.NoError(t, err)
+	return fn.Call(args...)
+}
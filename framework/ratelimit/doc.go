@@ -0,0 +1,26 @@
+// Package ratelimit contains helpers for plugins to throttle the requests
+// they make to cloud APIs.
+//
+// Resource lifecycle functions, and status reports in particular, can make
+// many API calls in a short time. A Group has one token bucket Limiter per
+// API that is shared by every function that uses the group, so a plugin
+// stays under the limits of the account it deploys to no matter how many
+// resources it manages. The limits can be set from the plugin
+// configuration with Config.
+//
+// Groups are made available to lifecycle functions with dependency
+// injection:
+//
+//	limits := ratelimit.NewGroup(10, 5)
+//	mgr := resource.NewManager(
+//		resource.WithValueProvider(limits.Provider()),
+//		resource.WithResource(resource.NewResource(
+//			resource.WithCreate(func(ctx context.Context, limits *ratelimit.Group) error {
+//				if err := limits.Wait(ctx, "ec2"); err != nil {
+//					return err
+//				}
+//				...
+//			}),
+//		)),
+//	)
+package ratelimit
@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+)
+
+// Config is the rate limit configuration of a plugin. Plugins can embed
+// this in their configuration so that users can tune the limits for their
+// accounts:
+//
+//	type PluginConfig struct {
+//		RateLimit *ratelimit.Config `hcl:"rate_limit,block"`
+//	}
+//
+// which is configured as:
+//
+//	rate_limit {
+//	  rate  = 5
+//	  burst = 10
+//
+//	  api "ec2" {
+//	    rate = 2
+//	  }
+//	}
+type Config struct {
+	// Rate is the number of requests per second allowed for each API.
+	// If this is zero, the default rate of the group is used.
+	Rate float64 `hcl:"rate,optional"`
+
+	// Burst is the number of requests that can be made at once for each
+	// API. If this is zero, the default burst of the group is used.
+	Burst int `hcl:"burst,optional"`
+
+	// APIs override the limits for specific APIs.
+	APIs []*APIConfig `hcl:"api,block"`
+}
+
+// APIConfig is the rate limit configuration of a single API. Zero values
+// use the limits of the enclosing Config.
+type APIConfig struct {
+	Name  string  `hcl:",label"`
+	Rate  float64 `hcl:"rate,optional"`
+	Burst int     `hcl:"burst,optional"`
+}
+
+// Group is a set of limiters, one per API, that share the same default
+// limits. The API names are chosen by the plugin, such as "ec2" or "ecs".
+// A Group is safe for concurrent use.
+//
+// A nil Group doesn't limit anything.
+type Group struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    int
+	apis     map[string]*APIConfig
+	limiters map[string]*Limiter
+}
+
+// NewGroup returns a group whose limiters allow rate requests per second
+// with bursts of up to burst requests by default.
+func NewGroup(rate float64, burst int) *Group {
+	return &Group{
+		rate:     rate,
+		burst:    burst,
+		apis:     map[string]*APIConfig{},
+		limiters: map[string]*Limiter{},
+	}
+}
+
+// Configure changes the limits of the group to those in c. Limiters that
+// were already created are updated. A nil config changes nothing.
+func (g *Group) Configure(c *Config) {
+	if c == nil {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if c.Rate != 0 {
+		g.rate = c.Rate
+	}
+	if c.Burst != 0 {
+		g.burst = c.Burst
+	}
+
+	g.apis = map[string]*APIConfig{}
+	for _, api := range c.APIs {
+		g.apis[api.Name] = api
+	}
+
+	for name, l := range g.limiters {
+		l.SetLimit(g.limit(name))
+	}
+}
+
+// Limiter returns the limiter for the given API, creating it if needed.
+// Every call with the same name returns the same limiter.
+func (g *Group) Limiter(api string) *Limiter {
+	if g == nil {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	l, ok := g.limiters[api]
+	if !ok {
+		l = NewLimiter(g.limit(api))
+		g.limiters[api] = l
+	}
+
+	return l
+}
+
+// Wait waits for a token from the limiter of the given API. See
+// Limiter.Wait.
+func (g *Group) Wait(ctx context.Context, api string) error {
+	return g.Limiter(api).Wait(ctx)
+}
+
+// Provider returns a function that provides this group. This can be given
+// to resource.WithValueProvider so that resource lifecycle functions can
+// request a *Group argument.
+func (g *Group) Provider() interface{} {
+	return func() *Group { return g }
+}
+
+// limit returns the rate and burst for the given API. This must be called
+// with the lock held.
+func (g *Group) limit(api string) (float64, int) {
+	rate, burst := g.rate, g.burst
+	if c, ok := g.apis[api]; ok {
+		if c.Rate != 0 {
+			rate = c.Rate
+		}
+		if c.Burst != 0 {
+			burst = c.Burst
+		}
+	}
+
+	return rate, burst
+}
@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket rate limiter. Tokens are added to the bucket
+// at a fixed rate up to its burst size and each request takes one. A
+// Limiter is safe for concurrent use.
+//
+// A nil Limiter doesn't limit anything.
+type Limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  int
+	tokens float64
+	last   time.Time
+
+	// now is used for tests
+	now func() time.Time
+}
+
+// NewLimiter returns a limiter that allows rate requests per second with
+// bursts of up to burst requests. If rate is zero or less the limiter
+// doesn't limit anything. A burst of less than one is treated as one. The
+// bucket starts full.
+func NewLimiter(rate float64, burst int) *Limiter {
+	l := &Limiter{}
+	l.SetLimit(rate, burst)
+	return l
+}
+
+// SetLimit changes the rate and burst size of the limiter. Tokens that
+// were already added are kept, up to the new burst size.
+func (l *Limiter) SetLimit(rate float64, burst int) {
+	if burst < 1 {
+		burst = 1
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.last.IsZero() {
+		l.tokens = float64(burst)
+		l.last = l.timeNow()
+	} else {
+		l.advance(l.timeNow())
+	}
+
+	l.rate = rate
+	l.burst = burst
+	l.tokens = math.Min(l.tokens, float64(burst))
+}
+
+// Limit returns the rate and burst size of the limiter.
+func (l *Limiter) Limit() (float64, int) {
+	if l == nil {
+		return 0, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rate, l.burst
+}
+
+// Allow takes a token and returns true if one is available now, and
+// returns false without waiting otherwise.
+func (l *Limiter) Allow() bool {
+	return l.reserve(false) == 0
+}
+
+// Wait blocks until a token is available and takes it. This returns an
+// error if the context is cancelled first.
+func (l *Limiter) Wait(ctx context.Context) error {
+	for {
+		d := l.reserve(true)
+		if d == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available and returns zero. Otherwise it
+// returns how long until the next token is available. If wait is false the
+// result is only compared to zero.
+func (l *Limiter) reserve(wait bool) time.Duration {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.advance(l.timeNow())
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	if !wait {
+		return -1
+	}
+
+	d := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	if d <= 0 {
+		d = time.Millisecond
+	}
+
+	return d
+}
+
+// advance adds the tokens for the time since the last call. This must be
+// called with the lock held.
+func (l *Limiter) advance(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 && l.rate > 0 {
+		l.tokens = math.Min(float64(l.burst), l.tokens+elapsed.Seconds()*l.rate)
+	}
+
+	l.last = now
+}
+
+func (l *Limiter) timeNow() time.Time {
+	if l.now != nil {
+		return l.now()
+	}
+
+	return time.Now()
+}
@@ -0,0 +1,140 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/framework/resource"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
+)
+
+func TestLimiter(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	l := &Limiter{now: func() time.Time { return now }}
+	l.SetLimit(2, 3)
+
+	// The bucket starts full
+	require.True(l.Allow())
+	require.True(l.Allow())
+	require.True(l.Allow())
+	require.False(l.Allow())
+
+	// Tokens are added at the rate
+	now = now.Add(500 * time.Millisecond)
+	require.True(l.Allow())
+	require.False(l.Allow())
+
+	// Up to the burst size
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		require.True(l.Allow())
+	}
+	require.False(l.Allow())
+
+	// Lowering the burst drops the extra tokens
+	now = now.Add(time.Hour)
+	l.SetLimit(2, 1)
+	require.True(l.Allow())
+	require.False(l.Allow())
+}
+
+func TestLimiter_unlimited(t *testing.T) {
+	require := require.New(t)
+
+	var nilLimiter *Limiter
+	require.True(nilLimiter.Allow())
+	require.NoError(nilLimiter.Wait(context.Background()))
+
+	l := NewLimiter(0, 0)
+	for i := 0; i < 100; i++ {
+		require.True(l.Allow())
+	}
+}
+
+func TestLimiter_wait(t *testing.T) {
+	require := require.New(t)
+
+	l := NewLimiter(100, 1)
+	require.NoError(l.Wait(context.Background()))
+
+	// The next token is available in 10ms
+	start := time.Now()
+	require.NoError(l.Wait(context.Background()))
+	require.GreaterOrEqual(int64(time.Since(start)), int64(5*time.Millisecond))
+
+	// Cancelling the context stops the wait
+	l.SetLimit(0.001, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Equal(context.Canceled, l.Wait(ctx))
+}
+
+func TestGroup(t *testing.T) {
+	require := require.New(t)
+
+	g := NewGroup(10, 5)
+	require.True(g.Limiter("ec2") == g.Limiter("ec2"))
+	require.False(g.Limiter("ec2") == g.Limiter("ecs"))
+
+	var c struct {
+		RateLimit *Config `hcl:"rate_limit,block"`
+	}
+	require.NoError(hclsimple.Decode("test.hcl", []byte(`
+rate_limit {
+  rate = 5
+
+  api "ec2" {
+    rate  = 2
+    burst = 1
+  }
+}
+`), nil, &c))
+
+	g.Configure(c.RateLimit)
+	rate, burst := g.Limiter("ec2").Limit()
+	require.Equal(2.0, rate)
+	require.Equal(1, burst)
+
+	rate, burst = g.Limiter("ecs").Limit()
+	require.Equal(5.0, rate)
+	require.Equal(5, burst)
+
+	var nilGroup *Group
+	require.NoError(nilGroup.Wait(context.Background(), "ec2"))
+}
+
+func TestGroup_provider(t *testing.T) {
+	require := require.New(t)
+
+	// The same group is shared by all the lifecycle functions.
+	g := NewGroup(1, 1)
+	var groups []*Group
+	create := func(s *testproto.Data, g *Group) error {
+		groups = append(groups, g)
+		return nil
+	}
+
+	m := resource.NewManager(
+		resource.WithValueProvider(g.Provider()),
+		resource.WithResource(resource.NewResource(
+			resource.WithName("A"),
+			resource.WithState(&testproto.Data{}),
+			resource.WithCreate(create),
+		)),
+		resource.WithResource(resource.NewResource(
+			resource.WithName("B"),
+			resource.WithState(&testproto.Data{}),
+			resource.WithCreate(create),
+		)),
+	)
+	require.NoError(m.CreateAll())
+	require.Len(groups, 2)
+	require.True(groups[0] == g)
+	require.True(groups[1] == g)
+}
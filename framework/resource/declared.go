@@ -0,0 +1,116 @@
+package resource
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-argmapper"
+
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// WithResourceURL sets a function that returns the URL of the resource,
+// such as its page in the cloud console. The URL is set on the declared
+// resource so the Waypoint UI can link to it.
+//
+// The function may take the state type specified for WithState as an
+// argument and must return a string, optionally followed by an error. An
+// empty string means the resource has no URL.
+func WithResourceURL(f interface{}) ResourceOption {
+	return func(r *Resource) { r.urlFunc = f }
+}
+
+// WithCostEstimate sets a function that returns the estimated cost of the
+// resource, which is set on the declared resource.
+//
+// The function may take the state type specified for WithState as an
+// argument and must return a *pb.ResourceCostEstimate, optionally followed
+// by an error. A nil estimate means the cost isn't known.
+//
+// An error from the function prevents the resource from being declared, so
+// functions that look up prices remotely should return nil rather than an
+// error if the lookup fails.
+func WithCostEstimate(f interface{}) ResourceOption {
+	return func(r *Resource) { r.costFunc = f }
+}
+
+// WithMetadata adds key/value pairs that are displayed with the declared
+// resource, such as the region or account it was created in. This can be
+// called multiple times; later values replace earlier values of the same
+// key.
+func WithMetadata(m map[string]string) ResourceOption {
+	return func(r *Resource) {
+		if r.metadata == nil {
+			r.metadata = map[string]string{}
+		}
+
+		for k, v := range m {
+			r.metadata[k] = v
+		}
+	}
+}
+
+// enrichDeclared sets the URL, cost estimate and metadata of the declared
+// resource.
+func (r *Resource) enrichDeclared(dr *pb.DeclaredResource) error {
+	if len(r.metadata) > 0 {
+		dr.Metadata = map[string]string{}
+		for k, v := range r.metadata {
+			dr.Metadata[k] = v
+		}
+	}
+
+	if r.urlFunc != nil {
+		raw, err := r.callStateFunc(r.urlFunc)
+		if err != nil {
+			return fmt.Errorf("error getting URL of resource %q: %w", r.name, err)
+		}
+
+		url, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("URL function of resource %q must return a string", r.name)
+		}
+
+		dr.Url = url
+	}
+
+	if r.costFunc != nil {
+		raw, err := r.callStateFunc(r.costFunc)
+		if err != nil {
+			return fmt.Errorf("error estimating cost of resource %q: %w", r.name, err)
+		}
+
+		cost, ok := raw.(*pb.ResourceCostEstimate)
+		if raw != nil && !ok {
+			return fmt.Errorf(
+				"cost estimate function of resource %q must return a *pb.ResourceCostEstimate", r.name)
+		}
+
+		dr.CostEstimate = cost
+	}
+
+	return nil
+}
+
+// callStateFunc calls f with the state of the resource, if there is any,
+// and returns its first result.
+func (r *Resource) callStateFunc(f interface{}) (interface{}, error) {
+	fn, err := argmapper.NewFunc(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var args []argmapper.Arg
+	if r.stateValue != nil {
+		args = append(args, argmapper.Typed(r.stateValue))
+	}
+
+	result := fn.Call(args...)
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+	if result.Len() == 0 {
+		return nil, nil
+	}
+
+	return result.Out(0), nil
+}
@@ -5,5 +5,6 @@
 //
 // This package simplifies resource lifecycle, state management, error handling,
 // and more. Even if your plugin only has a single resource, this library is
-// highly recommended.
+// highly recommended. Plugins with a single resource can use Run to manage
+// it in one call.
 package resource
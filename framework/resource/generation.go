@@ -0,0 +1,91 @@
+package resource
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// WithDesiredInputs sets the configuration-relevant desired inputs of the
+// resource, such as the image, ports and resource limits of a container.
+// These are used by GenerationFromResources to determine whether an
+// operation creates new physical resources.
+//
+// v should only contain the values that, when changed, result in new
+// physical resources. It must not contain volatile values such as IDs,
+// timestamps or anything else in the resource state. v may be a
+// proto.Message or any value that can be encoded with encoding/json.
+func WithDesiredInputs(v interface{}) ResourceOption {
+	return func(r *Resource) { r.desiredInputs = v }
+}
+
+// GenerationFromResources returns a generation ID computed from the desired
+// inputs (see WithDesiredInputs) of the resources with the given names. If
+// no names are given, every resource with desired inputs is included. This
+// can be returned directly from the function returned by GenerationFunc to
+// implement component.Generation:
+//
+//	func (p *Platform) generation(src *component.Source) ([]byte, error) {
+//		return resource.GenerationFromResources(p.resourceManager(src), "service")
+//	}
+//
+// The result only depends on the names and desired inputs of the included
+// resources, so the same configuration always results in the same
+// generation. An error is returned if an included resource doesn't exist or
+// doesn't have desired inputs.
+func GenerationFromResources(m *Manager, include ...string) ([]byte, error) {
+	names := include
+	if len(names) == 0 {
+		for name, r := range m.resources {
+			if r.desiredInputs != nil {
+				names = append(names, name)
+			}
+		}
+
+		if len(names) == 0 {
+			return nil, fmt.Errorf("no resources have desired inputs set")
+		}
+	}
+
+	// Sort a copy so the order of include doesn't matter.
+	names = append([]string(nil), names...)
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		r, ok := m.resources[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown resource %q", name)
+		}
+		if r.desiredInputs == nil {
+			return nil, fmt.Errorf("resource %q has no desired inputs", name)
+		}
+
+		data, err := desiredInputsBytes(r.desiredInputs)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding desired inputs of resource %q: %w", name, err)
+		}
+
+		// Length-prefix each value so that different splits of the same
+		// bytes can't collide.
+		fmt.Fprintf(h, "%d:%s%d:", len(name), name, len(data))
+		h.Write(data)
+	}
+
+	return []byte(hex.EncodeToString(h.Sum(nil))), nil
+}
+
+// desiredInputsBytes returns a stable encoding of v.
+func desiredInputsBytes(v interface{}) ([]byte, error) {
+	if msg, ok := v.(proto.Message); ok {
+		return proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	}
+
+	// encoding/json writes struct fields in order and sorts map keys, so
+	// this is stable.
+	return json.Marshal(v)
+}
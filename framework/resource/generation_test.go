@@ -0,0 +1,73 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
+)
+
+func TestGenerationFromResources(t *testing.T) {
+	require := require.New(t)
+
+	type service struct {
+		Image string
+		Ports []int
+	}
+
+	newManager := func(image string) *Manager {
+		return NewManager(
+			WithResource(NewResource(
+				WithName("service"),
+				WithDesiredInputs(&service{Image: image, Ports: []int{80}}),
+			)),
+			WithResource(NewResource(
+				WithName("config"),
+				WithDesiredInputs(&testproto.Data{Value: "config"}),
+			)),
+			WithResource(NewResource(WithName("nothing"))),
+		)
+	}
+
+	gen, err := GenerationFromResources(newManager("nginx:1"))
+	require.NoError(err)
+	require.Len(gen, 64)
+
+	// Stable for the same inputs regardless of the include order
+	same, err := GenerationFromResources(newManager("nginx:1"), "service", "config")
+	require.NoError(err)
+	require.Equal(gen, same)
+	same, err = GenerationFromResources(newManager("nginx:1"), "config", "service")
+	require.NoError(err)
+	require.Equal(gen, same)
+
+	// Changes with the inputs of included resources only
+	changed, err := GenerationFromResources(newManager("nginx:2"))
+	require.NoError(err)
+	require.NotEqual(gen, changed)
+
+	config1, err := GenerationFromResources(newManager("nginx:1"), "config")
+	require.NoError(err)
+	config2, err := GenerationFromResources(newManager("nginx:2"), "config")
+	require.NoError(err)
+	require.Equal(config1, config2)
+	require.NotEqual(gen, config1)
+}
+
+func TestGenerationFromResources_errors(t *testing.T) {
+	m := NewManager(
+		WithResource(NewResource(WithName("nothing"))),
+	)
+
+	_, err := GenerationFromResources(m)
+	require.Error(t, err)
+
+	_, err = GenerationFromResources(m, "nothing")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no desired inputs")
+
+	_, err = GenerationFromResources(m, "missing")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown resource")
+}
@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -30,6 +31,7 @@ type Manager struct {
 	valueProviders []interface{}
 	dcr            *component.DeclaredResourcesResp
 	dtr            *component.DestroyedResourcesResp
+	rollbackPolicy RollbackPolicy
 }
 
 // NewManager creates a new resource manager.
@@ -146,10 +148,31 @@ func (m *Manager) proto() *pb.Framework_ResourceManagerState {
 // Create will initialize brand new state. This will not reuse existing state.
 // If there is any existing state loaded, this will return an error immediately
 // because it risks that state being lost.
+//
+// If a context.Context is in args, it is used like the context given to
+// CreateAllWithContext.
 func (m *Manager) CreateAll(args ...interface{}) error {
+	return m.CreateAllWithContext(contextFromArgs(args), args...)
+}
+
+// CreateAllWithContext is CreateAll with a context that is available to
+// the lifecycle functions in place of any context.Context in args.
+//
+// Once ctx is cancelled, no further resources are created. The functions
+// that are already running are expected to return when their context is
+// cancelled. The resources that were created are then rolled back like for
+// any other error, with a context that isn't cancelled, and the error of
+// ctx is returned.
+func (m *Manager) CreateAllWithContext(ctx context.Context, args ...interface{}) error {
 	if err := m.Validate(); err != nil {
 		return err
 	}
+	args = withContext(ctx, args)
+
+	// Tainted resources are destroyed first so that they're recreated.
+	if err := m.destroyTainted(ctx, args); err != nil {
+		return err
+	}
 
 	// We need to build up the final function in our argmapper chain. This
 	// function will do nothing, but will take as an input all the marker
@@ -182,15 +205,12 @@ func (m *Manager) CreateAll(args ...interface{}) error {
 	m.createState = &createState{}
 
 	// Start building our arguments
-	mapperArgs, err := m.mapperArgs()
+	mapperArgs, err := m.mapperArgs(args)
 	if err != nil {
 		return err
 	}
-	for _, arg := range args {
-		mapperArgs = append(mapperArgs, argmapper.Typed(arg))
-	}
 	for _, r := range m.resources {
-		createFunc, err := r.mapperForCreate(m.createState)
+		createFunc, err := r.mapperForCreate(ctx, m.createState)
 		if err != nil {
 			return err
 		}
@@ -203,19 +223,17 @@ func (m *Manager) CreateAll(args ...interface{}) error {
 	// If we got an error, perform an automatic rollback.
 	resultErr := result.Err()
 	if resultErr != nil {
-		m.logger.Info("error during creation, starting rollback", "err", resultErr)
-		if err := m.DestroyAll(args...); err != nil {
-			m.logger.Warn("error during rollback", "err", err)
-			resultErr = multierror.Append(resultErr, fmt.Errorf(
-				"Error during rollback: %w", err))
-		} else {
-			m.logger.Info("rollback successful")
-		}
+		resultErr = m.rollback(ctx, args, resultErr)
 	}
 
 	// Now that resource state has been filled, populate the declared resource response if available.
 	if m.dcr != nil {
 		for name, resource := range m.resources {
+			// Data sources don't create anything so there is nothing to declare.
+			if resource.dataFunc != nil {
+				continue
+			}
+
 			declaredResource, err := resource.DeclaredResource()
 			if err != nil {
 				// Will likely only occur when developing plugins.
@@ -242,10 +260,24 @@ func (m *Manager) CreateAll(args ...interface{}) error {
 // that if Create partially failed, then only the resources that attempted
 // creation will have Destroy called. Resources that were never called to
 // Create will do nothing.
+//
+// If a context.Context is in args, it is used like the context given to
+// DestroyAllWithContext.
 func (m *Manager) DestroyAll(args ...interface{}) error {
+	return m.DestroyAllWithContext(contextFromArgs(args), args...)
+}
+
+// DestroyAllWithContext is DestroyAll with a context that is available to
+// the lifecycle functions in place of any context.Context in args.
+//
+// Once ctx is cancelled, no further resources are destroyed and the error
+// of ctx is returned. The resources that weren't destroyed keep their
+// state, so calling DestroyAll again resumes the destroy.
+func (m *Manager) DestroyAllWithContext(ctx context.Context, args ...interface{}) error {
 	if err := m.Validate(); err != nil {
 		return err
 	}
+	args = withContext(ctx, args)
 
 	cs := m.createState
 	if cs == nil || len(cs.Order) == 0 {
@@ -283,36 +315,118 @@ func (m *Manager) DestroyAll(args ...interface{}) error {
 		})
 	}
 
-	var finalInputs []argmapper.Value
-	mapperArgs, err := m.mapperArgs()
+	resultErr := m.destroy(ctx, cs.Order, args)
+	if resultErr != nil {
+		m.logger.Info("error during destruction", "err", resultErr)
+	} else {
+		// If this was successful, then we clear out our creation state.
+		m.createState = nil
+	}
+
+	// Populate the declared/destroyed resources. The declared resources are the resources
+	// which remain after destroying, and the destroyed resources are the ones that have
+	// been destroyed (which implement WithDestroy). If a resource does not implement a
+	// destroy function, then it is a declaredResource. If it does, it's a destroyedResource
+	if m.dcr != nil || m.dtr != nil {
+		for name, resource := range m.resources {
+			if resource.dataFunc != nil {
+				continue
+			}
+
+			if m.dtr != nil && (resource.destroyFunc != nil || len(resource.destroyPhases) > 0) {
+				destroyedResource, err := resource.DestroyedResource()
+				if err != nil {
+					m.logger.Debug("Failed to convert resource to a DestroyedResource proto message",
+						"resource name", name,
+						"error", err,
+					)
+					return err
+				}
+
+				m.dtr.DestroyedResources = append(m.dtr.DestroyedResources, destroyedResource)
+			} else if m.dcr != nil && resource.createFunc != nil {
+				declaredResource, err := resource.DeclaredResource()
+				if err != nil {
+					m.logger.Debug("Failed to convert resource to a DeclaredResource proto message",
+						"resource name", name,
+						"error", err,
+					)
+					return err
+				}
+				m.dcr.DeclaredResources = append(m.dcr.DeclaredResources, declaredResource)
+			}
+		}
+	}
+
+	return resultErr
+}
+
+// destroy destroys the resources with the given names, which are in
+// creation order, in reverse order.
+func (m *Manager) destroy(ctx context.Context, order []string, args []interface{}) error {
+	mapperArgs, err := m.mapperArgs(args)
 	if err != nil {
 		return err
 	}
-	for _, arg := range args {
-		mapperArgs = append(mapperArgs, argmapper.Typed(arg))
+
+	// Data sources are available so that destroy functions can use the
+	// values they produce. They're only called if something requires them.
+	dataArgs, err := m.dataSourceArgs()
+	if err != nil {
+		return err
+	}
+	mapperArgs = append(mapperArgs, dataArgs...)
+
+	// Resources with destroy phases are destroyed in rounds. Every resource
+	// runs its first phase before any resource runs its second.
+	rounds := 1
+	for _, n := range order {
+		if r := m.Resource(n); r != nil && len(r.destroyPhases) > rounds {
+			rounds = len(r.destroyPhases)
+		}
+	}
+
+	for round := 0; round < rounds; round++ {
+		if err := m.destroyRound(ctx, order, round, rounds, mapperArgs); err != nil {
+			return err
+		}
 	}
 
-	// Go through our creation order and create all our destroyers.
-	for i := 0; i < len(cs.Order); i++ {
-		r := m.Resource(cs.Order[i])
+	return nil
+}
+
+// destroyRound calls the destroy functions of the resources in order for
+// one round of a destroy. See WithDestroyPhases.
+func (m *Manager) destroyRound(
+	ctx context.Context,
+	order []string,
+	round, rounds int,
+	baseArgs []argmapper.Arg,
+) error {
+	// Copy our args since the markers of each round must be distinct.
+	mapperArgs := append([]argmapper.Arg{}, baseArgs...)
+
+	var finalInputs []argmapper.Value
+	for i := 0; i < len(order); i++ {
+		r := m.Resource(order[i])
 		if r == nil {
 			// We are missing a resource that we should be destroying.
 			return fmt.Errorf(
 				"destroy failed: missing resource definition %q",
-				cs.Order[i],
+				order[i],
 			)
 		}
 
 		// The dependencies are the resources that were created after
 		// this resource.
 		var deps []string
-		if next := i + 1; next < len(cs.Order) {
-			deps = cs.Order[next:]
+		if next := i + 1; next < len(order) {
+			deps = order[next:]
 		}
 
 		// Create the mapper for destroy. The dependencies are the set of
 		// created resources in the creation order that were ahead of this one.
-		f, err := r.mapperForDestroy(deps)
+		f, err := r.mapperForDestroy(ctx, deps, round, rounds)
 		if err != nil {
 			return err
 		}
@@ -348,46 +462,6 @@ func (m *Manager) DestroyAll(args ...interface{}) error {
 
 	// Call it
 	result := finalFunc.Call(mapperArgs...)
-
-	resultErr := result.Err()
-	if resultErr != nil {
-		m.logger.Info("error during destruction", "err", resultErr)
-	} else {
-		// If this was successful, then we clear out our creation state.
-		m.createState = nil
-	}
-
-	// Populate the declared/destroyed resources. The declared resources are the resources
-	// which remain after destroying, and the destroyed resources are the ones that have
-	// been destroyed (which implement WithDestroy). If a resource does not implement a
-	// destroy function, then it is a declaredResource. If it does, it's a destroyedResource
-	if m.dcr != nil || m.dtr != nil {
-		for name, resource := range m.resources {
-			if m.dtr != nil && resource.destroyFunc != nil {
-				destroyedResource, err := resource.DestroyedResource()
-				if err != nil {
-					m.logger.Debug("Failed to convert resource to a DestroyedResource proto message",
-						"resource name", name,
-						"error", err,
-					)
-					return err
-				}
-
-				m.dtr.DestroyedResources = append(m.dtr.DestroyedResources, destroyedResource)
-			} else if m.dcr != nil && resource.createFunc != nil {
-				declaredResource, err := resource.DeclaredResource()
-				if err != nil {
-					m.logger.Debug("Failed to convert resource to a DeclaredResource proto message",
-						"resource name", name,
-						"error", err,
-					)
-					return err
-				}
-				m.dcr.DeclaredResources = append(m.dcr.DeclaredResources, declaredResource)
-			}
-		}
-	}
-
 	return result.Err()
 }
 
@@ -460,12 +534,16 @@ func healthSummary(resources []*pb.StatusReport_Resource) (
 // will give more details.
 // If your plugin wishes to use a different algorithm for determining overall health, you may
 // modify this report before returning from your status function.
+//
+// If one of the args is a *component.StatusFilter, only the resources it
+// selects are in the report and the report is paginated. The overall
+// health is still determined from all resources.
 func (m *Manager) StatusReport(args ...interface{}) (*pb.StatusReport, error) {
 	if err := m.Validate(); err != nil {
 		return nil, err
 	}
 
-	resources, err := m.StatusAll(args...)
+	resources, err := m.statusAll(args)
 	if err != nil {
 		return nil, fmt.Errorf("failed generating resource statuses: %s", err)
 	}
@@ -476,13 +554,18 @@ func (m *Manager) StatusReport(args ...interface{}) (*pb.StatusReport, error) {
 		return nil, err
 	}
 
-	return &pb.StatusReport{
+	report := &pb.StatusReport{
 		External:      true,
 		GeneratedTime: timestamppb.Now(),
 		Resources:     resources,
 		Health:        health,
 		HealthMessage: healthMessage,
-	}, nil
+	}
+	if err := StatusFilterArg(args).Apply(report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
 }
 
 // StatusAll invokes the statusFunc method of all the resources under management.
@@ -491,22 +574,60 @@ func (m *Manager) StatusReport(args ...interface{}) (*pb.StatusReport, error) {
 // resource. All the state that was created via Create will be available to the
 // Status callbacks, if any. Resources are not required to have a state to have
 // a status. Returns a slice of reports or an error.
+//
+// The returned reports are always sorted by the name of the resource that
+// reported them and then by report name. Reports with the same name and ID
+// are only returned once, even if a status function reported them more than
+// once.
+//
+// If one of the args is a *component.StatusFilter, only the reports it
+// selects are returned. The reports aren't paginated.
 func (m *Manager) StatusAll(args ...interface{}) ([]*pb.StatusReport_Resource, error) {
 	if err := m.Validate(); err != nil {
 		return nil, err
 	}
 
-	mapperArgs, err := m.mapperArgs()
+	reports, err := m.statusAll(args)
 	if err != nil {
 		return nil, err
 	}
+
+	return StatusFilterArg(args).Filter(reports), nil
+}
+
+// StatusFilterArg returns the first *component.StatusFilter in args, or nil
+// if there isn't one.
+func StatusFilterArg(args []interface{}) *component.StatusFilter {
 	for _, arg := range args {
-		mapperArgs = append(mapperArgs, argmapper.Typed(arg))
+		if f, ok := arg.(*component.StatusFilter); ok && f != nil {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// statusAll returns the reports of all resources, unfiltered.
+func (m *Manager) statusAll(args []interface{}) ([]*pb.StatusReport_Resource, error) {
+	mapperArgs, err := m.mapperArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	dataArgs, err := m.dataSourceArgs()
+	if err != nil {
+		return nil, err
 	}
+	mapperArgs = append(mapperArgs, dataArgs...)
 
 	var finalInputs []argmapper.Value
 	// Go through available resources.
 	for _, r := range m.resources {
+		// Data sources have no status to report.
+		if r.dataFunc != nil {
+			continue
+		}
+
 		// Create the mapper for status
 		f, err := r.mapperForStatus()
 		if err != nil {
@@ -558,12 +679,65 @@ func (m *Manager) StatusAll(args ...interface{}) ([]*pb.StatusReport_Resource, e
 			reports = append(reports, st.Resources...)
 		}
 	}
-	return reports, nil
+
+	return sortStatusReports(reports), nil
 }
 
-func (m *Manager) mapperArgs() ([]argmapper.Arg, error) {
+// sortStatusReports sorts the reports by declared resource name and then
+// by report name (and platform and ID for reports with the same name) so
+// that the result is deterministic. Reports of the same declared resource
+// with the same name, platform and ID are de-duplicated, keeping the first
+// after sorting. Different declared resources may report resources with
+// the same name, so those are all kept.
+func sortStatusReports(reports []*pb.StatusReport_Resource) []*pb.StatusReport_Resource {
+	sort.SliceStable(reports, func(i, j int) bool {
+		a, b := reports[i], reports[j]
+		if an, bn := a.DeclaredResource.GetName(), b.DeclaredResource.GetName(); an != bn {
+			return an < bn
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Platform != b.Platform {
+			return a.Platform < b.Platform
+		}
+
+		return a.Id < b.Id
+	})
+
+	type key struct{ declared, platform, name, id string }
+	seen := map[key]struct{}{}
+	result := reports[:0]
+	for _, r := range reports {
+		k := key{
+			declared: r.DeclaredResource.GetName(),
+			platform: r.Platform,
+			name:     r.Name,
+			id:       r.Id,
+		}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+
+		seen[k] = struct{}{}
+		result = append(result, r)
+	}
+
+	return result
+}
+
+// mapperArgs returns the arguments for the lifecycle functions of an
+// operation that was given args.
+func (m *Manager) mapperArgs(args []interface{}) ([]argmapper.Arg, error) {
 	result := []argmapper.Arg{
 		argmapper.Logger(m.logger),
+
+		// Nested managers are called with the same args. See
+		// WithNestedManager.
+		argmapper.Typed(&nestedArgs{args: args}),
+	}
+	for _, arg := range args {
+		result = append(result, argmapper.Typed(arg))
 	}
 
 	// Add our value providers which are always available
@@ -579,6 +753,51 @@ func (m *Manager) mapperArgs() ([]argmapper.Arg, error) {
 	return result, nil
 }
 
+// dataSourceArgs returns the converters for all the data sources under
+// management. This is used for operations other than creation where data
+// sources should only be called if another function requires their values.
+func (m *Manager) dataSourceArgs() ([]argmapper.Arg, error) {
+	var result []argmapper.Arg
+	for _, r := range m.resources {
+		if r.dataFunc == nil {
+			continue
+		}
+
+		f, err := r.mapperForData()
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, argmapper.ConverterFunc(f))
+	}
+
+	return result, nil
+}
+
+// contextFromArgs returns the last context.Context in args, or
+// context.Background if there is none.
+func contextFromArgs(args []interface{}) context.Context {
+	for i := len(args) - 1; i >= 0; i-- {
+		if ctx, ok := args[i].(context.Context); ok && ctx != nil {
+			return ctx
+		}
+	}
+
+	return context.Background()
+}
+
+// withContext returns args with ctx in place of any context.Context.
+func withContext(ctx context.Context, args []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(args)+1)
+	for _, arg := range args {
+		if _, ok := arg.(context.Context); !ok {
+			result = append(result, arg)
+		}
+	}
+
+	return append(result, ctx)
+}
+
 // ManagerOption is used to configure NewManager.
 type ManagerOption func(*Manager)
 
@@ -615,7 +834,11 @@ func WithResource(r *Resource) ManagerOption {
 // depend on the value it returns).
 //
 // The argument f should be a function. The function may accept arguments
-// from any other value providers as well.
+// from any other value providers as well as any arguments given to the
+// lifecycle functions such as CreateAll. Notably, if a terminal.UI is given
+// to CreateAll, DestroyAll, or StatusAll, it can be requested by the value
+// provider to tell the user what it is doing, such as opening a tunnel or
+// logging into a registry.
 func WithValueProvider(f interface{}) ManagerOption {
 	// NOTE(mitchellh): In the future, we can probably do something fancier
 	// here so that if any values returned by this implement io.Closer we will
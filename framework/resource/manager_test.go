@@ -1,16 +1,21 @@
 package resource
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
 )
@@ -192,6 +197,420 @@ func TestManagerCreateAll(t *testing.T) {
 		// Ensure we have no state
 		require.NotNil(m.State())
 	})
+
+	t.Run("rollback policies", func(t *testing.T) {
+		cases := []struct {
+			Policy     RollbackPolicy
+			Destroyed  []string
+			RolledBack []string
+			Kept       []string
+		}{
+			{RollbackAlways, []string{"B", "A"}, []string{"A", "B"}, nil},
+			{RollbackOnlyFailed, []string{"B"}, []string{"B"}, []string{"A"}},
+			{RollbackNever, nil, nil, []string{"A", "B"}},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.Policy.String(), func(t *testing.T) {
+				require := require.New(t)
+
+				var destroyOrder []string
+				m := NewManager(
+					WithRollbackPolicy(tt.Policy),
+					WithResource(NewResource(
+						WithName("A"),
+						WithState(&testState{}),
+						WithCreate(func(s *testState, v int) error {
+							s.Value = v
+							return nil
+						}),
+						WithDestroy(func() error {
+							destroyOrder = append(destroyOrder, "A")
+							return nil
+						}),
+					)),
+
+					WithResource(NewResource(
+						WithName("B"),
+						WithCreate(func(s *testState) error {
+							return errors.New("whelp")
+						}),
+						WithDestroy(func() error {
+							destroyOrder = append(destroyOrder, "B")
+							return nil
+						}),
+					)),
+				)
+
+				err := m.CreateAll(int(42))
+				require.Error(err)
+				require.Equal("whelp", err.Error())
+
+				var rollbackErr *RollbackError
+				require.True(errors.As(err, &rollbackErr))
+				require.Equal(tt.RolledBack, rollbackErr.RolledBack)
+				require.Equal(tt.Kept, rollbackErr.Kept)
+				require.NoError(rollbackErr.RollbackErr)
+				require.Equal(tt.Destroyed, destroyOrder)
+
+				// The kept resources are destroyed later
+				destroyOrder = nil
+				require.NoError(m.DestroyAll())
+				if len(tt.Kept) > 0 {
+					require.Contains(destroyOrder, "A")
+				}
+			})
+		}
+	})
+
+	t.Run("rollback keeps the status code", func(t *testing.T) {
+		require := require.New(t)
+
+		m := NewManager(
+			WithResource(NewResource(
+				WithName("A"),
+				WithState(&testState{}),
+				WithCreate(func(s *testState, v int) error {
+					return status.Error(codes.PermissionDenied, "denied")
+				}),
+				WithDestroy(func() error { return nil }),
+			)),
+		)
+
+		err := m.CreateAll(int(42))
+		require.Error(err)
+
+		var rollbackErr *RollbackError
+		require.True(errors.As(err, &rollbackErr))
+		require.Equal(codes.PermissionDenied, status.Code(err))
+		require.Equal("denied", status.Convert(err).Message())
+	})
+
+	t.Run("with a data source", func(t *testing.T) {
+		require := require.New(t)
+
+		type zoneId string
+
+		var dcr component.DeclaredResourcesResp
+		var calledA zoneId
+		m := NewManager(
+			WithDeclaredResourcesResp(&dcr),
+			WithResource(NewDataSource("zone", func(v int) (zoneId, error) {
+				return zoneId(fmt.Sprintf("zone-%d", v)), nil
+			})),
+
+			WithResource(NewResource(
+				WithName("A"),
+				WithCreate(func(z zoneId) error {
+					calledA = z
+					return nil
+				}),
+			)),
+		)
+
+		// Create
+		require.NoError(m.CreateAll(int(42)))
+
+		// Ensure the data source value was used
+		require.Equal(zoneId("zone-42"), calledA)
+
+		// Ensure only the real resource is declared
+		require.Len(dcr.DeclaredResources, 1)
+		require.Equal("A", dcr.DeclaredResources[0].Name)
+
+		// Ensure the data source isn't part of the create order
+		require.Equal([]string{"A"}, m.createState.Order)
+	})
+
+	t.Run("with a data source error", func(t *testing.T) {
+		require := require.New(t)
+
+		type zoneId string
+
+		m := NewManager(
+			WithResource(NewDataSource("zone", func() (zoneId, error) {
+				return "", errors.New("not found")
+			})),
+
+			WithResource(NewResource(
+				WithName("A"),
+				WithCreate(func(z zoneId) error {
+					return nil
+				}),
+			)),
+		)
+
+		err := m.CreateAll()
+		require.Error(err)
+		require.Contains(err.Error(), "not found")
+	})
+}
+
+func TestManagerCreateAll_cancel(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calledB bool
+	var destroyErr error
+	m := NewManager(
+		WithResource(NewResource(
+			WithName("A"),
+			WithState(&testState{}),
+			WithCreate(func(ctx context.Context, s *testState) error {
+				// The operation is cancelled while A is being created
+				cancel()
+				s.Value = 1
+				return nil
+			}),
+			WithDestroy(func(ctx context.Context) error {
+				destroyErr = ctx.Err()
+				return nil
+			}),
+		)),
+
+		WithResource(NewResource(
+			WithName("B"),
+			WithCreate(func(s *testState) error {
+				calledB = true
+				return nil
+			}),
+		)),
+	)
+
+	err := m.CreateAllWithContext(ctx)
+	require.ErrorIs(err, context.Canceled)
+
+	// B was never created and A was rolled back with a live context
+	require.False(calledB)
+	require.NoError(destroyErr)
+	require.Nil(m.Resource("A").State().(*testState))
+}
+
+func TestManagerDestroyAll_cancel(t *testing.T) {
+	require := require.New(t)
+
+	var destroyed []string
+	resource := func(name string) *Resource {
+		return NewResource(
+			WithName(name),
+			WithState(&testproto.Data{}),
+			WithCreate(func(s *testproto.Data) error { return nil }),
+			WithDestroy(func() error {
+				destroyed = append(destroyed, name)
+				return nil
+			}),
+		)
+	}
+
+	m := NewManager(WithResource(resource("A")))
+	require.NoError(m.CreateAll())
+
+	// A cancelled context destroys nothing and keeps the state
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(m.DestroyAll(ctx), context.Canceled)
+	require.Empty(destroyed)
+	require.NotNil(m.Resource("A").State().(*testproto.Data))
+
+	// The destroy can be resumed
+	require.NoError(m.DestroyAllWithContext(context.Background()))
+	require.Equal([]string{"A"}, destroyed)
+}
+
+func TestManagerCreateAll_waiter(t *testing.T) {
+	t.Run("waits after create", func(t *testing.T) {
+		require := require.New(t)
+
+		var order []string
+		var waited string
+		m := NewManager(
+			WithResource(NewResource(
+				WithName("A"),
+				WithState(&testState{}),
+				WithCreate(func(s *testState, v int) error {
+					order = append(order, "create")
+					s.Value = v
+					return nil
+				}),
+				WithWaiter(func(ctx context.Context, s *testState, name string) error {
+					order = append(order, "wait")
+					require.NotNil(ctx)
+					require.Equal(42, s.Value)
+					waited = name
+					return nil
+				}),
+			)),
+		)
+
+		require.NoError(m.CreateAll(int(42), "web"))
+		require.Equal([]string{"create", "wait"}, order)
+		require.Equal("web", waited)
+	})
+
+	t.Run("error rolls back", func(t *testing.T) {
+		require := require.New(t)
+
+		destroyed := false
+		m := NewManager(
+			WithResource(NewResource(
+				WithName("A"),
+				WithState(&testState{}),
+				WithCreate(func(s *testState) error { return nil }),
+				WithDestroy(func(s *testState) error {
+					destroyed = true
+					return nil
+				}),
+				WithWaiter(func() error { return errors.New("never ready") }),
+			)),
+		)
+
+		err := m.CreateAll()
+		require.Error(err)
+		require.Contains(err.Error(), "never ready")
+		require.True(destroyed)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		require := require.New(t)
+
+		m := NewManager(
+			WithResource(NewResource(
+				WithName("A"),
+				WithCreate(func() error { return nil }),
+				WithWaiter(func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}, WithWaitTimeout(10*time.Millisecond)),
+			)),
+		)
+
+		err := m.CreateAll(context.Background())
+		require.Error(err)
+		require.Contains(err.Error(), "timed out")
+		require.True(errors.Is(err, context.DeadlineExceeded))
+	})
+}
+
+func TestManagerCreateAll_postCreate(t *testing.T) {
+	t.Run("validates after create", func(t *testing.T) {
+		require := require.New(t)
+
+		var order []string
+		m := NewManager(
+			WithResource(NewResource(
+				WithName("A"),
+				WithState(&testState{}),
+				WithCreate(func(s *testState, v int) error {
+					order = append(order, "create")
+					s.Value = v
+					return nil
+				}),
+				WithWaiter(func() error {
+					order = append(order, "wait")
+					return nil
+				}),
+				WithPostCreate(func(s *testState) error {
+					order = append(order, "check value")
+					require.Equal(42, s.Value)
+					return nil
+				}),
+				WithPostCreate(func(s *testState, name string) error {
+					order = append(order, "check name")
+					require.Equal("web", name)
+					return nil
+				}),
+			)),
+		)
+
+		require.NoError(m.CreateAll(int(42), "web"))
+		require.Equal([]string{"create", "wait", "check value", "check name"}, order)
+	})
+
+	t.Run("error rolls back", func(t *testing.T) {
+		require := require.New(t)
+
+		destroyed := false
+		checked := false
+		m := NewManager(
+			WithResource(NewResource(
+				WithName("A"),
+				WithState(&testState{}),
+				WithCreate(func(s *testState) error { return nil }),
+				WithDestroy(func(s *testState) error {
+					destroyed = true
+					return nil
+				}),
+				WithPostCreate(func() error { return errors.New("wrong scheme") }),
+				WithPostCreate(func() error {
+					checked = true
+					return nil
+				}),
+			)),
+		)
+
+		err := m.CreateAll()
+		require.Error(err)
+		require.Contains(err.Error(), "wrong scheme")
+		require.Contains(err.Error(), `resource "A"`)
+		require.True(destroyed)
+		require.False(checked)
+	})
+
+	t.Run("data source", func(t *testing.T) {
+		r := NewDataSource("A", func() int { return 42 },
+			WithPostCreate(func() error { return nil }))
+		require.Error(t, r.Validate())
+	})
+}
+
+func TestManager_valueProviderUI(t *testing.T) {
+	require := require.New(t)
+
+	type client struct{ ui terminal.UI }
+
+	var calls int
+	init := func() *Manager {
+		return NewManager(
+			WithValueProvider(func(ui terminal.UI) (*client, error) {
+				calls++
+				ui.Output("connecting")
+				return &client{ui: ui}, nil
+			}),
+
+			WithResource(NewResource(
+				WithName("A"),
+				WithState(&testproto.Data{}),
+				WithCreate(func(s *testproto.Data, c *client) error {
+					require.NotNil(c.ui)
+					s.Value = "created"
+					return nil
+				}),
+				WithDestroy(func(s *testproto.Data, c *client) error {
+					require.NotNil(c.ui)
+					return nil
+				}),
+				WithStatus(func(s *testproto.Data, c *client, sr *StatusResponse) error {
+					require.NotNil(c.ui)
+					return nil
+				}),
+			)),
+		)
+	}
+
+	ui := terminal.NonInteractiveUI(context.Background())
+
+	m := init()
+	require.NoError(m.CreateAll(ui))
+	require.Equal(1, calls)
+
+	_, err := m.StatusAll(ui)
+	require.NoError(err)
+	require.Equal(2, calls)
+
+	require.NoError(m.DestroyAll(ui))
+	require.Equal(3, calls)
 }
 
 func TestManagerDestroyAll(t *testing.T) {
@@ -311,6 +730,78 @@ func TestManagerDestroyAll_noDestroyFunc(t *testing.T) {
 	require.Equal([]string{"B"}, destroyOrder)
 }
 
+func TestManagerDestroyAll_phases(t *testing.T) {
+	require := require.New(t)
+
+	var destroyOrder []string
+	failDelete := true
+	phase := func(name string) DestroyPhase {
+		return DestroyPhase{
+			Name: name,
+			Func: func(s *testproto.Data) error {
+				destroyOrder = append(destroyOrder, name)
+				return nil
+			},
+		}
+	}
+
+	init := func() *Manager {
+		return NewManager(
+			WithResource(NewResource(
+				WithName("A"),
+				WithState(&testproto.Data{}),
+				WithCreate(func(s *testproto.Data, v int32) error {
+					s.Number = v
+					return nil
+				}),
+				WithDestroyPhases(
+					phase("A deregister"),
+					phase("A delete"),
+				),
+			)),
+
+			WithResource(NewResource(
+				WithName("B"),
+				WithCreate(func(s *testproto.Data) error {
+					return nil
+				}),
+				WithDestroyPhases(
+					phase("B deregister"),
+					DestroyPhase{
+						Name: "delete",
+						Func: func() error {
+							destroyOrder = append(destroyOrder, "B delete")
+							if failDelete {
+								return errors.New("interrupted")
+							}
+
+							return nil
+						},
+					},
+				),
+			)),
+		)
+	}
+
+	m := init()
+	require.NoError(m.CreateAll(int32(42)))
+
+	// Every resource runs its first phase before the second
+	require.Error(m.DestroyAll())
+	require.Equal([]string{
+		"B deregister", "A deregister", "B delete",
+	}, destroyOrder)
+
+	// Resuming with the state skips the completed phases
+	destroyOrder = nil
+	failDelete = false
+	m2 := init()
+	require.NoError(m2.LoadState(m.State()))
+	require.NoError(m2.DestroyAll())
+	require.Equal([]string{"B delete", "A delete"}, destroyOrder)
+	require.Nil(m2.Resource("A").State())
+}
+
 func TestManagerDestroyAll_loadState(t *testing.T) {
 	require := require.New(t)
 
@@ -363,6 +854,312 @@ func TestManagerDestroyAll_loadState(t *testing.T) {
 	require.Equal(destroyState, int32(42))
 }
 
+func TestManagerCreateAll_taint(t *testing.T) {
+	require := require.New(t)
+
+	var created, destroyed []int32
+	init := func() *Manager {
+		return NewManager(
+			WithResource(NewResource(
+				WithName("A"),
+				WithState(&testproto.Data{}),
+				WithCreate(func(s *testproto.Data, v int32) error {
+					created = append(created, s.Number)
+					s.Number = v
+					return nil
+				}),
+				WithDestroy(func(s *testproto.Data) error {
+					destroyed = append(destroyed, s.Number)
+					return nil
+				}),
+			)),
+
+			WithResource(NewResource(
+				WithName("B"),
+				WithCreate(func(s *testproto.Data) error {
+					return nil
+				}),
+				WithDestroy(func() error {
+					t.Fatal("untainted resource destroyed")
+					return nil
+				}),
+			)),
+		)
+	}
+
+	m := init()
+	require.Error(m.Taint("C"))
+	require.NoError(m.CreateAll(int32(42)))
+	require.NoError(m.Taint("A"))
+	require.True(m.Resource("A").Tainted())
+	require.False(m.Resource("B").Tainted())
+
+	// The taint is kept in the state.
+	m2 := init()
+	require.NoError(m2.LoadState(m.State()))
+	require.True(m2.Resource("A").Tainted())
+
+	// Updating the resource destroys it and creates it from a zero state.
+	m2.Resource("A").update = true
+	require.NoError(m2.CreateAll(int32(12)))
+	require.Equal([]int32{42}, destroyed)
+	require.Equal([]int32{0, 0}, created)
+	require.False(m2.Resource("A").Tainted())
+	require.Equal(int32(12), m2.Resource("A").State().(*testproto.Data).Number)
+
+	// The resource is recreated once.
+	require.NoError(m2.CreateAll(int32(12)))
+	require.Equal([]int32{42}, destroyed)
+	require.Equal([]int32{0, 0, 12}, created)
+}
+
+func TestManager_nested(t *testing.T) {
+	require := require.New(t)
+
+	var calls []string
+	bundle := func() *Manager {
+		return NewManager(
+			WithResource(NewResource(
+				WithName("listener"),
+				WithState(&testproto.A{}),
+				WithCreate(func(s *testproto.A, v int) error {
+					calls = append(calls, "create listener")
+					s.Value = int32(v)
+					return nil
+				}),
+				WithDestroy(func(s *testproto.A) error {
+					calls = append(calls, fmt.Sprintf("destroy listener %d", s.Value))
+					return nil
+				}),
+				WithStatus(func(sr *StatusResponse) error {
+					sr.Resources = append(sr.Resources, &pb.StatusReport_Resource{
+						Name:   "listener",
+						Health: pb.StatusReport_READY,
+					})
+					return nil
+				}),
+			)),
+			WithResource(NewResource(
+				WithName("target group"),
+				WithState(&testproto.B{}),
+				WithCreate(func(s *testproto.B, a *testproto.A) error {
+					calls = append(calls, "create target group")
+					s.Value = a.Value + 1
+					return nil
+				}),
+				WithDestroy(func(s *testproto.B) error {
+					calls = append(calls, fmt.Sprintf("destroy target group %d", s.Value))
+					return nil
+				}),
+			)),
+		)
+	}
+
+	init := func() *Manager {
+		return NewManager(
+			WithResource(NewResource(
+				WithName("alb"),
+				WithNestedManager(bundle()),
+			)),
+			WithResource(NewResource(
+				WithName("service"),
+				WithState(&testproto.Data{}),
+				WithCreate(func(s *testproto.Data) error {
+					s.Value = "service"
+					return nil
+				}),
+			)),
+		)
+	}
+
+	// The nested manager is created with the args of the parent
+	var dcr component.DeclaredResourcesResp
+	m := init()
+	WithDeclaredResourcesResp(&dcr)(m)
+	require.NoError(m.CreateAll(42))
+	require.Equal([]string{"create listener", "create target group"}, calls)
+	require.Len(dcr.DeclaredResources, 2)
+
+	nested := m.Resource("alb").State().(*pb.Framework_ResourceManagerState)
+	require.Len(nested.Resources, 2)
+
+	// The status of the nested resources is reported by the parent
+	reports, err := m.StatusAll()
+	require.NoError(err)
+	require.Len(reports, 1)
+	require.Equal("listener", reports[0].Name)
+	require.Equal("alb", reports[0].DeclaredResource.Name)
+
+	// The nested state is loaded with the parent state
+	calls = nil
+	m2 := init()
+	require.NoError(m2.LoadState(m.State()))
+	require.NoError(m2.DestroyAll())
+	require.Equal([]string{"destroy target group 43", "destroy listener 42"}, calls)
+	require.Nil(m2.Resource("alb").State())
+
+	// The nested manager is validated with the parent
+	m3 := NewManager(WithResource(NewResource(
+		WithName("bad"),
+		WithNestedManager(NewManager(WithResource(NewResource()))),
+	)))
+	require.Error(m3.Validate())
+}
+
+func TestManagerRefreshAll(t *testing.T) {
+	require := require.New(t)
+
+	// infra is the actual infrastructure, keyed by resource name
+	infra := map[string]int32{}
+	var destroyed []string
+	resource := func(name string) *Resource {
+		return NewResource(
+			WithName(name),
+			WithState(&testproto.Data{}),
+			WithCreate(func(s *testproto.Data, v int32) error {
+				s.Number = v
+				infra[name] = v
+				return nil
+			}),
+			WithDestroy(func(s *testproto.Data) error {
+				destroyed = append(destroyed, name)
+				delete(infra, name)
+				return nil
+			}),
+			WithRead(func(s *testproto.Data) error {
+				v, ok := infra[name]
+				if !ok {
+					return fmt.Errorf("reading %s: %w", name, ErrResourceNotFound)
+				}
+
+				s.Number = v
+				return nil
+			}),
+		)
+	}
+
+	m := NewManager(
+		WithResource(resource("A")),
+		WithResource(resource("B")),
+	)
+	require.NoError(m.CreateAll(int32(42)))
+
+	// No drift right after creation
+	report, err := m.RefreshAll()
+	require.NoError(err)
+	require.False(report.HasDrift())
+
+	// A manager with more resources loads the state
+	m2 := NewManager(
+		WithResource(resource("A")),
+		WithResource(resource("B")),
+		WithResource(resource("C")),
+		WithResource(NewResource(
+			WithName("D"),
+			WithState(&testproto.Data{}),
+			WithCreate(func(s *testproto.Data) {}),
+		)),
+	)
+	require.NoError(m2.LoadState(m.State()))
+
+	// A changed, B was deleted and C was created outside of Waypoint
+	infra["A"] = 7
+	delete(infra, "B")
+	infra["C"] = 42
+
+	report, err = m2.RefreshAll()
+	require.NoError(err)
+	require.True(report.HasDrift())
+	require.Equal([]string{"B"}, report.Missing)
+	require.Equal([]string{"C"}, report.Unmanaged)
+	require.Len(report.Changed, 1)
+	require.Equal("A", report.Changed[0].Name)
+	require.Equal(int32(42), report.Changed[0].Previous.(*testproto.Data).Number)
+	require.Equal(int32(7), report.Changed[0].Current.(*testproto.Data).Number)
+
+	// The state was updated
+	require.Equal(int32(7), m2.Resource("A").State().(*testproto.Data).Number)
+	require.Nil(m2.Resource("B").State())
+	require.Nil(m2.Resource("C").State())
+
+	// Only the managed resources that exist are destroyed
+	require.NoError(m2.DestroyAll())
+	require.Equal([]string{"A"}, destroyed)
+	require.Equal(map[string]int32{"C": 42}, infra)
+
+	// Other errors fail the refresh
+	m3 := NewManager(WithResource(NewResource(
+		WithName("A"),
+		WithCreate(func() error { return nil }),
+		WithRead(func() error { return errors.New("boom") }),
+	)))
+	_, err = m3.RefreshAll()
+	require.Error(err)
+	require.Contains(err.Error(), "boom")
+}
+
+func TestManagerReport(t *testing.T) {
+	require := require.New(t)
+
+	init := func() *Manager {
+		return NewManager(
+			WithResource(NewResource(
+				WithName("A"),
+				WithState(&testproto.Data{}),
+				WithCreate(func(s *testproto.Data) error {
+					time.Sleep(10 * time.Millisecond)
+					return nil
+				}),
+				WithDestroy(func() error { return nil }),
+				WithStatus(func(sr *StatusResponse) error {
+					return errors.New("no status")
+				}),
+			)),
+
+			WithResource(NewResource(
+				WithName("B"),
+				WithCreate(func(s *testproto.Data) error { return nil }),
+			)),
+		)
+	}
+
+	m := init()
+	require.Empty(m.Report())
+	require.NoError(m.CreateAll())
+	_, err := m.StatusAll()
+	require.Error(err)
+
+	report := m.Report()
+	require.Len(report, 3)
+	require.Equal("A", report[0].Resource)
+	require.Equal(OperationCreate, report[0].Operation)
+	require.False(report[0].Failed)
+	require.GreaterOrEqual(report[0].Duration(), 10*time.Millisecond)
+	require.Equal("B", report[1].Resource)
+	require.Equal(OperationCreate, report[1].Operation)
+	require.Equal("A", report[2].Resource)
+	require.Equal(OperationStatus, report[2].Operation)
+	require.True(report[2].Failed)
+
+	// The timings are kept in the state
+	m2 := init()
+	require.NoError(m2.LoadState(m.State()))
+	report2 := m2.Report()
+	require.Len(report2, 3)
+	for i := range report {
+		require.Equal(report[i].Resource, report2[i].Resource)
+		require.Equal(report[i].Operation, report2[i].Operation)
+		require.True(report[i].Start.Equal(report2[i].Start))
+		require.Equal(report[i].Duration(), report2[i].Duration())
+	}
+
+	// Later calls replace the timing of the same operation
+	require.NoError(m2.DestroyAll())
+	report2 = m2.Report()
+	require.Len(report2, 4)
+	require.Equal(OperationDestroy, report2[3].Operation)
+}
+
 func TestManagerDestroyAll_destroyedResources(t *testing.T) {
 	require := require.New(t)
 
@@ -625,6 +1422,134 @@ func TestStatus_Manager(t *testing.T) {
 	require.NoError(m.DestroyAll())
 }
 
+// TestStatus_Manager_ordering tests that StatusAll returns reports in a
+// deterministic order without duplicates.
+func TestStatus_Manager_ordering(t *testing.T) {
+	require := require.New(t)
+
+	m := NewManager(
+		WithResource(NewResource(
+			WithName("B"),
+			WithCreate(func(v int) error { return nil }),
+			WithStatus(func(sr *StatusResponse) error {
+				sr.Resources = append(sr.Resources,
+					&pb.StatusReport_Resource{Name: "z", Id: "1"},
+					&pb.StatusReport_Resource{Name: "a", Id: "2"},
+					&pb.StatusReport_Resource{Name: "a", Id: "1"},
+					// duplicate
+					&pb.StatusReport_Resource{Name: "z", Id: "1"},
+				)
+				return nil
+			}),
+		)),
+		WithResource(NewResource(
+			WithName("A"),
+			WithCreate(func(v int) error { return nil }),
+			WithStatus(func(sr *StatusResponse) error {
+				sr.Resources = append(sr.Resources,
+					&pb.StatusReport_Resource{Name: "y"},
+					&pb.StatusReport_Resource{Name: "x"},
+					// same name as a resource of B
+					&pb.StatusReport_Resource{Name: "z", Id: "1"},
+				)
+				return nil
+			}),
+		)),
+	)
+	require.NoError(m.CreateAll(42))
+
+	// Run it a few times to make sure map ordering doesn't matter
+	for i := 0; i < 5; i++ {
+		reports, err := m.StatusAll()
+		require.NoError(err)
+
+		var actual []string
+		for _, r := range reports {
+			actual = append(actual, r.DeclaredResource.Name+"/"+r.Name+"/"+r.Id)
+		}
+		require.Equal([]string{
+			"A/x/",
+			"A/y/",
+			"A/z/1",
+			"B/a/1",
+			"B/a/2",
+			"B/z/1",
+		}, actual)
+	}
+}
+
+func TestStatus_Manager_filter(t *testing.T) {
+	require := require.New(t)
+
+	m := NewManager(
+		WithResource(NewResource(
+			WithName("A"),
+			WithCreate(func(v int) error { return nil }),
+			WithStatus(func(sr *StatusResponse) error {
+				for _, name := range []string{"a", "b", "c", "d"} {
+					sr.Resources = append(sr.Resources, &pb.StatusReport_Resource{
+						Name:   name,
+						Type:   "pod",
+						Health: pb.StatusReport_DOWN,
+					})
+				}
+				sr.Resources = append(sr.Resources, &pb.StatusReport_Resource{
+					Name:   "e",
+					Type:   "pod",
+					Health: pb.StatusReport_READY,
+				}, &pb.StatusReport_Resource{
+					Name:   "f",
+					Type:   "service",
+					Health: pb.StatusReport_DOWN,
+				})
+				return nil
+			}),
+		)),
+	)
+	require.NoError(m.CreateAll(42))
+
+	filter := &component.StatusFilter{
+		Types:    []string{"pod"},
+		Health:   component.UnhealthyStatus,
+		PageSize: 3,
+	}
+
+	var names []string
+	for {
+		report, err := m.StatusReport(filter)
+		require.NoError(err)
+
+		// The overall health is of all the resources
+		require.Equal(pb.StatusReport_PARTIAL, report.Health)
+		require.Equal(uint32(4), report.Page.Total)
+
+		for _, r := range report.Resources {
+			names = append(names, r.Name)
+		}
+		if report.Page.NextPageToken == "" {
+			break
+		}
+
+		filter.PageToken = report.Page.NextPageToken
+	}
+	require.Equal([]string{"a", "b", "c", "d"}, names)
+
+	// StatusAll filters but doesn't paginate
+	filter.PageToken = ""
+	reports, err := m.StatusAll(filter)
+	require.NoError(err)
+	require.Len(reports, 4)
+
+	// No filter returns everything
+	report, err := m.StatusReport()
+	require.NoError(err)
+	require.Len(report.Resources, 6)
+	require.Nil(report.Page)
+
+	_, err = m.StatusReport(&component.StatusFilter{PageToken: "nope"})
+	require.Error(err)
+}
+
 // TestStatus_Manager_LoopRepro is a regression test for a loop discovered while
 // implementing StatusAll involving using Resource Manager with a single
 // Resource that reports a status.
@@ -0,0 +1,65 @@
+package resource
+
+import (
+	"errors"
+)
+
+// nestedArgs are the args given to the operation of a manager. These are
+// available to the lifecycle functions of resources backed by a nested
+// manager so that the nested manager can be called with them.
+type nestedArgs struct {
+	args []interface{}
+}
+
+// WithNestedManager backs this resource with the manager m. This lets
+// plugins compose groups of resources that are used together, such as a
+// load balancer with its listener, target group and security group, as a
+// single resource of another manager.
+//
+// Creating, destroying and reporting the status of the resource creates,
+// destroys and reports the status of all the resources of m. The nested
+// manager is called with the same args that were given to the operation of
+// the parent manager, such as CreateAll, but it has its own value providers
+// and it can't use the state of the other resources of the parent manager.
+//
+// The state of m is serialized as the state of this resource, so the state
+// of the parent manager includes the state of all nested managers. State
+// returns the *pb.Framework_ResourceManagerState of m, or nil if none of
+// its resources were created.
+//
+// This sets the create, destroy and status functions of the resource, so
+// it can't be combined with WithCreate, WithDestroy, WithStatus or
+// WithState.
+func WithNestedManager(m *Manager) ResourceOption {
+	return func(r *Resource) {
+		r.nested = m
+		r.createFunc = func(args *nestedArgs) error {
+			return m.CreateAll(args.args...)
+		}
+		r.destroyFunc = func(args *nestedArgs) error {
+			return m.DestroyAll(args.args...)
+		}
+		r.statusFunc = func(args *nestedArgs, sr *StatusResponse) error {
+			reports, err := m.StatusAll(args.args...)
+			if err != nil {
+				return err
+			}
+
+			sr.Resources = append(sr.Resources, reports...)
+			return nil
+		}
+	}
+}
+
+// validateNested validates the nested manager of the resource, if any.
+func (r *Resource) validateNested() error {
+	if r.nested == nil {
+		return nil
+	}
+
+	if r.stateType != nil {
+		return errors.New("resource with a nested manager can't have a state type")
+	}
+
+	return r.nested.Validate()
+}
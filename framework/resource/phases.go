@@ -0,0 +1,28 @@
+package resource
+
+// DestroyPhase is one phase of destroying a resource. See WithDestroyPhases.
+type DestroyPhase struct {
+	// Name is a short description of the phase, such as "deregister".
+	Name string
+
+	// Func is the function called for this phase. It has the same
+	// arguments available as a function given to WithDestroy.
+	Func interface{}
+}
+
+// WithDestroyPhases destroys this resource in multiple phases rather than
+// with a single function given to WithDestroy, such as deregistering from
+// a load balancer and draining before deleting.
+//
+// The Manager runs each phase across all resources (in reverse creation
+// order) before running the next phase of any resource. Phases are aligned
+// so the last phase of every resource, and the destroy function of
+// resources without phases, run together last.
+//
+// The number of completed phases is recorded in the state of the resource
+// so that if a destroy is interrupted, destroying again with the state
+// from Manager.State skips the phases that already completed. The state of
+// the resource is only cleared once the last phase completes.
+func WithDestroyPhases(phases ...DestroyPhase) ResourceOption {
+	return func(r *Resource) { r.destroyPhases = phases }
+}
@@ -0,0 +1,83 @@
+package resource
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/go-argmapper"
+)
+
+// WithPostCreate adds a function that is called after the create function
+// (and the waiter, if any) succeeds to validate the created resource, such
+// as checking that a load balancer was provisioned with the right scheme.
+// This keeps validation out of the create function so that each check can
+// be tested on its own.
+//
+// The function has the same arguments available as the create function,
+// including the state, and must return an error if the resource is not
+// valid. This can be called multiple times to add multiple functions; they
+// are called in the order they are added and the first error stops the
+// remaining ones.
+//
+// If a function returns an error, the creation fails and the Manager rolls
+// back like any other creation error.
+func WithPostCreate(f interface{}) ResourceOption {
+	return func(r *Resource) { r.postCreateFuncs = append(r.postCreateFuncs, f) }
+}
+
+// postCreate calls the post-create functions for the resource using the
+// given inputs of the create mapper.
+func (r *Resource) postCreate(fs []*argmapper.Func, in *argmapper.ValueSet) error {
+	args := in.Args()
+	if r.stateType != nil {
+		args = append(args, argmapper.Typed(r.stateValue))
+	}
+
+	for _, f := range fs {
+		result := f.Call(args...)
+		if err := result.Err(); err != nil {
+			return fmt.Errorf("validation of resource %q failed: %w", r.name, err)
+		}
+	}
+
+	return nil
+}
+
+// appendInputs returns inputs with the values f requires added, except for
+// the state and the given types, which are provided by the caller.
+func (r *Resource) appendInputs(
+	inputs *argmapper.ValueSet,
+	f *argmapper.Func,
+	except ...reflect.Type,
+) (*argmapper.ValueSet, error) {
+	inputVals := inputs.Values()
+	for _, v := range f.Input().Values() {
+		if v.Type == r.stateType {
+			continue
+		}
+
+		skip := false
+		for _, t := range except {
+			if v.Type == t {
+				skip = true
+				break
+			}
+		}
+		if skip {
+			continue
+		}
+
+		found := false
+		for _, existing := range inputVals {
+			if existing.Name == v.Name && existing.Type == v.Type && existing.Subtype == v.Subtype {
+				found = true
+				break
+			}
+		}
+		if !found {
+			inputVals = append(inputVals, v)
+		}
+	}
+
+	return argmapper.NewValueSet(inputVals)
+}
@@ -0,0 +1,246 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-argmapper"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+)
+
+// ErrResourceNotFound is returned by a read function (see WithRead) when
+// the resource doesn't exist. It may be wrapped.
+var ErrResourceNotFound = errors.New("resource not found")
+
+// WithRead sets the function that reads the actual state of this resource
+// from the infrastructure. It is called by Manager.RefreshAll to detect
+// drift between the saved state and the infrastructure.
+//
+// The function may take as inputs any arguments it requires, like the
+// function of WithCreate, including the state type specified for WithState.
+// The state is populated with the saved state, if there is any, and the
+// function should update it in place to match the infrastructure. If the
+// resource doesn't exist, the function must return ErrResourceNotFound.
+// Any other error fails the refresh.
+func WithRead(f interface{}) ResourceOption {
+	return func(r *Resource) { r.readFunc = f }
+}
+
+// DriftReport is the difference between the saved state of the resources
+// of a Manager and the infrastructure, returned by Manager.RefreshAll. The
+// names in each field are sorted.
+type DriftReport struct {
+	// Missing are the names of the resources that have state but no
+	// longer exist. Their state is cleared, so they aren't destroyed.
+	Missing []string
+
+	// Changed are the resources whose state differs from the saved state.
+	// Their state is updated to the state that was read.
+	Changed []*DriftChange
+
+	// Unmanaged are the names of the resources that have no state but
+	// exist in the infrastructure, such as resources created outside of
+	// Waypoint or resources whose creation failed after they were created.
+	// Their state is not updated, so they aren't destroyed.
+	Unmanaged []string
+}
+
+// DriftChange is a resource whose state changed in a DriftReport.
+type DriftChange struct {
+	// Name is the name of the resource.
+	Name string
+
+	// Previous and Current are the saved state and the state that was read.
+	// These are the type given to WithState.
+	Previous interface{}
+	Current  interface{}
+}
+
+// HasDrift returns true if any resource drifted from its saved state.
+func (d *DriftReport) HasDrift() bool {
+	return len(d.Missing) > 0 || len(d.Changed) > 0 || len(d.Unmanaged) > 0
+}
+
+// RefreshAll calls the read function (see WithRead) of all the resources
+// under management to update their state from the infrastructure, and
+// returns the drift that was found. args are made available to the read
+// functions, like for CreateAll. Resources without a read function are
+// skipped.
+//
+// The resources are read in order of their names. If a read function
+// returns an error other than ErrResourceNotFound, RefreshAll stops and
+// returns the error; the resources that were read before it keep their
+// updated state.
+func (m *Manager) RefreshAll(args ...interface{}) (*DriftReport, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	mapperArgs, err := m.mapperArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	dataArgs, err := m.dataSourceArgs()
+	if err != nil {
+		return nil, err
+	}
+	mapperArgs = append(mapperArgs, dataArgs...)
+
+	var names []string
+	for name, r := range m.resources {
+		if r.readFunc != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	report := &DriftReport{}
+	for _, name := range names {
+		r := m.resources[name]
+		managed := r.hasState() || m.created(name)
+
+		var previous interface{}
+		if r.hasState() {
+			previous = r.stateValue
+		}
+
+		current, found, err := r.read(mapperArgs)
+		if err != nil {
+			return nil, fmt.Errorf("error reading resource %q: %w", name, err)
+		}
+
+		switch {
+		case !found && managed:
+			report.Missing = append(report.Missing, name)
+			r.stateValue = nil
+			r.statusResp = nil
+			m.forget(name)
+
+		case found && !managed:
+			report.Unmanaged = append(report.Unmanaged, name)
+
+		case found && !stateEqual(previous, current):
+			report.Changed = append(report.Changed, &DriftChange{
+				Name:     name,
+				Previous: previous,
+				Current:  current,
+			})
+			r.stateValue = current
+		}
+	}
+
+	return report, nil
+}
+
+// created returns true if the resource with the given name is in the
+// creation order.
+func (m *Manager) created(name string) bool {
+	if m.createState == nil {
+		return false
+	}
+
+	for _, n := range m.createState.Order {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// forget removes the resource with the given name from the creation order
+// so it isn't destroyed.
+func (m *Manager) forget(name string) {
+	if m.createState == nil {
+		return
+	}
+
+	order := m.createState.Order[:0]
+	for _, n := range m.createState.Order {
+		if n != name {
+			order = append(order, n)
+		}
+	}
+	m.createState.Order = order
+}
+
+// read calls the read function with a copy of the state of the resource
+// and returns the state that was read. found is false if the read function
+// returned ErrResourceNotFound.
+func (r *Resource) read(mapperArgs []argmapper.Arg) (state interface{}, found bool, err error) {
+	f, err := argmapper.NewFunc(r.readFunc)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if r.stateType != nil {
+		state = reflect.New(r.stateType.Elem()).Interface()
+		if r.hasState() {
+			state = r.copyState()
+		}
+
+		mapperArgs = append(mapperArgs, argmapper.Typed(state))
+	}
+
+	start := time.Now()
+	result := f.Call(mapperArgs...)
+	r.track(OperationRead, "", start, result.Err())
+	if err := result.Err(); err != nil {
+		if errors.Is(err, ErrResourceNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return state, true, nil
+}
+
+// hasState returns true if the resource has a non-nil state.
+func (r *Resource) hasState() bool {
+	if r.nested != nil {
+		return r.State() != nil
+	}
+
+	if r.stateValue == nil {
+		return false
+	}
+
+	v := reflect.ValueOf(r.stateValue)
+	return v.Kind() != reflect.Ptr || !v.IsNil()
+}
+
+// copyState returns a copy of the state so that read functions don't
+// modify the saved state. States that aren't protobuf messages can't be
+// copied, so they are updated in place.
+func (r *Resource) copyState() interface{} {
+	if msg, ok := r.stateValue.(proto.Message); ok {
+		return proto.Clone(msg)
+	}
+
+	return r.stateValue
+}
+
+// stateEqual returns true if the two states are equal.
+func stateEqual(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	ap, err := component.Proto(a)
+	if err != nil {
+		return false
+	}
+	bp, err := component.Proto(b)
+	if err != nil {
+		return false
+	}
+
+	return proto.Equal(ap, bp)
+}
@@ -1,11 +1,13 @@
 package resource
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
 	"sync/atomic"
+	"time"
 
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-multierror"
@@ -26,6 +28,10 @@ type createState struct {
 	// This is serialized in the state and used to determine the destruction
 	// order later.
 	Order []string
+
+	// Failed is the name of the resource whose creation failed, if any.
+	// This is only used for rollbacks and isn't serialized.
+	Failed string
 }
 
 // Resource is a single resource type with an associated lifecycle and state.
@@ -41,9 +47,26 @@ type Resource struct {
 	setStateClock       uint32
 	createFunc          interface{}
 	destroyFunc         interface{}
+	dataFunc            interface{}
 	platform            string
 	categoryDisplayHint pb.ResourceCategoryDisplayHint
 	statusFunc          interface{}
+	stabilizer          *statusStabilizer
+	waiter              *waiter
+	postCreateFuncs     []interface{}
+	update              bool
+	desiredInputs       interface{}
+	sensitiveFields     []string
+	urlFunc             interface{}
+	costFunc            interface{}
+	metadata            map[string]string
+	readFunc            interface{}
+	timings             []*Timing
+	nested              *Manager
+	tainted             bool
+
+	destroyPhases          []DestroyPhase
+	destroyPhasesCompleted int
 
 	statusResp *StatusResponse
 }
@@ -76,6 +99,24 @@ func NewResource(opts ...ResourceOption) *Resource {
 	return &r
 }
 
+// NewDataSource creates a new data source. A data source is a resource
+// that only produces values, such as looking up an existing AMI or
+// resolving a hosted zone. It has no state and is never destroyed.
+//
+// The function f may take as inputs any arguments it requires, including
+// values produced by other resources or data sources. All of its results
+// (except a final "error" value) are made available as inputs to other
+// resources. A data source is called during Manager.CreateAll if it is
+// required, and may also be called to provide values to destroy and status
+// functions.
+//
+// Data sources are not reported as declared or destroyed resources.
+func NewDataSource(name string, f interface{}, opts ...ResourceOption) *Resource {
+	r := NewResource(append([]ResourceOption{WithName(name)}, opts...)...)
+	r.dataFunc = f
+	return r
+}
+
 // Validate checks that the resource structure is configured correctly.
 // This is always called prior to any operation. Users may want to call
 // this during unit tests or earlier in order to provide a better user
@@ -85,9 +126,43 @@ func (r *Resource) Validate() error {
 	if r.name == "" {
 		result = multierror.Append(result, errors.New("name must be set"))
 	}
-	if r.createFunc == nil {
+	if r.dataFunc != nil {
+		if r.createFunc != nil || r.destroyFunc != nil || len(r.destroyPhases) > 0 {
+			result = multierror.Append(result, errors.New(
+				"data source can't have creation or destroy functions"))
+		}
+		if r.stateType != nil {
+			result = multierror.Append(result, errors.New(
+				"data source can't have a state type"))
+		}
+		if r.waiter != nil {
+			result = multierror.Append(result, errors.New(
+				"data source can't have a waiter"))
+		}
+		if len(r.postCreateFuncs) > 0 {
+			result = multierror.Append(result, errors.New(
+				"data source can't have post-create functions"))
+		}
+		if r.readFunc != nil {
+			result = multierror.Append(result, errors.New(
+				"data source can't have a read function"))
+		}
+	} else if r.createFunc == nil {
 		result = multierror.Append(result, errors.New("creation function must be set"))
 	}
+	if r.destroyFunc != nil && len(r.destroyPhases) > 0 {
+		result = multierror.Append(result, errors.New(
+			"only one of destroy function or destroy phases can be set"))
+	}
+	for i, p := range r.destroyPhases {
+		if p.Func == nil {
+			result = multierror.Append(result, fmt.Errorf(
+				"destroy phase %d must have a function", i+1))
+		}
+	}
+	if err := r.validateNested(); err != nil {
+		result = multierror.Append(result, err)
+	}
 
 	return result
 }
@@ -102,6 +177,14 @@ func (r *Resource) Validate() error {
 // state so it should not be modified simultaneously to any resource
 // operations.
 func (r *Resource) State() interface{} {
+	if r.nested != nil {
+		if r.nested.createState == nil {
+			return nil
+		}
+
+		return r.nested.proto()
+	}
+
 	return r.stateValue
 }
 
@@ -138,7 +221,7 @@ func (r *Resource) Create(args ...interface{}) error {
 		return err
 	}
 
-	f, err := r.mapperForCreate(nil)
+	f, err := r.mapperForCreate(context.Background(), nil)
 	if err != nil {
 		return err
 	}
@@ -164,24 +247,36 @@ func (r *Resource) Destroy(args ...interface{}) error {
 		return err
 	}
 
-	f, err := r.mapperForDestroy(nil)
-	if err != nil {
-		return err
-	}
-
 	mapperArgs := make([]argmapper.Arg, len(args))
 	for i, v := range args {
 		mapperArgs[i] = argmapper.Typed(v)
 	}
 
-	result := f.Call(mapperArgs...)
-	return result.Err()
+	// Destroy phases, if any, are called in order.
+	rounds := 1
+	if len(r.destroyPhases) > rounds {
+		rounds = len(r.destroyPhases)
+	}
+
+	for round := 0; round < rounds; round++ {
+		f, err := r.mapperForDestroy(context.Background(), nil, round, rounds)
+		if err != nil {
+			return err
+		}
+
+		result := f.Call(mapperArgs...)
+		if err := result.Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // DeclaredResource converts a resource to a DeclaredResource protobuf, which
 // can be used in a component.DeclaredResourcesResp.
 func (r *Resource) DeclaredResource() (*pb.DeclaredResource, error) {
-	stateJson, err := json.Marshal(r.State())
+	stateJson, err := json.Marshal(redactSecrets(r.State()))
 	if err != nil {
 		return nil, fmt.Errorf("state for resource is not serializable to json: %w", err)
 	}
@@ -191,20 +286,25 @@ func (r *Resource) DeclaredResource() (*pb.DeclaredResource, error) {
 		return nil, fmt.Errorf("state for resource is not serializable to protobuf: %w", err)
 	}
 
-	return &pb.DeclaredResource{
+	result := &pb.DeclaredResource{
 		Name:                r.name,
 		Type:                r.resourceType,
 		Platform:            r.platform,
 		CategoryDisplayHint: r.categoryDisplayHint,
 		State:               stateProtoAny,
-		StateJson:           string(stateJson),
-	}, nil
+		StateJson:           r.redactJSON(string(stateJson), ""),
+	}
+	if err := r.enrichDeclared(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
 // DestroyedResource converts a resource to a DestroyedResource protobuf, which
 // can be used in a component.DestroyedResourcesResp
 func (r *Resource) DestroyedResource() (*pb.DestroyedResource, error) {
-	stateJson, err := json.Marshal(r.State())
+	stateJson, err := json.Marshal(redactSecrets(r.State()))
 	if err != nil {
 		return nil, fmt.Errorf("state for resource is not serializable to json: %w", err)
 	}
@@ -219,7 +319,7 @@ func (r *Resource) DestroyedResource() (*pb.DestroyedResource, error) {
 		Type:      r.resourceType,
 		Platform:  r.platform,
 		State:     stateProtoAny,
-		StateJson: string(stateJson),
+		StateJson: r.redactJSON(string(stateJson), ""),
 	}, nil
 }
 
@@ -259,8 +359,15 @@ func (r *Resource) status(args ...interface{}) error {
 
 // mapperForCreate returns an argmapper func that takes as input the
 // requirements for the createFunc and returns the state type plus an error.
-// This creates a valid "mapper" we can use with Manager.
-func (r *Resource) mapperForCreate(cs *createState) (*argmapper.Func, error) {
+// This creates a valid "mapper" we can use with Manager. If ctx is
+// cancelled, the create function isn't called and the error of ctx is
+// returned.
+func (r *Resource) mapperForCreate(ctx context.Context, cs *createState) (*argmapper.Func, error) {
+	// Data sources have no lifecycle, they only produce values.
+	if r.dataFunc != nil {
+		return r.mapperForData()
+	}
+
 	// Create the func for the createFunc as-is. We need to get the input/output sets.
 	original, err := argmapper.NewFunc(r.createFunc)
 	if err != nil {
@@ -291,8 +398,11 @@ func (r *Resource) mapperForCreate(cs *createState) (*argmapper.Func, error) {
 			return nil, err
 		}
 
-		// Zero our state now
-		r.initState(true)
+		// Zero our state now, unless we're updating the resource and
+		// start from its previous state. See Run.
+		if !r.update || r.stateValue == nil {
+			r.initState(true)
+		}
 
 		// For input, we have to remove the state type
 		inputVals := inputs.Values()
@@ -316,7 +426,37 @@ func (r *Resource) mapperForCreate(cs *createState) (*argmapper.Func, error) {
 		}
 	}
 
-	return argmapper.BuildFunc(inputs, outputs, func(in, out *argmapper.ValueSet) error {
+	// If we have a waiter, our inputs must also include everything it
+	// requires except for the state and context, which we provide.
+	var waitFunc *argmapper.Func
+	if r.waiter != nil {
+		waitFunc, err = argmapper.NewFunc(r.waiter.f)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs, err = r.appendInputs(inputs, waitFunc, contextType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Our inputs must also include everything the post-create functions
+	// require except for the state.
+	postCreateFuncs := make([]*argmapper.Func, len(r.postCreateFuncs))
+	for i, raw := range r.postCreateFuncs {
+		postCreateFuncs[i], err = argmapper.NewFunc(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs, err = r.appendInputs(inputs, postCreateFuncs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return argmapper.BuildFunc(inputs, outputs, func(in, out *argmapper.ValueSet) (err error) {
 		// Our available arguments are what was given to us and required
 		// by our function plus our newly allocated state.
 		args := in.Args()
@@ -336,14 +476,85 @@ func (r *Resource) mapperForCreate(cs *createState) (*argmapper.Func, error) {
 			v.Value = markerVal.Value
 		}
 
+		// Don't start creating if the operation was cancelled. This is
+		// before the order is updated so a rollback doesn't destroy it.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		// If we have creation state, append our resource to the order.
 		if cs != nil {
 			cs.Order = append(cs.Order, r.name)
 		}
 
+		// Time the creation and record if it fails.
+		start := time.Now()
+		defer func() {
+			r.track(OperationCreate, "", start, err)
+			if err != nil && cs != nil {
+				cs.Failed = r.name
+			}
+		}()
+
 		// Call our function. We throw away any result types except for the error.
 		result := original.Call(args...)
-		return result.Err()
+		if err := result.Err(); err != nil {
+			return err
+		}
+
+		// Wait for the resource to be ready.
+		if waitFunc != nil {
+			if err := r.wait(ctx, waitFunc, in); err != nil {
+				return err
+			}
+		}
+
+		// Validate the created resource.
+		return r.postCreate(postCreateFuncs, in)
+	}, argmapper.FuncOnce())
+}
+
+// mapperForData returns an argmapper func that calls the data source function
+// and outputs all of its results along with our marker type. Data sources
+// are not part of the creation order since there is nothing to destroy.
+func (r *Resource) mapperForData() (*argmapper.Func, error) {
+	original, err := argmapper.NewFunc(r.dataFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Our outputs are our marker plus everything the function returns.
+	markerVal := markerValue(r.name)
+	dataVals := original.Output().Values()
+	outputs, err := argmapper.NewValueSet(append([]argmapper.Value{markerVal}, dataVals...))
+	if err != nil {
+		return nil, err
+	}
+
+	inputs, err := argmapper.NewValueSet(original.Input().Values())
+	if err != nil {
+		return nil, err
+	}
+
+	return argmapper.BuildFunc(inputs, outputs, func(in, out *argmapper.ValueSet) error {
+		// Ensure our output marker type is set
+		if v := out.TypedSubtype(markerVal.Type, markerVal.Subtype); v != nil {
+			v.Value = markerVal.Value
+		}
+
+		result := original.Call(in.Args()...)
+		if err := result.Err(); err != nil {
+			return err
+		}
+
+		// Copy the results of the function into our outputs.
+		for i := 0; i < result.Len() && i < len(dataVals); i++ {
+			if v := out.TypedSubtype(dataVals[i].Type, dataVals[i].Subtype); v != nil {
+				v.Value = reflect.ValueOf(result.Out(i))
+			}
+		}
+
+		return nil
 	}, argmapper.FuncOnce())
 }
 
@@ -409,7 +620,11 @@ func (r *Resource) mapperForStatus() (*argmapper.Func, error) {
 
 		// Call our function. We throw away any result types except for the
 		// error.
+		start := time.Now()
 		result := original.Call(args...)
+		if r.statusFunc != nil {
+			r.track(OperationStatus, "", start, result.Err())
+		}
 
 		// Fill in default values where we can
 		if r.statusResp != nil {
@@ -430,6 +645,13 @@ func (r *Resource) mapperForStatus() (*argmapper.Func, error) {
 			}
 		}
 
+		if r.stabilizer != nil && r.statusResp != nil {
+			r.stabilizer.apply(r.statusResp.Resources)
+		}
+		if r.statusResp != nil {
+			r.redactStatus(r.statusResp.Resources)
+		}
+
 		return result.Err()
 	}, argmapper.FuncOnce())
 }
@@ -437,12 +659,31 @@ func (r *Resource) mapperForStatus() (*argmapper.Func, error) {
 // mapperForDestroy returns an argmapper func that will call the destroy
 // function. The deps given will be created as input dependencies to ensure
 // that they are destroyed first. The value of deps should be the name of
-// the resource.
-func (r *Resource) mapperForDestroy(deps []string) (*argmapper.Func, error) {
+// the resource. If ctx is cancelled, the destroy function isn't called and
+// the error of ctx is returned.
+func (r *Resource) mapperForDestroy(ctx context.Context, deps []string, round, rounds int) (*argmapper.Func, error) {
+	// Determine what to call in this round. Plain destroy functions and the
+	// last phase of resources with destroy phases are called in the last
+	// round. Phases that completed in an earlier, interrupted destroy are
+	// skipped.
+	final := round == rounds-1
+	phase := -1
+	var destroyFunc interface{}
+	if len(r.destroyPhases) == 0 {
+		if final {
+			destroyFunc = r.destroyFunc
+		}
+	} else {
+		phase = round - (rounds - len(r.destroyPhases))
+		if phase >= 0 && phase >= r.destroyPhasesCompleted {
+			destroyFunc = r.destroyPhases[phase].Func
+		}
+	}
+
 	// The destroy function is optional (some resources aren't destroyed
 	// or are destroyed via some other functions). If so, just set it to
 	// a no-op since we still want to execute and do our state logic and so on.
-	destroyFunc := r.destroyFunc
+	called := destroyFunc != nil
 	if destroyFunc == nil {
 		destroyFunc = func() {}
 	}
@@ -502,14 +743,39 @@ func (r *Resource) mapperForDestroy(deps []string) (*argmapper.Func, error) {
 			v.Value = markerVal.Value
 		}
 
+		// Don't start destroying if the operation was cancelled so that
+		// the state is kept for a later destroy.
+		if called {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
 		// Call our function. We throw away any result types except for the error.
+		start := time.Now()
 		result := original.Call(args...)
 		err := result.Err()
+		if called {
+			var phaseName string
+			if phase >= 0 {
+				phaseName = r.destroyPhases[phase].Name
+			}
+
+			r.track(OperationDestroy, phaseName, start, err)
+		}
+
+		if err == nil && called && phase >= 0 {
+			r.destroyPhasesCompleted = phase + 1
+		}
 
 		// If the destroy was successful, we clear our state and status
-		if err == nil {
+		if err == nil && final {
 			r.initState(false)
+			r.destroyPhasesCompleted = 0
 			r.statusResp = nil
+			if r.stabilizer != nil {
+				r.stabilizer.reset()
+			}
 		}
 
 		return err
@@ -533,11 +799,22 @@ func (r *Resource) initState(zero bool) {
 // serialized proto format. This will discard any previous state that is
 // currently loaded.
 func (r *Resource) loadState(s *pb.Framework_ResourceState) error {
+	if s != nil {
+		r.destroyPhasesCompleted = int(s.DestroyPhasesCompleted)
+		r.tainted = s.Tainted
+		r.loadTimings(s.Timings)
+	}
+
 	// If we have no raw value in the state then ignore it.
 	if s == nil || s.Raw == nil {
 		return nil
 	}
 
+	// The state of a nested manager is the state of all its resources.
+	if r.nested != nil {
+		return r.nested.LoadState(s.Raw)
+	}
+
 	// We try to unmarshal directly into a state value
 	r.initState(true)
 	if r.stateValue == nil {
@@ -557,7 +834,7 @@ func (r *Resource) loadState(s *pb.Framework_ResourceState) error {
 
 // proto returns the protobuf message for the state of this resource.
 func (r *Resource) proto() *pb.Framework_ResourceState {
-	stateProto, err := component.Proto(r.stateValue)
+	stateProto, err := component.Proto(r.State())
 	if err != nil {
 		// This shouldn't happen.
 		panic(err)
@@ -565,7 +842,12 @@ func (r *Resource) proto() *pb.Framework_ResourceState {
 
 	// This means we have no state value, we return just the name.
 	if stateProto == nil {
-		return &pb.Framework_ResourceState{Name: r.name}
+		return &pb.Framework_ResourceState{
+			Name:                   r.name,
+			DestroyPhasesCompleted: uint32(r.destroyPhasesCompleted),
+			Timings:                r.timingsProto(),
+			Tainted:                r.tainted,
+		}
 	}
 
 	// Encode our state
@@ -577,16 +859,19 @@ func (r *Resource) proto() *pb.Framework_ResourceState {
 
 	jsonVal, err := protojson.MarshalOptions{
 		Indent: "\t",
-	}.Marshal(stateProto)
+	}.Marshal(component.RedactSecrets(stateProto))
 
 	if err != nil {
 		jsonVal = []byte(fmt.Sprintf(`{"error": %q}`, err))
 	}
 
 	return &pb.Framework_ResourceState{
-		Name: r.name,
-		Raw:  anyVal,
-		Json: string(jsonVal),
+		Name:                   r.name,
+		Raw:                    anyVal,
+		Json:                   r.redactJSON(string(jsonVal), "\t"),
+		DestroyPhasesCompleted: uint32(r.destroyPhasesCompleted),
+		Timings:                r.timingsProto(),
+		Tainted:                r.tainted,
 	}
 }
 
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
 
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
@@ -151,6 +153,143 @@ func TestResource_DeclaredResource(t *testing.T) {
 	require.True(dr.State.MessageIs(testResource.State().(proto.Message)))
 }
 
+func TestResource_DeclaredResourceEnrichment(t *testing.T) {
+	require := require.New(t)
+
+	r := NewResource(
+		WithName("test"),
+		WithState(&testproto.Data{}),
+		WithCreate(func(state *testproto.Data) error {
+			state.Value = "web"
+			return nil
+		}),
+		WithDestroy(func() {}),
+		WithResourceURL(func(state *testproto.Data) string {
+			return "https://console.example.com/" + state.Value
+		}),
+		WithCostEstimate(func() (*pb.ResourceCostEstimate, error) {
+			return &pb.ResourceCostEstimate{Amount: 1.5, Currency: "USD", Period: "hour"}, nil
+		}),
+		WithMetadata(map[string]string{"region": "us-east-1", "account": "a"}),
+		WithMetadata(map[string]string{"account": "b"}),
+	)
+	require.NoError(r.Create())
+
+	dr, err := r.DeclaredResource()
+	require.NoError(err)
+	require.Equal("https://console.example.com/web", dr.Url)
+	require.Equal(1.5, dr.CostEstimate.Amount)
+	require.Equal("USD", dr.CostEstimate.Currency)
+	require.Equal("hour", dr.CostEstimate.Period)
+	require.Equal(map[string]string{"region": "us-east-1", "account": "b"}, dr.Metadata)
+
+	// An error from an enrichment function is returned
+	r = NewResource(
+		WithName("test"),
+		WithState(&testproto.Data{}),
+		WithCreate(func(state *testproto.Data) {}),
+		WithCostEstimate(func() (*pb.ResourceCostEstimate, error) {
+			return nil, fmt.Errorf("no prices")
+		}),
+	)
+	require.NoError(r.Create())
+	_, err = r.DeclaredResource()
+	require.Error(err)
+	require.Contains(err.Error(), "no prices")
+}
+
+func TestResource_sensitiveFields(t *testing.T) {
+	require := require.New(t)
+
+	r := NewResource(
+		WithName("test"),
+		WithState(&testproto.Data{}),
+		WithSensitiveFields("value"),
+		WithCreate(func(state *testproto.Data) error {
+			state.Value = "hunter2"
+			state.Number = 42
+			return nil
+		}),
+		WithDestroy(func() {}),
+	)
+	require.NoError(r.Create())
+
+	dr, err := r.DeclaredResource()
+	require.NoError(err)
+	require.JSONEq(`{"value":"`+RedactedValue+`","number":42}`, dr.StateJson)
+
+	dtr, err := r.DestroyedResource()
+	require.NoError(err)
+	require.JSONEq(`{"value":"`+RedactedValue+`","number":42}`, dtr.StateJson)
+
+	// The state given back to the plugin is intact
+	var state testproto.Data
+	require.NoError(component.ProtoAnyUnmarshal(dr.State, &state))
+	require.Equal("hunter2", state.Value)
+	require.Equal("hunter2", r.State().(*testproto.Data).Value)
+
+	s := r.proto()
+	require.NotContains(s.Json, "hunter2")
+	require.Contains(s.Json, RedactedValue)
+	require.NoError(component.ProtoAnyUnmarshal(s.Raw, &state))
+	require.Equal("hunter2", state.Value)
+}
+
+func TestResource_redactJSON(t *testing.T) {
+	r := NewResource(WithSensitiveFields(
+		"db_password",
+		"credentials.token",
+		"users.secret",
+		"missing.field",
+	))
+
+	cases := []struct {
+		Name     string
+		Input    string
+		Expected string
+	}{
+		{
+			"no sensitive fields in state",
+			`{"value":"v"}`,
+			`{"value":"v"}`,
+		},
+		{
+			"top level field",
+			`{"db_password":"p","value":"v"}`,
+			`{"db_password":"` + RedactedValue + `","value":"v"}`,
+		},
+		{
+			"lowerCamelCase field",
+			`{"dbPassword":"p"}`,
+			`{"dbPassword":"` + RedactedValue + `"}`,
+		},
+		{
+			"nested field",
+			`{"credentials":{"token":"t","user":"u"}}`,
+			`{"credentials":{"token":"` + RedactedValue + `","user":"u"}}`,
+		},
+		{
+			"field in list",
+			`{"users":[{"secret":"a"},{"secret":"b","name":"n"}]}`,
+			`{"users":[{"secret":"` + RedactedValue + `"},{"secret":"` + RedactedValue + `","name":"n"}]}`,
+		},
+		{
+			"null value",
+			`{"db_password":null}`,
+			`{"db_password":null}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require.JSONEq(t, tt.Expected, r.redactJSON(tt.Input, ""))
+		})
+	}
+
+	// Invalid JSON is returned as-is
+	require.Equal(t, "nope", r.redactJSON("nope", ""))
+}
+
 var (
 	statusNameTpl    = "status-%d"
 	healthMessageTpl = "alive-%d"
@@ -203,3 +342,148 @@ func TestStatus_Resource(t *testing.T) {
 	// make sure status is cleared after destroy
 	require.Nil(r.statusResp)
 }
+
+func TestDataSource_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := NewDataSource("ami", func() (string, error) { return "ami-123", nil })
+		require.NoError(t, r.Validate())
+	})
+
+	t.Run("with state", func(t *testing.T) {
+		r := NewDataSource("ami", func() string { return "" }, WithState(&testState{}))
+		require.Error(t, r.Validate())
+	})
+
+	t.Run("with destroy", func(t *testing.T) {
+		r := NewDataSource("ami", func() string { return "" }, WithDestroy(func() {}))
+		require.Error(t, r.Validate())
+	})
+}
+
+func TestStatus_Resource_stabilization(t *testing.T) {
+	require := require.New(t)
+
+	var health pb.StatusReport_Health
+	r := NewResource(
+		WithName("test"),
+		WithState(&testState{}),
+		WithCreate(func(state *testState) error { return nil }),
+		WithStatus(func(sr *StatusResponse) error {
+			sr.Resources = append(sr.Resources, &pb.StatusReport_Resource{
+				Health:        health,
+				HealthMessage: health.String(),
+				StateJson:     `{"pod":"web-1"}`,
+			})
+			return nil
+		}),
+		WithStatusStabilization(time.Minute, 3),
+	)
+	require.NoError(r.Create())
+
+	now := time.Now()
+	r.stabilizer.now = func() time.Time { return now }
+
+	check := func(observed, expected pb.StatusReport_Health) *pb.StatusReport_Resource {
+		health = observed
+		require.NoError(r.status())
+		report := r.statusResp.Resources[0]
+		require.Equal(expected, report.Health)
+		require.Equal(expected.String(), report.HealthMessage)
+		return report
+	}
+
+	// The first observation is reported as-is
+	check(pb.StatusReport_READY, pb.StatusReport_READY)
+
+	// A single DOWN doesn't change the health, but the raw value is kept
+	report := check(pb.StatusReport_DOWN, pb.StatusReport_READY)
+	require.JSONEq(
+		`{"pod":"web-1","observedHealth":"DOWN","observedHealthMessage":"DOWN"}`,
+		report.StateJson)
+
+	// Flapping back resets the count
+	report = check(pb.StatusReport_READY, pb.StatusReport_READY)
+	require.JSONEq(`{"pod":"web-1"}`, report.StateJson)
+	check(pb.StatusReport_DOWN, pb.StatusReport_READY)
+	check(pb.StatusReport_DOWN, pb.StatusReport_READY)
+
+	// Observations outside the window start over
+	now = now.Add(2 * time.Minute)
+	check(pb.StatusReport_DOWN, pb.StatusReport_READY)
+	check(pb.StatusReport_DOWN, pb.StatusReport_READY)
+
+	// The third consecutive observation within the window is reported
+	check(pb.StatusReport_DOWN, pb.StatusReport_DOWN)
+	check(pb.StatusReport_DOWN, pb.StatusReport_DOWN)
+}
+
+func TestStatusStabilizer_delay(t *testing.T) {
+	s := &statusStabilizer{
+		window: time.Minute,
+		jitter: func() float64 { return 0 },
+	}
+
+	var delays []time.Duration
+	for flaps := 0; flaps <= 8; flaps++ {
+		delays = append(delays, s.delay(flaps))
+	}
+	require.Equal(t, []time.Duration{
+		0,
+		time.Minute,
+		2 * time.Minute,
+		4 * time.Minute,
+		8 * time.Minute,
+		16 * time.Minute,
+		32 * time.Minute,
+		32 * time.Minute,
+		32 * time.Minute,
+	}, delays)
+
+	// Jitter adds at most a tenth of the delay
+	s.jitter = func() float64 { return 0.5 }
+	require.Equal(t, 4*time.Minute+12*time.Second, s.delay(3))
+	require.Equal(t, 32*time.Minute+96*time.Second, s.delay(10))
+}
+
+func TestStatusStabilizer_backoff(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	s := &statusStabilizer{
+		window:    time.Minute,
+		threshold: 1,
+		now:       func() time.Time { return now },
+		jitter:    func() float64 { return 0 },
+	}
+
+	observe := func(observed, expected pb.StatusReport_Health) {
+		report := &pb.StatusReport_Resource{Name: "web", Health: observed}
+		s.apply([]*pb.StatusReport_Resource{report})
+		require.Equal(expected, report.Health)
+	}
+
+	observe(pb.StatusReport_READY, pb.StatusReport_READY)
+
+	// The first transition is reported right away
+	observe(pb.StatusReport_DOWN, pb.StatusReport_DOWN)
+
+	// The next one is held back for the window, then twice the window
+	for _, d := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute} {
+		reported := pb.StatusReport_DOWN
+		observed := pb.StatusReport_READY
+		if d == 2*time.Minute {
+			reported, observed = observed, reported
+		}
+
+		now = now.Add(d - time.Second)
+		observe(observed, reported)
+		now = now.Add(time.Second)
+		observe(observed, observed)
+	}
+
+	// After being steady for long enough the delay starts over
+	now = now.Add(2*s.maxDelay() + time.Second)
+	observe(pb.StatusReport_DOWN, pb.StatusReport_DOWN)
+	now = now.Add(time.Minute)
+	observe(pb.StatusReport_READY, pb.StatusReport_READY)
+}
@@ -0,0 +1,157 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"google.golang.org/grpc/status"
+)
+
+// RollbackPolicy determines which resources are destroyed when
+// Manager.CreateAll fails. See WithRollbackPolicy.
+type RollbackPolicy int
+
+const (
+	// RollbackAlways destroys all the resources that were created. This
+	// is the default.
+	RollbackAlways RollbackPolicy = iota
+
+	// RollbackNever keeps all the resources that were created, such as to
+	// debug the failure. Their state is kept so they can be destroyed later.
+	RollbackNever
+
+	// RollbackOnlyFailed destroys only the resource whose creation failed,
+	// since it may be partially created, and keeps the others.
+	RollbackOnlyFailed
+)
+
+func (p RollbackPolicy) String() string {
+	switch p {
+	case RollbackAlways:
+		return "always"
+	case RollbackNever:
+		return "never"
+	case RollbackOnlyFailed:
+		return "only-failed"
+	default:
+		return fmt.Sprintf("RollbackPolicy(%d)", int(p))
+	}
+}
+
+// WithRollbackPolicy sets which resources are destroyed when CreateAll
+// fails. The default is RollbackAlways.
+func WithRollbackPolicy(p RollbackPolicy) ManagerOption {
+	return func(m *Manager) { m.rollbackPolicy = p }
+}
+
+// RollbackError is the error returned by Manager.CreateAll when creation
+// fails. It describes what was rolled back according to the rollback policy.
+// The resources that were kept are still in the state of the Manager.
+type RollbackError struct {
+	// Err is the error that failed the creation.
+	Err error
+
+	// RolledBack are the names of the resources that the rollback
+	// destroyed, in creation order. If RollbackErr is set, these may only
+	// be partially destroyed.
+	RolledBack []string
+
+	// Kept are the names of the created resources that weren't rolled
+	// back, in creation order.
+	Kept []string
+
+	// RollbackErr is the error of the rollback, if it failed.
+	RollbackErr error
+}
+
+func (e *RollbackError) Error() string {
+	if e.RollbackErr == nil {
+		return e.Err.Error()
+	}
+
+	return multierror.Append(e.Err, fmt.Errorf(
+		"Error during rollback: %w", e.RollbackErr)).Error()
+}
+
+func (e *RollbackError) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus returns the status of Err, so the code and details of Err
+// are kept when the error is returned from a plugin. If the rollback
+// failed, its error is added to the message.
+func (e *RollbackError) GRPCStatus() *status.Status {
+	st, _ := status.FromError(e.Err)
+	if e.RollbackErr == nil {
+		return st
+	}
+
+	p := st.Proto()
+	p.Message = multierror.Append(errors.New(p.Message), fmt.Errorf(
+		"Error during rollback: %w", e.RollbackErr)).Error()
+	return status.FromProto(p)
+}
+
+// rollback rolls back a failed creation according to the rollback policy
+// and returns the *RollbackError for err.
+func (m *Manager) rollback(ctx context.Context, args []interface{}, err error) error {
+	result := &RollbackError{Err: err}
+
+	var created []string
+	var failed string
+	if cs := m.createState; cs != nil {
+		created = append(created, cs.Order...)
+		failed = cs.Failed
+	}
+
+	switch m.rollbackPolicy {
+	case RollbackAlways:
+		result.RolledBack = created
+
+	case RollbackOnlyFailed:
+		for _, name := range created {
+			if name == failed {
+				result.RolledBack = append(result.RolledBack, name)
+			} else {
+				result.Kept = append(result.Kept, name)
+			}
+		}
+
+	default:
+		result.Kept = created
+	}
+
+	if len(result.RolledBack) == 0 {
+		return result
+	}
+
+	// If we were cancelled, the rollback can't use the cancelled context.
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+
+	m.logger.Info("error during creation, starting rollback",
+		"err", err,
+		"policy", m.rollbackPolicy.String(),
+		"resources", result.RolledBack)
+	if len(result.Kept) == 0 {
+		result.RollbackErr = m.DestroyAllWithContext(ctx, args...)
+	} else {
+		result.RollbackErr = m.destroy(ctx, result.RolledBack, withContext(ctx, args))
+		if result.RollbackErr == nil {
+			for _, name := range result.RolledBack {
+				m.forget(name)
+			}
+		}
+	}
+
+	if result.RollbackErr != nil {
+		m.logger.Warn("error during rollback", "err", result.RollbackErr)
+	} else {
+		m.logger.Info("rollback successful")
+	}
+
+	return result
+}
@@ -0,0 +1,148 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/opaqueany"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// ResourceSpec describes the single resource managed by Run. The fields
+// correspond to the ResourceOption functions of the same name, such as
+// WithCreate for Create.
+type ResourceSpec struct {
+	// Name is the name of the resource. This is required. See WithName.
+	Name string
+
+	// Type, Platform and CategoryDisplayHint describe the resource in the
+	// declared and destroyed resources. See WithType, WithPlatform and
+	// WithCategoryDisplayHint.
+	Type                string
+	Platform            string
+	CategoryDisplayHint pb.ResourceCategoryDisplayHint
+
+	// State is the state type of the resource. See WithState.
+	State interface{}
+
+	// Create, Destroy and Status are the lifecycle functions of the
+	// resource. Create is required. See WithCreate, WithDestroy and
+	// WithStatus.
+	Create  interface{}
+	Destroy interface{}
+	Status  interface{}
+
+	// Options are any other options for the resource, such as WithWaiter.
+	Options []ResourceOption
+}
+
+// RunOptions are the options for Run.
+type RunOptions struct {
+	// State is the state from the result of a previous Run. If this is set,
+	// the resource is updated: the state given to the create function is the
+	// previous state rather than a zero value, and the resource is not
+	// destroyed if the create function fails since it existed before.
+	State *opaqueany.Any
+
+	// Destroy destroys the resource with the given State instead of
+	// creating or updating it.
+	Destroy bool
+
+	// Args are the arguments available to the lifecycle functions in
+	// addition to the context given to Run.
+	Args []interface{}
+
+	// DeclaredResourcesResp and DestroyedResourcesResp are populated with
+	// the resource if set. See WithDeclaredResourcesResp and
+	// WithDestroyedResourcesResp.
+	DeclaredResourcesResp  *component.DeclaredResourcesResp
+	DestroyedResourcesResp *component.DestroyedResourcesResp
+
+	// Logger is the logger for the Manager. See WithLogger.
+	Logger hclog.Logger
+}
+
+// RunResult is the result of Run.
+type RunResult struct {
+	// State is the serialized state to store, such as in the deployment the
+	// plugin returns, and give as RunOptions.State to the next Run.
+	State *opaqueany.Any
+
+	// Value is the state value of the resource. See Resource.State.
+	Value interface{}
+}
+
+// Run creates, updates or destroys a single resource in one call. This is
+// for plugins that manage exactly one resource and would otherwise need to
+// build a Manager, load and save its state and populate the declared or
+// destroyed resources responses themselves. Plugins with more resources
+// should use a Manager.
+//
+// If the lifecycle function fails, the result has the state at the time
+// of the failure, which may be partial, along with the error.
+func Run(ctx context.Context, spec ResourceSpec, opts RunOptions) (*RunResult, error) {
+	r := NewResource(spec.resourceOptions()...)
+
+	managerOpts := []ManagerOption{WithResource(r)}
+	if opts.Logger != nil {
+		managerOpts = append(managerOpts, WithLogger(opts.Logger))
+	}
+	if opts.DeclaredResourcesResp != nil {
+		managerOpts = append(managerOpts, WithDeclaredResourcesResp(opts.DeclaredResourcesResp))
+	}
+	if opts.DestroyedResourcesResp != nil {
+		managerOpts = append(managerOpts, WithDestroyedResourcesResp(opts.DestroyedResourcesResp))
+	}
+
+	m := NewManager(managerOpts...)
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.State != nil {
+		if err := m.LoadState(opts.State); err != nil {
+			return nil, err
+		}
+
+		r.update = true
+		m.rollbackPolicy = RollbackNever
+	}
+
+	args := append([]interface{}{ctx}, opts.Args...)
+
+	var err error
+	if opts.Destroy {
+		err = m.DestroyAll(args...)
+	} else {
+		err = m.CreateAll(args...)
+	}
+
+	return &RunResult{
+		State: m.State(),
+		Value: r.State(),
+	}, err
+}
+
+// resourceOptions returns the options for NewResource for the spec.
+func (s *ResourceSpec) resourceOptions() []ResourceOption {
+	opts := []ResourceOption{
+		WithName(s.Name),
+		WithType(s.Type),
+		WithPlatform(s.Platform),
+		WithCategoryDisplayHint(s.CategoryDisplayHint),
+		WithCreate(s.Create),
+	}
+	if s.State != nil {
+		opts = append(opts, WithState(s.State))
+	}
+	if s.Destroy != nil {
+		opts = append(opts, WithDestroy(s.Destroy))
+	}
+	if s.Status != nil {
+		opts = append(opts, WithStatus(s.Status))
+	}
+
+	return append(opts, s.Options...)
+}
@@ -0,0 +1,98 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
+)
+
+func TestRun(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var createErr error
+	var destroyed *testproto.Data
+	spec := ResourceSpec{
+		Name:     "app",
+		Platform: "test",
+		State:    &testproto.Data{},
+		Create: func(ctx context.Context, s *testproto.Data, v int32) error {
+			require.NotNil(ctx)
+			s.Number += v
+			return createErr
+		},
+		Destroy: func(s *testproto.Data) error {
+			destroyed = s
+			return nil
+		},
+	}
+
+	// Create
+	var dcr component.DeclaredResourcesResp
+	result, err := Run(ctx, spec, RunOptions{
+		Args:                  []interface{}{int32(42)},
+		DeclaredResourcesResp: &dcr,
+	})
+	require.NoError(err)
+	require.NotNil(result.State)
+	require.Equal(int32(42), result.Value.(*testproto.Data).Number)
+	require.Len(dcr.DeclaredResources, 1)
+	require.Equal("app", dcr.DeclaredResources[0].Name)
+	require.Equal("app", dcr.DeclaredResources[0].Type)
+	require.Equal("test", dcr.DeclaredResources[0].Platform)
+
+	// Update starts from the previous state
+	result, err = Run(ctx, spec, RunOptions{
+		State: result.State,
+		Args:  []interface{}{int32(1)},
+	})
+	require.NoError(err)
+	require.Equal(int32(43), result.Value.(*testproto.Data).Number)
+
+	// A failed update doesn't destroy the resource
+	createErr = errors.New("boom")
+	failed, err := Run(ctx, spec, RunOptions{
+		State: result.State,
+		Args:  []interface{}{int32(1)},
+	})
+	require.Error(err)
+	require.Contains(err.Error(), "boom")
+	require.NotNil(failed)
+	require.Nil(destroyed)
+
+	// Destroy
+	var dtr component.DestroyedResourcesResp
+	_, err = Run(ctx, spec, RunOptions{
+		State:                  result.State,
+		Destroy:                true,
+		DestroyedResourcesResp: &dtr,
+	})
+	require.NoError(err)
+	require.NotNil(destroyed)
+	require.Equal(int32(43), destroyed.Number)
+	require.Len(dtr.DestroyedResources, 1)
+}
+
+func TestRun_createRollsBack(t *testing.T) {
+	require := require.New(t)
+
+	destroyed := false
+	_, err := Run(context.Background(), ResourceSpec{
+		Name:    "app",
+		State:   &testproto.Data{},
+		Create:  func(s *testproto.Data) error { return errors.New("boom") },
+		Destroy: func(s *testproto.Data) error { destroyed = true; return nil },
+	}, RunOptions{})
+	require.Error(err)
+	require.True(destroyed)
+}
+
+func TestRun_invalid(t *testing.T) {
+	_, err := Run(context.Background(), ResourceSpec{Name: "app"}, RunOptions{})
+	require.Error(t, err)
+}
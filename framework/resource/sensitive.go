@@ -0,0 +1,144 @@
+package resource
+
+import (
+	"encoding/json"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// RedactedValue replaces the values of sensitive fields in state JSON.
+const RedactedValue = "(sensitive value redacted)"
+
+// WithSensitiveFields marks fields of the resource state as sensitive, such
+// as database passwords or API tokens. The values of these fields are
+// replaced with RedactedValue in the state JSON of the declared and destroyed
+// resources, the status reports and the serialized Manager state. The state
+// itself, which is what the plugin gets back, is not modified.
+//
+// Each field is a dot-separated path into the state, such as "password" or
+// "credentials.token". Path elements are protobuf field names and also match
+// their lowerCamelCase JSON form. If a path passes through a list, the field
+// is redacted in each element. This can be called multiple times to add more
+// fields.
+func WithSensitiveFields(fields ...string) ResourceOption {
+	return func(r *Resource) { r.sensitiveFields = append(r.sensitiveFields, fields...) }
+}
+
+// redactSecrets returns the state with the values of any Secret messages
+// redacted. See component.RedactSecrets.
+func redactSecrets(state interface{}) interface{} {
+	if msg, ok := state.(proto.Message); ok {
+		return component.RedactSecrets(msg)
+	}
+
+	return state
+}
+
+// redactJSON returns the state JSON with the sensitive fields of this
+// resource redacted, indented with indent if it isn't empty. If nothing is
+// redacted, the JSON is returned as-is.
+func (r *Resource) redactJSON(v, indent string) string {
+	if len(r.sensitiveFields) == 0 || v == "" {
+		return v
+	}
+
+	var state interface{}
+	if err := json.Unmarshal([]byte(v), &state); err != nil {
+		return v
+	}
+
+	redacted := false
+	for _, field := range r.sensitiveFields {
+		if redactPath(state, strings.Split(field, ".")) {
+			redacted = true
+		}
+	}
+	if !redacted {
+		return v
+	}
+
+	result, err := json.MarshalIndent(state, "", indent)
+	if err != nil {
+		// This shouldn't happen since we just unmarshaled it.
+		panic(err)
+	}
+
+	return string(result)
+}
+
+// redactStatus redacts the state JSON of the given status reports in place.
+func (r *Resource) redactStatus(reports []*pb.StatusReport_Resource) {
+	for _, report := range reports {
+		report.StateJson = r.redactJSON(report.StateJson, "")
+	}
+}
+
+// redactPath replaces the value at path in v with RedactedValue and returns
+// true if anything was redacted.
+func redactPath(v interface{}, path []string) bool {
+	switch v := v.(type) {
+	case []interface{}:
+		redacted := false
+		for _, elem := range v {
+			if redactPath(elem, path) {
+				redacted = true
+			}
+		}
+
+		return redacted
+
+	case map[string]interface{}:
+		redacted := false
+		for _, key := range []string{path[0], lowerCamel(path[0])} {
+			elem, ok := v[key]
+			if !ok {
+				continue
+			}
+
+			if len(path) > 1 {
+				if redactPath(elem, path[1:]) {
+					redacted = true
+				}
+
+				continue
+			}
+
+			// Null values have nothing to hide and are left as-is so that
+			// unset fields remain distinguishable.
+			if elem != nil {
+				v[key] = RedactedValue
+				redacted = true
+			}
+		}
+
+		return redacted
+
+	default:
+		return false
+	}
+}
+
+// lowerCamel converts a protobuf field name to its JSON name, such as
+// "db_password" to "dbPassword".
+func lowerCamel(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, c := range name {
+		if c == '_' {
+			upper = true
+			continue
+		}
+
+		if upper && 'a' <= c && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(c)
+	}
+
+	return b.String()
+}
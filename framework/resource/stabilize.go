@@ -0,0 +1,208 @@
+package resource
+
+import (
+	"encoding/json"
+	"math/rand"
+	"sync"
+	"time"
+
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// WithStatusStabilization delays health transitions in the status reports
+// of this resource until the new health has been observed threshold times
+// in a row within window. This prevents flapping resources from producing
+// alternating READY/DOWN reports.
+//
+// While a transition is held back, the previously reported health and
+// message are reported and the raw observation is added to the state_json
+// of the report as "observedHealth" and "observedHealthMessage".
+//
+// Resources that keep flapping are held back exponentially longer: after a
+// transition is reported, the next one is not reported until window has
+// passed, and each further transition doubles that delay up to 32 times
+// window, plus up to 10% jitter. The delay starts over once the reported
+// health has been steady for twice the maximum delay.
+//
+// Observations are kept on the Resource, so the same Resource (usually via
+// the same Manager) must be used for repeated status checks for this to
+// have an effect. The first observation of each report is always reported
+// as-is.
+func WithStatusStabilization(window time.Duration, threshold int) ResourceOption {
+	return func(r *Resource) {
+		r.stabilizer = &statusStabilizer{
+			window:    window,
+			threshold: threshold,
+			now:       time.Now,
+			jitter:    rand.Float64,
+		}
+	}
+}
+
+// statusStabilizer tracks the health observations for each status report
+// of a resource. Reports are keyed by name and ID.
+type statusStabilizer struct {
+	window    time.Duration
+	threshold int
+	now       func() time.Time
+	jitter    func() float64 // returns a value in [0, 1)
+
+	mu      sync.Mutex
+	history map[stabilizeKey]*stabilizeHistory
+}
+
+const (
+	// stabilizeFactor is the factor the delay between reported transitions
+	// grows by with each transition.
+	stabilizeFactor = 2
+
+	// stabilizeMaxFactor caps the delay between reported transitions at
+	// this multiple of the window.
+	stabilizeMaxFactor = 32
+
+	// stabilizeJitter is the maximum jitter added to the delay, as a
+	// fraction of the delay.
+	stabilizeJitter = 0.1
+)
+
+type stabilizeKey struct {
+	name string
+	id   string
+}
+
+type stabilizeHistory struct {
+	reported        pb.StatusReport_Health
+	reportedMessage string
+	reportedAt      time.Time
+
+	// flaps is the number of recently reported transitions and holdUntil
+	// the time before which no further transition is reported.
+	flaps     int
+	holdUntil time.Time
+
+	candidate      pb.StatusReport_Health
+	candidateCount int
+	candidateFirst time.Time
+}
+
+// apply records the observations in the given reports and modifies them in
+// place to report the stabilized health.
+func (s *statusStabilizer) apply(reports []*pb.StatusReport_Resource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.history == nil {
+		s.history = map[stabilizeKey]*stabilizeHistory{}
+	}
+
+	now := s.now()
+	for _, report := range reports {
+		if report == nil {
+			continue
+		}
+
+		key := stabilizeKey{name: report.Name, id: report.Id}
+		h, ok := s.history[key]
+		if !ok {
+			s.history[key] = &stabilizeHistory{
+				reported:        report.Health,
+				reportedMessage: report.HealthMessage,
+				reportedAt:      now,
+			}
+			continue
+		}
+
+		if report.Health == h.reported {
+			// No transition, clear any pending candidate.
+			h.reportedMessage = report.HealthMessage
+			h.candidateCount = 0
+			continue
+		}
+
+		// The threshold must be reached within the window, after that the
+		// candidate only has to hold until the delay passed.
+		if h.candidateCount > 0 &&
+			report.Health == h.candidate &&
+			(h.candidateCount >= s.threshold || now.Sub(h.candidateFirst) <= s.window) {
+			h.candidateCount++
+		} else {
+			h.candidate = report.Health
+			h.candidateCount = 1
+			h.candidateFirst = now
+		}
+
+		if h.candidateCount >= s.threshold && !now.Before(h.holdUntil) {
+			if now.Sub(h.reportedAt) > 2*s.maxDelay() {
+				h.flaps = 0
+			}
+			h.flaps++
+
+			h.reported = report.Health
+			h.reportedMessage = report.HealthMessage
+			h.reportedAt = now
+			h.holdUntil = now.Add(s.delay(h.flaps))
+			h.candidateCount = 0
+			continue
+		}
+
+		// Hold back the transition, keeping the raw observation.
+		report.StateJson = stabilizeStateJson(report)
+		report.Health = h.reported
+		report.HealthMessage = h.reportedMessage
+	}
+}
+
+// delay returns how long the next transition is held back after the given
+// number of recently reported transitions: window after the first one,
+// multiplied by stabilizeFactor for each further one up to maxDelay, plus
+// jitter.
+func (s *statusStabilizer) delay(flaps int) time.Duration {
+	if flaps <= 0 {
+		return 0
+	}
+
+	d := s.window
+	for i := 1; i < flaps && d < s.maxDelay(); i++ {
+		d *= stabilizeFactor
+	}
+	if d > s.maxDelay() {
+		d = s.maxDelay()
+	}
+
+	return d + time.Duration(s.jitter()*stabilizeJitter*float64(d))
+}
+
+// maxDelay is the maximum delay between reported transitions, without
+// jitter.
+func (s *statusStabilizer) maxDelay() time.Duration {
+	return s.window * stabilizeMaxFactor
+}
+
+// reset clears all observations.
+func (s *statusStabilizer) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = nil
+}
+
+// stabilizeStateJson returns the state_json of the report with the raw
+// health observation added. If the existing value isn't a JSON object it
+// is returned unmodified.
+func stabilizeStateJson(report *pb.StatusReport_Resource) string {
+	state := map[string]interface{}{}
+	if report.StateJson != "" {
+		if err := json.Unmarshal([]byte(report.StateJson), &state); err != nil || state == nil {
+			return report.StateJson
+		}
+	}
+
+	state["observedHealth"] = report.Health.String()
+	state["observedHealthMessage"] = report.HealthMessage
+
+	result, err := json.Marshal(state)
+	if err != nil {
+		return report.StateJson
+	}
+
+	return string(result)
+}
@@ -0,0 +1,76 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Taint marks the resource with the given name so that the next CreateAll
+// destroys it and creates it again from scratch, even if it has state. This
+// can be used to recreate a single broken resource without recreating the
+// others. The taint is part of the State of the manager so it is kept until
+// the resource is recreated. Hosts may also taint resources by setting the
+// tainted field of the serialized state.
+func (m *Manager) Taint(name string) error {
+	r, ok := m.resources[name]
+	if !ok {
+		return fmt.Errorf("unknown resource %q", name)
+	}
+
+	r.tainted = true
+	return nil
+}
+
+// Tainted returns true if the resource will be recreated by the next
+// CreateAll. See Manager.Taint.
+func (r *Resource) Tainted() bool {
+	return r.tainted
+}
+
+// destroyTainted destroys the tainted resources that exist so that
+// CreateAll creates them from scratch. The taint is cleared once a
+// resource is destroyed or if it doesn't exist.
+func (m *Manager) destroyTainted(ctx context.Context, args []interface{}) error {
+	var order []string
+	if cs := m.createState; cs != nil {
+		for _, name := range cs.Order {
+			if r := m.resources[name]; r != nil && r.tainted {
+				order = append(order, name)
+			}
+		}
+	}
+
+	// Resources with state that aren't in the creation order, such as when
+	// the state was set manually, are destroyed in the order their state
+	// was set like in DestroyAll.
+	var manual []string
+	for name, r := range m.resources {
+		if r.tainted && !m.created(name) && r.hasState() {
+			manual = append(manual, name)
+		}
+	}
+	sort.Slice(manual, func(i, j int) bool {
+		return m.resources[manual[i]].setStateClock < m.resources[manual[j]].setStateClock
+	})
+	order = append(manual, order...)
+
+	if len(order) > 0 {
+		m.logger.Info("destroying tainted resources", "resources", order)
+		if err := m.destroy(ctx, order, args); err != nil {
+			return fmt.Errorf("error destroying tainted resources: %w", err)
+		}
+	}
+
+	for _, name := range order {
+		// Clear the state so that it's created from a zero state even if
+		// the resource is being updated.
+		m.resources[name].stateValue = nil
+		m.forget(name)
+	}
+	for _, r := range m.resources {
+		r.tainted = false
+	}
+
+	return nil
+}
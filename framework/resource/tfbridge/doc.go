@@ -0,0 +1,42 @@
+// Package tfbridge adapts the create, read and delete logic of Terraform
+// provider resources into resources for the framework/resource package.
+// This lets plugins reuse infrastructure logic that is already encoded in
+// a Terraform provider rather than reimplementing it.
+//
+// This package is experimental and may change in backwards incompatible
+// ways.
+//
+// To avoid depending on the Terraform plugin SDK, the adapter uses Funcs
+// and Data, which mirror the CRUD functions and schema.ResourceData of a
+// provider resource. A schema.Resource from the Terraform plugin SDK can be
+// adapted with a small shim that copies values between Data and
+// schema.ResourceData:
+//
+//	func create(r *schema.Resource) func(context.Context, *tfbridge.Data, interface{}) error {
+//		return func(ctx context.Context, d *tfbridge.Data, meta interface{}) error {
+//			rd := r.Data(nil)
+//			for k, v := range d.Attributes {
+//				if err := rd.Set(k, v); err != nil {
+//					return err
+//				}
+//			}
+//
+//			if diags := r.CreateContext(ctx, rd, meta); diags.HasError() {
+//				return fmt.Errorf("%v", diags)
+//			}
+//
+//			d.ID = rd.Id()
+//			for k := range r.Schema {
+//				d.Attributes[k] = rd.Get(k)
+//			}
+//			return nil
+//		}
+//	}
+//
+// Attribute values must be representable as JSON, so values such as a
+// *schema.Set must be converted, for example with its List method.
+//
+// The state of each resource is stored as a
+// proto.Framework_TerraformResourceState so it is saved and restored with
+// the rest of the resource manager state.
+package tfbridge
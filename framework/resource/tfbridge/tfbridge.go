@@ -0,0 +1,206 @@
+package tfbridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/framework/resource"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// Data is the equivalent of schema.ResourceData from the Terraform plugin
+// SDK: the ID and attributes of a single resource.
+type Data struct {
+	// ID is the ID of the resource. Create must set this. Read sets this
+	// to "" if the resource no longer exists, like Terraform providers.
+	ID string
+
+	// Attributes are the attributes of the resource keyed by the name in
+	// the resource schema. Values must be representable as JSON.
+	Attributes map[string]interface{}
+}
+
+// Funcs are the CRUD functions of a Terraform provider resource. The meta
+// argument is the configured provider client, such as the result of the
+// provider ConfigureContextFunc.
+type Funcs struct {
+	// Create creates the resource. d has the desired attributes. Create
+	// must set the ID and may update the attributes with computed values.
+	// This is required.
+	Create func(ctx context.Context, d *Data, meta interface{}) error
+
+	// Read refreshes the attributes of an existing resource. If the
+	// resource no longer exists, Read sets the ID to "". If this is set, it
+	// is used to report the status of the resource.
+	Read func(ctx context.Context, d *Data, meta interface{}) error
+
+	// Delete deletes the resource. If this isn't set, the resource is not
+	// deleted when it is destroyed.
+	Delete func(ctx context.Context, d *Data, meta interface{}) error
+}
+
+// NewResource returns a resource with the given name that manages a
+// Terraform provider resource with funcs. config are the desired
+// attributes of the resource and meta is passed to every function. Any
+// other options, such as resource.WithType and resource.WithPlatform, are
+// applied to the result.
+//
+// The functions of the resource require a context.Context, so the
+// resource manager must be given one, as is usual:
+//
+//	err := m.CreateAll(ctx, log, sg, ui)
+//
+// The state of the resource is a *proto.Framework_TerraformResourceState.
+// Other resources shouldn't require this type since it is shared by every
+// resource created with NewResource.
+func NewResource(
+	name string,
+	funcs *Funcs,
+	config map[string]interface{},
+	meta interface{},
+	opts ...resource.ResourceOption,
+) *resource.Resource {
+	b := &bridge{funcs: funcs, config: config, meta: meta}
+
+	resourceOpts := []resource.ResourceOption{
+		resource.WithName(name),
+		resource.WithState(&pb.Framework_TerraformResourceState{}),
+		resource.WithCreate(b.create),
+	}
+	if funcs.Delete != nil {
+		resourceOpts = append(resourceOpts, resource.WithDestroy(b.destroy))
+	}
+	if funcs.Read != nil {
+		resourceOpts = append(resourceOpts, resource.WithStatus(b.status))
+	}
+
+	b.resource = resource.NewResource(append(resourceOpts, opts...)...)
+	return b.resource
+}
+
+// bridge implements the resource functions for NewResource.
+type bridge struct {
+	funcs    *Funcs
+	config   map[string]interface{}
+	meta     interface{}
+	resource *resource.Resource
+}
+
+func (b *bridge) create(ctx context.Context, s *pb.Framework_TerraformResourceState) error {
+	if b.funcs.Create == nil {
+		return errors.New("Terraform resource has no create function")
+	}
+
+	// Copy the config so that Create can update the attributes.
+	d := &Data{Attributes: map[string]interface{}{}}
+	for k, v := range b.config {
+		d.Attributes[k] = v
+	}
+
+	if err := b.funcs.Create(ctx, d, b.meta); err != nil {
+		// Like Terraform, if the ID is set the resource exists even though
+		// there was an error, so we keep the state for it to be destroyed.
+		if d.ID != "" {
+			if stateErr := setState(s, d); stateErr != nil {
+				return multierror.Append(err, stateErr)
+			}
+		}
+
+		return err
+	}
+	if d.ID == "" {
+		return errors.New("Terraform resource create didn't set an ID")
+	}
+
+	return setState(s, d)
+}
+
+func (b *bridge) destroy(ctx context.Context) error {
+	// Nothing was created if there is no ID
+	s := b.state()
+	if s.Id == "" {
+		return nil
+	}
+
+	return b.funcs.Delete(ctx, stateData(s), b.meta)
+}
+
+func (b *bridge) status(ctx context.Context, sr *resource.StatusResponse) error {
+	s := b.state()
+	report := &pb.StatusReport_Resource{
+		Id:     s.Id,
+		Health: pb.StatusReport_READY,
+	}
+
+	d := stateData(s)
+	if s.Id == "" {
+		report.Health = pb.StatusReport_MISSING
+		report.HealthMessage = "resource has not been created"
+	} else if err := b.funcs.Read(ctx, d, b.meta); err != nil {
+		report.Health = pb.StatusReport_UNKNOWN
+		report.HealthMessage = err.Error()
+	} else if d.ID == "" {
+		report.Health = pb.StatusReport_MISSING
+		report.HealthMessage = "resource no longer exists"
+	} else if data, err := json.Marshal(d.Attributes); err == nil {
+		report.StateJson = string(data)
+	}
+
+	sr.Resources = append(sr.Resources, report)
+	return nil
+}
+
+// state returns the state of the resource. The state is read from the
+// resource rather than being an argument of destroy and status since the
+// resource manager provides the state of every resource to them and all
+// of our resources have the same state type.
+func (b *bridge) state() *pb.Framework_TerraformResourceState {
+	s, _ := b.resource.State().(*pb.Framework_TerraformResourceState)
+	if s == nil {
+		s = &pb.Framework_TerraformResourceState{}
+	}
+
+	return s
+}
+
+// setState sets the state s from d.
+func setState(s *pb.Framework_TerraformResourceState, d *Data) error {
+	attrs, err := attributesStruct(d.Attributes)
+	if err != nil {
+		return err
+	}
+
+	s.Id = d.ID
+	s.Attributes = attrs
+	return nil
+}
+
+// stateData returns the Data for the state s.
+func stateData(s *pb.Framework_TerraformResourceState) *Data {
+	return &Data{
+		ID:         s.Id,
+		Attributes: s.Attributes.AsMap(),
+	}
+}
+
+// attributesStruct converts the attributes to a Struct. The attributes are
+// encoded as JSON first so that any value that can be represented as JSON,
+// such as a []string, is supported.
+func attributesStruct(attrs map[string]interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding Terraform resource attributes: %w", err)
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("error encoding Terraform resource attributes: %w", err)
+	}
+
+	return structpb.NewStruct(values)
+}
@@ -0,0 +1,124 @@
+package tfbridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/framework/resource"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// testProvider is a fake provider that stores resources in memory.
+type testProvider struct {
+	nextID    int
+	resources map[string]map[string]interface{}
+}
+
+func (p *testProvider) funcs() *Funcs {
+	return &Funcs{
+		Create: func(ctx context.Context, d *Data, meta interface{}) error {
+			p := meta.(*testProvider)
+			p.nextID++
+			d.ID = fmt.Sprintf("r-%d", p.nextID)
+			d.Attributes["arn"] = "arn:" + d.ID
+			p.resources[d.ID] = d.Attributes
+			return nil
+		},
+
+		Read: func(ctx context.Context, d *Data, meta interface{}) error {
+			attrs, ok := meta.(*testProvider).resources[d.ID]
+			if !ok {
+				d.ID = ""
+				return nil
+			}
+
+			d.Attributes = attrs
+			return nil
+		},
+
+		Delete: func(ctx context.Context, d *Data, meta interface{}) error {
+			delete(meta.(*testProvider).resources, d.ID)
+			return nil
+		},
+	}
+}
+
+func TestNewResource(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	p := &testProvider{resources: map[string]map[string]interface{}{}}
+	init := func(dcr *component.DeclaredResourcesResp) *resource.Manager {
+		return resource.NewManager(
+			resource.WithResource(NewResource("bucket", p.funcs(),
+				map[string]interface{}{"name": "logs", "tags": []string{"a", "b"}},
+				p,
+				resource.WithPlatform("aws"),
+				resource.WithType("s3_bucket"),
+			)),
+			resource.WithResource(NewResource("queue", p.funcs(),
+				map[string]interface{}{"name": "jobs"}, p)),
+			resource.WithDeclaredResourcesResp(dcr),
+		)
+	}
+
+	// Create
+	var dcr component.DeclaredResourcesResp
+	m := init(&dcr)
+	require.NoError(m.CreateAll(ctx))
+	require.Len(p.resources, 2)
+	require.Len(dcr.DeclaredResources, 2)
+
+	state := m.Resource("bucket").State().(*pb.Framework_TerraformResourceState)
+	require.NotEmpty(state.Id)
+	require.Equal("logs", state.Attributes.AsMap()["name"])
+	require.Equal([]interface{}{"a", "b"}, state.Attributes.AsMap()["tags"])
+	require.Equal("arn:"+state.Id, state.Attributes.AsMap()["arn"])
+
+	// Status reads the resources
+	m2 := init(nil)
+	require.NoError(m2.LoadState(m.State()))
+	report, err := m2.StatusReport(ctx)
+	require.NoError(err)
+	require.Equal(pb.StatusReport_READY, report.Health)
+
+	delete(p.resources, state.Id)
+	report, err = m2.StatusReport(ctx)
+	require.NoError(err)
+	require.Equal(pb.StatusReport_PARTIAL, report.Health)
+
+	// Destroy
+	require.NoError(m2.DestroyAll(ctx))
+	require.Empty(p.resources)
+}
+
+func TestNewResource_createError(t *testing.T) {
+	require := require.New(t)
+
+	var deleted string
+	funcs := &Funcs{
+		Create: func(ctx context.Context, d *Data, meta interface{}) error {
+			// The resource was created but not configured
+			d.ID = "r-1"
+			return errors.New("failed to configure")
+		},
+
+		Delete: func(ctx context.Context, d *Data, meta interface{}) error {
+			deleted = d.ID
+			return nil
+		},
+	}
+
+	m := resource.NewManager(resource.WithResource(NewResource("bucket", funcs, nil, nil)))
+	err := m.CreateAll(context.Background())
+	require.Error(err)
+	require.Contains(err.Error(), "failed to configure")
+
+	// The partially created resource is rolled back
+	require.Equal("r-1", deleted)
+}
@@ -0,0 +1,139 @@
+package resource
+
+import (
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// The operations that are timed. See Timing.
+const (
+	OperationCreate  = "create"
+	OperationDestroy = "destroy"
+	OperationStatus  = "status"
+	OperationRead    = "read"
+)
+
+// Timing is the timing of an operation on a single resource, such as
+// calling its create function.
+type Timing struct {
+	// Resource is the name of the resource.
+	Resource string
+
+	// Operation is the operation, one of the Operation constants.
+	Operation string
+
+	// Phase is the name of the destroy phase for resources destroyed in
+	// phases. See WithDestroyPhases.
+	Phase string
+
+	// Start and End are when the operation started and ended. For
+	// creation, this includes the waiter and post-create functions.
+	Start time.Time
+	End   time.Time
+
+	// Failed is true if the operation returned an error.
+	Failed bool
+}
+
+// Duration returns how long the operation took.
+func (t *Timing) Duration() time.Duration {
+	return t.End.Sub(t.Start)
+}
+
+// Report returns the timings of the last call of each operation on every
+// resource under management, ordered by start time. This includes the
+// timings from the state loaded with LoadState, so timings of earlier
+// operations, such as the creation of a deployment, are available to later
+// operations.
+//
+// Plugins can use this to log slow resources, such as:
+//
+//	for _, t := range m.Report() {
+//		if t.Duration() > time.Minute {
+//			log.Warn("slow resource", "name", t.Resource, "operation", t.Operation)
+//		}
+//	}
+func (m *Manager) Report() []*Timing {
+	var result []*Timing
+	for _, r := range m.resources {
+		for _, t := range r.timings {
+			c := *t
+			result = append(result, &c)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if !a.Start.Equal(b.Start) {
+			return a.Start.Before(b.Start)
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+
+		return a.Operation < b.Operation
+	})
+
+	return result
+}
+
+// track records the timing of an operation that started at start and
+// returned err. This replaces the timing of an earlier call of the same
+// operation.
+//
+// The monotonic clock readings are stripped so the durations are the same
+// before and after the timings are stored in the state.
+func (r *Resource) track(op, phase string, start time.Time, err error) {
+	t := &Timing{
+		Resource:  r.name,
+		Operation: op,
+		Phase:     phase,
+		Start:     start.Round(0),
+		End:       time.Now().Round(0),
+		Failed:    err != nil,
+	}
+
+	for i, existing := range r.timings {
+		if existing.Operation == op && existing.Phase == phase {
+			r.timings[i] = t
+			return
+		}
+	}
+
+	r.timings = append(r.timings, t)
+}
+
+// timingsProto returns the timings of the resource for its state.
+func (r *Resource) timingsProto() []*pb.Framework_Timing {
+	var result []*pb.Framework_Timing
+	for _, t := range r.timings {
+		result = append(result, &pb.Framework_Timing{
+			Operation: t.Operation,
+			Phase:     t.Phase,
+			StartTime: timestamppb.New(t.Start),
+			EndTime:   timestamppb.New(t.End),
+			Failed:    t.Failed,
+		})
+	}
+
+	return result
+}
+
+// loadTimings loads the timings of the resource from its state.
+func (r *Resource) loadTimings(ts []*pb.Framework_Timing) {
+	r.timings = nil
+	for _, t := range ts {
+		r.timings = append(r.timings, &Timing{
+			Resource:  r.name,
+			Operation: t.Operation,
+			Phase:     t.Phase,
+			Start:     t.StartTime.AsTime(),
+			End:       t.EndTime.AsTime(),
+			Failed:    t.Failed,
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/go-argmapper"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+)
+
+// WithWaiter sets a function that is called after the create function
+// succeeds to wait until the resource is ready, such as an AWS SDK
+// WaitUntilX function or waiting on a GCP operation.
+//
+// The function has the same arguments available as the create function,
+// including the state, and must return an error if the resource never
+// becomes ready. A context.Context is always available to it, which is
+// canceled when the timeout set with WithWaitTimeout is reached. If the
+// create or wait function takes a terminal.UI, a status is shown while
+// waiting.
+//
+// If the waiter returns an error, the creation fails and the Manager rolls
+// back like any other creation error.
+func WithWaiter(f interface{}, opts ...WaiterOption) ResourceOption {
+	return func(r *Resource) {
+		w := &waiter{f: f}
+		for _, opt := range opts {
+			opt(w)
+		}
+
+		r.waiter = w
+	}
+}
+
+// WaiterOption is used to configure WithWaiter.
+type WaiterOption func(*waiter)
+
+// WithWaitTimeout sets the maximum time to wait for the resource to be
+// ready. The default is no timeout other than the context given to the
+// Manager.
+func WithWaitTimeout(d time.Duration) WaiterOption {
+	return func(w *waiter) { w.timeout = d }
+}
+
+// WithWaitMessage sets the status message shown while waiting. The default
+// is "Waiting for <name> to be ready...".
+func WithWaitMessage(msg string) WaiterOption {
+	return func(w *waiter) { w.message = msg }
+}
+
+type waiter struct {
+	f       interface{}
+	timeout time.Duration
+	message string
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	uiType      = reflect.TypeOf((*terminal.UI)(nil)).Elem()
+)
+
+// wait calls the waiter for the resource using the given inputs of the
+// create mapper. The context in the inputs, or ctx if there is none, is
+// replaced with one that respects the timeout.
+func (r *Resource) wait(ctx context.Context, f *argmapper.Func, in *argmapper.ValueSet) error {
+	var ui terminal.UI
+	var args []argmapper.Arg
+	for _, v := range in.Values() {
+		v := v
+		switch v.Type {
+		case contextType:
+			if c, ok := v.Value.Interface().(context.Context); ok && c != nil {
+				ctx = c
+			}
+			continue
+
+		case uiType:
+			ui, _ = v.Value.Interface().(terminal.UI)
+		}
+
+		args = append(args, v.Arg())
+	}
+
+	if r.stateType != nil {
+		args = append(args, argmapper.Typed(r.stateValue))
+	}
+
+	if r.waiter.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.waiter.timeout)
+		defer cancel()
+	}
+	args = append(args, argmapper.Typed(ctx))
+
+	msg := r.waiter.message
+	if msg == "" {
+		msg = fmt.Sprintf("Waiting for %s to be ready...", r.name)
+	}
+
+	var st terminal.Status
+	if ui != nil {
+		st = ui.Status()
+		defer st.Close()
+		st.Update(msg)
+	}
+
+	result := f.Call(args...)
+	err := result.Err()
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s waiting for resource %q: %w",
+			r.waiter.timeout, r.name, err)
+	}
+
+	if st != nil {
+		if err != nil {
+			st.Step(terminal.StatusError, msg)
+		} else {
+			st.Step(terminal.StatusOK, msg)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,99 @@
+package resource
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/opaqueany"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// WorkspaceState is the state of all the resource managers that created
+// resources in a workspace, such as the managers of every deployment and
+// release. WorkspaceDestroyer implementations can accept a *WorkspaceState
+// as an argument to DestroyWorkspaceFunc to destroy the resources that are
+// left in the workspace:
+//
+//	func (p *Platform) destroyWorkspace(ws *resource.WorkspaceState) error {
+//		return ws.DestroyAll(p.resourceManager)
+//	}
+//
+// The states are the opaque values returned by Manager.State. A
+// WorkspaceState is sent to the plugin as a *proto.Framework_WorkspaceState.
+type WorkspaceState struct {
+	states []*opaqueany.Any
+}
+
+// NewWorkspaceState returns a WorkspaceState with the given states, see Add.
+func NewWorkspaceState(states ...*opaqueany.Any) *WorkspaceState {
+	var result WorkspaceState
+	result.Add(states...)
+	return &result
+}
+
+// WorkspaceStateFromProto returns the WorkspaceState for the proto
+// message. This returns an empty WorkspaceState if v is nil.
+func WorkspaceStateFromProto(v *pb.Framework_WorkspaceState) *WorkspaceState {
+	return NewWorkspaceState(v.GetStates()...)
+}
+
+// Add adds manager states to the workspace state. Nil states and states
+// equal to one that was already added are ignored, so the same state can
+// safely be added for both a deployment and its release.
+func (w *WorkspaceState) Add(states ...*opaqueany.Any) {
+	for _, s := range states {
+		if s == nil || w.contains(s) {
+			continue
+		}
+
+		w.states = append(w.states, s)
+	}
+}
+
+func (w *WorkspaceState) contains(s *opaqueany.Any) bool {
+	for _, existing := range w.states {
+		if proto.Equal(existing, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// States returns the manager states in the order they were added.
+func (w *WorkspaceState) States() []*opaqueany.Any {
+	return append([]*opaqueany.Any(nil), w.states...)
+}
+
+// Proto returns the proto message for the workspace state.
+func (w *WorkspaceState) Proto() *pb.Framework_WorkspaceState {
+	return &pb.Framework_WorkspaceState{States: w.States()}
+}
+
+// DestroyAll destroys the resources of every manager state, most recently
+// added first. newManager must return a new Manager with the same
+// resources as the managers that created the states; the state is loaded
+// into it and its DestroyAll is called with args.
+//
+// All the states are destroyed even if some fail. The errors are returned
+// together.
+func (w *WorkspaceState) DestroyAll(newManager func() *Manager, args ...interface{}) error {
+	var result error
+	for i := len(w.states) - 1; i >= 0; i-- {
+		m := newManager()
+		if err := m.LoadState(w.states[i]); err != nil {
+			result = multierror.Append(result, fmt.Errorf(
+				"failed to load state %d: %w", i, err))
+			continue
+		}
+
+		if err := m.DestroyAll(args...); err != nil {
+			result = multierror.Append(result, fmt.Errorf(
+				"failed to destroy state %d: %w", i, err))
+		}
+	}
+
+	return result
+}
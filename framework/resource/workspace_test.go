@@ -0,0 +1,90 @@
+package resource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
+)
+
+func TestWorkspaceStateDestroyAll(t *testing.T) {
+	require := require.New(t)
+
+	var destroyed []string
+	init := func() *Manager {
+		return NewManager(
+			WithResource(NewResource(
+				WithName("A"),
+				WithState(&testproto.Data{}),
+				WithCreate(func(s *testproto.Data, v string) error {
+					s.Value = v
+					return nil
+				}),
+				WithDestroy(func(s *testproto.Data) error {
+					destroyed = append(destroyed, s.Value)
+					return nil
+				}),
+			)),
+		)
+	}
+
+	// Create a manager for each deployment
+	ws := NewWorkspaceState()
+	for _, v := range []string{"v1", "v2"} {
+		m := init()
+		require.NoError(m.CreateAll(v))
+		ws.Add(m.State())
+
+		// Adding the same state again, such as for a release, is ignored
+		ws.Add(m.State(), nil)
+	}
+	require.Len(ws.States(), 2)
+
+	// Round trip through the proto like core does
+	ws = WorkspaceStateFromProto(ws.Proto())
+
+	// Destroy
+	require.NoError(ws.DestroyAll(init))
+	require.Equal([]string{"v2", "v1"}, destroyed)
+}
+
+func TestWorkspaceStateDestroyAll_error(t *testing.T) {
+	require := require.New(t)
+
+	var destroyed []string
+	init := func() *Manager {
+		return NewManager(
+			WithResource(NewResource(
+				WithName("A"),
+				WithState(&testproto.Data{}),
+				WithCreate(func(s *testproto.Data, v string) error {
+					s.Value = v
+					return nil
+				}),
+				WithDestroy(func(s *testproto.Data) error {
+					if s.Value == "v2" {
+						return errors.New("failed")
+					}
+
+					destroyed = append(destroyed, s.Value)
+					return nil
+				}),
+			)),
+		)
+	}
+
+	ws := NewWorkspaceState()
+	for _, v := range []string{"v1", "v2"} {
+		m := init()
+		require.NoError(m.CreateAll(v))
+		ws.Add(m.State())
+	}
+
+	// The other states are still destroyed if one fails
+	err := ws.DestroyAll(init)
+	require.Error(err)
+	require.Contains(err.Error(), "failed")
+	require.Equal([]string{"v1"}, destroyed)
+}
@@ -0,0 +1,11 @@
+package sdk
+
+import (
+	"github.com/hashicorp/waypoint-plugin-sdk/host"
+)
+
+// HostClient gives plugin functions access to selected services of the
+// host, such as the previous deployments of the app. Plugin functions
+// request a *HostClient as an argument like any other value provided by
+// the host. See the host package for details.
+type HostClient = host.Client
@@ -0,0 +1,172 @@
+// Package host gives plugins access to selected services of the host, such
+// as the previous deployments of the app, the artifacts it built and the
+// config variables of the project. The calls are made over the plugin
+// connection, so the plugin never talks to the Waypoint server directly and
+// only sees what the host chooses to expose.
+//
+// Plugin functions request a *Client as an argument, also available as
+// *sdk.HostClient:
+//
+//	func (p *Platform) deploy(ctx context.Context, host *host.Client) (*Deployment, error) {
+//		prev, err := host.PreviousDeployment(ctx)
+//		if err != nil {
+//			return nil, err
+//		}
+//		...
+//	}
+//
+// The host provides its implementation of the services as a Service.
+package host
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/opaqueany"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrNotFound is returned by the Service when the requested value doesn't
+// exist. Errors are matched against it with errors.Is.
+var ErrNotFound = errors.New("not found")
+
+// Service is the set of host services available to plugins. Hosts that
+// don't support a service return an error with the gRPC Unimplemented
+// code from it.
+type Service interface {
+	// ListDeployments returns the deployments of the app, newest first.
+	ListDeployments(ctx context.Context, opts *ListDeploymentsOptions) ([]*Deployment, error)
+
+	// Artifact returns the artifact of the app with the given ID. If it
+	// doesn't exist, the error matches ErrNotFound.
+	Artifact(ctx context.Context, id string) (*Artifact, error)
+
+	// ConfigVars returns the config variables of the project, by name.
+	ConfigVars(ctx context.Context) (map[string]string, error)
+}
+
+// ListDeploymentsOptions are the options for Service.ListDeployments.
+type ListDeploymentsOptions struct {
+	// Workspace limits the deployments to a workspace. If this is empty,
+	// the workspace of the operation is used.
+	Workspace string
+
+	// Limit is the maximum number of deployments to return. If this is
+	// zero, all the deployments are returned.
+	Limit int
+}
+
+// Deployment is a deployment of the app.
+type Deployment struct {
+	// Id is the ID the host assigned to the deployment.
+	Id string
+
+	// Sequence is the number of the deployment within the app.
+	Sequence uint64
+
+	// Workspace is the workspace the deployment is in.
+	Workspace string
+
+	// State is the state of the deployment operation, such as "success"
+	// or "error".
+	State string
+
+	// Labels are the labels of the deployment.
+	Labels map[string]string
+
+	// Value is the deployment value the platform returned. Use Decode to
+	// read it into the deployment type of the plugin.
+	Value *opaqueany.Any
+
+	// CreatedAt is the time the deployment started.
+	CreatedAt time.Time
+}
+
+// The states of deployments.
+const (
+	StateSuccess = "success"
+	StateError   = "error"
+	StateRunning = "running"
+)
+
+// Decode unmarshals the value of the deployment into v, which is usually
+// the deployment type of the plugin.
+func (d *Deployment) Decode(v proto.Message) error {
+	return decode(d.Value, v)
+}
+
+// Artifact is an artifact of the app.
+type Artifact struct {
+	// Id is the ID the host assigned to the artifact.
+	Id string
+
+	// Sequence is the number of the artifact within the app.
+	Sequence uint64
+
+	// Labels are the labels of the artifact.
+	Labels map[string]string
+
+	// Value is the artifact value the builder or registry returned. Use
+	// Decode to read it into the artifact type of the plugin.
+	Value *opaqueany.Any
+
+	// CreatedAt is the time the artifact was built.
+	CreatedAt time.Time
+}
+
+// Decode unmarshals the value of the artifact into v, which is usually
+// the artifact type of the plugin.
+func (a *Artifact) Decode(v proto.Message) error {
+	return decode(a.Value, v)
+}
+
+// Client is the Service available to plugins with helpers for common
+// questions, such as what the previous deployment was.
+type Client struct {
+	Service
+}
+
+// NewClient returns a Client for s. This is used by the SDK, and by
+// plugins to test functions that take a Client with a fake Service.
+func NewClient(s Service) *Client {
+	return &Client{Service: s}
+}
+
+// PreviousDeployment returns the latest successful deployment of the app
+// in the workspace of the operation. This returns nil if there is none.
+func (c *Client) PreviousDeployment(ctx context.Context) (*Deployment, error) {
+	deployments, err := c.ListDeployments(ctx, &ListDeploymentsOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range deployments {
+		if d.State == StateSuccess {
+			return d, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ConfigVar returns the value of the project config variable name and
+// whether it is set.
+func (c *Client) ConfigVar(ctx context.Context, name string) (string, bool, error) {
+	vars, err := c.ConfigVars(ctx)
+	if err != nil {
+		return "", false, err
+	}
+
+	v, ok := vars[name]
+	return v, ok, nil
+}
+
+// decode unmarshals value into v.
+func decode(value *opaqueany.Any, v proto.Message) error {
+	if value == nil {
+		return errors.New("no value to decode")
+	}
+
+	return value.UnmarshalTo(v)
+}
@@ -0,0 +1,89 @@
+package sdk
+
+import (
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pkg/sdkversion"
+)
+
+// The name, version and commit of the plugin can be set at build time
+// rather than with WithPluginInfo, so release tooling can stamp them:
+//
+//	go build -ldflags "\
+//		-X github.com/hashicorp/waypoint-plugin-sdk.buildName=docker \
+//		-X github.com/hashicorp/waypoint-plugin-sdk.buildVersion=v1.2.3 \
+//		-X github.com/hashicorp/waypoint-plugin-sdk.buildCommit=$(git rev-parse HEAD)"
+//
+// Values set with WithPluginInfo take precedence. If neither
+// sets the version or commit, they are read from the build information Go
+// embeds in the binary when available.
+var (
+	buildName    string
+	buildVersion string
+	buildCommit  string
+)
+
+// PluginInfo is the metadata of a plugin binary that is reported to hosts,
+// such as for listing the installed plugins with their versions. The SDK
+// version the plugin was built with is always reported.
+type PluginInfo struct {
+	// Name is the name of the plugin, such as "docker".
+	Name string
+
+	// Version is the version of the plugin, such as "v1.2.3".
+	Version string
+
+	// Commit is the VCS commit the plugin was built from.
+	Commit string
+}
+
+// WithPluginInfo sets the metadata of the plugin. This is reported to hosts
+// and tooling along with the served components by the info plugin and the
+// manifest command (see ManifestCommand). Empty fields are left unchanged,
+// so they fall back to the values set at build time.
+func WithPluginInfo(info PluginInfo) Option {
+	return func(c *config) {
+		if info.Name != "" {
+			c.Name = info.Name
+		}
+		if info.Version != "" {
+			c.Version = info.Version
+		}
+		if info.Commit != "" {
+			c.Commit = info.Commit
+		}
+	}
+}
+
+// pluginName returns the name of the plugin to report.
+func (c *config) pluginName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+
+	return buildName
+}
+
+// pluginVersion returns the version of the plugin to report.
+func (c *config) pluginVersion() string {
+	if c.Version != "" {
+		return c.Version
+	}
+	if buildVersion != "" {
+		return buildVersion
+	}
+
+	version, _ := sdkversion.Main()
+	return version
+}
+
+// pluginCommit returns the commit of the plugin to report.
+func (c *config) pluginCommit() string {
+	if c.Commit != "" {
+		return c.Commit
+	}
+	if buildCommit != "" {
+		return buildCommit
+	}
+
+	_, commit := sdkversion.Main()
+	return commit
+}
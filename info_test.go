@@ -0,0 +1,32 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPluginInfo(t *testing.T) {
+	require := require.New(t)
+
+	defer func(name, version, commit string) {
+		buildName, buildVersion, buildCommit = name, version, commit
+	}(buildName, buildVersion, buildCommit)
+	buildName, buildVersion, buildCommit = "built", "v0.0.1", "def456"
+
+	// Build time values are used if nothing is set
+	var c config
+	require.Equal("built", c.pluginName())
+	require.Equal("v0.0.1", c.pluginVersion())
+	require.Equal("def456", c.pluginCommit())
+
+	// Options take precedence, but empty fields are left unchanged
+	WithPluginInfo(PluginInfo{Name: "test", Version: "v1.2.3"})(&c)
+	require.Equal("test", c.pluginName())
+	require.Equal("v1.2.3", c.pluginVersion())
+	require.Equal("def456", c.pluginCommit())
+
+	WithPluginInfo(PluginInfo{Commit: "abc123"})(&c)
+	require.Equal("test", c.pluginName())
+	require.Equal("abc123", c.pluginCommit())
+}
@@ -1,12 +1,19 @@
 package pluginclient
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
 
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pkg/sdkversion"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pkg/tracing"
 	internalplugin "github.com/hashicorp/waypoint-plugin-sdk/internal/plugin"
 )
 
@@ -37,7 +44,40 @@ func ClientConfig(log hclog.Logger, odr bool) *plugin.ClientConfig {
 	}
 }
 
-// Mappers returns the mappers supported by the plugin.
+// TracingDialOptions returns the dial options that trace the calls to a
+// plugin with tp and send the trace context to the plugin, so the spans of
+// plugins served with sdk.WithTracerProvider are part of the same traces.
+// Add these to the GRPCDialOptions of the client config.
+func TracingDialOptions(tp trace.TracerProvider) []grpc.DialOption {
+	return tracing.DialOptions(tp)
+}
+
+// MaxMessageSizeDialOptions returns the dial options that limit the
+// messages sent to and received from a plugin to n bytes. Add these to
+// the GRPCDialOptions of the client config for plugins served with
+// sdk.WithMaxMessageSize, with the same size, so both sides of a call use
+// the same limit.
+func MaxMessageSizeDialOptions(n int) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(n),
+			grpc.MaxCallSendMsgSize(n),
+		),
+	}
+}
+
+// CompressionDialOptions returns the dial options that compress the
+// requests to a plugin with gzip. Add these to the GRPCDialOptions of the
+// client config for plugins served with sdk.WithCompression. Plugins
+// accept compressed requests regardless of their settings.
+func CompressionDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)),
+	}
+}
+
+// Mappers returns the mappers supported by the plugin. The mappers are
+// listed once for each connection to the plugin and cached after that.
 func Mappers(c *plugin.Client) ([]*argmapper.Func, error) {
 	rpcClient, err := c.Client()
 	if err != nil {
@@ -56,3 +96,103 @@ func Mappers(c *plugin.Client) ([]*argmapper.Func, error) {
 
 	return client.Mappers()
 }
+
+// NegotiateFeatures negotiates the optional protocol features with the
+// plugin. fs are the features the host supports in addition to the
+// features the SDK implements, such as component.FeatureLargeValues. This
+// returns the features that both the host and the plugin support, which
+// are enabled for the components of the plugin. Call this before calling
+// the components so that they can use the features. Plugins that predate
+// negotiation have no features enabled.
+func NegotiateFeatures(
+	ctx context.Context,
+	c *plugin.Client,
+	fs ...component.Feature,
+) (*component.Features, error) {
+	rpcClient, err := c.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := rpcClient.Dispense("info")
+	if err != nil {
+		return nil, err
+	}
+
+	client, ok := v.(*internalplugin.InfoClient)
+	if !ok {
+		return nil, fmt.Errorf("info service was unexpected type: %T", v)
+	}
+
+	return client.Negotiate(ctx, fs...)
+}
+
+// SDKVersion is the version of the SDK the host was built with. This is
+// empty if it is unknown.
+func SDKVersion() string {
+	return sdkversion.Version()
+}
+
+// CheckSDKVersion returns an error if the plugin described by info was
+// built with an SDK version that differs from the SDK of the host in its
+// major or minor version, see sdkversion.Compatible. Hosts should warn
+// rather than fail, since such plugins usually still work but may lack
+// features. Plugins that don't report their SDK version are assumed to be
+// compatible.
+func CheckSDKVersion(info *component.PluginInfo) error {
+	host := SDKVersion()
+	if sdkversion.Compatible(host, info.SDKVersion) {
+		return nil
+	}
+
+	name := info.Name
+	if name == "" {
+		name = "plugin"
+	}
+
+	return fmt.Errorf(
+		"%s was built with SDK %s, which differs from the host SDK %s; "+
+			"some features may not be available",
+		name, info.SDKVersion, host)
+}
+
+// Dispense dispenses the component of the given type with the given name
+// from the plugin. If name is empty, the component served with
+// sdk.WithComponents is dispensed. Otherwise the component served with
+// sdk.WithNamedComponent with that name is dispensed. The names of the
+// components a plugin serves are available from the "info" plugin. This
+// is safe to call concurrently.
+func Dispense(c *plugin.Client, typ component.Type, name string) (interface{}, error) {
+	rpcClient, err := c.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	key := internalplugin.PluginName(typ, name)
+	if name == "" {
+		return rpcClient.Dispense(key)
+	}
+
+	grpcClient, ok := rpcClient.(*plugin.GRPCClient)
+	if !ok {
+		return nil, fmt.Errorf("plugin client was unexpected type: %T", rpcClient)
+	}
+
+	// Named components aren't in the plugin set from ClientConfig since we
+	// can't know the names in advance. The set is shared by every dispense
+	// from the client, so rather than adding them to it we dispense from a
+	// copy of the client with a set of just the named component.
+	base, ok := grpcClient.Plugins[internalplugin.PluginName(typ, "")]
+	if !ok {
+		return nil, fmt.Errorf("unknown plugin type: %s", typ)
+	}
+
+	named, err := internalplugin.NamedPlugin(base, name)
+	if err != nil {
+		return nil, err
+	}
+
+	dispenser := *grpcClient
+	dispenser.Plugins = map[string]plugin.Plugin{key: named}
+	return dispenser.Dispense(key)
+}
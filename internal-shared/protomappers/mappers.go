@@ -3,17 +3,30 @@ package protomappers
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
-	"github.com/hashicorp/go-plugin"
 	"github.com/mitchellh/mapstructure"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/component/ociref"
+	"github.com/hashicorp/waypoint-plugin-sdk/component/pipe"
 	"github.com/hashicorp/waypoint-plugin-sdk/datadir"
+	"github.com/hashicorp/waypoint-plugin-sdk/filesync"
+	"github.com/hashicorp/waypoint-plugin-sdk/framework/resource"
+	"github.com/hashicorp/waypoint-plugin-sdk/host"
+	pluginconfigwatch "github.com/hashicorp/waypoint-plugin-sdk/internal/plugin/configwatch"
 	pluginexec "github.com/hashicorp/waypoint-plugin-sdk/internal/plugin/exec"
+	pluginfilesync "github.com/hashicorp/waypoint-plugin-sdk/internal/plugin/filesync"
+	pluginhost "github.com/hashicorp/waypoint-plugin-sdk/internal/plugin/host"
 	pluginlogs "github.com/hashicorp/waypoint-plugin-sdk/internal/plugin/logs"
+	pluginpipe "github.com/hashicorp/waypoint-plugin-sdk/internal/plugin/pipe"
+	pluginportforward "github.com/hashicorp/waypoint-plugin-sdk/internal/plugin/portforward"
+	plugintaskwatch "github.com/hashicorp/waypoint-plugin-sdk/internal/plugin/taskwatch"
 	pluginterminal "github.com/hashicorp/waypoint-plugin-sdk/internal/plugin/terminal"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
@@ -24,10 +37,15 @@ import (
 var All = []interface{}{
 	Source,
 	SourceProto,
+	BuildContext,
 	JobInfo,
 	JobInfoProto,
+	Context,
+	ContextProto,
 	DeploymentConfig,
 	DeploymentConfigProto,
+	PreviousDeployment,
+	PreviousDeploymentProto,
 	DatadirProject,
 	DatadirApp,
 	DatadirComponent,
@@ -44,10 +62,43 @@ var All = []interface{}{
 	LabelSetProto,
 	ExecSessionInfo,
 	ExecSessionInfoProto,
+	PortForwardInfo,
+	PortForwardInfoProto,
 	LogViewer,
 	LogViewerProto,
+	ConfigWatcher,
+	ConfigWatcherProto,
 	TaskLaunchInfo,
 	TaskLaunchInfoProto,
+	TaskWatchInfo,
+	TaskWatchInfoProto,
+	Pipe,
+	PipeProto,
+	PipePublisher,
+	FileSync,
+	FileSyncProto,
+	HostClient,
+	HostClientProto,
+	OCIRef,
+	OCIRefProto,
+	Secret,
+	SecretProto,
+	Remediation,
+	RemediationProto,
+	StatusFilter,
+	StatusFilterProto,
+	ProtocolVersion,
+	Features,
+	PreviousGeneration,
+	PreviousGenerationProto,
+	Duration,
+	DurationProto,
+	Timestamp,
+	TimestampProto,
+	Struct,
+	StructProto,
+	WorkspaceState,
+	WorkspaceStateProto,
 }
 
 // Source maps Args.Source to component.Source.
@@ -62,6 +113,12 @@ func SourceProto(input *component.Source) (*pb.Args_Source, error) {
 	return &result, mapstructure.Decode(input, &result)
 }
 
+// BuildContext maps Args.Source to component.BuildContext. There is no
+// reverse mapper since the build context is only ever derived from the source.
+func BuildContext(input *pb.Args_Source) *component.BuildContext {
+	return &component.BuildContext{Path: input.Path}
+}
+
 // JobInfo maps Args.JobInfo to component.JobInfo.
 func JobInfo(input *pb.Args_JobInfo) (*component.JobInfo, error) {
 	var result component.JobInfo
@@ -74,6 +131,37 @@ func JobInfoProto(input *component.JobInfo) (*pb.Args_JobInfo, error) {
 	return &result, mapstructure.Decode(input, &result)
 }
 
+// Context maps Args.Context to component.Context.
+func Context(input *pb.Args_Context) (*component.Context, error) {
+	result := component.Context{
+		AppLabels:     input.AppLabels,
+		RunnerId:      input.RunnerId,
+		ServerVersion: input.ServerVersion,
+	}
+	if input.JobInfo != nil {
+		if err := mapstructure.Decode(input.JobInfo, &result.JobInfo); err != nil {
+			return nil, err
+		}
+	}
+
+	return &result, nil
+}
+
+// ContextProto
+func ContextProto(input *component.Context) (*pb.Args_Context, error) {
+	var jobInfo pb.Args_JobInfo
+	if err := mapstructure.Decode(&input.JobInfo, &jobInfo); err != nil {
+		return nil, err
+	}
+
+	return &pb.Args_Context{
+		JobInfo:       &jobInfo,
+		AppLabels:     input.AppLabels,
+		RunnerId:      input.RunnerId,
+		ServerVersion: input.ServerVersion,
+	}, nil
+}
+
 // TaskLaunchInfo maps Args.Args_TaskLaunchInfo to component.TaskLaunchInfo.
 func TaskLaunchInfo(input *pb.Args_TaskLaunchInfo) (*component.TaskLaunchInfo, error) {
 	var result component.TaskLaunchInfo
@@ -86,6 +174,63 @@ func TaskLaunchInfoProto(input *component.TaskLaunchInfo) (*pb.Args_TaskLaunchIn
 	return &result, mapstructure.Decode(input, &result)
 }
 
+// OCIRef maps *pb.OCIRef to *ociref.Reference. The result is normalized
+// and validated.
+func OCIRef(input *pb.OCIRef) (*ociref.Reference, error) {
+	return ociref.FromProto(input)
+}
+
+// OCIRefProto
+func OCIRefProto(input *ociref.Reference) *pb.OCIRef {
+	return input.Proto()
+}
+
+// StatusFilter maps StatusReport.Filter to component.StatusFilter.
+func StatusFilter(input *pb.StatusReport_Filter) *component.StatusFilter {
+	return &component.StatusFilter{
+		Types:     input.Types,
+		Health:    input.Health,
+		PageSize:  int(input.PageSize),
+		PageToken: input.PageToken,
+	}
+}
+
+// StatusFilterProto
+func StatusFilterProto(input *component.StatusFilter) *pb.StatusReport_Filter {
+	return &pb.StatusReport_Filter{
+		Types:     input.Types,
+		Health:    input.Health,
+		PageSize:  uint32(input.PageSize),
+		PageToken: input.PageToken,
+	}
+}
+
+// Secret maps Secret to component.Secret.
+func Secret(input *pb.Secret) component.Secret {
+	return component.SecretFromProto(input)
+}
+
+// SecretProto
+func SecretProto(input component.Secret) *pb.Secret {
+	return input.Proto()
+}
+
+// Remediation maps Args.Remediation to component.Remediation.
+func Remediation(input *pb.Args_Remediation) *component.Remediation {
+	return &component.Remediation{
+		Action:   input.Action,
+		Resource: input.Resource,
+	}
+}
+
+// RemediationProto
+func RemediationProto(input *component.Remediation) *pb.Args_Remediation {
+	return &pb.Args_Remediation{
+		Action:   input.Action,
+		Resource: input.Resource,
+	}
+}
+
 // DeploymentConfig
 func DeploymentConfig(input *pb.Args_DeploymentConfig) (*component.DeploymentConfig, error) {
 	var result component.DeploymentConfig
@@ -97,6 +242,25 @@ func DeploymentConfigProto(input *component.DeploymentConfig) (*pb.Args_Deployme
 	return &result, mapstructure.Decode(input, &result)
 }
 
+// PreviousDeployment maps Args.PreviousDeployment to
+// component.PreviousDeployment.
+func PreviousDeployment(input *pb.Args_PreviousDeployment) *component.PreviousDeployment {
+	return &component.PreviousDeployment{
+		ID:         input.Id,
+		Sequence:   input.Sequence,
+		Deployment: input.Deployment,
+	}
+}
+
+// PreviousDeploymentProto
+func PreviousDeploymentProto(input *component.PreviousDeployment) *pb.Args_PreviousDeployment {
+	return &pb.Args_PreviousDeployment{
+		Id:         input.ID,
+		Sequence:   input.Sequence,
+		Deployment: input.Deployment,
+	}
+}
+
 // DatadirProject maps *pb.Args_DataDir_Project to *datadir.Project
 func DatadirProject(input *pb.Args_DataDir_Project) *datadir.Project {
 	dir := datadir.NewBasicDir(input.CacheDir, input.DataDir)
@@ -161,6 +325,80 @@ func LoggerProto(log hclog.Logger) *pb.Args_Logger {
 	}
 }
 
+// PreviousGeneration maps Generation.Previous to component.PreviousGeneration.
+func PreviousGeneration(input *pb.Generation_Previous) *component.PreviousGeneration {
+	return &component.PreviousGeneration{
+		ID:       input.Id,
+		Sequence: input.Sequence,
+	}
+}
+
+// PreviousGenerationProto
+func PreviousGenerationProto(input *component.PreviousGeneration) *pb.Generation_Previous {
+	return &pb.Generation_Previous{
+		Id:       input.ID,
+		Sequence: input.Sequence,
+	}
+}
+
+// Duration maps a *durationpb.Duration to a time.Duration.
+func Duration(input *durationpb.Duration) time.Duration {
+	return input.AsDuration()
+}
+
+// DurationProto maps a time.Duration to a *durationpb.Duration.
+func DurationProto(input time.Duration) *durationpb.Duration {
+	return durationpb.New(input)
+}
+
+// Timestamp maps a *timestamppb.Timestamp to a time.Time.
+func Timestamp(input *timestamppb.Timestamp) time.Time {
+	return input.AsTime()
+}
+
+// TimestampProto maps a time.Time to a *timestamppb.Timestamp.
+func TimestampProto(input time.Time) *timestamppb.Timestamp {
+	return timestamppb.New(input)
+}
+
+// Struct maps a *structpb.Struct to a map[string]interface{}.
+func Struct(input *structpb.Struct) map[string]interface{} {
+	return input.AsMap()
+}
+
+// StructProto maps a map[string]interface{} to a *structpb.Struct. This
+// fails if a value can't be represented, see structpb.NewValue.
+func StructProto(input map[string]interface{}) (*structpb.Struct, error) {
+	return structpb.NewStruct(input)
+}
+
+// WorkspaceState maps Framework.WorkspaceState to resource.WorkspaceState.
+func WorkspaceState(input *pb.Framework_WorkspaceState) *resource.WorkspaceState {
+	return resource.WorkspaceStateFromProto(input)
+}
+
+// WorkspaceStateProto
+func WorkspaceStateProto(input *resource.WorkspaceState) *pb.Framework_WorkspaceState {
+	return input.Proto()
+}
+
+// ProtocolVersion returns the protocol version negotiated with the host.
+func ProtocolVersion(internal *pluginargs.Internal) *component.ProtocolVersion {
+	// If the version isn't known then it is the only version that existed
+	// before versions were tracked.
+	v := internal.ProtocolVersion
+	if v == 0 {
+		v = 1
+	}
+
+	return &component.ProtocolVersion{Version: v}
+}
+
+// Features returns the features negotiated with the host.
+func Features(internal *pluginargs.Internal) *component.Features {
+	return internal.Features.Negotiated()
+}
+
 // TerminalUI maps *pb.Args_TerminalUI to an hclog.TerminalUI
 func TerminalUI(
 	ctx context.Context,
@@ -170,8 +408,10 @@ func TerminalUI(
 ) (terminal.UI, error) {
 	// Create our plugin
 	p := &pluginterminal.UIPlugin{
-		Mappers: internal.Mappers,
-		Logger:  log,
+		Mappers:  internal.Mappers,
+		Logger:   log,
+		Internal: internal,
+		Features: internal.Features.Negotiated(),
 	}
 
 	conn, err := internal.Broker.Dial(input.StreamId)
@@ -210,7 +450,7 @@ func TerminalUIProto(
 
 	// Serve it
 	go internal.Broker.AcceptAndServe(id, func(opts []grpc.ServerOption) *grpc.Server {
-		server := plugin.DefaultGRPCServer(opts)
+		server := internal.NewServer(opts)
 		if err := p.GRPCServer(internal.Broker, server); err != nil {
 			panic(err)
 		}
@@ -239,8 +479,9 @@ func ExecSessionInfo(
 ) (*component.ExecSessionInfo, error) {
 	// Create our plugin
 	p := &pluginexec.ExecPlugin{
-		Mappers: internal.Mappers,
-		Logger:  log,
+		Mappers:  internal.Mappers,
+		Logger:   log,
+		Internal: internal,
 	}
 
 	conn, err := internal.Broker.Dial(input.StreamId)
@@ -285,7 +526,7 @@ func ExecSessionInfoProto(
 
 	// Serve it
 	go internal.Broker.AcceptAndServe(id, func(opts []grpc.ServerOption) *grpc.Server {
-		server := plugin.DefaultGRPCServer(opts)
+		server := internal.NewServer(opts)
 		if err := p.GRPCServer(internal.Broker, server); err != nil {
 			panic(err)
 		}
@@ -311,19 +552,75 @@ func ExecSessionInfoProto(
 	return out
 }
 
-// LogViewer maps *pb.Args_LogViewer to a *component.LogViewer
-func LogViewer(
+// TaskWatchInfo maps *pb.Args_TaskWatchInfo to a *component.TaskWatchInfo
+func TaskWatchInfo(
 	ctx context.Context,
-	input *pb.Args_LogViewer,
+	input *pb.Args_TaskWatchInfo,
 	log hclog.Logger,
 	internal *pluginargs.Internal,
-) (*component.LogViewer, error) {
+) (*component.TaskWatchInfo, error) {
 	// Create our plugin
-	p := &pluginlogs.LogsPlugin{
+	p := &plugintaskwatch.TaskWatchPlugin{
+		Mappers:  internal.Mappers,
+		Logger:   log,
+		Internal: internal,
+	}
+
+	conn, err := internal.Broker.Dial(input.StreamId)
+	if err != nil {
+		return nil, err
+	}
+	internal.Cleanup.Do(func() { conn.Close() })
+
+	v, err := p.GRPCClient(ctx, internal.Broker, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*component.TaskWatchInfo), nil
+}
+
+// TaskWatchInfoProto maps a *component.TaskWatchInfo to a *pb.Args_TaskWatchInfo
+func TaskWatchInfoProto(
+	twi *component.TaskWatchInfo,
+	log hclog.Logger,
+	internal *pluginargs.Internal,
+) *pb.Args_TaskWatchInfo {
+	// Create our plugin
+	p := &plugintaskwatch.TaskWatchPlugin{
+		Impl:    twi,
 		Mappers: internal.Mappers,
 		Logger:  log,
 	}
 
+	id := internal.Broker.NextId()
+
+	// Serve it
+	go internal.Broker.AcceptAndServe(id, func(opts []grpc.ServerOption) *grpc.Server {
+		server := internal.NewServer(opts)
+		if err := p.GRPCServer(internal.Broker, server); err != nil {
+			panic(err)
+		}
+		return server
+	})
+
+	return &pb.Args_TaskWatchInfo{StreamId: id}
+}
+
+// Pipe maps *pb.Args_Pipe to a *pipe.Reader. The pipe is connected
+// immediately since a published pipe must be connected to within a few
+// seconds, even if the reader isn't read from until later.
+func Pipe(
+	ctx context.Context,
+	input *pb.Args_Pipe,
+	log hclog.Logger,
+	internal *pluginargs.Internal,
+) (*pipe.Reader, error) {
+	p := &pluginpipe.PipePlugin{
+		Logger:   log,
+		Internal: internal,
+	}
+
 	conn, err := internal.Broker.Dial(input.StreamId)
 	if err != nil {
 		return nil, err
@@ -335,10 +632,265 @@ func LogViewer(
 		return nil, err
 	}
 
+	r := v.(*pipe.Reader)
+	internal.Cleanup.Do(func() { r.Close() })
+
+	return r, nil
+}
+
+// PipeProto maps a *pipe.Source to a *pb.Args_Pipe
+func PipeProto(
+	src *pipe.Source,
+	log hclog.Logger,
+	internal *pluginargs.Internal,
+) *pb.Args_Pipe {
+	return &pb.Args_Pipe{StreamId: servePipe(src, log, internal)}
+}
+
+// PipePublisher returns the *pipe.Publisher for components to publish
+// their own pipes with.
+func PipePublisher(
+	log hclog.Logger,
+	internal *pluginargs.Internal,
+) *pipe.Publisher {
+	return pipe.NewPublisher(func(src *pipe.Source) uint32 {
+		return servePipe(src, log, internal)
+	})
+}
+
+// servePipe serves src with the broker and returns its stream ID.
+func servePipe(
+	src *pipe.Source,
+	log hclog.Logger,
+	internal *pluginargs.Internal,
+) uint32 {
+	// Create our plugin
+	p := &pluginpipe.PipePlugin{
+		Impl:   src,
+		Logger: log,
+	}
+
+	id := internal.Broker.NextId()
+
+	// Serve it
+	go internal.Broker.AcceptAndServe(id, func(opts []grpc.ServerOption) *grpc.Server {
+		server := internal.NewServer(opts)
+		if err := p.GRPCServer(internal.Broker, server); err != nil {
+			panic(err)
+		}
+		return server
+	})
+
+	return id
+}
+
+// FileSync maps *pb.Args_FileSync to a *filesync.Client
+func FileSync(
+	ctx context.Context,
+	input *pb.Args_FileSync,
+	log hclog.Logger,
+	internal *pluginargs.Internal,
+) (*filesync.Client, error) {
+	// Create our plugin
+	p := &pluginfilesync.FileSyncPlugin{
+		Logger:   log,
+		Internal: internal,
+	}
+
+	conn, err := internal.Broker.Dial(input.StreamId)
+	if err != nil {
+		return nil, err
+	}
+	internal.Cleanup.Do(func() { conn.Close() })
+
+	v, err := p.GRPCClient(ctx, internal.Broker, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*filesync.Client), nil
+}
+
+// FileSyncProto maps a filesync.Service to a *pb.Args_FileSync
+func FileSyncProto(
+	svc filesync.Service,
+	log hclog.Logger,
+	internal *pluginargs.Internal,
+) *pb.Args_FileSync {
+	// Create our plugin
+	p := &pluginfilesync.FileSyncPlugin{
+		Impl:   svc,
+		Logger: log,
+	}
+
+	id := internal.Broker.NextId()
+
+	// Serve it
+	go internal.Broker.AcceptAndServe(id, func(opts []grpc.ServerOption) *grpc.Server {
+		server := internal.NewServer(opts)
+		if err := p.GRPCServer(internal.Broker, server); err != nil {
+			panic(err)
+		}
+		return server
+	})
+
+	return &pb.Args_FileSync{StreamId: id}
+}
+
+// HostClient maps *pb.Args_HostClient to a *host.Client
+func HostClient(
+	ctx context.Context,
+	input *pb.Args_HostClient,
+	log hclog.Logger,
+	internal *pluginargs.Internal,
+) (*host.Client, error) {
+	// Create our plugin
+	p := &pluginhost.HostPlugin{
+		Logger:   log,
+		Internal: internal,
+	}
+
+	conn, err := internal.Broker.Dial(input.StreamId)
+	if err != nil {
+		return nil, err
+	}
+	internal.Cleanup.Do(func() { conn.Close() })
+
+	v, err := p.GRPCClient(ctx, internal.Broker, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*host.Client), nil
+}
+
+// HostClientProto maps a host.Service to a *pb.Args_HostClient
+func HostClientProto(
+	svc host.Service,
+	log hclog.Logger,
+	internal *pluginargs.Internal,
+) *pb.Args_HostClient {
+	// Create our plugin
+	p := &pluginhost.HostPlugin{
+		Impl:   svc,
+		Logger: log,
+	}
+
+	id := internal.Broker.NextId()
+
+	// Serve it
+	go internal.Broker.AcceptAndServe(id, func(opts []grpc.ServerOption) *grpc.Server {
+		server := internal.NewServer(opts)
+		if err := p.GRPCServer(internal.Broker, server); err != nil {
+			panic(err)
+		}
+		return server
+	})
+
+	return &pb.Args_HostClient{StreamId: id}
+}
+
+// PortForwardInfo maps *pb.Args_PortForwardInfo to a *component.PortForwardInfo
+func PortForwardInfo(
+	ctx context.Context,
+	input *pb.Args_PortForwardInfo,
+	log hclog.Logger,
+	internal *pluginargs.Internal,
+) (*component.PortForwardInfo, error) {
+	// Create our plugin
+	p := &pluginportforward.PortForwardPlugin{
+		Mappers:  internal.Mappers,
+		Logger:   log,
+		Internal: internal,
+	}
+
+	conn, err := internal.Broker.Dial(input.StreamId)
+	if err != nil {
+		return nil, err
+	}
+	internal.Cleanup.Do(func() { conn.Close() })
+
+	v, err := p.GRPCClient(ctx, internal.Broker, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	pfi := v.(*component.PortForwardInfo)
+	pfi.Port = int(input.Port)
+
+	return pfi, nil
+}
+
+// PortForwardInfoProto maps a *component.PortForwardInfo to a *pb.Args_PortForwardInfo
+func PortForwardInfoProto(
+	pfi *component.PortForwardInfo,
+	log hclog.Logger,
+	internal *pluginargs.Internal,
+) *pb.Args_PortForwardInfo {
+	// Create our plugin
+	p := &pluginportforward.PortForwardPlugin{
+		Impl:    pfi,
+		Mappers: internal.Mappers,
+		Logger:  log,
+	}
+
+	id := internal.Broker.NextId()
+
+	// Serve it
+	go internal.Broker.AcceptAndServe(id, func(opts []grpc.ServerOption) *grpc.Server {
+		server := internal.NewServer(opts)
+		if err := p.GRPCServer(internal.Broker, server); err != nil {
+			panic(err)
+		}
+		return server
+	})
+
+	return &pb.Args_PortForwardInfo{
+		StreamId: id,
+		Port:     uint32(pfi.Port),
+	}
+}
+
+// LogViewer maps *pb.Args_LogViewer to a *component.LogViewer
+func LogViewer(
+	ctx context.Context,
+	input *pb.Args_LogViewer,
+	log hclog.Logger,
+	internal *pluginargs.Internal,
+) (*component.LogViewer, error) {
+	conn, err := internal.Broker.Dial(input.StreamId)
+	if err != nil {
+		return nil, err
+	}
+	internal.Cleanup.Do(func() { conn.Close() })
+
+	// Create our plugin. This registers its own cleanup after the
+	// connection cleanup so buffered events are sent before the
+	// connection is closed.
+	p := &pluginlogs.LogsPlugin{
+		Mappers:     internal.Mappers,
+		Logger:      log,
+		Internal:    internal,
+		Cleanup:     internal.Cleanup,
+		ResumeToken: input.ResumeToken,
+	}
+
+	v, err := p.GRPCClient(ctx, internal.Broker, conn)
+	if err != nil {
+		return nil, err
+	}
+
 	lv := v.(*component.LogViewer)
 	lv.StartingAt = input.StartingAt.AsTime()
 	lv.Limit = int(input.Limit)
 
+	// Platforms don't need to look earlier than where we resume. The
+	// token was already validated by the plugin.
+	resume, _ := component.ParseLogResumeToken(input.ResumeToken)
+	if resume.Time.After(lv.StartingAt) {
+		lv.StartingAt = resume.Time
+	}
+
 	return lv, nil
 }
 
@@ -359,7 +911,7 @@ func LogViewerProto(
 
 	// Serve it
 	go internal.Broker.AcceptAndServe(id, func(opts []grpc.ServerOption) *grpc.Server {
-		server := plugin.DefaultGRPCServer(opts)
+		server := internal.NewServer(opts)
 		if err := p.GRPCServer(internal.Broker, server); err != nil {
 			panic(err)
 		}
@@ -367,10 +919,71 @@ func LogViewerProto(
 	})
 
 	out := &pb.Args_LogViewer{
-		StreamId:   id,
-		StartingAt: timestamppb.New(lv.StartingAt),
-		Limit:      uint32(lv.Limit),
+		StreamId:    id,
+		StartingAt:  timestamppb.New(lv.StartingAt),
+		Limit:       uint32(lv.Limit),
+		ResumeToken: lv.ResumeToken,
 	}
 
 	return out
 }
+
+// ConfigWatcher maps *pb.Args_ConfigWatcher to a *component.ConfigWatcher
+func ConfigWatcher(
+	ctx context.Context,
+	input *pb.Args_ConfigWatcher,
+	log hclog.Logger,
+	internal *pluginargs.Internal,
+) (*component.ConfigWatcher, error) {
+	conn, err := internal.Broker.Dial(input.StreamId)
+	if err != nil {
+		return nil, err
+	}
+	internal.Cleanup.Do(func() { conn.Close() })
+
+	// Create our plugin. This registers its own cleanup after the
+	// connection cleanup so pending updates are flushed before the
+	// connection is closed.
+	p := &pluginconfigwatch.ConfigWatcherPlugin{
+		Mappers:  internal.Mappers,
+		Logger:   log,
+		Internal: internal,
+		Cleanup:  internal.Cleanup,
+	}
+
+	v, err := p.GRPCClient(ctx, internal.Broker, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*component.ConfigWatcher), nil
+}
+
+// ConfigWatcherProto maps a *component.ConfigWatcher to a *pb.Args_ConfigWatcher
+func ConfigWatcherProto(
+	cw *component.ConfigWatcher,
+	log hclog.Logger,
+	internal *pluginargs.Internal,
+) *pb.Args_ConfigWatcher {
+	// Create our plugin
+	p := &pluginconfigwatch.ConfigWatcherPlugin{
+		Impl:    cw,
+		Mappers: internal.Mappers,
+		Logger:  log,
+	}
+
+	id := internal.Broker.NextId()
+
+	// Serve it
+	go internal.Broker.AcceptAndServe(id, func(opts []grpc.ServerOption) *grpc.Server {
+		server := internal.NewServer(opts)
+		if err := p.GRPCServer(internal.Broker, server); err != nil {
+			panic(err)
+		}
+		return server
+	})
+
+	return &pb.Args_ConfigWatcher{
+		StreamId: id,
+	}
+}
@@ -2,11 +2,19 @@ package protomappers
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/opaqueany"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/component/ociref"
+	"github.com/hashicorp/waypoint-plugin-sdk/framework/resource"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
 
@@ -33,6 +41,258 @@ func TestMappers(t *testing.T) {
 			&pb.Args_Source{App: "foo"},
 			"",
 		},
+
+		{
+			"BuildContext",
+			BuildContext,
+			[]interface{}{&pb.Args_Source{App: "foo", Path: "/app"}},
+			&component.BuildContext{Path: "/app"},
+			"",
+		},
+
+		{
+			"PreviousDeployment",
+			PreviousDeployment,
+			[]interface{}{&pb.Args_PreviousDeployment{Id: "d1", Sequence: 4}},
+			&component.PreviousDeployment{ID: "d1", Sequence: 4},
+			"",
+		},
+
+		{
+			"PreviousDeploymentProto",
+			PreviousDeploymentProto,
+			[]interface{}{&component.PreviousDeployment{ID: "d1", Sequence: 4}},
+			&pb.Args_PreviousDeployment{Id: "d1", Sequence: 4},
+			"",
+		},
+
+		{
+			"PreviousGeneration",
+			PreviousGeneration,
+			[]interface{}{&pb.Generation_Previous{Id: []byte("v1"), Sequence: 4}},
+			&component.PreviousGeneration{ID: []byte("v1"), Sequence: 4},
+			"",
+		},
+
+		{
+			"PreviousGenerationProto",
+			PreviousGenerationProto,
+			[]interface{}{&component.PreviousGeneration{ID: []byte("v1"), Sequence: 4}},
+			&pb.Generation_Previous{Id: []byte("v1"), Sequence: 4},
+			"",
+		},
+
+		{
+			"Duration",
+			Duration,
+			[]interface{}{durationpb.New(90 * time.Second)},
+			90 * time.Second,
+			"",
+		},
+
+		{
+			"DurationProto",
+			DurationProto,
+			[]interface{}{90 * time.Second},
+			durationpb.New(90 * time.Second),
+			"",
+		},
+
+		{
+			"Timestamp",
+			Timestamp,
+			[]interface{}{timestamppb.New(time.Unix(1600000000, 0))},
+			time.Unix(1600000000, 0).UTC(),
+			"",
+		},
+
+		{
+			"TimestampProto",
+			TimestampProto,
+			[]interface{}{time.Unix(1600000000, 0)},
+			timestamppb.New(time.Unix(1600000000, 0)),
+			"",
+		},
+
+		{
+			"Struct",
+			Struct,
+			[]interface{}{testStruct(t)},
+			map[string]interface{}{"name": "web", "replicas": float64(3)},
+			"",
+		},
+
+		{
+			"StructProto",
+			StructProto,
+			[]interface{}{map[string]interface{}{"name": "web", "replicas": 3}},
+			testStruct(t),
+			"",
+		},
+
+		{
+			"StructProto invalid",
+			StructProto,
+			[]interface{}{map[string]interface{}{"ch": make(chan int)}},
+			nil,
+			"invalid type",
+		},
+
+		{
+			"WorkspaceState",
+			WorkspaceState,
+			[]interface{}{&pb.Framework_WorkspaceState{
+				States: []*opaqueany.Any{testAny(t, "a"), testAny(t, "b")},
+			}},
+			resource.NewWorkspaceState(testAny(t, "a"), testAny(t, "b")),
+			"",
+		},
+
+		{
+			"WorkspaceStateProto",
+			WorkspaceStateProto,
+			[]interface{}{resource.NewWorkspaceState(testAny(t, "a"))},
+			&pb.Framework_WorkspaceState{
+				States: []*opaqueany.Any{testAny(t, "a")},
+			},
+			"",
+		},
+
+		{
+			"Context",
+			Context,
+			[]interface{}{&pb.Args_Context{
+				JobInfo:       &pb.Args_JobInfo{Id: "job", Project: "p", App: "a"},
+				AppLabels:     map[string]string{"env": "prod"},
+				RunnerId:      "runner",
+				ServerVersion: "v0.11.0",
+			}},
+			&component.Context{
+				JobInfo:       component.JobInfo{Id: "job", Project: "p", App: "a"},
+				AppLabels:     map[string]string{"env": "prod"},
+				RunnerId:      "runner",
+				ServerVersion: "v0.11.0",
+			},
+			"",
+		},
+
+		{
+			"ContextProto",
+			ContextProto,
+			[]interface{}{&component.Context{
+				JobInfo:  component.JobInfo{Workspace: "default", Local: true},
+				RunnerId: "runner",
+			}},
+			&pb.Args_Context{
+				JobInfo:  &pb.Args_JobInfo{Workspace: "default", Local: true},
+				RunnerId: "runner",
+			},
+			"",
+		},
+
+		{
+			"TaskLaunchInfo",
+			TaskLaunchInfo,
+			[]interface{}{&pb.Args_TaskLaunchInfo{
+				OciUrl: "ubuntu",
+				Resources: &pb.Args_TaskLaunchInfo_Resources{
+					CpuMillicores: 500,
+					MemoryMb:      256,
+					GpuCount:      1,
+					GpuType:       "nvidia-tesla-t4",
+				},
+				Placement: &pb.Args_TaskLaunchInfo_Placement{
+					NodeLabels: map[string]string{"pool": "gpu"},
+					Zones:      []string{"us-east-1a"},
+				},
+			}},
+			&component.TaskLaunchInfo{
+				OciUrl: "ubuntu",
+				Resources: &component.TaskResources{
+					CPUMillicores: 500,
+					MemoryMB:      256,
+					GPUCount:      1,
+					GPUType:       "nvidia-tesla-t4",
+				},
+				Placement: &component.TaskPlacement{
+					NodeLabels: map[string]string{"pool": "gpu"},
+					Zones:      []string{"us-east-1a"},
+				},
+			},
+			"",
+		},
+
+		{
+			"TaskLaunchInfoProto",
+			TaskLaunchInfoProto,
+			[]interface{}{&component.TaskLaunchInfo{
+				OciUrl:    "ubuntu",
+				Resources: &component.TaskResources{CPUMillicores: 500, MemoryMB: 256},
+				Placement: &component.TaskPlacement{Zones: []string{"us-east-1a"}},
+			}},
+			&pb.Args_TaskLaunchInfo{
+				OciUrl:    "ubuntu",
+				Resources: &pb.Args_TaskLaunchInfo_Resources{CpuMillicores: 500, MemoryMb: 256},
+				Placement: &pb.Args_TaskLaunchInfo_Placement{Zones: []string{"us-east-1a"}},
+			},
+			"",
+		},
+
+		{
+			"OCIRef",
+			OCIRef,
+			[]interface{}{&pb.OCIRef{Repository: "nginx"}},
+			&ociref.Reference{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"},
+			"",
+		},
+
+		{
+			"OCIRef invalid",
+			OCIRef,
+			[]interface{}{&pb.OCIRef{Repository: "Nginx"}},
+			nil,
+			"invalid repository",
+		},
+
+		{
+			"OCIRefProto",
+			OCIRefProto,
+			[]interface{}{ociref.MustParse("gcr.io/project/app:v1")},
+			&pb.OCIRef{Registry: "gcr.io", Repository: "project/app", Tag: "v1"},
+			"",
+		},
+
+		{
+			"StatusFilter",
+			StatusFilter,
+			[]interface{}{&pb.StatusReport_Filter{
+				Types:    []string{"pod"},
+				Health:   []pb.StatusReport_Health{pb.StatusReport_DOWN},
+				PageSize: 10,
+			}},
+			&component.StatusFilter{
+				Types:    []string{"pod"},
+				Health:   []pb.StatusReport_Health{pb.StatusReport_DOWN},
+				PageSize: 10,
+			},
+			"",
+		},
+
+		{
+			"Secret",
+			Secret,
+			[]interface{}{&pb.Secret{Value: "hunter2"}},
+			component.NewSecret("hunter2"),
+			"",
+		},
+
+		{
+			"SecretProto",
+			SecretProto,
+			[]interface{}{component.NewSecret("hunter2")},
+			&pb.Secret{Value: "hunter2"},
+			"",
+		},
 	}
 
 	for _, tt := range cases {
@@ -58,3 +318,15 @@ func TestMappers(t *testing.T) {
 		})
 	}
 }
+
+func testStruct(t *testing.T) *structpb.Struct {
+	s, err := structpb.NewStruct(map[string]interface{}{"name": "web", "replicas": 3})
+	require.NoError(t, err)
+	return s
+}
+
+func testAny(t *testing.T, v string) *opaqueany.Any {
+	result, err := component.ProtoAny(&testproto.Data{Value: v})
+	require.NoError(t, err)
+	return result
+}
@@ -73,8 +73,158 @@ func appendValue(args Args, v argmapper.Value) Args {
 		value.Value = &pb.FuncSpec_Value_String_{String_: v}
 
 	default:
-		panic(fmt.Sprintf("invalid value type for args: %T", v))
+		if !appendContainer(value, v) {
+			panic(fmt.Sprintf("invalid value type for args: %T", v))
+		}
 	}
 
 	return append(args, value)
 }
+
+// appendContainer sets the value of value to v if v is a slice of or a
+// map of strings to supported primitives, and reports whether it is.
+func appendContainer(value *pb.FuncSpec_Value, v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	typ, elem, ok := primitiveType(rv.Type())
+	if !ok || elem == 0 {
+		return false
+	}
+
+	value.PrimitiveType = typ
+	value.ElementType = elem
+
+	// element returns the inline value for an element.
+	elemType := PrimitiveGoType(elem, 0)
+	element := func(v reflect.Value) *pb.FuncSpec_Value {
+		v = v.Convert(elemType)
+		return appendValue(nil, argmapper.Value{Type: elemType, Value: v})[0]
+	}
+
+	switch typ {
+	case pb.FuncSpec_Value_SLICE:
+		list := &pb.FuncSpec_Value_List{}
+		for i := 0; i < rv.Len(); i++ {
+			list.Values = append(list.Values, element(rv.Index(i)))
+		}
+
+		value.Value = &pb.FuncSpec_Value_List_{List: list}
+
+	case pb.FuncSpec_Value_MAP:
+		m := &pb.FuncSpec_Value_Map{Values: map[string]*pb.FuncSpec_Value{}}
+		iter := rv.MapRange()
+		for iter.Next() {
+			m.Values[iter.Key().String()] = element(iter.Value())
+		}
+
+		value.Value = &pb.FuncSpec_Value_Map_{Map: m}
+	}
+
+	return true
+}
+
+// DecodePrimitive decodes the value of a primitive argument, such as one
+// appended to Args by Func.
+func DecodePrimitive(arg *pb.FuncSpec_Value) (interface{}, error) {
+	switch v := arg.Value.(type) {
+	case *pb.FuncSpec_Value_Bool:
+		return v.Bool, nil
+
+	case *pb.FuncSpec_Value_Int:
+		switch arg.PrimitiveType {
+		case pb.FuncSpec_Value_INT8:
+			return int8(v.Int), nil
+		case pb.FuncSpec_Value_INT16:
+			return int16(v.Int), nil
+		case pb.FuncSpec_Value_INT32:
+			return int32(v.Int), nil
+		case pb.FuncSpec_Value_INT64:
+			return int64(v.Int), nil
+		default:
+			// Fallback to int as a default
+			return int(v.Int), nil
+		}
+
+	case *pb.FuncSpec_Value_Uint:
+		switch arg.PrimitiveType {
+		case pb.FuncSpec_Value_UINT8:
+			return uint8(v.Uint), nil
+		case pb.FuncSpec_Value_UINT16:
+			return uint16(v.Uint), nil
+		case pb.FuncSpec_Value_UINT32:
+			return uint32(v.Uint), nil
+		case pb.FuncSpec_Value_UINT64:
+			return uint64(v.Uint), nil
+		default:
+			// Fallback to uint as a default
+			return uint(v.Uint), nil
+		}
+
+	case *pb.FuncSpec_Value_String_:
+		return v.String_, nil
+
+	case *pb.FuncSpec_Value_List_, *pb.FuncSpec_Value_Map_:
+		return decodeContainer(arg)
+
+	default:
+		return nil, fmt.Errorf("internal error! invalid argument value: %#v",
+			arg.Value)
+	}
+}
+
+// decodeContainer decodes the value of a slice or map primitive argument.
+func decodeContainer(arg *pb.FuncSpec_Value) (interface{}, error) {
+	typ := PrimitiveGoType(arg.PrimitiveType, arg.ElementType)
+	if typ == nil {
+		return nil, fmt.Errorf("invalid element type for argument %q: %s",
+			arg.Name, arg.ElementType)
+	}
+
+	// element decodes an element, which must be of the element type.
+	element := func(v *pb.FuncSpec_Value) (reflect.Value, error) {
+		raw, err := DecodePrimitive(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		result := reflect.ValueOf(raw)
+		if result.Type() != typ.Elem() {
+			return reflect.Value{}, fmt.Errorf(
+				"invalid element for argument %q: expected %s, got %s",
+				arg.Name, typ.Elem(), result.Type())
+		}
+
+		return result, nil
+	}
+
+	switch v := arg.Value.(type) {
+	case *pb.FuncSpec_Value_List_:
+		result := reflect.MakeSlice(typ, 0, len(v.List.Values))
+		for _, elem := range v.List.Values {
+			ev, err := element(elem)
+			if err != nil {
+				return nil, err
+			}
+
+			result = reflect.Append(result, ev)
+		}
+
+		return result.Interface(), nil
+
+	case *pb.FuncSpec_Value_Map_:
+		result := reflect.MakeMapWithSize(typ, len(v.Map.Values))
+		for k, elem := range v.Map.Values {
+			ev, err := element(elem)
+			if err != nil {
+				return nil, err
+			}
+
+			result.SetMapIndex(reflect.ValueOf(k), ev)
+		}
+
+		return result.Interface(), nil
+
+	default:
+		return nil, fmt.Errorf("internal error! invalid argument value: %#v",
+			arg.Value)
+	}
+}
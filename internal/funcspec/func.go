@@ -26,43 +26,27 @@ func Func(s *pb.FuncSpec, cb interface{}, args ...argmapper.Arg) *argmapper.Func
 	// We append them directly to our expected values for the callback.
 	// This lets us get our callback types in addition to our funcspec types.
 	inputValues := cbFunc.Input().Values()
+	var defaults []*argmapper.Func
 	for _, arg := range s.Args {
 		value := argmapper.Value{Name: arg.Name, Subtype: arg.Type, Type: anyType}
 
 		// If we have a primitive type set, then we set the proper type.
-		switch arg.PrimitiveType {
-		case pb.FuncSpec_Value_BOOL:
-			value.Type = reflect.TypeOf(false)
-		case pb.FuncSpec_Value_INT:
-			value.Type = reflect.TypeOf(int(0))
-		case pb.FuncSpec_Value_INT8:
-			value.Type = reflect.TypeOf(int8(0))
-		case pb.FuncSpec_Value_INT16:
-			value.Type = reflect.TypeOf(int16(0))
-		case pb.FuncSpec_Value_INT32:
-			value.Type = reflect.TypeOf(int32(0))
-		case pb.FuncSpec_Value_INT64:
-			value.Type = reflect.TypeOf(int64(0))
-
-		case pb.FuncSpec_Value_UINT:
-			value.Type = reflect.TypeOf(uint(0))
-		case pb.FuncSpec_Value_UINT8:
-			value.Type = reflect.TypeOf(uint8(0))
-		case pb.FuncSpec_Value_UINT16:
-			value.Type = reflect.TypeOf(uint16(0))
-		case pb.FuncSpec_Value_UINT32:
-			value.Type = reflect.TypeOf(uint32(0))
-		case pb.FuncSpec_Value_UINT64:
-			value.Type = reflect.TypeOf(uint64(0))
-
-		case pb.FuncSpec_Value_STRING:
-			value.Type = reflect.TypeOf("")
-
-		case pb.FuncSpec_Value_INVALID:
-			// Ignore
+		if t := PrimitiveGoType(arg.PrimitiveType, arg.ElementType); t != nil {
+			value.Type = t
 		}
 
 		inputValues = append(inputValues, value)
+
+		// Optional arguments get their default value if the caller
+		// doesn't have one.
+		if arg.Optional {
+			f, err := defaultFunc(arg, value)
+			if err != nil {
+				panic(err)
+			}
+
+			defaults = append(defaults, f)
+		}
 	}
 
 	// Remove the Args value if there is one, since we're going to populate
@@ -112,8 +96,7 @@ func Func(s *pb.FuncSpec, cb interface{}, args ...argmapper.Arg) *argmapper.Func
 		var args Args
 		for _, v := range in.Values() {
 			// Append any *opaqueany.Any types or supported primitive to the Args
-			_, okPrim := validPrimitive[v.Type.Kind()]
-			if v.Type == anyType || okPrim {
+			if v.Type == anyType || filterPrimitive(v) {
 				args = appendValue(args, v)
 			}
 
@@ -139,28 +122,34 @@ func Func(s *pb.FuncSpec, cb interface{}, args ...argmapper.Arg) *argmapper.Func
 		// for the *opaqueany.Any value or []*opaqueany.Any and populate our expected
 		// outputs.
 		for _, v := range cbOut.Values() {
+			var values []*opaqueany.Any
 			switch v.Type {
 			case anyType:
-				// We're seeing an *opaqueany.Any. So we encode this and try
-				// to match it to any value that we have.
-				anyVal := v.Value.Interface().(*opaqueany.Any)
-				st := anyVal.MessageName()
+				values = append(values, v.Value.Interface().(*opaqueany.Any))
+
+			case anyListType:
+				// Mappers with several results return them all at once
+				values = v.Value.Interface().([]*opaqueany.Any)
+			}
+
+			// Match each *opaqueany.Any to the value that we expect
+			// for its type.
+			for _, anyVal := range values {
+				if anyVal == nil {
+					continue
+				}
 
-				expected := out.TypedSubtype(v.Type, string(st))
+				expected := out.TypedSubtype(anyType, string(anyVal.MessageName()))
 				if expected == nil {
 					continue
 				}
 
-				expected.Value = v.Value
+				expected.Value = reflect.ValueOf(anyVal)
 			}
 		}
 
-		// Go through our callback output looking
 		return nil
-	}, append([]argmapper.Arg{
-		argmapper.FuncName(s.Name),
-		argmapper.ConverterGen(anyConvGen),
-	}, args...)...)
+	}, append(funcArgs(s.Name, defaults), args...)...)
 	if err != nil {
 		panic(err)
 	}
@@ -168,7 +157,26 @@ func Func(s *pb.FuncSpec, cb interface{}, args ...argmapper.Arg) *argmapper.Func
 	return result
 }
 
+// funcArgs returns the arguments every function built by Func is called
+// with, along with the converters for the default values of its optional
+// arguments.
+func funcArgs(name string, defaults []*argmapper.Func) []argmapper.Arg {
+	result := []argmapper.Arg{
+		argmapper.FuncName(name),
+		argmapper.ConverterGen(anyConvGen),
+	}
+	if len(defaults) > 0 {
+		result = append(result,
+			argmapper.ConverterFunc(defaults...),
+			argmapper.Typed(&defaultMarker{}),
+		)
+	}
+
+	return result
+}
+
 var (
-	anyType  = reflect.TypeOf((*opaqueany.Any)(nil))
-	argsType = reflect.TypeOf(Args(nil))
+	anyType     = reflect.TypeOf((*opaqueany.Any)(nil))
+	anyListType = reflect.TypeOf([]*opaqueany.Any(nil))
+	argsType    = reflect.TypeOf(Args(nil))
 )
@@ -10,6 +10,7 @@ import (
 	empty "google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
 
@@ -68,6 +69,40 @@ func TestFunc(t *testing.T) {
 		require.IsType(result.Err(), &argmapper.ErrArgumentUnsatisfied{})
 	})
 
+	t.Run("multiple any results", func(t *testing.T) {
+		require := require.New(t)
+
+		spec, err := Spec(func(*empty.Empty) (*testproto.A, *testproto.B) { return nil, nil })
+		require.NoError(err)
+		require.Len(spec.Result, 2)
+
+		f := Func(spec, func(args Args) ([]*opaqueany.Any, error) {
+			a, err := opaqueany.New(&testproto.A{Value: 1})
+			require.NoError(err)
+			b, err := opaqueany.New(&testproto.B{Value: 2})
+			require.NoError(err)
+
+			// At this point we'd normally RPC out.
+			return []*opaqueany.Any{a, b}, nil
+		})
+
+		msg, err := opaqueany.New(&empty.Empty{})
+		require.NoError(err)
+
+		name := string((&empty.Empty{}).ProtoReflect().Descriptor().FullName())
+		result := f.Call(argmapper.TypedSubtype(msg, name))
+		require.NoError(result.Err())
+
+		// Both results are set
+		out := f.Output()
+		require.NoError(out.FromResult(result))
+		for _, typ := range []string{"testproto.A", "testproto.B"} {
+			v := out.TypedSubtype(anyType, typ)
+			require.NotNil(v)
+			require.Equal(typ, string(v.Value.Interface().(*opaqueany.Any).MessageName()))
+		}
+	})
+
 	t.Run("match callback output if no results", func(t *testing.T) {
 		require := require.New(t)
 
@@ -144,4 +179,116 @@ func TestFunc(t *testing.T) {
 		require.NoError(result.Err())
 		require.Equal(reflect.Struct, reflect.ValueOf(result.Out(0)).Kind())
 	})
+
+	t.Run("slice and map arguments", func(t *testing.T) {
+		require := require.New(t)
+
+		spec, err := Spec(func([]string, map[string]bool) *empty.Empty { return &empty.Empty{} })
+		require.NoError(err)
+
+		f := Func(spec, func(args Args) (*opaqueany.Any, error) {
+			require.Len(args, 2)
+			for _, arg := range args {
+				switch arg.PrimitiveType {
+				case pb.FuncSpec_Value_SLICE:
+					require.Equal(pb.FuncSpec_Value_STRING, arg.ElementType)
+					values := arg.GetList().Values
+					require.Len(values, 2)
+					require.Equal("a", values[0].GetString_())
+					require.Equal("b", values[1].GetString_())
+
+				case pb.FuncSpec_Value_MAP:
+					require.Equal(pb.FuncSpec_Value_BOOL, arg.ElementType)
+					values := arg.GetMap().Values
+					require.Len(values, 1)
+					require.True(values["x"].GetBool())
+
+				default:
+					t.Fatalf("unexpected arg: %v", arg)
+				}
+			}
+
+			return opaqueany.New(&empty.Empty{})
+		})
+
+		result := f.Call(
+			argmapper.Typed([]string{"a", "b"}),
+			argmapper.Typed(map[string]bool{"x": true}),
+		)
+		require.NoError(result.Err())
+	})
+
+	t.Run("optional arguments", func(t *testing.T) {
+		spec, err := Spec(func(struct {
+			argmapper.Struct
+
+			Empty    *empty.Empty `argmapper:",typeOnly,optional"`
+			Replicas int          `argmapper:"replicas,default=3"`
+		}) *empty.Empty {
+			return &empty.Empty{}
+		})
+		require.NoError(t, err)
+
+		var got Args
+		f := Func(spec, func(args Args) (*opaqueany.Any, error) {
+			got = args
+			return opaqueany.New(&empty.Empty{})
+		})
+
+		values := func() map[string]*pb.FuncSpec_Value {
+			result := map[string]*pb.FuncSpec_Value{}
+			for _, arg := range got {
+				result[arg.Name] = arg
+			}
+
+			return result
+		}
+
+		t.Run("defaults", func(t *testing.T) {
+			require := require.New(t)
+
+			result := f.Call()
+			require.NoError(result.Err())
+			require.Len(got, 2)
+			require.Equal(int64(3), values()["replicas"].GetInt())
+			require.Equal("google.protobuf.Empty", string(values()[""].GetProtoAny().MessageName()))
+		})
+
+		t.Run("given", func(t *testing.T) {
+			require := require.New(t)
+
+			result := f.Call(argmapper.Named("replicas", 5))
+			require.NoError(result.Err())
+			require.Equal(int64(5), values()["replicas"].GetInt())
+		})
+
+		t.Run("given by conversion", func(t *testing.T) {
+			require := require.New(t)
+
+			// The message is converted to an *opaqueany.Any, which must be
+			// used over the default.
+			msg := &testproto.Data{Value: "hello"}
+			spec, err := Spec(func(struct {
+				argmapper.Struct
+
+				Data *testproto.Data `argmapper:",typeOnly,optional"`
+			}) *empty.Empty {
+				return &empty.Empty{}
+			})
+			require.NoError(err)
+
+			f := Func(spec, func(args Args) (*opaqueany.Any, error) {
+				got = args
+				return opaqueany.New(&empty.Empty{})
+			})
+
+			result := f.Call(argmapper.Typed(msg))
+			require.NoError(result.Err())
+			require.Len(got, 1)
+
+			var data testproto.Data
+			require.NoError(got[0].GetProtoAny().UnmarshalTo(&data))
+			require.Equal("hello", data.Value)
+		})
+	})
 }
@@ -0,0 +1,339 @@
+package funcspec
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/opaqueany"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// Functions mark arguments as optional with the "optional" option of the
+// argmapper struct tag on the fields of an argmapper.Struct argument.
+// Primitive fields can also have a default value with the "default"
+// option, which implies "optional":
+//
+//	func(args struct {
+//		argmapper.Struct
+//
+//		Labels   *component.LabelSet `argmapper:",typeOnly,optional"`
+//		Replicas int                 `argmapper:"replicas,default=3"`
+//	})
+//
+// The spec values for the inputs that only optional fields need have
+// Optional set and their value is the default value, which is the zero
+// value if there is no default. Protobuf messages default to an empty
+// message. Func provides the default value when the caller doesn't have
+// a value for the argument.
+const (
+	tagOptional = "optional"
+	tagDefault  = "default"
+)
+
+// optionalField is a field of an argmapper.Struct argument that is marked
+// optional.
+type optionalField struct {
+	// Name is the argument name of the field, which is empty for typed
+	// fields, and Type is the type of the field.
+	Name string
+	Type reflect.Type
+
+	// Default is the default value from the struct tag, or nil.
+	Default *string
+}
+
+// optionalFields returns the fields of the argmapper.Struct arguments of
+// fn that are marked optional.
+func optionalFields(fn interface{}) []*optionalField {
+	t := reflect.TypeOf(fn)
+	if t == nil || t.Kind() != reflect.Func {
+		return nil
+	}
+
+	var result []*optionalField
+	for i := 0; i < t.NumIn(); i++ {
+		st := t.In(i)
+		for st.Kind() == reflect.Ptr {
+			st = st.Elem()
+		}
+		if st.Kind() != reflect.Struct || !isArgmapperStruct(st) {
+			continue
+		}
+
+		for j := 0; j < st.NumField(); j++ {
+			sf := st.Field(j)
+			if sf.Anonymous && sf.Type == structMarkerType {
+				continue
+			}
+
+			// Parse the tag the same way argmapper does
+			name := sf.Name
+			options := map[string]*string{}
+			if tag := sf.Tag.Get("argmapper"); tag != "" {
+				parts := strings.Split(tag, ",")
+				if parts[0] != "" {
+					name = parts[0]
+				}
+
+				for _, opt := range parts[1:] {
+					k, v := opt, (*string)(nil)
+					if idx := strings.Index(opt, "="); idx >= 0 {
+						k = opt[:idx]
+						value := opt[idx+1:]
+						v = &value
+					}
+
+					options[k] = v
+				}
+			}
+
+			_, optional := options[tagOptional]
+			def, hasDefault := options[tagDefault]
+			if !optional && !hasDefault {
+				continue
+			}
+
+			name = strings.ToLower(name)
+			if _, ok := options["typeOnly"]; ok {
+				name = ""
+			}
+
+			result = append(result, &optionalField{
+				Name:    name,
+				Type:    sf.Type,
+				Default: def,
+			})
+		}
+	}
+
+	return result
+}
+
+// isArgmapperStruct returns true if t embeds argmapper.Struct.
+func isArgmapperStruct(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if sf := t.Field(i); sf.Anonymous && sf.Type == structMarkerType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// requiredInputs returns the inputs of the spec of f, which is the function
+// before it is redefined, that fields aren't the only ones to need. args
+// are the arguments given to Spec.
+func requiredInputs(f *argmapper.Func, fields []*optionalField, args []argmapper.Arg) (*argmapper.ValueSet, error) {
+	var required []argmapper.Value
+	for _, v := range f.Input().Values() {
+		if findField(fields, v) == nil {
+			required = append(required, v)
+		}
+	}
+
+	in, err := argmapper.NewValueSet(required)
+	if err != nil {
+		return nil, err
+	}
+	out, err := argmapper.NewValueSet(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rf, err := argmapper.BuildFunc(in, out, func(in, out *argmapper.ValueSet) error {
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rf, err = rf.Redefine(append(args, argmapper.FilterInput(inputFilter))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return rf.Input(), nil
+}
+
+// findField returns the field for the value v, or nil.
+func findField(fields []*optionalField, v argmapper.Value) *optionalField {
+	for _, field := range fields {
+		if field.Name == v.Name && field.Type == v.Type {
+			return field
+		}
+	}
+
+	return nil
+}
+
+// hasValue returns true if the set has the value v.
+func hasValue(set *argmapper.ValueSet, v argmapper.Value) bool {
+	if v.Name != "" {
+		found := set.Named(v.Name)
+		return found != nil && found.Type == v.Type
+	}
+
+	return set.TypedSubtype(v.Type, v.Subtype) != nil
+}
+
+// setDefault sets the value of the spec value val for the optional input v
+// to its default value. field is the field of v if v isn't converted from
+// another type, or nil.
+func setDefault(val *pb.FuncSpec_Value, v argmapper.Value, field *optionalField) error {
+	switch {
+	case field != nil && field.Default != nil:
+		if val.PrimitiveType == pb.FuncSpec_Value_INVALID || val.ElementType != pb.FuncSpec_Value_INVALID {
+			return fmt.Errorf("argument %q can't have a default: only scalar primitives can", v.Name)
+		}
+
+		goType := PrimitiveGoType(val.PrimitiveType, 0)
+		def, err := parseDefault(goType, *field.Default)
+		if err != nil {
+			return fmt.Errorf("invalid default for argument %q: %w", v.Name, err)
+		}
+
+		val.Value = appendValue(nil, argmapper.Value{Type: goType, Value: def})[0].Value
+
+	case filterProto(v):
+		msg := reflect.New(v.Type.Elem()).Interface().(proto.Message)
+		anyVal, err := opaqueany.New(msg)
+		if err != nil {
+			return err
+		}
+
+		val.Value = &pb.FuncSpec_Value_ProtoAny{ProtoAny: anyVal}
+
+	default:
+		goType := PrimitiveGoType(val.PrimitiveType, val.ElementType)
+		val.Value = appendValue(nil, argmapper.Value{Type: goType, Value: reflect.Zero(goType)})[0].Value
+	}
+
+	return nil
+}
+
+// parseDefault parses the default value s for the scalar primitive type t.
+func parseDefault(t reflect.Type, s string) (reflect.Value, error) {
+	result := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Bool:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.SetBool(v)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.SetInt(v)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result.SetUint(v)
+
+	case reflect.String:
+		result.SetString(s)
+
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type %s", t)
+	}
+
+	return result, nil
+}
+
+// defaultMarker is the input of the converters that provide the default
+// values of optional arguments. It is given to the call without a subtype
+// but the converters require it with defaultMarkerSubtype, which argmapper
+// allows at a high cost. argmapper uses the path with the lowest cost to
+// each argument, so the value of an argument that the caller gives, either
+// directly or through a few conversions, is used over the default.
+type defaultMarker struct{}
+
+const defaultMarkerSubtype = "default"
+
+// defaultFunc returns the converter that provides the default value of the
+// optional spec argument arg, which has the value v in the input set.
+func defaultFunc(arg *pb.FuncSpec_Value, v argmapper.Value) (*argmapper.Func, error) {
+	in, err := argmapper.NewValueSet([]argmapper.Value{{
+		Type:    defaultMarkerType,
+		Subtype: defaultMarkerSubtype,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := argmapper.NewValueSet([]argmapper.Value{v})
+	if err != nil {
+		return nil, err
+	}
+
+	return argmapper.BuildFunc(in, out, func(in, out *argmapper.ValueSet) error {
+		def, err := defaultArg(arg, v)
+		if err != nil {
+			return err
+		}
+
+		target := out.TypedSubtype(v.Type, v.Subtype)
+		if v.Name != "" {
+			target = out.Named(v.Name)
+		}
+		target.Value = def
+
+		return nil
+	}, argmapper.FuncName(fmt.Sprintf("default(%s)", argLabel(arg))))
+}
+
+// defaultArg returns the default value of the optional spec argument arg,
+// which has the value v in the input set.
+func defaultArg(arg *pb.FuncSpec_Value, v argmapper.Value) (reflect.Value, error) {
+	switch value := arg.Value.(type) {
+	case *pb.FuncSpec_Value_ProtoAny:
+		return reflect.ValueOf(proto.Clone(value.ProtoAny)), nil
+
+	case nil:
+		// Older specs may not have a default, so we use the zero value.
+		if v.Type == anyType {
+			return reflect.ValueOf(&opaqueany.Any{TypeUrl: anyURLPrefix + arg.Type}), nil
+		}
+
+		return reflect.Zero(v.Type), nil
+
+	default:
+		raw, err := DecodePrimitive(arg)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		return reflect.ValueOf(raw), nil
+	}
+}
+
+// argLabel returns the name of the argument for function names.
+func argLabel(arg *pb.FuncSpec_Value) string {
+	if arg.Name != "" {
+		return arg.Name
+	}
+	if arg.Type != "" {
+		return arg.Type
+	}
+
+	return arg.PrimitiveType.String()
+}
+
+// anyURLPrefix is the prefix of the type URLs of *opaqueany.Any values.
+const anyURLPrefix = "type.googleapis.com/"
+
+var (
+	defaultMarkerType = reflect.TypeOf((*defaultMarker)(nil))
+	structMarkerType  = reflect.TypeOf((*argmapper.Struct)(nil)).Elem()
+)
@@ -2,6 +2,7 @@ package funcspec
 
 import (
 	"context"
+	"errors"
 	"reflect"
 
 	"github.com/hashicorp/go-argmapper"
@@ -10,6 +11,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
 
@@ -21,16 +23,17 @@ func Spec(fn interface{}, args ...argmapper.Arg) (*pb.FuncSpec, error) {
 		return nil, status.Errorf(codes.Unimplemented, "required plugin type not implemented")
 	}
 
-	filterProto := argmapper.FilterType(protoMessageType)
-
-	// Outparameters do not need to be supplied by core, and should
-	// be omitted from the advertised function spec.
-	filterOutParameter := argmapper.FilterType(outParameterType)
+	// Diagnostics can be returned alongside the result. They're sent
+	// as status details rather than as part of the result.
+	filterDiags := argmapper.FilterOr(
+		argmapper.FilterType(diagnosticsType),
+		argmapper.FilterType(diagnosticSliceType),
+	)
 
 	// Copy our args cause we're going to use append() and we don't
 	// want to modify our caller.
 	args = append([]argmapper.Arg{
-		argmapper.FilterOutput(filterProto),
+		argmapper.FilterOutput(argmapper.FilterOr(filterProto, filterDiags)),
 	}, args...)
 
 	// Build our function
@@ -39,23 +42,46 @@ func Spec(fn interface{}, args ...argmapper.Arg) (*pb.FuncSpec, error) {
 		return nil, err
 	}
 
-	filter := argmapper.FilterOr(
-		argmapper.FilterType(contextType),
-		filterPrimitive,
-		filterProto,
-		filterOutParameter,
-	)
+	// The optional arguments need the function before it is redefined.
+	orig := f
+	fields := optionalFields(fn)
 
 	// Redefine the function in terms of protobuf messages. "Redefine" changes
 	// the inputs of a function to only require values that match our filter
 	// function. In our case, that is protobuf messages.
+	name := f.Name()
 	f, err = f.Redefine(append(args,
-		argmapper.FilterInput(filter),
+		argmapper.FilterInput(inputFilter),
 	)...)
+	strict, mappers := specOptions(args)
 	if err != nil {
+		// In strict mode we describe the inputs that couldn't be satisfied
+		// and the conversions that were tried.
+		var unsatisfied *argmapper.ErrArgumentUnsatisfied
+		if strict && errors.As(err, &unsatisfied) {
+			return nil, droppedErr(name, unsatisfied.Args, unsatisfied.Converters)
+		}
+
 		return nil, err
 	}
 
+	// Inputs that don't match the filter are left out of the spec below.
+	// In strict mode that is an error rather than failing when called.
+	if strict {
+		if err := droppedErr(name, dropped(f), mappers); err != nil {
+			return nil, err
+		}
+	}
+
+	// Inputs are optional if only optional arguments need them
+	var required *argmapper.ValueSet
+	if len(fields) > 0 {
+		required, err = requiredInputs(orig, fields, args)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Grab the input set of the function and build up our funcspec
 	result := pb.FuncSpec{Name: f.Name()}
 	for _, v := range f.Input().Values() {
@@ -69,7 +95,14 @@ func Spec(fn interface{}, args ...argmapper.Arg) (*pb.FuncSpec, error) {
 			val.Type = typeToMessage(v.Type)
 
 		case filterPrimitive(v):
-			val.PrimitiveType = pb.FuncSpec_Value_PrimitiveType(v.Type.Kind())
+			val.PrimitiveType, val.ElementType, _ = primitiveType(v.Type)
+		}
+
+		if required != nil && !hasValue(required, v) {
+			val.Optional = true
+			if err := setDefault(val, v, findField(fields, v)); err != nil {
+				return nil, err
+			}
 		}
 
 		result.Args = append(result.Args, val)
@@ -97,23 +130,115 @@ func typeToMessage(typ reflect.Type) string {
 	return string(val.ProtoReflect().Descriptor().FullName())
 }
 
+// inputFilter matches the inputs that can be in a spec or that don't need
+// to be in it. Outparameters do not need to be supplied by core, and should
+// be omitted from the advertised function spec.
+func inputFilter(v argmapper.Value) bool {
+	return filterContext(v) || filterPrimitive(v) || filterProto(v) || filterOutParameter(v)
+}
+
 func filterPrimitive(v argmapper.Value) bool {
-	_, ok := validPrimitive[v.Type.Kind()]
+	_, _, ok := primitiveType(v.Type)
 	return ok
 }
 
+// primitiveType returns the PrimitiveType of t and, for slices and maps,
+// the PrimitiveType of its elements. ok is false if t isn't a primitive
+// we support: a scalar in validPrimitive, or a slice of or a map of
+// strings to one.
+func primitiveType(t reflect.Type) (typ, elem pb.FuncSpec_Value_PrimitiveType, ok bool) {
+	switch t.Kind() {
+	case reflect.Slice:
+		if _, ok := validPrimitive[t.Elem().Kind()]; !ok {
+			return 0, 0, false
+		}
+
+		return pb.FuncSpec_Value_SLICE, pb.FuncSpec_Value_PrimitiveType(t.Elem().Kind()), true
+
+	case reflect.Map:
+		if _, ok := validPrimitive[t.Elem().Kind()]; !ok || t.Key().Kind() != reflect.String {
+			return 0, 0, false
+		}
+
+		return pb.FuncSpec_Value_MAP, pb.FuncSpec_Value_PrimitiveType(t.Elem().Kind()), true
+
+	default:
+		if _, ok := validPrimitive[t.Kind()]; !ok {
+			return 0, 0, false
+		}
+
+		return pb.FuncSpec_Value_PrimitiveType(t.Kind()), 0, true
+	}
+}
+
+// PrimitiveGoType returns the Go type for a PrimitiveType, using elem for
+// the elements of slices and maps. This returns nil for INVALID or an
+// unknown type.
+func PrimitiveGoType(typ, elem pb.FuncSpec_Value_PrimitiveType) reflect.Type {
+	switch typ {
+	case pb.FuncSpec_Value_SLICE:
+		if t := PrimitiveGoType(elem, 0); t != nil {
+			return reflect.SliceOf(t)
+		}
+
+	case pb.FuncSpec_Value_MAP:
+		if t := PrimitiveGoType(elem, 0); t != nil {
+			return reflect.MapOf(reflect.TypeOf(""), t)
+		}
+
+	case pb.FuncSpec_Value_BOOL:
+		return reflect.TypeOf(false)
+	case pb.FuncSpec_Value_INT:
+		return reflect.TypeOf(int(0))
+	case pb.FuncSpec_Value_INT8:
+		return reflect.TypeOf(int8(0))
+	case pb.FuncSpec_Value_INT16:
+		return reflect.TypeOf(int16(0))
+	case pb.FuncSpec_Value_INT32:
+		return reflect.TypeOf(int32(0))
+	case pb.FuncSpec_Value_INT64:
+		return reflect.TypeOf(int64(0))
+
+	case pb.FuncSpec_Value_UINT:
+		return reflect.TypeOf(uint(0))
+	case pb.FuncSpec_Value_UINT8:
+		return reflect.TypeOf(uint8(0))
+	case pb.FuncSpec_Value_UINT16:
+		return reflect.TypeOf(uint16(0))
+	case pb.FuncSpec_Value_UINT32:
+		return reflect.TypeOf(uint32(0))
+	case pb.FuncSpec_Value_UINT64:
+		return reflect.TypeOf(uint64(0))
+
+	case pb.FuncSpec_Value_STRING:
+		return reflect.TypeOf("")
+	}
+
+	return nil
+}
+
+var (
+	filterContext      = argmapper.FilterType(contextType)
+	filterProto        = argmapper.FilterType(protoMessageType)
+	filterOutParameter = argmapper.FilterType(outParameterType)
+)
+
 var (
 	contextType      = reflect.TypeOf((*context.Context)(nil)).Elem()
 	protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
 	outParameterType = reflect.TypeOf((*component.OutParameter)(nil)).Elem()
 
-	// validPrimitive is the map of primitive types we support coming
-	// over the plugin boundary. To add a new type to this, you must
-	// update:
+	diagnosticsType     = reflect.TypeOf(diag.Diagnostics(nil))
+	diagnosticSliceType = reflect.TypeOf([]diag.Diagnostic(nil))
+
+	// validPrimitive is the map of scalar primitive types we support coming
+	// over the plugin boundary. Slices of and maps of strings to these
+	// types are supported too (see primitiveType). To add a new type to
+	// this, you must update:
 	//
 	//  1. the Primitive enum in plugin.proto
 	//  2. appendValue in args.go
-	//  3. value.Type setting in func.go Func
+	//  3. PrimitiveGoType in this file
 	//  4. arg decoding in internal/plugin/dynamic_call.go
 	//
 	validPrimitive = map[reflect.Kind]struct{}{
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
 
@@ -31,6 +32,16 @@ func TestSpec(t *testing.T) {
 		require.Equal("google.protobuf.Empty", spec.Result[0].Type)
 	})
 
+	t.Run("proto and diagnostics", func(t *testing.T) {
+		require := require.New(t)
+
+		spec, err := Spec(func(*empty.Empty) (*empty.Empty, diag.Diagnostics) { return nil, nil })
+		require.NoError(err)
+		require.NotNil(spec)
+		require.Len(spec.Result, 1)
+		require.Equal("google.protobuf.Empty", spec.Result[0].Type)
+	})
+
 	t.Run("converted args to proto", func(t *testing.T) {
 		require := require.New(t)
 
@@ -64,6 +75,34 @@ func TestSpec(t *testing.T) {
 		require.Equal("google.protobuf.Empty", spec.Result[0].Type)
 	})
 
+	t.Run("slice and map args", func(t *testing.T) {
+		require := require.New(t)
+
+		spec, err := Spec(func([]string, map[string]int32) *empty.Empty { return nil })
+		require.NoError(err)
+		require.Len(spec.Args, 2)
+
+		// The order of the args isn't stable
+		types := map[pb.FuncSpec_Value_PrimitiveType]pb.FuncSpec_Value_PrimitiveType{}
+		for _, arg := range spec.Args {
+			types[arg.PrimitiveType] = arg.ElementType
+		}
+		require.Equal(map[pb.FuncSpec_Value_PrimitiveType]pb.FuncSpec_Value_PrimitiveType{
+			pb.FuncSpec_Value_SLICE: pb.FuncSpec_Value_STRING,
+			pb.FuncSpec_Value_MAP:   pb.FuncSpec_Value_INT32,
+		}, types)
+	})
+
+	t.Run("unsupported slice args", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := Spec(func([][]string) *empty.Empty { return nil })
+		require.Error(err)
+
+		_, err = Spec(func(map[int]string) *empty.Empty { return nil })
+		require.Error(err)
+	})
+
 	t.Run("named primitive args", func(t *testing.T) {
 		require := require.New(t)
 
@@ -85,6 +124,64 @@ func TestSpec(t *testing.T) {
 		require.Equal("google.protobuf.Empty", spec.Result[0].Type)
 	})
 
+	t.Run("optional args", func(t *testing.T) {
+		require := require.New(t)
+
+		spec, err := Spec(func(struct {
+			argmapper.Struct
+
+			Required *empty.Empty
+			Replicas int    `argmapper:"replicas,default=3"`
+			Name     string `argmapper:"name,optional"`
+		}) *empty.Empty {
+			return nil
+		})
+		require.NoError(err)
+		require.Len(spec.Args, 3)
+
+		args := map[string]*pb.FuncSpec_Value{}
+		for _, arg := range spec.Args {
+			args[arg.Name] = arg
+		}
+
+		require.False(args["required"].Optional)
+		require.Nil(args["required"].Value)
+		require.True(args["replicas"].Optional)
+		require.Equal(int64(3), args["replicas"].GetInt())
+		require.True(args["name"].Optional)
+		require.Equal("", args["name"].GetString_())
+	})
+
+	t.Run("optional message args", func(t *testing.T) {
+		require := require.New(t)
+
+		spec, err := Spec(func(struct {
+			argmapper.Struct
+
+			Empty *empty.Empty `argmapper:",typeOnly,optional"`
+		}) *empty.Empty {
+			return nil
+		})
+		require.NoError(err)
+		require.Len(spec.Args, 1)
+		require.True(spec.Args[0].Optional)
+		require.Equal("google.protobuf.Empty", string(spec.Args[0].GetProtoAny().MessageName()))
+	})
+
+	t.Run("invalid default", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := Spec(func(struct {
+			argmapper.Struct
+
+			Replicas int `argmapper:"replicas,default=many"`
+		}) *empty.Empty {
+			return nil
+		})
+		require.Error(err)
+		require.Contains(err.Error(), "replicas")
+	})
+
 	t.Run("unsatisfied conversion", func(t *testing.T) {
 		require := require.New(t)
 
@@ -98,6 +195,36 @@ func TestSpec(t *testing.T) {
 		require.Nil(spec)
 	})
 
+	t.Run("unsatisfied conversion in strict mode", func(t *testing.T) {
+		require := require.New(t)
+
+		type Foo struct{}
+		type Bar struct{}
+
+		spec, err := Spec(func(*Foo, *empty.Empty) *empty.Empty { return nil },
+			argmapper.Converter(func(*Bar) *Foo { return nil }),
+			Strict(),
+		)
+		require.Error(err)
+		require.Nil(spec)
+		require.Contains(err.Error(), "requires *funcspec.Bar")
+		require.Contains(err.Error(), "can't be provided by the host")
+	})
+
+	t.Run("strict mode with satisfied args", func(t *testing.T) {
+		require := require.New(t)
+
+		type Foo struct{}
+
+		spec, err := Spec(func(*Foo) *empty.Empty { return nil },
+			argmapper.Converter(func(*empty.Empty) *Foo { return nil }),
+			Strict(),
+		)
+		require.NoError(err)
+		require.Len(spec.Args, 1)
+		require.Equal("google.protobuf.Empty", spec.Args[0].Type)
+	})
+
 	t.Run("proto to int", func(t *testing.T) {
 		require := require.New(t)
 
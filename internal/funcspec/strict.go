@@ -0,0 +1,104 @@
+package funcspec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-argmapper"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+)
+
+// strictMode is the type of the value provided by Strict.
+type strictMode struct{}
+
+// Strict returns an argument that makes Spec return an error if the
+// function has inputs that can't be provided over the plugin boundary.
+// Without it, these inputs are left out of the spec and the error only
+// happens when the function is called. The error lists each of these
+// inputs and the mappers that were tried to produce it.
+func Strict() argmapper.Arg {
+	return argmapper.Typed(&strictMode{})
+}
+
+// specOptions returns whether strict mode is enabled by args and the
+// mappers from the *pluginargs.Internal argument, if any. The args are
+// opaque so we find out by calling functions that require the values.
+func specOptions(args []argmapper.Arg) (bool, []*argmapper.Func) {
+	strict := false
+	if f, err := argmapper.NewFunc(func(*strictMode) {}); err == nil {
+		result := f.Call(args...)
+		strict = result.Err() == nil
+	}
+
+	var mappers []*argmapper.Func
+	f, err := argmapper.NewFunc(func(in *pluginargs.Internal) *pluginargs.Internal { return in })
+	if err == nil {
+		if result := f.Call(args...); result.Err() == nil {
+			in := result.Out(0).(*pluginargs.Internal)
+			mappers = in.Mappers
+		}
+	}
+
+	return strict, mappers
+}
+
+// dropped returns the inputs of the redefined function f that Spec leaves
+// out of the spec. These are the inputs that can't be provided over the
+// plugin boundary and that no mapper converts to from a value that can.
+func dropped(f *argmapper.Func) []*argmapper.Value {
+	var result []*argmapper.Value
+	for _, v := range f.Input().Values() {
+		if !inputFilter(v) {
+			v := v
+			result = append(result, &v)
+		}
+	}
+
+	return result
+}
+
+// droppedErr returns an error describing the dropped inputs of the function
+// with the given name, or nil if there are none. For each input, the
+// mappers that produce its type are listed along with their inputs since
+// those are the conversions that were attempted.
+func droppedErr(name string, values []*argmapper.Value, mappers []*argmapper.Func) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "function %q has arguments that can't be provided by the host:\n", name)
+	for _, v := range values {
+		fmt.Fprintf(&b, "\n  - %s: ", v.String())
+
+		var attempts []string
+		for _, m := range mappers {
+			for _, out := range m.Output().Values() {
+				if out.Type == v.Type {
+					var inputs []string
+					for _, in := range m.Input().Values() {
+						inputs = append(inputs, in.Type.String())
+					}
+
+					attempts = append(attempts, fmt.Sprintf("%s (requires %s)",
+						m.Name(), strings.Join(inputs, ", ")))
+					break
+				}
+			}
+		}
+
+		if len(attempts) == 0 {
+			b.WriteString("not a proto message or primitive and no mapper produces this type")
+			continue
+		}
+
+		b.WriteString("not a proto message or primitive and the inputs of the " +
+			"mappers that produce this type can't be satisfied:")
+		for _, a := range attempts {
+			fmt.Fprintf(&b, "\n      %s", a)
+		}
+	}
+
+	return fmt.Errorf("%s", b.String())
+}
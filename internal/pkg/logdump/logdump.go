@@ -0,0 +1,189 @@
+// Package logdump formats values for plugin logs. Plugin logs are sent to
+// the host and often end up in job logs, so values are dumped with a depth
+// limit, sensitive fields are redacted and the output is capped in size.
+package logdump
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+const (
+	// MaxDepth is how deep nested values are dumped.
+	MaxDepth = 6
+
+	// MaxBytes is the maximum size of a dump. Larger dumps are truncated.
+	MaxBytes = 4096
+
+	// SensitiveValue replaces the value of sensitive fields. This matches
+	// component.SensitiveValue.
+	SensitiveValue = "(sensitive)"
+)
+
+// sensitiveNames are the substrings of field and map key names that are
+// redacted even without a sensitive tag.
+var sensitiveNames = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"credential",
+	"privatekey",
+	"private_key",
+	"apikey",
+	"api_key",
+}
+
+var config = &spew.ConfigState{
+	Indent:                  " ",
+	MaxDepth:                MaxDepth,
+	DisablePointerAddresses: true,
+	DisableCapacities:       true,
+	SortKeys:                true,
+}
+
+// Value returns a value to log v with, such as:
+//
+//	log.Debug("docs", "docs", logdump.Value(docs))
+//
+// The value is only dumped if the log level is enabled. Fields tagged with
+// `sensitive:"true"` or with names like "password" or "token" are redacted.
+func Value(v interface{}) fmt.Stringer {
+	return &dump{value: v}
+}
+
+type dump struct {
+	value interface{}
+}
+
+func (d *dump) String() string {
+	if d.value == nil {
+		return "<nil>"
+	}
+
+	result := config.Sdump(redact(reflect.ValueOf(d.value), 0).Interface())
+	if len(result) > MaxBytes {
+		result = fmt.Sprintf("%s... (truncated, %d bytes total)", result[:MaxBytes], len(result))
+	}
+
+	return result
+}
+
+// redact returns a copy of v with sensitive fields replaced. Values are
+// only copied up to MaxDepth since deeper values aren't dumped.
+func redact(v reflect.Value, depth int) reflect.Value {
+	if depth > MaxDepth {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			return v
+		}
+
+		result := reflect.New(v.Elem().Type())
+		result.Elem().Set(redact(v.Elem(), depth+1))
+		return result
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		result := reflect.New(v.Type()).Elem()
+		result.Set(redact(v.Elem(), depth))
+		return result
+
+	case reflect.Struct:
+		result := reflect.New(v.Type()).Elem()
+		result.Set(v)
+
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+
+			field := result.Field(i)
+			if f.Tag.Get("sensitive") == "true" || sensitiveName(f.Name) {
+				field.Set(sensitiveValue(field.Type()))
+				continue
+			}
+
+			field.Set(redact(field, depth+1))
+		}
+
+		return result
+
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+			return v
+		}
+
+		result := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			value := iter.Value()
+			if sensitiveName(iter.Key().String()) {
+				value = sensitiveValue(value.Type())
+			} else {
+				value = redact(value, depth+1)
+			}
+
+			result.SetMapIndex(iter.Key(), value)
+		}
+
+		return result
+
+	case reflect.Slice:
+		if v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8 {
+			return v
+		}
+
+		result := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(redact(v.Index(i), depth+1))
+		}
+
+		return result
+	}
+
+	return v
+}
+
+// sensitiveValue returns the value that replaces a sensitive value of type
+// t. This is SensitiveValue if t can hold it, and the zero value if not.
+func sensitiveValue(t reflect.Type) reflect.Value {
+	v := reflect.ValueOf(SensitiveValue)
+	switch {
+	case v.Type().ConvertibleTo(t) && t.Kind() == reflect.String:
+		return v.Convert(t)
+
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return reflect.ValueOf([]byte(SensitiveValue)).Convert(t)
+
+	case t.Kind() == reflect.Interface && v.Type().Implements(t):
+		result := reflect.New(t).Elem()
+		result.Set(v)
+		return result
+	}
+
+	return reflect.Zero(t)
+}
+
+// sensitiveName returns true if the field or key name looks sensitive.
+func sensitiveName(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitiveNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+package logdump
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+)
+
+func TestValue(t *testing.T) {
+	type inner struct {
+		Name     string
+		APIToken string
+	}
+
+	type config struct {
+		Region   string
+		Key      []byte `sensitive:"true"`
+		Password string
+		Nested   *inner
+		Env      map[string]string
+		Any      interface{} `sensitive:"true"`
+		Count    int         `sensitive:"true"`
+	}
+
+	v := &config{
+		Region:   "us-east-1",
+		Key:      []byte("hunter2"),
+		Password: "hunter2",
+		Nested:   &inner{Name: "app", APIToken: "hunter2"},
+		Env:      map[string]string{"HOME": "/root", "DB_PASSWORD": "hunter2"},
+		Any:      "hunter2",
+		Count:    42,
+	}
+
+	result := Value(v).String()
+	require.NotContains(t, result, "hunter2")
+	require.NotContains(t, result, "42")
+	require.Contains(t, result, "us-east-1")
+	require.Contains(t, result, "app")
+	require.Contains(t, result, "/root")
+	require.Contains(t, result, SensitiveValue)
+
+	// The original value isn't modified
+	require.Equal(t, "hunter2", v.Password)
+	require.Equal(t, "hunter2", v.Nested.APIToken)
+	require.Equal(t, "hunter2", v.Env["DB_PASSWORD"])
+}
+
+func TestValue_truncated(t *testing.T) {
+	result := Value(strings.Repeat("a", MaxBytes*2)).String()
+	require.Less(t, len(result), MaxBytes+100)
+	require.Contains(t, result, "truncated")
+}
+
+func TestValue_depth(t *testing.T) {
+	type node struct {
+		Next *node
+	}
+
+	// A cycle is dumped up to the depth limit
+	n := &node{}
+	n.Next = n
+	require.NotEmpty(t, Value(n).String())
+}
+
+func TestValue_secret(t *testing.T) {
+	type config struct {
+		Region string
+		Auth   component.Secret
+		Keys   []component.Secret
+	}
+
+	result := Value(&config{
+		Region: "us-east-1",
+		Auth:   component.NewSecret("hunter2"),
+		Keys:   []component.Secret{component.NewSecret("hunter2")},
+	}).String()
+	require.NotContains(t, result, "hunter2")
+	require.Contains(t, result, "us-east-1")
+	require.Contains(t, result, SensitiveValue)
+}
+
+func TestValue_nil(t *testing.T) {
+	require.Equal(t, "<nil>", Value(nil).String())
+}
@@ -0,0 +1,145 @@
+// Package sdkversion reports the version of the SDK a binary was built
+// with, and of the binary itself, from the build information Go embeds in
+// every binary. Hosts compare the SDK versions of plugins against their own
+// to warn about skew.
+package sdkversion
+
+import (
+	"regexp"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Path is the module path of the SDK.
+const Path = "github.com/hashicorp/waypoint-plugin-sdk"
+
+var (
+	once sync.Once
+	info *debug.BuildInfo
+)
+
+// buildInfo returns the build information of the binary, or nil if it
+// isn't available, such as for binaries built without module support.
+func buildInfo() *debug.BuildInfo {
+	once.Do(func() {
+		if bi, ok := debug.ReadBuildInfo(); ok {
+			info = bi
+		}
+	})
+
+	return info
+}
+
+// Version returns the version of the SDK the binary was built with, such
+// as "v0.0.0-20230101000000-abcdef123456". This is "(devel)" if the binary
+// is built from the SDK module itself and empty if it is unknown.
+func Version() string {
+	bi := buildInfo()
+	if bi == nil {
+		return ""
+	}
+
+	if bi.Main.Path == Path {
+		return bi.Main.Version
+	}
+
+	for _, dep := range bi.Deps {
+		if dep.Path != Path {
+			continue
+		}
+
+		if dep.Replace != nil && dep.Replace.Version != "" {
+			return dep.Replace.Version
+		}
+
+		return dep.Version
+	}
+
+	return ""
+}
+
+// Main returns the version and the VCS commit of the binary itself, for
+// plugins that don't set them at build time. Either is empty if it is
+// unknown. The version is empty rather than "(devel)" for binaries built
+// from a working tree.
+func Main() (version, commit string) {
+	bi := buildInfo()
+	if bi == nil {
+		return "", ""
+	}
+
+	if v := bi.Main.Version; v != "(devel)" {
+		version = v
+	}
+
+	for _, s := range bi.Settings {
+		if s.Key == "vcs.revision" {
+			commit = s.Value
+		}
+	}
+
+	return version, commit
+}
+
+// Compatible returns false if the SDK versions a and b are known to differ
+// in their major or minor version. Unknown, development and unparsable
+// versions are assumed to be compatible, since we can't tell either way.
+//
+// Pseudo-versions are compared by the version they're based on, which has
+// the same major and minor version, such as v0.1.0 for
+// "v0.1.1-0.20230101000000-abcdef123456". Pseudo-versions of commits that
+// aren't based on any version, such as "v0.0.0-20230101000000-abcdef123456",
+// only tell the major version, so only that is compared for them. Builds of
+// different commits are common during development and usually compatible.
+func Compatible(a, b string) bool {
+	amajor, aminor, ok := majorMinor(a)
+	if !ok {
+		return true
+	}
+	bmajor, bminor, ok := majorMinor(b)
+	if !ok {
+		return true
+	}
+
+	if untagged(a) || untagged(b) {
+		return amajor == bmajor
+	}
+
+	return amajor == bmajor && aminor == bminor
+}
+
+// untaggedRe matches the pseudo-versions of commits that aren't based on
+// any version, see https://go.dev/ref/mod#pseudo-versions.
+var untaggedRe = regexp.MustCompile(`^v\d+\.0\.0-\d{14}-[0-9a-f]{12}$`)
+
+// untagged returns true if v is the pseudo-version of a commit that isn't
+// based on any version.
+func untagged(v string) bool {
+	return untaggedRe.MatchString(v)
+}
+
+// majorMinor returns the major and minor version of the semantic version
+// v, such as 1 and 2 for "v1.2.3".
+func majorMinor(v string) (major, minor int, ok bool) {
+	if !strings.HasPrefix(v, "v") {
+		return 0, 0, false
+	}
+
+	parts := strings.SplitN(v[1:], ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return major, minor, true
+}
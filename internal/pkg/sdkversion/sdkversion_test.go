@@ -0,0 +1,36 @@
+package sdkversion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompatible(t *testing.T) {
+	cases := []struct {
+		A, B     string
+		Expected bool
+	}{
+		{"v0.1.0", "v0.1.5", true},
+		{"v0.1.0", "v0.2.0", false},
+		{"v1.2.3", "v2.2.3", false},
+		{"v0.0.0-20230101000000-abcdef123456", "v0.0.0-20240101000000-123456abcdef", true},
+		{"v0.0.0-20230101000000-abcdef123456", "v0.0.0-20230101000000-abcdef123456", true},
+		{"v0.0.0-20230101000000-abcdef123456", "v0.2.0", true},
+		{"v0.0.0-20230101000000-abcdef123456", "v1.2.0", false},
+		{"v2.0.0-20230101000000-abcdef123456", "v1.0.0-20230101000000-123456abcdef", false},
+		{"v0.1.1-0.20230101000000-abcdef123456", "v0.1.0", true},
+		{"v0.1.1-0.20230101000000-abcdef123456", "v0.1.4-0.20240101000000-123456abcdef", true},
+		{"v0.1.1-0.20230101000000-abcdef123456", "v0.2.0", false},
+		{"v0.2.0-beta.1.0.20230101000000-abcdef123456", "v0.2.0", true},
+		{"(devel)", "v0.2.0", true},
+		{"", "v0.2.0", true},
+		{"v0.1.0", "garbage", true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.A+"_"+tt.B, func(t *testing.T) {
+			require.Equal(t, tt.Expected, Compatible(tt.A, tt.B))
+		})
+	}
+}
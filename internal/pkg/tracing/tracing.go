@@ -0,0 +1,261 @@
+// Package tracing traces the gRPC calls between hosts and plugins with
+// OpenTelemetry. The trace context is propagated in the gRPC metadata with
+// the W3C trace context and baggage formats, so the spans of a plugin are
+// part of the traces of the host that called it.
+package tracing
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// InstrumentationName is the name of the tracers created by this package.
+const InstrumentationName = "github.com/hashicorp/waypoint-plugin-sdk"
+
+// Propagator is the propagator used to send the trace context in the gRPC
+// metadata.
+var Propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// ServerOptions returns the gRPC server options that start a span for every
+// call with the trace context sent by the client as the parent. If tp is
+// nil, this returns no options.
+func ServerOptions(tp trace.TracerProvider) []grpc.ServerOption {
+	if tp == nil {
+		return nil
+	}
+
+	tracer := tp.Tracer(InstrumentationName)
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(
+			ctx context.Context,
+			req interface{},
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (interface{}, error) {
+			ctx, span := startServerSpan(ctx, tracer, info.FullMethod)
+			defer span.End()
+
+			resp, err := handler(ctx, req)
+			setStatus(span, err)
+			return resp, err
+		}),
+
+		grpc.ChainStreamInterceptor(func(
+			srv interface{},
+			ss grpc.ServerStream,
+			info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) error {
+			ctx, span := startServerSpan(ss.Context(), tracer, info.FullMethod)
+			defer span.End()
+
+			err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+			setStatus(span, err)
+			return err
+		}),
+	}
+}
+
+// DialOptions returns the gRPC dial options that start a span for every
+// call and send its trace context to the server. If tp is nil, this
+// returns no options.
+func DialOptions(tp trace.TracerProvider) []grpc.DialOption {
+	if tp == nil {
+		return nil
+	}
+
+	unary, stream := ClientInterceptors(tp)
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unary),
+		grpc.WithChainStreamInterceptor(stream),
+	}
+}
+
+// ClientInterceptors returns the interceptors of DialOptions, for
+// connections that can't be dialed with options such as the connections
+// dialed with a go-plugin broker. If tp is nil, this returns nil
+// interceptors.
+func ClientInterceptors(tp trace.TracerProvider) (
+	grpc.UnaryClientInterceptor,
+	grpc.StreamClientInterceptor,
+) {
+	if tp == nil {
+		return nil, nil
+	}
+
+	tracer := tp.Tracer(InstrumentationName)
+	unary := func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		ctx, span := startClientSpan(ctx, tracer, method)
+		defer span.End()
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		setStatus(span, err)
+		return err
+	}
+
+	stream := func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		ctx, span := startClientSpan(ctx, tracer, method)
+
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			setStatus(span, err)
+			span.End()
+			return nil, err
+		}
+
+		return &clientStream{ClientStream: cs, span: span}, nil
+	}
+
+	return unary, stream
+}
+
+// startServerSpan starts the span for a call to a server with the trace
+// context from the incoming metadata of ctx as the parent.
+func startServerSpan(
+	ctx context.Context,
+	tracer trace.Tracer,
+	method string,
+) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = Propagator.Extract(ctx, metadataCarrier(md))
+
+	return tracer.Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(methodAttributes(method)...),
+	)
+}
+
+// startClientSpan starts the span for a call by a client and adds its
+// trace context to the outgoing metadata of ctx.
+func startClientSpan(
+	ctx context.Context,
+	tracer trace.Tracer,
+	method string,
+) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(methodAttributes(method)...),
+	)
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	Propagator.Inject(ctx, metadataCarrier(md))
+
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// methodAttributes returns the RPC attributes of a span for the full gRPC
+// method name, such as "/hashicorp.waypoint.sdk.Builder/Build".
+func methodAttributes(method string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attribute.String("rpc.system", "grpc")}
+
+	service, name, ok := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if ok {
+		attrs = append(attrs,
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", name),
+		)
+	}
+
+	return attrs
+}
+
+// setStatus records the gRPC status of err on span.
+func setStatus(span trace.Span, err error) {
+	s, _ := status.FromError(err)
+	span.SetAttributes(attribute.Int64("rpc.grpc.status_code", int64(s.Code())))
+	if err != nil {
+		span.SetStatus(otelcodes.Error, s.Message())
+	}
+}
+
+// metadataCarrier adapts gRPC metadata to a propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if vs := metadata.MD(c).Get(key); len(vs) > 0 {
+		return vs[0]
+	}
+
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// serverStream is a grpc.ServerStream with the context of the span.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// clientStream is a grpc.ClientStream that ends its span when the stream
+// is done, which is when RecvMsg returns an error such as io.EOF.
+type clientStream struct {
+	grpc.ClientStream
+	span trace.Span
+	once sync.Once
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.once.Do(func() {
+			if err == io.EOF {
+				setStatus(s.span, nil)
+			} else {
+				setStatus(s.span, err)
+			}
+			s.span.End()
+		})
+	}
+
+	return err
+}
+
+var _ propagation.TextMapCarrier = metadataCarrier(nil)
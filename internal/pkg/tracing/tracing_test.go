@@ -0,0 +1,122 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestServerOptions(t *testing.T) {
+	require := require.New(t)
+
+	srv := &testHealthServer{}
+	client := testHealthClient(t, srv, trace.NewNoopTracerProvider(), nil)
+
+	// The host sends the trace context in the metadata
+	sc := testSpanContext()
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), sc)
+	md := metadata.MD{}
+	Propagator.Inject(ctx, metadataCarrier(md))
+	ctx = metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(err)
+	require.Equal(sc.TraceID(), srv.spanContext.TraceID())
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(err)
+	_, err = stream.Recv()
+	require.NoError(err)
+	require.Equal(sc.TraceID(), srv.spanContext.TraceID())
+}
+
+func TestDialOptions(t *testing.T) {
+	require := require.New(t)
+
+	tp := trace.NewNoopTracerProvider()
+	srv := &testHealthServer{}
+	client := testHealthClient(t, srv, tp, DialOptions(tp))
+
+	sc := testSpanContext()
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), sc)
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(err)
+	require.Equal(sc.TraceID(), srv.spanContext.TraceID())
+}
+
+func TestNilTracerProvider(t *testing.T) {
+	require.Empty(t, ServerOptions(nil))
+	require.Empty(t, DialOptions(nil))
+}
+
+func TestMethodAttributes(t *testing.T) {
+	require.Equal(t, []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", "hashicorp.waypoint.sdk.Builder"),
+		attribute.String("rpc.method", "Build"),
+	}, methodAttributes("/hashicorp.waypoint.sdk.Builder/Build"))
+}
+
+func testSpanContext() trace.SpanContext {
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03},
+		SpanID:     trace.SpanID{0x04, 0x05},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+}
+
+// testHealthClient serves srv with the server options for tp and returns
+// a client for it.
+func testHealthClient(
+	t *testing.T,
+	srv healthpb.HealthServer,
+	tp trace.TracerProvider,
+	dialOpts []grpc.DialOption,
+) healthpb.HealthClient {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer(ServerOptions(tp)...)
+	healthpb.RegisterHealthServer(server, srv)
+	go server.Serve(ln)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(ln.Addr().String(), append(dialOpts,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+// testHealthServer records the span context of the last call.
+type testHealthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	spanContext trace.SpanContext
+}
+
+func (s *testHealthServer) Check(
+	ctx context.Context,
+	req *healthpb.HealthCheckRequest,
+) (*healthpb.HealthCheckResponse, error) {
+	s.spanContext = trace.SpanContextFromContext(ctx)
+	return &healthpb.HealthCheckResponse{}, nil
+}
+
+func (s *testHealthServer) Watch(
+	req *healthpb.HealthCheckRequest,
+	stream healthpb.Health_WatchServer,
+) error {
+	s.spanContext = trace.SpanContextFromContext(stream.Context())
+	return stream.Send(&healthpb.HealthCheckResponse{})
+}
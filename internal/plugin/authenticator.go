@@ -5,7 +5,10 @@ import (
 	"reflect"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	empty "google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
@@ -111,9 +114,7 @@ func (c *authenticatorClient) auth(
 		return nil, err
 	}
 
-	return &component.AuthResult{
-		Authenticated: resp.Authenticated,
-	}, nil
+	return authResult(resp), nil
 }
 
 func (c *authenticatorClient) validateAuth(
@@ -169,8 +170,8 @@ func (s *authenticatorServer) Auth(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	raw, err := callDynamicFunc2(s.Impl.(component.Authenticator).AuthFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	raw, err := callDynamicFunc2(ctx, s.Impl.(component.Authenticator).AuthFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Typed(internal),
 		argmapper.Typed(ctx),
 	)
@@ -178,16 +179,7 @@ func (s *authenticatorServer) Auth(
 		return nil, err
 	}
 
-	result, ok := raw.(*component.AuthResult)
-	if !ok {
-		return &pb.Auth_AuthResponse{
-			Authenticated: false,
-		}, nil
-	}
-
-	return &pb.Auth_AuthResponse{
-		Authenticated: result.Authenticated,
-	}, nil
+	return authResponse(raw), nil
 }
 
 func (s *authenticatorServer) ValidateAuthSpec(
@@ -208,8 +200,8 @@ func (s *authenticatorServer) ValidateAuth(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	_, err := callDynamicFunc2(s.Impl.(component.Authenticator).ValidateAuthFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	_, err := callDynamicFunc2(ctx, s.Impl.(component.Authenticator).ValidateAuthFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Typed(internal),
 		argmapper.Typed(ctx),
 	)
@@ -220,6 +212,173 @@ func (s *authenticatorServer) ValidateAuth(
 	return &empty.Empty{}, nil
 }
 
+func (s *authenticatorServer) IsAuthRefresher(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.ImplementsResp, error) {
+	_, ok := s.Impl.(component.AuthRefresher)
+	return &pb.ImplementsResp{Implements: ok}, nil
+}
+
+func (s *authenticatorServer) RefreshAuthSpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	refresher, ok := s.Impl.(component.AuthRefresher)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: AuthRefresher")
+	}
+
+	return funcspec.Spec(refresher.RefreshAuthFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+
+		// We expect a auth result.
+		argmapper.FilterOutput(argmapper.FilterOr(
+			argmapper.FilterType(reflect.TypeOf((*component.AuthResult)(nil))),
+
+			// We expect this for tests.
+			argmapper.FilterType(reflect.TypeOf((*testproto.Data)(nil))),
+		)),
+	)
+}
+
+func (s *authenticatorServer) RefreshAuth(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*pb.Auth_AuthResponse, error) {
+	refresher, ok := s.Impl.(component.AuthRefresher)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: AuthRefresher")
+	}
+
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	raw, err := callDynamicFunc2(ctx, refresher.RefreshAuthFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Typed(internal),
+		argmapper.Typed(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return authResponse(raw), nil
+}
+
+// authRefresherProtoClient is the interface implemented by all gRPC
+// services that have the auth refresh RPC methods.
+type authRefresherProtoClient interface {
+	IsAuthRefresher(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ImplementsResp, error)
+	RefreshAuth(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (*pb.Auth_AuthResponse, error)
+	RefreshAuthSpec(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error)
+}
+
+// authRefresherClient implements component.AuthRefresher for a service
+// that has the auth refresh methods implemented.
+type authRefresherClient struct {
+	Client  authRefresherProtoClient
+	Logger  hclog.Logger
+	Broker  *plugin.GRPCBroker
+	Mappers []*argmapper.Func
+}
+
+func (c *authRefresherClient) Implements(ctx context.Context) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	resp, err := c.Client.IsAuthRefresher(ctx, &empty.Empty{})
+	if err != nil {
+		// Plugins built against older SDKs don't have the IsAuthRefresher
+		// RPC at all, which just means they don't implement it.
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return resp.Implements, nil
+}
+
+func (c *authRefresherClient) RefreshAuthFunc() interface{} {
+	if c == nil {
+		return func() (*component.AuthResult, error) {
+			return nil, nil
+		}
+	}
+
+	// Get the spec
+	spec, err := c.Client.RefreshAuthSpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		return funcErr(err)
+	}
+
+	// We don't want to be a mapper
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.refreshAuth,
+		argmapper.Logger(c.Logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.Broker,
+			Mappers: c.Mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *authRefresherClient) refreshAuth(
+	ctx context.Context,
+	args funcspec.Args,
+	internal *pluginargs.Internal,
+) (*component.AuthResult, error) {
+	// Run the cleanup
+	defer internal.Cleanup.Close()
+
+	// Call our function
+	resp, err := c.Client.RefreshAuth(ctx, &pb.FuncSpec_Args{Args: args})
+	if err != nil {
+		return nil, err
+	}
+
+	return authResult(resp), nil
+}
+
+// authResponse converts the result of an auth function to its protobuf
+// response. Results that aren't an AuthResult aren't authenticated.
+func authResponse(raw interface{}) *pb.Auth_AuthResponse {
+	result, ok := raw.(*component.AuthResult)
+	if !ok || result == nil {
+		return &pb.Auth_AuthResponse{
+			Authenticated: false,
+		}
+	}
+
+	resp := &pb.Auth_AuthResponse{
+		Authenticated: result.Authenticated,
+	}
+	if !result.ExpiresAt.IsZero() {
+		resp.ExpiresAt = timestamppb.New(result.ExpiresAt)
+	}
+
+	return resp
+}
+
+// authResult converts an auth response back to an AuthResult.
+func authResult(resp *pb.Auth_AuthResponse) *component.AuthResult {
+	result := &component.AuthResult{
+		Authenticated: resp.Authenticated,
+	}
+	if resp.ExpiresAt != nil {
+		result.ExpiresAt = resp.ExpiresAt.AsTime()
+	}
+
+	return result
+}
+
 var (
 	_ component.Authenticator = (*authenticatorClient)(nil)
+	_ component.AuthRefresher = (*authRefresherClient)(nil)
 )
@@ -1,28 +1,97 @@
 package plugin
 
 import (
+	"sync"
+
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
 )
 
 // base contains shared logic for all plugins. This should be embedded
 // in every plugin implementation.
 type base struct {
-	Broker  *plugin.GRPCBroker
-	Logger  hclog.Logger
-	Mappers []*argmapper.Func
+	Broker          *plugin.GRPCBroker
+	Logger          hclog.Logger
+	Mappers         []*argmapper.Func
+	ProtocolVersion *component.ProtocolVersion
+	Features        *pluginargs.FeatureSet
+	TracerProvider  trace.TracerProvider
+
+	UnaryInterceptors  []grpc.UnaryServerInterceptor
+	StreamInterceptors []grpc.StreamServerInterceptor
+	ServerSettings     *pluginargs.ServerSettings
+
+	// cache caches the results of the mappers for the calls to the
+	// server until it is purged, cachedMappers are the Mappers wrapped
+	// with it. Use mapperCache to access them.
+	cacheOnce     sync.Once
+	cache         *pluginargs.MapperCache
+	cachedMappers []*argmapper.Func
 }
 
 // internal returns a new pluginargs.Internal that can be used with
 // dynamic calls. The Internal structure is an internal-only argument
 // that is used to perform cleanup.
 func (b *base) internal() *pluginargs.Internal {
-	return &pluginargs.Internal{
-		Broker:  b.Broker,
-		Mappers: b.Mappers,
-		Cleanup: &pluginargs.Cleanup{},
+	cache, mappers := b.mapperCache()
+	result := &pluginargs.Internal{
+		Broker:      b.Broker,
+		Mappers:     mappers,
+		Cleanup:     &pluginargs.Cleanup{},
+		MapperCache: cache,
+		Features:    b.Features,
+
+		TracerProvider:     b.TracerProvider,
+		UnaryInterceptors:  b.UnaryInterceptors,
+		StreamInterceptors: b.StreamInterceptors,
+		ServerSettings:     b.ServerSettings,
+	}
+	if b.ProtocolVersion != nil {
+		result.ProtocolVersion = b.ProtocolVersion.Version
+	}
+
+	return result
+}
+
+// mapperCache returns the mapper cache of the server and the mappers
+// wrapped with it.
+func (b *base) mapperCache() (*pluginargs.MapperCache, []*argmapper.Func) {
+	b.cacheOnce.Do(func() {
+		b.cache = pluginargs.NewMapperCache()
+		b.cachedMappers = b.cache.Wrap(b.Mappers)
+	})
+
+	return b.cache, b.cachedMappers
+}
+
+// purgeMappers purges the mapper cache. This is called when the component
+// is configured, since the cached results may depend on the previous
+// configuration, such as an API client created from a config struct that
+// is modified in place.
+func (b *base) purgeMappers() {
+	cache, _ := b.mapperCache()
+	cache.Purge()
+}
+
+// withLogger returns a base for another server with the same settings as b
+// that logs to logger. The new base has its own mapper cache.
+func (b *base) withLogger(logger hclog.Logger) *base {
+	return &base{
+		Broker:          b.Broker,
+		Logger:          logger,
+		Mappers:         b.Mappers,
+		ProtocolVersion: b.ProtocolVersion,
+		Features:        b.Features,
+		TracerProvider:  b.TracerProvider,
+
+		UnaryInterceptors:  b.UnaryInterceptors,
+		StreamInterceptors: b.StreamInterceptors,
+		ServerSettings:     b.ServerSettings,
 	}
 }
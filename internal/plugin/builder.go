@@ -3,16 +3,25 @@ package plugin
 import (
 	"context"
 	"encoding/json"
+	"reflect"
+	"strings"
 
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/hashicorp/opaqueany"
+	"github.com/zclconf/go-cty/cty"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
 	"github.com/hashicorp/waypoint-plugin-sdk/docs"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
@@ -30,16 +39,32 @@ type BuilderPlugin struct {
 	Logger  hclog.Logger      // Logger
 
 	ODR *ODRSetting // Used to switch builder modes based on ondemand-runner in play
+
+	ProtocolVersion *component.ProtocolVersion // Protocol version of the plugin set
+	Features        *pluginargs.FeatureSet     // Features of the plugin, see WithFeatures
+	Name            ComponentName              // Name of the component, see WithNamedComponent
+	TracerProvider  trace.TracerProvider       // Traces brokered servers, see WithTracerProvider
+
+	UnaryInterceptors  []grpc.UnaryServerInterceptor  // Interceptors for brokered servers, see WithInterceptors
+	StreamInterceptors []grpc.StreamServerInterceptor // Interceptors for brokered servers, see WithInterceptors
+	ServerSettings     *pluginargs.ServerSettings     // Settings for brokered servers, see WithServerSettings
 }
 
 func (p *BuilderPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
 	base := &base{
-		Mappers: p.Mappers,
-		Logger:  p.Logger,
-		Broker:  broker,
+		Mappers:         p.Mappers,
+		Logger:          p.Logger,
+		Broker:          broker,
+		ProtocolVersion: p.ProtocolVersion,
+		Features:        p.Features,
+		TracerProvider:  p.TracerProvider,
+
+		UnaryInterceptors:  p.UnaryInterceptors,
+		StreamInterceptors: p.StreamInterceptors,
+		ServerSettings:     p.ServerSettings,
 	}
 
-	pb.RegisterBuilderServer(s, &builderServer{
+	pb.RegisterBuilderServer(largeValueRegistrar(namedRegistrar(s, p.Name), broker), &builderServer{
 		base: base,
 		Impl: p.Impl,
 
@@ -47,6 +72,30 @@ func (p *BuilderPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) er
 			base: base,
 			Impl: p.Impl,
 		},
+		validatorServer: &validatorServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		configDefaulterServer: &configDefaulterServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		signerServer: &signerServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		odrProfilerServer: &odrProfilerServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		destroyerServer: &destroyerServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		workspaceDestroyerServer: &workspaceDestroyerServer{
+			base: base,
+			Impl: p.Impl,
+		},
 	})
 	return nil
 }
@@ -57,12 +106,17 @@ func (p *BuilderPlugin) GRPCClient(
 	c *grpc.ClientConn,
 ) (interface{}, error) {
 	client := &builderClient{
-		client:  pb.NewBuilderClient(c),
+		client:  pb.NewBuilderClient(diagnosticsConn(largeValueConn(namedConn(c, p.Name), broker, p.Features, p.Logger), p.Logger)),
 		logger:  p.Logger,
 		broker:  broker,
 		mappers: p.Mappers,
 	}
 
+	probe, err := newCapabilityProbe(ctx, c, component.BuilderType, p.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	if p.ODR != nil && p.ODR.Enabled {
 		client.odr = true
 	}
@@ -73,7 +127,7 @@ func (p *BuilderPlugin) GRPCClient(
 		Broker:  client.broker,
 		Mappers: client.mappers,
 	}
-	if ok, err := authenticator.Implements(ctx); err != nil {
+	if ok, err := probe.Implements(ctx, component.CapabilityAuthenticator, authenticator); err != nil {
 		return nil, err
 	} else if ok {
 		p.Logger.Info("builder plugin capable of auth")
@@ -81,10 +135,129 @@ func (p *BuilderPlugin) GRPCClient(
 		authenticator = nil
 	}
 
+	authRefresher := &authRefresherClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityAuthRefresher, authRefresher); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("builder plugin capable of auth refresh")
+	} else {
+		authRefresher = nil
+	}
+
+	validator := &validatorClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityValidator, validator); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("builder plugin capable of validation")
+	} else {
+		validator = nil
+	}
+
+	configDefaulter := &configDefaulterClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityConfigDefaulter, configDefaulter); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("builder plugin capable of config defaults")
+	} else {
+		configDefaulter = nil
+	}
+
+	signer := &signerClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilitySigner, signer); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("builder plugin capable of signing")
+	} else {
+		signer = nil
+	}
+
+	odrProfiler := &odrProfilerClient{
+		Client: client.client,
+		Logger: client.logger,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityODRProfiler, odrProfiler); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("builder plugin capable of ODR profiles")
+	} else {
+		odrProfiler = nil
+	}
+
+	// Compose destroyer
+	destroyer := &destroyerClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityDestroyer, destroyer); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("builder plugin capable of destroy")
+	} else {
+		destroyer = nil
+	}
+
+	// Compose workspace destroyer
+	wsDestroyer := &workspaceDestroyerClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityWorkspaceDestroyer, wsDestroyer); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("builder plugin capable of workspace destroy")
+	} else {
+		wsDestroyer = nil
+	}
+
+	var caps capabilities
+	caps.add(component.CapabilityAuthenticator, authenticator != nil)
+	caps.add(component.CapabilityAuthRefresher, authRefresher != nil)
+	caps.add(component.CapabilityValidator, validator != nil)
+	caps.add(component.CapabilityConfigDefaulter, configDefaulter != nil)
+	caps.add(component.CapabilitySigner, signer != nil)
+	caps.add(component.CapabilityODRProfiler, odrProfiler != nil)
+	caps.add(component.CapabilityDestroyer, destroyer != nil)
+	caps.add(component.CapabilityWorkspaceDestroyer, wsDestroyer != nil)
+
 	result := &mix_Builder_Authenticator{
+		capabilities:       caps,
 		ConfigurableNotify: client,
+		ConfigurableSchema: client,
 		Builder:            client,
+		BuilderMulti:       client,
 		Authenticator:      authenticator,
+		AuthRefresher:      authRefresher,
+		Validator:          validator,
+		ConfigDefaulter:    configDefaulter,
+		Signer:             signer,
+		ODRProfiler:        odrProfiler,
+		Destroyer:          destroyer,
+		DestroyHooks:       destroyer,
+		WorkspaceDestroyer: wsDestroyer,
 		Documented:         client,
 	}
 
@@ -111,6 +284,14 @@ func (c *builderClient) ConfigSet(v interface{}) error {
 	return configureCall(context.Background(), c.client, v)
 }
 
+func (c *builderClient) ConfigSchema() (hcldec.Spec, error) {
+	return configSchemaCall(context.Background(), c.client)
+}
+
+func (c *builderClient) ConfigSetValue(v cty.Value) error {
+	return configureValueCall(context.Background(), c.client, v)
+}
+
 func (c *builderClient) Documentation() (*docs.Documentation, error) {
 	return documentationCall(context.Background(), c.client)
 }
@@ -191,6 +372,8 @@ func (c *builderClient) build(
 		AnyJson:     resp.ResultJson,
 		LabelsVal:   resp.Labels,
 		TemplateVal: tplData,
+		EventsVal:   resp.EventLog.GetEvents(),
+		WarningsVal: diag.FromProto(resp.Warnings),
 	}, nil
 }
 
@@ -215,14 +398,87 @@ func (c *builderClient) buildODR(
 		Any:         resp.Result,
 		LabelsVal:   resp.Labels,
 		TemplateVal: tplData,
+		EventsVal:   resp.EventLog.GetEvents(),
+		WarningsVal: diag.FromProto(resp.Warnings),
 	}, nil
 }
 
+func (c *builderClient) BuildManyFunc() interface{} {
+	resp, err := c.client.IsBuilderMulti(context.Background(), &empty.Empty{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			// Older plugins don't support multi-platform builds.
+			return nil
+		}
+
+		return funcErr(err)
+	}
+	if !resp.Implements {
+		return nil
+	}
+
+	// Get the spec
+	spec, err := c.client.BuildManySpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		return funcErr(err)
+	}
+
+	// We don't want to be a mapper
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.buildMany,
+		argmapper.Logger(c.logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.broker,
+			Mappers: c.mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *builderClient) buildMany(
+	ctx context.Context,
+	args funcspec.Args,
+) (*component.MultiArtifact, error) {
+	// Call our function
+	resp, err := c.client.BuildMany(ctx, &pb.FuncSpec_Args{Args: args})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &component.MultiArtifact{
+		Artifacts: make(map[string]component.Artifact, len(resp.Results)),
+	}
+	for platform, r := range resp.Results {
+		var tplData map[string]interface{}
+		if len(r.TemplateData) > 0 {
+			if err := json.Unmarshal(r.TemplateData, &tplData); err != nil {
+				return nil, err
+			}
+		}
+
+		result.Artifacts[platform] = &plugincomponent.Artifact{
+			Any:         r.Result,
+			AnyJson:     r.ResultJson,
+			LabelsVal:   r.Labels,
+			TemplateVal: tplData,
+		}
+	}
+
+	return result, nil
+}
+
 // builderServer is a gRPC server that the client talks to and calls a
 // real implementation of the component.
 type builderServer struct {
 	*base
 	*authenticatorServer
+	*validatorServer
+	*configDefaulterServer
+	*signerServer
+	*odrProfilerServer
+	*destroyerServer
+	*workspaceDestroyerServer
 
 	pb.UnsafeBuilderServer // to avoid having to copy stubs into here for authServer
 
@@ -240,14 +496,30 @@ func (s *builderServer) Configure(
 	ctx context.Context,
 	req *pb.Config_ConfigureRequest,
 ) (*empty.Empty, error) {
+	defer s.purgeMappers()
 	return configure(s.Impl, req)
 }
 
+func (s *builderServer) ConfigSchema(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.Config_SchemaResp, error) {
+	return configSchema(s.Impl)
+}
+
+func (s *builderServer) ConfigureValue(
+	ctx context.Context,
+	req *pb.Config_ConfigureValueRequest,
+) (*empty.Empty, error) {
+	defer s.purgeMappers()
+	return configureValue(s.Impl, req)
+}
+
 func (s *builderServer) Documentation(
 	ctx context.Context,
 	empty *empty.Empty,
 ) (*pb.Config_Documentation, error) {
-	return documentation(s.Impl)
+	return documentation(s.Impl, s.Mappers, s.Logger)
 }
 
 func (s *builderServer) BuildSpec(
@@ -292,22 +564,33 @@ func (s *builderServer) Build(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	encoded, encodedJson, raw, err := callDynamicFuncAny2(s.Impl.BuildFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	// Inject our outparameters, so we can capture the events and warnings
+	// after invocation
+	eventLogger := &component.EventLogger{}
+	warnings := &component.Warnings{}
+
+	encoded, encodedJson, raw, err := callDynamicFuncAnyWarnings(ctx, s.Impl.BuildFunc(), args.Args, warnings,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Logger(s.Logger),
 		argmapper.Typed(ctx),
 		argmapper.Typed(internal),
+		argmapper.Typed(eventLogger),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	result := &pb.Build_Resp{Result: encoded, ResultJson: encodedJson}
+	result := &pb.Build_Resp{
+		Result:     encoded,
+		ResultJson: encodedJson,
+		EventLog:   &pb.EventLog{Events: eventLogger.Events()},
+		Warnings:   warnings.Warnings().Proto(),
+	}
 	if artifact, ok := raw.(component.Artifact); ok {
 		result.Labels = artifact.Labels()
 	}
 
-	result.TemplateData, err = templateData(raw)
+	result.TemplateData, err = templateData(raw, s.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -327,35 +610,165 @@ func (s *builderServer) BuildODR(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	encoded, encodedJson, raw, err := callDynamicFuncAny2(odr.BuildODRFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	// Inject our outparameters, so we can capture the events and warnings
+	// after invocation
+	eventLogger := &component.EventLogger{}
+	warnings := &component.Warnings{}
+
+	encoded, encodedJson, raw, err := callDynamicFuncAnyWarnings(ctx, odr.BuildODRFunc(), args.Args, warnings,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Logger(s.Logger),
 		argmapper.Typed(ctx),
 		argmapper.Typed(internal),
+		argmapper.Typed(eventLogger),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	result := &pb.Build_Resp{Result: encoded, ResultJson: encodedJson}
+	result := &pb.Build_Resp{
+		Result:     encoded,
+		ResultJson: encodedJson,
+		EventLog:   &pb.EventLog{Events: eventLogger.Events()},
+		Warnings:   warnings.Warnings().Proto(),
+	}
 	if artifact, ok := raw.(component.Artifact); ok {
 		result.Labels = artifact.Labels()
 	}
 
-	result.TemplateData, err = templateData(raw)
+	result.TemplateData, err = templateData(raw, s.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *builderServer) IsBuilderMulti(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.ImplementsResp, error) {
+	b, ok := s.Impl.(component.BuilderMulti)
+	return &pb.ImplementsResp{
+		Implements: ok && b.BuildManyFunc() != nil,
+	}, nil
+}
+
+func (s *builderServer) BuildManySpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	b, ok := s.Impl.(component.BuilderMulti)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: BuilderMulti")
+	}
+
+	return funcspec.Spec(b.BuildManyFunc(),
+		argmapper.Logger(s.Logger),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Typed(s.internal()),
+
+		argmapper.FilterOutput(argmapper.FilterType(
+			reflect.TypeOf((*component.MultiArtifact)(nil))),
+		),
+	)
+}
+
+func (s *builderServer) BuildMany(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*pb.Build_MultiResp, error) {
+	b, ok := s.Impl.(component.BuilderMulti)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: BuilderMulti")
+	}
+
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	raw, err := callDynamicFunc2(ctx, b.BuildManyFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(ctx),
+		argmapper.Typed(internal),
+	)
 	if err != nil {
 		return nil, err
 	}
 
+	multi, ok := raw.(*component.MultiArtifact)
+	if !ok {
+		return nil, status.Errorf(codes.Aborted,
+			"build many result is not a *component.MultiArtifact, got %T", raw)
+	}
+
+	result := &pb.Build_MultiResp{
+		Results: make(map[string]*pb.Build_Resp, len(multi.Artifacts)),
+	}
+	for platform, artifact := range multi.Artifacts {
+		if !validBuildPlatform(platform) {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"invalid platform %q, expected os/arch or os/arch/variant", platform)
+		}
+
+		msg, ok := artifact.(proto.Message)
+		if !ok {
+			return nil, status.Errorf(codes.Aborted,
+				"artifact for %q must be a proto.Message, got %T", platform, artifact)
+		}
+
+		encoded, err := opaqueany.New(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		encodedJson, err := protojson.Marshal(component.RedactSecrets(msg))
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &pb.Build_Resp{
+			Result:     encoded,
+			ResultJson: string(encodedJson),
+			Labels:     artifact.Labels(),
+		}
+
+		resp.TemplateData, err = templateData(artifact, s.Logger)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Results[platform] = resp
+	}
+
 	return result, nil
 }
 
+// validBuildPlatform returns true if the platform is in "os/arch" or
+// "os/arch/variant" form.
+func validBuildPlatform(v string) bool {
+	parts := strings.Split(v, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return false
+	}
+
+	for _, part := range parts {
+		if part == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
 var (
 	_ plugin.Plugin                = (*BuilderPlugin)(nil)
 	_ plugin.GRPCPlugin            = (*BuilderPlugin)(nil)
 	_ pb.BuilderServer             = (*builderServer)(nil)
 	_ component.Builder            = (*builderClient)(nil)
+	_ component.BuilderMulti       = (*builderClient)(nil)
 	_ component.Configurable       = (*builderClient)(nil)
 	_ component.Documented         = (*builderClient)(nil)
 	_ component.ConfigurableNotify = (*builderClient)(nil)
+	_ component.ConfigurableSchema = (*builderClient)(nil)
 )
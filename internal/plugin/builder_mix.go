@@ -5,8 +5,20 @@ import (
 )
 
 type mix_Builder_Authenticator struct {
+	capabilities
+
 	component.Authenticator
+	component.AuthRefresher
+	component.Validator
+	component.ConfigDefaulter
 	component.ConfigurableNotify
+	component.ConfigurableSchema
 	component.Builder
+	component.BuilderMulti
 	component.Documented
+	component.Signer
+	component.ODRProfiler
+	component.Destroyer
+	component.DestroyHooks
+	component.WorkspaceDestroyer
 }
@@ -2,16 +2,29 @@ package plugin
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/opaqueany"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint-plugin-sdk/component/pipe"
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
+	pluginpipe "github.com/hashicorp/waypoint-plugin-sdk/internal/plugin/pipe"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
@@ -60,6 +73,384 @@ func TestBuilderBuild(t *testing.T) {
 	require.True(called)
 }
 
+func TestBuilderBuild_sliceAndMapArgs(t *testing.T) {
+	require := require.New(t)
+
+	var gotArgs []string
+	var gotLabels map[string]string
+	var gotPorts []uint16
+	buildFunc := func(args []string, labels map[string]string, ports []uint16) *testproto.Data {
+		gotArgs, gotLabels, gotPorts = args, labels, ports
+		return &testproto.Data{Value: "hello"}
+	}
+
+	mockB := &mocks.Builder{}
+	mockB.On("BuildFunc").Return(buildFunc)
+
+	plugins := Plugins(WithComponents(mockB), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("builder")
+	require.NoError(err)
+	f := raw.(component.Builder).BuildFunc().(*argmapper.Func)
+
+	result := f.Call(
+		argmapper.Typed(context.Background()),
+		argmapper.Typed([]string{"GO_VERSION=1.20", "CGO_ENABLED=0"}),
+		argmapper.Typed(map[string]string{"team": "web"}),
+		argmapper.Typed([]uint16{80, 443}),
+	)
+	require.NoError(result.Err())
+
+	require.Equal([]string{"GO_VERSION=1.20", "CGO_ENABLED=0"}, gotArgs)
+	require.Equal(map[string]string{"team": "web"}, gotLabels)
+	require.Equal([]uint16{80, 443}, gotPorts)
+}
+
+func TestBuilderBuild_optionalArgs(t *testing.T) {
+	type buildArgs struct {
+		argmapper.Struct
+
+		Source   *component.Source
+		Labels   *component.LabelSet `argmapper:",typeOnly,optional"`
+		Replicas int                 `argmapper:"replicas,default=3"`
+	}
+
+	var got buildArgs
+	buildFunc := func(args buildArgs) *testproto.Data {
+		got = args
+		return &testproto.Data{Value: "hello"}
+	}
+
+	mockB := &mocks.Builder{}
+	mockB.On("BuildFunc").Return(buildFunc)
+
+	plugins := Plugins(WithComponents(mockB), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("builder")
+	require.NoError(t, err)
+	f := raw.(component.Builder).BuildFunc().(*argmapper.Func)
+
+	t.Run("defaults", func(t *testing.T) {
+		require := require.New(t)
+
+		result := f.Call(
+			argmapper.Typed(context.Background()),
+			argmapper.Typed(&pb.Args_Source{App: "foo"}),
+		)
+		require.NoError(result.Err())
+		require.Equal("foo", got.Source.App)
+		require.NotNil(got.Labels)
+		require.Empty(got.Labels.Labels)
+		require.Equal(3, got.Replicas)
+	})
+
+	t.Run("given", func(t *testing.T) {
+		require := require.New(t)
+
+		result := f.Call(
+			argmapper.Typed(context.Background()),
+			argmapper.Typed(&pb.Args_Source{App: "foo"}),
+			argmapper.Typed(&pb.Args_LabelSet{Labels: map[string]string{"env": "prod"}}),
+			argmapper.Named("replicas", 5),
+		)
+		require.NoError(result.Err())
+		require.Equal(map[string]string{"env": "prod"}, got.Labels.Labels)
+		require.Equal(5, got.Replicas)
+	})
+
+	// Required arguments are still required
+	result := f.Call(argmapper.Typed(context.Background()))
+	require.Error(t, result.Err())
+}
+
+func TestBuilderBuild_events(t *testing.T) {
+	require := require.New(t)
+
+	buildFunc := func(ctx context.Context, events *component.EventLogger) *testproto.Data {
+		events.Info("image", "pushed image", map[string]string{"tag": "v1"})
+		events.Warn("cache", "cache miss", nil)
+		return &testproto.Data{Value: "hello"}
+	}
+
+	mockB := &mocks.Builder{}
+	mockB.On("BuildFunc").Return(buildFunc)
+
+	plugins := Plugins(WithComponents(mockB), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("builder")
+	require.NoError(err)
+	f := raw.(component.Builder).BuildFunc().(*argmapper.Func)
+
+	// The event logger is injected on the plugin side, so the host doesn't
+	// need to provide it.
+	result := f.Call(argmapper.Typed(context.Background()))
+	require.NoError(result.Err())
+
+	events := result.Out(0).(component.EventTrail).Events()
+	require.Len(events, 2)
+	require.Equal(pb.EventLog_INFO, events[0].Level)
+	require.Equal("image", events[0].Category)
+	require.Equal("pushed image", events[0].Message)
+	require.Equal(map[string]string{"tag": "v1"}, events[0].Metadata)
+	require.NotNil(events[0].Time)
+	require.Equal(pb.EventLog_WARN, events[1].Level)
+}
+
+func TestBuilderBuild_warnings(t *testing.T) {
+	require := require.New(t)
+
+	buildFunc := func(ctx context.Context, warnings *component.Warnings) (*testproto.Data, diag.Diagnostics) {
+		warnings.Addf("image %q not pinned by digest", "nginx")
+		return &testproto.Data{Value: "hello"}, diag.Diagnostics{}.Warnf("deprecated")
+	}
+
+	mockB := &mocks.Builder{}
+	mockB.On("BuildFunc").Return(buildFunc)
+
+	plugins := Plugins(WithComponents(mockB), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("builder")
+	require.NoError(err)
+	f := raw.(component.Builder).BuildFunc().(*argmapper.Func)
+
+	// Warnings don't fail the operation and are returned with the result,
+	// including the warning diagnostics returned by the function.
+	result := f.Call(argmapper.Typed(context.Background()))
+	require.NoError(result.Err())
+	require.Equal(diag.Diagnostics{
+		{Severity: diag.Warning, Summary: `image "nginx" not pinned by digest`},
+		{Severity: diag.Warning, Summary: "deprecated"},
+	}, result.Out(0).(component.WarningTrail).Warnings())
+}
+
+func TestBuilderBuild_warningsError(t *testing.T) {
+	require := require.New(t)
+
+	buildFunc := func(ctx context.Context, warnings *component.Warnings) (*testproto.Data, error) {
+		warnings.Add("image not pinned by digest")
+		return nil, status.Error(codes.FailedPrecondition, "registry unavailable")
+	}
+
+	mockB := &mocks.Builder{}
+	mockB.On("BuildFunc").Return(buildFunc)
+
+	plugins := Plugins(WithComponents(mockB), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("builder")
+	require.NoError(err)
+	f := raw.(component.Builder).BuildFunc().(*argmapper.Func)
+
+	// The warnings are returned with the error, which keeps its status
+	result := f.Call(argmapper.Typed(context.Background()))
+	require.Error(result.Err())
+	require.Equal(codes.FailedPrecondition, status.Code(result.Err()))
+	require.Equal(diag.Diagnostics{
+		{Severity: diag.Warning, Summary: "image not pinned by digest"},
+	}, diag.FromError(result.Err()))
+}
+
+func TestBuilderBuild_largeArg(t *testing.T) {
+	defer func(v int) { largeValueSize = v }(largeValueSize)
+	largeValueSize = 64
+
+	app := strings.Repeat("a", 100)
+	buildFunc := func(args *component.Source) *testproto.Data {
+		return &testproto.Data{Value: args.App}
+	}
+
+	for _, negotiate := range []bool{false, true} {
+		t.Run(fmt.Sprintf("negotiate=%v", negotiate), func(t *testing.T) {
+			require := require.New(t)
+
+			mockB := &mocks.Builder{}
+			mockB.On("BuildFunc").Return(buildFunc)
+
+			plugins := Plugins(WithComponents(mockB), WithMappers(testDefaultMappers(t)...))
+			client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+			defer client.Close()
+			defer server.Stop()
+
+			// Large values are only streamed if the plugin supports it,
+			// otherwise they're sent inline.
+			if negotiate {
+				raw, err := client.Dispense("info")
+				require.NoError(err)
+				features, err := raw.(*InfoClient).Negotiate(context.Background())
+				require.NoError(err)
+				require.True(features.Enabled(component.FeatureLargeValues))
+			}
+
+			raw, err := client.Dispense("builder")
+			require.NoError(err)
+			f := raw.(component.Builder).BuildFunc().(*argmapper.Func)
+
+			result := f.Call(
+				argmapper.Typed(context.Background()),
+				argmapper.Typed(&pb.Args_Source{App: app}),
+			)
+			require.NoError(result.Err())
+
+			var data testproto.Data
+			require.NoError(component.ProtoAnyUnmarshal(result.Out(0), &data))
+			require.Equal(app, data.Value)
+		})
+	}
+
+	// Without the feature the args are sent as-is
+	args := &pb.FuncSpec_Args{Args: []*pb.FuncSpec_Value{{
+		Value: &pb.FuncSpec_Value_String_{String_: app},
+	}}}
+	conn := &valueConn{features: &pluginargs.FeatureSet{}}
+	result, servers, err := conn.streamValues(args)
+	require.NoError(t, err)
+	require.Nil(t, servers)
+	require.True(t, result == args)
+}
+
+func TestValueServer_stopsAfterRead(t *testing.T) {
+	require := require.New(t)
+
+	data := []byte(strings.Repeat("a", 100))
+	vs := &valueServer{}
+	server := vs.newServer(nil, data, hclog.L())(nil)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	serveCh := make(chan error, 1)
+	go func() { serveCh <- server.Serve(ln) }()
+
+	conn, err := grpc.Dial(ln.Addr().String(), grpc.WithInsecure())
+	require.NoError(err)
+	defer conn.Close()
+
+	raw, err := (&pluginpipe.PipePlugin{}).GRPCClient(context.Background(), nil, conn)
+	require.NoError(err)
+	r := raw.(*pipe.Reader)
+	defer r.Close()
+
+	// The whole value is read before the server stops
+	result, err := io.ReadAll(r)
+	require.NoError(err)
+	require.Equal(data, result)
+
+	select {
+	case <-serveCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("value is still served after it was read")
+	}
+	require.True(vs.Stopped())
+}
+
+func TestBuilderBuildMany(t *testing.T) {
+	require := require.New(t)
+
+	buildFunc := func(ctx context.Context, args *component.Source) *component.MultiArtifact {
+		return &component.MultiArtifact{
+			Artifacts: map[string]component.Artifact{
+				"linux/amd64": &testArtifact{Data: &testproto.Data{Value: "amd64"}},
+				"linux/arm64": &testArtifact{Data: &testproto.Data{Value: "arm64"}},
+			},
+		}
+	}
+
+	mockB := &mockBuilderMulti{}
+	mockB.BuilderMulti.On("BuildManyFunc").Return(buildFunc)
+
+	plugins := Plugins(WithComponents(mockB), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("builder")
+	require.NoError(err)
+	builder := raw.(component.BuilderMulti)
+	f := builder.BuildManyFunc().(*argmapper.Func)
+	require.NotNil(f)
+
+	result := f.Call(
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(&pb.Args_Source{App: "foo"}),
+	)
+	require.NoError(result.Err())
+
+	multi := result.Out(0).(*component.MultiArtifact)
+	require.Len(multi.Artifacts, 2)
+	for _, platform := range []string{"linux/amd64", "linux/arm64"} {
+		artifact := multi.Artifacts[platform]
+		require.NotNil(artifact, platform)
+		require.Equal(map[string]string{"arch": "test"}, artifact.Labels())
+
+		anyVal := artifact.(component.ProtoMarshaler).Proto().(*opaqueany.Any)
+		require.Equal("testproto.Data", string(anyVal.MessageName()))
+	}
+}
+
+func TestBuilderBuildMany_invalidPlatform(t *testing.T) {
+	require := require.New(t)
+
+	buildFunc := func() *component.MultiArtifact {
+		return &component.MultiArtifact{
+			Artifacts: map[string]component.Artifact{
+				"amd64": &testArtifact{Data: &testproto.Data{Value: "amd64"}},
+			},
+		}
+	}
+
+	mockB := &mockBuilderMulti{}
+	mockB.BuilderMulti.On("BuildManyFunc").Return(buildFunc)
+
+	plugins := Plugins(WithComponents(mockB), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("builder")
+	require.NoError(err)
+	f := raw.(component.BuilderMulti).BuildManyFunc().(*argmapper.Func)
+
+	result := f.Call(argmapper.Typed(context.Background()))
+	require.Error(result.Err())
+	require.Contains(result.Err().Error(), "invalid platform")
+}
+
+func TestBuilderBuildMany_notImplemented(t *testing.T) {
+	require := require.New(t)
+
+	mockB := &mocks.Builder{}
+
+	plugins := Plugins(WithComponents(mockB), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("builder")
+	require.NoError(err)
+	require.Nil(raw.(component.BuilderMulti).BuildManyFunc())
+}
+
+func TestBuilderDynamicFunc_verifySignature(t *testing.T) {
+	testDynamicFunc(t, "builder", &mockBuilderSigner{}, func(v, f interface{}) {
+		v.(*mockBuilderSigner).Signer.On("VerifySignatureFunc").Return(f)
+	}, func(raw interface{}) interface{} {
+		return raw.(component.Signer).VerifySignatureFunc()
+	})
+}
+
 func TestBuilderDynamicFunc_auth(t *testing.T) {
 	testDynamicFunc(t, "builder", &mockBuilderAuthenticator{}, func(v, f interface{}) {
 		v.(*mockBuilderAuthenticator).Authenticator.On("AuthFunc").Return(f)
@@ -76,6 +467,91 @@ func TestBuilderDynamicFunc_validateAuth(t *testing.T) {
 	})
 }
 
+func TestBuilderDynamicFunc_refreshAuth(t *testing.T) {
+	testDynamicFunc(t, "builder", &mockBuilderAuthRefresher{}, func(v, f interface{}) {
+		v.(*mockBuilderAuthRefresher).AuthRefresher.On("RefreshAuthFunc").Return(f)
+	}, func(raw interface{}) interface{} {
+		return raw.(component.AuthRefresher).RefreshAuthFunc()
+	})
+}
+
+func TestBuilderRefreshAuth_expiry(t *testing.T) {
+	require := require.New(t)
+
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	mockV := &mockBuilderAuthRefresher{}
+	mockV.AuthRefresher.On("RefreshAuthFunc").Return(func() *component.AuthResult {
+		return &component.AuthResult{Authenticated: true, ExpiresAt: expires}
+	})
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("builder")
+	require.NoError(err)
+	require.Contains(component.Capabilities(raw), component.CapabilityAuthRefresher)
+
+	f := raw.(component.AuthRefresher).RefreshAuthFunc().(*argmapper.Func)
+	result := f.Call(argmapper.Typed(context.Background()))
+	require.NoError(result.Err())
+
+	auth := result.Out(0).(*component.AuthResult)
+	require.True(auth.Authenticated)
+	require.True(expires.Equal(auth.ExpiresAt))
+	require.True(auth.ExpiresWithin(2 * time.Hour))
+	require.False(auth.ExpiresWithin(30 * time.Minute))
+}
+
+func TestBuilderRefreshAuth_notImplemented(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mockBuilderAuthenticator{}
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("builder")
+	require.NoError(err)
+	require.Contains(component.Capabilities(raw), component.CapabilityAuthenticator)
+	require.NotContains(component.Capabilities(raw), component.CapabilityAuthRefresher)
+}
+
+func TestBuilderDynamicFunc_destroy(t *testing.T) {
+	testDynamicFunc(t, "builder", &mockBuilderDestroyer{}, func(v, f interface{}) {
+		v.(*mockBuilderDestroyer).Destroyer.On("DestroyFunc").Return(f)
+	}, func(raw interface{}) interface{} {
+		return raw.(component.Destroyer).DestroyFunc()
+	})
+}
+
+func TestBuilderDynamicFunc_destroyWorkspace(t *testing.T) {
+	testDynamicFunc(t, "builder", &mockBuilderWorkspaceDestroyer{}, func(v, f interface{}) {
+		v.(*mockBuilderWorkspaceDestroyer).WorkspaceDestroyer.On("DestroyWorkspaceFunc").Return(f)
+	}, func(raw interface{}) interface{} {
+		return raw.(component.WorkspaceDestroyer).DestroyWorkspaceFunc()
+	})
+}
+
+func TestBuilderDestroyer_notImplemented(t *testing.T) {
+	require := require.New(t)
+
+	plugins := Plugins(WithComponents(&mocks.Builder{}), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("builder")
+	require.NoError(err)
+	require.NotContains(component.Capabilities(raw), component.CapabilityDestroyer)
+	require.NotContains(component.Capabilities(raw), component.CapabilityWorkspaceDestroyer)
+	require.Nil(raw.(component.Destroyer).DestroyFunc())
+	require.Nil(raw.(component.WorkspaceDestroyer).DestroyWorkspaceFunc())
+}
+
 func TestBuilderConfig(t *testing.T) {
 	mockV := &mockBuilderConfigurable{}
 	testConfigurable(t, "builder", mockV, &mockV.Configurable)
@@ -86,7 +562,86 @@ type mockBuilderAuthenticator struct {
 	mocks.Authenticator
 }
 
+type mockBuilderAuthRefresher struct {
+	mocks.Builder
+	mocks.Authenticator
+	mocks.AuthRefresher
+}
+
+func TestBuilderConfigure_purgesMappers(t *testing.T) {
+	require := require.New(t)
+
+	type config struct {
+		Addr string `json:"addr"`
+	}
+	type apiClient struct{ Addr string }
+
+	calls := 0
+	mapper, err := argmapper.NewFunc(func(c *config) *apiClient {
+		calls++
+		return &apiClient{Addr: c.Addr}
+	})
+	require.NoError(err)
+
+	cfg := &config{Addr: "a"}
+	mockB := &mockBuilderConfigurable{}
+	mockB.Configurable.On("Config").Return(cfg, nil)
+
+	s := &builderServer{
+		base: &base{Logger: hclog.L(), Mappers: []*argmapper.Func{mapper}},
+		Impl: mockB,
+	}
+
+	client := func() *apiClient {
+		result := s.internal().Mappers[0].Call(argmapper.Typed(cfg))
+		require.NoError(result.Err())
+		return result.Out(0).(*apiClient)
+	}
+
+	// The result is cached across calls
+	require.Equal("a", client().Addr)
+	require.Equal("a", client().Addr)
+	require.Equal(1, calls)
+
+	// Configuring modifies the config in place, so the mapper runs again
+	_, err = s.Configure(context.Background(), &pb.Config_ConfigureRequest{
+		Json: []byte(`{"addr":"b"}`),
+	})
+	require.NoError(err)
+	require.Equal("b", client().Addr)
+	require.Equal(2, calls)
+}
+
 type mockBuilderConfigurable struct {
 	mocks.Builder
 	mocks.Configurable
 }
+
+type mockBuilderMulti struct {
+	mocks.Builder
+	mocks.BuilderMulti
+}
+
+// testArtifact is a component.Artifact that is also a proto message.
+type testArtifact struct {
+	*testproto.Data
+}
+
+func (a *testArtifact) Labels() map[string]string {
+	return map[string]string{"arch": "test"}
+}
+
+type mockBuilderSigner struct {
+	mocks.Builder
+	mocks.Signer
+}
+
+type mockBuilderDestroyer struct {
+	mocks.Builder
+	mocks.Destroyer
+}
+
+type mockBuilderWorkspaceDestroyer struct {
+	mocks.Builder
+	mocks.WorkspaceDestroyer
+}
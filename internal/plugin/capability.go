@@ -0,0 +1,92 @@
+package plugin
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// capabilities is embedded in the mixin clients to record the optional
+// capabilities that the plugin reported while composing the client.
+type capabilities []string
+
+// add records the capability if ok is true.
+func (c *capabilities) add(name string, ok bool) {
+	if ok {
+		*c = append(*c, name)
+	}
+}
+
+func (c capabilities) Capabilities() []string {
+	return append([]string(nil), c...)
+}
+
+// implementer is implemented by the clients of the optional interfaces to
+// check with an RPC whether the plugin implements the interface.
+type implementer interface {
+	Implements(context.Context) (bool, error)
+}
+
+// capabilityProbe determines the optional capabilities of a component when
+// its client is composed. The capabilities that the plugin reports with the
+// Components RPC of the info service are used if it has one, so composing a
+// client takes a single RPC rather than one for each optional interface.
+// Otherwise each interface is probed.
+type capabilityProbe struct {
+	// reported are the capabilities the plugin reported, or nil if it
+	// didn't report them.
+	reported map[string]bool
+}
+
+// newCapabilityProbe returns a probe for the component of the given type
+// and name served on c.
+func newCapabilityProbe(
+	ctx context.Context,
+	c grpc.ClientConnInterface,
+	typ component.Type,
+	name ComponentName,
+) (*capabilityProbe, error) {
+	resp, err := pb.NewInfoClient(c).Components(ctx, &empty.Empty{})
+	if status.Code(err) == codes.Unimplemented {
+		// Plugins built with an SDK before the Components RPC
+		return &capabilityProbe{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, comp := range resp.Components {
+		if component.TypeFromString(comp.Type) != typ || comp.RegisteredName != string(name) {
+			continue
+		}
+
+		reported := map[string]bool{}
+		for _, c := range comp.Capabilities {
+			reported[c] = true
+		}
+
+		return &capabilityProbe{reported: reported}, nil
+	}
+
+	return &capabilityProbe{}, nil
+}
+
+// Implements returns true if the component implements the given capability.
+// If the plugin didn't report its capabilities, c is used to check.
+func (p *capabilityProbe) Implements(ctx context.Context, capability string, c implementer) (bool, error) {
+	if p.reported != nil {
+		return p.reported[capability], nil
+	}
+
+	return c.Implements(ctx)
+}
+
+var (
+	_ component.CapabilityReporter = capabilities(nil)
+)
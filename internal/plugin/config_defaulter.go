@@ -0,0 +1,187 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// configDefaulterClient implements component.ConfigDefaulter for a service
+// that has the defaults methods implemented.
+type configDefaulterClient struct {
+	Client  configDefaulterProtoClient
+	Logger  hclog.Logger
+	Broker  *plugin.GRPCBroker
+	Mappers []*argmapper.Func
+}
+
+func (c *configDefaulterClient) Implements(ctx context.Context) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	resp, err := c.Client.IsConfigDefaulter(ctx, &empty.Empty{})
+	if err != nil {
+		// Plugins built against older SDKs don't have the IsConfigDefaulter
+		// RPC at all, which just means they don't implement it.
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return resp.Implements, nil
+}
+
+func (c *configDefaulterClient) DefaultsFunc() interface{} {
+	// The client is nil if the plugin doesn't implement this, see
+	// the GRPCClient of each component.
+	if c == nil {
+		return nil
+	}
+
+	// Get the spec
+	spec, err := c.Client.DefaultsSpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		return funcErr(err)
+	}
+
+	// We don't want to be a mapper
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.defaults,
+		argmapper.Logger(c.Logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.Broker,
+			Mappers: c.Mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *configDefaulterClient) defaults(
+	ctx context.Context,
+	args funcspec.Args,
+	internal *pluginargs.Internal,
+) (*pb.Config_Effective, error) {
+	// Run the cleanup
+	defer internal.Cleanup.Close()
+
+	// Call our function
+	return c.Client.Defaults(ctx, &pb.FuncSpec_Args{Args: args})
+}
+
+// configDefaulterServer implements the common ConfigDefaulter-related RPC
+// calls. This should be embedded into the service implementation.
+type configDefaulterServer struct {
+	*base
+	Impl interface{}
+}
+
+func (s *configDefaulterServer) IsConfigDefaulter(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.ImplementsResp, error) {
+	d, ok := s.Impl.(component.ConfigDefaulter)
+	return &pb.ImplementsResp{
+		Implements: ok && d.DefaultsFunc() != nil,
+	}, nil
+}
+
+func (s *configDefaulterServer) DefaultsSpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	d, ok := s.Impl.(component.ConfigDefaulter)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: ConfigDefaulter")
+	}
+
+	return funcspec.Spec(d.DefaultsFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+	)
+}
+
+func (s *configDefaulterServer) Defaults(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*pb.Config_Effective, error) {
+	d, ok := s.Impl.(component.ConfigDefaulter)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: ConfigDefaulter")
+	}
+
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	_, err := callDynamicFunc2(ctx, d.DefaultsFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Typed(internal),
+		argmapper.Typed(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return effectiveConfig(s.Impl)
+}
+
+// effectiveConfig returns the current configuration of impl for display.
+// This is empty if impl isn't configurable.
+func effectiveConfig(impl interface{}) (*pb.Config_Effective, error) {
+	result := &pb.Config_Effective{}
+
+	c, ok := impl.(component.Configurable)
+	if !ok {
+		return result, nil
+	}
+
+	v, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range component.ConfigFields(v) {
+		field := &pb.Config_Effective_Field{
+			Name:      f.Name,
+			Sensitive: f.Sensitive,
+		}
+
+		if !f.Sensitive {
+			field.Json, err = json.Marshal(f.Value)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result.Fields = append(result.Fields, field)
+	}
+
+	return result, nil
+}
+
+// configDefaulterProtoClient is the interface we expect any gRPC service
+// that supports config defaults to implement.
+type configDefaulterProtoClient interface {
+	IsConfigDefaulter(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ImplementsResp, error)
+	DefaultsSpec(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error)
+	Defaults(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (*pb.Config_Effective, error)
+}
+
+var (
+	_ component.ConfigDefaulter = (*configDefaulterClient)(nil)
+)
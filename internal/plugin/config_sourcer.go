@@ -7,6 +7,9 @@ import (
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/zclconf/go-cty/cty"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -27,16 +30,32 @@ type ConfigSourcerPlugin struct {
 	Impl    component.ConfigSourcer // Impl is the concrete implementation
 	Mappers []*argmapper.Func       // Mappers
 	Logger  hclog.Logger            // Logger
+
+	ProtocolVersion *component.ProtocolVersion // Protocol version of the plugin set
+	Features        *pluginargs.FeatureSet     // Features of the plugin, see WithFeatures
+	Name            ComponentName              // Name of the component, see WithNamedComponent
+	TracerProvider  trace.TracerProvider       // Traces brokered servers, see WithTracerProvider
+
+	UnaryInterceptors  []grpc.UnaryServerInterceptor  // Interceptors for brokered servers, see WithInterceptors
+	StreamInterceptors []grpc.StreamServerInterceptor // Interceptors for brokered servers, see WithInterceptors
+	ServerSettings     *pluginargs.ServerSettings     // Settings for brokered servers, see WithServerSettings
 }
 
 func (p *ConfigSourcerPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
 	base := &base{
-		Mappers: p.Mappers,
-		Logger:  p.Logger,
-		Broker:  broker,
+		Mappers:         p.Mappers,
+		Logger:          p.Logger,
+		Broker:          broker,
+		ProtocolVersion: p.ProtocolVersion,
+		Features:        p.Features,
+		TracerProvider:  p.TracerProvider,
+
+		UnaryInterceptors:  p.UnaryInterceptors,
+		StreamInterceptors: p.StreamInterceptors,
+		ServerSettings:     p.ServerSettings,
 	}
 
-	pb.RegisterConfigSourcerServer(s, &configSourcerServer{
+	pb.RegisterConfigSourcerServer(largeValueRegistrar(namedRegistrar(s, p.Name), broker), &configSourcerServer{
 		base: base,
 		Impl: p.Impl,
 	})
@@ -49,7 +68,7 @@ func (p *ConfigSourcerPlugin) GRPCClient(
 	c *grpc.ClientConn,
 ) (interface{}, error) {
 	client := &configSourcerClient{
-		client:  pb.NewConfigSourcerClient(c),
+		client:  pb.NewConfigSourcerClient(diagnosticsConn(largeValueConn(namedConn(c, p.Name), broker, p.Features, p.Logger), p.Logger)),
 		logger:  p.Logger,
 		broker:  broker,
 		mappers: p.Mappers,
@@ -75,6 +94,14 @@ func (c *configSourcerClient) ConfigSet(v interface{}) error {
 	return configureCall(context.Background(), c.client, v)
 }
 
+func (c *configSourcerClient) ConfigSchema() (hcldec.Spec, error) {
+	return configSchemaCall(context.Background(), c.client)
+}
+
+func (c *configSourcerClient) ConfigSetValue(v cty.Value) error {
+	return configureValueCall(context.Background(), c.client, v)
+}
+
 func (c *configSourcerClient) Documentation() (*docs.Documentation, error) {
 	return documentationCall(context.Background(), c.client)
 }
@@ -141,6 +168,47 @@ func (c *configSourcerClient) stop(
 	return err
 }
 
+func (c *configSourcerClient) WatchFunc() interface{} {
+	resp, err := c.client.IsWatcher(context.Background(), &empty.Empty{})
+	if err != nil {
+		return funcErr(err)
+	}
+	if !resp.Implements {
+		return nil
+	}
+
+	// Get the spec
+	spec, err := c.client.WatchSpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		return funcErr(err)
+	}
+
+	// We don't want to be a mapper
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.watch,
+		argmapper.Logger(c.logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.broker,
+			Mappers: c.mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *configSourcerClient) watch(
+	ctx context.Context,
+	args funcspec.Args,
+	internal *pluginargs.Internal,
+) error {
+	// Run the cleanup
+	defer internal.Cleanup.Close()
+
+	// Call our function
+	_, err := c.client.Watch(ctx, &pb.FuncSpec_Args{Args: args})
+	return err
+}
+
 // configSourcerServer is a gRPC server that the client talks to and calls a
 // real implementation of the component.
 type configSourcerServer struct {
@@ -162,14 +230,30 @@ func (s *configSourcerServer) Configure(
 	ctx context.Context,
 	req *pb.Config_ConfigureRequest,
 ) (*empty.Empty, error) {
+	defer s.purgeMappers()
 	return configure(s.Impl, req)
 }
 
+func (s *configSourcerServer) ConfigSchema(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.Config_SchemaResp, error) {
+	return configSchema(s.Impl)
+}
+
+func (s *configSourcerServer) ConfigureValue(
+	ctx context.Context,
+	req *pb.Config_ConfigureValueRequest,
+) (*empty.Empty, error) {
+	defer s.purgeMappers()
+	return configureValue(s.Impl, req)
+}
+
 func (s *configSourcerServer) Documentation(
 	ctx context.Context,
 	empty *empty.Empty,
 ) (*pb.Config_Documentation, error) {
-	return documentation(s.Impl)
+	return documentation(s.Impl, s.Mappers, s.Logger)
 }
 
 func (s *configSourcerServer) ReadSpec(
@@ -198,8 +282,8 @@ func (s *configSourcerServer) Read(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	raw, err := callDynamicFunc2(s.Impl.ReadFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	raw, err := callDynamicFunc2(ctx, s.Impl.ReadFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Logger(s.Logger),
 		argmapper.Typed(ctx),
 		argmapper.Typed(internal),
@@ -213,6 +297,17 @@ func (s *configSourcerServer) Read(
 		return nil, status.Errorf(codes.Aborted, "read result is not []*proto.ConfigSource_Value")
 	}
 
+	for _, v := range values {
+		if v.Ttl == nil {
+			continue
+		}
+
+		if err := v.Ttl.CheckValid(); err != nil || v.Ttl.AsDuration() < 0 {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"value %q has an invalid ttl: %s", v.Name, v.Ttl)
+		}
+	}
+
 	result := &pb.ConfigSource_ReadResponse{Values: values}
 	return result, nil
 }
@@ -239,8 +334,59 @@ func (s *configSourcerServer) Stop(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	_, err := callDynamicFunc2(s.Impl.StopFunc(), args.Args,
+	_, err := callDynamicFunc2(ctx, s.Impl.StopFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(ctx),
+		argmapper.Typed(internal),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+func (s *configSourcerServer) IsWatcher(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.ImplementsResp, error) {
+	w, ok := s.Impl.(component.ConfigSourcerWatcher)
+	return &pb.ImplementsResp{
+		Implements: ok && w.WatchFunc() != nil,
+	}, nil
+}
+
+func (s *configSourcerServer) WatchSpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	w, ok := s.Impl.(component.ConfigSourcerWatcher)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: ConfigSourcerWatcher")
+	}
+
+	return funcspec.Spec(w.WatchFunc(),
+		argmapper.Logger(s.Logger),
 		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Typed(s.internal()),
+	)
+}
+
+func (s *configSourcerServer) Watch(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*empty.Empty, error) {
+	w, ok := s.Impl.(component.ConfigSourcerWatcher)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: ConfigSourcerWatcher")
+	}
+
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	_, err := callDynamicFunc2(ctx, w.WatchFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Logger(s.Logger),
 		argmapper.Typed(ctx),
 		argmapper.Typed(internal),
@@ -253,11 +399,13 @@ func (s *configSourcerServer) Stop(
 }
 
 var (
-	_ plugin.Plugin                = (*ConfigSourcerPlugin)(nil)
-	_ plugin.GRPCPlugin            = (*ConfigSourcerPlugin)(nil)
-	_ pb.ConfigSourcerServer       = (*configSourcerServer)(nil)
-	_ component.ConfigSourcer      = (*configSourcerClient)(nil)
-	_ component.Configurable       = (*configSourcerClient)(nil)
-	_ component.Documented         = (*configSourcerClient)(nil)
-	_ component.ConfigurableNotify = (*configSourcerClient)(nil)
+	_ plugin.Plugin                  = (*ConfigSourcerPlugin)(nil)
+	_ plugin.GRPCPlugin              = (*ConfigSourcerPlugin)(nil)
+	_ pb.ConfigSourcerServer         = (*configSourcerServer)(nil)
+	_ component.ConfigSourcer        = (*configSourcerClient)(nil)
+	_ component.ConfigSourcerWatcher = (*configSourcerClient)(nil)
+	_ component.Configurable         = (*configSourcerClient)(nil)
+	_ component.Documented           = (*configSourcerClient)(nil)
+	_ component.ConfigurableNotify   = (*configSourcerClient)(nil)
+	_ component.ConfigurableSchema   = (*configSourcerClient)(nil)
 )
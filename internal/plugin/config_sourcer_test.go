@@ -3,14 +3,18 @@ package plugin
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint-plugin-sdk/docs"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
 
@@ -57,6 +61,49 @@ func TestConfigSourcerRead(t *testing.T) {
 	require.True(called)
 }
 
+func TestConfigSourcerRead_ttl(t *testing.T) {
+	require := require.New(t)
+
+	ttl := durationpb.New(5 * time.Minute)
+	readFunc := func(ctx context.Context) []*pb.ConfigSource_Value {
+		return []*pb.ConfigSource_Value{
+			{
+				Name:      "hello",
+				Ttl:       ttl,
+				Renewable: true,
+			},
+		}
+	}
+
+	mockB := &mocks.ConfigSourcer{}
+	mockB.On("ReadFunc").Return(readFunc)
+
+	plugins := Plugins(WithComponents(mockB), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("configsourcer")
+	require.NoError(err)
+	source := raw.(component.ConfigSourcer)
+	f := source.ReadFunc().(*argmapper.Func)
+	require.NotNil(f)
+
+	// Valid TTL is passed through
+	result := f.Call(argmapper.Typed(context.Background()))
+	require.NoError(result.Err())
+	values := result.Out(0).([]*pb.ConfigSource_Value)
+	require.Len(values, 1)
+	require.Equal(5*time.Minute, values[0].Ttl.AsDuration())
+	require.True(values[0].Renewable)
+
+	// Negative TTL is an error
+	ttl = durationpb.New(-time.Minute)
+	result = f.Call(argmapper.Typed(context.Background()))
+	require.Error(result.Err())
+	require.Contains(result.Err().Error(), "invalid ttl")
+}
+
 func TestConfigSourcerStop(t *testing.T) {
 	require := require.New(t)
 	assert := assert.New(t)
@@ -90,11 +137,119 @@ func TestConfigSourcerStop(t *testing.T) {
 	require.True(called)
 }
 
+func TestConfigSourcerWatch(t *testing.T) {
+	require := require.New(t)
+
+	watchFunc := func(ctx context.Context, w *component.ConfigWatcher) error {
+		w.Output <- []*pb.ConfigSource_Value{
+			{
+				Name:   "hello",
+				Result: &pb.ConfigSource_Value_Value{Value: "world"},
+			},
+		}
+		return nil
+	}
+
+	mockB := &mockConfigSourcerWatcher{}
+	mockB.ConfigSourcerWatcher.On("WatchFunc").Return(watchFunc)
+
+	plugins := Plugins(WithComponents(mockB), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("configsourcer")
+	require.NoError(err)
+	source := raw.(component.ConfigSourcerWatcher)
+	f := source.WatchFunc().(*argmapper.Func)
+	require.NotNil(f)
+
+	watcher := &component.ConfigWatcher{
+		Output: make(chan []*pb.ConfigSource_Value, 1),
+	}
+	result := f.Call(
+		argmapper.ConverterFunc(testDefaultMappers(t)...),
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(hclog.L()),
+		argmapper.Typed(watcher),
+	)
+	require.NoError(result.Err())
+
+	values := <-watcher.Output
+	require.Len(values, 1)
+	require.Equal("world", values[0].GetValue())
+}
+
+func TestConfigSourcerWatch_notImplemented(t *testing.T) {
+	require := require.New(t)
+
+	mockB := &mocks.ConfigSourcer{}
+
+	plugins := Plugins(WithComponents(mockB), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("configsourcer")
+	require.NoError(err)
+	source := raw.(component.ConfigSourcerWatcher)
+	require.Nil(source.WatchFunc())
+}
+
 func TestConfigSourcerConfig(t *testing.T) {
 	mockV := &mockConfigSourcerConfigurable{}
 	testConfigurable(t, "configsourcer", mockV, &mockV.Configurable)
 }
 
+func TestConfigSourcerDocumentation(t *testing.T) {
+	require := require.New(t)
+
+	d, err := docs.New(docs.RequestFromStruct(&struct {
+		Path string `hcl:"path"`
+	}{}))
+	require.NoError(err)
+	require.NoError(d.SetRequestField("path", "the path of the secret"))
+	d.AddMapper("hashicorp.waypoint.sdk.OCIRef", "hashicorp.waypoint.sdk.Secret",
+		"reads the credentials of the image")
+
+	mockV := &mockConfigSourcerDocumented{}
+	mockV.Documented.On("Documentation").Return(d, nil)
+
+	// A mapper that the plugin documented and one that it didn't
+	documentedMapper, err := argmapper.NewFunc(func(*pb.OCIRef) *pb.Secret { return nil })
+	require.NoError(err)
+	mapper, err := argmapper.NewFunc(func(*pb.StatusReport) *pb.OCIRef { return nil })
+	require.NoError(err)
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(
+		append(testDefaultMappers(t), documentedMapper, mapper)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("configsourcer")
+	require.NoError(err)
+	result, err := raw.(component.Documented).Documentation()
+	require.NoError(err)
+
+	fields := result.RequestFields()
+	require.Len(fields, 1)
+	require.Equal("path", fields[0].Field)
+	require.Equal("the path of the secret", fields[0].Synopsis)
+
+	// The built-in protomappers aren't documented.
+	mappers := result.Details().Mappers
+	require.Len(mappers, 2)
+	require.Equal("hashicorp.waypoint.sdk.OCIRef", mappers[0].Input)
+	require.Equal("hashicorp.waypoint.sdk.Secret", mappers[0].Output)
+	require.Equal("reads the credentials of the image", mappers[0].Description)
+	require.Equal(documentedMapper.Name(), mappers[0].Name)
+	require.Equal("hashicorp.waypoint.sdk.StatusReport", mappers[1].Input)
+	require.Equal("hashicorp.waypoint.sdk.OCIRef", mappers[1].Output)
+	require.Empty(mappers[1].Description)
+	require.Equal(mapper.Name(), mappers[1].Name)
+}
+
 type mockConfigSourcerAuthenticator struct {
 	mocks.ConfigSourcer
 	mocks.Authenticator
@@ -104,3 +259,13 @@ type mockConfigSourcerConfigurable struct {
 	mocks.ConfigSourcer
 	mocks.Configurable
 }
+
+type mockConfigSourcerWatcher struct {
+	mocks.ConfigSourcer
+	mocks.ConfigSourcerWatcher
+}
+
+type mockConfigSourcerDocumented struct {
+	mocks.ConfigSourcer
+	mocks.Documented
+}
@@ -3,7 +3,10 @@ package plugin
 import (
 	"context"
 	"encoding/json"
+	"strings"
 
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/protostructure"
 	"google.golang.org/grpc"
 	empty "google.golang.org/protobuf/types/known/emptypb"
@@ -81,13 +84,27 @@ func configure(impl interface{}, req *pb.Config_ConfigureRequest) (*empty.Empty,
 		return nil, err
 	}
 
+	// Keep the previous value so we can compute the changes.
+	var old interface{}
+	if _, ok := c.(component.ConfigurableNotifyDiff); ok {
+		old, err = component.CopyConfig(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Decode our JSON value directly into our structure.
 	if err := json.Unmarshal(req.Json, v); err != nil {
 		return nil, err
 	}
 
 	// If our client also implements the notify interface, call that.
-	if cn, ok := c.(component.ConfigurableNotify); ok {
+	// ConfigSetDiff takes precedence over ConfigSet.
+	if cn, ok := c.(component.ConfigurableNotifyDiff); ok {
+		if err := cn.ConfigSetDiff(v, component.DiffConfig(old, v)); err != nil {
+			return nil, err
+		}
+	} else if cn, ok := c.(component.ConfigurableNotify); ok {
 		if err := cn.ConfigSet(v); err != nil {
 			return nil, err
 		}
@@ -119,6 +136,17 @@ func convertFieldOut(f *docs.FieldDocs) *pb.Config_FieldDocumentation {
 		EnvVar:   f.EnvVar,
 		Optional: f.Optional,
 		Category: f.Category,
+
+		ValidValues:   f.ValidValues,
+		ConflictsWith: f.ConflictsWith,
+		RequiredWith:  f.RequiredWith,
+	}
+
+	if f.Range != nil {
+		fd.Range = &pb.Config_FieldDocumentation_Range{
+			Min: f.Range.Min,
+			Max: f.Range.Max,
+		}
 	}
 
 	for _, f := range f.SubFields {
@@ -131,7 +159,15 @@ func convertFieldOut(f *docs.FieldDocs) *pb.Config_FieldDocumentation {
 // documentation is the shared helper to implement the Documentation RPC call
 // for components. The logic is the same regardless of component so this can
 // be called instead.
-func documentation(impl interface{}) (*pb.Config_Documentation, error) {
+//
+// The mappers that the plugin advertises with ListMappers are included in
+// the documentation unless the component already documented a mapper with
+// the same input and output types.
+func documentation(
+	impl interface{},
+	mappers []*argmapper.Func,
+	logger hclog.Logger,
+) (*pb.Config_Documentation, error) {
 	d, err := component.Documentation(impl)
 	if err != nil {
 		return nil, err
@@ -147,6 +183,8 @@ func documentation(impl interface{}) (*pb.Config_Documentation, error) {
 		Fields:         make(map[string]*pb.Config_FieldDocumentation),
 		TemplateFields: make(map[string]*pb.Config_FieldDocumentation),
 		RequestFields:  make(map[string]*pb.Config_FieldDocumentation),
+
+		TemplateVersion: int32(dets.TemplateVersion),
 	}
 
 	for _, f := range d.Fields() {
@@ -166,6 +204,34 @@ func documentation(impl interface{}) (*pb.Config_Documentation, error) {
 			Input:       m.Input,
 			Output:      m.Output,
 			Description: m.Description,
+			Name:        m.Name,
+		})
+	}
+
+	for _, spec := range mapperSpecs(mappers, logger) {
+		input := strings.Join(specTypes(spec.Args), ", ")
+		output := strings.Join(specTypes(spec.Result), ", ")
+
+		documented := false
+		for _, m := range v.Mappers {
+			if m.Input == input && m.Output == output {
+				// Keep the plugin's description but fill in the name
+				if m.Name == "" {
+					m.Name = spec.Name
+				}
+
+				documented = true
+				break
+			}
+		}
+		if documented {
+			continue
+		}
+
+		v.Mappers = append(v.Mappers, &pb.Config_MapperDocumentation{
+			Input:  input,
+			Output: output,
+			Name:   spec.Name,
 		})
 	}
 
@@ -179,6 +245,11 @@ func convertFieldIn(f *pb.Config_FieldDocumentation) *docs.FieldDocs {
 		sf = append(sf, convertFieldIn(f))
 	}
 
+	var r *docs.FieldRange
+	if f.Range != nil {
+		r = docs.Range(f.Range.Min, f.Range.Max)
+	}
+
 	return &docs.FieldDocs{
 		Field:     f.Name,
 		Type:      f.Type,
@@ -189,6 +260,11 @@ func convertFieldIn(f *pb.Config_FieldDocumentation) *docs.FieldDocs {
 		EnvVar:    f.EnvVar,
 		Category:  f.Category,
 		SubFields: sf,
+
+		ValidValues:   f.ValidValues,
+		Range:         r,
+		ConflictsWith: f.ConflictsWith,
+		RequiredWith:  f.RequiredWith,
 	}
 }
 
@@ -209,6 +285,7 @@ func documentationCall(ctx context.Context, c configurableClient) (*docs.Documen
 	d.Description(resp.Description)
 	d.Input(resp.Input)
 	d.Output(resp.Output)
+	d.TemplateVersion(int(resp.TemplateVersion))
 
 	for _, f := range resp.Fields {
 		d.OverrideField(convertFieldIn(f))
@@ -223,7 +300,12 @@ func documentationCall(ctx context.Context, c configurableClient) (*docs.Documen
 	}
 
 	for _, m := range resp.Mappers {
-		d.AddMapper(m.Input, m.Output, m.Description)
+		d.AddMapperDocs(docs.Mapper{
+			Input:       m.Input,
+			Output:      m.Output,
+			Description: m.Description,
+			Name:        m.Name,
+		})
 	}
 
 	return d, nil
@@ -236,4 +318,6 @@ type configurableClient interface {
 	ConfigStruct(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.Config_StructResp, error)
 	Configure(context.Context, *pb.Config_ConfigureRequest, ...grpc.CallOption) (*empty.Empty, error)
 	Documentation(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.Config_Documentation, error)
+	ConfigSchema(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.Config_SchemaResp, error)
+	ConfigureValue(context.Context, *pb.Config_ConfigureValueRequest, ...grpc.CallOption) (*empty.Empty, error)
 }
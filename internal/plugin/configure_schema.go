@@ -0,0 +1,350 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// configSchema is the shared helper to implement the ConfigSchema RPC call
+// for components.
+func configSchema(impl interface{}) (*pb.Config_SchemaResp, error) {
+	c, ok := impl.(component.ConfigurableSchema)
+
+	// If ConfigurableSchema isn't implemented, we return an empty response
+	// and the host falls back to ConfigStruct.
+	if !ok {
+		return &pb.Config_SchemaResp{}, nil
+	}
+
+	spec, err := c.ConfigSchema()
+	if err != nil {
+		return nil, err
+	}
+	if spec == nil {
+		return &pb.Config_SchemaResp{}, nil
+	}
+
+	result, err := specToProto(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Config_SchemaResp{Spec: result}, nil
+}
+
+// configSchemaCall is the shared helper to call the ConfigSchema RPC call.
+// This returns a nil spec if the plugin has no explicit schema.
+func configSchemaCall(ctx context.Context, c configurableClient) (hcldec.Spec, error) {
+	resp, err := c.ConfigSchema(ctx, &empty.Empty{})
+	if err != nil {
+		// Plugins built with older SDKs don't have this RPC.
+		if status.Code(err) == codes.Unimplemented {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if resp.Spec == nil {
+		return nil, nil
+	}
+
+	return specFromProto(resp.Spec)
+}
+
+// configureValue is the shared helper to implement the ConfigureValue RPC.
+func configureValue(impl interface{}, req *pb.Config_ConfigureValueRequest) (*empty.Empty, error) {
+	c, ok := impl.(component.ConfigurableSchema)
+
+	// This should never happen since the host only calls this if we
+	// returned a spec from ConfigSchema.
+	if !ok {
+		return &empty.Empty{}, nil
+	}
+
+	v, err := ctyjson.Unmarshal(req.Value, cty.DynamicPseudoType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, c.ConfigSetValue(v)
+}
+
+// configureValueCall calls the ConfigureValue RPC endpoint.
+func configureValueCall(ctx context.Context, c configurableClient, v cty.Value) error {
+	value, err := ctyjson.Marshal(v, cty.DynamicPseudoType)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.ConfigureValue(ctx, &pb.Config_ConfigureValueRequest{
+		Value: value,
+	})
+	return err
+}
+
+// specToProto converts a hcldec.Spec to its protobuf representation. Only
+// the spec types that describe the structure of configuration are
+// supported.
+func specToProto(spec hcldec.Spec) (*pb.Config_Spec, error) {
+	switch s := spec.(type) {
+	case hcldec.ObjectSpec:
+		attrs := map[string]*pb.Config_Spec{}
+		for k, v := range s {
+			result, err := specToProto(v)
+			if err != nil {
+				return nil, err
+			}
+
+			attrs[k] = result
+		}
+
+		return &pb.Config_Spec{Spec: &pb.Config_Spec_Object_{
+			Object: &pb.Config_Spec_Object{Attributes: attrs},
+		}}, nil
+
+	case *hcldec.AttrSpec:
+		typ, err := ctyjson.MarshalType(s.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.Config_Spec{Spec: &pb.Config_Spec_Attr_{
+			Attr: &pb.Config_Spec_Attr{
+				Name:     s.Name,
+				Type:     typ,
+				Required: s.Required,
+			},
+		}}, nil
+
+	case *hcldec.BlockSpec:
+		nested, err := specToProto(s.Nested)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.Config_Spec{Spec: &pb.Config_Spec_Block_{
+			Block: &pb.Config_Spec_Block{
+				TypeName: s.TypeName,
+				Nested:   nested,
+				Required: s.Required,
+			},
+		}}, nil
+
+	case *hcldec.BlockListSpec:
+		nested, err := specToProto(s.Nested)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.Config_Spec{Spec: &pb.Config_Spec_BlockList_{
+			BlockList: &pb.Config_Spec_BlockList{
+				TypeName: s.TypeName,
+				Nested:   nested,
+				MinItems: int64(s.MinItems),
+				MaxItems: int64(s.MaxItems),
+			},
+		}}, nil
+
+	case *hcldec.BlockSetSpec:
+		nested, err := specToProto(s.Nested)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.Config_Spec{Spec: &pb.Config_Spec_BlockSet{
+			BlockSet: &pb.Config_Spec_BlockList{
+				TypeName: s.TypeName,
+				Nested:   nested,
+				MinItems: int64(s.MinItems),
+				MaxItems: int64(s.MaxItems),
+			},
+		}}, nil
+
+	case *hcldec.BlockMapSpec:
+		nested, err := specToProto(s.Nested)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.Config_Spec{Spec: &pb.Config_Spec_BlockMap_{
+			BlockMap: &pb.Config_Spec_BlockMap{
+				TypeName:   s.TypeName,
+				LabelNames: s.LabelNames,
+				Nested:     nested,
+			},
+		}}, nil
+
+	case *hcldec.BlockAttrsSpec:
+		typ, err := ctyjson.MarshalType(s.ElementType)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.Config_Spec{Spec: &pb.Config_Spec_BlockAttrs_{
+			BlockAttrs: &pb.Config_Spec_BlockAttrs{
+				TypeName:    s.TypeName,
+				ElementType: typ,
+				Required:    s.Required,
+			},
+		}}, nil
+
+	case *hcldec.LiteralSpec:
+		value, err := ctyjson.Marshal(s.Value, cty.DynamicPseudoType)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.Config_Spec{Spec: &pb.Config_Spec_Literal_{
+			Literal: &pb.Config_Spec_Literal{Value: value},
+		}}, nil
+
+	case *hcldec.DefaultSpec:
+		primary, err := specToProto(s.Primary)
+		if err != nil {
+			return nil, err
+		}
+
+		def, err := specToProto(s.Default)
+		if err != nil {
+			return nil, err
+		}
+
+		return &pb.Config_Spec{Spec: &pb.Config_Spec_Default_{
+			Default: &pb.Config_Spec_Default{
+				Primary: primary,
+				Default: def,
+			},
+		}}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported config spec type: %T", spec)
+	}
+}
+
+// specFromProto converts the protobuf representation of a spec back to a
+// hcldec.Spec.
+func specFromProto(spec *pb.Config_Spec) (hcldec.Spec, error) {
+	switch s := spec.Spec.(type) {
+	case *pb.Config_Spec_Object_:
+		result := hcldec.ObjectSpec{}
+		for k, v := range s.Object.Attributes {
+			attr, err := specFromProto(v)
+			if err != nil {
+				return nil, err
+			}
+
+			result[k] = attr
+		}
+
+		return result, nil
+
+	case *pb.Config_Spec_Attr_:
+		typ, err := ctyjson.UnmarshalType(s.Attr.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		return &hcldec.AttrSpec{
+			Name:     s.Attr.Name,
+			Type:     typ,
+			Required: s.Attr.Required,
+		}, nil
+
+	case *pb.Config_Spec_Block_:
+		nested, err := specFromProto(s.Block.Nested)
+		if err != nil {
+			return nil, err
+		}
+
+		return &hcldec.BlockSpec{
+			TypeName: s.Block.TypeName,
+			Nested:   nested,
+			Required: s.Block.Required,
+		}, nil
+
+	case *pb.Config_Spec_BlockList_:
+		nested, err := specFromProto(s.BlockList.Nested)
+		if err != nil {
+			return nil, err
+		}
+
+		return &hcldec.BlockListSpec{
+			TypeName: s.BlockList.TypeName,
+			Nested:   nested,
+			MinItems: int(s.BlockList.MinItems),
+			MaxItems: int(s.BlockList.MaxItems),
+		}, nil
+
+	case *pb.Config_Spec_BlockSet:
+		nested, err := specFromProto(s.BlockSet.Nested)
+		if err != nil {
+			return nil, err
+		}
+
+		return &hcldec.BlockSetSpec{
+			TypeName: s.BlockSet.TypeName,
+			Nested:   nested,
+			MinItems: int(s.BlockSet.MinItems),
+			MaxItems: int(s.BlockSet.MaxItems),
+		}, nil
+
+	case *pb.Config_Spec_BlockMap_:
+		nested, err := specFromProto(s.BlockMap.Nested)
+		if err != nil {
+			return nil, err
+		}
+
+		return &hcldec.BlockMapSpec{
+			TypeName:   s.BlockMap.TypeName,
+			LabelNames: s.BlockMap.LabelNames,
+			Nested:     nested,
+		}, nil
+
+	case *pb.Config_Spec_BlockAttrs_:
+		typ, err := ctyjson.UnmarshalType(s.BlockAttrs.ElementType)
+		if err != nil {
+			return nil, err
+		}
+
+		return &hcldec.BlockAttrsSpec{
+			TypeName:    s.BlockAttrs.TypeName,
+			ElementType: typ,
+			Required:    s.BlockAttrs.Required,
+		}, nil
+
+	case *pb.Config_Spec_Literal_:
+		value, err := ctyjson.Unmarshal(s.Literal.Value, cty.DynamicPseudoType)
+		if err != nil {
+			return nil, err
+		}
+
+		return &hcldec.LiteralSpec{Value: value}, nil
+
+	case *pb.Config_Spec_Default_:
+		primary, err := specFromProto(s.Default.Primary)
+		if err != nil {
+			return nil, err
+		}
+
+		def, err := specFromProto(s.Default.Default)
+		if err != nil {
+			return nil, err
+		}
+
+		return &hcldec.DefaultSpec{Primary: primary, Default: def}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported config spec type: %T", spec.Spec)
+	}
+}
@@ -4,11 +4,15 @@ import (
 	"testing"
 
 	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/hcl/v2/hcldec"
 	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint-plugin-sdk/docs"
 )
 
 // testConfigurable is a reusable helper that tests that a component implements
@@ -42,3 +46,127 @@ func testConfigurable(
 	require.False(diag.HasErrors())
 	require.Equal("foo", config.Name)
 }
+
+func TestConvertField_validation(t *testing.T) {
+	require := require.New(t)
+
+	d, err := docs.New()
+	require.NoError(err)
+	require.NoError(d.SetField("mode", "the mode",
+		docs.ValidValues{"fast", "slow"},
+		docs.ConflictsWith{"turbo"},
+	))
+	require.NoError(d.SetField("count", "the count",
+		docs.Range(1, 10),
+		docs.RequiredWith{"mode"},
+	))
+
+	for _, f := range d.Fields() {
+		require.Equal(f, convertFieldIn(convertFieldOut(f)))
+	}
+}
+
+func TestConfigurableSchema(t *testing.T) {
+	require := require.New(t)
+
+	spec := hcldec.ObjectSpec{
+		"labels": &hcldec.BlockAttrsSpec{
+			TypeName:    "labels",
+			ElementType: cty.String,
+		},
+		"port": &hcldec.DefaultSpec{
+			Primary: &hcldec.AttrSpec{Name: "port", Type: cty.Number},
+			Default: &hcldec.LiteralSpec{Value: cty.NumberIntVal(80)},
+		},
+	}
+
+	var value cty.Value
+	mockV := &mockBuilderConfigurableSchema{}
+	mockV.ConfigurableSchema.On("ConfigSchema").Return(spec, nil)
+	mockV.ConfigurableSchema.On("ConfigSetValue", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		value = args.Get(0).(cty.Value)
+	})
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("builder")
+	require.NoError(err)
+
+	src := `
+labels {
+  env = "prod"
+}
+`
+	f, diag := hclparse.NewParser().ParseHCL([]byte(src), "test.hcl")
+	require.False(diag.HasErrors())
+
+	diag = component.Configure(raw, f.Body, nil)
+	require.False(diag.HasErrors())
+	require.True(value.GetAttr("port").RawEquals(cty.NumberIntVal(80)))
+	require.Equal("prod", value.GetAttr("labels").Index(cty.StringVal("env")).AsString())
+}
+
+func TestConfigurableSchema_none(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mockBuilderConfigurable{}
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("builder")
+	require.NoError(err)
+
+	spec, err := raw.(component.ConfigurableSchema).ConfigSchema()
+	require.NoError(err)
+	require.Nil(spec)
+}
+
+func TestSpecToProto(t *testing.T) {
+	require := require.New(t)
+
+	spec := hcldec.ObjectSpec{
+		"name": &hcldec.AttrSpec{Name: "name", Type: cty.String, Required: true},
+		"tags": &hcldec.AttrSpec{Name: "tags", Type: cty.List(cty.String)},
+		"network": &hcldec.BlockSpec{
+			TypeName: "network",
+			Nested: hcldec.ObjectSpec{
+				"cidr": &hcldec.AttrSpec{Name: "cidr", Type: cty.String},
+			},
+		},
+		"volume": &hcldec.BlockListSpec{
+			TypeName: "volume",
+			Nested:   &hcldec.AttrSpec{Name: "path", Type: cty.String},
+			MaxItems: 3,
+		},
+		"port": &hcldec.BlockSetSpec{
+			TypeName: "port",
+			Nested:   &hcldec.AttrSpec{Name: "number", Type: cty.Number},
+			MinItems: 1,
+		},
+		"service": &hcldec.BlockMapSpec{
+			TypeName:   "service",
+			LabelNames: []string{"name"},
+			Nested:     &hcldec.AttrSpec{Name: "image", Type: cty.String},
+		},
+	}
+
+	result, err := specToProto(spec)
+	require.NoError(err)
+
+	actual, err := specFromProto(result)
+	require.NoError(err)
+	require.Equal(spec, actual)
+
+	_, err = specToProto(&hcldec.ExprSpec{})
+	require.Error(err)
+}
+
+type mockBuilderConfigurableSchema struct {
+	mocks.Builder
+	mocks.ConfigurableSchema
+}
@@ -0,0 +1,158 @@
+package configwatch
+
+import (
+	"context"
+	"io"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// ConfigWatcherPlugin implements plugin.Plugin (specifically GRPCPlugin) for
+// the component.ConfigWatcher type.
+type ConfigWatcherPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+
+	Impl     *component.ConfigWatcher // Impl is the concrete implementation
+	Mappers  []*argmapper.Func        // Mappers
+	Logger   hclog.Logger             // Logger
+	Internal *pluginargs.Internal     // Internal sets up the client connection
+
+	// Cleanup is used by the client to flush any pending updates and
+	// close the stream once the plugin call is complete.
+	Cleanup *pluginargs.Cleanup
+}
+
+func (p *ConfigWatcherPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	pb.RegisterConfigWatcherServer(s, &configWatcherServer{
+		Impl:    p.Impl,
+		Mappers: p.Mappers,
+		Logger:  p.Logger,
+	})
+	return nil
+}
+
+func (p *ConfigWatcherPlugin) GRPCClient(
+	ctx context.Context,
+	broker *plugin.GRPCBroker,
+	c *grpc.ClientConn,
+) (interface{}, error) {
+	p.Logger.Debug("starting configwatcher client")
+
+	client := pb.NewConfigWatcherClient(p.Internal.ClientConn(c))
+	stream, err := client.Update(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Output is unbuffered so that once a send to it completes, the only
+	// value that can be in flight is the one the goroutine below is
+	// currently sending.
+	output := make(chan []*pb.ConfigSource_Value)
+	stopCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	closeStream := func() {
+		if _, err := stream.CloseAndRecv(); err != nil && err != io.EOF {
+			p.Logger.Warn("error closing configwatcher stream", "err", err)
+		}
+	}
+	go func() {
+		defer close(doneCh)
+
+		// If sending fails we keep receiving from output until we're
+		// stopped so that the plugin never blocks sending to it.
+		failed := false
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-stopCh:
+				if failed {
+					return
+				}
+
+				closeStream()
+				return
+
+			case values := <-output:
+				if failed {
+					p.Logger.Debug("configwatcher stream failed, dropping config update")
+					continue
+				}
+
+				// Don't send if we were stopped while waiting for values.
+				select {
+				case <-ctx.Done():
+					return
+				case <-stopCh:
+					closeStream()
+					return
+				default:
+				}
+
+				err := stream.Send(&pb.ConfigSource_WatchUpdate{Values: values})
+				if err != nil {
+					p.Logger.Warn("error sending config update", "err", err)
+					failed = true
+				}
+			}
+		}
+	}()
+
+	if p.Cleanup != nil {
+		p.Cleanup.Do(func() {
+			close(stopCh)
+			<-doneCh
+		})
+	}
+
+	return &component.ConfigWatcher{
+		Output: output,
+	}, nil
+}
+
+// configWatcherServer is a gRPC server that the client talks to and sends
+// updates to the real component.ConfigWatcher.
+type configWatcherServer struct {
+	pb.UnimplementedConfigWatcherServer
+
+	Impl    *component.ConfigWatcher
+	Mappers []*argmapper.Func
+	Logger  hclog.Logger
+}
+
+func (s *configWatcherServer) Update(stream pb.ConfigWatcher_UpdateServer) error {
+	s.Logger.Debug("starting configwatcher update rpc")
+	defer s.Logger.Debug("ending configwatcher update rpc")
+
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&empty.Empty{})
+		}
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case s.Impl.Output <- update.Values:
+			// ok
+		}
+	}
+}
+
+var (
+	_ plugin.Plugin          = (*ConfigWatcherPlugin)(nil)
+	_ plugin.GRPCPlugin      = (*ConfigWatcherPlugin)(nil)
+	_ pb.ConfigWatcherServer = (*configWatcherServer)(nil)
+)
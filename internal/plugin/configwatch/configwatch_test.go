@@ -0,0 +1,68 @@
+package configwatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+func TestConfigWatcherPlugin(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Host side
+	hostOutput := make(chan []*pb.ConfigSource_Value)
+	host := &ConfigWatcherPlugin{
+		Impl:   &component.ConfigWatcher{Output: hostOutput},
+		Logger: hclog.L(),
+	}
+
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		require.NoError(host.GRPCServer(nil, s))
+	})
+	defer conn.Close()
+
+	// Plugin side
+	var cleanup pluginargs.Cleanup
+	client := &ConfigWatcherPlugin{
+		Logger:  hclog.L(),
+		Cleanup: &cleanup,
+	}
+
+	raw, err := client.GRPCClient(ctx, nil, conn)
+	require.NoError(err)
+	cw := raw.(*component.ConfigWatcher)
+
+	value := []*pb.ConfigSource_Value{{Name: "DATABASE_URL"}}
+	cw.Output <- value
+	select {
+	case values := <-hostOutput:
+		require.Len(values, 1)
+		require.Equal("DATABASE_URL", values[0].Name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for update")
+	}
+
+	// Once the host goes away, sending updates must not block the plugin
+	server.Stop()
+	for i := 0; i < 10; i++ {
+		select {
+		case cw.Output <- value:
+		case <-time.After(5 * time.Second):
+			t.Fatal("sending update blocked")
+		}
+	}
+
+	require.NoError(cleanup.Close())
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
@@ -31,6 +33,12 @@ func (c *destroyerClient) Implements(ctx context.Context) (bool, error) {
 
 	resp, err := c.Client.IsDestroyer(ctx, &empty.Empty{})
 	if err != nil {
+		// Builders and registries built with an SDK before they could
+		// destroy resources don't implement the RPC.
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+
 		return false, err
 	}
 
@@ -84,6 +92,71 @@ func (c *destroyerClient) destroy(
 	return nil
 }
 
+func (c *destroyerClient) PreDestroyFunc() interface{} {
+	if c == nil {
+		return nil
+	}
+
+	return c.hookFunc(c.Client.PreDestroySpec, c.Client.PreDestroy)
+}
+
+func (c *destroyerClient) PostDestroyFunc() interface{} {
+	if c == nil {
+		return nil
+	}
+
+	return c.hookFunc(c.Client.PostDestroySpec, c.Client.PostDestroy)
+}
+
+// hookFunc returns the function for a destroy hook given the RPCs to get
+// its spec and call it. This returns nil if the plugin doesn't implement
+// the hook.
+func (c *destroyerClient) hookFunc(
+	specFunc func(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error),
+	callFunc func(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (*empty.Empty, error),
+) interface{} {
+	impl, err := c.Implements(context.Background())
+	if err != nil {
+		return funcErr(err)
+	}
+	if !impl {
+		return nil
+	}
+
+	// Get the spec. Plugins that don't implement the hook, including
+	// plugins built against older SDKs, return Unimplemented.
+	spec, err := specFunc(context.Background(), &empty.Empty{})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err != nil {
+		return funcErr(err)
+	}
+
+	// We don't want to be a mapper
+	spec.Result = nil
+
+	return funcspec.Func(spec, func(
+		ctx context.Context,
+		args funcspec.Args,
+		internal *pluginargs.Internal,
+	) error {
+		// Run the cleanup
+		defer internal.Cleanup.Close()
+
+		// Call our function
+		_, err := callFunc(ctx, &pb.FuncSpec_Args{Args: args})
+		return err
+	},
+		argmapper.Logger(c.Logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.Broker,
+			Mappers: c.Mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
 // destroyerServer implements the common Destroyer-related RPC calls.
 // This should be embedded into the service implementation.
 type destroyerServer struct {
@@ -124,8 +197,8 @@ func (s *destroyerServer) Destroy(
 	declaredResourcesResp := &component.DeclaredResourcesResp{}
 	destroyedResourcesResp := &component.DestroyedResourcesResp{}
 
-	_, err := callDynamicFunc2(s.Impl.(component.Destroyer).DestroyFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	_, err := callDynamicFunc2(ctx, s.Impl.(component.Destroyer).DestroyFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Typed(internal),
 		argmapper.Typed(ctx),
 		argmapper.Typed(declaredResourcesResp),
@@ -145,14 +218,99 @@ func (s *destroyerServer) Destroy(
 	}, nil
 }
 
+func (s *destroyerServer) PreDestroySpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	return s.hookSpec(s.preDestroyFunc())
+}
+
+func (s *destroyerServer) PreDestroy(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*empty.Empty, error) {
+	return s.hookCall(ctx, s.preDestroyFunc(), args)
+}
+
+func (s *destroyerServer) PostDestroySpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	return s.hookSpec(s.postDestroyFunc())
+}
+
+func (s *destroyerServer) PostDestroy(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*empty.Empty, error) {
+	return s.hookCall(ctx, s.postDestroyFunc(), args)
+}
+
+func (s *destroyerServer) preDestroyFunc() interface{} {
+	if h, ok := s.Impl.(component.DestroyHooks); ok {
+		return h.PreDestroyFunc()
+	}
+
+	return nil
+}
+
+func (s *destroyerServer) postDestroyFunc() interface{} {
+	if h, ok := s.Impl.(component.DestroyHooks); ok {
+		return h.PostDestroyFunc()
+	}
+
+	return nil
+}
+
+func (s *destroyerServer) hookSpec(f interface{}) (*pb.FuncSpec, error) {
+	if f == nil {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: DestroyHooks")
+	}
+
+	return funcspec.Spec(f,
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+	)
+}
+
+func (s *destroyerServer) hookCall(
+	ctx context.Context,
+	f interface{},
+	args *pb.FuncSpec_Args,
+) (*empty.Empty, error) {
+	if f == nil {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: DestroyHooks")
+	}
+
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	_, err := callDynamicFunc2(ctx, f, args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Typed(internal),
+		argmapper.Typed(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
 // destroyerProtoClient is the interface we expect any gRPC service that
 // supports destroy to implement.
 type destroyerProtoClient interface {
 	IsDestroyer(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ImplementsResp, error)
 	DestroySpec(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error)
 	Destroy(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (*pb.Destroy_Resp, error)
+	PreDestroySpec(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error)
+	PreDestroy(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (*empty.Empty, error)
+	PostDestroySpec(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error)
+	PostDestroy(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (*empty.Empty, error)
 }
 
 var (
-	_ component.Destroyer = (*destroyerClient)(nil)
+	_ component.Destroyer    = (*destroyerClient)(nil)
+	_ component.DestroyHooks = (*destroyerClient)(nil)
 )
@@ -7,6 +7,8 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
@@ -31,6 +33,12 @@ func (c *workspaceDestroyerClient) Implements(ctx context.Context) (bool, error)
 
 	resp, err := c.Client.IsWorkspaceDestroyer(ctx, &empty.Empty{})
 	if err != nil {
+		// Builders and registries built with an SDK before they could
+		// destroy resources don't implement the RPC.
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+
 		return false, err
 	}
 
@@ -111,8 +119,8 @@ func (s *workspaceDestroyerServer) DestroyWorkspace(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	_, err := callDynamicFunc2(s.Impl.(component.WorkspaceDestroyer).DestroyWorkspaceFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	_, err := callDynamicFunc2(ctx, s.Impl.(component.WorkspaceDestroyer).DestroyWorkspaceFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Typed(internal),
 		argmapper.Typed(ctx),
 	)
@@ -0,0 +1,85 @@
+package plugin
+
+import (
+	"context"
+	"io"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
+)
+
+// diagnosticsConn returns a connection that logs the warning diagnostics
+// the plugin sends in the trailer of calls that succeed, see
+// callDynamicFuncResults. Warnings of calls that fail are available from
+// the error with diag.FromError.
+func diagnosticsConn(c grpc.ClientConnInterface, logger hclog.Logger) grpc.ClientConnInterface {
+	return &diagConn{conn: c, logger: logger}
+}
+
+type diagConn struct {
+	conn   grpc.ClientConnInterface
+	logger hclog.Logger
+}
+
+func (c *diagConn) Invoke(
+	ctx context.Context,
+	method string,
+	args, reply interface{},
+	opts ...grpc.CallOption,
+) error {
+	var md metadata.MD
+	err := c.conn.Invoke(ctx, method, args, reply, append(opts, grpc.Trailer(&md))...)
+	if err == nil {
+		c.log(method, md)
+	}
+
+	return err
+}
+
+func (c *diagConn) NewStream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	method string,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	stream, err := c.conn.NewStream(ctx, desc, method, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &diagClientStream{ClientStream: stream, conn: c, method: method}, nil
+}
+
+// log logs the diagnostics in the trailer md of a call to method.
+func (c *diagConn) log(method string, md metadata.MD) {
+	if c.logger == nil {
+		return
+	}
+
+	for _, d := range diag.FromTrailer(md) {
+		c.logger.Warn("plugin returned a warning", "method", method, "warning", d.String())
+	}
+}
+
+// diagClientStream logs the diagnostics in the trailer of a stream once
+// the stream completes successfully.
+type diagClientStream struct {
+	grpc.ClientStream
+
+	conn   *diagConn
+	method string
+}
+
+func (s *diagClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == io.EOF {
+		s.conn.log(s.method, s.Trailer())
+	}
+
+	return err
+}
+
+var _ grpc.ClientConnInterface = (*diagConn)(nil)
@@ -1,15 +1,20 @@
 package plugin
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/opaqueany"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoregistry"
 
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
@@ -18,61 +23,58 @@ import (
 // given input arguments. This is a helper that is expected to be used
 // by most component gRPC servers to implement their function calls.
 func callDynamicFunc2(
+	ctx context.Context,
 	f interface{},
 	args funcspec.Args,
 	callArgs ...argmapper.Arg,
 ) (interface{}, error) {
+	return callDynamicFuncWarnings(ctx, f, args, nil, callArgs...)
+}
+
+// callDynamicFuncWarnings is callDynamicFunc that makes warnings available
+// to the function and adds the warning diagnostics it returns to them. If
+// warnings is nil, this is the same as callDynamicFunc.
+func callDynamicFuncWarnings(
+	ctx context.Context,
+	f interface{},
+	args funcspec.Args,
+	warnings *component.Warnings,
+	callArgs ...argmapper.Arg,
+) (interface{}, error) {
+	results, err := callDynamicFuncResults(ctx, f, args, warnings, callArgs...)
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+
+	return results[0], nil
+}
+
+// callDynamicFuncResults is callDynamicFuncWarnings that returns all the
+// results of the function other than diagnostics and errors, in order.
+//
+// If warnings is nil, the warning diagnostics the function returns are
+// sent to the host in the trailer of the call with ctx instead.
+func callDynamicFuncResults(
+	ctx context.Context,
+	f interface{},
+	args funcspec.Args,
+	warnings *component.Warnings,
+	callArgs ...argmapper.Arg,
+) ([]interface{}, error) {
+	if warnings != nil {
+		callArgs = append(callArgs, argmapper.Typed(warnings))
+	}
+
 	// Decode our *opaqueany.Any values.
 	for _, arg := range args {
 		var value interface{}
 		var err error
-		switch v := arg.Value.(type) {
+		switch arg.Value.(type) {
 		case *pb.FuncSpec_Value_ProtoAny:
 			value, err = argProtoAny(arg)
 
-		case *pb.FuncSpec_Value_Bool:
-			value = v.Bool
-
-		case *pb.FuncSpec_Value_Int:
-			switch arg.PrimitiveType {
-			case pb.FuncSpec_Value_INT8:
-				value = int8(v.Int)
-			case pb.FuncSpec_Value_INT16:
-				value = int16(v.Int)
-			case pb.FuncSpec_Value_INT32:
-				value = int32(v.Int)
-			case pb.FuncSpec_Value_INT64:
-				value = int64(v.Int)
-			case pb.FuncSpec_Value_INT:
-				fallthrough
-			default:
-				// Fallback to int as a default
-				value = int(v.Int)
-			}
-
-		case *pb.FuncSpec_Value_Uint:
-			switch arg.PrimitiveType {
-			case pb.FuncSpec_Value_INT8:
-				value = uint8(v.Uint)
-			case pb.FuncSpec_Value_INT16:
-				value = uint16(v.Uint)
-			case pb.FuncSpec_Value_INT32:
-				value = uint32(v.Uint)
-			case pb.FuncSpec_Value_INT64:
-				value = uint64(v.Uint)
-			case pb.FuncSpec_Value_INT:
-				fallthrough
-			default:
-				// Fallback to uint as a default
-				value = uint(v.Uint)
-			}
-
-		case *pb.FuncSpec_Value_String_:
-			value = v.String_
-
 		default:
-			return nil, fmt.Errorf("internal error! invalid argument value: %#v",
-				arg.Value)
+			value, err = funcspec.DecodePrimitive(arg)
 		}
 		if err != nil {
 			return nil, err
@@ -90,20 +92,95 @@ func callDynamicFunc2(
 
 	result := mapF.Call(callArgs...)
 	if err := result.Err(); err != nil {
-		return nil, err
+		return nil, withWarnings(err, warnings)
+	}
+
+	// Functions may return diagnostics in addition to their result. If
+	// there are any errors we return them with the diagnostics attached
+	// as status details so the host can render them.
+	var out []interface{}
+	var diags diag.Diagnostics
+	for i := 0; i < result.Len(); i++ {
+		switch v := result.Out(i).(type) {
+		case diag.Diagnostics:
+			diags = append(diags, v...)
+
+		case []diag.Diagnostic:
+			diags = append(diags, v...)
+
+		default:
+			out = append(out, v)
+		}
+	}
+	if err := diags.Err(); err != nil {
+		return nil, withWarnings(err, warnings)
+	}
+	if warnings != nil {
+		warnings.AddDiagnostics(diags...)
+	} else if len(diags) > 0 {
+		// This only fails if ctx isn't the context of an RPC, in which
+		// case there is no host to send the warnings to.
+		_ = grpc.SetTrailer(ctx, diags.Trailer())
+	}
+
+	return out, nil
+}
+
+// withWarnings returns err with the warnings added so far attached as
+// status details, so the host gets them with diag.FromError even though
+// the call failed. This returns err if there are no warnings.
+func withWarnings(err error, warnings *component.Warnings) error {
+	if warnings == nil {
+		return err
+	}
+
+	ds := warnings.Warnings()
+	if len(ds) == 0 {
+		return err
 	}
 
-	return result.Out(0), nil
+	st := status.Convert(err)
+	withDetails, derr := st.WithDetails(ds.Proto())
+	if derr != nil {
+		return err
+	}
+
+	return withDetails.Err()
+}
+
+// CallDynamicFunc calls the dynamic function f with the arguments sent by
+// the host, like the component servers in this package do. This is used
+// by servers of custom component types, see the componentkit package.
+func CallDynamicFunc(
+	ctx context.Context,
+	f interface{},
+	args funcspec.Args,
+	callArgs ...argmapper.Arg,
+) (interface{}, error) {
+	return callDynamicFunc2(ctx, f, args, callArgs...)
 }
 
 // callDynamicFuncAny is callDynamicFunc that automatically encodes the
 // result to an *opaqueany.Any.
 func callDynamicFuncAny2(
+	ctx context.Context,
+	f interface{},
+	args funcspec.Args,
+	callArgs ...argmapper.Arg,
+) (*opaqueany.Any, string, interface{}, error) {
+	return callDynamicFuncAnyWarnings(ctx, f, args, nil, callArgs...)
+}
+
+// callDynamicFuncAnyWarnings is callDynamicFuncWarnings that automatically
+// encodes the result like callDynamicFuncAny.
+func callDynamicFuncAnyWarnings(
+	ctx context.Context,
 	f interface{},
 	args funcspec.Args,
+	warnings *component.Warnings,
 	callArgs ...argmapper.Arg,
 ) (*opaqueany.Any, string, interface{}, error) {
-	result, err := callDynamicFunc2(f, args, callArgs...)
+	result, err := callDynamicFuncWarnings(ctx, f, args, warnings, callArgs...)
 	if err != nil {
 		return nil, "", nil, err
 	}
@@ -125,7 +202,7 @@ func callDynamicFuncAny2(
 		return nil, "", nil, err
 	}
 
-	anyJson, err := protojson.Marshal(msg)
+	anyJson, err := protojson.Marshal(component.RedactSecrets(msg))
 	if err != nil {
 		return nil, "", nil, err
 	}
@@ -8,9 +8,12 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
 
@@ -19,9 +22,10 @@ import (
 type ExecPlugin struct {
 	plugin.NetRPCUnsupportedPlugin
 
-	Impl    *component.ExecSessionInfo // Impl is the concrete implementation
-	Mappers []*argmapper.Func          // Mappers
-	Logger  hclog.Logger               // Logger
+	Impl     *component.ExecSessionInfo // Impl is the concrete implementation
+	Mappers  []*argmapper.Func          // Mappers
+	Logger   hclog.Logger               // Logger
+	Internal *pluginargs.Internal       // Internal sets up the client connection
 }
 
 func (p *ExecPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
@@ -56,7 +60,7 @@ func (p *ExecPlugin) GRPCClient(
 	broker *plugin.GRPCBroker,
 	c *grpc.ClientConn,
 ) (interface{}, error) {
-	client := pb.NewExecSessionServiceClient(c)
+	client := pb.NewExecSessionServiceClient(p.Internal.ClientConn(c))
 
 	input, err := client.Input(ctx, &empty.Empty{})
 	if err != nil {
@@ -65,8 +69,12 @@ func (p *ExecPlugin) GRPCClient(
 
 	stdinR, stdinW := io.Pipe()
 
-	// 2 is picked randomly, just to try not to block.
+	// 2 is picked randomly, just to try not to block. The plugin may not
+	// read these at all, so sends to them never block the loop below,
+	// which would hold up stdin.
 	wsUpdates := make(chan component.WindowSize, 2)
+	envUpdates := make(chan []string, 2)
+	signals := make(chan string, 2)
 
 	go func() {
 		for {
@@ -78,7 +86,9 @@ func (p *ExecPlugin) GRPCClient(
 
 			switch v := req.Input.(type) {
 			case *pb.ExecSession_InputRequest_Data:
-				p.Logger.Debug("exec plugin client, input received", "input", v.Data)
+				// The input is whatever the user typed, such as passwords, so
+				// only the size is logged.
+				p.Logger.Debug("exec plugin client, input received", "bytes", len(v.Data))
 
 				stdinW.Write(v.Data)
 			case *pb.ExecSession_InputRequest_WindowSize:
@@ -86,14 +96,45 @@ func (p *ExecPlugin) GRPCClient(
 					Height: int(v.WindowSize.Height),
 					Width:  int(v.WindowSize.Width),
 				}
+
+				// Only the latest size matters, so replace the oldest
+				// update if the plugin is behind.
 				select {
-				case <-ctx.Done():
-					return
 				case wsUpdates <- update:
-					// ok
+				default:
+					// This is the only sender, so there is room once
+					// the oldest update is dropped.
+					select {
+					case <-wsUpdates:
+					default:
+					}
+					wsUpdates <- update
 				}
 			case *pb.ExecSession_InputRequest_InputClosed:
 				stdinW.Close()
+			case *pb.ExecSession_InputRequest_Signal:
+				p.Logger.Debug("exec plugin client, signal received", "signal", v.Signal)
+				select {
+				case signals <- v.Signal:
+					// ok
+				default:
+					p.Logger.Warn("exec plugin not reading signals, dropping signal",
+						"signal", v.Signal)
+				}
+			case *pb.ExecSession_InputRequest_Env:
+				// Each update replaces the environment entirely, so replace
+				// the oldest update if the plugin is behind.
+				select {
+				case envUpdates <- v.Env.Env:
+				default:
+					// This is the only sender, so there is room once
+					// the oldest update is dropped.
+					select {
+					case <-envUpdates:
+					default:
+					}
+					envUpdates <- v.Env.Env
+				}
 			}
 		}
 	}()
@@ -109,7 +150,15 @@ func (p *ExecPlugin) GRPCClient(
 			stderr: true,
 			client: client,
 		},
-		WindowSizeUpdates: wsUpdates,
+		WindowSizeUpdates:  wsUpdates,
+		EnvironmentUpdates: envUpdates,
+		Signals:            signals,
+		Exit: func(code int) error {
+			_, err := client.Exit(ctx, &pb.ExecSession_ExitRequest{
+				ExitCode: int32(code),
+			})
+			return err
+		},
 	}
 
 	return esi, nil
@@ -140,6 +189,17 @@ func (s *execServer) Output(
 	return &empty.Empty{}, err
 }
 
+func (s *execServer) Exit(
+	ctx context.Context,
+	req *pb.ExecSession_ExitRequest,
+) (*empty.Empty, error) {
+	if s.Impl.Exit == nil {
+		return nil, status.Error(codes.Unimplemented, "exit status is not supported")
+	}
+
+	return &empty.Empty{}, s.Impl.Exit(int(req.ExitCode))
+}
+
 func (s *execServer) Input(_ *empty.Empty, stream pb.ExecSessionService_InputServer) error {
 	s.Logger.Trace("starting exec server input")
 
@@ -172,6 +232,12 @@ func (s *execServer) Input(_ *empty.Empty, stream pb.ExecSessionService_InputSer
 		}
 	}()
 
+	// Closed update channels are set to nil so that they're no longer
+	// selected.
+	wsUpdates := s.Impl.WindowSizeUpdates
+	signals := s.Impl.Signals
+	envUpdates := s.Impl.EnvironmentUpdates
+
 	for {
 		select {
 		case <-stream.Context().Done():
@@ -192,7 +258,12 @@ func (s *execServer) Input(_ *empty.Empty, stream pb.ExecSessionService_InputSer
 			if err != nil {
 				return err
 			}
-		case ws := <-s.Impl.WindowSizeUpdates:
+		case ws, ok := <-wsUpdates:
+			if !ok {
+				wsUpdates = nil
+				continue
+			}
+
 			err := stream.Send(&pb.ExecSession_InputRequest{
 				Input: &pb.ExecSession_InputRequest_WindowSize{
 					WindowSize: &pb.WindowSize{
@@ -204,6 +275,34 @@ func (s *execServer) Input(_ *empty.Empty, stream pb.ExecSessionService_InputSer
 			if err != nil {
 				return err
 			}
+		case sig, ok := <-signals:
+			if !ok {
+				signals = nil
+				continue
+			}
+
+			err := stream.Send(&pb.ExecSession_InputRequest{
+				Input: &pb.ExecSession_InputRequest_Signal{
+					Signal: sig,
+				},
+			})
+			if err != nil {
+				return err
+			}
+		case env, ok := <-envUpdates:
+			if !ok {
+				envUpdates = nil
+				continue
+			}
+
+			err := stream.Send(&pb.ExecSession_InputRequest{
+				Input: &pb.ExecSession_InputRequest_Env{
+					Env: &pb.ExecSession_Env{Env: env},
+				},
+			})
+			if err != nil {
+				return err
+			}
 		}
 	}
 }
@@ -0,0 +1,177 @@
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+)
+
+func TestExecPlugin(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Host side
+	signals := make(chan string, 1)
+	envUpdates := make(chan []string, 1)
+	exitCh := make(chan int, 1)
+	var stdout bytes.Buffer
+	host := &ExecPlugin{
+		Impl: &component.ExecSessionInfo{
+			Input:              strings.NewReader(""),
+			Output:             &stdout,
+			Error:              &bytes.Buffer{},
+			Signals:            signals,
+			EnvironmentUpdates: envUpdates,
+			Exit: func(code int) error {
+				exitCh <- code
+				return nil
+			},
+		},
+		Logger: hclog.L(),
+	}
+
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		require.NoError(host.GRPCServer(nil, s))
+	})
+	defer conn.Close()
+	defer server.Stop()
+
+	// Plugin side
+	client := &ExecPlugin{Logger: hclog.L()}
+	raw, err := client.GRPCClient(ctx, nil, conn)
+	require.NoError(err)
+	esi := raw.(*component.ExecSessionInfo)
+
+	// Signals and environment updates are forwarded to the plugin
+	signals <- "SIGINT"
+	select {
+	case sig := <-esi.Signals:
+		require.Equal("SIGINT", sig)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for signal")
+	}
+
+	envUpdates <- []string{"A=1", "B=2"}
+	select {
+	case env := <-esi.EnvironmentUpdates:
+		require.Equal([]string{"A=1", "B=2"}, env)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for environment")
+	}
+
+	// Output and the exit code are sent to the host
+	_, err = esi.Output.Write([]byte("hello"))
+	require.NoError(err)
+	require.Equal("hello", stdout.String())
+
+	require.NoError(esi.Exit(3))
+	require.Equal(3, <-exitCh)
+}
+
+func TestExecPlugin_exitUnsupported(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	host := &ExecPlugin{
+		Impl:   &component.ExecSessionInfo{Input: strings.NewReader("")},
+		Logger: hclog.L(),
+	}
+
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		require.NoError(host.GRPCServer(nil, s))
+	})
+	defer conn.Close()
+	defer server.Stop()
+
+	client := &ExecPlugin{Logger: hclog.L()}
+	raw, err := client.GRPCClient(ctx, nil, conn)
+	require.NoError(err)
+
+	require.Error(raw.(*component.ExecSessionInfo).Exit(1))
+}
+
+func TestExecPlugin_updatesDontBlockInput(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Host side. The update channels are closed once their updates are
+	// read, and the plugin never reads them.
+	stdinR, stdinW := io.Pipe()
+	defer stdinW.Close()
+	signals := make(chan string, 5)
+	envUpdates := make(chan []string, 5)
+	for i := 0; i < 5; i++ {
+		signals <- "SIGWINCH"
+		envUpdates <- []string{fmt.Sprintf("N=%d", i)}
+	}
+	close(signals)
+	close(envUpdates)
+
+	host := &ExecPlugin{
+		Impl: &component.ExecSessionInfo{
+			Input:              stdinR,
+			Output:             &bytes.Buffer{},
+			Error:              &bytes.Buffer{},
+			Signals:            signals,
+			EnvironmentUpdates: envUpdates,
+		},
+		Logger: hclog.L(),
+	}
+
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		require.NoError(host.GRPCServer(nil, s))
+	})
+	defer conn.Close()
+	defer server.Stop()
+
+	// Plugin side
+	client := &ExecPlugin{Logger: hclog.L()}
+	raw, err := client.GRPCClient(ctx, nil, conn)
+	require.NoError(err)
+	esi := raw.(*component.ExecSessionInfo)
+
+	// Stdin is still delivered
+	go stdinW.Write([]byte("hello"))
+	readCh := make(chan string)
+	go func() {
+		buf := make([]byte, 5)
+		n, _ := io.ReadFull(esi.Input, buf)
+		readCh <- string(buf[:n])
+	}()
+	select {
+	case data := <-readCh:
+		require.Equal("hello", data)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for input")
+	}
+
+	// Only the latest environment is kept, and closed channels don't
+	// send empty updates.
+	var env []string
+	require.Eventually(func() bool {
+		for len(esi.EnvironmentUpdates) > 0 {
+			env = <-esi.EnvironmentUpdates
+		}
+		return len(env) == 1 && env[0] == "N=4"
+	}, 5*time.Second, 10*time.Millisecond)
+	for len(esi.Signals) > 0 {
+		require.Equal("SIGWINCH", <-esi.Signals)
+	}
+}
@@ -129,8 +129,8 @@ func (s *execerServer) Exec(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	result, err := callDynamicFunc2(s.Impl.(component.Execer).ExecFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	result, err := callDynamicFunc2(ctx, s.Impl.(component.Execer).ExecFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Typed(internal),
 		argmapper.Typed(ctx),
 	)
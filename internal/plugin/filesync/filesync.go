@@ -0,0 +1,261 @@
+package filesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/filesync"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// chunkSize is the maximum size of the data in each message.
+const chunkSize = 32 * 1024
+
+// FileSyncPlugin implements plugin.Plugin (specifically GRPCPlugin) for
+// the filesync.Service type. The client is a *filesync.Client.
+type FileSyncPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+
+	Impl     filesync.Service     // Impl is the concrete implementation
+	Logger   hclog.Logger         // Logger
+	Internal *pluginargs.Internal // Internal sets up the client connection
+}
+
+func (p *FileSyncPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	pb.RegisterFileServiceServer(s, &fileServer{
+		Impl:   p.Impl,
+		Logger: p.Logger,
+	})
+	return nil
+}
+
+func (p *FileSyncPlugin) GRPCClient(
+	ctx context.Context,
+	broker *plugin.GRPCBroker,
+	c *grpc.ClientConn,
+) (interface{}, error) {
+	return filesync.NewClient(&fileClient{
+		client: pb.NewFileServiceClient(p.Internal.ClientConn(c)),
+	}), nil
+}
+
+// fileClient is a filesync.Service that calls the FileService.
+type fileClient struct {
+	client pb.FileServiceClient
+}
+
+func (c *fileClient) Upload(ctx context.Context, path string, mode os.FileMode, r io.Reader) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.Upload(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := stream.Send(&pb.FileSync_UploadRequest{
+		Event: &pb.FileSync_UploadRequest_Header{
+			Header: &pb.FileSync_Header{
+				Path: path,
+				Mode: uint32(mode.Perm()),
+			},
+		},
+	}); err != nil {
+		return c.sendErr(path, stream, err)
+	}
+
+	for {
+		buf := make([]byte, chunkSize)
+		n, err := r.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.FileSync_UploadRequest{
+				Event: &pb.FileSync_UploadRequest_Data{Data: buf[:n]},
+			}); err != nil {
+				return c.sendErr(path, stream, err)
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Canceling the stream fails the upload on the host
+			return err
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	return c.err(path, err)
+}
+
+func (c *fileClient) Download(ctx context.Context, path string, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.Download(ctx, &pb.FileSync_DownloadRequest{Path: path})
+	if err != nil {
+		return c.err(path, err)
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return c.err(path, err)
+		}
+
+		if _, err := w.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// sendErr returns the error for a failed Send of an upload. The real
+// error is returned by receiving from the stream, so that is preferred
+// over the send error.
+func (c *fileClient) sendErr(path string, stream pb.FileService_UploadClient, sendErr error) error {
+	_, err := stream.CloseAndRecv()
+	if err == nil || err == io.EOF {
+		err = sendErr
+	}
+
+	return c.err(path, err)
+}
+
+// err returns the error for a call on path. NotFound is returned as an
+// error matching os.ErrNotExist.
+func (c *fileClient) err(path string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("%s: %w", path, os.ErrNotExist)
+	}
+
+	return err
+}
+
+// fileServer is a gRPC server that the plugin talks to and calls a real
+// implementation of the Service.
+type fileServer struct {
+	pb.UnimplementedFileServiceServer
+
+	Impl   filesync.Service
+	Logger hclog.Logger
+}
+
+func (s *fileServer) Upload(stream pb.FileService_UploadServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	header := req.GetHeader()
+	if header == nil {
+		return status.Errorf(codes.InvalidArgument, "first message must be the header")
+	}
+
+	s.Logger.Trace("uploading file", "path", header.Path)
+	err = s.Impl.Upload(
+		stream.Context(),
+		header.Path,
+		os.FileMode(header.Mode).Perm(),
+		&uploadReader{stream: stream},
+	)
+	if err != nil {
+		return s.err(err)
+	}
+
+	return stream.SendAndClose(&empty.Empty{})
+}
+
+func (s *fileServer) Download(
+	req *pb.FileSync_DownloadRequest,
+	stream pb.FileService_DownloadServer,
+) error {
+	s.Logger.Trace("downloading file", "path", req.Path)
+	return s.err(s.Impl.Download(stream.Context(), req.Path, &downloadWriter{stream: stream}))
+}
+
+// err returns the status error for err.
+func (s *fileServer) err(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	return status.Error(codes.Unknown, err.Error())
+}
+
+// uploadReader reads the data messages of an upload.
+type uploadReader struct {
+	stream pb.FileService_UploadServer
+	buf    []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+
+		data, ok := req.Event.(*pb.FileSync_UploadRequest_Data)
+		if !ok {
+			return 0, status.Errorf(codes.InvalidArgument, "unexpected message after the header")
+		}
+		r.buf = data.Data
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// downloadWriter writes the data of a download as chunks.
+type downloadWriter struct {
+	stream pb.FileService_DownloadServer
+}
+
+func (w *downloadWriter) Write(p []byte) (int, error) {
+	total := len(p)
+	for len(p) > 0 {
+		n := len(p)
+		if n > chunkSize {
+			n = chunkSize
+		}
+
+		if err := w.stream.Send(&pb.FileSync_Chunk{Data: p[:n]}); err != nil {
+			return 0, err
+		}
+		p = p[n:]
+	}
+
+	return total, nil
+}
+
+var (
+	_ plugin.Plugin        = (*FileSyncPlugin)(nil)
+	_ plugin.GRPCPlugin    = (*FileSyncPlugin)(nil)
+	_ pb.FileServiceServer = (*fileServer)(nil)
+	_ filesync.Service     = (*fileClient)(nil)
+)
@@ -0,0 +1,60 @@
+package filesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/filesync"
+)
+
+func TestFileSync(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	client := testClient(t, filesync.NewDir(t.TempDir()))
+
+	// More than one chunk
+	data := bytes.Repeat([]byte("hello"), chunkSize)
+	require.NoError(client.Upload(ctx, "dir/file", 0600, bytes.NewReader(data)))
+
+	var buf bytes.Buffer
+	require.NoError(client.Download(ctx, "dir/file", &buf))
+	require.Equal(data, buf.Bytes())
+}
+
+func TestFileSync_errors(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	client := testClient(t, filesync.NewDir(t.TempDir()))
+
+	err := client.Download(ctx, "nope", &bytes.Buffer{})
+	require.Error(err)
+	require.True(errors.Is(err, os.ErrNotExist))
+
+	err = client.Upload(ctx, "../escape", 0, bytes.NewReader([]byte("hello")))
+	require.Error(err)
+	require.Contains(err.Error(), "invalid path")
+}
+
+// testClient serves svc and returns a client for it.
+func testClient(t *testing.T, svc filesync.Service) *filesync.Client {
+	client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		"filesync": &FileSyncPlugin{Impl: svc, Logger: hclog.L()},
+	})
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+
+	raw, err := client.Dispense("filesync")
+	require.NoError(t, err)
+	return raw.(*filesync.Client)
+}
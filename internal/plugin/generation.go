@@ -118,8 +118,8 @@ func (s *generationServer) Generation(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	resp, err := callDynamicFunc2(s.Impl.(component.Generation).GenerationFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	resp, err := callDynamicFunc2(ctx, s.Impl.(component.Generation).GenerationFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Typed(internal),
 		argmapper.Typed(ctx),
 	)
@@ -0,0 +1,269 @@
+package plugin
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// healthServices are the gRPC services of the component types that are
+// reported by the health server. Named components are reported with the
+// name added to the service name, like the services they are served with.
+var healthServices = map[component.Type]string{
+	component.BuilderType:        pb.Builder_ServiceDesc.ServiceName,
+	component.PlatformType:       pb.Platform_ServiceDesc.ServiceName,
+	component.RegistryType:       pb.Registry_ServiceDesc.ServiceName,
+	component.ReleaseManagerType: pb.ReleaseManager_ServiceDesc.ServiceName,
+	component.ConfigSourcerType:  pb.ConfigSourcer_ServiceDesc.ServiceName,
+	component.TaskLauncherType:   pb.TaskLauncher_ServiceDesc.ServiceName,
+}
+
+// healthWatchInterval is how often the health of the components is checked
+// for Watch calls.
+var healthWatchInterval = 5 * time.Second
+
+const (
+	healthCheckMethod = "/grpc.health.v1.Health/Check"
+	healthWatchMethod = "/grpc.health.v1.Health/Watch"
+)
+
+// HealthServer implements the standard gRPC health service for the
+// components served by Plugins. go-plugin already registers the health
+// service to report the "plugin" service for liveness, so this is served
+// with the interceptors from HealthServerOptions rather than registered.
+//
+// The empty service name reports whether every component is healthy,
+// which can be used for readiness. Each component is also reported with
+// the name of its gRPC service, such as "hashicorp.waypoint.sdk.Builder".
+// A component is healthy unless it implements component.Healthy and
+// returns an error.
+//
+// Changes in the health of each service are logged to the logger of the
+// health server.
+type HealthServer struct {
+	mu       sync.RWMutex
+	logger   hclog.Logger
+	services map[string][]interface{}
+
+	// statuses is the last health checked for each service, to log the
+	// changes.
+	statusesMu sync.Mutex
+	statuses   map[string]healthpb.HealthCheckResponse_ServingStatus
+}
+
+// NewHealthServer returns a health server with no components that logs to
+// logger. Use WithHealthServer to add the components served by Plugins to
+// it.
+func NewHealthServer(logger hclog.Logger) *HealthServer {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
+	return &HealthServer{
+		logger:   logger,
+		services: map[string][]interface{}{},
+		statuses: map[string]healthpb.HealthCheckResponse_ServingStatus{},
+	}
+}
+
+// WithHealthServer adds the components served by Plugins to h.
+func WithHealthServer(h *HealthServer) Option {
+	return func(c *pluginConfig) { c.Health = h }
+}
+
+// HealthServerOptions returns the gRPC server options that serve the
+// health service with h.
+func HealthServerOptions(h *HealthServer) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(h.unaryInterceptor),
+		grpc.ChainStreamInterceptor(h.streamInterceptor),
+	}
+}
+
+// Check returns the health of the given service.
+func (h *HealthServer) Check(
+	ctx context.Context,
+	req *healthpb.HealthCheckRequest,
+) (*healthpb.HealthCheckResponse, error) {
+	st, ok := h.check(ctx, req.Service)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+	}
+
+	return &healthpb.HealthCheckResponse{Status: st}, nil
+}
+
+// Watch sends the health of the given service when it changes. The
+// health is checked every healthWatchInterval.
+func (h *HealthServer) Watch(
+	req *healthpb.HealthCheckRequest,
+	stream healthpb.Health_WatchServer,
+) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(healthWatchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	for {
+		st, ok := h.check(ctx, req.Service)
+		if !ok {
+			st = healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+
+		if st != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: st}); err != nil {
+				return err
+			}
+			last = st
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// add adds the component c for every component type it implements. name
+// is the name of the component from WithNamedComponent, if any.
+func (h *HealthServer) add(c interface{}, name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ct := reflect.TypeOf(c)
+	for typ, service := range healthServices {
+		if !ct.Implements(reflect.TypeOf(component.TypeMap[typ]).Elem()) {
+			continue
+		}
+
+		if name != "" {
+			service += "." + name
+		}
+
+		h.services[service] = append(h.services[service], c)
+	}
+}
+
+// check returns the health of the given service. This returns false if
+// the service is unknown.
+func (h *HealthServer) check(
+	ctx context.Context,
+	service string,
+) (healthpb.HealthCheckResponse_ServingStatus, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	// The plugin is serving if we can respond, like go-plugin reports.
+	if service == plugin.GRPCServiceName {
+		return healthpb.HealthCheckResponse_SERVING, true
+	}
+
+	services := h.services
+	if service != "" {
+		cs, ok := h.services[service]
+		if !ok {
+			return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, false
+		}
+
+		services = map[string][]interface{}{service: cs}
+	}
+
+	result := healthpb.HealthCheckResponse_SERVING
+	for name, cs := range services {
+		for _, c := range cs {
+			healthy, ok := c.(component.Healthy)
+			if !ok {
+				continue
+			}
+
+			if err := healthy.Healthy(ctx); err != nil {
+				h.logger.Warn("component is unhealthy", "service", name, "error", err)
+				result = healthpb.HealthCheckResponse_NOT_SERVING
+			}
+		}
+	}
+
+	h.record(service, result)
+	return result, true
+}
+
+// record logs the health st of service if it changed since the last check.
+func (h *HealthServer) record(service string, st healthpb.HealthCheckResponse_ServingStatus) {
+	h.statusesMu.Lock()
+	defer h.statusesMu.Unlock()
+
+	last, ok := h.statuses[service]
+	if ok && last == st {
+		return
+	}
+	h.statuses[service] = st
+
+	// Only log changes, not the initial health of healthy services.
+	if !ok && st == healthpb.HealthCheckResponse_SERVING {
+		return
+	}
+
+	from := "UNKNOWN"
+	if ok {
+		from = last.String()
+	}
+	h.logger.Info("health changed", "service", service, "from", from, "to", st.String())
+}
+
+func (h *HealthServer) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if info.FullMethod != healthCheckMethod {
+		return handler(ctx, req)
+	}
+
+	return h.Check(ctx, req.(*healthpb.HealthCheckRequest))
+}
+
+func (h *HealthServer) streamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if info.FullMethod != healthWatchMethod {
+		return handler(srv, ss)
+	}
+
+	var req healthpb.HealthCheckRequest
+	if err := ss.RecvMsg(&req); err != nil {
+		return err
+	}
+
+	return h.Watch(&req, &healthWatchServer{ServerStream: ss})
+}
+
+// healthWatchServer implements healthpb.Health_WatchServer for a stream
+// intercepted by streamInterceptor.
+type healthWatchServer struct {
+	grpc.ServerStream
+}
+
+func (s *healthWatchServer) Send(resp *healthpb.HealthCheckResponse) error {
+	return s.ServerStream.SendMsg(resp)
+}
+
+var (
+	_ healthpb.HealthServer       = (*HealthServer)(nil)
+	_ healthpb.Health_WatchServer = (*healthWatchServer)(nil)
+)
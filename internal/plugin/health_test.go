@@ -0,0 +1,128 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+)
+
+func TestHealthServer(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	builder := &healthyBuilder{}
+	h := NewHealthServer(hclog.New(&hclog.LoggerOptions{Output: &buf}))
+	Plugins(
+		WithComponents(builder, &mocks.Platform{}),
+		WithNamedComponent("docker", &healthyBuilder{}),
+		WithHealthServer(h),
+	)
+	client := testHealthClient(t, h)
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(err)
+		return resp.Status
+	}
+
+	require.Equal(healthpb.HealthCheckResponse_SERVING, check(""))
+	require.Equal(healthpb.HealthCheckResponse_SERVING, check("plugin"))
+	require.Equal(healthpb.HealthCheckResponse_SERVING, check("hashicorp.waypoint.sdk.Builder"))
+	require.Equal(healthpb.HealthCheckResponse_SERVING, check("hashicorp.waypoint.sdk.Builder.docker"))
+	require.Equal(healthpb.HealthCheckResponse_SERVING, check("hashicorp.waypoint.sdk.Platform"))
+
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "nope"})
+	require.Equal(codes.NotFound, status.Code(err))
+
+	// A degraded component affects its service and the overall health
+	builder.setErr(errors.New("token expired"))
+	require.Equal(healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+	require.Equal(healthpb.HealthCheckResponse_NOT_SERVING, check("hashicorp.waypoint.sdk.Builder"))
+	require.Equal(healthpb.HealthCheckResponse_SERVING, check("hashicorp.waypoint.sdk.Builder.docker"))
+	require.Equal(healthpb.HealthCheckResponse_SERVING, check("plugin"))
+
+	// The changes are logged
+	require.Contains(buf.String(), "health changed")
+	require.Contains(buf.String(), "service=hashicorp.waypoint.sdk.Builder from=SERVING to=NOT_SERVING")
+}
+
+func TestHealthServer_watch(t *testing.T) {
+	require := require.New(t)
+
+	old := healthWatchInterval
+	healthWatchInterval = 10 * time.Millisecond
+	defer func() { healthWatchInterval = old }()
+
+	builder := &healthyBuilder{}
+	h := NewHealthServer(nil)
+	Plugins(WithComponents(builder), WithHealthServer(h))
+	client := testHealthClient(t, h)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(err)
+
+	resp, err := stream.Recv()
+	require.NoError(err)
+	require.Equal(healthpb.HealthCheckResponse_SERVING, resp.Status)
+
+	builder.setErr(errors.New("token expired"))
+	resp, err = stream.Recv()
+	require.NoError(err)
+	require.Equal(healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+}
+
+// testHealthClient serves h on a gRPC server that also has the health
+// service registered like go-plugin does and returns a client for it.
+func testHealthClient(t *testing.T, h *HealthServer) healthpb.HealthClient {
+	s := grpc.NewServer(HealthServerOptions(h)...)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go s.Serve(ln)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial(ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+// healthyBuilder is a builder that implements component.Healthy.
+type healthyBuilder struct {
+	mocks.Builder
+
+	mu  sync.Mutex
+	err error
+}
+
+func (b *healthyBuilder) setErr(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.err = err
+}
+
+func (b *healthyBuilder) Healthy(context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.err
+}
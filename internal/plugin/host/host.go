@@ -0,0 +1,197 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/host"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// HostPlugin implements plugin.Plugin (specifically GRPCPlugin) for
+// the host.Service type. The client is a *host.Client.
+type HostPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+
+	Impl     host.Service         // Impl is the concrete implementation
+	Logger   hclog.Logger         // Logger
+	Internal *pluginargs.Internal // Internal sets up the client connection
+}
+
+func (p *HostPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	pb.RegisterHostServiceServer(s, &hostServer{
+		Impl:   p.Impl,
+		Logger: p.Logger,
+	})
+	return nil
+}
+
+func (p *HostPlugin) GRPCClient(
+	ctx context.Context,
+	broker *plugin.GRPCBroker,
+	c *grpc.ClientConn,
+) (interface{}, error) {
+	return host.NewClient(&hostClient{
+		client: pb.NewHostServiceClient(p.Internal.ClientConn(c)),
+	}), nil
+}
+
+// hostClient is a host.Service that calls the HostService.
+type hostClient struct {
+	client pb.HostServiceClient
+}
+
+func (c *hostClient) ListDeployments(
+	ctx context.Context,
+	opts *host.ListDeploymentsOptions,
+) ([]*host.Deployment, error) {
+	if opts == nil {
+		opts = &host.ListDeploymentsOptions{}
+	}
+
+	resp, err := c.client.ListDeployments(ctx, &pb.Host_ListDeploymentsRequest{
+		Workspace: opts.Workspace,
+		Limit:     uint32(opts.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*host.Deployment, len(resp.Deployments))
+	for i, d := range resp.Deployments {
+		result[i] = &host.Deployment{
+			Id:        d.Id,
+			Sequence:  d.Sequence,
+			Workspace: d.Workspace,
+			State:     d.State,
+			Labels:    d.Labels,
+			Value:     d.Value,
+			CreatedAt: d.CreatedAt.AsTime(),
+		}
+	}
+
+	return result, nil
+}
+
+func (c *hostClient) Artifact(ctx context.Context, id string) (*host.Artifact, error) {
+	resp, err := c.client.GetArtifact(ctx, &pb.Host_GetArtifactRequest{Id: id})
+	if status.Code(err) == codes.NotFound {
+		return nil, fmt.Errorf("artifact %q: %w", id, host.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &host.Artifact{
+		Id:        resp.Id,
+		Sequence:  resp.Sequence,
+		Labels:    resp.Labels,
+		Value:     resp.Value,
+		CreatedAt: resp.CreatedAt.AsTime(),
+	}, nil
+}
+
+func (c *hostClient) ConfigVars(ctx context.Context) (map[string]string, error) {
+	resp, err := c.client.ConfigVars(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Vars, nil
+}
+
+// hostServer is a gRPC server that the plugin talks to and calls a real
+// implementation of the Service.
+type hostServer struct {
+	pb.UnimplementedHostServiceServer
+
+	Impl   host.Service
+	Logger hclog.Logger
+}
+
+func (s *hostServer) ListDeployments(
+	ctx context.Context,
+	req *pb.Host_ListDeploymentsRequest,
+) (*pb.Host_ListDeploymentsResponse, error) {
+	deployments, err := s.Impl.ListDeployments(ctx, &host.ListDeploymentsOptions{
+		Workspace: req.Workspace,
+		Limit:     int(req.Limit),
+	})
+	if err != nil {
+		return nil, s.err(err)
+	}
+
+	var result pb.Host_ListDeploymentsResponse
+	for _, d := range deployments {
+		result.Deployments = append(result.Deployments, &pb.Host_Deployment{
+			Id:        d.Id,
+			Sequence:  d.Sequence,
+			Workspace: d.Workspace,
+			State:     d.State,
+			Labels:    d.Labels,
+			Value:     d.Value,
+			CreatedAt: timestamppb.New(d.CreatedAt),
+		})
+	}
+
+	return &result, nil
+}
+
+func (s *hostServer) GetArtifact(
+	ctx context.Context,
+	req *pb.Host_GetArtifactRequest,
+) (*pb.Host_Artifact, error) {
+	a, err := s.Impl.Artifact(ctx, req.Id)
+	if err != nil {
+		return nil, s.err(err)
+	}
+
+	return &pb.Host_Artifact{
+		Id:        a.Id,
+		Sequence:  a.Sequence,
+		Labels:    a.Labels,
+		Value:     a.Value,
+		CreatedAt: timestamppb.New(a.CreatedAt),
+	}, nil
+}
+
+func (s *hostServer) ConfigVars(
+	ctx context.Context,
+	req *empty.Empty,
+) (*pb.Host_ConfigVarsResponse, error) {
+	vars, err := s.Impl.ConfigVars(ctx)
+	if err != nil {
+		return nil, s.err(err)
+	}
+
+	return &pb.Host_ConfigVarsResponse{Vars: vars}, nil
+}
+
+// err returns the status error for err.
+func (s *hostServer) err(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, host.ErrNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	return status.Error(codes.Unknown, err.Error())
+}
+
+var (
+	_ plugin.Plugin        = (*HostPlugin)(nil)
+	_ plugin.GRPCPlugin    = (*HostPlugin)(nil)
+	_ pb.HostServiceServer = (*hostServer)(nil)
+	_ host.Service         = (*hostClient)(nil)
+)
@@ -0,0 +1,123 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/opaqueany"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/host"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
+)
+
+func TestHost(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	value, err := opaqueany.New(&testproto.Data{Value: "v1"})
+	require.NoError(err)
+
+	created := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	svc := &testService{
+		deployments: []*host.Deployment{
+			{Id: "d2", Sequence: 2, State: host.StateError},
+			{Id: "d1", Sequence: 1, State: host.StateSuccess, Value: value, CreatedAt: created},
+		},
+		vars: map[string]string{"PORT": "8080"},
+	}
+	client := testClient(t, svc)
+
+	// The previous successful deployment
+	d, err := client.PreviousDeployment(ctx)
+	require.NoError(err)
+	require.Equal("d1", d.Id)
+	require.Equal(uint64(1), d.Sequence)
+	require.True(created.Equal(d.CreatedAt))
+
+	var data testproto.Data
+	require.NoError(d.Decode(&data))
+	require.Equal("v1", data.Value)
+
+	// The options are sent
+	_, err = client.ListDeployments(ctx, &host.ListDeploymentsOptions{Workspace: "prod", Limit: 1})
+	require.NoError(err)
+	require.Equal(&host.ListDeploymentsOptions{Workspace: "prod", Limit: 1}, svc.opts)
+
+	v, ok, err := client.ConfigVar(ctx, "PORT")
+	require.NoError(err)
+	require.True(ok)
+	require.Equal("8080", v)
+
+	_, ok, err = client.ConfigVar(ctx, "NOPE")
+	require.NoError(err)
+	require.False(ok)
+}
+
+func TestHost_errors(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	client := testClient(t, &testService{})
+
+	_, err := client.Artifact(ctx, "nope")
+	require.Error(err)
+	require.True(errors.Is(err, host.ErrNotFound))
+
+	// Services the host doesn't support
+	_, err = client.ConfigVars(ctx)
+	require.Error(err)
+	require.Equal(codes.Unimplemented, status.Code(err))
+
+	// No deployments
+	d, err := client.PreviousDeployment(ctx)
+	require.NoError(err)
+	require.Nil(d)
+}
+
+// testClient serves svc and returns a client for it.
+func testClient(t *testing.T, svc host.Service) *host.Client {
+	client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		"host": &HostPlugin{Impl: svc, Logger: hclog.L()},
+	})
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+
+	raw, err := client.Dispense("host")
+	require.NoError(t, err)
+	return raw.(*host.Client)
+}
+
+type testService struct {
+	deployments []*host.Deployment
+	vars        map[string]string
+	opts        *host.ListDeploymentsOptions
+}
+
+func (s *testService) ListDeployments(
+	ctx context.Context,
+	opts *host.ListDeploymentsOptions,
+) ([]*host.Deployment, error) {
+	s.opts = opts
+	return s.deployments, nil
+}
+
+func (s *testService) Artifact(ctx context.Context, id string) (*host.Artifact, error) {
+	return nil, host.ErrNotFound
+}
+
+func (s *testService) ConfigVars(ctx context.Context) (map[string]string, error) {
+	if s.vars == nil {
+		return nil, status.Error(codes.Unimplemented, "config vars are not available")
+	}
+
+	return s.vars, nil
+}
@@ -0,0 +1,478 @@
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pkg/sdkversion"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// InfoPlugin implements plugin.Plugin (specifically GRPCPlugin) for
+// the Info plugin type. This is always available in the sets returned by
+// Plugins and describes the other plugins in the set.
+type InfoPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+
+	Info     *component.PluginInfo  // Info is the info for this plugin set
+	Set      plugin.PluginSet       // Set is the plugin set that is described
+	Features *pluginargs.FeatureSet // Features to negotiate, see WithFeatures
+	Mappers  []*argmapper.Func      // Mappers
+	Logger   hclog.Logger           // Logger
+}
+
+func (p *InfoPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	pb.RegisterInfoServer(s, &infoServer{
+		Static:   p.Info,
+		Describe: p.describe,
+		Features: p.Features,
+		Logger:   p.Logger,
+	})
+	return nil
+}
+
+func (p *InfoPlugin) GRPCClient(
+	ctx context.Context,
+	broker *plugin.GRPCBroker,
+	c *grpc.ClientConn,
+) (interface{}, error) {
+	return &InfoClient{
+		client:   pb.NewInfoClient(c),
+		logger:   p.Logger,
+		features: p.Features,
+	}, nil
+}
+
+// InfoClient is an implementation of component.PluginInfoProvider over gRPC.
+type InfoClient struct {
+	client   pb.InfoClient
+	logger   hclog.Logger
+	features *pluginargs.FeatureSet
+}
+
+// PluginInfo returns the information for the negotiated protocol version.
+func (c *InfoClient) PluginInfo(ctx context.Context) (*component.PluginInfo, error) {
+	resp, err := c.client.Info(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &component.PluginInfo{
+		Name:            resp.Name,
+		Version:         resp.Version,
+		Commit:          resp.Commit,
+		SDKVersion:      resp.SdkVersion,
+		ProtocolVersion: int(resp.ProtocolVersion),
+	}
+	for _, comp := range resp.Components {
+		result.Components = append(result.Components, &component.ComponentInfo{
+			Type:           component.TypeFromString(comp.Type),
+			Name:           comp.Name,
+			RegisteredName: comp.RegisteredName,
+			Capabilities:   comp.Capabilities,
+			Outputs:        contractsFromProto(comp.Outputs),
+			Accepts:        contractsFromProto(comp.Accepts),
+			ConfigDigest:   comp.ConfigDigest,
+		})
+	}
+	for _, m := range resp.Mappers {
+		result.Mappers = append(result.Mappers, &component.MapperInfo{
+			Name:    m.Name,
+			Inputs:  m.Inputs,
+			Outputs: m.Outputs,
+		})
+	}
+
+	return result, nil
+}
+
+// Negotiate negotiates the features that are enabled with the plugin. The
+// host supports the features fs in addition to SDKFeatures. This returns
+// the features that both the host and the plugin support, which are also
+// used by the clients of the plugin set. If the plugin predates
+// negotiation, no features are enabled.
+func (c *InfoClient) Negotiate(ctx context.Context, fs ...component.Feature) (*component.Features, error) {
+	fs = append(append([]component.Feature(nil), SDKFeatures...), fs...)
+	req := &pb.Features_NegotiateRequest{}
+	for _, f := range fs {
+		req.Features = append(req.Features, string(f))
+	}
+
+	resp, err := c.client.Negotiate(ctx, req)
+	if status.Code(err) == codes.Unimplemented {
+		return component.NewFeatures(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The plugin shouldn't enable features we don't support, but we
+	// intersect anyways so a misbehaving plugin can't enable them.
+	result := featuresFromProto(resp.Features).Intersect(fs...)
+	if c.features != nil {
+		c.features.SetNegotiated(result)
+	}
+
+	return result, nil
+}
+
+// infoServer is a gRPC server that implements the Info service.
+type infoServer struct {
+	pb.UnimplementedInfoServer
+
+	Static   *component.PluginInfo
+	Describe func() *component.PluginInfo
+	Features *pluginargs.FeatureSet
+	Logger   hclog.Logger
+}
+
+func (s *infoServer) Info(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.PluginInfo_Resp, error) {
+	return infoProto(s.Describe()), nil
+}
+
+// Components returns the info computed when the plugin set was built, which
+// has the capabilities of the components but not their config digests or
+// the mappers.
+func (s *infoServer) Components(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.PluginInfo_Resp, error) {
+	return infoProto(s.Static), nil
+}
+
+func (s *infoServer) Negotiate(
+	ctx context.Context,
+	req *pb.Features_NegotiateRequest,
+) (*pb.Features_NegotiateResponse, error) {
+	// Without a feature set the plugin supports no features, but we still
+	// answer so the host knows negotiation took place.
+	var result *component.Features
+	if s.Features != nil {
+		result = s.Features.Negotiate(featuresFromProto(req.Features).List())
+	}
+
+	resp := &pb.Features_NegotiateResponse{}
+	for _, f := range result.List() {
+		resp.Features = append(resp.Features, string(f))
+	}
+
+	return resp, nil
+}
+
+// WriteManifest writes the info for every protocol version served by the
+// plugin set built from the given options to w as JSON. This is the output
+// of the plugin manifest command.
+func WriteManifest(w io.Writer, opts ...Option) error {
+	var versions []int
+	plugins := Plugins(opts...)
+	for v := range plugins {
+		versions = append(versions, v)
+	}
+	sort.Ints(versions)
+
+	var manifest pb.PluginInfo_Manifest
+	for _, v := range versions {
+		p := plugins[v]["info"].(*InfoPlugin)
+		manifest.Versions = append(manifest.Versions, infoProto(p.describe()))
+	}
+
+	data, err := protojson.MarshalOptions{
+		Multiline:       true,
+		EmitUnpopulated: true,
+	}.Marshal(&manifest)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// pluginInfo returns the info for the components in the given plugin set.
+func pluginInfo(c *pluginConfig, version int, set plugin.PluginSet) *component.PluginInfo {
+	result := &component.PluginInfo{
+		Name:            c.Name,
+		Version:         c.Version,
+		Commit:          c.Commit,
+		SDKVersion:      sdkversion.Version(),
+		ProtocolVersion: version,
+	}
+
+	for _, p := range set {
+		impl, typ, name := pluginImpl(p)
+
+		// impl is nil for the mapper plugin and for component types that
+		// aren't served.
+		if impl == nil {
+			continue
+		}
+
+		info := &component.ComponentInfo{
+			Type:           typ,
+			Name:           fmt.Sprintf("%T", impl),
+			RegisteredName: string(name),
+			Capabilities:   component.Capabilities(impl),
+		}
+		if len(c.Contracts) > 0 {
+			info.Accepts, info.Outputs = operationContracts(c.Contracts, operationFunc(impl, typ))
+		}
+
+		result.Components = append(result.Components, info)
+	}
+
+	// Sort by type and name so the result is stable regardless of map
+	// ordering. The unnamed component of each type is first.
+	sort.Slice(result.Components, func(i, j int) bool {
+		a, b := result.Components[i], result.Components[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+
+		return a.RegisteredName < b.RegisteredName
+	})
+
+	return result
+}
+
+// pluginImpl returns the component served by the plugin p with its type
+// and name. impl is nil if p doesn't serve a component.
+func pluginImpl(p plugin.Plugin) (impl interface{}, typ component.Type, name ComponentName) {
+	switch p := p.(type) {
+	case *BuilderPlugin:
+		impl, typ, name = p.Impl, component.BuilderType, p.Name
+	case *PlatformPlugin:
+		impl, typ, name = p.Impl, component.PlatformType, p.Name
+	case *RegistryPlugin:
+		impl, typ, name = p.Impl, component.RegistryType, p.Name
+	case *ReleaseManagerPlugin:
+		impl, typ, name = p.Impl, component.ReleaseManagerType, p.Name
+	case *ConfigSourcerPlugin:
+		impl, typ, name = p.Impl, component.ConfigSourcerType, p.Name
+	case *TaskLauncherPlugin:
+		impl, typ, name = p.Impl, component.TaskLauncherType, p.Name
+	}
+
+	return impl, typ, name
+}
+
+// describe returns the info for the plugin set with the details that are
+// computed when they are requested rather than when the plugin set is
+// built, since they call into the components and mappers.
+func (p *InfoPlugin) describe() *component.PluginInfo {
+	result := *p.Info
+	result.Components = nil
+	for _, c := range p.Info.Components {
+		copied := *c
+		if impl, _, _ := pluginImpl(p.Set[PluginName(c.Type, c.RegisteredName)]); impl != nil {
+			digest, err := configDigest(impl)
+			if err != nil {
+				p.Logger.Warn("error computing config digest",
+					"component", c.Name,
+					"err", err)
+			}
+
+			copied.ConfigDigest = digest
+		}
+
+		result.Components = append(result.Components, &copied)
+	}
+
+	for _, spec := range mapperSpecs(p.Mappers, p.Logger) {
+		result.Mappers = append(result.Mappers, &component.MapperInfo{
+			Name:    spec.Name,
+			Inputs:  specTypes(spec.Args),
+			Outputs: specTypes(spec.Result),
+		})
+	}
+
+	return &result
+}
+
+// configDigest returns the hex-encoded SHA-256 digest of the config
+// struct and schema of impl, or an empty string if impl isn't
+// configurable.
+func configDigest(impl interface{}) (string, error) {
+	structResp, err := configStruct(impl)
+	if err != nil {
+		return "", err
+	}
+	schemaResp, err := configSchema(impl)
+	if err != nil {
+		return "", err
+	}
+	if structResp.Struct == nil && schemaResp.Spec == nil {
+		return "", nil
+	}
+
+	h := sha256.New()
+	opts := proto.MarshalOptions{Deterministic: true}
+	for _, m := range []proto.Message{structResp, schemaResp} {
+		data, err := opts.Marshal(m)
+		if err != nil {
+			return "", err
+		}
+
+		h.Write(data)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// specTypes returns the types of the values of a FuncSpec.
+func specTypes(vs []*pb.FuncSpec_Value) []string {
+	var result []string
+	for _, v := range vs {
+		if v.Type != "" {
+			result = append(result, v.Type)
+			continue
+		}
+
+		result = append(result, v.PrimitiveType.String())
+	}
+
+	return result
+}
+
+func infoProto(info *component.PluginInfo) *pb.PluginInfo_Resp {
+	result := &pb.PluginInfo_Resp{
+		Name:            info.Name,
+		Version:         info.Version,
+		Commit:          info.Commit,
+		SdkVersion:      info.SDKVersion,
+		ProtocolVersion: int32(info.ProtocolVersion),
+	}
+	for _, c := range info.Components {
+		result.Components = append(result.Components, &pb.PluginInfo_Component{
+			Type:           c.Type.String(),
+			Name:           c.Name,
+			RegisteredName: c.RegisteredName,
+			Capabilities:   c.Capabilities,
+			Outputs:        contractsProto(c.Outputs),
+			Accepts:        contractsProto(c.Accepts),
+			ConfigDigest:   c.ConfigDigest,
+		})
+	}
+	for _, m := range info.Mappers {
+		result.Mappers = append(result.Mappers, &pb.PluginInfo_Mapper{
+			Name:    m.Name,
+			Inputs:  m.Inputs,
+			Outputs: m.Outputs,
+		})
+	}
+
+	return result
+}
+
+// operationFunc returns the function of the main operation of a component
+// of the given type, such as the result of BuildFunc for a builder. This
+// returns nil for types without one.
+func operationFunc(impl interface{}, typ component.Type) interface{} {
+	switch typ {
+	case component.BuilderType:
+		return impl.(component.Builder).BuildFunc()
+	case component.RegistryType:
+		return impl.(component.Registry).PushFunc()
+	case component.PlatformType:
+		return impl.(component.Platform).DeployFunc()
+	case component.ReleaseManagerType:
+		return impl.(component.ReleaseManager).ReleaseFunc()
+	default:
+		return nil
+	}
+}
+
+// operationContracts returns the contracts for the arguments and the
+// results of f, in order, that are proto messages of the types of
+// contracts. Other arguments and results are ignored.
+func operationContracts(contracts []component.Contract, f interface{}) (accepts, outputs []component.Contract) {
+	t := reflect.TypeOf(f)
+	if t == nil || t.Kind() != reflect.Func {
+		return nil, nil
+	}
+
+	byType := map[string]component.Contract{}
+	for _, c := range contracts {
+		byType[c.Type] = c
+	}
+
+	find := func(t reflect.Type) (component.Contract, bool) {
+		if t.Kind() != reflect.Ptr {
+			return component.Contract{}, false
+		}
+
+		msg, ok := reflect.Zero(t).Interface().(proto.Message)
+		if !ok {
+			return component.Contract{}, false
+		}
+
+		c, ok := byType[string(msg.ProtoReflect().Descriptor().FullName())]
+		return c, ok
+	}
+
+	for i := 0; i < t.NumIn(); i++ {
+		if c, ok := find(t.In(i)); ok {
+			accepts = append(accepts, c)
+		}
+	}
+	for i := 0; i < t.NumOut(); i++ {
+		if c, ok := find(t.Out(i)); ok {
+			outputs = append(outputs, c)
+		}
+	}
+
+	return accepts, outputs
+}
+
+func contractsProto(cs []component.Contract) []*pb.PluginInfo_Contract {
+	var result []*pb.PluginInfo_Contract
+	for _, c := range cs {
+		result = append(result, &pb.PluginInfo_Contract{Name: c.Name, Type: c.Type})
+	}
+
+	return result
+}
+
+func featuresFromProto(fs []string) *component.Features {
+	var result []component.Feature
+	for _, f := range fs {
+		result = append(result, component.Feature(f))
+	}
+
+	return component.NewFeatures(result...)
+}
+
+func contractsFromProto(cs []*pb.PluginInfo_Contract) []component.Contract {
+	var result []component.Contract
+	for _, c := range cs {
+		result = append(result, component.Contract{Name: c.Name, Type: c.Type})
+	}
+
+	return result
+}
+
+var (
+	_ plugin.Plugin                = (*InfoPlugin)(nil)
+	_ plugin.GRPCPlugin            = (*InfoPlugin)(nil)
+	_ pb.InfoServer                = (*infoServer)(nil)
+	_ component.PluginInfoProvider = (*InfoClient)(nil)
+)
@@ -0,0 +1,299 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pkg/sdkversion"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+func TestPlugins_info(t *testing.T) {
+	require := require.New(t)
+
+	plugins := Plugins(
+		WithComponents(&mocks.PlatformWithStatus{}, &mocks.Builder{}),
+		WithVersionedComponents(2, &mocks.Registry{}),
+		WithInfo("test", "1.2.3"),
+		WithCommit("abc123"),
+	)
+
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("info")
+	require.NoError(err)
+	info, err := raw.(component.PluginInfoProvider).PluginInfo(context.Background())
+	require.NoError(err)
+
+	require.Equal("test", info.Name)
+	require.Equal("1.2.3", info.Version)
+	require.Equal("abc123", info.Commit)
+	require.Equal(sdkversion.Version(), info.SDKVersion)
+	require.Equal(1, info.ProtocolVersion)
+	require.Equal([]*component.ComponentInfo{
+		{
+			Type: component.BuilderType,
+			Name: "*mocks.Builder",
+		},
+		{
+			Type:         component.PlatformType,
+			Name:         "*mocks.PlatformWithStatus",
+			Capabilities: []string{component.CapabilityStatus},
+		},
+	}, info.Components)
+
+	// Version-specific components are described separately
+	v2 := plugins[2]["info"].(*InfoPlugin).Info
+	require.Equal(2, v2.ProtocolVersion)
+	require.Len(v2.Components, 1)
+	require.Equal(component.RegistryType, v2.Components[0].Type)
+}
+
+func TestPlugins_infoContracts(t *testing.T) {
+	require := require.New(t)
+
+	builder := &mocks.Builder{}
+	mocks.ExpectFunc(builder, "BuildFunc", func(*component.Source) (*testproto.Data, error) {
+		return nil, nil
+	})
+
+	platform := &mocks.Platform{}
+	mocks.ExpectFunc(platform, "DeployFunc", func(*testproto.Data, *testproto.A) *testproto.Deployment {
+		return nil
+	})
+
+	image := component.NewContract("Image", (*testproto.Data)(nil))
+	plugins := Plugins(
+		WithComponents(builder, platform),
+		WithContracts(image),
+	)
+
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("info")
+	require.NoError(err)
+	info, err := raw.(component.PluginInfoProvider).PluginInfo(context.Background())
+	require.NoError(err)
+	require.Len(info.Components, 2)
+
+	// Only the registered types are listed
+	b, p := info.Components[0], info.Components[1]
+	require.Equal(component.Contract{Name: "Image", Type: "testproto.Data"}, image)
+	require.Empty(b.Accepts)
+	require.Equal([]component.Contract{image}, b.Outputs)
+	require.Equal([]component.Contract{image}, p.Accepts)
+	require.Empty(p.Outputs)
+
+	require.NoError(component.CheckChain(b, p))
+}
+
+func TestPlugins_infoDescribe(t *testing.T) {
+	require := require.New(t)
+
+	describe := func(config interface{}) *component.PluginInfo {
+		mockV := &mockPlatformConfigurable{}
+		mockV.Configurable.On("Config").Return(config, nil)
+
+		mapper, err := argmapper.NewFunc(func(v *testproto.Data) *testproto.A {
+			return &testproto.A{Value: int32(len(v.Value))}
+		})
+		require.NoError(err)
+
+		plugins := Plugins(
+			WithComponents(mockV, &mocks.Builder{}),
+			WithMappers(append(testDefaultMappers(t), mapper)...),
+		)
+		client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+		defer client.Close()
+		defer server.Stop()
+
+		raw, err := client.Dispense("info")
+		require.NoError(err)
+		info, err := raw.(component.PluginInfoProvider).PluginInfo(context.Background())
+		require.NoError(err)
+		return info
+	}
+
+	var config struct {
+		Name string `hcl:"name"`
+	}
+	info := describe(&config)
+
+	// Only the mappers of the plugin are listed
+	require.Len(info.Mappers, 1)
+	require.Equal([]string{"testproto.Data"}, info.Mappers[0].Inputs)
+	require.Equal([]string{"testproto.A"}, info.Mappers[0].Outputs)
+
+	// Only the configurable component has a digest
+	require.Len(info.Components, 2)
+	require.Equal(component.BuilderType, info.Components[0].Type)
+	require.Empty(info.Components[0].ConfigDigest)
+	digest := info.Components[1].ConfigDigest
+	require.NotEmpty(digest)
+
+	// The digest is stable and changes with the schema
+	require.Equal(digest, describe(&config).Components[1].ConfigDigest)
+
+	var other struct {
+		Name  string `hcl:"name"`
+		Count int    `hcl:"count,optional"`
+	}
+	require.NotEqual(digest, describe(&other).Components[1].ConfigDigest)
+}
+
+func TestPlugins_infoNoComponents(t *testing.T) {
+	require := require.New(t)
+
+	client, server := plugin.TestPluginGRPCConn(t, Plugins()[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("info")
+	require.NoError(err)
+	info, err := raw.(component.PluginInfoProvider).PluginInfo(context.Background())
+	require.NoError(err)
+	require.Empty(info.Components)
+}
+
+func TestInfoClient_negotiateUnimplemented(t *testing.T) {
+	require := require.New(t)
+
+	// Plugins built before negotiation existed don't have the RPC
+	client := &InfoClient{client: unimplementedInfoClient{}}
+	features, err := client.Negotiate(context.Background(), component.FeatureLargeValues)
+	require.NoError(err)
+	require.Empty(features.List())
+	require.False(features.Enabled(component.FeatureLargeValues))
+}
+
+// unimplementedInfoClient is a pb.InfoClient for a plugin that predates
+// feature negotiation.
+type unimplementedInfoClient struct {
+	pb.InfoClient
+}
+
+func (unimplementedInfoClient) Negotiate(
+	context.Context,
+	*pb.Features_NegotiateRequest,
+	...grpc.CallOption,
+) (*pb.Features_NegotiateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "unknown method Negotiate")
+}
+
+func TestWriteManifest(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	require.NoError(WriteManifest(&buf,
+		WithComponents(&mocks.Builder{}),
+		WithVersionedComponents(2, &mocks.Builder{}, &mocks.Platform{}),
+		WithInfo("test", "1.2.3"),
+	))
+
+	var manifest struct {
+		Versions []struct {
+			Name            string
+			ProtocolVersion int
+			Components      []struct {
+				Type string
+			}
+		}
+	}
+	require.NoError(json.Unmarshal(buf.Bytes(), &manifest))
+	require.Len(manifest.Versions, 2)
+	require.Equal("test", manifest.Versions[0].Name)
+	require.Equal(1, manifest.Versions[0].ProtocolVersion)
+	require.Len(manifest.Versions[0].Components, 1)
+	require.Equal(2, manifest.Versions[1].ProtocolVersion)
+	require.Equal("Builder", manifest.Versions[1].Components[0].Type)
+	require.Equal("Platform", manifest.Versions[1].Components[1].Type)
+}
+
+func TestCapabilityProbe(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	info := &component.PluginInfo{
+		Components: []*component.ComponentInfo{
+			{
+				Type:         component.PlatformType,
+				Capabilities: []string{component.CapabilityDestroyer},
+			},
+		},
+	}
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		pb.RegisterInfoServer(s, &infoServer{Static: info})
+	})
+	defer conn.Close()
+	defer server.Stop()
+
+	// The reported capabilities are used without probing
+	probe, err := newCapabilityProbe(ctx, conn, component.PlatformType, "")
+	require.NoError(err)
+	ok, err := probe.Implements(ctx, component.CapabilityDestroyer, testImplementer{t: t})
+	require.NoError(err)
+	require.True(ok)
+	ok, err = probe.Implements(ctx, component.CapabilityExecer, testImplementer{t: t})
+	require.NoError(err)
+	require.False(ok)
+
+	// Components that aren't reported are probed
+	probe, err = newCapabilityProbe(ctx, conn, component.PlatformType, "other")
+	require.NoError(err)
+	ok, err = probe.Implements(ctx, component.CapabilityExecer, testImplementer{implements: true})
+	require.NoError(err)
+	require.True(ok)
+}
+
+func TestCapabilityProbe_unimplemented(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Plugins built before the Components RPC are probed
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		pb.RegisterInfoServer(s, unimplementedInfoServer{})
+	})
+	defer conn.Close()
+	defer server.Stop()
+
+	probe, err := newCapabilityProbe(ctx, conn, component.PlatformType, "")
+	require.NoError(err)
+	ok, err := probe.Implements(ctx, component.CapabilityDestroyer, testImplementer{implements: true})
+	require.NoError(err)
+	require.True(ok)
+}
+
+// testImplementer is an implementer that fails the test if t is set,
+// since the probe shouldn't need it.
+type testImplementer struct {
+	t          *testing.T
+	implements bool
+}
+
+func (i testImplementer) Implements(context.Context) (bool, error) {
+	if i.t != nil {
+		i.t.Fatal("Implements should not be called")
+	}
+
+	return i.implements, nil
+}
+
+type unimplementedInfoServer struct {
+	pb.UnimplementedInfoServer
+}
@@ -0,0 +1,425 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/component/pipe"
+	pluginpipe "github.com/hashicorp/waypoint-plugin-sdk/internal/plugin/pipe"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// largeValueSize is the encoded size in bytes above which an argument
+// value is streamed rather than sent inline with the request. This keeps
+// calls with large arguments, such as rendered manifests, under the gRPC
+// message size limit (4MB by default).
+var largeValueSize = 1024 * 1024
+
+// largeValueConn returns a connection that streams the argument values of
+// calls that are larger than largeValueSize with the broker, if the plugin
+// negotiated component.FeatureLargeValues. Otherwise all values are sent
+// inline, which is what plugins that predate the feature expect.
+//
+// Only *pb.FuncSpec_Args requests are changed. The values are served with
+// a PipeService for each value until the call completes, so this works
+// even if the host and the plugin don't share a filesystem.
+func largeValueConn(
+	c grpc.ClientConnInterface,
+	broker *plugin.GRPCBroker,
+	features *pluginargs.FeatureSet,
+	logger hclog.Logger,
+) grpc.ClientConnInterface {
+	return &valueConn{conn: c, broker: broker, features: features, logger: logger}
+}
+
+type valueConn struct {
+	conn     grpc.ClientConnInterface
+	broker   *plugin.GRPCBroker
+	features *pluginargs.FeatureSet
+	logger   hclog.Logger
+}
+
+func (c *valueConn) Invoke(
+	ctx context.Context,
+	method string,
+	args, reply interface{},
+	opts ...grpc.CallOption,
+) error {
+	args, servers, err := c.streamValues(args)
+	if err != nil {
+		return err
+	}
+	defer stopValues(servers)
+
+	return c.conn.Invoke(ctx, method, args, reply, opts...)
+}
+
+func (c *valueConn) NewStream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	method string,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	stream, err := c.conn.NewStream(ctx, desc, method, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &valueClientStream{ClientStream: stream, conn: c}, nil
+}
+
+// streamValues returns m with the values that are larger than
+// largeValueSize replaced by references to pipes served with the broker.
+// m itself is not modified. The returned servers serve the pipes until
+// their value is read or they're stopped, and are nil if no values were
+// replaced.
+func (c *valueConn) streamValues(m interface{}) (interface{}, []*valueServer, error) {
+	args, ok := m.(*pb.FuncSpec_Args)
+	if !ok || c.broker == nil || !c.features.Negotiated().Enabled(component.FeatureLargeValues) {
+		return m, nil, nil
+	}
+
+	var result *pb.FuncSpec_Args
+	var servers []*valueServer
+
+	for i, v := range args.Args {
+		if proto.Size(v) <= largeValueSize {
+			continue
+		}
+
+		data, err := proto.Marshal(v)
+		if err != nil {
+			stopValues(servers)
+			return nil, nil, err
+		}
+
+		// Copy the args the first time we replace a value
+		if result == nil {
+			result = &pb.FuncSpec_Args{Args: append([]*pb.FuncSpec_Value(nil), args.Args...)}
+		}
+
+		s := &valueServer{}
+		servers = append(servers, s)
+		result.Args[i] = &pb.FuncSpec_Value{
+			Name:          v.Name,
+			Type:          v.Type,
+			PrimitiveType: v.PrimitiveType,
+			ElementType:   v.ElementType,
+			Value: &pb.FuncSpec_Value_Stream_{
+				Stream: &pb.FuncSpec_Value_Stream{
+					StreamId: s.Serve(c.broker, data, c.logger),
+					Size:     uint64(len(data)),
+				},
+			},
+		}
+	}
+
+	if result == nil {
+		return m, nil, nil
+	}
+
+	return result, servers, nil
+}
+
+// stopValues stops serving the values of servers.
+func stopValues(servers []*valueServer) {
+	for _, s := range servers {
+		s.Stop()
+	}
+}
+
+// valueClientStream streams the large values of the messages sent on a
+// stream. Each value is served until it is read, and the values that
+// aren't read are stopped when the stream is done.
+type valueClientStream struct {
+	grpc.ClientStream
+
+	conn *valueConn
+
+	lock    sync.Mutex
+	once    sync.Once
+	servers []*valueServer
+}
+
+func (s *valueClientStream) SendMsg(m interface{}) error {
+	m, servers, err := s.conn.streamValues(m)
+	if err != nil {
+		return err
+	}
+	if len(servers) > 0 {
+		s.track(servers)
+	}
+
+	return s.ClientStream.SendMsg(m)
+}
+
+// track keeps servers to stop them when the stream is done, dropping the
+// servers of the values that were already read.
+func (s *valueClientStream) track(servers []*valueServer) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	active := servers
+	for _, v := range s.servers {
+		if !v.Stopped() {
+			active = append(active, v)
+		}
+	}
+	s.servers = active
+
+	s.once.Do(func() {
+		go func() {
+			<-s.Context().Done()
+
+			s.lock.Lock()
+			servers := s.servers
+			s.servers = nil
+			s.lock.Unlock()
+
+			stopValues(servers)
+		}()
+	})
+}
+
+// valueServer serves the encoded value of an argument with a PipeService.
+// The value can only be read once, so the server stops once it is read.
+type valueServer struct {
+	lock    sync.Mutex
+	server  *grpc.Server
+	stopped bool
+}
+
+// Serve starts serving data with the broker and returns the stream ID.
+func (s *valueServer) Serve(broker *plugin.GRPCBroker, data []byte, logger hclog.Logger) uint32 {
+	id := broker.NextId()
+	go broker.AcceptAndServe(id, s.newServer(broker, data, logger))
+	return id
+}
+
+// newServer returns the function that creates the gRPC server serving
+// data.
+func (s *valueServer) newServer(
+	broker *plugin.GRPCBroker,
+	data []byte,
+	logger hclog.Logger,
+) func([]grpc.ServerOption) *grpc.Server {
+	p := &pluginpipe.PipePlugin{
+		Impl: &pipe.Source{R: &valueReader{
+			Reader: bytes.NewReader(data),
+			close:  s.done,
+		}},
+		Logger: logger,
+	}
+
+	return func(opts []grpc.ServerOption) *grpc.Server {
+		server := plugin.DefaultGRPCServer(opts)
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		s.server = server
+
+		if err := p.GRPCServer(broker, server); err != nil {
+			logger.Error("error serving large value", "err", err)
+			s.stopped = true
+		}
+		if s.stopped {
+			server.Stop()
+		}
+
+		return server
+	}
+}
+
+// done stops serving the value after it has been read. This lets the read
+// finish, unlike Stop.
+func (s *valueServer) done() {
+	s.lock.Lock()
+	s.stopped = true
+	server := s.server
+	s.lock.Unlock()
+
+	if server != nil {
+		// The read is still finishing, so this can't wait for it.
+		go server.GracefulStop()
+	}
+}
+
+// Stopped returns true if the value isn't served anymore.
+func (s *valueServer) Stopped() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.stopped
+}
+
+// Stop stops serving the value.
+func (s *valueServer) Stop() {
+	// The lock isn't held while stopping since a read that is canceled
+	// calls done.
+	s.lock.Lock()
+	s.stopped = true
+	server := s.server
+	s.lock.Unlock()
+
+	if server != nil {
+		server.Stop()
+	}
+}
+
+// valueReader reads the data of a value and notifies when the pipe closes
+// it, which happens once the value has been read.
+type valueReader struct {
+	*bytes.Reader
+
+	close func()
+}
+
+func (r *valueReader) Close() error {
+	r.close()
+	return nil
+}
+
+// largeValueRegistrar returns a registrar that registers services that
+// read the argument values streamed by largeValueConn before the request
+// is handled, so the servers only see inline values.
+func largeValueRegistrar(s grpc.ServiceRegistrar, broker *plugin.GRPCBroker) grpc.ServiceRegistrar {
+	return &valueRegistrar{registrar: s, broker: broker}
+}
+
+type valueRegistrar struct {
+	registrar grpc.ServiceRegistrar
+	broker    *plugin.GRPCBroker
+}
+
+func (r *valueRegistrar) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	wrapped := *desc
+	wrapped.Methods = make([]grpc.MethodDesc, len(desc.Methods))
+	for i, m := range desc.Methods {
+		handler := m.Handler
+		m.Handler = func(
+			srv interface{},
+			ctx context.Context,
+			dec func(interface{}) error,
+			interceptor grpc.UnaryServerInterceptor,
+		) (interface{}, error) {
+			return handler(srv, ctx, func(v interface{}) error {
+				if err := dec(v); err != nil {
+					return err
+				}
+
+				return loadValues(ctx, r.broker, v)
+			}, interceptor)
+		}
+
+		wrapped.Methods[i] = m
+	}
+
+	wrapped.Streams = make([]grpc.StreamDesc, len(desc.Streams))
+	for i, st := range desc.Streams {
+		handler := st.Handler
+		st.Handler = func(srv interface{}, stream grpc.ServerStream) error {
+			return handler(srv, &valueServerStream{ServerStream: stream, broker: r.broker})
+		}
+
+		wrapped.Streams[i] = st
+	}
+
+	r.registrar.RegisterService(&wrapped, impl)
+}
+
+// valueServerStream reads the streamed values of the messages received
+// on a stream.
+type valueServerStream struct {
+	grpc.ServerStream
+
+	broker *plugin.GRPCBroker
+}
+
+func (s *valueServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return loadValues(s.Context(), s.broker, m)
+}
+
+// loadValues replaces the streamed values of m, if it is a
+// *pb.FuncSpec_Args, with the values read from their pipes.
+func loadValues(ctx context.Context, broker *plugin.GRPCBroker, m interface{}) error {
+	args, ok := m.(*pb.FuncSpec_Args)
+	if !ok {
+		return nil
+	}
+
+	for i, v := range args.Args {
+		stream := v.GetStream()
+		if stream == nil {
+			continue
+		}
+
+		loaded, err := loadValue(ctx, broker, v.Name, stream)
+		if err != nil {
+			return err
+		}
+
+		args.Args[i] = loaded
+	}
+
+	return nil
+}
+
+// loadValue reads the value of the argument with the given name from the
+// pipe it was streamed with.
+func loadValue(
+	ctx context.Context,
+	broker *plugin.GRPCBroker,
+	name string,
+	stream *pb.FuncSpec_Value_Stream,
+) (*pb.FuncSpec_Value, error) {
+	conn, err := broker.Dial(stream.StreamId)
+	if err != nil {
+		return nil, fmt.Errorf("error reading value of argument %q: %w", name, err)
+	}
+	defer conn.Close()
+
+	raw, err := (&pluginpipe.PipePlugin{}).GRPCClient(ctx, broker, conn)
+	if err != nil {
+		return nil, fmt.Errorf("error reading value of argument %q: %w", name, err)
+	}
+	r := raw.(*pipe.Reader)
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading value of argument %q: %w", name, err)
+	}
+	if uint64(len(data)) != stream.Size {
+		return nil, fmt.Errorf(
+			"error reading value of argument %q: expected %d bytes, got %d",
+			name, stream.Size, len(data))
+	}
+
+	var result pb.FuncSpec_Value
+	if err := proto.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("error decoding value of argument %q: %w", name, err)
+	}
+	if result.GetStream() != nil {
+		return nil, fmt.Errorf("value of argument %q references another stream", name)
+	}
+
+	return &result, nil
+}
+
+var (
+	_ grpc.ClientConnInterface = (*valueConn)(nil)
+	_ grpc.ServiceRegistrar    = (*valueRegistrar)(nil)
+)
@@ -108,9 +108,9 @@ func (s *logPlatformServer) Logs(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	_, err := callDynamicFunc2(s.Impl.(component.LogPlatform).LogsFunc(), args.Args,
+	_, err := callDynamicFunc2(ctx, s.Impl.(component.LogPlatform).LogsFunc(), args.Args,
 		argmapper.Typed(ctx),
-		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Typed(internal),
 	)
 	return &empty.Empty{}, err
@@ -2,25 +2,56 @@ package logs
 
 import (
 	"context"
+	"io"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
 
+// defaultMaxEvents is the number of events the host requests per batch.
+const defaultMaxEvents = 100
+
+// batchSize is the number of events the host requests per batch, which
+// tests lower to split the events into several batches.
+var batchSize = defaultMaxEvents
+
+// maxReconnects is the number of times in a row the plugin reopens the
+// NextBatch stream after it fails, waiting reconnectDelay in between.
+// Events the host hasn't acknowledged are sent again on the new stream.
+const maxReconnects = 5
+
+var reconnectDelay = 500 * time.Millisecond
+
 // UIPlugin implements plugin.Plugin (specifically GRPCPlugin) for
 // the terminal.UI interface.
 type LogsPlugin struct {
 	plugin.NetRPCUnsupportedPlugin
 
-	Impl    *component.LogViewer // Impl is the concrete implementation
-	Mappers []*argmapper.Func    // Mappers
-	Logger  hclog.Logger         // Logger
+	Impl     *component.LogViewer // Impl is the concrete implementation
+	Mappers  []*argmapper.Func    // Mappers
+	Logger   hclog.Logger         // Logger
+	Internal *pluginargs.Internal // Internal sets up the client connection
+
+	// Cleanup is used by the client to send any buffered events and
+	// close the stream once the plugin call is complete.
+	Cleanup *pluginargs.Cleanup
+
+	// ResumeToken is used by the client to skip the events up to the
+	// position in the token. See component.LogViewer.ResumeToken.
+	ResumeToken string
 }
 
 func (p *LogsPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
@@ -39,75 +70,295 @@ func (p *LogsPlugin) GRPCClient(
 ) (interface{}, error) {
 	p.Logger.Debug("starting logviewer client")
 
-	client := pb.NewLogViewerClient(c)
+	resume, err := component.ParseLogResumeToken(p.ResumeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	client := pb.NewLogViewerClient(p.Internal.ClientConn(c))
 
-	nlb, err := client.NextLogBatch(ctx)
+	stream, err := client.NextBatch(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO(evanphx) figure out the right backlog for this
+	// The output is buffered only so plugins can write a handful of events
+	// without waiting on the host. The host pulls events from here in
+	// batches so memory is bounded by this buffer plus one batch.
 	output := make(chan component.LogEvent, 10)
 
-	/*
-		go func() {
-			for {
-				req, err := stream.Recv()
-				if err != nil {
-					p.Logger.Debug("exec plugin input stream exitted", "error", err)
-					return
-				}
-
-				p.Logger.Debug("processing logviewer events", "events", len(req.Events))
-
-				for _, ev := range req.Events {
-
-					out := component.LogEvent{
-						Partition: ev.Partition,
-						Timestamp: ev.Timestamp.AsTime(),
-						Message:   ev.Contents,
-					}
-					select {
-					case <-ctx.Done():
-						return
-					case output <- out:
-						// ok
-					}
-				}
-			}
-		}()
-	*/
+	b := &batcher{
+		ctx:    ctx,
+		log:    p.Logger,
+		output: output,
+		stopCh: make(chan struct{}),
+		resume: resume,
+	}
 
+	doneCh := make(chan struct{})
 	go func() {
+		defer close(doneCh)
+
+		err := b.run(client, stream)
+		if err != nil && err != io.EOF && ctx.Err() == nil {
+			p.Logger.Warn("error sending log events", "err", err)
+		}
+
+		// Discard the events the plugin writes after the host stopped
+		// reading, such as after reaching its limit, so the plugin doesn't
+		// block writing them.
 		for {
 			select {
+			case <-output:
+			case <-b.stopCh:
+				return
 			case <-ctx.Done():
 				return
-			case cle, ok := <-output:
-				if !ok {
-					return
-				}
-
-				nlb.Send(&pb.Logs_NextBatchResp{
-					Events: []*pb.Logs_Event{
-						{
-							Partition: cle.Partition,
-							Timestamp: timestamppb.New(cle.Timestamp),
-							Contents:  cle.Message,
-						},
-					},
-				})
 			}
 		}
 	}()
 
+	if p.Cleanup != nil {
+		p.Cleanup.Do(func() {
+			close(b.stopCh)
+			<-doneCh
+		})
+	}
+
 	lv := &component.LogViewer{
-		Output: output,
+		Output:      output,
+		ResumeToken: p.ResumeToken,
 	}
 
 	return lv, nil
 }
 
+// batcher reads events from the plugin's LogViewer output and sends them
+// to the host in the batches the host requests.
+type batcher struct {
+	ctx    context.Context
+	log    hclog.Logger
+	output <-chan component.LogEvent
+	stopCh chan struct{}
+
+	// seq is the sequence number of the last event read from output.
+	seq uint64
+
+	// pending are the events sent to the host that haven't been
+	// acknowledged yet and pendingSeq is the sequence number of the
+	// first pending event.
+	pending    []*pb.Logs_Event
+	pendingSeq uint64
+
+	// resume is the position of the ResumeToken. Events up to it are
+	// dropped rather than sent.
+	resume component.LogPosition
+}
+
+// run serves the host over stream, reopening the stream if it fails.
+// This falls back to push if the host doesn't support NextBatch.
+func (b *batcher) run(client pb.LogViewerClient, stream pb.LogViewer_NextBatchClient) error {
+	failures := 0
+	for {
+		acked := b.pendingSeq
+		err := b.serve(stream)
+		if err == nil || err == io.EOF {
+			return err
+		}
+
+		if status.Code(err) == codes.Unimplemented {
+			// The host is too old to support NextBatch, fall back to
+			// pushing events as they arrive.
+			b.log.Debug("host doesn't support NextBatch, using NextLogBatch")
+			return b.push(client)
+		}
+
+		// Only count failures in a row, a stream that made progress
+		// before it failed starts over.
+		if b.pendingSeq != acked {
+			failures = 0
+		}
+		failures++
+		if failures > maxReconnects || b.ctx.Err() != nil {
+			return err
+		}
+
+		b.log.Debug("log stream failed, reconnecting", "err", err, "attempt", failures)
+		select {
+		case <-b.ctx.Done():
+			return b.ctx.Err()
+		case <-time.After(reconnectDelay):
+		}
+
+		stream, err = client.NextBatch(b.ctx)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// serve answers NextBatch requests from the host until the plugin is done
+// and every event has been acknowledged.
+func (b *batcher) serve(stream pb.LogViewer_NextBatchClient) error {
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		b.ack(req.Cursor)
+
+		// If the host didn't acknowledge everything we sent, it is
+		// asking again so we resend the pending events. Otherwise we
+		// wait for new events.
+		if len(b.pending) == 0 {
+			b.pendingSeq = b.seq + 1
+			b.pending = b.next(int(req.MaxEvents))
+		}
+
+		// No pending events means the plugin is done and the host has
+		// everything, so we're done.
+		if len(b.pending) == 0 {
+			return stream.CloseSend()
+		}
+
+		events := b.pending
+		if max := int(req.MaxEvents); max > 0 && len(events) > max {
+			events = events[:max]
+		}
+
+		if err := stream.Send(&pb.Logs_NextBatchResp{
+			Events: events,
+			Cursor: strconv.FormatUint(b.pendingSeq+uint64(len(events))-1, 10),
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// ack drops all pending events up to and including the given cursor.
+func (b *batcher) ack(cursor string) {
+	if cursor == "" {
+		return
+	}
+
+	seq, err := strconv.ParseUint(cursor, 10, 64)
+	if err != nil {
+		b.log.Warn("invalid log cursor from host", "cursor", cursor)
+		return
+	}
+
+	if seq < b.pendingSeq {
+		return
+	}
+
+	n := int(seq - b.pendingSeq + 1)
+	if n > len(b.pending) {
+		n = len(b.pending)
+	}
+
+	b.pending = b.pending[n:]
+	b.pendingSeq += uint64(n)
+}
+
+// next blocks until at least one event is available and then returns up to
+// max events without blocking further. This returns nil if the plugin is
+// done and there are no more events.
+func (b *batcher) next(max int) []*pb.Logs_Event {
+	if max <= 0 {
+		max = defaultMaxEvents
+	}
+
+	var result []*pb.Logs_Event
+	for len(result) == 0 {
+		select {
+		case <-b.ctx.Done():
+			return nil
+
+		case ev := <-b.output:
+			if !b.skip(ev) {
+				result = append(result, b.event(ev))
+			}
+
+		case <-b.stopCh:
+			// The plugin is done, but we still drain what it already sent.
+			return b.drain(max)
+		}
+	}
+
+	return append(result, b.drain(max-len(result))...)
+}
+
+// drain returns up to max events that are available without blocking.
+func (b *batcher) drain(max int) []*pb.Logs_Event {
+	var result []*pb.Logs_Event
+	for len(result) < max {
+		select {
+		case ev := <-b.output:
+			if !b.skip(ev) {
+				result = append(result, b.event(ev))
+			}
+		default:
+			return result
+		}
+	}
+
+	return result
+}
+
+// skip returns true if ev is at or before the resume position.
+func (b *batcher) skip(ev component.LogEvent) bool {
+	switch {
+	case b.resume.Count == 0:
+		return false
+
+	case ev.Timestamp.Before(b.resume.Time):
+		return true
+
+	case ev.Timestamp.Equal(b.resume.Time):
+		b.resume.Count--
+		return true
+
+	default:
+		// Past the resume position, so nothing else is skipped
+		b.resume = component.LogPosition{}
+		return false
+	}
+}
+
+func (b *batcher) event(ev component.LogEvent) *pb.Logs_Event {
+	b.seq++
+	return &pb.Logs_Event{
+		Partition: ev.Partition,
+		Timestamp: timestamppb.New(ev.Timestamp),
+		Contents:  ev.Message,
+		Severity:  pb.Logs_Event_Severity(ev.Severity),
+		Source:    pb.Logs_Event_Source(ev.Source),
+		Metadata:  ev.Metadata,
+	}
+}
+
+// push sends events to the host as they arrive using the legacy
+// NextLogBatch RPC.
+func (b *batcher) push(client pb.LogViewerClient) error {
+	nlb, err := client.NextLogBatch(b.ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		events := b.next(defaultMaxEvents)
+		if len(events) == 0 {
+			_, err := nlb.CloseAndRecv()
+			return err
+		}
+
+		if err := nlb.Send(&pb.Logs_NextBatchResp{Events: events}); err != nil {
+			return err
+		}
+	}
+}
+
 // logsServer is a gRPC server that the client talks to and calls a
 // real implementation of the component.
 type logsServer struct {
@@ -116,57 +367,140 @@ type logsServer struct {
 	Impl    *component.LogViewer
 	Mappers []*argmapper.Func
 	Logger  hclog.Logger
+
+	// cursor is the cursor of the last batch received and delivered is
+	// the number of events of the following batch already sent to the
+	// output, if a stream failed partway through sending it. These are
+	// kept across NextBatch calls so a plugin that reconnects continues
+	// after the events we already have. streamMu is held for the whole
+	// NextBatch call so only one stream is served at a time.
+	streamMu  sync.Mutex
+	cursor    string
+	delivered int
+
+	// sent is the number of events sent to the output, which stops at
+	// the Limit of the LogViewer.
+	sent int
+}
+
+func (s *logsServer) NextBatch(stream pb.LogViewer_NextBatchServer) error {
+	s.Logger.Debug("starting nextbatch rpc")
+	defer s.Logger.Debug("ending nextbatch rpc")
+
+	s.streamMu.Lock()
+	defer s.streamMu.Unlock()
+
+	for {
+		// Don't request more events than we still need to reach the limit.
+		max := batchSize
+		if remaining, ok := s.remaining(); ok {
+			if remaining == 0 {
+				return nil
+			}
+			if remaining < max {
+				max = remaining
+			}
+		}
+
+		if err := stream.Send(&pb.Logs_NextBatchRequest{
+			MaxEvents: uint32(max),
+			Cursor:    s.cursor,
+		}); err != nil {
+			return err
+		}
+
+		batch, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// The plugin resends the batch we didn't acknowledge, so skip
+		// the events of it we already sent.
+		events := batch.Events
+		if s.delivered > len(events) {
+			s.delivered = len(events)
+		}
+
+		n, err := s.send(stream.Context(), events[s.delivered:])
+		if err != nil {
+			s.delivered += n
+			return nil
+		}
+
+		s.cursor = batch.Cursor
+		s.delivered = 0
+	}
 }
 
 func (s *logsServer) NextLogBatch(lv pb.LogViewer_NextLogBatchServer) error {
 	s.Logger.Debug("starting nextlogbatch rpc")
 	defer s.Logger.Debug("ending nextlogbatch rpc")
 
+	s.streamMu.Lock()
+	defer s.streamMu.Unlock()
+
 	for {
+		if remaining, ok := s.remaining(); ok && remaining == 0 {
+			return lv.SendAndClose(&empty.Empty{})
+		}
+
 		chunk, err := lv.Recv()
+		if err == io.EOF {
+			return lv.SendAndClose(&empty.Empty{})
+		}
 		if err != nil {
 			return err
 		}
 
-		for _, ev := range chunk.Events {
-			out := component.LogEvent{
-				Partition: ev.Partition,
-				Timestamp: ev.Timestamp.AsTime(),
-				Message:   ev.Contents,
-			}
-			select {
-			case <-lv.Context().Done():
-				return nil
-			case s.Impl.Output <- out:
-				// ok
-			}
+		if _, err := s.send(lv.Context(), chunk.Events); err != nil {
+			return nil
 		}
 	}
+}
 
-	/*
-		for {
-			select {
-			case <-lv.Context().Done():
-				return lv.Context().Err()
-			case log, ok := <-s.Impl.Output:
-				if !ok {
-					return io.EOF
-				}
-
-				s.Logger.Debug("sending log event")
-
-				lv.Send(&pb.Logs_NextBatchResp{
-					Events: []*pb.Logs_Event{
-						{
-							Partition: log.Partition,
-							Timestamp: timestamppb.New(log.Timestamp),
-							Contents:  log.Message,
-						},
-					},
-				})
-			}
+// remaining returns the number of events that can still be sent to the
+// output before reaching the Limit of the LogViewer. This returns false if
+// there is no limit.
+func (s *logsServer) remaining() (int, bool) {
+	if s.Impl.Limit <= 0 {
+		return 0, false
+	}
+	if s.sent >= s.Impl.Limit {
+		return 0, true
+	}
+
+	return s.Impl.Limit - s.sent, true
+}
+
+// send sends the events to the LogViewer output, blocking until they're
+// all sent, the Limit of the LogViewer is reached or the context is
+// cancelled. This returns the number of events sent.
+func (s *logsServer) send(ctx context.Context, events []*pb.Logs_Event) (int, error) {
+	if remaining, ok := s.remaining(); ok && len(events) > remaining {
+		events = events[:remaining]
+	}
+
+	for i, ev := range events {
+		out := component.LogEvent{
+			Partition: ev.Partition,
+			Timestamp: ev.Timestamp.AsTime(),
+			Message:   ev.Contents,
+			Severity:  component.LogSeverity(ev.Severity),
+			Source:    component.LogSource(ev.Source),
+			Metadata:  ev.Metadata,
+		}
+		select {
+		case <-ctx.Done():
+			return i, ctx.Err()
+		case s.Impl.Output <- out:
+			s.sent++
 		}
-	*/
+	}
+
+	return len(events), nil
 }
 
 var (
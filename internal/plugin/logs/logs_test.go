@@ -0,0 +1,356 @@
+package logs
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+func TestLogsPlugin(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func(n int) { batchSize = n }(batchSize)
+	batchSize = 7
+
+	// Host side
+	hostOutput := make(chan component.LogEvent)
+	host := &LogsPlugin{
+		Impl:   &component.LogViewer{Output: hostOutput},
+		Logger: hclog.L(),
+	}
+
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		require.NoError(host.GRPCServer(nil, s))
+	})
+	defer conn.Close()
+	defer server.Stop()
+
+	// Plugin side
+	var cleanup pluginargs.Cleanup
+	client := &LogsPlugin{
+		Logger:  hclog.L(),
+		Cleanup: &cleanup,
+	}
+
+	raw, err := client.GRPCClient(ctx, nil, conn)
+	require.NoError(err)
+	lv := raw.(*component.LogViewer)
+
+	// Read everything on the host side
+	const total = 50
+	receivedCh := make(chan []component.LogEvent)
+	go func() {
+		var received []component.LogEvent
+		for ev := range hostOutput {
+			received = append(received, ev)
+			if len(received) == total {
+				break
+			}
+		}
+		receivedCh <- received
+	}()
+
+	// Write events more quickly than the host reads them
+	for i := 0; i < total; i++ {
+		lv.Output <- component.LogEvent{
+			Partition: "test",
+			Timestamp: time.Now(),
+			Message:   strconv.Itoa(i),
+			Severity:  component.LogSeverityWarn,
+			Source:    component.LogSourceStderr,
+			Metadata:  map[string]string{"seq": strconv.Itoa(i)},
+		}
+	}
+
+	// Flush
+	require.NoError(cleanup.Close())
+
+	var received []component.LogEvent
+	select {
+	case received = <-receivedCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for events")
+	}
+
+	// Every event should arrive exactly once and in order
+	require.Len(received, total)
+	for i, ev := range received {
+		require.Equal(strconv.Itoa(i), ev.Message)
+		require.Equal("test", ev.Partition)
+		require.Equal(component.LogSeverityWarn, ev.Severity)
+		require.Equal(component.LogSourceStderr, ev.Source)
+		require.Equal(map[string]string{"seq": strconv.Itoa(i)}, ev.Metadata)
+	}
+}
+
+func TestBatcherAck(t *testing.T) {
+	require := require.New(t)
+
+	output := make(chan component.LogEvent, 5)
+	for i := 0; i < 5; i++ {
+		output <- component.LogEvent{Message: strconv.Itoa(i)}
+	}
+
+	b := &batcher{
+		ctx:    context.Background(),
+		log:    hclog.L(),
+		output: output,
+		stopCh: make(chan struct{}),
+	}
+
+	b.pendingSeq = b.seq + 1
+	b.pending = b.next(5)
+	require.Len(b.pending, 5)
+
+	// Partial ack keeps the rest
+	b.ack("2")
+	require.Len(b.pending, 3)
+	require.Equal("2", b.pending[0].Contents)
+
+	// Old acks are ignored
+	b.ack("1")
+	require.Len(b.pending, 3)
+
+	// Full ack drops everything
+	b.ack("5")
+	require.Empty(b.pending)
+}
+
+func TestLogEventEnums(t *testing.T) {
+	require := require.New(t)
+
+	// The component values are converted directly to the proto values, so
+	// they must match.
+	for s := component.LogSeverityUnknown; s <= component.LogSeverityError; s++ {
+		require.Equal(strings.ToUpper(s.String()), pb.Logs_Event_Severity(s).String())
+	}
+
+	require.Equal("STDOUT", pb.Logs_Event_Source(component.LogSourceStdout).String())
+	require.Equal("STDERR", pb.Logs_Event_Source(component.LogSourceStderr).String())
+	require.Equal("UNSPECIFIED", pb.Logs_Event_Source(component.LogSourceUnknown).String())
+}
+
+func TestLogsPlugin_reconnect(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func(d time.Duration) { reconnectDelay = d }(reconnectDelay)
+	reconnectDelay = time.Millisecond
+
+	defer func(n int) { batchSize = n }(batchSize)
+	batchSize = 3
+
+	// Host side, reading 3 events per batch
+	hostOutput := make(chan component.LogEvent)
+	host := &LogsPlugin{
+		Impl:   &component.LogViewer{Output: hostOutput},
+		Logger: hclog.L(),
+	}
+
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		require.NoError(host.GRPCServer(nil, s))
+	})
+	defer conn.Close()
+	defer server.Stop()
+
+	// The first stream fails after the host received the first batch but
+	// before the plugin got the acknowledgement.
+	client := &failingClient{
+		LogViewerClient: pb.NewLogViewerClient(conn),
+		failAfter:       2,
+	}
+
+	const total = 10
+	output := make(chan component.LogEvent, total)
+	for i := 0; i < total; i++ {
+		output <- component.LogEvent{Message: strconv.Itoa(i)}
+	}
+
+	b := &batcher{
+		ctx:    ctx,
+		log:    hclog.L(),
+		output: output,
+		stopCh: make(chan struct{}),
+	}
+	close(b.stopCh)
+
+	stream, err := client.NextBatch(ctx)
+	require.NoError(err)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- b.run(client, stream) }()
+
+	var received []string
+	for len(received) < total {
+		select {
+		case ev := <-hostOutput:
+			received = append(received, ev.Message)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for events")
+		}
+	}
+
+	require.NoError(<-errCh)
+	require.Equal(2, client.calls)
+
+	// Every event arrives exactly once and in order
+	for i, msg := range received {
+		require.Equal(strconv.Itoa(i), msg)
+	}
+}
+
+func TestLogsPlugin_limit(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func(n int) { batchSize = n }(batchSize)
+	batchSize = 2
+
+	// Host side, stopping after 5 events
+	hostOutput := make(chan component.LogEvent, 10)
+	host := &LogsPlugin{
+		Impl:   &component.LogViewer{Output: hostOutput, Limit: 5},
+		Logger: hclog.L(),
+	}
+
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		require.NoError(host.GRPCServer(nil, s))
+	})
+	defer conn.Close()
+	defer server.Stop()
+
+	// Plugin side, which never finishes on its own
+	const total = 10
+	output := make(chan component.LogEvent, total)
+	for i := 0; i < total; i++ {
+		output <- component.LogEvent{Message: strconv.Itoa(i)}
+	}
+
+	b := &batcher{
+		ctx:    ctx,
+		log:    hclog.L(),
+		output: output,
+		stopCh: make(chan struct{}),
+	}
+
+	client := pb.NewLogViewerClient(conn)
+	stream, err := client.NextBatch(ctx)
+	require.NoError(err)
+
+	// The host ends the stream once it has the limit
+	errCh := make(chan error, 1)
+	go func() { errCh <- b.run(client, stream) }()
+	select {
+	case err := <-errCh:
+		require.Equal(io.EOF, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the stream to end")
+	}
+
+	close(hostOutput)
+	var received []string
+	for ev := range hostOutput {
+		received = append(received, ev.Message)
+	}
+	require.Equal([]string{"0", "1", "2", "3", "4"}, received)
+}
+
+func TestBatcherResume(t *testing.T) {
+	require := require.New(t)
+
+	t0 := time.Unix(100, 0)
+	t1 := t0.Add(time.Second)
+	t2 := t1.Add(time.Second)
+
+	output := make(chan component.LogEvent, 5)
+	output <- component.LogEvent{Timestamp: t0, Message: "a"}
+	output <- component.LogEvent{Timestamp: t1, Message: "b"}
+	output <- component.LogEvent{Timestamp: t1, Message: "c"}
+	output <- component.LogEvent{Timestamp: t2, Message: "d"}
+	output <- component.LogEvent{Timestamp: t1, Message: "e"}
+
+	b := &batcher{
+		ctx:    context.Background(),
+		log:    hclog.L(),
+		output: output,
+		stopCh: make(chan struct{}),
+		resume: component.LogPosition{Time: t1, Count: 1},
+	}
+
+	// Events up to the position are skipped, after that nothing is
+	var msgs []string
+	for _, ev := range b.next(10) {
+		msgs = append(msgs, ev.Contents)
+	}
+	require.Equal([]string{"c", "d", "e"}, msgs)
+}
+
+// failingClient is a LogViewerClient where the first NextBatch stream
+// fails after receiving failAfter messages.
+type failingClient struct {
+	pb.LogViewerClient
+
+	failAfter int
+	calls     int
+}
+
+func (c *failingClient) NextBatch(
+	ctx context.Context,
+	opts ...grpc.CallOption,
+) (pb.LogViewer_NextBatchClient, error) {
+	c.calls++
+	if c.calls > 1 {
+		return c.LogViewerClient.NextBatch(ctx, opts...)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.LogViewerClient.NextBatch(ctx, opts...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return &failingStream{
+		LogViewer_NextBatchClient: stream,
+		remaining:                 c.failAfter,
+		cancel:                    cancel,
+	}, nil
+}
+
+type failingStream struct {
+	pb.LogViewer_NextBatchClient
+
+	remaining int
+	cancel    context.CancelFunc
+}
+
+func (s *failingStream) Recv() (*pb.Logs_NextBatchRequest, error) {
+	if s.remaining == 0 {
+		s.cancel()
+		return nil, status.Error(codes.Unavailable, "connection lost")
+	}
+
+	s.remaining--
+	return s.LogViewer_NextBatchClient.Recv()
+}
@@ -1,8 +1,14 @@
 package plugin
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
@@ -16,6 +22,7 @@ import (
 	"google.golang.org/protobuf/reflect/protoregistry"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal-shared/protomappers"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
@@ -28,6 +35,12 @@ type MapperPlugin struct {
 
 	Mappers []*argmapper.Func // Mappers
 	Logger  hclog.Logger      // Logger
+
+	// client is the client dispensed for conn. The plugin set is created
+	// for each plugin client, so this is scoped to the plugin client.
+	clientLock sync.Mutex
+	client     *MapperClient
+	conn       *grpc.ClientConn
 }
 
 func (p *MapperPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
@@ -43,20 +56,73 @@ func (p *MapperPlugin) GRPCClient(
 	broker *plugin.GRPCBroker,
 	c *grpc.ClientConn,
 ) (interface{}, error) {
-	return &MapperClient{
-		client: pb.NewMapperClient(c),
-		logger: p.Logger,
-	}, nil
+	p.clientLock.Lock()
+	defer p.clientLock.Unlock()
+
+	// The client is dispensed for every operation, so we reuse the client
+	// of the connection to keep its mappers cached.
+	if p.client == nil || p.conn != c {
+		p.client = &MapperClient{
+			client: pb.NewMapperClient(c),
+			logger: p.Logger,
+		}
+		p.conn = c
+	}
+
+	return p.client, nil
 }
 
 // MapperClient is an implementation of component.Mapper over gRPC.
+//
+// Mappers that take the same arguments are called together with one
+// MapBatch call, see mapGroup.
+//
+// The mappers are listed the first time Mappers is called and cached
+// until Invalidate is called. The same MapperClient is dispensed for a
+// plugin client, so the cache is shared by every operation of a plugin.
 type MapperClient struct {
 	client pb.MapperClient
 	logger hclog.Logger
+
+	lock    sync.Mutex
+	funcs   []*argmapper.Func
+	fetched bool
+
+	// unbatched is true if the plugin doesn't support MapBatch.
+	unbatched int32
 }
 
 // Mappers returns the list of mappers that are supported by this plugin.
 func (c *MapperClient) Mappers() ([]*argmapper.Func, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.fetched {
+		funcs, err := c.listMappers()
+		if err != nil {
+			return nil, err
+		}
+
+		c.funcs = funcs
+		c.fetched = true
+	}
+
+	// Copy so callers can append to the list
+	return append([]*argmapper.Func(nil), c.funcs...), nil
+}
+
+// Invalidate clears the cached mappers so the next call to Mappers lists
+// them again. Call this if the mappers of the plugin may have changed.
+func (c *MapperClient) Invalidate() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.funcs = nil
+	c.fetched = false
+}
+
+// listMappers lists the mappers of the plugin and builds their funcs.
+func (c *MapperClient) listMappers() ([]*argmapper.Func, error) {
 	// Get our list of mapper FuncSpecs
 	resp, err := c.client.ListMappers(context.Background(), &empty.Empty{})
 	if err != nil {
@@ -65,33 +131,180 @@ func (c *MapperClient) Mappers() ([]*argmapper.Func, error) {
 
 	// For each FuncSpec we turn that into a real mapper.Func which calls back
 	// into our clien to make an RPC call to generate the proper type.
+	// Mappers with the same arguments share a group so they are called
+	// with one RPC.
+	groups := map[string]*mapGroup{}
 	var funcs []*argmapper.Func
 	for _, spec := range resp.Funcs {
-		specCopy := spec
-
-		// We use a closure here to capture spec so that we can provide
-		// the correct result type. All we're doing is making our callback
-		// call the Map RPC call and return the result/error.
-		cb := func(ctx context.Context, args funcspec.Args) (*opaqueany.Any, error) {
-			resp, err := c.client.Map(ctx, &pb.Map_Request{
-				Args:   &pb.FuncSpec_Args{Args: args},
-				Result: specCopy.Result[0].Type,
-			})
-			if err != nil {
-				return nil, err
+		sig := mapperSignature(spec)
+		g, ok := groups[sig]
+		if !ok {
+			g = &mapGroup{client: c}
+			groups[sig] = g
+		}
+		member := g.add(spec)
+
+		// argmapper can only use one *opaqueany.Any output of a converter,
+		// so a mapper with several results becomes one function for each
+		// result. They share the call so the mapper is called once when
+		// more than one of its results is needed.
+		for i, result := range spec.Result {
+			specCopy := proto.Clone(spec).(*pb.FuncSpec)
+			specCopy.Result = []*pb.FuncSpec_Value{result}
+			cb := g.callback(member, i)
+
+			// Build our funcspec function
+			f := funcspec.Func(specCopy, cb, argmapper.Logger(c.logger))
+
+			// Accumulate our functions
+			funcs = append(funcs, f)
+		}
+	}
+
+	return funcs, nil
+}
+
+// mapperSignature returns a key that is equal for mappers that take the
+// same arguments.
+func mapperSignature(spec *pb.FuncSpec) string {
+	var args []string
+	for _, arg := range spec.Args {
+		args = append(args, fmt.Sprintf("%s/%s/%s", arg.Name, arg.Type, arg.PrimitiveType))
+	}
+	sort.Strings(args)
+
+	return strings.Join(args, ",")
+}
+
+// mapGroup calls the mappers of a plugin that take the same arguments.
+// argmapper usually needs several of them to build the arguments of an
+// operation, so when one of them is called, all of them are called with
+// the same args in one MapBatch call. The results are reused by the
+// callbacks of the group if they are called with the same args, but each
+// result is only used once so later operations call the mappers again.
+type mapGroup struct {
+	client *MapperClient
+
+	// results are the result types of each mapper of the group.
+	results [][]string
+
+	lock     sync.Mutex
+	lastArgs []byte
+	last     []*mapResult
+}
+
+// mapResult is the result of a mapper in the last call of a group.
+type mapResult struct {
+	values []*opaqueany.Any
+	err    error
+	used   []bool
+}
+
+// add adds the mapper of spec to the group and returns its index.
+func (g *mapGroup) add(spec *pb.FuncSpec) int {
+	var results []string
+	for _, r := range spec.Result {
+		results = append(results, r.Type)
+	}
+
+	g.results = append(g.results, results)
+	return len(g.results) - 1
+}
+
+// callback returns the callback for the funcspec.Func of result idx of
+// mapper member.
+func (g *mapGroup) callback(member, idx int) interface{} {
+	return func(ctx context.Context, args funcspec.Args) ([]*opaqueany.Any, error) {
+		key, err := proto.MarshalOptions{Deterministic: true}.Marshal(&pb.FuncSpec_Args{Args: args})
+		if err != nil {
+			return nil, err
+		}
+
+		g.lock.Lock()
+		defer g.lock.Unlock()
+		if g.last != nil && bytes.Equal(key, g.lastArgs) {
+			if r := g.last[member]; r != nil && !r.used[idx] {
+				r.used[idx] = true
+				return r.values, r.err
 			}
+		}
 
-			return resp.Result, nil
+		last, err := g.call(ctx, args, member)
+		if err != nil {
+			return nil, err
 		}
 
-		// Build our funcspec function
-		f := funcspec.Func(specCopy, cb, argmapper.Logger(c.logger))
+		g.last = last
+		g.lastArgs = key
+		r := last[member]
+		r.used[idx] = true
+		return r.values, r.err
+	}
+}
 
-		// Accumulate our functions
-		funcs = append(funcs, f)
+// call calls the mappers of the group with args. The result of member is
+// always set, the others are nil if they weren't called.
+func (g *mapGroup) call(
+	ctx context.Context,
+	args funcspec.Args,
+	member int,
+) ([]*mapResult, error) {
+	reqs := make([]*pb.Map_Request, len(g.results))
+	for i, results := range g.results {
+		reqs[i] = &pb.Map_Request{
+			Args:    &pb.FuncSpec_Args{Args: args},
+			Result:  results[0],
+			Results: results,
+		}
 	}
 
-	return funcs, nil
+	last := make([]*mapResult, len(reqs))
+	if len(reqs) > 1 && atomic.LoadInt32(&g.client.unbatched) == 0 {
+		resp, err := g.client.client.MapBatch(ctx, &pb.Map_BatchRequest{Requests: reqs})
+		if err == nil && len(resp.Results) == len(reqs) {
+			for i, r := range resp.Results {
+				last[i] = &mapResult{used: make([]bool, len(g.results[i]))}
+				if r.Error != nil {
+					last[i].err = mapperError(status.FromProto(r.Error).Err())
+				} else {
+					last[i].values = responseResults(r.Response)
+				}
+			}
+
+			return last, nil
+		}
+		if status.Code(err) != codes.Unimplemented {
+			if err == nil {
+				err = fmt.Errorf("plugin returned %d results for %d mappers", len(resp.Results), len(reqs))
+			}
+
+			return nil, mapperError(err)
+		}
+
+		// Older plugins only support Map
+		atomic.StoreInt32(&g.client.unbatched, 1)
+	}
+
+	resp, err := g.client.client.Map(ctx, reqs[member])
+	if err != nil {
+		return nil, mapperError(err)
+	}
+
+	last[member] = &mapResult{
+		values: responseResults(resp),
+		used:   make([]bool, len(g.results[member])),
+	}
+	return last, nil
+}
+
+// responseResults returns the results of a Map response. Plugins that
+// only support a single result only set result.
+func responseResults(resp *pb.Map_Response) []*opaqueany.Any {
+	if len(resp.Results) == 0 {
+		return []*opaqueany.Any{resp.Result}
+	}
+
+	return resp.Results
 }
 
 // mapperServer is a gRPC server that implements the Mapper service.
@@ -106,9 +319,15 @@ func (s *mapperServer) ListMappers(
 	ctx context.Context,
 	empty *empty.Empty,
 ) (*pb.Map_ListResponse, error) {
+	return &pb.Map_ListResponse{Funcs: mapperSpecs(s.Mappers, s.Logger)}, nil
+}
+
+// mapperSpecs returns the FuncSpecs of the mappers that the plugin
+// provides, skipping the built-in protomappers.
+func mapperSpecs(mappers []*argmapper.Func, logger hclog.Logger) []*pb.FuncSpec {
 	// Go through each mapper and build up our FuncSpecs for each of them.
-	var result pb.Map_ListResponse
-	for _, m := range s.Mappers {
+	var result []*pb.FuncSpec
+	for _, m := range mappers {
 		fn := m.Func()
 
 		// Skip our built-in protomappers
@@ -117,10 +336,10 @@ func (s *mapperServer) ListMappers(
 		}
 
 		spec, err := funcspec.Spec(fn,
-			argmapper.ConverterFunc(s.Mappers...),
-			argmapper.Logger(s.Logger))
+			argmapper.ConverterFunc(mappers...),
+			argmapper.Logger(logger))
 		if err != nil {
-			s.Logger.Warn(
+			logger.Warn(
 				"error converting mapper, will not notify plugin host",
 				"func", m.String(),
 				"err", err,
@@ -128,48 +347,139 @@ func (s *mapperServer) ListMappers(
 			continue
 		}
 
-		result.Funcs = append(result.Funcs, spec)
+		result = append(result, spec)
 	}
 
-	return &result, nil
+	return result
 }
 
 func (s *mapperServer) Map(
 	ctx context.Context,
 	args *pb.Map_Request,
 ) (*pb.Map_Response, error) {
-	// Find the output type, which we should know about.
-	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(args.Result))
-	if err != nil {
-		return nil, status.Newf(
-			codes.FailedPrecondition,
-			"output type is not known: %s",
-			args.Result,
-		).Err()
+	results := args.Results
+	if len(results) == 0 {
+		results = []string{args.Result}
 	}
 
-	typ := reflect.TypeOf(proto.Message(mt.Zero().Interface()))
+	// Find the output types, which we should know about.
+	var types []reflect.Type
+	for _, name := range results {
+		mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, status.Newf(
+				codes.FailedPrecondition,
+				"output type is not known: %s",
+				name,
+			).Err()
+		}
 
-	// Build our function that expects this type as an argument
-	// so that we can return it. We do this dynamic function thing so
+		types = append(types, reflect.TypeOf(proto.Message(mt.Zero().Interface())))
+	}
+
+	// Build our function that expects these types as arguments
+	// so that we can return them. We do this dynamic function thing so
 	// that we can just pretend that this is a function we have so that
-	// callDynamicFunc just works.
+	// callDynamicFunc just works. A mapper with several results is called
+	// once for all of them.
 	f := reflect.MakeFunc(
-		reflect.FuncOf([]reflect.Type{typ}, []reflect.Type{typ}, false),
+		reflect.FuncOf(types, types, false),
 		func(args []reflect.Value) []reflect.Value {
 			return args
 		},
 	).Interface()
 
 	// Call it!
-	result, _, _, err := callDynamicFuncAny2(f, args.Args.Args,
+	out, err := callDynamicFuncResults(ctx, f, args.Args.Args, nil,
 		argmapper.Typed(ctx),
 		argmapper.ConverterFunc(s.Mappers...),
 	)
 	if err != nil {
-		return nil, err
+		return nil, mapFailed(err, results, args.Args.Args)
 	}
-	return &pb.Map_Response{Result: result}, nil
+
+	var resp pb.Map_Response
+	for _, v := range out {
+		anyVal, err := opaqueany.New(v.(proto.Message))
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Results = append(resp.Results, anyVal)
+	}
+	resp.Result = resp.Results[0]
+
+	return &resp, nil
+}
+
+func (s *mapperServer) MapBatch(
+	ctx context.Context,
+	args *pb.Map_BatchRequest,
+) (*pb.Map_BatchResponse, error) {
+	var result pb.Map_BatchResponse
+	for _, req := range args.Requests {
+		resp, err := s.Map(ctx, req)
+		if err != nil {
+			result.Results = append(result.Results, &pb.Map_BatchResponse_Result{
+				Error: status.Convert(err).Proto(),
+			})
+			continue
+		}
+
+		result.Results = append(result.Results, &pb.Map_BatchResponse_Result{
+			Response: resp,
+		})
+	}
+
+	return &result, nil
+}
+
+// mapFailed returns the error for the Map RPC when mapping the args to
+// the results fails. The error keeps the status of err, such as the
+// diagnostics the mapper returned, with a pb.Map_Error detail added.
+func mapFailed(err error, results []string, args funcspec.Args) error {
+	st := status.Convert(err)
+
+	detail := &pb.Map_Error{
+		Results: results,
+		Message: st.Message(),
+	}
+	for _, arg := range args {
+		typ := arg.Type
+		if typ == "" {
+			typ = arg.PrimitiveType.String()
+		}
+
+		detail.Args = append(detail.Args, typ)
+	}
+
+	if withDetails, err := st.WithDetails(detail); err == nil {
+		st = withDetails
+	}
+
+	return st.Err()
+}
+
+// mapperError returns the *component.ErrMapperFailed for an error from
+// the Map RPC, or err if it has no pb.Map_Error detail.
+func mapperError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	for _, detail := range st.Details() {
+		if v, ok := detail.(*pb.Map_Error); ok {
+			return &component.ErrMapperFailed{
+				Results: v.Results,
+				Args:    v.Args,
+				Message: v.Message,
+				Err:     err,
+			}
+		}
+	}
+
+	return err
 }
 
 var (
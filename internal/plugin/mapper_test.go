@@ -2,13 +2,21 @@ package plugin
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-plugin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
@@ -58,7 +66,7 @@ func TestMapperClient(t *testing.T) {
 			return &testproto.Data{}
 		}
 
-		return callDynamicFunc2(cb, args,
+		return callDynamicFunc2(context.Background(), cb, args,
 			argmapper.Typed(context.Background()),
 			argmapper.ConverterFunc(mappers...),
 		)
@@ -72,3 +80,267 @@ func TestMapperClient(t *testing.T) {
 	require.NoError(result.Err())
 	require.True(called)
 }
+
+func TestMapperClient_multipleResults(t *testing.T) {
+	require := require.New(t)
+
+	mA, err := argmapper.NewFunc(func(a *testproto.A) (*testproto.B, *testproto.Data) {
+		return &testproto.B{Value: a.Value + 1}, &testproto.Data{Value: "hello"}
+	})
+	require.NoError(err)
+
+	plugins := Plugins(WithMappers(append(testDefaultMappers(t), mA)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("mapper")
+	require.NoError(err)
+	mc := raw.(*MapperClient)
+	counter := &countingMapperClient{MapperClient: mc.client}
+	mc.client = counter
+	mappers, err := mc.Mappers()
+	require.NoError(err)
+
+	targetSpec := &pb.FuncSpec{
+		Args: []*pb.FuncSpec_Value{
+			{Type: "testproto.B"},
+			{Type: "testproto.Data"},
+		},
+	}
+
+	var got string
+	target := funcspec.Func(targetSpec, func(args funcspec.Args) (interface{}, error) {
+		cb := func(b *testproto.B, d *testproto.Data) {
+			got = fmt.Sprintf("%d %s", b.Value, d.Value)
+		}
+
+		return callDynamicFunc2(context.Background(), cb, args,
+			argmapper.Typed(context.Background()),
+			argmapper.ConverterFunc(mappers...),
+		)
+	})
+
+	result := target.Call(
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(&testproto.A{Value: 1}),
+		argmapper.ConverterFunc(mappers...),
+	)
+	require.NoError(result.Err())
+	require.Equal("2 hello", got)
+
+	// Both results came from one call
+	require.Equal(1, counter.calls)
+}
+
+func TestMapperClient_error(t *testing.T) {
+	require := require.New(t)
+
+	mA, err := argmapper.NewFunc(func(a *testproto.A) (*testproto.B, error) {
+		return nil, diag.Diagnostics{{
+			Summary: fmt.Sprintf("invalid value %d", a.Value),
+			Path:    []string{"value"},
+		}}.Err()
+	})
+	require.NoError(err)
+
+	plugins := Plugins(WithMappers(append(testDefaultMappers(t), mA)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("mapper")
+	require.NoError(err)
+	mappers, err := raw.(*MapperClient).Mappers()
+	require.NoError(err)
+
+	targetSpec := &pb.FuncSpec{
+		Args: []*pb.FuncSpec_Value{
+			{Type: "testproto.B"},
+		},
+	}
+
+	target := funcspec.Func(targetSpec, func(args funcspec.Args) (interface{}, error) {
+		return callDynamicFunc2(context.Background(), func(*testproto.B) {}, args,
+			argmapper.Typed(context.Background()),
+			argmapper.ConverterFunc(mappers...),
+		)
+	})
+
+	result := target.Call(
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(&testproto.A{Value: 1}),
+		argmapper.ConverterFunc(mappers...),
+	)
+	require.Error(result.Err())
+
+	var mapErr *component.ErrMapperFailed
+	require.True(errors.As(result.Err(), &mapErr))
+	require.Equal([]string{"testproto.B"}, mapErr.Results)
+	require.Equal([]string{"testproto.A"}, mapErr.Args)
+	require.Contains(mapErr.Message, "invalid value 1")
+
+	// The diagnostics of the mapper are kept
+	diags := diag.FromError(mapErr)
+	require.Len(diags, 1)
+	require.Equal([]string{"value"}, diags[0].Path)
+}
+
+func TestMapperClient_cache(t *testing.T) {
+	require := require.New(t)
+
+	mA, err := argmapper.NewFunc(func(a *testproto.A) *testproto.B {
+		return &testproto.B{Value: a.Value + 1}
+	})
+	require.NoError(err)
+
+	plugins := Plugins(WithMappers(append(testDefaultMappers(t), mA)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("mapper")
+	require.NoError(err)
+	mc := raw.(*MapperClient)
+	counter := &countingMapperClient{MapperClient: mc.client}
+	mc.client = counter
+
+	// The same client is dispensed for the plugin client
+	raw, err = client.Dispense("mapper")
+	require.NoError(err)
+	require.Same(mc, raw)
+
+	mappers, err := mc.Mappers()
+	require.NoError(err)
+	require.NotEmpty(mappers)
+
+	// The mappers are cached
+	again, err := raw.(*MapperClient).Mappers()
+	require.NoError(err)
+	require.Equal(mappers, again)
+	require.Equal(1, counter.listCalls)
+
+	// Until they are invalidated
+	mc.Invalidate()
+	_, err = mc.Mappers()
+	require.NoError(err)
+	require.Equal(2, counter.listCalls)
+}
+
+func TestMapperClient_batch(t *testing.T) {
+	mB, err := argmapper.NewFunc(func(a *testproto.A) *testproto.B {
+		return &testproto.B{Value: a.Value + 1}
+	})
+	require.NoError(t, err)
+	mData, err := argmapper.NewFunc(func(a *testproto.A) *testproto.Data {
+		return &testproto.Data{Value: fmt.Sprintf("data %d", a.Value)}
+	})
+	require.NoError(t, err)
+	mFail, err := argmapper.NewFunc(func(a *testproto.A) (*testproto.Deployment, error) {
+		return nil, errors.New("no deployment")
+	})
+	require.NoError(t, err)
+
+	for _, unimplemented := range []bool{false, true} {
+		t.Run(fmt.Sprintf("unimplemented=%v", unimplemented), func(t *testing.T) {
+			require := require.New(t)
+
+			plugins := Plugins(WithMappers(append(testDefaultMappers(t), mB, mData, mFail)...))
+			client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+			defer client.Close()
+			defer server.Stop()
+
+			raw, err := client.Dispense("mapper")
+			require.NoError(err)
+			mc := raw.(*MapperClient)
+			counter := &countingMapperClient{
+				MapperClient:       mc.client,
+				batchUnimplemented: unimplemented,
+			}
+			mc.client = counter
+			mappers, err := mc.Mappers()
+			require.NoError(err)
+
+			targetSpec := &pb.FuncSpec{
+				Args: []*pb.FuncSpec_Value{
+					{Type: "testproto.B"},
+					{Type: "testproto.Data"},
+				},
+			}
+
+			var got string
+			target := funcspec.Func(targetSpec, func(args funcspec.Args) (interface{}, error) {
+				cb := func(b *testproto.B, d *testproto.Data) {
+					got = fmt.Sprintf("%d %s", b.Value, d.Value)
+				}
+
+				return callDynamicFunc2(context.Background(), cb, args,
+					argmapper.Typed(context.Background()),
+					argmapper.ConverterFunc(mappers...),
+				)
+			})
+
+			result := target.Call(
+				argmapper.Typed(context.Background()),
+				argmapper.Typed(&testproto.A{Value: 1}),
+				argmapper.ConverterFunc(mappers...),
+			)
+			require.NoError(result.Err())
+			require.Equal("2 data 1", got)
+
+			if unimplemented {
+				// Older plugins are called once for each mapper
+				require.Equal(1, counter.batchCalls)
+				require.Equal(2, counter.calls)
+			} else {
+				// One call served both conversions, the failing mapper
+				// in the batch doesn't affect the others
+				require.Equal(1, counter.batchCalls)
+				require.Equal(0, counter.calls)
+			}
+		})
+	}
+}
+
+// countingMapperClient is a pb.MapperClient that counts the Map calls.
+type countingMapperClient struct {
+	pb.MapperClient
+
+	calls      int
+	listCalls  int
+	batchCalls int
+
+	// batchUnimplemented makes MapBatch fail like it does for older plugins
+	batchUnimplemented bool
+}
+
+func (c *countingMapperClient) ListMappers(
+	ctx context.Context,
+	req *empty.Empty,
+	opts ...grpc.CallOption,
+) (*pb.Map_ListResponse, error) {
+	c.listCalls++
+	return c.MapperClient.ListMappers(ctx, req, opts...)
+}
+
+func (c *countingMapperClient) MapBatch(
+	ctx context.Context,
+	req *pb.Map_BatchRequest,
+	opts ...grpc.CallOption,
+) (*pb.Map_BatchResponse, error) {
+	c.batchCalls++
+	if c.batchUnimplemented {
+		return nil, status.Error(codes.Unimplemented, "unknown method MapBatch")
+	}
+
+	return c.MapperClient.MapBatch(ctx, req, opts...)
+}
+
+func (c *countingMapperClient) Map(
+	ctx context.Context,
+	req *pb.Map_Request,
+	opts ...grpc.CallOption,
+) (*pb.Map_Response, error) {
+	c.calls++
+	return c.MapperClient.Map(ctx, req, opts...)
+}
@@ -0,0 +1,217 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+)
+
+// ComponentName is the name of a component served with WithNamedComponent.
+// The plugins in a set have a Name field of this type that is empty for
+// the components from WithComponents. This is a distinct type so that
+// setFieldValue never sets it from anything other than a ComponentName.
+type ComponentName string
+
+// pluginTypes are the component types that can be served by name and the
+// name of the plugin for each in the plugin set.
+var pluginTypes = map[component.Type]string{
+	component.BuilderType:        "builder",
+	component.PlatformType:       "platform",
+	component.RegistryType:       "registry",
+	component.ReleaseManagerType: "releasemanager",
+	component.ConfigSourcerType:  "configsourcer",
+	component.TaskLauncherType:   "tasklauncher",
+}
+
+// validComponentName is the format of component names. Names can't
+// contain dots since they separate the type and name in plugin names.
+var validComponentName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// PluginName returns the name of the plugin to dispense for the component
+// of the given type with the given name, such as "builder.docker". If name
+// is empty this returns the name of the component from WithComponents,
+// such as "builder".
+func PluginName(typ component.Type, name string) string {
+	result := pluginTypes[typ]
+	if name != "" {
+		result += "." + name
+	}
+
+	return result
+}
+
+// WithNamedComponent adds a component that is served with the given name
+// in addition to the components from WithComponents. This allows a plugin
+// set to contain several components of the same type. The component is
+// served for every protocol version and for each component type it
+// implements.
+func WithNamedComponent(name string, c interface{}) Option {
+	return func(cfg *pluginConfig) {
+		cfg.NamedComponents = append(cfg.NamedComponents, &namedComponent{
+			Name:      name,
+			Component: c,
+		})
+	}
+}
+
+// namedComponent is a component from WithNamedComponent.
+type namedComponent struct {
+	Name      string
+	Component interface{}
+}
+
+// addNamedComponent adds a plugin to set for every component type that
+// nc implements. An error is returned if the name is invalid, if nc
+// doesn't implement any component type or if the set already has a
+// component of the same type with the same name.
+func addNamedComponent(set plugin.PluginSet, nc *namedComponent) error {
+	if !validComponentName.MatchString(nc.Name) {
+		return fmt.Errorf("invalid component name %q: names may only contain "+
+			"letters, digits, underscores and dashes", nc.Name)
+	}
+
+	once := false
+	ct := reflect.TypeOf(nc.Component)
+	for typ, key := range pluginTypes {
+		iface := reflect.TypeOf(component.TypeMap[typ]).Elem()
+		if !ct.Implements(iface) {
+			continue
+		}
+
+		name := PluginName(typ, nc.Name)
+		if _, ok := set[name]; ok {
+			return fmt.Errorf("duplicate %s component named %q", typ, nc.Name)
+		}
+
+		// Create a new plugin of the right type and set only that one.
+		p, err := NamedPlugin(pluginSet()[key], nc.Name)
+		if err != nil {
+			return err
+		}
+		single := map[int]plugin.PluginSet{0: {key: p}}
+		if err := setFieldValue(single, nc.Component); err != nil {
+			return err
+		}
+
+		set[name] = p
+		once = true
+	}
+
+	if !once {
+		return fmt.Errorf("component %q of type %T doesn't implement any component type",
+			nc.Name, nc.Component)
+	}
+
+	return nil
+}
+
+// NamedPlugin returns a copy of the component plugin p for the component
+// with the given name. Hosts use this to dispense named components since
+// the plugin sets from Plugins only contain the unnamed components. See
+// PluginName.
+func NamedPlugin(p plugin.Plugin, name string) (plugin.Plugin, error) {
+	copied, ok := copyPlugin(p)
+	if !ok {
+		return nil, fmt.Errorf("plugin %T can't be named", p)
+	}
+
+	single := map[int]plugin.PluginSet{0: {"": copied}}
+	if err := setFieldValue(single, ComponentName(name)); err != nil {
+		return nil, fmt.Errorf("plugin %T can't be named", p)
+	}
+
+	return copied, nil
+}
+
+// namedRegistrar returns a registrar that registers services with the name
+// of the component added to the service name, such as
+// "hashicorp.waypoint.sdk.Builder.docker". This lets several components of
+// the same type be registered on one server. If name is empty s is
+// returned as-is.
+func namedRegistrar(s grpc.ServiceRegistrar, name ComponentName) grpc.ServiceRegistrar {
+	if name == "" {
+		return s
+	}
+
+	return &componentRegistrar{registrar: s, name: name}
+}
+
+type componentRegistrar struct {
+	registrar grpc.ServiceRegistrar
+	name      ComponentName
+}
+
+func (r *componentRegistrar) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	named := *desc
+	named.ServiceName = desc.ServiceName + "." + string(r.name)
+	r.registrar.RegisterService(&named, impl)
+}
+
+// namedConn returns a connection that calls the services registered with
+// namedRegistrar for the given name. If name is empty c is returned as-is.
+func namedConn(c grpc.ClientConnInterface, name ComponentName) grpc.ClientConnInterface {
+	if name == "" {
+		return c
+	}
+
+	return &componentConn{conn: c, name: name}
+}
+
+type componentConn struct {
+	conn grpc.ClientConnInterface
+	name ComponentName
+}
+
+func (c *componentConn) Invoke(
+	ctx context.Context,
+	method string,
+	args, reply interface{},
+	opts ...grpc.CallOption,
+) error {
+	return c.conn.Invoke(ctx, c.method(method), args, reply, opts...)
+}
+
+func (c *componentConn) NewStream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	method string,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	return c.conn.NewStream(ctx, desc, c.method(method), opts...)
+}
+
+// method adds the name to the service of a full method name such as
+// "/hashicorp.waypoint.sdk.Builder/Build".
+func (c *componentConn) method(method string) string {
+	idx := strings.LastIndex(method, "/")
+	if idx <= 0 {
+		return method
+	}
+
+	return method[:idx] + "." + string(c.name) + method[idx:]
+}
+
+// copyPlugin returns a copy of the plugin p. This returns false if p
+// isn't a pointer to a struct, which every plugin in a plugin set is.
+func copyPlugin(p plugin.Plugin) (plugin.Plugin, bool) {
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	result := reflect.New(v.Elem().Type())
+	result.Elem().Set(v.Elem())
+	return result.Interface().(plugin.Plugin), true
+}
+
+var (
+	_ grpc.ServiceRegistrar    = (*componentRegistrar)(nil)
+	_ grpc.ClientConnInterface = (*componentConn)(nil)
+)
@@ -0,0 +1,99 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
+)
+
+func TestPlugins_named(t *testing.T) {
+	require := require.New(t)
+
+	builder := func(v string) *mocks.Builder {
+		b := &mocks.Builder{}
+		b.On("BuildFunc").Return(func() *testproto.Data {
+			return &testproto.Data{Value: v}
+		})
+		return b
+	}
+
+	plugins := Plugins(
+		WithComponents(builder("default")),
+		WithNamedComponent("docker", builder("docker")),
+		WithNamedComponent("pack", builder("pack")),
+		WithMappers(testDefaultMappers(t)...),
+	)
+
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	for _, name := range []string{"", "docker", "pack"} {
+		raw, err := client.Dispense(PluginName(component.BuilderType, name))
+		require.NoError(err)
+
+		f := raw.(component.Builder).BuildFunc().(*argmapper.Func)
+		result := f.Call(argmapper.Typed(context.Background()))
+		require.NoError(result.Err())
+
+		expected := name
+		if expected == "" {
+			expected = "default"
+		}
+		var data testproto.Data
+		require.NoError(component.ProtoAnyUnmarshal(result.Out(0), &data))
+		require.Equal(expected, data.Value)
+	}
+
+	// The named components are described by the info plugin
+	raw, err := client.Dispense("info")
+	require.NoError(err)
+	info, err := raw.(component.PluginInfoProvider).PluginInfo(context.Background())
+	require.NoError(err)
+	require.Len(info.Components, 3)
+	require.Equal("", info.Components[0].RegisteredName)
+	require.Equal("docker", info.Components[1].RegisteredName)
+	require.Equal("pack", info.Components[2].RegisteredName)
+}
+
+func TestPlugins_namedInvalid(t *testing.T) {
+	require.Panics(t, func() {
+		Plugins(WithNamedComponent("a.b", &mocks.Builder{}))
+	})
+
+	require.Panics(t, func() {
+		Plugins(
+			WithNamedComponent("a", &mocks.Builder{}),
+			WithNamedComponent("a", &mocks.Builder{}),
+		)
+	})
+
+	require.Panics(t, func() {
+		Plugins(WithNamedComponent("a", 42))
+	})
+}
+
+func TestNamedPlugin(t *testing.T) {
+	require := require.New(t)
+
+	base := &BuilderPlugin{}
+	p, err := NamedPlugin(base, "docker")
+	require.NoError(err)
+	require.Equal(ComponentName("docker"), p.(*BuilderPlugin).Name)
+	require.Empty(base.Name)
+
+	_, err = NamedPlugin(&MapperPlugin{}, "docker")
+	require.Error(err)
+}
+
+func TestPluginName(t *testing.T) {
+	require.Equal(t, "builder", PluginName(component.BuilderType, ""))
+	require.Equal(t, "releasemanager.k8s", PluginName(component.ReleaseManagerType, "k8s"))
+}
@@ -0,0 +1,112 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// odrProfilerClient implements component.ODRProfiler for a service that
+// has the ODR profile methods implemented.
+type odrProfilerClient struct {
+	Client odrProfilerProtoClient
+	Logger hclog.Logger
+}
+
+func (c *odrProfilerClient) Implements(ctx context.Context) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	resp, err := c.Client.IsODRProfiler(ctx, &empty.Empty{})
+	if err != nil {
+		// Plugins built against older SDKs don't have the IsODRProfiler
+		// RPC at all, which just means they don't implement it.
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return resp.Implements, nil
+}
+
+func (c *odrProfilerClient) ODRRequirements() (*component.ODRRequirements, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	resp, err := c.Client.ODRRequirements(context.Background(), &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &component.ODRRequirements{
+		Image:       resp.Image,
+		CPU:         resp.Cpu,
+		Memory:      resp.Memory,
+		Environment: resp.Environment,
+		Credentials: resp.Credentials,
+		Privileged:  resp.Privileged,
+	}, nil
+}
+
+// odrProfilerServer implements the common ODRProfiler-related RPC calls.
+// This should be embedded into the service implementation.
+type odrProfilerServer struct {
+	*base
+	Impl interface{}
+}
+
+func (s *odrProfilerServer) IsODRProfiler(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.ImplementsResp, error) {
+	_, ok := s.Impl.(component.ODRProfiler)
+	return &pb.ImplementsResp{Implements: ok}, nil
+}
+
+func (s *odrProfilerServer) ODRRequirements(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.ODR_Requirements, error) {
+	profiler, ok := s.Impl.(component.ODRProfiler)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: ODRProfiler")
+	}
+
+	req, err := profiler.ODRRequirements()
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		req = &component.ODRRequirements{}
+	}
+
+	return &pb.ODR_Requirements{
+		Image:       req.Image,
+		Cpu:         req.CPU,
+		Memory:      req.Memory,
+		Environment: req.Environment,
+		Credentials: req.Credentials,
+		Privileged:  req.Privileged,
+	}, nil
+}
+
+// odrProfilerProtoClient is the interface we expect any gRPC service that
+// supports ODR profiles to implement.
+type odrProfilerProtoClient interface {
+	IsODRProfiler(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ImplementsResp, error)
+	ODRRequirements(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ODR_Requirements, error)
+}
+
+var (
+	_ component.ODRProfiler = (*odrProfilerClient)(nil)
+)
@@ -0,0 +1,190 @@
+package pipe
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"hash"
+	"io"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component/pipe"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// chunkSize is the maximum size of the data in each message.
+const chunkSize = 32 * 1024
+
+// PipePlugin implements plugin.Plugin (specifically GRPCPlugin) for
+// the pipe.Source type. The client is a *pipe.Reader.
+type PipePlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+
+	Impl     *pipe.Source         // Impl is the concrete implementation
+	Logger   hclog.Logger         // Logger
+	Internal *pluginargs.Internal // Internal sets up the client connection
+}
+
+func (p *PipePlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	pb.RegisterPipeServiceServer(s, &pipeServer{
+		Impl:   p.Impl,
+		Logger: p.Logger,
+	})
+	return nil
+}
+
+// GRPCClient starts reading the pipe immediately. The Reader outlives the
+// call that received it, so it isn't canceled with ctx; it is canceled
+// when it is closed.
+func (p *PipePlugin) GRPCClient(
+	ctx context.Context,
+	broker *plugin.GRPCBroker,
+	c *grpc.ClientConn,
+) (interface{}, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := pb.NewPipeServiceClient(p.Internal.ClientConn(c)).Read(ctx, &empty.Empty{})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return pipe.NewReader(&reader{
+		stream: stream,
+		cancel: cancel,
+		hash:   sha256.New(),
+	}), nil
+}
+
+// reader reads the chunks of a pipe and verifies their checksum.
+type reader struct {
+	stream pb.PipeService_ReadClient
+	cancel context.CancelFunc
+	hash   hash.Hash
+
+	buf []byte
+	err error
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			// The server always ends with a checksum, so the end of the
+			// stream without one means the data is incomplete.
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+
+			r.done(err)
+			continue
+		}
+
+		if chunk.Sha256 != nil {
+			if !bytes.Equal(chunk.Sha256, r.hash.Sum(nil)) {
+				r.done(pipe.ErrChecksum)
+			} else {
+				r.done(io.EOF)
+			}
+
+			continue
+		}
+
+		r.hash.Write(chunk.Data)
+		r.buf = chunk.Data
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *reader) Close() error {
+	r.done(io.ErrClosedPipe)
+	return nil
+}
+
+// done ends the stream so that future reads return err. The first error
+// wins, so closing after reading to the end doesn't change the result.
+func (r *reader) done(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+	r.buf = nil
+	r.cancel()
+}
+
+// pipeServer is a gRPC server that the plugin talks to and reads from a
+// real Source.
+type pipeServer struct {
+	pb.UnimplementedPipeServiceServer
+
+	Impl   *pipe.Source
+	Logger hclog.Logger
+
+	mu   sync.Mutex
+	read bool
+}
+
+func (s *pipeServer) Read(
+	_ *empty.Empty,
+	stream pb.PipeService_ReadServer,
+) error {
+	s.mu.Lock()
+	read := s.read
+	s.read = true
+	s.mu.Unlock()
+	if read {
+		return status.Errorf(codes.FailedPrecondition, "pipe can only be read once")
+	}
+
+	if c, ok := s.Impl.R.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	ctx := stream.Context()
+	h := sha256.New()
+	for {
+		// Stop reading the source if the reader went away
+		if err := ctx.Err(); err != nil {
+			s.Logger.Debug("pipe canceled", "err", err)
+			return status.Error(codes.Canceled, err.Error())
+		}
+
+		buf := make([]byte, chunkSize)
+		n, err := s.Impl.R.Read(buf)
+		if n > 0 {
+			h.Write(buf[:n])
+			if err := stream.Send(&pb.Pipe_Chunk{Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return status.Errorf(codes.Aborted, "error reading pipe source: %s", err)
+		}
+	}
+
+	return stream.Send(&pb.Pipe_Chunk{Sha256: h.Sum(nil)})
+}
+
+var (
+	_ plugin.Plugin        = (*PipePlugin)(nil)
+	_ plugin.GRPCPlugin    = (*PipePlugin)(nil)
+	_ pb.PipeServiceServer = (*pipeServer)(nil)
+	_ io.ReadCloser        = (*reader)(nil)
+)
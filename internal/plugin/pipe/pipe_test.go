@@ -0,0 +1,189 @@
+package pipe
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component/pipe"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+func TestPipe(t *testing.T) {
+	require := require.New(t)
+
+	// More than one chunk
+	data := bytes.Repeat([]byte("hello"), chunkSize)
+	src, c := testSource(bytes.NewReader(data))
+	r := testReader(t, src)
+
+	actual, err := io.ReadAll(r)
+	require.NoError(err)
+	require.Equal(data, actual)
+	require.Eventually(c.isClosed, 5*time.Second, 10*time.Millisecond)
+	require.NoError(r.Close())
+
+	// Reads after the end still return EOF
+	_, err = r.Read(make([]byte, 1))
+	require.Equal(io.EOF, err)
+}
+
+func TestPipe_sourceError(t *testing.T) {
+	require := require.New(t)
+
+	src, _ := testSource(io.MultiReader(
+		bytes.NewReader([]byte("hello")),
+		&errReader{err: errors.New("disk on fire")},
+	))
+	r := testReader(t, src)
+
+	_, err := io.ReadAll(r)
+	require.Error(err)
+	require.Contains(err.Error(), "disk on fire")
+}
+
+func TestPipe_close(t *testing.T) {
+	require := require.New(t)
+
+	// A source that never ends
+	src, c := testSource(zeroReader{})
+	r := testReader(t, src)
+
+	_, err := io.ReadFull(r, make([]byte, 10))
+	require.NoError(err)
+	require.NoError(r.Close())
+
+	_, err = r.Read(make([]byte, 1))
+	require.Equal(io.ErrClosedPipe, err)
+
+	// The server stops reading the source
+	require.Eventually(c.isClosed, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReader_checksum(t *testing.T) {
+	sum := sha256.Sum256([]byte("hello"))
+
+	t.Run("match", func(t *testing.T) {
+		r := &reader{
+			stream: &testStream{chunks: []*pb.Pipe_Chunk{
+				{Data: []byte("hel")},
+				{Data: []byte("lo")},
+				{Sha256: sum[:]},
+			}},
+			cancel: func() {},
+			hash:   sha256.New(),
+		}
+
+		actual, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(actual))
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		r := &reader{
+			stream: &testStream{chunks: []*pb.Pipe_Chunk{
+				{Data: []byte("jello")},
+				{Sha256: sum[:]},
+			}},
+			cancel: func() {},
+			hash:   sha256.New(),
+		}
+
+		_, err := io.ReadAll(r)
+		require.Equal(t, pipe.ErrChecksum, err)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		r := &reader{
+			stream: &testStream{chunks: []*pb.Pipe_Chunk{
+				{Data: []byte("hel")},
+			}},
+			cancel: func() {},
+			hash:   sha256.New(),
+		}
+
+		_, err := io.ReadAll(r)
+		require.Equal(t, io.ErrUnexpectedEOF, err)
+	})
+}
+
+// testReader serves src and returns a reader for it.
+func testReader(t *testing.T, src *pipe.Source) *pipe.Reader {
+	client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		"pipe": &PipePlugin{Impl: src, Logger: hclog.L()},
+	})
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+
+	raw, err := client.Dispense("pipe")
+	require.NoError(t, err)
+	return raw.(*pipe.Reader)
+}
+
+// testSource returns a source for r that records if it was closed.
+func testSource(r io.Reader) (*pipe.Source, *closeRecorder) {
+	c := &closeRecorder{Reader: r}
+	return &pipe.Source{R: c}, c
+}
+
+type closeRecorder struct {
+	io.Reader
+
+	mu     sync.Mutex
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func (c *closeRecorder) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// errReader always returns err.
+type errReader struct{ err error }
+
+func (r *errReader) Read(p []byte) (int, error) { return 0, r.err }
+
+// zeroReader never ends.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+// testStream is a pb.PipeService_ReadClient that returns chunks.
+type testStream struct {
+	pb.PipeService_ReadClient
+
+	chunks []*pb.Pipe_Chunk
+}
+
+func (s *testStream) Recv() (*pb.Pipe_Chunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+
+	c := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return c, nil
+}
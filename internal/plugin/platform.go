@@ -5,18 +5,22 @@ import (
 	"encoding/json"
 	"reflect"
 
-	"github.com/davecgh/go-spew/spew"
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/zclconf/go-cty/cty"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
 	"github.com/hashicorp/waypoint-plugin-sdk/docs"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pkg/logdump"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/plugincomponent"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
@@ -30,16 +34,34 @@ type PlatformPlugin struct {
 	Impl    component.Platform // Impl is the concrete implementation
 	Mappers []*argmapper.Func  // Mappers
 	Logger  hclog.Logger       // Logger
+
+	ODR *ODRSetting // Used to switch deploy modes based on ondemand-runner in play
+
+	ProtocolVersion *component.ProtocolVersion // Protocol version of the plugin set
+	Features        *pluginargs.FeatureSet     // Features of the plugin, see WithFeatures
+	Name            ComponentName              // Name of the component, see WithNamedComponent
+	TracerProvider  trace.TracerProvider       // Traces brokered servers, see WithTracerProvider
+
+	UnaryInterceptors  []grpc.UnaryServerInterceptor  // Interceptors for brokered servers, see WithInterceptors
+	StreamInterceptors []grpc.StreamServerInterceptor // Interceptors for brokered servers, see WithInterceptors
+	ServerSettings     *pluginargs.ServerSettings     // Settings for brokered servers, see WithServerSettings
 }
 
 func (p *PlatformPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
 	base := &base{
-		Mappers: p.Mappers,
-		Logger:  p.Logger,
-		Broker:  broker,
+		Mappers:         p.Mappers,
+		Logger:          p.Logger,
+		Broker:          broker,
+		ProtocolVersion: p.ProtocolVersion,
+		Features:        p.Features,
+		TracerProvider:  p.TracerProvider,
+
+		UnaryInterceptors:  p.UnaryInterceptors,
+		StreamInterceptors: p.StreamInterceptors,
+		ServerSettings:     p.ServerSettings,
 	}
 
-	pb.RegisterPlatformServer(s, &platformServer{
+	pb.RegisterPlatformServer(largeValueRegistrar(namedRegistrar(s, p.Name), broker), &platformServer{
 		base: base,
 		destroyerServer: &destroyerServer{
 			base: base,
@@ -54,10 +76,34 @@ func (p *PlatformPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) e
 			base: base,
 			Impl: p.Impl,
 		},
+		validatorServer: &validatorServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		configDefaulterServer: &configDefaulterServer{
+			base: base,
+			Impl: p.Impl,
+		},
 		execerServer: &execerServer{
 			base: base,
 			Impl: p.Impl,
 		},
+		portForwarderServer: &portForwarderServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		troubleshooterServer: &troubleshooterServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		snapshotterServer: &snapshotterServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		platformAccessServer: &platformAccessServer{
+			base: base,
+			Impl: p.Impl,
+		},
 		logPlatformServer: &logPlatformServer{
 			base: base,
 			Impl: p.Impl,
@@ -70,6 +116,14 @@ func (p *PlatformPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) e
 			base: base,
 			Impl: p.Impl,
 		},
+		remediatorServer: &remediatorServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		odrProfilerServer: &odrProfilerServer{
+			base: base,
+			Impl: p.Impl,
+		},
 
 		Impl: p.Impl,
 	})
@@ -84,10 +138,16 @@ func (p *PlatformPlugin) GRPCClient(
 ) (interface{}, error) {
 	// Build our client to the platform service
 	client := &platformClient{
-		client:  pb.NewPlatformClient(c),
+		client:  pb.NewPlatformClient(diagnosticsConn(largeValueConn(namedConn(c, p.Name), broker, p.Features, p.Logger), p.Logger)),
 		logger:  p.Logger,
 		broker:  broker,
 		mappers: p.Mappers,
+		odr:     p.ODR != nil && p.ODR.Enabled,
+	}
+
+	probe, err := newCapabilityProbe(ctx, c, component.PlatformType, p.Name)
+	if err != nil {
+		return nil, err
 	}
 
 	log := &logClient{
@@ -96,7 +156,7 @@ func (p *PlatformPlugin) GRPCClient(
 		Broker:  client.broker,
 		Mappers: client.mappers,
 	}
-	if ok, err := log.Implements(ctx); err != nil {
+	if ok, err := probe.Implements(ctx, component.CapabilityLogPlatform, log); err != nil {
 		return nil, err
 	} else if ok {
 		p.Logger.Info("platform plugin capable of destroy")
@@ -111,7 +171,7 @@ func (p *PlatformPlugin) GRPCClient(
 		Broker:  client.broker,
 		Mappers: client.mappers,
 	}
-	if ok, err := destroyer.Implements(ctx); err != nil {
+	if ok, err := probe.Implements(ctx, component.CapabilityDestroyer, destroyer); err != nil {
 		return nil, err
 	} else if ok {
 		p.Logger.Info("platform plugin capable of destroy")
@@ -126,7 +186,7 @@ func (p *PlatformPlugin) GRPCClient(
 		Broker:  client.broker,
 		Mappers: client.mappers,
 	}
-	if ok, err := wsDestroyer.Implements(ctx); err != nil {
+	if ok, err := probe.Implements(ctx, component.CapabilityWorkspaceDestroyer, wsDestroyer); err != nil {
 		return nil, err
 	} else if ok {
 		p.Logger.Info("platform plugin capable of destroy")
@@ -140,7 +200,7 @@ func (p *PlatformPlugin) GRPCClient(
 		Broker:  client.broker,
 		Mappers: client.mappers,
 	}
-	if ok, err := authenticator.Implements(ctx); err != nil {
+	if ok, err := probe.Implements(ctx, component.CapabilityAuthenticator, authenticator); err != nil {
 		return nil, err
 	} else if ok {
 		p.Logger.Info("platform plugin capable of auth")
@@ -148,13 +208,55 @@ func (p *PlatformPlugin) GRPCClient(
 		authenticator = nil
 	}
 
+	authRefresher := &authRefresherClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityAuthRefresher, authRefresher); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("platform plugin capable of auth refresh")
+	} else {
+		authRefresher = nil
+	}
+
+	validator := &validatorClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityValidator, validator); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("platform plugin capable of validation")
+	} else {
+		validator = nil
+	}
+
+	configDefaulter := &configDefaulterClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityConfigDefaulter, configDefaulter); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("platform plugin capable of config defaults")
+	} else {
+		configDefaulter = nil
+	}
+
 	execer := &execerClient{
 		Client:  client.client,
 		Logger:  client.logger,
 		Broker:  client.broker,
 		Mappers: client.mappers,
 	}
-	if ok, err := execer.Implements(ctx); err != nil {
+	if ok, err := probe.Implements(ctx, component.CapabilityExecer, execer); err != nil {
 		return nil, err
 	} else if ok {
 		p.Logger.Info("platform plugin capable of auth")
@@ -162,13 +264,69 @@ func (p *PlatformPlugin) GRPCClient(
 		execer = nil
 	}
 
+	portForwarder := &portForwarderClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityPortForwarder, portForwarder); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("platform plugin capable of port forwarding")
+	} else {
+		portForwarder = nil
+	}
+
+	platformAccess := &platformAccessClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityPlatformAccess, platformAccess); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("platform plugin capable of access info")
+	} else {
+		platformAccess = nil
+	}
+
+	troubleshooter := &troubleshooterClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityTroubleshooter, troubleshooter); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("platform plugin capable of troubleshooting")
+	} else {
+		troubleshooter = nil
+	}
+
+	snapshotter := &snapshotterClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilitySnapshotter, snapshotter); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("platform plugin capable of snapshots")
+	} else {
+		snapshotter = nil
+	}
+
 	generation := &generationClient{
 		Client:  client.client,
 		Logger:  client.logger,
 		Broker:  client.broker,
 		Mappers: client.mappers,
 	}
-	if ok, err := generation.Implements(ctx); err != nil {
+	if ok, err := probe.Implements(ctx, component.CapabilityGeneration, generation); err != nil {
 		return nil, err
 	} else if ok {
 		p.Logger.Info("platform plugin capable of generation ID creation")
@@ -182,7 +340,7 @@ func (p *PlatformPlugin) GRPCClient(
 		Broker:  client.broker,
 		Mappers: client.mappers,
 	}
-	if ok, err := status.Implements(ctx); err != nil {
+	if ok, err := probe.Implements(ctx, component.CapabilityStatus, status); err != nil {
 		return nil, err
 	} else if ok {
 		p.Logger.Info("platform plugin capable of status")
@@ -190,46 +348,124 @@ func (p *PlatformPlugin) GRPCClient(
 		status = nil
 	}
 
+	remediator := &remediatorClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityRemediator, remediator); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("platform plugin capable of remediation")
+	} else {
+		remediator = nil
+	}
+
+	odrProfiler := &odrProfilerClient{
+		Client: client.client,
+		Logger: client.logger,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityODRProfiler, odrProfiler); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("platform plugin capable of ODR profiles")
+	} else {
+		odrProfiler = nil
+	}
+
+	var caps capabilities
+	caps.add(component.CapabilityAuthenticator, authenticator != nil)
+	caps.add(component.CapabilityAuthRefresher, authRefresher != nil)
+	caps.add(component.CapabilityValidator, validator != nil)
+	caps.add(component.CapabilityConfigDefaulter, configDefaulter != nil)
+	caps.add(component.CapabilityDestroyer, destroyer != nil)
+	caps.add(component.CapabilityWorkspaceDestroyer, wsDestroyer != nil)
+	caps.add(component.CapabilityExecer, execer != nil)
+	caps.add(component.CapabilityPortForwarder, portForwarder != nil)
+	caps.add(component.CapabilityPlatformAccess, platformAccess != nil)
+	caps.add(component.CapabilityTroubleshooter, troubleshooter != nil)
+	caps.add(component.CapabilitySnapshotter, snapshotter != nil)
+	caps.add(component.CapabilityLogPlatform, log != nil)
+	caps.add(component.CapabilityGeneration, generation != nil)
+	caps.add(component.CapabilityStatus, status != nil)
+	caps.add(component.CapabilityRemediator, remediator != nil)
+	caps.add(component.CapabilityODRProfiler, odrProfiler != nil)
+
 	// Figure out what we're returning
 	var result interface{} = client
 	switch {
 	case destroyer != nil:
 		result = &mix_Platform_Destroy{
+			capabilities:       caps,
 			Authenticator:      authenticator,
+			AuthRefresher:      authRefresher,
+			Validator:          validator,
+			ConfigDefaulter:    configDefaulter,
 			ConfigurableNotify: client,
+			ConfigurableSchema: client,
 			Platform:           client,
 			PlatformReleaser:   client,
 			Destroyer:          destroyer,
+			DestroyHooks:       destroyer,
 			WorkspaceDestroyer: wsDestroyer,
 			Documented:         client,
 			Execer:             execer,
 			LogPlatform:        log,
+			PortForwarder:      portForwarder,
+			PlatformAccess:     platformAccess,
+			Troubleshooter:     troubleshooter,
+			Snapshotter:        snapshotter,
 			Generation:         generation,
 			Status:             status,
+			Remediator:         remediator,
+			ODRProfiler:        odrProfiler,
 		}
 	case execer != nil:
 		result = &mix_Platform_Exec{
+			capabilities:       caps,
 			Authenticator:      authenticator,
+			AuthRefresher:      authRefresher,
+			Validator:          validator,
+			ConfigDefaulter:    configDefaulter,
 			ConfigurableNotify: client,
+			ConfigurableSchema: client,
 			Platform:           client,
 			PlatformReleaser:   client,
 			Execer:             execer,
 			Documented:         client,
 			LogPlatform:        log,
+			PortForwarder:      portForwarder,
+			PlatformAccess:     platformAccess,
+			Troubleshooter:     troubleshooter,
+			Snapshotter:        snapshotter,
 			Generation:         generation,
 			Status:             status,
+			Remediator:         remediator,
+			ODRProfiler:        odrProfiler,
 		}
 	default:
 		result = &mix_Platform_Authenticator{
+			capabilities:       caps,
 			Authenticator:      authenticator,
+			AuthRefresher:      authRefresher,
+			Validator:          validator,
+			ConfigDefaulter:    configDefaulter,
 			ConfigurableNotify: client,
+			ConfigurableSchema: client,
 			Platform:           client,
 			PlatformReleaser:   client,
 			WorkspaceDestroyer: wsDestroyer,
 			Documented:         client,
 			LogPlatform:        log,
+			PortForwarder:      portForwarder,
+			PlatformAccess:     platformAccess,
+			Troubleshooter:     troubleshooter,
+			Snapshotter:        snapshotter,
 			Generation:         generation,
 			Status:             status,
+			Remediator:         remediator,
+			ODRProfiler:        odrProfiler,
 		}
 	}
 
@@ -242,6 +478,9 @@ type platformClient struct {
 	logger  hclog.Logger
 	broker  *plugin.GRPCBroker
 	mappers []*argmapper.Func
+
+	// indicates that the ODR version of the plugin should be used
+	odr bool
 }
 
 func (c *platformClient) Config() (interface{}, error) {
@@ -252,11 +491,47 @@ func (c *platformClient) ConfigSet(v interface{}) error {
 	return configureCall(context.Background(), c.client, v)
 }
 
+func (c *platformClient) ConfigSchema() (hcldec.Spec, error) {
+	return configSchemaCall(context.Background(), c.client)
+}
+
+func (c *platformClient) ConfigSetValue(v cty.Value) error {
+	return configureValueCall(context.Background(), c.client, v)
+}
+
 func (c *platformClient) Documentation() (*docs.Documentation, error) {
 	return documentationCall(context.Background(), c.client)
 }
 
 func (c *platformClient) DeployFunc() interface{} {
+	if c.odr {
+		c.logger.Debug("Running in ODR mode, attempting to retrieve ODR deploy spec")
+
+		spec, err := c.client.DeploySpecODR(context.Background(), &empty.Empty{})
+		if err == nil {
+			// We don't want to be a mapper
+			spec.Result = nil
+
+			return funcspec.Func(spec, c.deployODR,
+				argmapper.Logger(c.logger),
+				argmapper.Typed(&pluginargs.Internal{
+					Broker:  c.broker,
+					Mappers: c.mappers,
+					Cleanup: &pluginargs.Cleanup{},
+				}),
+			)
+		}
+
+		if status.Code(err) != codes.Unimplemented {
+			c.logger.Error("error retrieving ODR deploy spec", "error", err)
+			return funcErr(err)
+		}
+
+		// ok, the plugin doesn't have an ODR deploy, so just use the
+		// basic mode.
+		c.logger.Debug("plugin didn't implement DeploySpecODR, using Deploy")
+	}
+
 	// Get the spec
 	spec, err := c.client.DeploySpec(context.Background(), &empty.Empty{})
 	if err != nil {
@@ -281,12 +556,33 @@ func (c *platformClient) deploy(
 	args funcspec.Args,
 	internal *pluginargs.Internal,
 	declaredResourcesResp *component.DeclaredResourcesResp,
+) (component.Deployment, error) {
+	return c.callDeploy(ctx, c.client.Deploy, args, internal, declaredResourcesResp)
+}
+
+func (c *platformClient) deployODR(
+	ctx context.Context,
+	args funcspec.Args,
+	internal *pluginargs.Internal,
+	declaredResourcesResp *component.DeclaredResourcesResp,
+) (component.Deployment, error) {
+	return c.callDeploy(ctx, c.client.DeployODR, args, internal, declaredResourcesResp)
+}
+
+// callDeploy calls the deploy RPC rpc, which is Deploy or DeployODR, and
+// returns the deployment.
+func (c *platformClient) callDeploy(
+	ctx context.Context,
+	rpc func(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (*pb.Deploy_Resp, error),
+	args funcspec.Args,
+	internal *pluginargs.Internal,
+	declaredResourcesResp *component.DeclaredResourcesResp,
 ) (component.Deployment, error) {
 	// Run the cleanup
 	defer internal.Cleanup.Close()
 
 	// Call our function
-	resp, err := c.client.Deploy(ctx, &pb.FuncSpec_Args{Args: args})
+	resp, err := rpc(ctx, &pb.FuncSpec_Args{Args: args})
 	if err != nil {
 		return nil, err
 	}
@@ -300,12 +596,15 @@ func (c *platformClient) deploy(
 
 	// Add declared resources to our outparameter so the caller can access them
 	declaredResourcesResp.DeclaredResources = resp.DeclaredResources.Resources
+	declaredResourcesResp.Changelog = resp.ResourceChangelog
 
 	return &plugincomponent.Deployment{
 		Any:         resp.Result,
 		AnyJson:     resp.ResultJson,
 		Deployment:  resp.Deployment,
 		TemplateVal: tplData,
+		EventsVal:   resp.EventLog.GetEvents(),
+		WarningsVal: diag.FromProto(resp.Warnings),
 	}, nil
 }
 
@@ -368,10 +667,18 @@ type platformServer struct {
 	*destroyerServer
 	*workspaceDestroyerServer
 	*authenticatorServer
+	*validatorServer
+	*configDefaulterServer
 	*execerServer
+	*portForwarderServer
+	*troubleshooterServer
+	*snapshotterServer
+	*platformAccessServer
 	*logPlatformServer
 	*generationServer
 	*statusServer
+	*remediatorServer
+	*odrProfilerServer
 
 	pb.UnsafePlatformServer
 
@@ -397,17 +704,33 @@ func (s *platformServer) Configure(
 	ctx context.Context,
 	req *pb.Config_ConfigureRequest,
 ) (*empty.Empty, error) {
+	defer s.purgeMappers()
 	return configure(s.Impl, req)
 }
 
+func (s *platformServer) ConfigSchema(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.Config_SchemaResp, error) {
+	return configSchema(s.Impl)
+}
+
+func (s *platformServer) ConfigureValue(
+	ctx context.Context,
+	req *pb.Config_ConfigureValueRequest,
+) (*empty.Empty, error) {
+	defer s.purgeMappers()
+	return configureValue(s.Impl, req)
+}
+
 func (s *platformServer) Documentation(
 	ctx context.Context,
 	empty *empty.Empty,
 ) (*pb.Config_Documentation, error) {
-	docs, err := documentation(s.Impl)
+	docs, err := documentation(s.Impl, s.Mappers, s.Logger)
 
 	if docs != nil {
-		s.Logger.Debug("docs", "docs", spew.Sdump(docs))
+		s.Logger.Debug("docs", "docs", logdump.Value(docs))
 	}
 
 	return docs, err
@@ -431,18 +754,59 @@ func (s *platformServer) DeploySpec(
 func (s *platformServer) Deploy(
 	ctx context.Context,
 	args *pb.FuncSpec_Args,
+) (*pb.Deploy_Resp, error) {
+	return s.deploy(ctx, s.Impl.DeployFunc(), args)
+}
+
+func (s *platformServer) DeploySpecODR(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	odr, ok := s.Impl.(component.PlatformODR)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: PlatformODR")
+	}
+
+	return funcspec.Spec(odr.DeployODRFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+	)
+}
+
+func (s *platformServer) DeployODR(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*pb.Deploy_Resp, error) {
+	odr, ok := s.Impl.(component.PlatformODR)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: PlatformODR")
+	}
+
+	return s.deploy(ctx, odr.DeployODRFunc(), args)
+}
+
+// deploy calls the deploy function f, which is the DeployFunc or the
+// DeployODRFunc of the platform.
+func (s *platformServer) deploy(
+	ctx context.Context,
+	f interface{},
+	args *pb.FuncSpec_Args,
 ) (*pb.Deploy_Resp, error) {
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	// Inject our outparameter, so we can capture the response after invocation
+	// Inject our outparameters, so we can capture the response after invocation
 	declaredResourcesResp := &component.DeclaredResourcesResp{}
+	eventLogger := &component.EventLogger{}
+	warnings := &component.Warnings{}
 
-	encoded, encodedJson, raw, err := callDynamicFuncAny2(s.Impl.DeployFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	encoded, encodedJson, raw, err := callDynamicFuncAnyWarnings(ctx, f, args.Args, warnings,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Typed(internal),
 		argmapper.Typed(ctx),
 		argmapper.Typed(declaredResourcesResp),
+		argmapper.Typed(eventLogger),
 	)
 	if err != nil {
 		return nil, err
@@ -455,6 +819,17 @@ func (s *platformServer) Deploy(
 		DeclaredResources: &pb.DeclaredResources{
 			Resources: declaredResourcesResp.DeclaredResources,
 		},
+		EventLog: &pb.EventLog{Events: eventLogger.Events()},
+		Warnings: warnings.Warnings().Proto(),
+	}
+
+	// If the plugin told us what it declared previously, we can compute
+	// the changes for core.
+	if declaredResourcesResp.PreviousDeclaredResources != nil {
+		result.ResourceChangelog = component.DiffDeclaredResources(
+			declaredResourcesResp.PreviousDeclaredResources,
+			declaredResourcesResp.DeclaredResources,
+		)
 	}
 
 	deploymentWithUrl, ok := raw.(component.DeploymentWithUrl)
@@ -462,7 +837,7 @@ func (s *platformServer) Deploy(
 		result.Deployment.Url = deploymentWithUrl.URL()
 	}
 
-	result.TemplateData, err = templateData(raw)
+	result.TemplateData, err = templateData(raw, s.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -508,8 +883,8 @@ func (s *platformServer) DefaultReleaser(
 		return nil, status.Errorf(codes.Unimplemented, "")
 	}
 
-	raw, err := callDynamicFunc2(impl.DefaultReleaserFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	raw, err := callDynamicFunc2(ctx, impl.DefaultReleaserFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Typed(internal),
 		argmapper.Typed(ctx),
 	)
@@ -529,13 +904,10 @@ func (s *platformServer) DefaultReleaser(
 
 	// Start our server
 	go s.Broker.AcceptAndServe(id, func(opts []grpc.ServerOption) *grpc.Server {
-		base := *s.base
-		base.Logger = s.Logger.Named("releaser")
-
-		server := plugin.DefaultGRPCServer(opts)
+		server := s.internal().NewServer(opts)
 		pb.RegisterReleaseManagerServer(server, &releaseManagerServer{
 			Impl: releaser,
-			base: &base,
+			base: s.withLogger(s.Logger.Named("releaser")),
 		})
 		return server
 	})
@@ -551,4 +923,5 @@ var (
 	_ component.PlatformReleaser   = (*platformClient)(nil)
 	_ component.Configurable       = (*platformClient)(nil)
 	_ component.ConfigurableNotify = (*platformClient)(nil)
+	_ component.ConfigurableSchema = (*platformClient)(nil)
 )
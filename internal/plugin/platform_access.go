@@ -0,0 +1,155 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/plugincomponent"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// platformAccessClient implements component.PlatformAccess for a service
+// that has the access methods implemented.
+type platformAccessClient struct {
+	Client  platformAccessProtoClient
+	Logger  hclog.Logger
+	Broker  *plugin.GRPCBroker
+	Mappers []*argmapper.Func
+}
+
+// Implements returns true if the plugin implements component.PlatformAccess.
+// Like the registry, this is signaled by AccessSpec returning
+// codes.Unimplemented.
+func (c *platformAccessClient) Implements(ctx context.Context) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	if _, err := c.Client.AccessSpec(ctx, &empty.Empty{}); err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *platformAccessClient) AccessInfoFunc() interface{} {
+	// If we're not initialized, then it doesn't support access info
+	if c == nil {
+		return nil
+	}
+
+	// Get the spec
+	spec, err := c.Client.AccessSpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		// Signal that this is not implemented.
+		if status.Code(err) == codes.Unimplemented {
+			return nil
+		}
+
+		return funcErr(err)
+	}
+
+	// We don't want to be a mapper, WHICH MEANS that we get the real value instead
+	// of an argmapper interval value.
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.access,
+		argmapper.Logger(c.Logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.Broker,
+			Mappers: c.Mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *platformAccessClient) access(
+	ctx context.Context,
+	args funcspec.Args,
+) (component.AccessInfo, error) {
+	// Call our function
+	resp, err := c.Client.Access(ctx, &pb.FuncSpec_Args{Args: args})
+	if err != nil {
+		return nil, err
+	}
+
+	return &plugincomponent.AccessInfo{Any: resp.Result}, nil
+}
+
+// platformAccessServer implements the common PlatformAccess-related RPC
+// calls. This should be embedded into the service implementation.
+type platformAccessServer struct {
+	*base
+	Impl interface{}
+}
+
+// AccessSpec returns the information about the plugins AccessInfoFunc function.
+// If the plugin does not implement the function (as it is an optional interface)
+// then a codes.Unimplemented is returned as an error.
+func (s *platformAccessServer) AccessSpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	pa, ok := s.Impl.(component.PlatformAccess)
+	if !ok || pa.AccessInfoFunc() == nil {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: platform access")
+	}
+
+	return funcspec.Spec(pa.AccessInfoFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+	)
+}
+
+// Access calls the AccessInfoFunc on the plugin.
+func (s *platformAccessServer) Access(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*pb.Access_Resp, error) {
+	pa, ok := s.Impl.(component.PlatformAccess)
+	if !ok || pa.AccessInfoFunc() == nil {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: platform access")
+	}
+
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	encoded, _, _, err := callDynamicFuncAny2(ctx, pa.AccessInfoFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(ctx),
+		argmapper.Typed(internal),
+	)
+	if err != nil {
+		s.Logger.Error("error calling access info func", "error", err)
+		return nil, err
+	}
+
+	return &pb.Access_Resp{Result: encoded}, nil
+}
+
+// platformAccessProtoClient is the interface we expect any gRPC service
+// that supports platform access to implement.
+type platformAccessProtoClient interface {
+	AccessSpec(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error)
+	Access(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (*pb.Access_Resp, error)
+}
+
+var (
+	_ component.PlatformAccess = (*platformAccessClient)(nil)
+)
@@ -5,39 +5,76 @@ import (
 )
 
 type mix_Platform_Authenticator struct {
+	capabilities
+
 	component.Authenticator
+	component.AuthRefresher
+	component.Validator
+	component.ConfigDefaulter
 	component.ConfigurableNotify
+	component.ConfigurableSchema
 	component.Documented
 	component.Platform
 	component.PlatformReleaser
 	component.WorkspaceDestroyer
 	component.LogPlatform
+	component.PortForwarder
+	component.PlatformAccess
+	component.Troubleshooter
+	component.Snapshotter
 	component.Generation
 	component.Status
+	component.Remediator
+	component.ODRProfiler
 }
 
 type mix_Platform_Destroy struct {
+	capabilities
+
 	component.Authenticator
+	component.AuthRefresher
+	component.Validator
+	component.ConfigDefaulter
 	component.ConfigurableNotify
+	component.ConfigurableSchema
 	component.Documented
 	component.Platform
 	component.PlatformReleaser
 	component.Execer
 	component.LogPlatform
+	component.PortForwarder
+	component.PlatformAccess
+	component.Troubleshooter
+	component.Snapshotter
 	component.Destroyer
+	component.DestroyHooks
 	component.WorkspaceDestroyer
 	component.Generation
 	component.Status
+	component.Remediator
+	component.ODRProfiler
 }
 
 type mix_Platform_Exec struct {
+	capabilities
+
 	component.Authenticator
+	component.AuthRefresher
+	component.Validator
+	component.ConfigDefaulter
 	component.ConfigurableNotify
+	component.ConfigurableSchema
 	component.Documented
 	component.Platform
 	component.PlatformReleaser
 	component.LogPlatform
+	component.PortForwarder
+	component.PlatformAccess
+	component.Troubleshooter
+	component.Snapshotter
 	component.Execer
 	component.Generation
 	component.Status
+	component.Remediator
+	component.ODRProfiler
 }
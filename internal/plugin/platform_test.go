@@ -1,16 +1,26 @@
 package plugin
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
 
@@ -44,14 +54,206 @@ func TestPlatformDynamicFunc_core(t *testing.T) {
 	})
 }
 
+func TestPlatformDeploy_resourceChangelog(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mocks.Platform{}
+	mockV.On("DeployFunc").Return(func(dcr *component.DeclaredResourcesResp) *testproto.Data {
+		dcr.PreviousDeclaredResources = []*pb.DeclaredResource{
+			{Name: "a", Platform: "test"},
+			{Name: "b", Platform: "test"},
+		}
+		dcr.DeclaredResources = []*pb.DeclaredResource{
+			{Name: "b", Platform: "test"},
+			{Name: "c", Platform: "test"},
+		}
+
+		return &testproto.Data{Value: "hello"}
+	})
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	f := raw.(component.Platform).DeployFunc().(*argmapper.Func)
+
+	dcr := &component.DeclaredResourcesResp{}
+	result := f.Call(
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(dcr),
+	)
+	require.NoError(result.Err())
+	require.Len(dcr.DeclaredResources, 2)
+	require.NotNil(dcr.Changelog)
+	require.Len(dcr.Changelog.Added, 1)
+	require.Equal("c", dcr.Changelog.Added[0].Name)
+	require.Len(dcr.Changelog.Removed, 1)
+	require.Equal("a", dcr.Changelog.Removed[0].Name)
+	require.Empty(dcr.Changelog.Changed)
+}
+
+func TestPlatformDeploy_odr(t *testing.T) {
+	cases := []struct {
+		Name     string
+		ODR      bool
+		Impl     bool
+		Expected string
+	}{
+		{"odr", true, true, "odr"},
+		{"not odr", false, true, "basic"},
+		{"odr not implemented", true, false, "basic"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			deploy := func(v string) interface{} {
+				return func() *testproto.Data { return &testproto.Data{Value: v} }
+			}
+
+			var impl component.Platform
+			if tt.Impl {
+				mockV := &mockPlatformODR{}
+				mockV.Platform.On("DeployFunc").Return(deploy("basic"))
+				mockV.PlatformODR.On("DeployODRFunc").Return(deploy("odr"))
+				impl = mockV
+			} else {
+				mockV := &mocks.Platform{}
+				mockV.On("DeployFunc").Return(deploy("basic"))
+				impl = mockV
+			}
+
+			plugins := Plugins(
+				WithComponents(impl),
+				WithMappers(testDefaultMappers(t)...),
+				WithODR(&ODRSetting{Enabled: tt.ODR}),
+			)
+			client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+			defer client.Close()
+			defer server.Stop()
+
+			raw, err := client.Dispense("platform")
+			require.NoError(err)
+			f := raw.(component.Platform).DeployFunc().(*argmapper.Func)
+
+			result := f.Call(
+				argmapper.Typed(context.Background()),
+				argmapper.Typed(&component.DeclaredResourcesResp{}),
+			)
+			require.NoError(result.Err())
+
+			var data testproto.Data
+			require.NoError(component.ProtoAnyUnmarshal(result.Out(0), &data))
+			require.Equal(tt.Expected, data.Value)
+		})
+	}
+}
+
+func TestPlatformDeploy_previousDeployment(t *testing.T) {
+	type deployArgs struct {
+		argmapper.Struct
+
+		Previous *component.PreviousDeployment `argmapper:",typeOnly,optional"`
+	}
+
+	// Update the previous deployment in place if there is one
+	deployFunc := func(args deployArgs) (*testproto.Data, error) {
+		if args.Previous.Deployment == nil {
+			return &testproto.Data{Value: "new"}, nil
+		}
+
+		var prev testproto.Data
+		if err := args.Previous.Deployment.UnmarshalTo(&prev); err != nil {
+			return nil, err
+		}
+
+		return &testproto.Data{Value: prev.Value + "-" + args.Previous.ID}, nil
+	}
+
+	mockV := &mocks.Platform{}
+	mockV.On("DeployFunc").Return(deployFunc)
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(t, err)
+	f := raw.(component.Platform).DeployFunc().(*argmapper.Func)
+
+	t.Run("previous", func(t *testing.T) {
+		require := require.New(t)
+
+		prev, err := component.ProtoAny(&testproto.Data{Value: "old"})
+		require.NoError(err)
+
+		result := f.Call(
+			argmapper.Typed(context.Background()),
+			argmapper.Typed(&component.DeclaredResourcesResp{}),
+			argmapper.Typed(&pb.Args_PreviousDeployment{
+				Id:         "d1",
+				Sequence:   1,
+				Deployment: prev,
+			}),
+		)
+		require.NoError(result.Err())
+
+		var data testproto.Data
+		require.NoError(component.ProtoAnyUnmarshal(result.Out(0), &data))
+		require.Equal("old-d1", data.Value)
+	})
+
+	// Older hosts and the first deployment have no previous deployment
+	t.Run("first", func(t *testing.T) {
+		require := require.New(t)
+
+		result := f.Call(
+			argmapper.Typed(context.Background()),
+			argmapper.Typed(&component.DeclaredResourcesResp{}),
+		)
+		require.NoError(result.Err())
+
+		var data testproto.Data
+		require.NoError(component.ProtoAnyUnmarshal(result.Out(0), &data))
+		require.Equal("new", data.Value)
+	})
+}
+
 func TestPlatformDynamicFunc_destroy(t *testing.T) {
-	testDynamicFunc(t, "platform", &mockPlatformDestroyer{}, func(v, f interface{}) {
-		v.(*mockPlatformDestroyer).Destroyer.On("DestroyFunc").Return(f)
+	testDynamicFunc(t, "platform", &mocks.PlatformWithDestroy{}, func(v, f interface{}) {
+		v.(*mocks.PlatformWithDestroy).Destroyer.On("DestroyFunc").Return(f)
 	}, func(raw interface{}) interface{} {
 		return raw.(component.Destroyer).DestroyFunc()
 	})
 }
 
+func TestPlatformDynamicFunc_preDestroy(t *testing.T) {
+	testDynamicFunc(t, "platform", &mockPlatformDestroyHooks{}, func(v, f interface{}) {
+		v.(*mockPlatformDestroyHooks).Destroyer.On("DestroyFunc").Return(f)
+		v.(*mockPlatformDestroyHooks).DestroyHooks.On("PreDestroyFunc").Return(f)
+		v.(*mockPlatformDestroyHooks).DestroyHooks.On("PostDestroyFunc").Return(nil)
+	}, func(raw interface{}) interface{} {
+		require.Nil(t, raw.(component.DestroyHooks).PostDestroyFunc())
+		return raw.(component.DestroyHooks).PreDestroyFunc()
+	})
+}
+
+func TestPlatformDynamicFunc_postDestroy(t *testing.T) {
+	testDynamicFunc(t, "platform", &mockPlatformDestroyHooks{}, func(v, f interface{}) {
+		v.(*mockPlatformDestroyHooks).Destroyer.On("DestroyFunc").Return(f)
+		v.(*mockPlatformDestroyHooks).DestroyHooks.On("PreDestroyFunc").Return(nil)
+		v.(*mockPlatformDestroyHooks).DestroyHooks.On("PostDestroyFunc").Return(f)
+	}, func(raw interface{}) interface{} {
+		require.Nil(t, raw.(component.DestroyHooks).PreDestroyFunc())
+		return raw.(component.DestroyHooks).PostDestroyFunc()
+	})
+}
+
 func TestPlatformDynamicFunc_auth(t *testing.T) {
 	testDynamicFunc(t, "platform", &mockPlatformAuthenticator{}, func(v, f interface{}) {
 		v.(*mockPlatformAuthenticator).Authenticator.On("AuthFunc").Return(f)
@@ -68,6 +270,56 @@ func TestPlatformDynamicFunc_validateAuth(t *testing.T) {
 	})
 }
 
+func TestPlatformDynamicFunc_diagnostics(t *testing.T) {
+	require := require.New(t)
+
+	var diags diag.Diagnostics
+	mockV := &mocks.PlatformWithDestroy{}
+	mockV.Destroyer.On("DestroyFunc").Return(func(ctx context.Context) (diag.Diagnostics, error) {
+		return diags, nil
+	})
+
+	var buf bytes.Buffer
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	plugins[1]["platform"].(*PlatformPlugin).Logger = hclog.New(&hclog.LoggerOptions{Output: &buf})
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	destroyer := raw.(component.Destroyer)
+
+	// Warnings alone don't fail the call but are sent to the host
+	diags = diags.Warnf("deprecated")
+	f := destroyer.DestroyFunc().(*argmapper.Func)
+	result := f.Call(
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(&component.DeclaredResourcesResp{}),
+		argmapper.Typed(&component.DestroyedResourcesResp{}),
+	)
+	require.NoError(result.Err())
+	require.Contains(buf.String(), "plugin returned a warning")
+	require.Contains(buf.String(), "warning=deprecated")
+
+	// Errors fail the call with all the diagnostics attached
+	diags = append(diags, diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  "in use",
+		Path:     []string{"name"},
+	})
+	f = destroyer.DestroyFunc().(*argmapper.Func)
+	result = f.Call(
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(&component.DeclaredResourcesResp{}),
+		argmapper.Typed(&component.DestroyedResourcesResp{}),
+	)
+	err = result.Err()
+	require.Error(err)
+	require.Contains(err.Error(), "name: in use")
+	require.Equal(diags, diag.FromError(err))
+}
+
 func TestPlatformConfig(t *testing.T) {
 	mockV := &mockPlatformConfigurable{}
 	testConfigurable(t, "platform", mockV, &mockV.Configurable)
@@ -82,7 +334,7 @@ func TestPlatform_generation(t *testing.T) {
 		return []byte("HELLO"), nil
 	}
 
-	mockV := &mockPlatformGeneration{}
+	mockV := &mocks.PlatformWithGeneration{}
 	mockG := &mockV.Generation
 	mockG.On("GenerationFunc").Return(genFunc)
 
@@ -111,6 +363,47 @@ func TestPlatform_generation(t *testing.T) {
 	require.True(called)
 }
 
+func TestPlatform_generationPrevious(t *testing.T) {
+	require := require.New(t)
+
+	// Reuse the previous generation to mutate the existing resources
+	genFunc := func(prev *component.PreviousGeneration) []byte {
+		if len(prev.ID) > 0 {
+			return prev.ID
+		}
+
+		return []byte("NEW")
+	}
+
+	mockV := &mocks.PlatformWithGeneration{}
+	mockV.Generation.On("GenerationFunc").Return(genFunc)
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	f := raw.(component.Generation).GenerationFunc().(*argmapper.Func)
+
+	// The host sends the previous generation as an argument
+	result := f.Call(
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(&pb.Generation_Previous{Id: []byte("OLD"), Sequence: 3}),
+	)
+	require.NoError(result.Err())
+	require.Equal("OLD", string(result.Out(0).([]byte)))
+
+	// The first deployment has no previous generation
+	result = f.Call(
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(&pb.Generation_Previous{}),
+	)
+	require.NoError(result.Err())
+	require.Equal("NEW", string(result.Out(0).([]byte)))
+}
+
 func TestPlatform_generationNoImpl(t *testing.T) {
 	require := require.New(t)
 
@@ -147,7 +440,7 @@ func TestPlatform_status(t *testing.T) {
 		}, nil
 	}
 
-	mockV := &mockPlatformStatus{}
+	mockV := &mocks.PlatformWithStatus{}
 	mockG := &mockV.Status
 	mockG.On("StatusFunc").Return(statusFunc)
 
@@ -182,6 +475,318 @@ func TestPlatform_status(t *testing.T) {
 	require.True(called)
 }
 
+func TestPlatform_portForward(t *testing.T) {
+	require := require.New(t)
+
+	// The plugin replies to each connection with the port and closes it
+	mockV := &mocks.PlatformWithPortForward{}
+	mockV.PortForwarder.On("PortForwardFunc").Return(func(
+		ctx context.Context,
+		info *component.PortForwardInfo,
+	) error {
+		for conn := range info.Connections {
+			fmt.Fprintf(conn, "port %d", info.Port)
+			conn.Close()
+		}
+
+		return nil
+	})
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	require.Contains(component.Capabilities(raw), component.CapabilityPortForwarder)
+	f := raw.(component.PortForwarder).PortForwardFunc().(*argmapper.Func)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	info := &component.PortForwardInfo{
+		Port:        8080,
+		Connections: component.AcceptConnections(context.Background(), ln),
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		result := f.Call(
+			argmapper.ConverterFunc(testDefaultMappers(t)...),
+			argmapper.Typed(context.Background()),
+			argmapper.Typed(hclog.L()),
+			argmapper.Typed(info),
+		)
+		errCh <- result.Err()
+	}()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+	require.NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	data, err := io.ReadAll(conn)
+	require.NoError(err)
+	require.Equal("port 8080", string(data))
+
+	// Closing the listener ends the operation
+	require.NoError(ln.Close())
+	require.NoError(<-errCh)
+}
+
+func TestPlatform_access(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mocks.PlatformWithAccess{}
+	mockV.PlatformAccess.On("AccessInfoFunc").Return(func(
+		ctx context.Context,
+		d *testproto.Data,
+	) *testproto.Data {
+		return &testproto.Data{Value: "cluster:" + d.Value}
+	})
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	require.Contains(component.Capabilities(raw), component.CapabilityPlatformAccess)
+	f := raw.(component.PlatformAccess).AccessInfoFunc().(*argmapper.Func)
+
+	result := f.Call(
+		argmapper.ConverterFunc(testDefaultMappers(t)...),
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(hclog.L()),
+		argmapper.Typed(&testproto.Data{Value: "web"}),
+	)
+	require.NoError(result.Err())
+
+	info := result.Out(0).(component.ProtoMarshaler)
+	var data testproto.Data
+	require.NoError(component.ProtoAnyUnmarshal(info, &data))
+	require.Equal("cluster:web", data.Value)
+}
+
+func TestPlatform_accessNoImpl(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mockPlatformLog{}
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	require.NotContains(component.Capabilities(raw), component.CapabilityPlatformAccess)
+	require.Nil(raw.(component.PlatformAccess).AccessInfoFunc())
+}
+
+func TestPlatform_troubleshoot(t *testing.T) {
+	require := require.New(t)
+
+	// The log entry is larger than a single message so it is chunked
+	expected := &pb.DiagnosticsBundle{
+		GeneratedTime: timestamppb.Now(),
+		Summary:       "web is crashing",
+		Entries: []*pb.DiagnosticsBundle_Entry{
+			{
+				Name:        "web.log",
+				Kind:        pb.DiagnosticsBundle_LOG,
+				ContentType: "text/plain",
+				Data:        bytes.Repeat([]byte("panic: oops\n"), diagnosticsChunkSize/4),
+			},
+			{
+				Name: "events",
+				Kind: pb.DiagnosticsBundle_EVENTS,
+			},
+			{
+				Name:        "deployment",
+				Kind:        pb.DiagnosticsBundle_RESOURCE,
+				ContentType: "application/json",
+				Data:        []byte(`{"replicas":0}`),
+				Metadata:    map[string]string{"namespace": "default"},
+			},
+		},
+	}
+
+	mockV := &mocks.PlatformWithTroubleshoot{}
+	mockV.Troubleshooter.On("TroubleshootFunc").Return(func(
+		ctx context.Context,
+	) (*pb.DiagnosticsBundle, error) {
+		return expected, nil
+	})
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	require.Contains(component.Capabilities(raw), component.CapabilityTroubleshooter)
+	f := raw.(component.Troubleshooter).TroubleshootFunc().(*argmapper.Func)
+
+	result := f.Call(
+		argmapper.ConverterFunc(testDefaultMappers(t)...),
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(hclog.L()),
+	)
+	require.NoError(result.Err())
+
+	bundle := result.Out(0).(*pb.DiagnosticsBundle)
+	require.True(proto.Equal(expected, bundle))
+}
+
+func TestPlatform_troubleshootNilBundle(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mocks.PlatformWithTroubleshoot{}
+	mockV.Troubleshooter.On("TroubleshootFunc").Return(func(
+		ctx context.Context,
+	) (*pb.DiagnosticsBundle, error) {
+		return nil, nil
+	})
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	f := raw.(component.Troubleshooter).TroubleshootFunc().(*argmapper.Func)
+
+	result := f.Call(
+		argmapper.ConverterFunc(testDefaultMappers(t)...),
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(hclog.L()),
+	)
+	require.NoError(result.Err())
+
+	bundle := result.Out(0).(*pb.DiagnosticsBundle)
+	require.NotNil(bundle)
+	require.Empty(bundle.Entries)
+}
+
+func TestPlatform_troubleshootNoImpl(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mockPlatformLog{}
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	require.NotContains(component.Capabilities(raw), component.CapabilityTroubleshooter)
+	require.Nil(raw.(component.Troubleshooter).TroubleshootFunc())
+}
+
+func TestPlatform_snapshot(t *testing.T) {
+	require := require.New(t)
+
+	// More than one chunk
+	data := bytes.Repeat([]byte("hello"), snapshotChunkSize)
+
+	var restored []byte
+	mockV := &mocks.PlatformWithSnapshot{}
+	mockV.Snapshotter.On("SnapshotFunc").Return(func(
+		ctx context.Context,
+		w *component.SnapshotWriter,
+	) error {
+		_, err := w.Write(data)
+		return err
+	})
+	mockV.Snapshotter.On("RestoreFunc").Return(func(
+		ctx context.Context,
+		r *component.SnapshotReader,
+	) error {
+		var err error
+		restored, err = io.ReadAll(r)
+		return err
+	})
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	require.Contains(component.Capabilities(raw), component.CapabilitySnapshotter)
+	snapshotter := raw.(component.Snapshotter)
+
+	var buf bytes.Buffer
+	result := snapshotter.SnapshotFunc().(*argmapper.Func).Call(
+		argmapper.ConverterFunc(testDefaultMappers(t)...),
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(hclog.L()),
+		argmapper.Typed(component.NewSnapshotWriter(&buf)),
+	)
+	require.NoError(result.Err())
+	require.Equal(data, buf.Bytes())
+
+	result = snapshotter.RestoreFunc().(*argmapper.Func).Call(
+		argmapper.ConverterFunc(testDefaultMappers(t)...),
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(hclog.L()),
+		argmapper.Typed(component.NewSnapshotReader(&buf)),
+	)
+	require.NoError(result.Err())
+	require.Equal(data, restored)
+}
+
+func TestPlatform_restoreError(t *testing.T) {
+	require := require.New(t)
+
+	// The plugin fails without reading the data
+	mockV := &mocks.PlatformWithSnapshot{}
+	mockV.Snapshotter.On("SnapshotFunc").Return(func() error { return nil })
+	mockV.Snapshotter.On("RestoreFunc").Return(func(r *component.SnapshotReader) error {
+		return errors.New("incompatible snapshot")
+	})
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+
+	result := raw.(component.Snapshotter).RestoreFunc().(*argmapper.Func).Call(
+		argmapper.ConverterFunc(testDefaultMappers(t)...),
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(hclog.L()),
+		argmapper.Typed(component.NewSnapshotReader(
+			bytes.NewReader(bytes.Repeat([]byte("hello"), snapshotChunkSize)))),
+	)
+	require.Error(result.Err())
+	require.Contains(result.Err().Error(), "incompatible snapshot")
+}
+
+func TestPlatform_snapshotNoImpl(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mockPlatformLog{}
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	require.NotContains(component.Capabilities(raw), component.CapabilitySnapshotter)
+	require.Nil(raw.(component.Snapshotter).SnapshotFunc())
+	require.Nil(raw.(component.Snapshotter).RestoreFunc())
+}
+
 func TestPlatform_statusNoImpl(t *testing.T) {
 	require := require.New(t)
 
@@ -198,6 +803,260 @@ func TestPlatform_statusNoImpl(t *testing.T) {
 	require.Nil(value.StatusFunc())
 }
 
+func TestPlatformDynamicFunc_remediate(t *testing.T) {
+	require := require.New(t)
+
+	var actual *component.Remediation
+	remediateFunc := func(ctx context.Context, r *component.Remediation) error {
+		actual = r
+		return nil
+	}
+
+	mockV := &mockPlatformRemediator{}
+	mockV.Remediator.On("RemediateFunc").Return(remediateFunc)
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	value := raw.(component.Remediator)
+	f := value.RemediateFunc().(*argmapper.Func)
+	require.NotNil(f)
+
+	result := f.Call(
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(&pb.Args_Remediation{
+			Action: &pb.StatusReport_SuggestedAction{
+				Id:         "restart",
+				Label:      "Restart",
+				Disruptive: true,
+			},
+			Resource: &pb.StatusReport_Resource{Name: "web"},
+		}),
+	)
+	require.NoError(result.Err())
+
+	require.NotNil(actual)
+	require.Equal("restart", actual.Action.Id)
+	require.True(actual.Action.Disruptive)
+	require.Equal("web", actual.Resource.Name)
+}
+
+func TestPlatform_remediateNoImpl(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mockPlatformLog{}
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	value := raw.(component.Remediator)
+	require.Nil(value.RemediateFunc())
+}
+
+func TestPlatform_capabilities(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mocks.PlatformWithStatus{}
+	mockV.Status.On("StatusFunc").Return(func() *pb.StatusReport { return nil })
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	require.Equal([]string{component.CapabilityStatus}, component.Capabilities(raw))
+
+	// The mixin client embeds Destroyer but the plugin doesn't implement it.
+	err = component.RequireCapability(raw, "test", component.CapabilityDestroyer)
+	var capErr *component.ErrCapabilityRequired
+	require.True(errors.As(err, &capErr))
+	require.Equal([]string{component.CapabilityStatus}, capErr.Available)
+}
+
+func TestPlatform_odrProfiler(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mockPlatformODRProfiler{}
+	mockV.ODRProfiler.On("ODRRequirements").Return(&component.ODRRequirements{
+		Image:       "waypoint-odr:custom",
+		CPU:         500,
+		Memory:      1024,
+		Environment: []string{"AWS_REGION"},
+		Credentials: []string{"aws"},
+		Privileged:  true,
+	}, nil)
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	require.Contains(component.Capabilities(raw), component.CapabilityODRProfiler)
+
+	reqs, err := raw.(component.ODRProfiler).ODRRequirements()
+	require.NoError(err)
+	require.Equal(&component.ODRRequirements{
+		Image:       "waypoint-odr:custom",
+		CPU:         500,
+		Memory:      1024,
+		Environment: []string{"AWS_REGION"},
+		Credentials: []string{"aws"},
+		Privileged:  true,
+	}, reqs)
+}
+
+func TestPlatform_odrProfilerNoImpl(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mockPlatformLog{}
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	require.NotContains(component.Capabilities(raw), component.CapabilityODRProfiler)
+
+	// The client still has the method, which reports no requirements
+	reqs, err := raw.(component.ODRProfiler).ODRRequirements()
+	require.NoError(err)
+	require.Nil(reqs)
+}
+
+func TestPlatformValidate(t *testing.T) {
+	require := require.New(t)
+
+	validateFunc := func(ctx context.Context, src *component.Source) *pb.ValidationResult {
+		return &pb.ValidationResult{
+			Diagnostics: []*pb.ValidationResult_Diagnostic{
+				{
+					Severity: pb.ValidationResult_ERROR,
+					Summary:  "subnet does not exist",
+					Field:    "network.subnet",
+				},
+				{
+					Severity: pb.ValidationResult_WARNING,
+					Summary:  "app " + src.App + " has no health check",
+				},
+			},
+		}
+	}
+
+	mockV := &mockPlatformValidator{}
+	mockV.Validator.On("ValidateFunc").Return(validateFunc)
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	require.Contains(component.Capabilities(raw), component.CapabilityValidator)
+	f := raw.(component.Validator).ValidateFunc().(*argmapper.Func)
+	require.NotNil(f)
+
+	result := f.Call(
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(&pb.Args_Source{App: "foo"}),
+	)
+	require.NoError(result.Err())
+
+	vr := result.Out(0).(*pb.ValidationResult)
+	require.Len(vr.Diagnostics, 2)
+	require.Equal("network.subnet", vr.Diagnostics[0].Field)
+	require.Equal(pb.ValidationResult_WARNING, vr.Diagnostics[1].Severity)
+	require.Equal("app foo has no health check", vr.Diagnostics[1].Summary)
+}
+
+func TestPlatformValidate_noImpl(t *testing.T) {
+	require := require.New(t)
+
+	plugins := Plugins(WithComponents(&mockPlatformLog{}), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	require.Nil(raw.(component.Validator).ValidateFunc())
+}
+
+func TestPlatformDefaults(t *testing.T) {
+	require := require.New(t)
+
+	var config struct {
+		Region string `hcl:"region,optional"`
+		Token  string `hcl:"token,optional" sensitive:"true"`
+	}
+	config.Token = "secret"
+
+	defaultsFunc := func(ctx context.Context, src *component.Source) error {
+		if config.Region == "" {
+			config.Region = src.App + "-region"
+		}
+
+		return nil
+	}
+
+	mockV := &mockPlatformConfigDefaulter{}
+	mockV.Configurable.On("Config").Return(&config, nil)
+	mockV.ConfigDefaulter.On("DefaultsFunc").Return(defaultsFunc)
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	require.Contains(component.Capabilities(raw), component.CapabilityConfigDefaulter)
+	f := raw.(component.ConfigDefaulter).DefaultsFunc().(*argmapper.Func)
+	require.NotNil(f)
+
+	result := f.Call(
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(&pb.Args_Source{App: "foo"}),
+	)
+	require.NoError(result.Err())
+	require.Equal("foo-region", config.Region)
+
+	effective := result.Out(0).(*pb.Config_Effective)
+	require.Len(effective.Fields, 2)
+	require.Equal("region", effective.Fields[0].Name)
+	require.Equal(`"foo-region"`, string(effective.Fields[0].Json))
+	require.Equal("token", effective.Fields[1].Name)
+	require.True(effective.Fields[1].Sensitive)
+	require.Empty(effective.Fields[1].Json)
+}
+
+func TestPlatformDefaults_noImpl(t *testing.T) {
+	require := require.New(t)
+
+	plugins := Plugins(WithComponents(&mockPlatformLog{}), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("platform")
+	require.NoError(err)
+	require.Nil(raw.(component.ConfigDefaulter).DefaultsFunc())
+}
+
 type mockPlatformAuthenticator struct {
 	mocks.Platform
 	mocks.Authenticator
@@ -208,22 +1067,39 @@ type mockPlatformConfigurable struct {
 	mocks.Configurable
 }
 
+type mockPlatformODR struct {
+	mocks.Platform
+	mocks.PlatformODR
+}
+
+type mockPlatformODRProfiler struct {
+	mocks.Platform
+	mocks.ODRProfiler
+}
+
 type mockPlatformLog struct {
 	mocks.Platform
 	mocks.LogPlatform
 }
 
-type mockPlatformDestroyer struct {
+type mockPlatformDestroyHooks struct {
 	mocks.Platform
 	mocks.Destroyer
+	mocks.DestroyHooks
+}
+
+type mockPlatformRemediator struct {
+	mocks.Platform
+	mocks.Remediator
 }
 
-type mockPlatformGeneration struct {
+type mockPlatformValidator struct {
 	mocks.Platform
-	mocks.Generation
+	mocks.Validator
 }
 
-type mockPlatformStatus struct {
+type mockPlatformConfigDefaulter struct {
 	mocks.Platform
-	mocks.Status
+	mocks.Configurable
+	mocks.ConfigDefaulter
 }
@@ -7,6 +7,11 @@ import (
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
 )
 
 // Handshake is a common handshake that is shared by plugin and host.
@@ -18,6 +23,14 @@ var Handshake = plugin.HandshakeConfig{
 	MagicCookieValue: "be6c1928786a4df0222c13eef44ac846da2c0d461d99addc93f804601c6b7205",
 }
 
+// SDKFeatures are the features that the SDK implements itself. They are
+// supported by every plugin and negotiated by every host built with this
+// SDK, in addition to the features they declare.
+var SDKFeatures = []component.Feature{
+	component.FeatureLargeValues,
+	component.FeatureTerminalConfirm,
+}
+
 // Plugins returns the list of available plugins and initializes them with
 // the given components. This will panic if an invalid component is given.
 func Plugins(opts ...Option) map[int]plugin.PluginSet {
@@ -31,24 +44,103 @@ func Plugins(opts ...Option) map[int]plugin.PluginSet {
 		c.Logger = hclog.L()
 	}
 
+	// In status-only mode only the components that support status are
+	// initialized and served.
+	if c.StatusOnly {
+		c.Components = StatusOnlyComponents(c.Components)
+		for v, cs := range c.VersionedComponents {
+			c.VersionedComponents[v] = StatusOnlyComponents(cs)
+		}
+
+		var named []*namedComponent
+		for _, nc := range c.NamedComponents {
+			if len(StatusOnlyComponents([]interface{}{nc.Component})) > 0 {
+				named = append(named, nc)
+			}
+		}
+		c.NamedComponents = named
+	}
+
+	// Check the functions of every component up front in strict mode
+	if c.StrictSpecs {
+		cs := append([]interface{}{}, c.Components...)
+		for _, vcs := range c.VersionedComponents {
+			cs = append(cs, vcs...)
+		}
+		for _, nc := range c.NamedComponents {
+			cs = append(cs, nc.Component)
+		}
+
+		if err := CheckSpecs(cs, c.Mappers, c.Logger); err != nil {
+			panic(err)
+		}
+	}
+
+	// Report the health of every component
+	if h := c.Health; h != nil {
+		for _, c := range c.Components {
+			h.add(c, "")
+		}
+		for _, cs := range c.VersionedComponents {
+			for _, c := range cs {
+				h.add(c, "")
+			}
+		}
+		for _, nc := range c.NamedComponents {
+			h.add(nc.Component, nc.Name)
+		}
+	}
+
+	// The components from WithComponents are served for protocol version 1
+	// unless there are version-specific components for it.
+	components := map[int][]interface{}{1: c.Components}
+	for v, cs := range c.VersionedComponents {
+		components[v] = cs
+	}
+
 	// Build our plugin types
-	result := map[int]plugin.PluginSet{
-		1: {
-			"mapper":         &MapperPlugin{},
-			"builder":        &BuilderPlugin{},
-			"platform":       &PlatformPlugin{},
-			"registry":       &RegistryPlugin{},
-			"releasemanager": &ReleaseManagerPlugin{},
-			"configsourcer":  &ConfigSourcerPlugin{},
-			"tasklauncher":   &TaskLauncherPlugin{},
-		},
-	}
-
-	// Set the various field values
-	for _, c := range c.Components {
-		if err := setFieldValue(result, c); err != nil {
+	result := map[int]plugin.PluginSet{}
+	for v, cs := range components {
+		set := pluginSet()
+		result[v] = set
+
+		// Add the plugins for custom component types
+		for name, p := range c.Plugins {
+			if _, ok := set[name]; ok {
+				panic(fmt.Sprintf("plugin %q is already in the plugin set", name))
+			}
+
+			copied, ok := copyPlugin(p)
+			if !ok {
+				panic(fmt.Sprintf("plugin %q must be a pointer to a struct, got %T", name, p))
+			}
+			set[name] = copied
+		}
+
+		// Set the various field values
+		single := map[int]plugin.PluginSet{v: set}
+		for _, c := range cs {
+			if err := setFieldValue(single, c); err != nil {
+				panic(err)
+			}
+		}
+
+		// Add the named components alongside the components of every version
+		for _, nc := range c.NamedComponents {
+			if err := addNamedComponent(set, nc); err != nil {
+				panic(err)
+			}
+		}
+
+		// Set the protocol version so components can request it
+		if err := setFieldValue(single, &component.ProtocolVersion{Version: v}); err != nil {
 			panic(err)
 		}
+
+		// Describe the set so hosts can enumerate it
+		info := set["info"].(*InfoPlugin)
+		info.Info = pluginInfo(&c, v, set)
+		info.Set = set
 	}
 
 	// Set the mappers
@@ -63,16 +155,83 @@ func Plugins(opts ...Option) map[int]plugin.PluginSet {
 	if err := setFieldValue(result, c.ODR); err != nil {
 		panic(err)
 	}
+	// Set the features, which are negotiated through the info plugin
+	supported := append(append([]component.Feature(nil), SDKFeatures...), c.Features...)
+	if c.StatusOnly {
+		supported = append(supported, component.FeatureStatusOnly)
+	}
+	if err := setFieldValue(result, &pluginargs.FeatureSet{Supported: supported}); err != nil {
+		panic(err)
+	}
+	// Set the tracer provider and interceptors for the brokered servers
+	if c.TracerProvider != nil {
+		if err := setFieldValue(result, c.TracerProvider); err != nil {
+			panic(err)
+		}
+	}
+	if len(c.UnaryInterceptors) > 0 {
+		if err := setFieldValue(result, c.UnaryInterceptors); err != nil {
+			panic(err)
+		}
+	}
+	if len(c.StreamInterceptors) > 0 {
+		if err := setFieldValue(result, c.StreamInterceptors); err != nil {
+			panic(err)
+		}
+	}
+	// Set the settings for the brokered servers
+	if c.ServerSettings != nil {
+		if err := setFieldValue(result, c.ServerSettings); err != nil {
+			panic(err)
+		}
+	}
+
+	// In status-only mode the plugins that don't serve a component aren't
+	// registered, other than the mapper and info plugins.
+	if c.StatusOnly {
+		for _, set := range result {
+			statusOnlyPluginSet(set)
+		}
+	}
 
 	return result
 }
 
+// pluginSet returns a new plugin set with every plugin type.
+func pluginSet() plugin.PluginSet {
+	return plugin.PluginSet{
+		"mapper":         &MapperPlugin{},
+		"builder":        &BuilderPlugin{},
+		"platform":       &PlatformPlugin{},
+		"registry":       &RegistryPlugin{},
+		"releasemanager": &ReleaseManagerPlugin{},
+		"configsourcer":  &ConfigSourcerPlugin{},
+		"tasklauncher":   &TaskLauncherPlugin{},
+		"info":           &InfoPlugin{},
+	}
+}
+
 // pluginConfig is used to configure Plugins via Option calls.
 type pluginConfig struct {
-	Components []interface{}
-	Mappers    []*argmapper.Func
-	Logger     hclog.Logger
-	ODR        *ODRSetting
+	Components          []interface{}
+	VersionedComponents map[int][]interface{}
+	NamedComponents     []*namedComponent
+	Mappers             []*argmapper.Func
+	Logger              hclog.Logger
+	ODR                 *ODRSetting
+	Features            []component.Feature
+	StrictSpecs         bool
+	Health              *HealthServer
+	Plugins             map[string]plugin.Plugin
+	TracerProvider      trace.TracerProvider
+	UnaryInterceptors   []grpc.UnaryServerInterceptor
+	StreamInterceptors  []grpc.StreamServerInterceptor
+	ServerSettings      *pluginargs.ServerSettings
+	Name                string
+	Version             string
+	Commit              string
+	Contracts           []component.Contract
+	StatusOnly          bool
 }
 
 // Option configures Plugins
@@ -84,6 +243,21 @@ func WithComponents(cs ...interface{}) Option {
 	return func(c *pluginConfig) { c.Components = append(c.Components, cs...) }
 }
 
+// WithVersionedComponents sets the components to serve to hosts that
+// negotiate the given protocol version instead of the components from
+// WithComponents. This lets plugins serve new features to new hosts while
+// remaining compatible with older hosts. This will append to the components
+// for that version.
+func WithVersionedComponents(version int, cs ...interface{}) Option {
+	return func(c *pluginConfig) {
+		if c.VersionedComponents == nil {
+			c.VersionedComponents = map[int][]interface{}{}
+		}
+
+		c.VersionedComponents[version] = append(c.VersionedComponents[version], cs...)
+	}
+}
+
 // WithMappers sets the mappers to configure for the plugins. This will
 // append to the existing mappers.
 func WithMappers(ms ...*argmapper.Func) Option {
@@ -92,11 +266,65 @@ func WithMappers(ms ...*argmapper.Func) Option {
 	}
 }
 
+// WithContracts registers the protobuf message types that the components
+// exchange with other components. The info of each component lists the
+// registered types its main operation returns and accepts.
+func WithContracts(cs ...component.Contract) Option {
+	return func(c *pluginConfig) { c.Contracts = append(c.Contracts, cs...) }
+}
+
 // WithLogger sets the logger for the plugins.
 func WithLogger(log hclog.Logger) Option {
 	return func(c *pluginConfig) { c.Logger = log }
 }
 
+// WithInfo sets the name and version of the plugin that are reported by
+// the info plugin.
+func WithInfo(name, version string) Option {
+	return func(c *pluginConfig) {
+		c.Name = name
+		c.Version = version
+	}
+}
+
+// WithCommit sets the VCS commit the plugin was built from that is
+// reported by the info plugin.
+func WithCommit(commit string) Option {
+	return func(c *pluginConfig) { c.Commit = commit }
+}
+
+// WithStatusOnly serves only the components that support status, and only
+// their status-related RPCs along with the server options from
+// StatusOnlyServerOptions. The plugins for the other component types and
+// the custom component types aren't registered. Hosts detect this mode by
+// negotiating component.FeatureStatusOnly.
+func WithStatusOnly() Option {
+	return func(c *pluginConfig) { c.StatusOnly = true }
+}
+
+// WithPlugin adds the plugin p to the plugin set of every protocol version
+// with the given name. This is used to serve custom component types, see
+// the componentkit package. Like the plugins for the built-in component
+// types, p must be a pointer to a struct and the components, mappers and
+// logger are set on the fields of p that they can be assigned to. Each
+// plugin set gets its own copy of p.
+func WithPlugin(name string, p plugin.Plugin) Option {
+	return func(c *pluginConfig) {
+		if c.Plugins == nil {
+			c.Plugins = map[string]plugin.Plugin{}
+		}
+
+		c.Plugins[name] = p
+	}
+}
+
+// WithFeatures sets the optional protocol features the plugin supports.
+// The features that the host also supports are enabled when the host
+// negotiates them. This will append to the existing features.
+func WithFeatures(fs ...component.Feature) Option {
+	return func(c *pluginConfig) { c.Features = append(c.Features, fs...) }
+}
+
 // ODRSetting are any specific settings associated with running a plugin
 // in Ondemand Runner (aka ODR) mode.
 type ODRSetting struct {
@@ -108,6 +336,34 @@ func WithODR(odr *ODRSetting) Option {
 	return func(c *pluginConfig) { c.ODR = odr }
 }
 
+// WithTracerProvider sets the tracer provider the plugins use to trace
+// the servers they start with the broker, such as for the terminal.UI.
+// The servers created by go-plugin are traced with the server options from
+// tracing.ServerOptions.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *pluginConfig) { c.TracerProvider = tp }
+}
+
+// WithInterceptors adds interceptors to the servers the plugins start with
+// the broker, such as for the terminal.UI. This will append to the
+// existing interceptors.
+func WithInterceptors(
+	unary []grpc.UnaryServerInterceptor,
+	stream []grpc.StreamServerInterceptor,
+) Option {
+	return func(c *pluginConfig) {
+		c.UnaryInterceptors = append(c.UnaryInterceptors, unary...)
+		c.StreamInterceptors = append(c.StreamInterceptors, stream...)
+	}
+}
+
+// WithServerSettings sets the settings for the servers the plugins start
+// with the broker, such as for the terminal.UI. The server created by
+// go-plugin should be created with the options from s.Options.
+func WithServerSettings(s *pluginargs.ServerSettings) Option {
+	return func(c *pluginConfig) { c.ServerSettings = s }
+}
+
 // setFieldValue sets the given value c on any exported field of an available
 // plugin that matches the type of c. An error is returned if c can't be
 // assigned to ANY plugin type.
@@ -2,6 +2,8 @@ package plugin
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-argmapper"
@@ -9,10 +11,14 @@ import (
 	"github.com/hashicorp/go-plugin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal-shared/protomappers"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/plugincomponent"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
@@ -31,6 +37,149 @@ func TestPlugins(t *testing.T) {
 	require.Equal(bp.Impl, mock)
 }
 
+func TestPlugins_tracerProvider(t *testing.T) {
+	require := require.New(t)
+
+	tp := trace.NewNoopTracerProvider()
+	plugins := Plugins(WithComponents(&mocks.Builder{}), WithTracerProvider(tp))
+	bp := plugins[1]["builder"].(*BuilderPlugin)
+	require.Equal(tp, bp.TracerProvider)
+
+	// Without a tracer provider the servers aren't traced
+	plugins = Plugins(WithComponents(&mocks.Builder{}))
+	bp = plugins[1]["builder"].(*BuilderPlugin)
+	require.Nil(bp.TracerProvider)
+}
+
+func TestPlugins_interceptors(t *testing.T) {
+	require := require.New(t)
+
+	unary := func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		return handler(ctx, req)
+	}
+
+	plugins := Plugins(
+		WithComponents(&mocks.Platform{}),
+		WithInterceptors([]grpc.UnaryServerInterceptor{unary}, nil),
+	)
+	pp := plugins[1]["platform"].(*PlatformPlugin)
+	require.Len(pp.UnaryInterceptors, 1)
+	require.Empty(pp.StreamInterceptors)
+
+	// The interceptors are used for the servers started with the broker
+	b := &base{UnaryInterceptors: pp.UnaryInterceptors}
+	require.Len(b.internal().ServerOptions(), 1)
+	require.Empty((&base{}).internal().ServerOptions())
+}
+
+func TestPlugins_serverSettings(t *testing.T) {
+	require := require.New(t)
+
+	settings := &pluginargs.ServerSettings{MaxMessageSize: 16 << 20}
+	plugins := Plugins(WithComponents(&mocks.Builder{}), WithServerSettings(settings))
+	bp := plugins[1]["builder"].(*BuilderPlugin)
+	require.Equal(settings, bp.ServerSettings)
+}
+
+func TestPlugins_strictSpecs(t *testing.T) {
+	type Foo struct{}
+
+	valid := &mocks.Builder{}
+	valid.On("BuildFunc").Return(func(*testproto.Data) *testproto.Data { return nil })
+
+	invalid := &mocks.Builder{}
+	invalid.On("BuildFunc").Return(func(*Foo) *testproto.Data { return nil })
+
+	require.NotPanics(t, func() {
+		Plugins(WithComponents(valid), WithStrictSpecs())
+	})
+
+	// Without strict mode the error only happens when the spec is requested
+	require.NotPanics(t, func() {
+		Plugins(WithComponents(invalid))
+	})
+
+	require.Panics(t, func() {
+		Plugins(WithComponents(invalid), WithStrictSpecs())
+	})
+
+	err := CheckSpecs([]interface{}{invalid}, nil, hclog.L())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "BuildFunc")
+	require.Contains(t, err.Error(), "*plugin.Foo")
+}
+
+func TestPlugins_customPlugin(t *testing.T) {
+	require := require.New(t)
+
+	impl := &testCustomImpl{}
+	plugins := Plugins(
+		WithComponents(impl),
+		WithVersionedComponents(2, impl),
+		WithPlugin("custom", &testCustomPlugin{}),
+		WithLogger(hclog.L()),
+	)
+
+	p1 := plugins[1]["custom"].(*testCustomPlugin)
+	p2 := plugins[2]["custom"].(*testCustomPlugin)
+	require.Equal(impl, p1.Impl)
+	require.NotNil(p1.Logger)
+	require.Equal(1, p1.ProtocolVersion.Version)
+	require.Equal(2, p2.ProtocolVersion.Version)
+
+	require.Panics(func() {
+		Plugins(WithPlugin("builder", &testCustomPlugin{}))
+	})
+}
+
+func TestPlugins_versionedComponents(t *testing.T) {
+	require := require.New(t)
+
+	deployFunc := func(v *component.ProtocolVersion) *testproto.Data {
+		return &testproto.Data{Value: fmt.Sprintf("v%d", v.Version)}
+	}
+
+	v1 := &mocks.Platform{}
+	v1.On("DeployFunc").Return(deployFunc)
+	v2 := &mocks.Platform{}
+	v2.On("DeployFunc").Return(deployFunc)
+
+	plugins := Plugins(
+		WithComponents(v1),
+		WithVersionedComponents(2, v2),
+		WithMappers(testDefaultMappers(t)...),
+	)
+	require.Len(plugins, 2)
+	require.Equal(v1, plugins[1]["platform"].(*PlatformPlugin).Impl)
+	require.Equal(v2, plugins[2]["platform"].(*PlatformPlugin).Impl)
+
+	for _, version := range []int{1, 2} {
+		client, server := plugin.TestPluginGRPCConn(t, plugins[version])
+		defer client.Close()
+		defer server.Stop()
+
+		raw, err := client.Dispense("platform")
+		require.NoError(err)
+		f := raw.(component.Platform).DeployFunc().(*argmapper.Func)
+
+		result := f.Call(
+			argmapper.Typed(context.Background()),
+			argmapper.Typed(&component.DeclaredResourcesResp{}),
+		)
+		require.NoError(result.Err())
+
+		deployment := result.Out(0).(*plugincomponent.Deployment)
+		var data testproto.Data
+		require.NoError(component.ProtoAnyUnmarshal(deployment.Any, &data))
+		require.Equal(fmt.Sprintf("v%d", version), data.Value)
+	}
+}
+
 func testDefaultMappers(t *testing.T) []*argmapper.Func {
 	var mappers []*argmapper.Func
 	for _, raw := range protomappers.All {
@@ -109,3 +258,84 @@ func testDynamicFunc(
 
 	require.True(called)
 }
+
+type testCustomComponent interface {
+	CustomFunc() interface{}
+}
+
+func TestPlugins_features(t *testing.T) {
+	require := require.New(t)
+
+	deployFunc := func(fs *component.Features) *testproto.Data {
+		var names []string
+		for _, f := range fs.List() {
+			names = append(names, string(f))
+		}
+
+		return &testproto.Data{Value: strings.Join(names, ",")}
+	}
+
+	mockV := &mocks.Platform{}
+	mockV.On("DeployFunc").Return(deployFunc)
+
+	plugins := Plugins(
+		WithComponents(mockV),
+		WithMappers(testDefaultMappers(t)...),
+		WithFeatures("plugin-only", "both"),
+	)
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	deploy := func() string {
+		raw, err := client.Dispense("platform")
+		require.NoError(err)
+		f := raw.(component.Platform).DeployFunc().(*argmapper.Func)
+
+		result := f.Call(
+			argmapper.Typed(context.Background()),
+			argmapper.Typed(&component.DeclaredResourcesResp{}),
+		)
+		require.NoError(result.Err())
+
+		deployment := result.Out(0).(*plugincomponent.Deployment)
+		var data testproto.Data
+		require.NoError(component.ProtoAnyUnmarshal(deployment.Any, &data))
+		return data.Value
+	}
+
+	// Nothing is enabled until the host negotiates
+	require.Equal("", deploy())
+
+	raw, err := client.Dispense("info")
+	require.NoError(err)
+	features, err := raw.(*InfoClient).Negotiate(context.Background(),
+		"both", "host-only")
+	require.NoError(err)
+	require.Equal([]component.Feature{
+		"both",
+		component.FeatureLargeValues,
+		component.FeatureTerminalConfirm,
+	}, features.List())
+	require.Equal("both,large-values,terminal-confirm", deploy())
+}
+
+type testCustomImpl struct{}
+
+func (testCustomImpl) CustomFunc() interface{} { return nil }
+
+type testCustomPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+
+	Impl            testCustomComponent
+	Logger          hclog.Logger
+	ProtocolVersion *component.ProtocolVersion
+}
+
+func (p *testCustomPlugin) GRPCServer(*plugin.GRPCBroker, *grpc.Server) error {
+	return nil
+}
+
+func (p *testCustomPlugin) GRPCClient(context.Context, *plugin.GRPCBroker, *grpc.ClientConn) (interface{}, error) {
+	return nil, nil
+}
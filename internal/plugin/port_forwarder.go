@@ -0,0 +1,139 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// portForwarderClient implements component.PortForwarder for a service
+// that has the port forward methods implemented.
+type portForwarderClient struct {
+	Client  portForwarderProtoClient
+	Logger  hclog.Logger
+	Broker  *plugin.GRPCBroker
+	Mappers []*argmapper.Func
+}
+
+func (c *portForwarderClient) Implements(ctx context.Context) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	resp, err := c.Client.IsPortForwarder(ctx, &empty.Empty{})
+	if err != nil {
+		// Plugins built with an SDK before port forwarding was added
+		// don't implement the RPC, so they don't support it.
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return resp.Implements, nil
+}
+
+func (c *portForwarderClient) PortForwardFunc() interface{} {
+	// If we're not initialized, then it doesn't support port forwarding
+	if c == nil {
+		return nil
+	}
+
+	// Get the spec
+	spec, err := c.Client.PortForwardSpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		return funcErr(err)
+	}
+
+	// We don't want to be a mapper
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.portForward,
+		argmapper.Logger(c.Logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.Broker,
+			Mappers: c.Mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *portForwarderClient) portForward(
+	ctx context.Context,
+	args funcspec.Args,
+	internal *pluginargs.Internal,
+) error {
+	// Run the cleanup
+	defer internal.Cleanup.Close()
+
+	// Call our function
+	_, err := c.Client.PortForward(ctx, &pb.FuncSpec_Args{Args: args})
+	return err
+}
+
+// portForwarderServer implements the common PortForwarder-related RPC
+// calls. This should be embedded into the service implementation.
+type portForwarderServer struct {
+	*base
+	Impl interface{}
+}
+
+func (s *portForwarderServer) IsPortForwarder(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.ImplementsResp, error) {
+	d, ok := s.Impl.(component.PortForwarder)
+	return &pb.ImplementsResp{
+		Implements: ok && d.PortForwardFunc() != nil,
+	}, nil
+}
+
+func (s *portForwarderServer) PortForwardSpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	return funcspec.Spec(s.Impl.(component.PortForwarder).PortForwardFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+	)
+}
+
+func (s *portForwarderServer) PortForward(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*empty.Empty, error) {
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	_, err := callDynamicFunc2(ctx, s.Impl.(component.PortForwarder).PortForwardFunc(), args.Args,
+		argmapper.Typed(ctx),
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Typed(internal),
+	)
+	return &empty.Empty{}, err
+}
+
+// portForwarderProtoClient is the interface we expect any gRPC service
+// that supports port forwarding to implement.
+type portForwarderProtoClient interface {
+	IsPortForwarder(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ImplementsResp, error)
+	PortForwardSpec(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error)
+	PortForward(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (*empty.Empty, error)
+}
+
+var (
+	_ component.PortForwarder = (*portForwarderClient)(nil)
+)
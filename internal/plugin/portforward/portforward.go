@@ -0,0 +1,371 @@
+package portforward
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// bufferSize is the most data sent in a single message.
+const bufferSize = 32 * 1024
+
+// PortForwardPlugin implements plugin.Plugin (specifically GRPCPlugin) for
+// the component.PortForwardInfo type.
+type PortForwardPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+
+	Impl     *component.PortForwardInfo // Impl is the concrete implementation
+	Mappers  []*argmapper.Func          // Mappers
+	Logger   hclog.Logger               // Logger
+	Internal *pluginargs.Internal       // Internal sets up the client connection
+}
+
+func (p *PortForwardPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	pb.RegisterPortForwardServiceServer(s, &portForwardServer{
+		Impl:   p.Impl,
+		Logger: p.Logger,
+		conns:  map[uint64]io.ReadWriteCloser{},
+	})
+	return nil
+}
+
+func (p *PortForwardPlugin) GRPCClient(
+	ctx context.Context,
+	broker *plugin.GRPCBroker,
+	c *grpc.ClientConn,
+) (interface{}, error) {
+	client := pb.NewPortForwardServiceClient(p.Internal.ClientConn(c))
+
+	accept, err := client.Accept(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	connCh := make(chan io.ReadWriteCloser)
+	go func() {
+		defer close(connCh)
+
+		for {
+			msg, err := accept.Recv()
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					p.Logger.Warn("error accepting port forward connections", "err", err)
+				}
+
+				return
+			}
+
+			conn, err := connect(ctx, client, msg.Id)
+			if err != nil {
+				p.Logger.Warn("error connecting port forward connection", "id", msg.Id, "err", err)
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				conn.Close()
+				return
+			case connCh <- conn:
+				// ok
+			}
+		}
+	}()
+
+	return &component.PortForwardInfo{Connections: connCh}, nil
+}
+
+// connect opens the stream for the connection with the given ID.
+func connect(
+	ctx context.Context,
+	client pb.PortForwardServiceClient,
+	id uint64,
+) (*streamConn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := client.Connect(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if err := stream.Send(&pb.PortForward_Data{ConnId: id}); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	c := &streamConn{
+		stream:  stream,
+		cancel:  cancel,
+		dataCh:  make(chan []byte),
+		closeCh: make(chan struct{}),
+	}
+	go c.recv()
+
+	return c, nil
+}
+
+// streamConn is an io.ReadWriteCloser over a Connect stream.
+type streamConn struct {
+	stream pb.PortForwardService_ConnectClient
+	cancel context.CancelFunc
+
+	// dataCh is sent the data received from the stream by recv. err is
+	// the error that ended the stream, if it wasn't closed normally, and
+	// is set before dataCh is closed.
+	dataCh chan []byte
+	err    error
+
+	// buf is the data received but not yet read.
+	buf []byte
+
+	sendMu    sync.Mutex
+	closeOnce sync.Once
+	closeCh   chan struct{}
+}
+
+// recv receives data until the host ends the stream. Data received after
+// the connection is closed is dropped.
+func (c *streamConn) recv() {
+	defer c.cancel()
+	defer close(c.dataCh)
+
+	for {
+		msg, err := c.stream.Recv()
+		if err != nil {
+			if err != io.EOF {
+				c.err = err
+			}
+
+			return
+		}
+
+		select {
+		case c.dataCh <- msg.Data:
+		case <-c.closeCh:
+		}
+	}
+}
+
+func (c *streamConn) Read(p []byte) (int, error) {
+	for len(c.buf) == 0 {
+		select {
+		case <-c.closeCh:
+			return 0, io.ErrClosedPipe
+
+		case data, ok := <-c.dataCh:
+			if !ok {
+				if c.err != nil {
+					return 0, c.err
+				}
+
+				return 0, io.EOF
+			}
+
+			c.buf = data
+		}
+	}
+
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
+
+func (c *streamConn) Write(p []byte) (int, error) {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	select {
+	case <-c.closeCh:
+		return 0, io.ErrClosedPipe
+	default:
+	}
+
+	for written := 0; written < len(p); {
+		n := len(p) - written
+		if n > bufferSize {
+			n = bufferSize
+		}
+
+		if err := c.stream.Send(&pb.PortForward_Data{
+			Data: p[written : written+n],
+		}); err != nil {
+			return written, err
+		}
+
+		written += n
+	}
+
+	return len(p), nil
+}
+
+// Close ends our side of the stream. The host then closes its side of the
+// connection, which ends the stream.
+func (c *streamConn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.closeCh)
+
+		c.sendMu.Lock()
+		defer c.sendMu.Unlock()
+		err = c.stream.CloseSend()
+	})
+
+	return err
+}
+
+// portForwardServer is a gRPC server that the client talks to and calls a
+// real implementation of the component.
+type portForwardServer struct {
+	pb.UnimplementedPortForwardServiceServer
+
+	Impl   *component.PortForwardInfo
+	Logger hclog.Logger
+
+	// conns are the accepted connections that the plugin hasn't
+	// connected to yet.
+	mu     sync.Mutex
+	nextID uint64
+	conns  map[uint64]io.ReadWriteCloser
+}
+
+func (s *portForwardServer) Accept(
+	_ *empty.Empty,
+	stream pb.PortForwardService_AcceptServer,
+) error {
+	for {
+		var conn io.ReadWriteCloser
+		var ok bool
+		select {
+		case <-stream.Context().Done():
+			// The plugin is gone so it won't connect to the connections
+			// it hasn't yet.
+			s.closePending()
+			return nil
+		case conn, ok = <-s.Impl.Connections:
+			if !ok {
+				return nil
+			}
+		}
+
+		s.mu.Lock()
+		s.nextID++
+		id := s.nextID
+		s.conns[id] = conn
+		s.mu.Unlock()
+
+		s.Logger.Debug("port forward connection accepted", "id", id)
+		if err := stream.Send(&pb.PortForward_Conn{Id: id}); err != nil {
+			s.take(id)
+			conn.Close()
+			return err
+		}
+	}
+}
+
+func (s *portForwardServer) Connect(stream pb.PortForwardService_ConnectServer) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	conn := s.take(msg.ConnId)
+	if conn == nil {
+		return status.Errorf(codes.NotFound, "unknown connection: %d", msg.ConnId)
+	}
+	defer conn.Close()
+
+	errCh := make(chan error, 2)
+
+	// Plugin to the local connection
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err == io.EOF {
+				errCh <- nil
+				return
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+
+			if _, err := conn.Write(msg.Data); err != nil {
+				errCh <- err
+				return
+			}
+		}
+	}()
+
+	// The local connection to the plugin
+	go func() {
+		buf := make([]byte, bufferSize)
+		for {
+			n, err := conn.Read(buf)
+			if n > 0 {
+				if err := stream.Send(&pb.PortForward_Data{Data: buf[:n]}); err != nil {
+					errCh <- err
+					return
+				}
+			}
+
+			if err == io.EOF {
+				errCh <- nil
+				return
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+		}
+	}()
+
+	// The connection is done once either side is done
+	err = <-errCh
+	s.Logger.Debug("port forward connection closed", "id", msg.ConnId, "err", err)
+	return err
+}
+
+// take removes the connection with the given ID, returning nil if there
+// is none.
+func (s *portForwardServer) take(id uint64) io.ReadWriteCloser {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conn, ok := s.conns[id]
+	if !ok {
+		return nil
+	}
+
+	delete(s.conns, id)
+	return conn
+}
+
+// closePending closes all the connections that the plugin hasn't
+// connected to.
+func (s *portForwardServer) closePending() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, conn := range s.conns {
+		conn.Close()
+		delete(s.conns, id)
+	}
+}
+
+var (
+	_ plugin.Plugin               = (*PortForwardPlugin)(nil)
+	_ plugin.GRPCPlugin           = (*PortForwardPlugin)(nil)
+	_ pb.PortForwardServiceServer = (*portForwardServer)(nil)
+	_ io.ReadWriteCloser          = (*streamConn)(nil)
+)
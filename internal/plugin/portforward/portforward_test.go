@@ -0,0 +1,133 @@
+package portforward
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+)
+
+func TestPortForwardPlugin(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Host side, accepting local connections
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	host := &PortForwardPlugin{
+		Impl: &component.PortForwardInfo{
+			Connections: component.AcceptConnections(ctx, ln),
+		},
+		Logger: hclog.L(),
+	}
+
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		require.NoError(host.GRPCServer(nil, s))
+	})
+	defer conn.Close()
+	defer server.Stop()
+
+	// Plugin side, replying to each connection in upper case
+	client := &PortForwardPlugin{Logger: hclog.L()}
+	raw, err := client.GRPCClient(ctx, nil, conn)
+	require.NoError(err)
+	info := raw.(*component.PortForwardInfo)
+
+	doneCh := make(chan struct{})
+	go func() {
+		defer close(doneCh)
+		for c := range info.Connections {
+			go func(c io.ReadWriteCloser) {
+				defer c.Close()
+
+				buf := make([]byte, 5)
+				if _, err := io.ReadFull(c, buf); err != nil {
+					return
+				}
+				c.Write(bytes.ToUpper(buf))
+			}(c)
+		}
+	}()
+
+	for _, msg := range []string{"hello", "world"} {
+		local, err := net.Dial("tcp", ln.Addr().String())
+		require.NoError(err)
+
+		_, err = local.Write([]byte(msg))
+		require.NoError(err)
+
+		// The plugin closing the connection closes the local connection
+		require.NoError(local.SetReadDeadline(time.Now().Add(5 * time.Second)))
+		reply, err := io.ReadAll(local)
+		require.NoError(err)
+		require.Equal(string(bytes.ToUpper([]byte(msg))), string(reply))
+		local.Close()
+	}
+
+	// Closing the listener ends the connections
+	require.NoError(ln.Close())
+	select {
+	case <-doneCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for connections to close")
+	}
+}
+
+func TestPortForwardPlugin_localClose(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer ln.Close()
+	host := &PortForwardPlugin{
+		Impl: &component.PortForwardInfo{
+			Connections: component.AcceptConnections(ctx, ln),
+		},
+		Logger: hclog.L(),
+	}
+
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		require.NoError(host.GRPCServer(nil, s))
+	})
+	defer conn.Close()
+	defer server.Stop()
+
+	client := &PortForwardPlugin{Logger: hclog.L()}
+	raw, err := client.GRPCClient(ctx, nil, conn)
+	require.NoError(err)
+	info := raw.(*component.PortForwardInfo)
+
+	local, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(err)
+	_, err = local.Write([]byte("bye"))
+	require.NoError(err)
+
+	var c io.ReadWriteCloser
+	select {
+	case c = <-info.Connections:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for connection")
+	}
+	defer c.Close()
+
+	// The plugin reads what was sent and then EOF once the local
+	// connection is closed.
+	require.NoError(local.Close())
+	data, err := io.ReadAll(c)
+	require.NoError(err)
+	require.Equal("bye", string(data))
+}
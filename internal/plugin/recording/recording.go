@@ -0,0 +1,217 @@
+// Package recording records the dynamic function calls that a plugin
+// serves, such as deploys, and replays them. Plugin authors record a real
+// run against their cloud once and replay it in regression tests so the
+// tests are deterministic and don't call any APIs.
+//
+// Calls are recorded by a gRPC server interceptor for every unary RPC
+// whose request is a *pb.FuncSpec_Args. Other RPCs, such as the specs of
+// the functions and configuration, always reach the plugin. A recording is
+// a file with one JSON object per call.
+//
+// Replay serves the recorded results of each RPC method in the order they
+// were recorded, regardless of the arguments, since arguments such as the
+// stream IDs of brokered servers differ between runs. Tests must make the
+// calls of each method in the same order as the recorded run.
+package recording
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// Call is a recorded call.
+type Call struct {
+	// Method is the full gRPC method, such as
+	// "/hashicorp.waypoint.sdk.Platform/Deploy".
+	Method string `json:"method"`
+
+	// Args are the arguments of the call as a FuncSpec.Args in the
+	// protobuf JSON format. These are only recorded for inspection and
+	// aren't used by replay.
+	Args json.RawMessage `json:"args"`
+
+	// Response is the response of the call as an Any in the protobuf JSON
+	// format. This is empty if the call failed.
+	Response json.RawMessage `json:"response,omitempty"`
+
+	// Error is the gRPC status of the error of the call in the protobuf
+	// JSON format, including details such as diagnostics. This is empty
+	// if the call succeeded.
+	Error json.RawMessage `json:"error,omitempty"`
+}
+
+// Recorder records calls to a file.
+type Recorder struct {
+	lock sync.Mutex
+	f    *os.File
+	enc  *json.Encoder
+}
+
+// NewRecorder returns a Recorder that records to the file at path. The
+// file is created or truncated.
+func NewRecorder(path string) (*Recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+// UnaryInterceptor returns the interceptor that records the calls.
+func (r *Recorder) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		args, ok := req.(*pb.FuncSpec_Args)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		resp, err := handler(ctx, req)
+		if rerr := r.record(info.FullMethod, args, resp, err); rerr != nil {
+			return nil, status.Errorf(codes.Internal,
+				"error recording call to %s: %s", info.FullMethod, rerr)
+		}
+
+		return resp, err
+	}
+}
+
+// Close closes the recording file.
+func (r *Recorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.f.Close()
+}
+
+func (r *Recorder) record(method string, args *pb.FuncSpec_Args, resp interface{}, err error) error {
+	call := &Call{Method: method}
+
+	var merr error
+	call.Args, merr = protojson.Marshal(args)
+	if merr != nil {
+		return merr
+	}
+
+	if err != nil {
+		call.Error, merr = protojson.Marshal(status.Convert(err).Proto())
+	} else if msg, ok := resp.(proto.Message); ok {
+		var anyVal *anypb.Any
+		anyVal, merr = anypb.New(msg)
+		if merr == nil {
+			call.Response, merr = protojson.Marshal(anyVal)
+		}
+	} else {
+		merr = fmt.Errorf("response must be a proto.Message, got %T", resp)
+	}
+	if merr != nil {
+		return merr
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.enc.Encode(call)
+}
+
+// Replayer serves recorded calls.
+type Replayer struct {
+	lock  sync.Mutex
+	calls map[string][]*Call
+}
+
+// NewReplayer returns a Replayer for the recording at path.
+func NewReplayer(path string) (*Replayer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	result := &Replayer{calls: map[string][]*Call{}}
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for dec.More() {
+		var call Call
+		if err := dec.Decode(&call); err != nil {
+			return nil, fmt.Errorf("error reading recording %s: %w", path, err)
+		}
+
+		result.calls[call.Method] = append(result.calls[call.Method], &call)
+	}
+
+	return result, nil
+}
+
+// UnaryInterceptor returns the interceptor that serves the recorded calls
+// instead of calling the plugin. If there are no more recorded calls for
+// a method, the call fails with codes.FailedPrecondition.
+func (r *Replayer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if _, ok := req.(*pb.FuncSpec_Args); !ok {
+			return handler(ctx, req)
+		}
+
+		call := r.next(info.FullMethod)
+		if call == nil {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"no more recorded calls to %s", info.FullMethod)
+		}
+
+		return replay(call)
+	}
+}
+
+// next returns the next recorded call to method, or nil if there is none.
+func (r *Replayer) next(method string) *Call {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	calls := r.calls[method]
+	if len(calls) == 0 {
+		return nil
+	}
+
+	r.calls[method] = calls[1:]
+	return calls[0]
+}
+
+// replay returns the response and error of call.
+func replay(call *Call) (interface{}, error) {
+	if len(call.Error) > 0 {
+		var s spb.Status
+		if err := protojson.Unmarshal(call.Error, &s); err != nil {
+			return nil, err
+		}
+
+		return nil, status.ErrorProto(&s)
+	}
+
+	var anyVal anypb.Any
+	if err := protojson.Unmarshal(call.Response, &anyVal); err != nil {
+		return nil, err
+	}
+
+	return anyVal.UnmarshalNew()
+}
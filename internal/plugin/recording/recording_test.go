@@ -0,0 +1,77 @@
+package recording
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+func TestRecordReplay(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "calls.jsonl")
+	deploy := &grpc.UnaryServerInfo{FullMethod: "/test/Deploy"}
+	config := &grpc.UnaryServerInfo{FullMethod: "/test/ConfigStruct"}
+
+	resp := &pb.Deploy_Resp{ResultJson: `{"value":"hello"}`, TemplateData: []byte("hello")}
+	callErr := status.Error(codes.NotFound, "no such app")
+
+	recorder, err := NewRecorder(path)
+	require.NoError(err)
+	record := recorder.UnaryInterceptor()
+
+	out, err := record(ctx, &pb.FuncSpec_Args{}, deploy, func(context.Context, interface{}) (interface{}, error) {
+		return resp, nil
+	})
+	require.NoError(err)
+	require.Same(resp, out)
+
+	_, err = record(ctx, &pb.FuncSpec_Args{}, deploy, func(context.Context, interface{}) (interface{}, error) {
+		return nil, callErr
+	})
+	require.Equal(callErr, err)
+
+	// Other calls aren't recorded
+	_, err = record(ctx, &empty.Empty{}, config, func(context.Context, interface{}) (interface{}, error) {
+		return &empty.Empty{}, nil
+	})
+	require.NoError(err)
+	require.NoError(recorder.Close())
+
+	replayer, err := NewReplayer(path)
+	require.NoError(err)
+	replay := replayer.UnaryInterceptor()
+
+	unexpected := func(context.Context, interface{}) (interface{}, error) {
+		return nil, errors.New("the handler should not be called")
+	}
+
+	// The calls are replayed in order
+	out, err = replay(ctx, &pb.FuncSpec_Args{}, deploy, unexpected)
+	require.NoError(err)
+	require.True(proto.Equal(resp, out.(proto.Message)))
+
+	_, err = replay(ctx, &pb.FuncSpec_Args{}, deploy, unexpected)
+	require.Equal(codes.NotFound, status.Code(err))
+	require.Equal("no such app", status.Convert(err).Message())
+
+	_, err = replay(ctx, &pb.FuncSpec_Args{}, deploy, unexpected)
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+
+	// Other calls reach the handler
+	_, err = replay(ctx, &empty.Empty{}, config, func(context.Context, interface{}) (interface{}, error) {
+		return &empty.Empty{}, nil
+	})
+	require.NoError(err)
+}
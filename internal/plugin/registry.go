@@ -7,12 +7,15 @@ import (
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/hcl/v2/hcldec"
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/docs"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/plugincomponent"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+	"github.com/zclconf/go-cty/cty"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -27,16 +30,34 @@ type RegistryPlugin struct {
 	Impl    component.Registry // Impl is the concrete implementation
 	Mappers []*argmapper.Func  // Mappers
 	Logger  hclog.Logger       // Logger
+
+	ODR *ODRSetting // Used to switch push modes based on ondemand-runner in play
+
+	ProtocolVersion *component.ProtocolVersion // Protocol version of the plugin set
+	Features        *pluginargs.FeatureSet     // Features of the plugin, see WithFeatures
+	Name            ComponentName              // Name of the component, see WithNamedComponent
+	TracerProvider  trace.TracerProvider       // Traces brokered servers, see WithTracerProvider
+
+	UnaryInterceptors  []grpc.UnaryServerInterceptor  // Interceptors for brokered servers, see WithInterceptors
+	StreamInterceptors []grpc.StreamServerInterceptor // Interceptors for brokered servers, see WithInterceptors
+	ServerSettings     *pluginargs.ServerSettings     // Settings for brokered servers, see WithServerSettings
 }
 
 func (p *RegistryPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
 	base := &base{
-		Mappers: p.Mappers,
-		Logger:  p.Logger,
-		Broker:  broker,
+		Mappers:         p.Mappers,
+		Logger:          p.Logger,
+		Broker:          broker,
+		ProtocolVersion: p.ProtocolVersion,
+		Features:        p.Features,
+		TracerProvider:  p.TracerProvider,
+
+		UnaryInterceptors:  p.UnaryInterceptors,
+		StreamInterceptors: p.StreamInterceptors,
+		ServerSettings:     p.ServerSettings,
 	}
 
-	pb.RegisterRegistryServer(s, &registryServer{
+	pb.RegisterRegistryServer(largeValueRegistrar(namedRegistrar(s, p.Name), broker), &registryServer{
 		base: base,
 		Impl: p.Impl,
 
@@ -44,6 +65,34 @@ func (p *RegistryPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) e
 			base: base,
 			Impl: p.Impl,
 		},
+		validatorServer: &validatorServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		configDefaulterServer: &configDefaulterServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		signerServer: &signerServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		odrProfilerServer: &odrProfilerServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		destroyerServer: &destroyerServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		workspaceDestroyerServer: &workspaceDestroyerServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		statusServer: &statusServer{
+			base: base,
+			Impl: p.Impl,
+		},
 	})
 	return nil
 }
@@ -54,10 +103,16 @@ func (p *RegistryPlugin) GRPCClient(
 	c *grpc.ClientConn,
 ) (interface{}, error) {
 	client := &registryClient{
-		client:  pb.NewRegistryClient(c),
+		client:  pb.NewRegistryClient(diagnosticsConn(largeValueConn(namedConn(c, p.Name), broker, p.Features, p.Logger), p.Logger)),
 		logger:  p.Logger,
 		broker:  broker,
 		mappers: p.Mappers,
+		odr:     p.ODR != nil && p.ODR.Enabled,
+	}
+
+	probe, err := newCapabilityProbe(ctx, c, component.RegistryType, p.Name)
+	if err != nil {
+		return nil, err
 	}
 
 	authenticator := &authenticatorClient{
@@ -66,7 +121,7 @@ func (p *RegistryPlugin) GRPCClient(
 		Broker:  client.broker,
 		Mappers: client.mappers,
 	}
-	if ok, err := authenticator.Implements(ctx); err != nil {
+	if ok, err := probe.Implements(ctx, component.CapabilityAuthenticator, authenticator); err != nil {
 		return nil, err
 	} else if ok {
 		p.Logger.Info("registry plugin capable of auth")
@@ -74,12 +129,162 @@ func (p *RegistryPlugin) GRPCClient(
 		authenticator = nil
 	}
 
+	authRefresher := &authRefresherClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityAuthRefresher, authRefresher); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("registry plugin capable of auth refresh")
+	} else {
+		authRefresher = nil
+	}
+
+	validator := &validatorClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityValidator, validator); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("registry plugin capable of validation")
+	} else {
+		validator = nil
+	}
+
+	configDefaulter := &configDefaulterClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityConfigDefaulter, configDefaulter); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("registry plugin capable of config defaults")
+	} else {
+		configDefaulter = nil
+	}
+
+	signer := &signerClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilitySigner, signer); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("registry plugin capable of signing")
+	} else {
+		signer = nil
+	}
+
+	odrProfiler := &odrProfilerClient{
+		Client: client.client,
+		Logger: client.logger,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityODRProfiler, odrProfiler); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("registry plugin capable of ODR profiles")
+	} else {
+		odrProfiler = nil
+	}
+
+	// Compose destroyer
+	destroyer := &destroyerClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityDestroyer, destroyer); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("registry plugin capable of destroy")
+	} else {
+		destroyer = nil
+	}
+
+	// Compose workspace destroyer
+	wsDestroyer := &workspaceDestroyerClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityWorkspaceDestroyer, wsDestroyer); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("registry plugin capable of workspace destroy")
+	} else {
+		wsDestroyer = nil
+	}
+
+	status := &statusClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityStatus, status); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("registry plugin capable of status")
+	} else {
+		status = nil
+	}
+
+	puller := &registryPullerClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityRegistryPuller, puller); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("registry plugin capable of pull")
+	} else {
+		puller = nil
+	}
+
+	var caps capabilities
+	caps.add(component.CapabilityAuthenticator, authenticator != nil)
+	caps.add(component.CapabilityAuthRefresher, authRefresher != nil)
+	caps.add(component.CapabilityValidator, validator != nil)
+	caps.add(component.CapabilityConfigDefaulter, configDefaulter != nil)
+	caps.add(component.CapabilitySigner, signer != nil)
+	caps.add(component.CapabilityODRProfiler, odrProfiler != nil)
+	caps.add(component.CapabilityDestroyer, destroyer != nil)
+	caps.add(component.CapabilityWorkspaceDestroyer, wsDestroyer != nil)
+	caps.add(component.CapabilityStatus, status != nil)
+	caps.add(component.CapabilityRegistryPuller, puller != nil)
+
 	result := &mix_Registry_Authenticator{
+		capabilities:       caps,
 		ConfigurableNotify: client,
+		ConfigurableSchema: client,
 		Registry:           client,
 		Authenticator:      authenticator,
+		AuthRefresher:      authRefresher,
+		Validator:          validator,
+		ConfigDefaulter:    configDefaulter,
+		Signer:             signer,
+		ODRProfiler:        odrProfiler,
+		Destroyer:          destroyer,
+		DestroyHooks:       destroyer,
+		WorkspaceDestroyer: wsDestroyer,
+		Status:             status,
 		Documented:         client,
 		RegistryAccess:     client,
+		RegistryPuller:     puller,
 	}
 
 	return result, nil
@@ -91,6 +296,9 @@ type registryClient struct {
 	logger  hclog.Logger
 	broker  *plugin.GRPCBroker
 	mappers []*argmapper.Func
+
+	// indicates that the ODR version of the plugin should be used
+	odr bool
 }
 
 func (c *registryClient) Config() (interface{}, error) {
@@ -101,11 +309,47 @@ func (c *registryClient) ConfigSet(v interface{}) error {
 	return configureCall(context.Background(), c.client, v)
 }
 
+func (c *registryClient) ConfigSchema() (hcldec.Spec, error) {
+	return configSchemaCall(context.Background(), c.client)
+}
+
+func (c *registryClient) ConfigSetValue(v cty.Value) error {
+	return configureValueCall(context.Background(), c.client, v)
+}
+
 func (c *registryClient) Documentation() (*docs.Documentation, error) {
 	return documentationCall(context.Background(), c.client)
 }
 
 func (c *registryClient) PushFunc() interface{} {
+	if c.odr {
+		c.logger.Debug("Running in ODR mode, attempting to retrieve ODR push spec")
+
+		spec, err := c.client.PushSpecODR(context.Background(), &empty.Empty{})
+		if err == nil {
+			// We don't want to be a mapper
+			spec.Result = nil
+
+			return funcspec.Func(spec, c.pushODR,
+				argmapper.Logger(c.logger),
+				argmapper.Typed(&pluginargs.Internal{
+					Broker:  c.broker,
+					Mappers: c.mappers,
+					Cleanup: &pluginargs.Cleanup{},
+				}),
+			)
+		}
+
+		if status.Code(err) != codes.Unimplemented {
+			c.logger.Error("error retrieving ODR push spec", "error", err)
+			return funcErr(err)
+		}
+
+		// ok, the plugin doesn't have an ODR push, so just use the
+		// basic mode.
+		c.logger.Debug("plugin didn't implement PushSpecODR, using Push")
+	}
+
 	// Get the spec
 	spec, err := c.client.PushSpec(context.Background(), &empty.Empty{})
 	if err != nil {
@@ -128,9 +372,26 @@ func (c *registryClient) PushFunc() interface{} {
 func (c *registryClient) push(
 	ctx context.Context,
 	args funcspec.Args,
+) (component.Artifact, error) {
+	return c.callPush(ctx, c.client.Push, args)
+}
+
+func (c *registryClient) pushODR(
+	ctx context.Context,
+	args funcspec.Args,
+) (component.Artifact, error) {
+	return c.callPush(ctx, c.client.PushODR, args)
+}
+
+// callPush calls the push RPC rpc, which is Push or PushODR, and returns
+// the artifact.
+func (c *registryClient) callPush(
+	ctx context.Context,
+	rpc func(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (*pb.Push_Resp, error),
+	args funcspec.Args,
 ) (component.Artifact, error) {
 	// Call our function
-	resp, err := c.client.Push(ctx, &pb.FuncSpec_Args{Args: args})
+	resp, err := rpc(ctx, &pb.FuncSpec_Args{Args: args})
 	if err != nil {
 		return nil, err
 	}
@@ -191,11 +452,141 @@ func (c *registryClient) access(
 	return &plugincomponent.AccessInfo{Any: resp.Result}, nil
 }
 
+// registryPullerClient implements component.RegistryPuller for a registry
+// that has the pull methods implemented.
+type registryPullerClient struct {
+	Client  pb.RegistryClient
+	Logger  hclog.Logger
+	Broker  *plugin.GRPCBroker
+	Mappers []*argmapper.Func
+}
+
+func (c *registryPullerClient) Implements(ctx context.Context) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	resp, err := c.Client.IsRegistryPuller(ctx, &empty.Empty{})
+	if err != nil {
+		// Registries built with an SDK before pulling existed don't
+		// implement the RPC.
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return resp.Implements, nil
+}
+
+// PullFunc implements component.RegistryPuller. It returns nil if the
+// remote plugin doesn't implement the function.
+func (c *registryPullerClient) PullFunc() interface{} {
+	if c == nil {
+		return nil
+	}
+
+	// Get the spec
+	spec, err := c.Client.PullSpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		// Signal that this is not implemented.
+		if status.Code(err) == codes.Unimplemented {
+			return nil
+		}
+
+		return funcErr(err)
+	}
+
+	// We don't want to be a mapper
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.pull,
+		argmapper.Logger(c.Logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.Broker,
+			Mappers: c.Mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *registryPullerClient) pull(
+	ctx context.Context,
+	args funcspec.Args,
+) (component.Artifact, error) {
+	// Call our function
+	resp, err := c.Client.Pull(ctx, &pb.FuncSpec_Args{Args: args})
+	if err != nil {
+		return nil, err
+	}
+
+	var tplData map[string]interface{}
+	if len(resp.TemplateData) > 0 {
+		if err := json.Unmarshal(resp.TemplateData, &tplData); err != nil {
+			return nil, err
+		}
+	}
+
+	return &plugincomponent.Artifact{
+		Any:         resp.Result,
+		AnyJson:     resp.ResultJson,
+		TemplateVal: tplData,
+	}, nil
+}
+
+// VerifyFunc implements component.RegistryPuller. It returns nil if the
+// remote plugin doesn't implement the function.
+func (c *registryPullerClient) VerifyFunc() interface{} {
+	if c == nil {
+		return nil
+	}
+
+	// Get the spec
+	spec, err := c.Client.VerifySpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		// Signal that this is not implemented.
+		if status.Code(err) == codes.Unimplemented {
+			return nil
+		}
+
+		return funcErr(err)
+	}
+
+	// We don't want to be a mapper
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.verify,
+		argmapper.Logger(c.Logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.Broker,
+			Mappers: c.Mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *registryPullerClient) verify(
+	ctx context.Context,
+	args funcspec.Args,
+) error {
+	// Call our function
+	_, err := c.Client.Verify(ctx, &pb.FuncSpec_Args{Args: args})
+	return err
+}
+
 // registryServer is a gRPC server that the client talks to and calls a
 // real implementation of the component.
 type registryServer struct {
 	*base
 	*authenticatorServer
+	*validatorServer
+	*configDefaulterServer
+	*signerServer
+	*odrProfilerServer
+	*destroyerServer
+	*workspaceDestroyerServer
+	*statusServer
 
 	pb.UnsafeRegistryServer
 
@@ -213,14 +604,30 @@ func (s *registryServer) Configure(
 	ctx context.Context,
 	req *pb.Config_ConfigureRequest,
 ) (*empty.Empty, error) {
+	defer s.purgeMappers()
 	return configure(s.Impl, req)
 }
 
+func (s *registryServer) ConfigSchema(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.Config_SchemaResp, error) {
+	return configSchema(s.Impl)
+}
+
+func (s *registryServer) ConfigureValue(
+	ctx context.Context,
+	req *pb.Config_ConfigureValueRequest,
+) (*empty.Empty, error) {
+	defer s.purgeMappers()
+	return configureValue(s.Impl, req)
+}
+
 func (s *registryServer) Documentation(
 	ctx context.Context,
 	empty *empty.Empty,
 ) (*pb.Config_Documentation, error) {
-	return documentation(s.Impl)
+	return documentation(s.Impl, s.Mappers, s.Logger)
 }
 
 func (s *registryServer) PushSpec(
@@ -241,12 +648,50 @@ func (s *registryServer) PushSpec(
 func (s *registryServer) Push(
 	ctx context.Context,
 	args *pb.FuncSpec_Args,
+) (*pb.Push_Resp, error) {
+	return s.push(ctx, s.Impl.PushFunc(), args)
+}
+
+func (s *registryServer) PushSpecODR(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	odr, ok := s.Impl.(component.RegistryODR)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: RegistryODR")
+	}
+
+	return funcspec.Spec(odr.PushODRFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+	)
+}
+
+func (s *registryServer) PushODR(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*pb.Push_Resp, error) {
+	odr, ok := s.Impl.(component.RegistryODR)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: RegistryODR")
+	}
+
+	return s.push(ctx, odr.PushODRFunc(), args)
+}
+
+// push calls the push function f, which is the PushFunc or the PushODRFunc
+// of the registry.
+func (s *registryServer) push(
+	ctx context.Context,
+	f interface{},
+	args *pb.FuncSpec_Args,
 ) (*pb.Push_Resp, error) {
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	encoded, encodedJson, raw, err := callDynamicFuncAny2(s.Impl.PushFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	encoded, encodedJson, raw, err := callDynamicFuncAny2(ctx, f, args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Logger(s.Logger),
 		argmapper.Typed(ctx),
 		argmapper.Typed(internal),
@@ -256,7 +701,7 @@ func (s *registryServer) Push(
 	}
 
 	result := &pb.Push_Resp{Result: encoded, ResultJson: encodedJson}
-	result.TemplateData, err = templateData(raw)
+	result.TemplateData, err = templateData(raw, s.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -302,8 +747,8 @@ func (s *registryServer) Access(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	encoded, _, _, err := callDynamicFuncAny2(fn, args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	encoded, _, _, err := callDynamicFuncAny2(ctx, fn, args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Logger(s.Logger),
 		argmapper.Typed(ctx),
 		argmapper.Typed(internal),
@@ -318,12 +763,120 @@ func (s *registryServer) Access(
 	return result, nil
 }
 
+func (s *registryServer) IsRegistryPuller(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.ImplementsResp, error) {
+	rp, ok := s.Impl.(component.RegistryPuller)
+	return &pb.ImplementsResp{
+		Implements: ok && rp.PullFunc() != nil,
+	}, nil
+}
+
+// PullSpec returns the information about the plugins PullFunc function.
+// If the plugin does not implement the function (as it is an optional interface)
+// then a codes.Unimplemented is returned as an error.
+func (s *registryServer) PullSpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	rp, ok := s.Impl.(component.RegistryPuller)
+	if !ok || rp.PullFunc() == nil {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: RegistryPuller")
+	}
+
+	return funcspec.Spec(rp.PullFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+	)
+}
+
+// Pull calls the PullFunc on the plugin.
+func (s *registryServer) Pull(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*pb.Pull_Resp, error) {
+	rp, ok := s.Impl.(component.RegistryPuller)
+	if !ok || rp.PullFunc() == nil {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: RegistryPuller")
+	}
+
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	encoded, encodedJson, raw, err := callDynamicFuncAny2(ctx, rp.PullFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(ctx),
+		argmapper.Typed(internal),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &pb.Pull_Resp{Result: encoded, ResultJson: encodedJson}
+	result.TemplateData, err = templateData(raw, s.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// VerifySpec returns the information about the plugins VerifyFunc function.
+// If the plugin does not implement the function (as it is an optional interface)
+// then a codes.Unimplemented is returned as an error.
+func (s *registryServer) VerifySpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	rp, ok := s.Impl.(component.RegistryPuller)
+	if !ok || rp.VerifyFunc() == nil {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: RegistryPuller")
+	}
+
+	return funcspec.Spec(rp.VerifyFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+	)
+}
+
+// Verify calls the VerifyFunc on the plugin.
+func (s *registryServer) Verify(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*empty.Empty, error) {
+	rp, ok := s.Impl.(component.RegistryPuller)
+	if !ok || rp.VerifyFunc() == nil {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: RegistryPuller")
+	}
+
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	_, err := callDynamicFunc2(ctx, rp.VerifyFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(ctx),
+		argmapper.Typed(internal),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
 var (
 	_ plugin.Plugin                = (*RegistryPlugin)(nil)
 	_ plugin.GRPCPlugin            = (*RegistryPlugin)(nil)
 	_ pb.RegistryServer            = (*registryServer)(nil)
 	_ component.Registry           = (*registryClient)(nil)
+	_ component.RegistryPuller     = (*registryPullerClient)(nil)
 	_ component.Configurable       = (*registryClient)(nil)
 	_ component.Documented         = (*registryClient)(nil)
 	_ component.ConfigurableNotify = (*registryClient)(nil)
+	_ component.ConfigurableSchema = (*registryClient)(nil)
 )
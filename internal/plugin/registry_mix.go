@@ -5,9 +5,22 @@ import (
 )
 
 type mix_Registry_Authenticator struct {
+	capabilities
+
 	component.Authenticator
+	component.AuthRefresher
+	component.Validator
+	component.ConfigDefaulter
 	component.ConfigurableNotify
+	component.ConfigurableSchema
 	component.Registry
 	component.Documented
+	component.Signer
+	component.ODRProfiler
+	component.Destroyer
+	component.DestroyHooks
+	component.WorkspaceDestroyer
+	component.Status
 	component.RegistryAccess
+	component.RegistryPuller
 }
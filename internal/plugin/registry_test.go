@@ -1,10 +1,20 @@
 package plugin
 
 import (
+	"context"
 	"testing"
 
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
 
 func TestRegistryDynamicFunc_validateAuth(t *testing.T) {
@@ -22,6 +32,250 @@ func TestRegistryDynamicFunc_auth(t *testing.T) {
 	})
 }
 
+func TestRegistryDynamicFunc_pull(t *testing.T) {
+	testDynamicFunc(t, "registry", &mockRegistryPuller{}, func(v, f interface{}) {
+		v.(*mockRegistryPuller).RegistryPuller.On("PullFunc").Return(f)
+	}, func(raw interface{}) interface{} {
+		return raw.(component.RegistryPuller).PullFunc()
+	})
+}
+
+func TestRegistryDynamicFunc_verify(t *testing.T) {
+	testDynamicFunc(t, "registry", &mockRegistryPuller{}, func(v, f interface{}) {
+		v.(*mockRegistryPuller).RegistryPuller.On("VerifyFunc").Return(f)
+	}, func(raw interface{}) interface{} {
+		return raw.(component.RegistryPuller).VerifyFunc()
+	})
+}
+
+func TestRegistryPuller_notImplemented(t *testing.T) {
+	require := require.New(t)
+
+	plugins := Plugins(WithComponents(&mocks.Registry{}), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("registry")
+	require.NoError(err)
+	require.NotContains(component.Capabilities(raw), component.CapabilityRegistryPuller)
+	puller := raw.(component.RegistryPuller)
+	require.Nil(puller.PullFunc())
+	require.Nil(puller.VerifyFunc())
+}
+
+func TestRegistryPuller_nilFunc(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mockRegistryPuller{}
+	mockV.RegistryPuller.On("PullFunc").Return(nil)
+	mockV.RegistryPuller.On("VerifyFunc").Return(nil)
+
+	server := &registryServer{Impl: mockV}
+	resp, err := server.IsRegistryPuller(context.Background(), &empty.Empty{})
+	require.NoError(err)
+	require.False(resp.Implements)
+
+	_, err = server.PullSpec(context.Background(), &empty.Empty{})
+	require.Equal(codes.Unimplemented, status.Code(err))
+	_, err = server.VerifySpec(context.Background(), &empty.Empty{})
+	require.Equal(codes.Unimplemented, status.Code(err))
+}
+
+func TestRegistryPush_odr(t *testing.T) {
+	cases := []struct {
+		Name     string
+		ODR      bool
+		Impl     bool
+		Expected string
+	}{
+		{"odr", true, true, "odr"},
+		{"not odr", false, true, "basic"},
+		{"odr not implemented", true, false, "basic"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			push := func(v string) interface{} {
+				return func() *testproto.Data { return &testproto.Data{Value: v} }
+			}
+
+			var impl component.Registry
+			if tt.Impl {
+				mockV := &mockRegistryODR{}
+				mockV.Registry.On("PushFunc").Return(push("basic"))
+				mockV.RegistryODR.On("PushODRFunc").Return(push("odr"))
+				impl = mockV
+			} else {
+				mockV := &mocks.Registry{}
+				mockV.On("PushFunc").Return(push("basic"))
+				impl = mockV
+			}
+
+			plugins := Plugins(
+				WithComponents(impl),
+				WithMappers(testDefaultMappers(t)...),
+				WithODR(&ODRSetting{Enabled: tt.ODR}),
+			)
+			client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+			defer client.Close()
+			defer server.Stop()
+
+			raw, err := client.Dispense("registry")
+			require.NoError(err)
+			f := raw.(component.Registry).PushFunc().(*argmapper.Func)
+
+			result := f.Call(argmapper.Typed(context.Background()))
+			require.NoError(result.Err())
+
+			var data testproto.Data
+			require.NoError(component.ProtoAnyUnmarshal(result.Out(0), &data))
+			require.Equal(tt.Expected, data.Value)
+		})
+	}
+}
+
+func TestRegistrySign(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mockRegistrySigner{}
+	mockV.Signer.On("SignFunc").Return(func(ctx context.Context) *pb.Signature {
+		return &pb.Signature{
+			Format:    "cosign",
+			Digest:    "sha256:abc",
+			Signature: []byte("sig"),
+			Attestations: []*pb.Signature_Attestation{
+				{PredicateType: "https://slsa.dev/provenance/v0.2"},
+			},
+		}
+	})
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("registry")
+	require.NoError(err)
+	f := raw.(component.Signer).SignFunc().(*argmapper.Func)
+	require.NotNil(f)
+
+	result := f.Call(argmapper.Typed(context.Background()))
+	require.NoError(result.Err())
+
+	sig := result.Out(0).(*pb.Signature)
+	require.Equal("cosign", sig.Format)
+	require.Equal("sha256:abc", sig.Digest)
+	require.Equal([]byte("sig"), sig.Signature)
+	require.Len(sig.Attestations, 1)
+}
+
+func TestRegistryDynamicFunc_verifySignature(t *testing.T) {
+	testDynamicFunc(t, "registry", &mockRegistrySigner{}, func(v, f interface{}) {
+		v.(*mockRegistrySigner).Signer.On("VerifySignatureFunc").Return(f)
+	}, func(raw interface{}) interface{} {
+		return raw.(component.Signer).VerifySignatureFunc()
+	})
+}
+
+func TestRegistrySigner_notImplemented(t *testing.T) {
+	require := require.New(t)
+
+	plugins := Plugins(WithComponents(&mocks.Registry{}), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("registry")
+	require.NoError(err)
+	signer := raw.(component.Signer)
+	require.Nil(signer.SignFunc())
+	require.Nil(signer.VerifySignatureFunc())
+}
+
+func TestRegistryDynamicFunc_destroy(t *testing.T) {
+	testDynamicFunc(t, "registry", &mockRegistryDestroyer{}, func(v, f interface{}) {
+		v.(*mockRegistryDestroyer).Destroyer.On("DestroyFunc").Return(f)
+	}, func(raw interface{}) interface{} {
+		return raw.(component.Destroyer).DestroyFunc()
+	})
+}
+
+func TestRegistryDynamicFunc_destroyWorkspace(t *testing.T) {
+	testDynamicFunc(t, "registry", &mockRegistryWorkspaceDestroyer{}, func(v, f interface{}) {
+		v.(*mockRegistryWorkspaceDestroyer).WorkspaceDestroyer.On("DestroyWorkspaceFunc").Return(f)
+	}, func(raw interface{}) interface{} {
+		return raw.(component.WorkspaceDestroyer).DestroyWorkspaceFunc()
+	})
+}
+
+func TestRegistryDestroyer_notImplemented(t *testing.T) {
+	require := require.New(t)
+
+	plugins := Plugins(WithComponents(&mocks.Registry{}), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("registry")
+	require.NoError(err)
+	require.NotContains(component.Capabilities(raw), component.CapabilityDestroyer)
+	require.NotContains(component.Capabilities(raw), component.CapabilityWorkspaceDestroyer)
+	require.Nil(raw.(component.Destroyer).DestroyFunc())
+	require.Nil(raw.(component.WorkspaceDestroyer).DestroyWorkspaceFunc())
+}
+
+func TestRegistryStatus(t *testing.T) {
+	require := require.New(t)
+
+	mockV := &mockRegistryStatus{}
+	mockV.Status.On("StatusFunc").Return(func(ctx context.Context) *pb.StatusReport {
+		return &pb.StatusReport{
+			Resources: []*pb.StatusReport_Resource{{
+				Name:          "scan",
+				Health:        pb.StatusReport_PARTIAL,
+				HealthMessage: "vulnerability scan pending",
+			}},
+			Health: pb.StatusReport_PARTIAL,
+		}
+	})
+
+	plugins := Plugins(WithComponents(mockV), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("registry")
+	require.NoError(err)
+	require.Contains(component.Capabilities(raw), component.CapabilityStatus)
+
+	result := raw.(component.Status).StatusFunc().(*argmapper.Func).Call(
+		argmapper.Typed(context.Background()),
+	)
+	require.NoError(result.Err())
+
+	report := result.Out(0).(*pb.StatusReport)
+	require.Equal(pb.StatusReport_PARTIAL, report.Health)
+	require.Len(report.Resources, 1)
+	require.Equal("vulnerability scan pending", report.Resources[0].HealthMessage)
+}
+
+func TestRegistryStatus_notImplemented(t *testing.T) {
+	require := require.New(t)
+
+	plugins := Plugins(WithComponents(&mocks.Registry{}), WithMappers(testDefaultMappers(t)...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("registry")
+	require.NoError(err)
+	require.NotContains(component.Capabilities(raw), component.CapabilityStatus)
+	require.Nil(raw.(component.Status).StatusFunc())
+}
+
 func TestRegistryConfig(t *testing.T) {
 	mockV := &mockRegistryConfigurable{}
 	testConfigurable(t, "registry", mockV, &mockV.Configurable)
@@ -32,7 +286,37 @@ type mockRegistryAuthenticator struct {
 	mocks.Authenticator
 }
 
+type mockRegistryODR struct {
+	mocks.Registry
+	mocks.RegistryODR
+}
+
 type mockRegistryConfigurable struct {
 	mocks.Registry
 	mocks.Configurable
 }
+
+type mockRegistryPuller struct {
+	mocks.Registry
+	mocks.RegistryPuller
+}
+
+type mockRegistrySigner struct {
+	mocks.Registry
+	mocks.Signer
+}
+
+type mockRegistryDestroyer struct {
+	mocks.Registry
+	mocks.Destroyer
+}
+
+type mockRegistryWorkspaceDestroyer struct {
+	mocks.Registry
+	mocks.WorkspaceDestroyer
+}
+
+type mockRegistryStatus struct {
+	mocks.Registry
+	mocks.Status
+}
@@ -7,12 +7,16 @@ import (
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/zclconf/go-cty/cty"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
 	"github.com/hashicorp/waypoint-plugin-sdk/docs"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
@@ -28,16 +32,32 @@ type ReleaseManagerPlugin struct {
 	Impl    component.ReleaseManager // Impl is the concrete implementation
 	Mappers []*argmapper.Func        // Mappers
 	Logger  hclog.Logger             // Logger
+
+	ProtocolVersion *component.ProtocolVersion // Protocol version of the plugin set
+	Features        *pluginargs.FeatureSet     // Features of the plugin, see WithFeatures
+	Name            ComponentName              // Name of the component, see WithNamedComponent
+	TracerProvider  trace.TracerProvider       // Traces brokered servers, see WithTracerProvider
+
+	UnaryInterceptors  []grpc.UnaryServerInterceptor  // Interceptors for brokered servers, see WithInterceptors
+	StreamInterceptors []grpc.StreamServerInterceptor // Interceptors for brokered servers, see WithInterceptors
+	ServerSettings     *pluginargs.ServerSettings     // Settings for brokered servers, see WithServerSettings
 }
 
 func (p *ReleaseManagerPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
 	base := &base{
-		Mappers: p.Mappers,
-		Logger:  p.Logger,
-		Broker:  broker,
+		Mappers:         p.Mappers,
+		Logger:          p.Logger,
+		Broker:          broker,
+		ProtocolVersion: p.ProtocolVersion,
+		Features:        p.Features,
+		TracerProvider:  p.TracerProvider,
+
+		UnaryInterceptors:  p.UnaryInterceptors,
+		StreamInterceptors: p.StreamInterceptors,
+		ServerSettings:     p.ServerSettings,
 	}
 
-	pb.RegisterReleaseManagerServer(s, &releaseManagerServer{
+	pb.RegisterReleaseManagerServer(largeValueRegistrar(namedRegistrar(s, p.Name), broker), &releaseManagerServer{
 		base: base,
 		Impl: p.Impl,
 
@@ -45,6 +65,14 @@ func (p *ReleaseManagerPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Ser
 			base: base,
 			Impl: p.Impl,
 		},
+		validatorServer: &validatorServer{
+			base: base,
+			Impl: p.Impl,
+		},
+		configDefaulterServer: &configDefaulterServer{
+			base: base,
+			Impl: p.Impl,
+		},
 
 		destroyerServer: &destroyerServer{
 			base: base,
@@ -60,6 +88,10 @@ func (p *ReleaseManagerPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Ser
 			base: base,
 			Impl: p.Impl,
 		},
+		remediatorServer: &remediatorServer{
+			base: base,
+			Impl: p.Impl,
+		},
 	})
 	return nil
 }
@@ -70,19 +102,24 @@ func (p *ReleaseManagerPlugin) GRPCClient(
 	c *grpc.ClientConn,
 ) (interface{}, error) {
 	client := &releaseManagerClient{
-		client:  pb.NewReleaseManagerClient(c),
+		client:  pb.NewReleaseManagerClient(diagnosticsConn(largeValueConn(namedConn(c, p.Name), broker, p.Features, p.Logger), p.Logger)),
 		logger:  p.Logger,
 		broker:  broker,
 		mappers: p.Mappers,
 	}
 
+	probe, err := newCapabilityProbe(ctx, c, component.ReleaseManagerType, p.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	authenticator := &authenticatorClient{
 		Client:  client.client,
 		Logger:  client.logger,
 		Broker:  client.broker,
 		Mappers: client.mappers,
 	}
-	if ok, err := authenticator.Implements(ctx); err != nil {
+	if ok, err := probe.Implements(ctx, component.CapabilityAuthenticator, authenticator); err != nil {
 		return nil, err
 	} else if ok {
 		p.Logger.Info("release plugin capable of auth")
@@ -90,6 +127,48 @@ func (p *ReleaseManagerPlugin) GRPCClient(
 		authenticator = nil
 	}
 
+	authRefresher := &authRefresherClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityAuthRefresher, authRefresher); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("release plugin capable of auth refresh")
+	} else {
+		authRefresher = nil
+	}
+
+	validator := &validatorClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityValidator, validator); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("release plugin capable of validation")
+	} else {
+		validator = nil
+	}
+
+	configDefaulter := &configDefaulterClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityConfigDefaulter, configDefaulter); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("release plugin capable of config defaults")
+	} else {
+		configDefaulter = nil
+	}
+
 	// Compose destroyer
 	destroyer := &destroyerClient{
 		Client:  client.client,
@@ -97,7 +176,7 @@ func (p *ReleaseManagerPlugin) GRPCClient(
 		Broker:  client.broker,
 		Mappers: client.mappers,
 	}
-	if ok, err := destroyer.Implements(ctx); err != nil {
+	if ok, err := probe.Implements(ctx, component.CapabilityDestroyer, destroyer); err != nil {
 		return nil, err
 	} else if ok {
 		p.Logger.Info("release plugin capable of destroy")
@@ -112,7 +191,7 @@ func (p *ReleaseManagerPlugin) GRPCClient(
 		Broker:  client.broker,
 		Mappers: client.mappers,
 	}
-	if ok, err := wsDestroyer.Implements(ctx); err != nil {
+	if ok, err := probe.Implements(ctx, component.CapabilityWorkspaceDestroyer, wsDestroyer); err != nil {
 		return nil, err
 	} else if ok {
 		p.Logger.Info("platform plugin capable of destroy")
@@ -126,7 +205,7 @@ func (p *ReleaseManagerPlugin) GRPCClient(
 		Broker:  client.broker,
 		Mappers: client.mappers,
 	}
-	if ok, err := status.Implements(ctx); err != nil {
+	if ok, err := probe.Implements(ctx, component.CapabilityStatus, status); err != nil {
 		return nil, err
 	} else if ok {
 		p.Logger.Info("platform plugin capable of status")
@@ -134,14 +213,45 @@ func (p *ReleaseManagerPlugin) GRPCClient(
 		status = nil
 	}
 
+	remediator := &remediatorClient{
+		Client:  client.client,
+		Logger:  client.logger,
+		Broker:  client.broker,
+		Mappers: client.mappers,
+	}
+	if ok, err := probe.Implements(ctx, component.CapabilityRemediator, remediator); err != nil {
+		return nil, err
+	} else if ok {
+		p.Logger.Info("release plugin capable of remediation")
+	} else {
+		remediator = nil
+	}
+
+	var caps capabilities
+	caps.add(component.CapabilityAuthenticator, authenticator != nil)
+	caps.add(component.CapabilityAuthRefresher, authRefresher != nil)
+	caps.add(component.CapabilityValidator, validator != nil)
+	caps.add(component.CapabilityConfigDefaulter, configDefaulter != nil)
+	caps.add(component.CapabilityDestroyer, destroyer != nil)
+	caps.add(component.CapabilityWorkspaceDestroyer, wsDestroyer != nil)
+	caps.add(component.CapabilityStatus, status != nil)
+	caps.add(component.CapabilityRemediator, remediator != nil)
+
 	result := &mix_ReleaseManager_Authenticator{
+		capabilities:       caps,
 		ConfigurableNotify: client,
+		ConfigurableSchema: client,
 		ReleaseManager:     client,
 		Authenticator:      authenticator,
+		AuthRefresher:      authRefresher,
+		Validator:          validator,
+		ConfigDefaulter:    configDefaulter,
 		Destroyer:          destroyer,
+		DestroyHooks:       destroyer,
 		WorkspaceDestroyer: wsDestroyer,
 		Documented:         client,
 		Status:             status,
+		Remediator:         remediator,
 	}
 
 	return result, nil
@@ -164,6 +274,14 @@ func (c *releaseManagerClient) ConfigSet(v interface{}) error {
 	return configureCall(context.Background(), c.client, v)
 }
 
+func (c *releaseManagerClient) ConfigSchema() (hcldec.Spec, error) {
+	return configSchemaCall(context.Background(), c.client)
+}
+
+func (c *releaseManagerClient) ConfigSetValue(v cty.Value) error {
+	return configureValueCall(context.Background(), c.client, v)
+}
+
 func (c *releaseManagerClient) Documentation() (*docs.Documentation, error) {
 	return documentationCall(context.Background(), c.client)
 }
@@ -217,6 +335,8 @@ func (c *releaseManagerClient) release(
 		Any:         resp.Result,
 		Release:     resp.Release,
 		TemplateVal: tplData,
+		EventsVal:   resp.EventLog.GetEvents(),
+		WarningsVal: diag.FromProto(resp.Warnings),
 	}, nil
 }
 
@@ -225,9 +345,12 @@ func (c *releaseManagerClient) release(
 type releaseManagerServer struct {
 	*base
 	*authenticatorServer
+	*validatorServer
+	*configDefaulterServer
 	*destroyerServer
 	*workspaceDestroyerServer
 	*statusServer
+	*remediatorServer
 
 	pb.UnsafeReleaseManagerServer
 
@@ -245,16 +368,32 @@ func (s *releaseManagerServer) Documentation(
 	ctx context.Context,
 	empty *empty.Empty,
 ) (*pb.Config_Documentation, error) {
-	return documentation(s.Impl)
+	return documentation(s.Impl, s.Mappers, s.Logger)
 }
 
 func (s *releaseManagerServer) Configure(
 	ctx context.Context,
 	req *pb.Config_ConfigureRequest,
 ) (*empty.Empty, error) {
+	defer s.purgeMappers()
 	return configure(s.Impl, req)
 }
 
+func (s *releaseManagerServer) ConfigSchema(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.Config_SchemaResp, error) {
+	return configSchema(s.Impl)
+}
+
+func (s *releaseManagerServer) ConfigureValue(
+	ctx context.Context,
+	req *pb.Config_ConfigureValueRequest,
+) (*empty.Empty, error) {
+	defer s.purgeMappers()
+	return configureValue(s.Impl, req)
+}
+
 func (s *releaseManagerServer) ReleaseSpec(
 	ctx context.Context,
 	args *empty.Empty,
@@ -277,15 +416,18 @@ func (s *releaseManagerServer) Release(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	// Inject our outparameter, so we can capture the response after invocation
+	// Inject our outparameters, so we can capture the response after invocation
 	declaredResourcesResp := &component.DeclaredResourcesResp{}
+	eventLogger := &component.EventLogger{}
+	warnings := &component.Warnings{}
 
-	raw, err := callDynamicFunc2(s.Impl.ReleaseFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	raw, err := callDynamicFuncWarnings(ctx, s.Impl.ReleaseFunc(), args.Args, warnings,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Logger(s.Logger),
 		argmapper.Typed(ctx),
 		argmapper.Typed(internal),
 		argmapper.Typed(declaredResourcesResp),
+		argmapper.Typed(eventLogger),
 	)
 	if err != nil {
 		return nil, err
@@ -304,9 +446,11 @@ func (s *releaseManagerServer) Release(
 		DeclaredResources: &pb.DeclaredResources{
 			Resources: declaredResourcesResp.DeclaredResources,
 		},
+		EventLog: &pb.EventLog{Events: eventLogger.Events()},
+		Warnings: warnings.Warnings().Proto(),
 	}
 
-	result.TemplateData, err = templateData(raw)
+	result.TemplateData, err = templateData(raw, s.Logger)
 	if err != nil {
 		return nil, err
 	}
@@ -322,4 +466,5 @@ var (
 	_ component.Configurable       = (*releaseManagerClient)(nil)
 	_ component.Documented         = (*releaseManagerClient)(nil)
 	_ component.ConfigurableNotify = (*releaseManagerClient)(nil)
+	_ component.ConfigurableSchema = (*releaseManagerClient)(nil)
 )
@@ -5,11 +5,19 @@ import (
 )
 
 type mix_ReleaseManager_Authenticator struct {
+	capabilities
+
 	component.Authenticator
+	component.AuthRefresher
+	component.Validator
+	component.ConfigDefaulter
 	component.ConfigurableNotify
+	component.ConfigurableSchema
 	component.ReleaseManager
 	component.Destroyer
+	component.DestroyHooks
 	component.WorkspaceDestroyer
 	component.Documented
 	component.Status
+	component.Remediator
 }
@@ -0,0 +1,154 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// remediatorClient implements component.Remediator for a service that
+// has the remediate methods implemented.
+type remediatorClient struct {
+	Client  remediatorProtoClient
+	Logger  hclog.Logger
+	Broker  *plugin.GRPCBroker
+	Mappers []*argmapper.Func
+}
+
+func (c *remediatorClient) Implements(ctx context.Context) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	resp, err := c.Client.IsRemediator(ctx, &empty.Empty{})
+	if err != nil {
+		// Plugins built against older SDKs don't have the IsRemediator
+		// RPC at all, which just means they don't implement it.
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return resp.Implements, nil
+}
+
+func (c *remediatorClient) RemediateFunc() interface{} {
+	impl, err := c.Implements(context.Background())
+	if err != nil {
+		return funcErr(err)
+	}
+	if !impl {
+		return nil
+	}
+
+	// Get the spec
+	spec, err := c.Client.RemediateSpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		return funcErr(err)
+	}
+
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.remediate,
+		argmapper.Logger(c.Logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.Broker,
+			Mappers: c.Mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *remediatorClient) remediate(
+	ctx context.Context,
+	args funcspec.Args,
+	internal *pluginargs.Internal,
+) error {
+	// Run the cleanup
+	defer internal.Cleanup.Close()
+
+	// Call our function
+	_, err := c.Client.Remediate(ctx, &pb.FuncSpec_Args{Args: args})
+	return err
+}
+
+// remediatorServer implements the common Remediator-related RPC calls.
+// This should be embedded into the service implementation.
+type remediatorServer struct {
+	*base
+	Impl interface{}
+}
+
+func (s *remediatorServer) IsRemediator(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.ImplementsResp, error) {
+	r, ok := s.Impl.(component.Remediator)
+	return &pb.ImplementsResp{
+		Implements: ok && r.RemediateFunc() != nil,
+	}, nil
+}
+
+func (s *remediatorServer) RemediateSpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	r, ok := s.Impl.(component.Remediator)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: Remediator")
+	}
+
+	return funcspec.Spec(r.RemediateFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+	)
+}
+
+func (s *remediatorServer) Remediate(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*empty.Empty, error) {
+	r, ok := s.Impl.(component.Remediator)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: Remediator")
+	}
+
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	_, err := callDynamicFunc2(ctx, r.RemediateFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Typed(internal),
+		argmapper.Typed(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// remediatorProtoClient is the interface we expect any gRPC service that
+// supports remediation to implement.
+type remediatorProtoClient interface {
+	IsRemediator(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ImplementsResp, error)
+	RemediateSpec(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error)
+	Remediate(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (*empty.Empty, error)
+}
+
+var (
+	_ component.Remediator = (*remediatorClient)(nil)
+)
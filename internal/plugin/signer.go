@@ -0,0 +1,239 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// signerClient implements component.Signer for a service that
+// has the signing methods implemented.
+type signerClient struct {
+	Client  signerProtoClient
+	Logger  hclog.Logger
+	Broker  *plugin.GRPCBroker
+	Mappers []*argmapper.Func
+}
+
+func (c *signerClient) Implements(ctx context.Context) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	resp, err := c.Client.IsSigner(ctx, &empty.Empty{})
+	if err != nil {
+		// Plugins built against older SDKs don't have the IsSigner
+		// RPC at all, which just means they don't implement it.
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return resp.Implements, nil
+}
+
+func (c *signerClient) SignFunc() interface{} {
+	impl, err := c.Implements(context.Background())
+	if err != nil {
+		return funcErr(err)
+	}
+	if !impl {
+		return nil
+	}
+
+	// Get the spec
+	spec, err := c.Client.SignSpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		return funcErr(err)
+	}
+
+	// We don't want to be a mapper
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.sign,
+		argmapper.Logger(c.Logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.Broker,
+			Mappers: c.Mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *signerClient) sign(
+	ctx context.Context,
+	args funcspec.Args,
+	internal *pluginargs.Internal,
+) (*pb.Signature, error) {
+	// Run the cleanup
+	defer internal.Cleanup.Close()
+
+	// Call our function
+	return c.Client.Sign(ctx, &pb.FuncSpec_Args{Args: args})
+}
+
+func (c *signerClient) VerifySignatureFunc() interface{} {
+	impl, err := c.Implements(context.Background())
+	if err != nil {
+		return funcErr(err)
+	}
+	if !impl {
+		return nil
+	}
+
+	// Get the spec
+	spec, err := c.Client.VerifySignatureSpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		return funcErr(err)
+	}
+
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.verifySignature,
+		argmapper.Logger(c.Logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.Broker,
+			Mappers: c.Mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *signerClient) verifySignature(
+	ctx context.Context,
+	args funcspec.Args,
+	internal *pluginargs.Internal,
+) error {
+	// Run the cleanup
+	defer internal.Cleanup.Close()
+
+	// Call our function
+	_, err := c.Client.VerifySignature(ctx, &pb.FuncSpec_Args{Args: args})
+	return err
+}
+
+// signerServer implements the common Signer-related RPC calls.
+// This should be embedded into the service implementation.
+type signerServer struct {
+	*base
+	Impl interface{}
+}
+
+func (s *signerServer) IsSigner(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.ImplementsResp, error) {
+	_, ok := s.Impl.(component.Signer)
+	return &pb.ImplementsResp{Implements: ok}, nil
+}
+
+func (s *signerServer) SignSpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	signer, ok := s.Impl.(component.Signer)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: Signer")
+	}
+
+	return funcspec.Spec(signer.SignFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+	)
+}
+
+func (s *signerServer) Sign(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*pb.Signature, error) {
+	signer, ok := s.Impl.(component.Signer)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: Signer")
+	}
+
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	result, err := callDynamicFunc2(ctx, signer.SignFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Typed(internal),
+		argmapper.Typed(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, ok := result.(*pb.Signature)
+	if !ok {
+		return nil, status.Errorf(codes.Aborted, "sign result is not a *proto.Signature")
+	}
+
+	return sig, nil
+}
+
+func (s *signerServer) VerifySignatureSpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	signer, ok := s.Impl.(component.Signer)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: Signer")
+	}
+
+	return funcspec.Spec(signer.VerifySignatureFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+	)
+}
+
+func (s *signerServer) VerifySignature(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*empty.Empty, error) {
+	signer, ok := s.Impl.(component.Signer)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: Signer")
+	}
+
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	_, err := callDynamicFunc2(ctx, signer.VerifySignatureFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Typed(internal),
+		argmapper.Typed(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// signerProtoClient is the interface we expect any gRPC service that
+// supports signing to implement.
+type signerProtoClient interface {
+	IsSigner(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ImplementsResp, error)
+	SignSpec(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error)
+	Sign(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (*pb.Signature, error)
+	VerifySignatureSpec(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error)
+	VerifySignature(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (*empty.Empty, error)
+}
+
+var (
+	_ component.Signer = (*signerClient)(nil)
+)
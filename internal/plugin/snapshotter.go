@@ -0,0 +1,339 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// snapshotChunkSize is the most snapshot data sent in a single message.
+const snapshotChunkSize = 64 * 1024
+
+// snapshotterClient implements component.Snapshotter for a service that
+// has the snapshot methods implemented.
+type snapshotterClient struct {
+	Client  snapshotterProtoClient
+	Logger  hclog.Logger
+	Broker  *plugin.GRPCBroker
+	Mappers []*argmapper.Func
+}
+
+func (c *snapshotterClient) Implements(ctx context.Context) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	resp, err := c.Client.IsSnapshotter(ctx, &empty.Empty{})
+	if err != nil {
+		// Plugins built with an SDK before snapshots were added don't
+		// implement the RPC, so they don't support it.
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return resp.Implements, nil
+}
+
+func (c *snapshotterClient) SnapshotFunc() interface{} {
+	// If we're not initialized, then it doesn't support snapshots
+	if c == nil {
+		return nil
+	}
+
+	// Get the spec
+	spec, err := c.Client.SnapshotSpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		return funcErr(err)
+	}
+
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.snapshot,
+		argmapper.Logger(c.Logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.Broker,
+			Mappers: c.Mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *snapshotterClient) snapshot(
+	ctx context.Context,
+	args funcspec.Args,
+	internal *pluginargs.Internal,
+	w *component.SnapshotWriter,
+) error {
+	// Run the cleanup
+	defer internal.Cleanup.Close()
+
+	// Canceling ends the snapshot if we fail to write the data
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.Client.Snapshot(ctx, &pb.FuncSpec_Args{Args: args})
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *snapshotterClient) RestoreFunc() interface{} {
+	// If we're not initialized, then it doesn't support snapshots
+	if c == nil {
+		return nil
+	}
+
+	// Get the spec
+	spec, err := c.Client.RestoreSpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		return funcErr(err)
+	}
+
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.restore,
+		argmapper.Logger(c.Logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.Broker,
+			Mappers: c.Mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *snapshotterClient) restore(
+	ctx context.Context,
+	args funcspec.Args,
+	internal *pluginargs.Internal,
+	r *component.SnapshotReader,
+) error {
+	// Run the cleanup
+	defer internal.Cleanup.Close()
+
+	// Canceling ends the restore if we fail to read the data
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.Client.Restore(ctx)
+	if err != nil {
+		return err
+	}
+
+	// If the plugin ends the call early, Send returns io.EOF and the
+	// result of the call is returned by CloseAndRecv.
+	err = stream.Send(&pb.Snapshot_RestoreRequest{
+		Request: &pb.Snapshot_RestoreRequest_Args{
+			Args: &pb.FuncSpec_Args{Args: args},
+		},
+	})
+	for err == nil {
+		buf := make([]byte, snapshotChunkSize)
+		n, rerr := r.Read(buf)
+		if n > 0 {
+			err = stream.Send(&pb.Snapshot_RestoreRequest{
+				Request: &pb.Snapshot_RestoreRequest_Chunk{
+					Chunk: &pb.Snapshot_Chunk{Data: buf[:n]},
+				},
+			})
+		}
+
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return rerr
+		}
+	}
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// snapshotterServer implements the common Snapshotter-related RPC calls.
+// This should be embedded into the service implementation.
+type snapshotterServer struct {
+	*base
+	Impl interface{}
+}
+
+func (s *snapshotterServer) IsSnapshotter(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.ImplementsResp, error) {
+	d, ok := s.Impl.(component.Snapshotter)
+	return &pb.ImplementsResp{
+		Implements: ok && d.SnapshotFunc() != nil && d.RestoreFunc() != nil,
+	}, nil
+}
+
+func (s *snapshotterServer) SnapshotSpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	return funcspec.Spec(s.Impl.(component.Snapshotter).SnapshotFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+
+		// The writer is given by the server so the host doesn't send it
+		argmapper.Typed(component.NewSnapshotWriter(io.Discard)),
+	)
+}
+
+func (s *snapshotterServer) Snapshot(
+	args *pb.FuncSpec_Args,
+	stream pb.Platform_SnapshotServer,
+) error {
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	_, err := callDynamicFunc2(stream.Context(), s.Impl.(component.Snapshotter).SnapshotFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Typed(internal),
+		argmapper.Typed(stream.Context()),
+		argmapper.Typed(component.NewSnapshotWriter(&snapshotStreamWriter{stream: stream})),
+	)
+	return err
+}
+
+func (s *snapshotterServer) RestoreSpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	return funcspec.Spec(s.Impl.(component.Snapshotter).RestoreFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+
+		// The reader is given by the server so the host doesn't send it
+		argmapper.Typed(component.NewSnapshotReader(bytes.NewReader(nil))),
+	)
+}
+
+func (s *snapshotterServer) Restore(stream pb.Platform_RestoreServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	args := req.GetArgs()
+	if args == nil {
+		return status.Errorf(codes.InvalidArgument,
+			"the first message of a restore must have the args")
+	}
+
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	_, err = callDynamicFunc2(stream.Context(), s.Impl.(component.Snapshotter).RestoreFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Typed(internal),
+		argmapper.Typed(stream.Context()),
+		argmapper.Typed(component.NewSnapshotReader(&snapshotStreamReader{stream: stream})),
+	)
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(&empty.Empty{})
+}
+
+// snapshotStreamWriter writes the data of a snapshot to a Snapshot stream.
+type snapshotStreamWriter struct {
+	stream pb.Platform_SnapshotServer
+}
+
+func (w *snapshotStreamWriter) Write(p []byte) (int, error) {
+	for written := 0; written < len(p); {
+		n := len(p) - written
+		if n > snapshotChunkSize {
+			n = snapshotChunkSize
+		}
+
+		if err := w.stream.Send(&pb.Snapshot_Chunk{
+			Data: p[written : written+n],
+		}); err != nil {
+			return written, err
+		}
+
+		written += n
+	}
+
+	return len(p), nil
+}
+
+// snapshotStreamReader reads the data to restore from a Restore stream.
+type snapshotStreamReader struct {
+	stream pb.Platform_RestoreServer
+	buf    []byte
+}
+
+func (r *snapshotStreamReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+
+		chunk := req.GetChunk()
+		if chunk == nil {
+			return 0, status.Errorf(codes.InvalidArgument,
+				"restore message without data")
+		}
+
+		r.buf = chunk.Data
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// snapshotterProtoClient is the interface we expect any gRPC service that
+// supports snapshots to implement.
+type snapshotterProtoClient interface {
+	IsSnapshotter(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ImplementsResp, error)
+	SnapshotSpec(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error)
+	Snapshot(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (pb.Platform_SnapshotClient, error)
+	RestoreSpec(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error)
+	Restore(context.Context, ...grpc.CallOption) (pb.Platform_RestoreClient, error)
+}
+
+var (
+	_ component.Snapshotter = (*snapshotterClient)(nil)
+	_ io.Writer             = (*snapshotStreamWriter)(nil)
+	_ io.Reader             = (*snapshotStreamReader)(nil)
+)
@@ -34,6 +34,12 @@ func (c *statusClient) Implements(ctx context.Context) (bool, error) {
 
 	resp, err := c.Client.IsStatus(ctx, &empty.Empty{})
 	if err != nil {
+		// Registries built with an SDK before they could report status
+		// don't implement the RPC.
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+
 		return false, err
 	}
 
@@ -125,8 +131,8 @@ func (s *statusServer) Status(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	raw, err := callDynamicFunc2(s.Impl.(component.Status).StatusFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	raw, err := callDynamicFunc2(ctx, s.Impl.(component.Status).StatusFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Typed(internal),
 		argmapper.Typed(ctx),
 	)
@@ -0,0 +1,136 @@
+package plugin
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// statusOnlyServicePrefix is the prefix of all the full method names for
+// the component services. Anything else, such as the go-plugin internal
+// services, is always allowed.
+const statusOnlyServicePrefix = "/hashicorp.waypoint.sdk."
+
+// statusOnlyMethods are the component RPCs that are allowed when serving
+// in status-only mode. Configuration is allowed because the status function
+// usually depends on the plugin configuration.
+var statusOnlyMethods = map[string]struct{}{
+	"IsStatus":      {},
+	"StatusSpec":    {},
+	"Status":        {},
+	"ConfigStruct":  {},
+	"Configure":     {},
+	"Documentation": {},
+}
+
+// StatusOnlyServerOptions returns the gRPC server options that restrict
+// the plugin server to the Status capability. Any "Is" capability check
+// other than IsStatus reports that the capability isn't implemented and
+// every other component RPC returns codes.Unimplemented. The Mapper
+// service is always allowed since mappers are needed for status calls, as
+// is the Info service.
+func StatusOnlyServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(statusOnlyUnaryInterceptor),
+		grpc.StreamInterceptor(statusOnlyStreamInterceptor),
+	}
+}
+
+// StatusOnlyComponents filters the given components to only those that
+// implement component.Status. Components that don't support status are
+// never needed in status-only mode so they don't need to be served.
+func StatusOnlyComponents(cs []interface{}) []interface{} {
+	var result []interface{}
+	for _, c := range cs {
+		if _, ok := c.(component.Status); ok {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+// statusOnlyPluginSet removes the plugins from set that don't serve a
+// component, other than the mapper and info plugins which are always
+// needed.
+func statusOnlyPluginSet(set plugin.PluginSet) {
+	for name, p := range set {
+		if name == "mapper" || name == "info" {
+			continue
+		}
+
+		if impl, _, _ := pluginImpl(p); impl == nil {
+			delete(set, name)
+		}
+	}
+}
+
+func statusOnlyUnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	service, method, ok := statusOnlyMethod(info.FullMethod)
+	if !ok || service == "Mapper" || service == "Info" {
+		return handler(ctx, req)
+	}
+
+	if _, ok := statusOnlyMethods[method]; ok {
+		return handler(ctx, req)
+	}
+
+	// Capability checks report that the capability isn't implemented so
+	// that the client can still be created.
+	if strings.HasPrefix(method, "Is") {
+		return &pb.ImplementsResp{Implements: false}, nil
+	}
+
+	return nil, statusOnlyErr(info.FullMethod)
+}
+
+func statusOnlyStreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	service, method, ok := statusOnlyMethod(info.FullMethod)
+	if !ok || service == "Mapper" || service == "Info" {
+		return handler(srv, ss)
+	}
+
+	if _, ok := statusOnlyMethods[method]; ok {
+		return handler(srv, ss)
+	}
+
+	return statusOnlyErr(info.FullMethod)
+}
+
+// statusOnlyMethod splits a full gRPC method name into the service and
+// method name. This returns false if the method isn't for one of the SDK
+// services.
+func statusOnlyMethod(fullMethod string) (string, string, bool) {
+	if !strings.HasPrefix(fullMethod, statusOnlyServicePrefix) {
+		return "", "", false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(fullMethod, statusOnlyServicePrefix), "/", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+func statusOnlyErr(fullMethod string) error {
+	return status.Errorf(codes.Unimplemented,
+		"plugin is running in status-only mode, %s is not available", fullMethod)
+}
@@ -0,0 +1,100 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+func TestStatusOnlyUnaryInterceptor(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "called", nil
+	}
+
+	cases := []struct {
+		Method string
+		Result interface{}
+		Code   codes.Code
+	}{
+		{"/hashicorp.waypoint.sdk.Platform/IsStatus", "called", codes.OK},
+		{"/hashicorp.waypoint.sdk.Platform/StatusSpec", "called", codes.OK},
+		{"/hashicorp.waypoint.sdk.Platform/Status", "called", codes.OK},
+		{"/hashicorp.waypoint.sdk.Platform/Configure", "called", codes.OK},
+		{"/hashicorp.waypoint.sdk.Mapper/Map", "called", codes.OK},
+		{"/plugin.GRPCController/Shutdown", "called", codes.OK},
+		{"/hashicorp.waypoint.sdk.Platform/IsDestroyer", &pb.ImplementsResp{}, codes.OK},
+		{"/hashicorp.waypoint.sdk.Platform/Deploy", nil, codes.Unimplemented},
+		{"/hashicorp.waypoint.sdk.ReleaseManager/Release", nil, codes.Unimplemented},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.Method, func(t *testing.T) {
+			require := require.New(t)
+
+			result, err := statusOnlyUnaryInterceptor(
+				context.Background(), nil,
+				&grpc.UnaryServerInfo{FullMethod: tt.Method}, handler)
+			require.Equal(tt.Code, status.Code(err))
+			if tt.Result == nil {
+				require.Nil(result)
+				return
+			}
+			require.Equal(tt.Result, result)
+		})
+	}
+}
+
+func TestStatusOnlyComponents(t *testing.T) {
+	require := require.New(t)
+
+	withStatus := &mocks.PlatformWithStatus{}
+	result := StatusOnlyComponents([]interface{}{
+		&mocks.Builder{},
+		withStatus,
+		&mocks.ConfigSourcer{},
+	})
+	require.Equal([]interface{}{withStatus}, result)
+}
+
+func TestPlugins_statusOnly(t *testing.T) {
+	require := require.New(t)
+
+	withStatus := &mocks.PlatformWithStatus{}
+	plugins := Plugins(
+		WithComponents(&mocks.Builder{}, withStatus),
+		WithNamedComponent("other", &mocks.Builder{}),
+		WithPlugin("custom", &testCustomPlugin{}),
+		WithLogger(hclog.L()),
+		WithStatusOnly(),
+	)
+
+	// Only the status components are registered
+	var names []string
+	for name := range plugins[1] {
+		names = append(names, name)
+	}
+	require.ElementsMatch([]string{"mapper", "info", "platform"}, names)
+	require.Equal(withStatus, plugins[1]["platform"].(*PlatformPlugin).Impl)
+
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	// The host detects the mode by negotiating it
+	raw, err := client.Dispense("info")
+	require.NoError(err)
+	features, err := raw.(*InfoClient).Negotiate(context.Background(),
+		component.FeatureStatusOnly)
+	require.NoError(err)
+	require.True(features.Enabled(component.FeatureStatusOnly))
+}
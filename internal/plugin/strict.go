@@ -0,0 +1,82 @@
+package plugin
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+)
+
+// WithStrictSpecs makes Plugins check the functions of every component
+// when the plugins are created rather than when the host first requests
+// their specs. Plugins panics if any function has arguments that can't be
+// provided by the host. See CheckSpecs.
+func WithStrictSpecs() Option {
+	return func(c *pluginConfig) { c.StrictSpecs = true }
+}
+
+// CheckSpecs generates the spec of every function of the given components
+// in strict mode and returns an error listing the functions with
+// arguments that can't be provided by the host. The functions are the
+// methods like BuildFunc that return the function for an operation. This
+// lets plugin authors find mistakes in function signatures when the plugin
+// starts instead of when the operation is run.
+func CheckSpecs(cs []interface{}, mappers []*argmapper.Func, log hclog.Logger) error {
+	var result error
+	for _, c := range cs {
+		for _, name := range specMethods(c) {
+			fn := reflect.ValueOf(c).MethodByName(name).Call(nil)[0].Interface()
+			if fn == nil {
+				continue
+			}
+
+			_, err := funcspec.Spec(fn,
+				argmapper.Logger(log),
+				argmapper.ConverterFunc(mappers...),
+				argmapper.Typed(&pluginargs.Internal{
+					Mappers: mappers,
+					Cleanup: &pluginargs.Cleanup{},
+				}),
+				funcspec.Strict(),
+			)
+			if err != nil {
+				result = multierror.Append(result, fmt.Errorf("%T.%s: %w", c, name, err))
+			}
+		}
+	}
+
+	return result
+}
+
+// specMethods returns the names of the methods of c that return a function
+// to generate a spec for, sorted by name. These are the methods with no
+// arguments that end in "Func" and return an interface{}.
+func specMethods(c interface{}) []string {
+	var result []string
+	t := reflect.TypeOf(c)
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		if !strings.HasSuffix(m.Name, "Func") {
+			continue
+		}
+
+		// The receiver is the first input of the method.
+		if m.Type.NumIn() != 1 || m.Type.NumOut() != 1 || m.Type.Out(0) != interfaceType {
+			continue
+		}
+
+		result = append(result, m.Name)
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
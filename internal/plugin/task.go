@@ -7,6 +7,9 @@ import (
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/zclconf/go-cty/cty"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -15,6 +18,7 @@ import (
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/docs"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pkg/logdump"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/plugincomponent"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
@@ -28,16 +32,32 @@ type TaskLauncherPlugin struct {
 	Impl    component.TaskLauncher // Impl is the concrete implementation
 	Mappers []*argmapper.Func      // Mappers
 	Logger  hclog.Logger           // Logger
+
+	ProtocolVersion *component.ProtocolVersion // Protocol version of the plugin set
+	Features        *pluginargs.FeatureSet     // Features of the plugin, see WithFeatures
+	Name            ComponentName              // Name of the component, see WithNamedComponent
+	TracerProvider  trace.TracerProvider       // Traces brokered servers, see WithTracerProvider
+
+	UnaryInterceptors  []grpc.UnaryServerInterceptor  // Interceptors for brokered servers, see WithInterceptors
+	StreamInterceptors []grpc.StreamServerInterceptor // Interceptors for brokered servers, see WithInterceptors
+	ServerSettings     *pluginargs.ServerSettings     // Settings for brokered servers, see WithServerSettings
 }
 
 func (p *TaskLauncherPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
 	base := &base{
-		Mappers: p.Mappers,
-		Logger:  p.Logger,
-		Broker:  broker,
+		Mappers:         p.Mappers,
+		Logger:          p.Logger,
+		Broker:          broker,
+		ProtocolVersion: p.ProtocolVersion,
+		Features:        p.Features,
+		TracerProvider:  p.TracerProvider,
+
+		UnaryInterceptors:  p.UnaryInterceptors,
+		StreamInterceptors: p.StreamInterceptors,
+		ServerSettings:     p.ServerSettings,
 	}
 
-	pb.RegisterTaskLauncherServer(s, &taskLauncherServer{
+	pb.RegisterTaskLauncherServer(largeValueRegistrar(namedRegistrar(s, p.Name), broker), &taskLauncherServer{
 		base: base,
 		Impl: p.Impl,
 
@@ -55,7 +75,7 @@ func (p *TaskLauncherPlugin) GRPCClient(
 	c *grpc.ClientConn,
 ) (interface{}, error) {
 	client := &taskLauncherClient{
-		client:  pb.NewTaskLauncherClient(c),
+		client:  pb.NewTaskLauncherClient(diagnosticsConn(largeValueConn(namedConn(c, p.Name), broker, p.Features, p.Logger), p.Logger)),
 		logger:  p.Logger,
 		broker:  broker,
 		mappers: p.Mappers,
@@ -63,6 +83,7 @@ func (p *TaskLauncherPlugin) GRPCClient(
 
 	result := &mix_TaskLauncher_Authenticator{
 		ConfigurableNotify: client,
+		ConfigurableSchema: client,
 		TaskLauncher:       client,
 		Documented:         client,
 	}
@@ -87,6 +108,14 @@ func (c *taskLauncherClient) ConfigSet(v interface{}) error {
 	return configureCall(context.Background(), c.client, v)
 }
 
+func (c *taskLauncherClient) ConfigSchema() (hcldec.Spec, error) {
+	return configSchemaCall(context.Background(), c.client)
+}
+
+func (c *taskLauncherClient) ConfigSetValue(v cty.Value) error {
+	return configureValueCall(context.Background(), c.client, v)
+}
+
 func (c *taskLauncherClient) Documentation() (*docs.Documentation, error) {
 	return documentationCall(context.Background(), c.client)
 }
@@ -160,7 +189,7 @@ func (c *taskLauncherClient) start(
 		return nil, err
 	}
 
-	c.logger.Info("start done", "value", resp.Result)
+	c.logger.Info("start done", "value", logdump.Value(resp.Result))
 
 	return &plugincomponent.RunningTask{
 		Any: resp.Result,
@@ -222,14 +251,30 @@ func (s *taskLauncherServer) Configure(
 	ctx context.Context,
 	req *pb.Config_ConfigureRequest,
 ) (*empty.Empty, error) {
+	defer s.purgeMappers()
 	return configure(s.Impl, req)
 }
 
+func (s *taskLauncherServer) ConfigSchema(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.Config_SchemaResp, error) {
+	return configSchema(s.Impl)
+}
+
+func (s *taskLauncherServer) ConfigureValue(
+	ctx context.Context,
+	req *pb.Config_ConfigureValueRequest,
+) (*empty.Empty, error) {
+	defer s.purgeMappers()
+	return configureValue(s.Impl, req)
+}
+
 func (s *taskLauncherServer) Documentation(
 	ctx context.Context,
 	empty *empty.Empty,
 ) (*pb.Config_Documentation, error) {
-	return documentation(s.Impl)
+	return documentation(s.Impl, s.Mappers, s.Logger)
 }
 
 func (s *taskLauncherServer) StartSpec(
@@ -254,8 +299,8 @@ func (s *taskLauncherServer) StartTask(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	encoded, encodedJson, _, err := callDynamicFuncAny2(s.Impl.StartTaskFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	encoded, encodedJson, _, err := callDynamicFuncAny2(ctx, s.Impl.StartTaskFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Logger(s.Logger),
 		argmapper.Typed(ctx),
 		argmapper.Typed(internal),
@@ -290,8 +335,8 @@ func (s *taskLauncherServer) StopTask(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	_, err := callDynamicFunc2(s.Impl.StopTaskFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	_, err := callDynamicFunc2(ctx, s.Impl.StopTaskFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Logger(s.Logger),
 		argmapper.Typed(ctx),
 		argmapper.Typed(internal),
@@ -328,8 +373,8 @@ func (s *taskLauncherServer) WatchTask(
 	internal := s.internal()
 	defer internal.Cleanup.Close()
 
-	result, err := callDynamicFunc2(s.Impl.WatchTaskFunc(), args.Args,
-		argmapper.ConverterFunc(s.Mappers...),
+	result, err := callDynamicFunc2(ctx, s.Impl.WatchTaskFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
 		argmapper.Logger(s.Logger),
 		argmapper.Typed(ctx),
 		argmapper.Typed(internal),
@@ -354,4 +399,5 @@ var (
 	_ component.Configurable       = (*taskLauncherClient)(nil)
 	_ component.Documented         = (*taskLauncherClient)(nil)
 	_ component.ConfigurableNotify = (*taskLauncherClient)(nil)
+	_ component.ConfigurableSchema = (*taskLauncherClient)(nil)
 )
@@ -6,6 +6,7 @@ import (
 
 type mix_TaskLauncher_Authenticator struct {
 	component.ConfigurableNotify
+	component.ConfigurableSchema
 	component.TaskLauncher
 	component.Documented
 }
@@ -1,11 +1,14 @@
 package plugin
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -101,6 +104,69 @@ func TestTaskLauncherWatch(t *testing.T) {
 	require.Equal(int(42), taskResult.ExitCode)
 }
 
+func TestTaskLauncherWatch_stream(t *testing.T) {
+	require := require.New(t)
+
+	watchFunc := func(ctx context.Context, w *component.TaskWatchInfo) (*component.TaskResult, error) {
+		if err := w.SetState(&component.TaskState{State: pb.TaskWatch_RUNNING}); err != nil {
+			return nil, err
+		}
+		for i := 0; i < 100; i++ {
+			fmt.Fprintf(w.Output, "line %d\n", i)
+		}
+		fmt.Fprint(w.Error, "oops")
+		if err := w.SetState(&component.TaskState{State: pb.TaskWatch_EXITED, ExitCode: 3}); err != nil {
+			return nil, err
+		}
+
+		return &component.TaskResult{ExitCode: 3}, nil
+	}
+
+	mockB := &mocks.TaskLauncher{}
+	mockB.On("WatchTaskFunc").Return(watchFunc)
+
+	mappers := testDefaultMappers(t)
+	plugins := Plugins(WithComponents(mockB), WithMappers(mappers...))
+	client, server := plugin.TestPluginGRPCConn(t, plugins[1])
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("tasklauncher")
+	require.NoError(err)
+	f := raw.(component.TaskLauncher).WatchTaskFunc().(*argmapper.Func)
+	require.NotNil(f)
+
+	var stdout, stderr bytes.Buffer
+	var states []*component.TaskState
+	result := f.Call(
+		argmapper.ConverterFunc(mappers...),
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(hclog.L()),
+		argmapper.Typed(&component.TaskWatchInfo{
+			Output: &stdout,
+			Error:  &stderr,
+			StateFunc: func(s *component.TaskState) error {
+				states = append(states, s)
+				return nil
+			},
+		}),
+	)
+	require.NoError(result.Err())
+	require.Equal(3, result.Out(0).(*component.TaskResult).ExitCode)
+
+	// All output must be delivered by the time the call returns.
+	var expected strings.Builder
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(&expected, "line %d\n", i)
+	}
+	require.Equal(expected.String(), stdout.String())
+	require.Equal("oops", stderr.String())
+	require.Len(states, 2)
+	require.Equal(pb.TaskWatch_RUNNING, states[0].State)
+	require.Equal(pb.TaskWatch_EXITED, states[1].State)
+	require.Equal(3, states[1].ExitCode)
+}
+
 func TestTaskLauncherConfig(t *testing.T) {
 	mockV := &mockTaskLauncherConfigurable{}
 	testConfigurable(t, "tasklauncher", mockV, &mockV.Configurable)
@@ -0,0 +1,219 @@
+package taskwatch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// TaskWatchPlugin implements plugin.Plugin (specifically GRPCPlugin) for
+// the component.TaskWatchInfo type.
+type TaskWatchPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+
+	Impl     *component.TaskWatchInfo // Impl is the concrete implementation
+	Mappers  []*argmapper.Func        // Mappers
+	Logger   hclog.Logger             // Logger
+	Internal *pluginargs.Internal     // Internal sets up the client connection
+}
+
+func (p *TaskWatchPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	pb.RegisterTaskWatchServiceServer(s, &taskWatchServer{
+		Impl:    p.Impl,
+		Mappers: p.Mappers,
+		Logger:  p.Logger,
+	})
+	return nil
+}
+
+func (p *TaskWatchPlugin) GRPCClient(
+	ctx context.Context,
+	broker *plugin.GRPCBroker,
+	c *grpc.ClientConn,
+) (interface{}, error) {
+	// All output and state changes of the watch are sent over a single
+	// stream which is closed once the plugin call completes.
+	stream := &watchStream{
+		ctx:    ctx,
+		client: pb.NewTaskWatchServiceClient(p.Internal.ClientConn(c)),
+	}
+	p.Internal.Cleanup.Do(func() {
+		if err := stream.Close(); err != nil {
+			p.Logger.Warn("error closing task watch stream", "error", err)
+		}
+	})
+
+	return &component.TaskWatchInfo{
+		Output: &ioWriter{stream: stream},
+		Error:  &ioWriter{stream: stream, stderr: true},
+		StateFunc: func(s *component.TaskState) error {
+			return stream.Send(&pb.TaskWatch_Event{
+				Event: &pb.TaskWatch_Event_State{
+					State: &pb.TaskWatch_StateRequest{
+						State:    s.State,
+						Message:  s.Message,
+						ExitCode: int32(s.ExitCode),
+					},
+				},
+			})
+		},
+	}, nil
+}
+
+// watchStream is the client side of the Watch stream. The stream is opened
+// on the first event so watches that never report anything don't use it.
+type watchStream struct {
+	ctx    context.Context
+	client pb.TaskWatchServiceClient
+
+	mu     sync.Mutex
+	stream pb.TaskWatchService_WatchClient
+	err    error
+}
+
+// Send sends an event to the host. Events are not acknowledged, so an
+// error the host returns for an event is returned by a later Send or by
+// Close. Once sending fails, all later calls return the same error.
+func (w *watchStream) Send(ev *pb.TaskWatch_Event) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil {
+		return w.err
+	}
+
+	if w.stream == nil {
+		w.stream, w.err = w.client.Watch(w.ctx)
+		if w.err != nil {
+			return w.err
+		}
+	}
+
+	if err := w.stream.Send(ev); err != nil {
+		// Send returns io.EOF if the host ended the stream, the actual
+		// error is returned by CloseAndRecv.
+		if err == io.EOF {
+			if _, rerr := w.stream.CloseAndRecv(); rerr != nil {
+				err = rerr
+			}
+		}
+
+		w.err = err
+		return err
+	}
+
+	return nil
+}
+
+// Close closes the stream and waits for the host to process all events
+// sent on it.
+func (w *watchStream) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.stream == nil || w.err != nil {
+		return nil
+	}
+
+	_, err := w.stream.CloseAndRecv()
+	w.err = errClosed
+	return err
+}
+
+var errClosed = errors.New("task watch is closed")
+
+type ioWriter struct {
+	stream *watchStream
+	stderr bool
+}
+
+func (i *ioWriter) Write(p []byte) (n int, err error) {
+	err = i.stream.Send(&pb.TaskWatch_Event{
+		Event: &pb.TaskWatch_Event_Output{
+			Output: &pb.TaskWatch_OutputRequest{
+				Data:   p,
+				Stderr: i.stderr,
+			},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// taskWatchServer is a gRPC server that the plugin talks to and calls a
+// real implementation of the component.
+type taskWatchServer struct {
+	pb.UnimplementedTaskWatchServiceServer
+
+	Impl    *component.TaskWatchInfo
+	Mappers []*argmapper.Func
+	Logger  hclog.Logger
+}
+
+func (s *taskWatchServer) Watch(stream pb.TaskWatchService_WatchServer) error {
+	for {
+		ev, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&empty.Empty{})
+		}
+		if err != nil {
+			return err
+		}
+
+		switch ev := ev.Event.(type) {
+		case *pb.TaskWatch_Event_Output:
+			err = s.output(ev.Output)
+
+		case *pb.TaskWatch_Event_State:
+			err = s.state(ev.State)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *taskWatchServer) output(req *pb.TaskWatch_OutputRequest) error {
+	w := s.Impl.Output
+	if req.Stderr {
+		w = s.Impl.Error
+	}
+
+	// The host may not care about one of the streams.
+	if w == nil {
+		return nil
+	}
+
+	_, err := w.Write(req.Data)
+	return err
+}
+
+func (s *taskWatchServer) state(req *pb.TaskWatch_StateRequest) error {
+	s.Logger.Trace("task state changed", "state", req.State.String())
+
+	return s.Impl.SetState(&component.TaskState{
+		State:    req.State,
+		Message:  req.Message,
+		ExitCode: int(req.ExitCode),
+	})
+}
+
+var (
+	_ plugin.Plugin             = (*TaskWatchPlugin)(nil)
+	_ plugin.GRPCPlugin         = (*TaskWatchPlugin)(nil)
+	_ pb.TaskWatchServiceServer = (*taskWatchServer)(nil)
+)
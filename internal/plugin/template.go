@@ -2,9 +2,11 @@ package plugin
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"strings"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/iancoleman/strcase"
 	"github.com/mitchellh/mapstructure"
 	"google.golang.org/grpc/codes"
@@ -15,12 +17,24 @@ import (
 
 // templateData returns the template data for a result object. If v
 // implements component.Template that value is used. Otherwise, we automatically
-// infer the fields based on the exported fields of the struct.
-func templateData(v interface{}) ([]byte, error) {
+// infer the fields based on the exported fields of the struct. If v
+// implements component.TemplateSchema, a warning is logged for any
+// difference between the data and the schema.
+func templateData(v interface{}, log hclog.Logger) ([]byte, error) {
 	// Determine our data
 	var data map[string]interface{}
 	if tpl, ok := v.(component.Template); ok {
 		data = tpl.TemplateData()
+
+		if ts, ok := v.(component.TemplateSchema); ok {
+			schema := ts.TemplateSchema()
+			for _, problem := range schema.Validate(data) {
+				log.Warn("template data does not match its schema",
+					"type", fmt.Sprintf("%T", v),
+					"version", schema.Version,
+					"problem", problem)
+			}
+		}
 	} else {
 		data = templateDataFromConfig(v)
 	}
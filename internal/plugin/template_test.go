@@ -1,9 +1,14 @@
 package plugin
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component/mocks"
+	"github.com/hashicorp/waypoint-plugin-sdk/docs"
 )
 
 func TestTemplateDataFromConfig(t *testing.T) {
@@ -69,3 +74,29 @@ func TestTemplateDataFromConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateData_schema(t *testing.T) {
+	require := require.New(t)
+
+	v := &mocks.TemplateSchema{}
+	v.On("TemplateData").Return(map[string]interface{}{
+		"url":  "http://localhost",
+		"port": "8080",
+	})
+	v.On("TemplateSchema").Return(&docs.TemplateSchema{
+		Version: 1,
+		Fields: []*docs.TemplateField{
+			{Name: "url", Type: docs.TemplateTypeString},
+			{Name: "port", Type: docs.TemplateTypeInt},
+		},
+	})
+
+	var buf bytes.Buffer
+	log := hclog.New(&hclog.LoggerOptions{Output: &buf})
+
+	data, err := templateData(v, log)
+	require.NoError(err)
+	require.JSONEq(`{"url":"http://localhost","port":"8080"}`, string(data))
+	require.Contains(buf.String(), "template data does not match its schema")
+	require.Contains(buf.String(), "has type string but the template schema declares int")
+}
@@ -2,6 +2,7 @@ package terminal
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -12,10 +13,13 @@ import (
 	"github.com/hashicorp/go-plugin"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	statuspkg "google.golang.org/grpc/status"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/pkg/pty"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
 )
@@ -25,9 +29,11 @@ import (
 type UIPlugin struct {
 	plugin.NetRPCUnsupportedPlugin
 
-	Impl    terminal.UI       // Impl is the concrete implementation
-	Mappers []*argmapper.Func // Mappers
-	Logger  hclog.Logger      // Logger
+	Impl     terminal.UI          // Impl is the concrete implementation
+	Mappers  []*argmapper.Func    // Mappers
+	Logger   hclog.Logger         // Logger
+	Features *component.Features  // Features negotiated with the host
+	Internal *pluginargs.Internal // Internal sets up the client connection
 }
 
 func (p *UIPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
@@ -44,7 +50,7 @@ func (p *UIPlugin) GRPCClient(
 	broker *plugin.GRPCBroker,
 	c *grpc.ClientConn,
 ) (interface{}, error) {
-	client := pb.NewTerminalUIServiceClient(c)
+	client := pb.NewTerminalUIServiceClient(p.Internal.ClientConn(c))
 	resp, err := client.IsInteractive(ctx, &empty.Empty{})
 	if err != nil {
 		return nil, err
@@ -58,10 +64,12 @@ func (p *UIPlugin) GRPCClient(
 	ctx, cancel := context.WithCancel(ctx)
 
 	return &uiBridge{
-		ctx:         ctx,
-		cancel:      cancel,
-		interactive: resp.Interactive,
-		evc:         evstream,
+		ctx:          ctx,
+		cancel:       cancel,
+		interactive:  resp.Interactive,
+		outputFormat: terminal.OutputFormat(resp.OutputFormat),
+		confirm:      p.Features.Enabled(component.FeatureTerminalConfirm),
+		evc:          evstream,
 	}, nil
 }
 
@@ -91,7 +99,8 @@ func (s *uiServer) IsInteractive(
 	req *empty.Empty,
 ) (*pb.TerminalUI_IsInteractiveResponse, error) {
 	return &pb.TerminalUI_IsInteractiveResponse{
-		Interactive: s.Impl.Interactive(),
+		Interactive:  s.Impl.Interactive(),
+		OutputFormat: string(terminal.FormatOf(s.Impl)),
 	}, nil
 }
 
@@ -112,8 +121,16 @@ func (s *uiServer) Events(stream pb.TerminalUIService_EventsServer) error {
 		stderr io.Writer
 
 		sgs = map[int32]*stepGroup{}
+		lts = map[int32]terminal.LiveTable{}
 	)
 
+	// Leave the final state of any live tables the plugin didn't close.
+	defer func() {
+		for _, lt := range lts {
+			lt.Close()
+		}
+	}()
+
 	for {
 		ev, err := stream.Recv()
 		if err != nil {
@@ -180,9 +197,38 @@ func (s *uiServer) Events(stream pb.TerminalUIService_EventsServer) error {
 						Color: ent.Color,
 					})
 				}
+
+				tbl.Rows = append(tbl.Rows, trow)
 			}
 
 			s.Impl.Table(tbl)
+		case *pb.TerminalUI_Event_LiveTable_:
+			lt, ok := lts[ev.LiveTable.Id]
+			if !ok {
+				lt = terminal.NewLiveTable(s.Impl, ev.LiveTable.Headers...)
+				lts[ev.LiveTable.Id] = lt
+			}
+
+			if ev.LiveTable.Key != "" {
+				if ev.LiveTable.Delete {
+					lt.DeleteRow(ev.LiveTable.Key)
+				} else {
+					var row []terminal.TableEntry
+					for _, ent := range ev.LiveTable.Row.GetEntries() {
+						row = append(row, terminal.TableEntry{
+							Value: ent.Value,
+							Color: ent.Color,
+						})
+					}
+
+					lt.SetRow(ev.LiveTable.Key, row...)
+				}
+			}
+
+			if ev.LiveTable.Close {
+				lt.Close()
+				delete(lts, ev.LiveTable.Id)
+			}
 		case *pb.TerminalUI_Event_StepGroup_:
 			sgd, ok := sgs[ev.StepGroup.Id]
 			if ok {
@@ -239,17 +285,25 @@ func (s *uiServer) Events(stream pb.TerminalUIService_EventsServer) error {
 				Secret: ev.Input.Secret,
 			})
 
-			var sterr *spb.Status
-			if err != nil {
-				st, _ := statuspkg.FromError(err)
-				sterr = st.Proto()
-			}
-
 			respEvent := &pb.TerminalUI_Response{
 				Event: &pb.TerminalUI_Response_Input{
 					Input: &pb.TerminalUI_Event_InputResp{
 						Input: result,
-						Error: sterr,
+						Error: inputStatus(err),
+					},
+				},
+			}
+			if err := stream.Send(respEvent); err != nil {
+				return err
+			}
+		case *pb.TerminalUI_Event_Confirm_:
+			result, err := terminal.Confirm(s.Impl, ev.Confirm.Prompt)
+
+			respEvent := &pb.TerminalUI_Response{
+				Event: &pb.TerminalUI_Response_Confirm{
+					Confirm: &pb.TerminalUI_Event_ConfirmResp{
+						Confirmed: result,
+						Error:     inputStatus(err),
 					},
 				},
 			}
@@ -263,12 +317,15 @@ func (s *uiServer) Events(stream pb.TerminalUIService_EventsServer) error {
 }
 
 type uiBridge struct {
-	ctx         context.Context
-	cancel      func()
-	mu          sync.Mutex
-	evc         pb.TerminalUIService_EventsClient
-	interactive bool
-	sgIdx       int32
+	ctx          context.Context
+	cancel       func()
+	mu           sync.Mutex
+	evc          pb.TerminalUIService_EventsClient
+	interactive  bool
+	outputFormat terminal.OutputFormat
+	confirm      bool
+	sgIdx        int32
+	ltIdx        int32
 
 	evcRecvLock    sync.Mutex
 	stdSetup       sync.Once
@@ -326,16 +383,95 @@ func (u *uiBridge) Input(input *terminal.Input) (string, error) {
 	}
 
 	if respEvent.Input.Error != nil {
-		return "", statuspkg.FromProto(respEvent.Input.Error).Err()
+		return "", inputErr(respEvent.Input.Error)
 	}
 
 	return respEvent.Input.Input, nil
 }
 
+// Confirm implements terminal.ConfirmUI. Hosts that predate confirm
+// events don't answer them, so unless the host negotiated
+// component.FeatureTerminalConfirm the confirmation is prompted for with
+// Input instead.
+func (u *uiBridge) Confirm(prompt string) (bool, error) {
+	if !u.interactive {
+		return false, terminal.ErrNonInteractive
+	}
+
+	if !u.confirm {
+		// Hide our Confirm so terminal.Confirm uses Input.
+		return terminal.Confirm(struct{ terminal.UI }{u}, prompt)
+	}
+
+	u.evcRecvLock.Lock()
+	defer u.evcRecvLock.Unlock()
+
+	err := u.evc.Send(&pb.TerminalUI_Event{
+		Event: &pb.TerminalUI_Event_Confirm_{
+			Confirm: &pb.TerminalUI_Event_Confirm{
+				Prompt: prompt,
+			},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// Wait for the response
+	resp, err := u.evc.Recv()
+	if err != nil {
+		return false, err
+	}
+
+	respEvent, ok := resp.Event.(*pb.TerminalUI_Response_Confirm)
+	if !ok {
+		return false, fmt.Errorf("unexpected response type: %T", resp.Event)
+	}
+
+	if respEvent.Confirm.Error != nil {
+		return false, inputErr(respEvent.Confirm.Error)
+	}
+
+	return respEvent.Confirm.Confirmed, nil
+}
+
+// inputStatus converts an error from Input or Confirm to send it to the
+// plugin. ErrNonInteractive is sent as codes.FailedPrecondition so that
+// inputErr can restore it.
+func inputStatus(err error) *spb.Status {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, terminal.ErrNonInteractive) {
+		return statuspkg.New(codes.FailedPrecondition, err.Error()).Proto()
+	}
+
+	st, _ := statuspkg.FromError(err)
+	return st.Proto()
+}
+
+// inputErr converts the error status from an input response back to an
+// error. See inputStatus.
+func inputErr(st *spb.Status) error {
+	if codes.Code(st.Code) == codes.FailedPrecondition &&
+		st.Message == terminal.ErrNonInteractive.Error() {
+		return terminal.ErrNonInteractive
+	}
+
+	return statuspkg.FromProto(st).Err()
+}
+
 func (u *uiBridge) Interactive() bool {
 	return u.interactive
 }
 
+// OutputFormat implements terminal.OutputFormatUI with the format of the
+// host UI.
+func (u *uiBridge) OutputFormat() terminal.OutputFormat {
+	return u.outputFormat
+}
+
 // Output outputs a message directly to the terminal. The remaining
 // arguments should be interpolations for the format string. After the
 // interpolations you may add Options.
@@ -469,26 +605,12 @@ func (u *uiBridge) sendData(r io.ReadCloser, stderr bool) {
 }
 
 func (u *uiBridge) Table(tbl *terminal.Table, opts ...terminal.Option) {
-	var (
-		ptbl *pb.TerminalUI_Event_Table
-		rows []*pb.TerminalUI_Event_TableRow
-	)
-
-	ptbl.Headers = tbl.Headers
+	ptbl := &pb.TerminalUI_Event_Table{
+		Headers: tbl.Headers,
+	}
 
 	for _, row := range tbl.Rows {
-		var entries []*pb.TerminalUI_Event_TableEntry
-
-		for _, ent := range row {
-			entries = append(entries, &pb.TerminalUI_Event_TableEntry{
-				Value: ent.Value,
-				Color: ent.Color,
-			})
-		}
-
-		rows = append(rows, &pb.TerminalUI_Event_TableRow{
-			Entries: entries,
-		})
+		ptbl.Rows = append(ptbl.Rows, tableRowProto(row))
 	}
 
 	u.mu.Lock()
@@ -505,6 +627,94 @@ func (u *uiBridge) Table(tbl *terminal.Table, opts ...terminal.Option) {
 	})
 }
 
+func tableRowProto(row []terminal.TableEntry) *pb.TerminalUI_Event_TableRow {
+	var entries []*pb.TerminalUI_Event_TableEntry
+	for _, ent := range row {
+		entries = append(entries, &pb.TerminalUI_Event_TableEntry{
+			Value: ent.Value,
+			Color: ent.Color,
+		})
+	}
+
+	return &pb.TerminalUI_Event_TableRow{
+		Entries: entries,
+	}
+}
+
+// LiveTable implements terminal.LiveTableUI. The host displays the table
+// with its own UI, falling back to outputting the final table if it can't
+// update output in place.
+func (u *uiBridge) LiveTable(headers ...string) terminal.LiveTable {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	lt := &uiBridgeLiveTable{
+		ui: u,
+		id: u.ltIdx,
+	}
+	u.ltIdx++
+
+	if u.evc != nil {
+		u.evc.Send(&pb.TerminalUI_Event{
+			Event: &pb.TerminalUI_Event_LiveTable_{
+				LiveTable: &pb.TerminalUI_Event_LiveTable{
+					Id:      lt.id,
+					Headers: headers,
+				},
+			},
+		})
+	}
+
+	return lt
+}
+
+type uiBridgeLiveTable struct {
+	ui     *uiBridge
+	id     int32
+	closed bool
+}
+
+func (t *uiBridgeLiveTable) SetRow(key string, row ...terminal.TableEntry) {
+	t.send(&pb.TerminalUI_Event_LiveTable{
+		Id:  t.id,
+		Key: key,
+		Row: tableRowProto(row),
+	})
+}
+
+func (t *uiBridgeLiveTable) DeleteRow(key string) {
+	t.send(&pb.TerminalUI_Event_LiveTable{
+		Id:     t.id,
+		Key:    key,
+		Delete: true,
+	})
+}
+
+func (t *uiBridgeLiveTable) Close() error {
+	t.send(&pb.TerminalUI_Event_LiveTable{
+		Id:    t.id,
+		Close: true,
+	})
+
+	return nil
+}
+
+func (t *uiBridgeLiveTable) send(ev *pb.TerminalUI_Event_LiveTable) {
+	t.ui.mu.Lock()
+	defer t.ui.mu.Unlock()
+
+	if t.closed || t.ui.evc == nil {
+		return
+	}
+	t.closed = ev.Close
+
+	t.ui.evc.Send(&pb.TerminalUI_Event{
+		Event: &pb.TerminalUI_Event_LiveTable_{
+			LiveTable: ev,
+		},
+	})
+}
+
 type uiBridgeSGStep struct {
 	sg   *uiBridgeSG
 	done bool
@@ -816,5 +1026,8 @@ var (
 	_ plugin.GRPCPlugin          = (*UIPlugin)(nil)
 	_ pb.TerminalUIServiceServer = (*uiServer)(nil)
 	_ terminal.UI                = (*uiBridge)(nil)
+	_ terminal.LiveTableUI       = (*uiBridge)(nil)
+	_ terminal.ConfirmUI         = (*uiBridge)(nil)
+	_ terminal.OutputFormatUI    = (*uiBridge)(nil)
 	_ terminal.Status            = (*uiBridgeStatus)(nil)
 )
@@ -0,0 +1,235 @@
+package terminal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+)
+
+// tableUI records the tables output to it.
+type tableUI struct {
+	terminal.UI
+
+	mu     sync.Mutex
+	tables []*terminal.Table
+}
+
+func (ui *tableUI) Table(tbl *terminal.Table, opts ...terminal.Option) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	ui.tables = append(ui.tables, tbl)
+}
+
+func TestUI_liveTable(t *testing.T) {
+	require := require.New(t)
+
+	impl := &tableUI{UI: terminal.NonInteractiveUI(context.Background())}
+	client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		"ui": &UIPlugin{Impl: impl, Logger: hclog.L()},
+	})
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("ui")
+	require.NoError(err)
+	ui := raw.(*uiBridge)
+
+	lt := terminal.NewLiveTable(ui, "Name", "Status")
+	lt.SetRow("a", terminal.TableEntry{Value: "a"}, terminal.TableEntry{Value: "Pending"})
+	lt.SetRow("b", terminal.TableEntry{Value: "b"}, terminal.TableEntry{Value: "Pending"})
+	lt.SetRow("a", terminal.TableEntry{Value: "a"}, terminal.TableEntry{Value: "Running"})
+	lt.DeleteRow("b")
+	require.NoError(lt.Close())
+
+	ui.Table(&terminal.Table{
+		Headers: []string{"Key"},
+		Rows:    [][]terminal.TableEntry{{{Value: "value"}}},
+	})
+
+	// Closing waits for the host to process every event
+	require.NoError(ui.Close())
+
+	impl.mu.Lock()
+	defer impl.mu.Unlock()
+	require.Equal([]*terminal.Table{
+		{
+			Headers: []string{"Name", "Status"},
+			Rows: [][]terminal.TableEntry{
+				{{Value: "a"}, {Value: "Running"}},
+			},
+		},
+		{
+			Headers: []string{"Key"},
+			Rows:    [][]terminal.TableEntry{{{Value: "value"}}},
+		},
+	}, impl.tables)
+}
+
+// inputUI is an interactive UI that answers input with the given answers
+// in order and records the inputs.
+type inputUI struct {
+	terminal.UI
+
+	answers []string
+	inputs  []*terminal.Input
+}
+
+func (ui *inputUI) Interactive() bool { return true }
+
+func (ui *inputUI) Input(input *terminal.Input) (string, error) {
+	ui.inputs = append(ui.inputs, input)
+	if len(ui.answers) == 0 {
+		return "", terminal.ErrNonInteractive
+	}
+
+	answer := ui.answers[0]
+	ui.answers = ui.answers[1:]
+	return answer, nil
+}
+
+func TestUI_prompt(t *testing.T) {
+	require := require.New(t)
+
+	impl := &inputUI{
+		UI:      terminal.NonInteractiveUI(context.Background()),
+		answers: []string{"hunter2", "Yes", "n"},
+	}
+	client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		"ui": &UIPlugin{Impl: impl, Logger: hclog.L()},
+	})
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("ui")
+	require.NoError(err)
+	ui := raw.(*uiBridge)
+
+	token, err := terminal.Prompt(ui, "Token:", true)
+	require.NoError(err)
+	require.Equal("hunter2", token)
+
+	ok, err := terminal.Confirm(ui, "Continue?")
+	require.NoError(err)
+	require.True(ok)
+
+	ok, err = terminal.Confirm(ui, "Really?")
+	require.NoError(err)
+	require.False(ok)
+
+	// The host error is returned as ErrNonInteractive
+	_, err = terminal.Confirm(ui, "Again?")
+	require.Equal(terminal.ErrNonInteractive, err)
+
+	require.NoError(ui.Close())
+	require.Len(impl.inputs, 4)
+	require.Equal(&terminal.Input{Prompt: "Token:", Secret: true}, impl.inputs[0])
+	require.Equal("Continue? [y/N]", impl.inputs[1].Prompt)
+}
+
+// confirmUI is an inputUI that also answers confirmations and records
+// their prompts.
+type confirmUI struct {
+	inputUI
+
+	confirms []string
+}
+
+func (ui *confirmUI) Confirm(prompt string) (bool, error) {
+	ui.confirms = append(ui.confirms, prompt)
+	return true, nil
+}
+
+func TestUI_confirm(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("enabled=%v", enabled), func(t *testing.T) {
+			require := require.New(t)
+
+			var features []component.Feature
+			if enabled {
+				features = append(features, component.FeatureTerminalConfirm)
+			}
+
+			impl := &confirmUI{inputUI: inputUI{
+				UI:      terminal.NonInteractiveUI(context.Background()),
+				answers: []string{"y"},
+			}}
+			client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+				"ui": &UIPlugin{
+					Impl:     impl,
+					Logger:   hclog.L(),
+					Features: component.NewFeatures(features...),
+				},
+			})
+			defer client.Close()
+			defer server.Stop()
+
+			raw, err := client.Dispense("ui")
+			require.NoError(err)
+			ui := raw.(*uiBridge)
+
+			ok, err := terminal.Confirm(ui, "Continue?")
+			require.NoError(err)
+			require.True(ok)
+			require.NoError(ui.Close())
+
+			// Hosts that don't support confirm events are prompted with input
+			if enabled {
+				require.Equal([]string{"Continue?"}, impl.confirms)
+				require.Empty(impl.inputs)
+			} else {
+				require.Empty(impl.confirms)
+				require.Len(impl.inputs, 1)
+				require.Equal("Continue? [y/N]", impl.inputs[0].Prompt)
+			}
+		})
+	}
+}
+
+func TestUI_promptNonInteractive(t *testing.T) {
+	require := require.New(t)
+
+	client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		"ui": &UIPlugin{Impl: terminal.NonInteractiveUI(context.Background()), Logger: hclog.L()},
+	})
+	defer client.Close()
+	defer server.Stop()
+
+	raw, err := client.Dispense("ui")
+	require.NoError(err)
+	ui := raw.(*uiBridge)
+	defer ui.Close()
+
+	_, err = terminal.Prompt(ui, "Token:", true)
+	require.Equal(terminal.ErrNonInteractive, err)
+	_, err = ui.Confirm("Continue?")
+	require.Equal(terminal.ErrNonInteractive, err)
+}
+
+func TestUI_outputFormat(t *testing.T) {
+	for _, impl := range []terminal.UI{
+		terminal.NonInteractiveUI(context.Background()),
+		terminal.JSONUI(context.Background(), io.Discard),
+	} {
+		client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+			"ui": &UIPlugin{Impl: impl, Logger: hclog.L()},
+		})
+
+		raw, err := client.Dispense("ui")
+		require.NoError(t, err)
+		ui := raw.(*uiBridge)
+		require.Equal(t, terminal.FormatOf(impl), terminal.FormatOf(ui))
+
+		require.NoError(t, ui.Close())
+		client.Close()
+		server.Stop()
+	}
+}
@@ -0,0 +1,235 @@
+package plugin
+
+import (
+	"context"
+	"io"
+	"reflect"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// diagnosticsChunkSize is the most entry data sent in a single message.
+const diagnosticsChunkSize = 64 * 1024
+
+// troubleshooterClient implements component.Troubleshooter for a service
+// that has the troubleshoot methods implemented.
+type troubleshooterClient struct {
+	Client  troubleshooterProtoClient
+	Logger  hclog.Logger
+	Broker  *plugin.GRPCBroker
+	Mappers []*argmapper.Func
+}
+
+func (c *troubleshooterClient) Implements(ctx context.Context) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	resp, err := c.Client.IsTroubleshooter(ctx, &empty.Empty{})
+	if err != nil {
+		// Plugins built with an SDK before troubleshooting was added
+		// don't implement the RPC, so they don't support it.
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return resp.Implements, nil
+}
+
+func (c *troubleshooterClient) TroubleshootFunc() interface{} {
+	// If we're not initialized, then it doesn't support troubleshooting
+	if c == nil {
+		return nil
+	}
+
+	// Get the spec
+	spec, err := c.Client.TroubleshootSpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		return funcErr(err)
+	}
+
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.troubleshoot,
+		argmapper.Logger(c.Logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.Broker,
+			Mappers: c.Mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *troubleshooterClient) troubleshoot(
+	ctx context.Context,
+	args funcspec.Args,
+	internal *pluginargs.Internal,
+) (*pb.DiagnosticsBundle, error) {
+	// Run the cleanup
+	defer internal.Cleanup.Close()
+
+	// Call our function
+	stream, err := c.Client.Troubleshoot(ctx, &pb.FuncSpec_Args{Args: args})
+	if err != nil {
+		return nil, err
+	}
+
+	// The first chunk is the bundle and the rest fill in the entry data
+	var bundle *pb.DiagnosticsBundle
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch v := chunk.Chunk.(type) {
+		case *pb.DiagnosticsBundle_Chunk_Header:
+			if bundle != nil {
+				return nil, status.Errorf(codes.Internal, "diagnostics bundle header sent twice")
+			}
+
+			bundle = v.Header
+
+		case *pb.DiagnosticsBundle_Chunk_Data:
+			if bundle == nil || int(v.Data.Index) >= len(bundle.Entries) {
+				return nil, status.Errorf(codes.Internal,
+					"diagnostics data for unknown entry %d", v.Data.Index)
+			}
+
+			entry := bundle.Entries[v.Data.Index]
+			entry.Data = append(entry.Data, v.Data.Data...)
+		}
+	}
+
+	if bundle == nil {
+		return nil, status.Errorf(codes.Internal, "no diagnostics bundle received")
+	}
+
+	return bundle, nil
+}
+
+// troubleshooterServer implements the common Troubleshooter-related RPC
+// calls. This should be embedded into the service implementation.
+type troubleshooterServer struct {
+	*base
+	Impl interface{}
+}
+
+func (s *troubleshooterServer) IsTroubleshooter(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.ImplementsResp, error) {
+	d, ok := s.Impl.(component.Troubleshooter)
+	return &pb.ImplementsResp{
+		Implements: ok && d.TroubleshootFunc() != nil,
+	}, nil
+}
+
+func (s *troubleshooterServer) TroubleshootSpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	return funcspec.Spec(s.Impl.(component.Troubleshooter).TroubleshootFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+
+		argmapper.FilterOutput(argmapper.FilterType(
+			reflect.TypeOf((*pb.DiagnosticsBundle)(nil))),
+		),
+	)
+}
+
+func (s *troubleshooterServer) Troubleshoot(
+	args *pb.FuncSpec_Args,
+	stream pb.Platform_TroubleshootServer,
+) error {
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	raw, err := callDynamicFunc2(stream.Context(), s.Impl.(component.Troubleshooter).TroubleshootFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Typed(internal),
+		argmapper.Typed(stream.Context()),
+	)
+	if err != nil {
+		return err
+	}
+
+	bundle, ok := raw.(*pb.DiagnosticsBundle)
+	if !ok && raw != nil {
+		return status.Errorf(codes.Aborted, "troubleshoot result is not a *proto.DiagnosticsBundle")
+	}
+
+	// A plugin with nothing to report may return a nil bundle.
+	if bundle == nil {
+		bundle = &pb.DiagnosticsBundle{}
+	}
+
+	// Send the bundle without the entry data first so the data can be
+	// split across as many messages as it needs.
+	header := proto.Clone(bundle).(*pb.DiagnosticsBundle)
+	for _, entry := range header.Entries {
+		entry.Data = nil
+	}
+
+	if err := stream.Send(&pb.DiagnosticsBundle_Chunk{
+		Chunk: &pb.DiagnosticsBundle_Chunk_Header{Header: header},
+	}); err != nil {
+		return err
+	}
+
+	for i, entry := range bundle.Entries {
+		for data := entry.Data; len(data) > 0; {
+			n := len(data)
+			if n > diagnosticsChunkSize {
+				n = diagnosticsChunkSize
+			}
+
+			if err := stream.Send(&pb.DiagnosticsBundle_Chunk{
+				Chunk: &pb.DiagnosticsBundle_Chunk_Data{
+					Data: &pb.DiagnosticsBundle_EntryData{
+						Index: uint32(i),
+						Data:  data[:n],
+					},
+				},
+			}); err != nil {
+				return err
+			}
+
+			data = data[n:]
+		}
+	}
+
+	return nil
+}
+
+// troubleshooterProtoClient is the interface we expect any gRPC service
+// that supports troubleshooting to implement.
+type troubleshooterProtoClient interface {
+	IsTroubleshooter(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ImplementsResp, error)
+	TroubleshootSpec(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error)
+	Troubleshoot(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (pb.Platform_TroubleshootClient, error)
+}
+
+var (
+	_ component.Troubleshooter = (*troubleshooterClient)(nil)
+)
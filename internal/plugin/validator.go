@@ -0,0 +1,167 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/funcspec"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
+)
+
+// validatorClient implements component.Validator for a service that
+// has the validate methods implemented.
+type validatorClient struct {
+	Client  validatorProtoClient
+	Logger  hclog.Logger
+	Broker  *plugin.GRPCBroker
+	Mappers []*argmapper.Func
+}
+
+func (c *validatorClient) Implements(ctx context.Context) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	resp, err := c.Client.IsValidator(ctx, &empty.Empty{})
+	if err != nil {
+		// Plugins built against older SDKs don't have the IsValidator
+		// RPC at all, which just means they don't implement it.
+		if status.Code(err) == codes.Unimplemented {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return resp.Implements, nil
+}
+
+func (c *validatorClient) ValidateFunc() interface{} {
+	impl, err := c.Implements(context.Background())
+	if err != nil {
+		return funcErr(err)
+	}
+	if !impl {
+		return nil
+	}
+
+	// Get the spec
+	spec, err := c.Client.ValidateSpec(context.Background(), &empty.Empty{})
+	if err != nil {
+		return funcErr(err)
+	}
+
+	// We don't want to be a mapper
+	spec.Result = nil
+
+	return funcspec.Func(spec, c.validate,
+		argmapper.Logger(c.Logger),
+		argmapper.Typed(&pluginargs.Internal{
+			Broker:  c.Broker,
+			Mappers: c.Mappers,
+			Cleanup: &pluginargs.Cleanup{},
+		}),
+	)
+}
+
+func (c *validatorClient) validate(
+	ctx context.Context,
+	args funcspec.Args,
+	internal *pluginargs.Internal,
+) (*pb.ValidationResult, error) {
+	// Run the cleanup
+	defer internal.Cleanup.Close()
+
+	// Call our function
+	return c.Client.Validate(ctx, &pb.FuncSpec_Args{Args: args})
+}
+
+// validatorServer implements the common Validator-related RPC calls.
+// This should be embedded into the service implementation.
+type validatorServer struct {
+	*base
+	Impl interface{}
+}
+
+func (s *validatorServer) IsValidator(
+	ctx context.Context,
+	empty *empty.Empty,
+) (*pb.ImplementsResp, error) {
+	v, ok := s.Impl.(component.Validator)
+	return &pb.ImplementsResp{
+		Implements: ok && v.ValidateFunc() != nil,
+	}, nil
+}
+
+func (s *validatorServer) ValidateSpec(
+	ctx context.Context,
+	args *empty.Empty,
+) (*pb.FuncSpec, error) {
+	v, ok := s.Impl.(component.Validator)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: Validator")
+	}
+
+	return funcspec.Spec(v.ValidateFunc(),
+		argmapper.ConverterFunc(s.Mappers...),
+		argmapper.Logger(s.Logger),
+		argmapper.Typed(s.internal()),
+	)
+}
+
+func (s *validatorServer) Validate(
+	ctx context.Context,
+	args *pb.FuncSpec_Args,
+) (*pb.ValidationResult, error) {
+	v, ok := s.Impl.(component.Validator)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "plugin does not implement: Validator")
+	}
+
+	internal := s.internal()
+	defer internal.Cleanup.Close()
+
+	raw, err := callDynamicFunc2(ctx, v.ValidateFunc(), args.Args,
+		argmapper.ConverterFunc(internal.Mappers...),
+		argmapper.Typed(internal),
+		argmapper.Typed(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// A nil result means there were no problems.
+	if raw == nil {
+		return &pb.ValidationResult{}, nil
+	}
+
+	result, ok := raw.(*pb.ValidationResult)
+	if !ok {
+		return nil, status.Errorf(codes.Aborted, "validate result is not a *proto.ValidationResult")
+	}
+	if result == nil {
+		result = &pb.ValidationResult{}
+	}
+
+	return result, nil
+}
+
+// validatorProtoClient is the interface we expect any gRPC service that
+// supports validation to implement.
+type validatorProtoClient interface {
+	IsValidator(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.ImplementsResp, error)
+	ValidateSpec(context.Context, *empty.Empty, ...grpc.CallOption) (*pb.FuncSpec, error)
+	Validate(context.Context, *pb.FuncSpec_Args, ...grpc.CallOption) (*pb.ValidationResult, error)
+}
+
+var (
+	_ component.Validator = (*validatorClient)(nil)
+)
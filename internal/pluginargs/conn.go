@@ -0,0 +1,95 @@
+package pluginargs
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pkg/tracing"
+)
+
+// ClientConn returns c with the call options and interceptors for the
+// connections dialed with Broker. Calls are traced with TracerProvider
+// and use the message size limit and compression of ServerSettings.
+// Broker.Dial doesn't accept dial options, so these are applied to each
+// call instead. This returns c as-is if i is nil or there is nothing to
+// apply.
+func (i *Internal) ClientConn(c *grpc.ClientConn) grpc.ClientConnInterface {
+	if i == nil {
+		return c
+	}
+
+	opts := i.ServerSettings.CallOptions()
+	unary, stream := tracing.ClientInterceptors(i.TracerProvider)
+	if len(opts) == 0 && unary == nil && stream == nil {
+		return c
+	}
+
+	return &clientConn{conn: c, opts: opts, unary: unary, stream: stream}
+}
+
+// clientConn adds call options and interceptors to the calls of a
+// connection.
+type clientConn struct {
+	conn   *grpc.ClientConn
+	opts   []grpc.CallOption
+	unary  grpc.UnaryClientInterceptor
+	stream grpc.StreamClientInterceptor
+}
+
+func (c *clientConn) Invoke(
+	ctx context.Context,
+	method string,
+	args, reply interface{},
+	opts ...grpc.CallOption,
+) error {
+	opts = c.callOptions(opts)
+	if c.unary == nil {
+		return c.conn.Invoke(ctx, method, args, reply, opts...)
+	}
+
+	return c.unary(ctx, method, args, reply, c.conn, invoke, opts...)
+}
+
+func (c *clientConn) NewStream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	method string,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	opts = c.callOptions(opts)
+	if c.stream == nil {
+		return c.conn.NewStream(ctx, desc, method, opts...)
+	}
+
+	return c.stream(ctx, desc, c.conn, method, newStream, opts...)
+}
+
+// callOptions returns our options followed by opts, so options given to
+// a call take precedence.
+func (c *clientConn) callOptions(opts []grpc.CallOption) []grpc.CallOption {
+	return append(append([]grpc.CallOption(nil), c.opts...), opts...)
+}
+
+// invoke and newStream call the connection for interceptors.
+func invoke(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	opts ...grpc.CallOption,
+) error {
+	return cc.Invoke(ctx, method, req, reply, opts...)
+}
+
+func newStream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	return cc.NewStream(ctx, desc, method, opts...)
+}
+
+var _ grpc.ClientConnInterface = (*clientConn)(nil)
@@ -0,0 +1,106 @@
+package pluginargs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestInternal_ClientConn(t *testing.T) {
+	require := require.New(t)
+
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		healthpb.RegisterHealthServer(s, health.NewServer())
+	})
+	defer conn.Close()
+	defer server.Stop()
+
+	// Without settings the connection is used as-is
+	var nilInternal *Internal
+	require.True(conn == nilInternal.ClientConn(conn))
+	require.True(conn == (&Internal{}).ClientConn(conn))
+
+	ctx := context.Background()
+	internal := &Internal{ServerSettings: &ServerSettings{Compression: true}}
+	client := healthpb.NewHealthClient(internal.ClientConn(conn))
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(err)
+
+	internal = &Internal{ServerSettings: &ServerSettings{MaxMessageSize: 1024}}
+	client = healthpb.NewHealthClient(internal.ClientConn(conn))
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(err)
+
+	// Requests larger than the limit fail before they're sent
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{
+		Service: strings.Repeat("a", 2048),
+	})
+	require.Equal(codes.ResourceExhausted, status.Code(err))
+}
+
+func TestInternal_ClientConnTracing(t *testing.T) {
+	require := require.New(t)
+
+	srv := &metadataHealthServer{}
+	conn, server := plugin.TestGRPCConn(t, func(s *grpc.Server) {
+		healthpb.RegisterHealthServer(s, srv)
+	})
+	defer conn.Close()
+	defer server.Stop()
+
+	internal := &Internal{TracerProvider: trace.NewNoopTracerProvider()}
+	client := healthpb.NewHealthClient(internal.ClientConn(conn))
+
+	// The trace context is sent to the server
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(),
+		trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0x01, 0x02, 0x03},
+			SpanID:     trace.SpanID{0x04, 0x05},
+			TraceFlags: trace.FlagsSampled,
+			Remote:     true,
+		}))
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(err)
+	require.Len(srv.md.Get("traceparent"), 1)
+	require.Contains(srv.md.Get("traceparent")[0], "01020300000000000000000000000000")
+
+	srv.md = nil
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(err)
+	_, err = stream.Recv()
+	require.NoError(err)
+	require.Len(srv.md.Get("traceparent"), 1)
+}
+
+// metadataHealthServer records the metadata of the last call.
+type metadataHealthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	md metadata.MD
+}
+
+func (s *metadataHealthServer) Check(
+	ctx context.Context,
+	req *healthpb.HealthCheckRequest,
+) (*healthpb.HealthCheckResponse, error) {
+	s.md, _ = metadata.FromIncomingContext(ctx)
+	return &healthpb.HealthCheckResponse{}, nil
+}
+
+func (s *metadataHealthServer) Watch(
+	req *healthpb.HealthCheckRequest,
+	stream healthpb.Health_WatchServer,
+) error {
+	s.md, _ = metadata.FromIncomingContext(stream.Context())
+	return stream.Send(&healthpb.HealthCheckResponse{})
+}
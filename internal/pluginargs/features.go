@@ -0,0 +1,55 @@
+package pluginargs
+
+import (
+	"sync"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+)
+
+// FeatureSet holds the features a plugin supports and the features that
+// were negotiated with the host. One FeatureSet is shared by the plugins
+// of a plugin set, so the features negotiated through the info plugin are
+// visible to the calls of every component.
+type FeatureSet struct {
+	// Supported are the features the plugin supports.
+	Supported []component.Feature
+
+	lock       sync.Mutex
+	negotiated *component.Features
+}
+
+// Negotiate enables the features that are supported by both the plugin
+// and the host, which supports host, and returns them.
+func (s *FeatureSet) Negotiate(host []component.Feature) *component.Features {
+	result := component.NewFeatures(s.Supported...).Intersect(host...)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.negotiated = result
+	return result
+}
+
+// SetNegotiated sets the negotiated features. Hosts use this to record
+// the features that were negotiated with the plugin, so the clients of
+// the plugin set can use them.
+func (s *FeatureSet) SetNegotiated(fs *component.Features) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.negotiated = fs
+}
+
+// Negotiated returns the negotiated features. This is empty if the host
+// hasn't negotiated, and is safe to call on a nil *FeatureSet.
+func (s *FeatureSet) Negotiated() *component.Features {
+	if s == nil {
+		return component.NewFeatures()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.negotiated == nil {
+		return component.NewFeatures()
+	}
+
+	return s.negotiated
+}
@@ -0,0 +1,159 @@
+package pluginargs
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/hashicorp/go-argmapper"
+)
+
+// MapperCache caches the results of mappers so that expensive conversions,
+// such as creating an API client from the plugin configuration, run once
+// even if the result is needed by multiple functions.
+//
+// A result is reused when a mapper is called again with equal inputs.
+// Inputs are compared with ==, so pointers are equal only if they point to
+// the same value. Calls with inputs that can't be compared, such as maps
+// and slices, and calls that return an error are never cached.
+//
+// The SDK creates a MapperCache for each component server, so results are
+// shared by the calls of an operation, such as a spec call followed by the
+// call itself. The cache is purged whenever the component is configured,
+// which the host does at the start of each operation, since results may
+// depend on the configuration, such as an API client created from it.
+// Results that depend on a single call, such as values that are closed by
+// Cleanup, are only reused by that call since their inputs, such as the
+// Internal or the context, differ between calls. At most mapperCacheSize
+// results are kept for each mapper, dropping the oldest first.
+type MapperCache struct {
+	mu      sync.Mutex
+	entries map[*argmapper.Func][]*mapperCacheEntry
+}
+
+// mapperCacheSize is the maximum number of results cached for each mapper.
+const mapperCacheSize = 16
+
+type mapperCacheEntry struct {
+	in  []reflect.Value
+	out []reflect.Value
+}
+
+// NewMapperCache returns an empty MapperCache.
+func NewMapperCache() *MapperCache {
+	return &MapperCache{entries: map[*argmapper.Func][]*mapperCacheEntry{}}
+}
+
+// Wrap returns mappers that call fs and cache their results in c. The
+// mappers have the same inputs and outputs as fs.
+func (c *MapperCache) Wrap(fs []*argmapper.Func) []*argmapper.Func {
+	result := make([]*argmapper.Func, len(fs))
+	for i, f := range fs {
+		result[i] = c.wrap(f)
+	}
+
+	return result
+}
+
+func (c *MapperCache) wrap(f *argmapper.Func) *argmapper.Func {
+	fv := reflect.ValueOf(f.Func())
+	ft := fv.Type()
+
+	// Only functions whose inputs can all be compared can be cached.
+	for i := 0; i < ft.NumIn(); i++ {
+		if !ft.In(i).Comparable() {
+			return f
+		}
+	}
+
+	wrapped := reflect.MakeFunc(ft, func(in []reflect.Value) []reflect.Value {
+		if out, ok := c.get(f, in); ok {
+			return out
+		}
+
+		out := fv.Call(in)
+		if !failed(ft, out) {
+			c.put(f, in, out)
+		}
+
+		return out
+	})
+
+	// The wrapped function has the same signature, so this can only fail
+	// if f couldn't have been created in the first place.
+	result, err := argmapper.NewFunc(wrapped.Interface(), argmapper.FuncName(f.Name()))
+	if err != nil {
+		return f
+	}
+
+	return result
+}
+
+// Purge removes all cached results.
+func (c *MapperCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[*argmapper.Func][]*mapperCacheEntry{}
+}
+
+func (c *MapperCache) get(f *argmapper.Func, in []reflect.Value) ([]reflect.Value, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range c.entries[f] {
+		if equalValues(e.in, in) {
+			return e.out, true
+		}
+	}
+
+	return nil, false
+}
+
+func (c *MapperCache) put(f *argmapper.Func, in, out []reflect.Value) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := append(c.entries[f], &mapperCacheEntry{
+		in:  append([]reflect.Value(nil), in...),
+		out: out,
+	})
+	if len(entries) > mapperCacheSize {
+		entries = entries[len(entries)-mapperCacheSize:]
+	}
+	c.entries[f] = entries
+}
+
+// failed returns true if the last result of a call to a function of type
+// ft is a non-nil error.
+func failed(ft reflect.Type, out []reflect.Value) bool {
+	n := ft.NumOut()
+	if n == 0 || ft.Out(n-1) != errorType {
+		return false
+	}
+
+	return !out[n-1].IsNil()
+}
+
+// equalValues returns true if the values of a and b are equal with ==.
+func equalValues(a, b []reflect.Value) (equal bool) {
+	// Types that can be compared can still hold values that can't, such
+	// as an interface holding a map, which panics.
+	defer func() {
+		if recover() != nil {
+			equal = false
+		}
+	}()
+
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Interface() != b[i].Interface() {
+			return false
+		}
+	}
+
+	return true
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
@@ -0,0 +1,122 @@
+package pluginargs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct{ Addr string }
+type testClient struct{ Addr string }
+
+func TestMapperCache(t *testing.T) {
+	require := require.New(t)
+
+	calls := 0
+	mapper, err := argmapper.NewFunc(func(c *testConfig) *testClient {
+		calls++
+		return &testClient{Addr: c.Addr}
+	})
+	require.NoError(err)
+
+	mappers := NewMapperCache().Wrap([]*argmapper.Func{mapper})
+	require.Equal(mapper.Name(), mappers[0].Name())
+
+	target := func(c *testClient) string { return c.Addr }
+	cfg := &testConfig{Addr: "a"}
+	for i := 0; i < 3; i++ {
+		f, err := argmapper.NewFunc(target)
+		require.NoError(err)
+
+		result := f.Call(argmapper.ConverterFunc(mappers...), argmapper.Typed(cfg))
+		require.NoError(result.Err())
+		require.Equal("a", result.Out(0))
+	}
+	require.Equal(1, calls)
+
+	// Different inputs aren't cached together
+	f, err := argmapper.NewFunc(target)
+	require.NoError(err)
+	result := f.Call(argmapper.ConverterFunc(mappers...), argmapper.Typed(&testConfig{Addr: "b"}))
+	require.NoError(result.Err())
+	require.Equal("b", result.Out(0))
+	require.Equal(2, calls)
+}
+
+func TestMapperCache_errors(t *testing.T) {
+	require := require.New(t)
+
+	calls := 0
+	mapper, err := argmapper.NewFunc(func(c *testConfig) (*testClient, error) {
+		calls++
+		return nil, errors.New("boom")
+	})
+	require.NoError(err)
+
+	wrapped := NewMapperCache().Wrap([]*argmapper.Func{mapper})[0]
+	cfg := &testConfig{}
+	var result argmapper.Result
+	result = wrapped.Call(argmapper.Typed(cfg))
+	require.Error(result.Err())
+	result = wrapped.Call(argmapper.Typed(cfg))
+	require.Error(result.Err())
+	require.Equal(2, calls)
+}
+
+func TestMapperCache_notComparable(t *testing.T) {
+	require := require.New(t)
+
+	// Inputs that can't be compared are never cached
+	mapper, err := argmapper.NewFunc(func(m map[string]string) *testClient { return nil })
+	require.NoError(err)
+	require.Same(mapper, NewMapperCache().Wrap([]*argmapper.Func{mapper})[0])
+
+	// Nor are comparable types holding values that can't be compared
+	calls := 0
+	mapper, err = argmapper.NewFunc(func(v interface{}) *testClient {
+		calls++
+		return nil
+	})
+	require.NoError(err)
+
+	wrapped := NewMapperCache().Wrap([]*argmapper.Func{mapper})[0]
+	in := map[string]string{}
+	var result argmapper.Result
+	result = wrapped.Call(argmapper.Typed(in))
+	require.NoError(result.Err())
+	result = wrapped.Call(argmapper.Typed(in))
+	require.NoError(result.Err())
+	require.Equal(2, calls)
+}
+
+func TestMapperCache_size(t *testing.T) {
+	require := require.New(t)
+
+	calls := 0
+	mapper, err := argmapper.NewFunc(func(c *testConfig) *testClient {
+		calls++
+		return &testClient{Addr: c.Addr}
+	})
+	require.NoError(err)
+
+	wrapped := NewMapperCache().Wrap([]*argmapper.Func{mapper})[0]
+	cfgs := make([]*testConfig, mapperCacheSize+1)
+	for i := range cfgs {
+		cfgs[i] = &testConfig{}
+		result := wrapped.Call(argmapper.Typed(cfgs[i]))
+		require.NoError(result.Err())
+	}
+	require.Equal(len(cfgs), calls)
+
+	// The most recent results are kept
+	result := wrapped.Call(argmapper.Typed(cfgs[len(cfgs)-1]))
+	require.NoError(result.Err())
+	require.Equal(len(cfgs), calls)
+
+	// The oldest was dropped
+	result = wrapped.Call(argmapper.Typed(cfgs[0]))
+	require.NoError(result.Err())
+	require.Equal(len(cfgs)+1, calls)
+}
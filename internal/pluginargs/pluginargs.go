@@ -4,6 +4,12 @@ package pluginargs
 import (
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-plugin"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pkg/tracing"
 )
 
 // Internal is a struct that is available to mappers. This is an internal-only
@@ -13,6 +19,126 @@ type Internal struct {
 	Broker  *plugin.GRPCBroker
 	Mappers []*argmapper.Func
 	Cleanup *Cleanup
+
+	// MapperCache caches the results of Mappers for the operation. On the
+	// plugin side, Mappers are wrapped with it so expensive conversions
+	// run once per operation. This is nil if results aren't cached.
+	MapperCache *MapperCache
+
+	// ProtocolVersion is the protocol version negotiated with the host.
+	// This is only set on the plugin side and is zero if unknown.
+	ProtocolVersion int
+
+	// Features are the features of the plugin and the features negotiated
+	// with the host. This is only set on the plugin side and may be nil.
+	Features *FeatureSet
+
+	// TracerProvider traces the calls to the servers started with Broker,
+	// such as for the terminal.UI. This is nil if tracing is disabled.
+	TracerProvider trace.TracerProvider
+
+	// UnaryInterceptors and StreamInterceptors are the interceptors set by
+	// the plugin author for the servers started with Broker.
+	UnaryInterceptors  []grpc.UnaryServerInterceptor
+	StreamInterceptors []grpc.StreamServerInterceptor
+
+	// ServerSettings are the settings for the servers started with Broker. This
+	// is nil if the defaults are used.
+	ServerSettings *ServerSettings
+}
+
+// ServerOptions returns the options for the gRPC servers started with
+// Broker in addition to the options given by the broker.
+func (i *Internal) ServerOptions() []grpc.ServerOption {
+	opts := tracing.ServerOptions(i.TracerProvider)
+	if len(i.UnaryInterceptors) > 0 {
+		opts = append(opts, grpc.ChainUnaryInterceptor(i.UnaryInterceptors...))
+	}
+	if len(i.StreamInterceptors) > 0 {
+		opts = append(opts, grpc.ChainStreamInterceptor(i.StreamInterceptors...))
+	}
+
+	return append(opts, i.ServerSettings.Options()...)
+}
+
+// NewServer returns a new gRPC server for AcceptAndServe of Broker with
+// the options from the broker, opts, in addition to ServerOptions.
+func (i *Internal) NewServer(opts []grpc.ServerOption) *grpc.Server {
+	server := plugin.DefaultGRPCServer(append(opts, i.ServerOptions()...))
+	i.ServerSettings.Register(server)
+	return server
+}
+
+// ServerSettings are the settings for the gRPC servers of a plugin, both
+// the server for the host and the servers started with the broker.
+type ServerSettings struct {
+	// MaxMessageSize is the maximum size in bytes of the messages the
+	// servers send and receive. This also limits the calls to the servers
+	// the host starts with the broker, so both sides of a call use the
+	// same limit. If this is zero, the gRPC defaults are used, which limit
+	// received messages to 4MB.
+	MaxMessageSize int
+
+	// Compression compresses the requests to the servers the host starts
+	// with the broker with gzip. Servers always accept requests compressed
+	// with gzip and compress their responses to them.
+	Compression bool
+
+	// Reflection registers the gRPC reflection service on the servers so
+	// tools such as grpcurl can list and call their services. This is
+	// meant for debugging.
+	Reflection bool
+}
+
+// Options returns the gRPC server options for the settings. This returns
+// no options if s is nil.
+func (s *ServerSettings) Options() []grpc.ServerOption {
+	if s == nil {
+		return nil
+	}
+
+	var opts []grpc.ServerOption
+	if s.MaxMessageSize > 0 {
+		opts = append(opts,
+			grpc.MaxRecvMsgSize(s.MaxMessageSize),
+			grpc.MaxSendMsgSize(s.MaxMessageSize),
+		)
+	}
+
+	return opts
+}
+
+// CallOptions returns the gRPC call options for the calls to the servers
+// the host starts with the broker. This returns no options if s is nil.
+func (s *ServerSettings) CallOptions() []grpc.CallOption {
+	if s == nil {
+		return nil
+	}
+
+	var opts []grpc.CallOption
+	if s.MaxMessageSize > 0 {
+		opts = append(opts,
+			grpc.MaxCallRecvMsgSize(s.MaxMessageSize),
+			grpc.MaxCallSendMsgSize(s.MaxMessageSize),
+		)
+	}
+	if s.Compression {
+		opts = append(opts, grpc.UseCompressor(gzip.Name))
+	}
+
+	return opts
+}
+
+// Register registers the services for the settings on server, such as
+// the reflection service. This does nothing if s is nil.
+func (s *ServerSettings) Register(server *grpc.Server) {
+	if s == nil {
+		return
+	}
+
+	if s.Reflection {
+		reflection.Register(server)
+	}
 }
 
 // Cleanup can be used to register cleanup functions.
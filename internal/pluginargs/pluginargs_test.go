@@ -0,0 +1,41 @@
+package pluginargs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerSettings(t *testing.T) {
+	var nilSettings *ServerSettings
+	require.Empty(t, nilSettings.Options())
+	require.Empty(t, (&ServerSettings{}).Options())
+	require.Len(t, (&ServerSettings{MaxMessageSize: 16 << 20}).Options(), 2)
+	require.Empty(t, (&ServerSettings{Compression: true}).Options())
+}
+
+func TestServerSettings_CallOptions(t *testing.T) {
+	var nilSettings *ServerSettings
+	require.Empty(t, nilSettings.CallOptions())
+	require.Empty(t, (&ServerSettings{}).CallOptions())
+	require.Len(t, (&ServerSettings{MaxMessageSize: 16 << 20}).CallOptions(), 2)
+	require.Len(t, (&ServerSettings{Compression: true}).CallOptions(), 1)
+}
+
+func TestInternal_ServerOptions(t *testing.T) {
+	require.Empty(t, (&Internal{}).ServerOptions())
+
+	internal := &Internal{ServerSettings: &ServerSettings{MaxMessageSize: 16 << 20}}
+	require.Len(t, internal.ServerOptions(), 2)
+}
+
+func TestInternal_NewServer(t *testing.T) {
+	const reflectionService = "grpc.reflection.v1alpha.ServerReflection"
+
+	server := (&Internal{}).NewServer(nil)
+	require.NotContains(t, server.GetServiceInfo(), reflectionService)
+
+	internal := &Internal{ServerSettings: &ServerSettings{Reflection: true}}
+	server = internal.NewServer(nil)
+	require.Contains(t, server.GetServiceInfo(), reflectionService)
+}
@@ -7,6 +7,8 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
+	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
 
 // Artifact implements component.Artifact.
@@ -15,6 +17,8 @@ type Artifact struct {
 	AnyJson     string
 	LabelsVal   map[string]string
 	TemplateVal map[string]interface{}
+	EventsVal   []*pb.EventLog_Event
+	WarningsVal diag.Diagnostics
 }
 
 func (c *Artifact) Proto() proto.Message { return c.Any }
@@ -23,10 +27,16 @@ func (c *Artifact) Labels() map[string]string { return c.LabelsVal }
 
 func (c *Artifact) TemplateData() map[string]interface{} { return c.TemplateVal }
 
+func (c *Artifact) Events() []*pb.EventLog_Event { return c.EventsVal }
+
+func (c *Artifact) Warnings() diag.Diagnostics { return c.WarningsVal }
+
 func (c *Artifact) MarshalJSON() ([]byte, error) { return []byte(c.AnyJson), nil }
 
 var (
-	_ component.Artifact = (*Artifact)(nil)
-	_ component.Template = (*Artifact)(nil)
-	_ json.Marshaler     = (*Artifact)(nil)
+	_ component.Artifact     = (*Artifact)(nil)
+	_ component.Template     = (*Artifact)(nil)
+	_ component.EventTrail   = (*Artifact)(nil)
+	_ component.WarningTrail = (*Artifact)(nil)
+	_ json.Marshaler         = (*Artifact)(nil)
 )
@@ -7,6 +7,7 @@ import (
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -16,6 +17,8 @@ type Deployment struct {
 	AnyJson     string
 	Deployment  *pb.Deploy
 	TemplateVal map[string]interface{}
+	EventsVal   []*pb.EventLog_Event
+	WarningsVal diag.Diagnostics
 }
 
 func (c *Deployment) Proto() proto.Message { return c.Any }
@@ -28,10 +31,16 @@ func (c *Deployment) URL() string {
 func (c *Deployment) String() string                       { return "" }
 func (c *Deployment) TemplateData() map[string]interface{} { return c.TemplateVal }
 
+func (c *Deployment) Events() []*pb.EventLog_Event { return c.EventsVal }
+
+func (c *Deployment) Warnings() diag.Diagnostics { return c.WarningsVal }
+
 func (c *Deployment) MarshalJSON() ([]byte, error) { return []byte(c.AnyJson), nil }
 
 var (
-	_ component.Deployment = (*Deployment)(nil)
-	_ component.Template   = (*Deployment)(nil)
-	_ json.Marshaler       = (*Deployment)(nil)
+	_ component.Deployment   = (*Deployment)(nil)
+	_ component.Template     = (*Deployment)(nil)
+	_ component.EventTrail   = (*Deployment)(nil)
+	_ component.WarningTrail = (*Deployment)(nil)
+	_ json.Marshaler         = (*Deployment)(nil)
 )
@@ -7,6 +7,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/diag"
 	pb "github.com/hashicorp/waypoint-plugin-sdk/proto/gen"
 )
 
@@ -16,15 +17,22 @@ type Release struct {
 	AnyJson     string
 	Release     *pb.Release
 	TemplateVal map[string]interface{}
+	EventsVal   []*pb.EventLog_Event
+	WarningsVal diag.Diagnostics
 }
 
 func (c *Release) Proto() proto.Message                 { return c.Any }
 func (c *Release) URL() string                          { return c.Release.Url }
 func (c *Release) TemplateData() map[string]interface{} { return c.TemplateVal }
-func (c *Release) MarshalJSON() ([]byte, error)         { return []byte(c.AnyJson), nil }
+func (c *Release) Events() []*pb.EventLog_Event         { return c.EventsVal }
+
+func (c *Release) Warnings() diag.Diagnostics   { return c.WarningsVal }
+func (c *Release) MarshalJSON() ([]byte, error) { return []byte(c.AnyJson), nil }
 
 var (
-	_ component.Release  = (*Release)(nil)
-	_ component.Template = (*Release)(nil)
-	_ json.Marshaler     = (*Release)(nil)
+	_ component.Release      = (*Release)(nil)
+	_ component.Template     = (*Release)(nil)
+	_ component.EventTrail   = (*Release)(nil)
+	_ component.WarningTrail = (*Release)(nil)
+	_ json.Marshaler         = (*Release)(nil)
 )
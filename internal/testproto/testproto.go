@@ -2,4 +2,4 @@
 // in internal tests.
 package testproto
 
-//go:generate sh -c "protoc -I ./ ./*.proto --go_out=plugins=grpc:./"
+//go:generate sh -c "protoc -I`go list -m -f \"{{.Dir}}\" github.com/hashicorp/opaqueany` -I ./ ./*.proto --go_out=plugins=grpc:./"
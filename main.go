@@ -16,9 +16,15 @@ import (
 	"github.com/hashicorp/go-argmapper"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal-shared/protomappers"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pkg/tracing"
 	sdkplugin "github.com/hashicorp/waypoint-plugin-sdk/internal/plugin"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/plugin/recording"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/pluginargs"
 	"github.com/hashicorp/waypoint-plugin-sdk/internal/stdio"
 )
 
@@ -32,6 +38,20 @@ func Main(opts ...Option) {
 
 	var c config
 
+	// The host sets this when it only needs the plugin for status checks.
+	if os.Getenv(EnvStatusOnly) != "" {
+		c.StatusOnly = true
+	}
+
+	// Developers set this to inspect the plugin with grpcurl.
+	if os.Getenv(EnvReflection) != "" {
+		c.Reflection = true
+	}
+
+	// Developers set these to record a real run and replay it in tests.
+	c.RecordPath = os.Getenv(EnvRecord)
+	c.ReplayPath = os.Getenv(EnvReplay)
+
 	// Default our mappers
 	c.Mappers = append(c.Mappers, protomappers.All...)
 
@@ -62,6 +82,32 @@ func Main(opts ...Option) {
 	})
 	hclog.SetDefault(log)
 
+	// Record or replay the dynamic function calls. This is the last
+	// interceptor so the others see the calls as usual.
+	switch {
+	case c.RecordPath != "" && c.ReplayPath != "":
+		panic("plugin calls can't be both recorded and replayed")
+
+	case c.RecordPath != "":
+		recorder, err := recording.NewRecorder(c.RecordPath)
+		if err != nil {
+			panic(err)
+		}
+		defer recorder.Close()
+
+		log.Info("recording plugin calls", "path", c.RecordPath)
+		c.UnaryInterceptors = append(c.UnaryInterceptors, recorder.UnaryInterceptor())
+
+	case c.ReplayPath != "":
+		replayer, err := recording.NewReplayer(c.ReplayPath)
+		if err != nil {
+			panic(err)
+		}
+
+		log.Info("replaying recorded plugin calls", "path", c.ReplayPath)
+		c.UnaryInterceptors = append(c.UnaryInterceptors, replayer.UnaryInterceptor())
+	}
+
 	// Build up our mappers
 	var mappers []*argmapper.Func
 	for _, raw := range c.Mappers {
@@ -78,29 +124,198 @@ func Main(opts ...Option) {
 		mappers = append(mappers, m)
 	}
 
+	// In status-only mode we only allow the status-related RPCs.
+	// The standard gRPC health service reports the health of the components.
+	// Calls are traced first so that their spans include the other options.
+	health := sdkplugin.NewHealthServer(log.Named("health"))
+	serverOpts := append(tracing.ServerOptions(c.TracerProvider), sdkplugin.HealthServerOptions(health)...)
+	if c.StatusOnly {
+		log.Debug("serving in status-only mode")
+		serverOpts = append(serverOpts, sdkplugin.StatusOnlyServerOptions()...)
+	}
+	if len(c.UnaryInterceptors) > 0 {
+		serverOpts = append(serverOpts, grpc.ChainUnaryInterceptor(c.UnaryInterceptors...))
+	}
+	if len(c.StreamInterceptors) > 0 {
+		serverOpts = append(serverOpts, grpc.ChainStreamInterceptor(c.StreamInterceptors...))
+	}
+	var serverSettings *pluginargs.ServerSettings
+	if c.MaxMessageSize > 0 || c.Compression || c.Reflection {
+		serverSettings = &pluginargs.ServerSettings{
+			MaxMessageSize: c.MaxMessageSize,
+			Compression:    c.Compression,
+			Reflection:     c.Reflection,
+		}
+		serverOpts = append(serverOpts, serverSettings.Options()...)
+	}
+	if c.Reflection {
+		log.Info("gRPC reflection is enabled for the brokered servers")
+	}
+	grpcServer := func(opts []grpc.ServerOption) *grpc.Server {
+		return plugin.DefaultGRPCServer(append(opts, serverOpts...))
+	}
+
+	pluginOpts := []sdkplugin.Option{
+		sdkplugin.WithComponents(c.Components...),
+		sdkplugin.WithMappers(mappers...),
+		sdkplugin.WithLogger(log),
+		sdkplugin.WithInfo(c.pluginName(), c.pluginVersion()),
+		sdkplugin.WithCommit(c.pluginCommit()),
+		sdkplugin.WithHealthServer(health),
+		sdkplugin.WithContracts(c.Contracts...),
+		sdkplugin.WithFeatures(c.Features...),
+	}
+	for name, p := range c.Plugins {
+		pluginOpts = append(pluginOpts, sdkplugin.WithPlugin(name, p))
+	}
+	if c.StrictSpecs {
+		pluginOpts = append(pluginOpts, sdkplugin.WithStrictSpecs())
+	}
+	if c.TracerProvider != nil {
+		pluginOpts = append(pluginOpts, sdkplugin.WithTracerProvider(c.TracerProvider))
+	}
+	if len(c.UnaryInterceptors) > 0 || len(c.StreamInterceptors) > 0 {
+		pluginOpts = append(pluginOpts, sdkplugin.WithInterceptors(c.UnaryInterceptors, c.StreamInterceptors))
+	}
+	if serverSettings != nil {
+		pluginOpts = append(pluginOpts, sdkplugin.WithServerSettings(serverSettings))
+	}
+	if c.StatusOnly {
+		pluginOpts = append(pluginOpts, sdkplugin.WithStatusOnly())
+	}
+	for v, cs := range c.VersionedComponents {
+		pluginOpts = append(pluginOpts, sdkplugin.WithVersionedComponents(v, cs...))
+	}
+	for _, nc := range c.NamedComponents {
+		pluginOpts = append(pluginOpts, sdkplugin.WithNamedComponent(nc.Name, nc.Component))
+	}
+
+	// The manifest command prints what we serve rather than serving it.
+	if c.TestConfig == nil && len(os.Args) == 2 && os.Args[1] == ManifestCommand {
+		if err := sdkplugin.WriteManifest(os.Stdout, pluginOpts...); err != nil {
+			fmt.Fprintf(os.Stderr, "error writing manifest: %s\n", err)
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
 	// Serve
 	plugin.Serve(&plugin.ServeConfig{
-		HandshakeConfig: sdkplugin.Handshake,
-		VersionedPlugins: sdkplugin.Plugins(
-			sdkplugin.WithComponents(c.Components...),
-			sdkplugin.WithMappers(mappers...),
-			sdkplugin.WithLogger(log),
-		),
-		GRPCServer: plugin.DefaultGRPCServer,
-		Logger:     log,
-		Test:       c.TestConfig,
+		HandshakeConfig:  sdkplugin.Handshake,
+		VersionedPlugins: sdkplugin.Plugins(pluginOpts...),
+		GRPCServer:       grpcServer,
+		Logger:           log,
+		Test:             c.TestConfig,
 	})
 }
 
+// MainStatusOnly is like Main but serves only the Status capability of
+// the given components. Components that don't support status aren't
+// initialized or served, nor are the plugins for their component types or
+// custom component types, and only the status-related RPCs of the others
+// can be called. Hosts detect this mode by negotiating
+// component.FeatureStatusOnly. This is meant for lightweight binaries that
+// are only used to poll the status of resources, for example by importing
+// only the packages required for status checks. Main also serves in this
+// mode if the host sets the EnvStatusOnly environment variable when
+// scheduling status jobs.
+func MainStatusOnly(opts ...Option) {
+	Main(append(opts, func(c *config) { c.StatusOnly = true })...)
+}
+
+// EnvStatusOnly is the environment variable the host sets to request that
+// the plugin serve in status-only mode. See MainStatusOnly.
+const EnvStatusOnly = "WAYPOINT_PLUGIN_STATUS_ONLY"
+
+// EnvRecord and EnvReplay are the environment variables that set the file
+// the dynamic function calls of the plugin are recorded to or replayed
+// from. See WithRecording and WithReplay.
+const (
+	EnvRecord = "WAYPOINT_PLUGIN_RECORD"
+	EnvReplay = "WAYPOINT_PLUGIN_REPLAY"
+)
+
+// EnvReflection is the environment variable that enables the gRPC
+// reflection service on the brokered servers of the plugin when set. See
+// WithReflection.
+const EnvReflection = "WAYPOINT_PLUGIN_GRPC_REFLECTION"
+
+// ManifestCommand is the argument that makes Main print the plugin
+// manifest as JSON and exit instead of serving the plugin. The manifest
+// lists the name and version of the plugin and the components served
+// for each protocol version, the same information hosts get from the info
+// plugin.
+const ManifestCommand = "manifest"
+
 // config is the configuration for Main. This can only be modified using
 // Option implementations.
 type config struct {
 	// Components is the list of components to serve from the plugin.
 	Components []interface{}
 
+	// VersionedComponents are the components to serve for specific
+	// protocol versions.
+	VersionedComponents map[int][]interface{}
+
+	// NamedComponents are the components to serve by name, in addition
+	// to the components for each protocol version.
+	NamedComponents []*namedComponent
+
 	// Mappers is the list of mapper functions.
 	Mappers []interface{}
 
+	// Plugins are the plugins for custom component types by name.
+	Plugins map[string]plugin.Plugin
+
+	// StatusOnly serves only the Status capability of the components.
+	StatusOnly bool
+
+	// StrictSpecs checks the functions of the components on startup.
+	StrictSpecs bool
+
+	// Name, Version and Commit are reported to hosts by the info plugin.
+	// If these are empty, the values set at build time are used.
+	Name    string
+	Version string
+	Commit  string
+
+	// Contracts are the types the components exchange with other
+	// components, reported by the info plugin.
+	Contracts []component.Contract
+
+	// Features are the optional protocol features the plugin supports.
+	Features []component.Feature
+
+	// TracerProvider traces the calls to the plugin if set.
+	TracerProvider trace.TracerProvider
+
+	// UnaryInterceptors and StreamInterceptors are added to the plugin
+	// server and the servers the plugin starts with the broker.
+	UnaryInterceptors  []grpc.UnaryServerInterceptor
+	StreamInterceptors []grpc.StreamServerInterceptor
+
+	// MaxMessageSize and Compression configure the plugin server and the
+	// servers the plugin starts with the broker.
+	MaxMessageSize int
+	Compression    bool
+
+	// Reflection registers the gRPC reflection service on the servers the
+	// plugin starts with the broker.
+	Reflection bool
+
+	// RecordPath and ReplayPath are the files the dynamic function calls
+	// are recorded to or replayed from, if set.
+	RecordPath string
+	ReplayPath string
+
+	// DebugAddr is the TCP address DebugServe accepts connections on.
+	// If this is empty the go-plugin default is used.
+	DebugAddr string
+
+	// ReattachFile is the path Debug writes the reattach config to.
+	ReattachFile string
+
 	// TestConfig should only be set when the plugin is being tested; it
 	// will opt out of go-plugin's lifecycle management and other features,
 	// and will use the supplied configuration options to control the
@@ -114,11 +329,107 @@ type Option func(*config)
 
 // WithComponents specifies a list of components to serve from the plugin
 // binary. This will append to the list of components to serve. You can
-// currently only serve at most one of each type of plugin.
+// only serve at most one of each type of plugin this way; use
+// WithNamedComponent to serve more.
 func WithComponents(cs ...interface{}) Option {
 	return func(c *config) { c.Components = append(c.Components, cs...) }
 }
 
+// WithNamedComponent specifies a component to serve from the plugin binary
+// with the given name, in addition to the components from WithComponents.
+// This allows a single binary to serve several components of the same
+// type, for example:
+//
+//	sdk.Main(
+//		sdk.WithNamedComponent("docker", &docker.Builder{}),
+//		sdk.WithNamedComponent("pack", &pack.Builder{}),
+//	)
+//
+// The component is served for each component type it implements. Names
+// may only contain letters, digits, underscores and dashes, and must be
+// unique per component type. Named components are served for every
+// protocol version.
+func WithNamedComponent(name string, component interface{}) Option {
+	return func(c *config) {
+		c.NamedComponents = append(c.NamedComponents, &namedComponent{
+			Name:      name,
+			Component: component,
+		})
+	}
+}
+
+// namedComponent is a component from WithNamedComponent.
+type namedComponent struct {
+	Name      string
+	Component interface{}
+}
+
+// WithVersionedComponents specifies a list of components to serve to hosts
+// that negotiate the given plugin protocol version, instead of the
+// components given to WithComponents. This lets a plugin adopt new RPCs
+// for newer hosts while remaining installable on older ones. Components
+// can also request a *component.ProtocolVersion argument to check the
+// negotiated version.
+func WithVersionedComponents(version int, cs ...interface{}) Option {
+	return func(c *config) {
+		if c.VersionedComponents == nil {
+			c.VersionedComponents = map[int][]interface{}{}
+		}
+
+		c.VersionedComponents[version] = append(c.VersionedComponents[version], cs...)
+	}
+}
+
+// WithContracts registers the protobuf message types the components of
+// the plugin exchange with other components, with human-readable names:
+//
+//	sdk.Main(
+//		sdk.WithComponents(&Builder{}, &Registry{}),
+//		sdk.WithContracts(component.NewContract("DockerImage", (*Image)(nil))),
+//	)
+//
+// The info plugin reports the registered types the main operation of each
+// component returns and accepts, such as the result of BuildFunc and the
+// arguments of PushFunc. Hosts can check with component.CheckChain that a
+// component can accept the output of the previous one when they configure
+// an app, rather than failing when the operation runs.
+func WithContracts(cs ...component.Contract) Option {
+	return func(c *config) { c.Contracts = append(c.Contracts, cs...) }
+}
+
+// WithFeatures specifies the optional protocol features the plugin
+// supports in addition to the ones the SDK implements itself, such as
+// component.FeatureLargeValues. When Waypoint connects it negotiates the
+// features it also supports and only those are enabled. Components
+// request a *component.Features argument to check them:
+//
+//	func (p *Platform) deploy(features *component.Features) (*Deployment, error) {
+//		if features.Enabled("example-feature") {
+//			...
+//		}
+//	}
+//
+// This lets a plugin adopt new behavior for new hosts without checking
+// protocol versions or probing for RPCs.
+func WithFeatures(fs ...component.Feature) Option {
+	return func(c *config) { c.Features = append(c.Features, fs...) }
+}
+
+// WithComponentType serves the plugin p for a custom component type with
+// the given name, which hosts use to dispense it. The components given to
+// WithComponents are set on the fields of p that they can be assigned to,
+// as are the mappers and logger. See the componentkit package for how to
+// define custom component types.
+func WithComponentType(name string, p plugin.Plugin) Option {
+	return func(c *config) {
+		if c.Plugins == nil {
+			c.Plugins = map[string]plugin.Plugin{}
+		}
+
+		c.Plugins[name] = p
+	}
+}
+
 // WithMappers specifies a list of mappers to apply to the plugin.
 //
 // Mappers are functions that take zero or more arguments and return
@@ -140,6 +451,135 @@ func WithMappers(ms ...interface{}) Option {
 	return func(c *config) { c.Mappers = append(c.Mappers, ms...) }
 }
 
+// WithStrictSpecs makes the plugin check the functions of every component
+// when it starts, such as the result of BuildFunc. If a function has
+// arguments that aren't proto messages or primitives and can't be converted
+// from them with the mappers, the plugin panics with an error listing those
+// arguments and the mappers that were tried. Without this, the host gets
+// an error only when it runs the operation. This is recommended while
+// developing a plugin.
+func WithStrictSpecs() Option {
+	return func(c *config) { c.StrictSpecs = true }
+}
+
+// WithTracerProvider makes the plugin trace the calls it serves with tp.
+// The trace context sent by the host in the gRPC metadata is the parent of
+// the spans, using the W3C trace context and baggage formats, so plugin
+// operations show up in the traces of the host. This includes the calls to
+// the servers the plugin starts for arguments such as the terminal.UI.
+//
+// The context.Context given to the functions of components has the span of
+// the call, so they can start child spans, for example:
+//
+//	func (b *Builder) build(ctx context.Context) (*Binary, error) {
+//		ctx, span := trace.SpanFromContext(ctx).TracerProvider().Tracer("builder").Start(ctx, "compile")
+//		defer span.End()
+//		...
+//	}
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.TracerProvider = tp }
+}
+
+// WithUnaryInterceptor adds interceptors for the unary RPCs served by the
+// plugin, such as for request logging, rate limiting or checking auth
+// tokens. This will append to the list of interceptors, which are called
+// in order.
+//
+// The interceptors are added to the plugin server and to the servers the
+// plugin starts for arguments such as the terminal.UI. On the plugin server
+// they also see the RPCs of go-plugin itself, such as for the broker, but
+// not the health checks, which are served before the interceptors run.
+func WithUnaryInterceptor(is ...grpc.UnaryServerInterceptor) Option {
+	return func(c *config) { c.UnaryInterceptors = append(c.UnaryInterceptors, is...) }
+}
+
+// WithStreamInterceptor adds interceptors for the streaming RPCs served by
+// the plugin. See WithUnaryInterceptor.
+func WithStreamInterceptor(is ...grpc.StreamServerInterceptor) Option {
+	return func(c *config) { c.StreamInterceptors = append(c.StreamInterceptors, is...) }
+}
+
+// WithMaxMessageSize sets the maximum size in bytes of the messages the
+// plugin sends and receives. By default gRPC limits received messages to
+// 4MB, which can be too small for components that receive large values,
+// such as template data or large artifacts. This applies to the plugin
+// server, the servers the plugin starts for arguments such as the
+// terminal.UI and the calls the plugin makes to the servers the host
+// starts. Hosts should use the same limit for their calls, see
+// pluginclient.MaxMessageSizeDialOptions.
+func WithMaxMessageSize(n int) Option {
+	return func(c *config) { c.MaxMessageSize = n }
+}
+
+// WithCompression makes the plugin compress the requests it makes to the
+// servers the host starts for arguments such as the terminal.UI with gzip.
+// Servers compress their responses to compressed requests, so hosts should
+// also compress their requests, see pluginclient.CompressionDialOptions.
+func WithCompression() Option {
+	return func(c *config) { c.Compression = true }
+}
+
+// WithReflection registers the gRPC reflection service on the servers the
+// plugin starts for arguments such as the terminal.UI, exec sessions and
+// log streams. The plugin server itself always serves reflection. This
+// lets developers list and call the services of a running plugin and of
+// its brokered servers with tools such as grpcurl:
+//
+//	grpcurl -plaintext -unix /tmp/plugin123456 list
+//
+// The address of the plugin server is in the reattach config printed by
+// Debug. Brokered servers listen on their own sockets next to it.
+// Reflection is always enabled by DebugServe and Debug, and can be enabled
+// for any plugin by setting the EnvReflection environment variable.
+func WithReflection() Option {
+	return func(c *config) { c.Reflection = true }
+}
+
+// WithRecording records the dynamic function calls the plugin serves,
+// such as deploys, with their arguments and results to the file at path.
+// The file can be given to WithReplay to serve the same results without
+// calling the components, so tests against real cloud interactions can run
+// offline and deterministically. Recording can also be enabled for a
+// plugin run by Waypoint by setting the EnvRecord environment variable.
+// Recordings contain the arguments and results of the calls, which may
+// include secrets, so review them before committing them.
+func WithRecording(path string) Option {
+	return func(c *config) { c.RecordPath = path }
+}
+
+// WithReplay serves the dynamic function calls recorded with WithRecording
+// to the file at path instead of calling the components. The results for
+// each function are served in the order they were recorded, regardless of
+// the arguments, so the calls must be made in the same order as the
+// recorded run. Other calls, such as configuring the components, still
+// reach the components. This is usually used with the sdktest package:
+//
+//	h := sdktest.New(t,
+//		sdk.WithComponents(&Platform{}),
+//		sdk.WithReplay("testdata/deploy.jsonl"),
+//	)
+func WithReplay(path string) Option {
+	return func(c *config) { c.ReplayPath = path }
+}
+
+// WithDebugAddr makes DebugServe and Debug accept connections from Waypoint
+// on the given TCP address, such as "0.0.0.0:1234", instead of a unix
+// socket. This allows debugging a plugin running in a container by
+// publishing the port. If the address has no host or an unspecified IP,
+// the reattach config uses 127.0.0.1, so the port should be published to
+// the same port on the host.
+func WithDebugAddr(addr string) Option {
+	return func(c *config) { c.DebugAddr = addr }
+}
+
+// WithReattachFile makes Debug write the value of WP_REATTACH_PLUGINS to
+// the file at path in addition to printing it. The file is rewritten every
+// time the server is restarted and is removed when Debug returns. This is
+// useful for scripts and editors that start Waypoint against the plugin.
+func WithReattachFile(path string) Option {
+	return func(c *config) { c.ReattachFile = path }
+}
+
 // DebugServe starts a plugin server in debug mode; this should only be used
 // when the plugin will manage its own lifecycle. It is not recommended for
 // normal usage; Serve is the correct function for that.
@@ -147,7 +587,25 @@ func DebugServe(ctx context.Context, opts ...Option) (ReattachConfig, <-chan str
 	reattachCh := make(chan *plugin.ReattachConfig)
 	closeCh := make(chan struct{})
 
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	// Bind the debug address before starting the server so that we can
+	// report errors such as the address being in use.
+	var proxy *debugProxy
+	if c.DebugAddr != "" {
+		var err error
+		proxy, err = newDebugProxy(c.DebugAddr)
+		if err != nil {
+			close(closeCh)
+			return ReattachConfig{}, closeCh, fmt.Errorf("error listening on %s: %w", c.DebugAddr, err)
+		}
+	}
+
 	opts = append(opts, func(c *config) {
+		c.Reflection = true
 		c.TestConfig = &plugin.ServeTestConfig{
 			Context:          ctx,
 			ReattachConfigCh: reattachCh,
@@ -161,14 +619,20 @@ func DebugServe(ctx context.Context, opts ...Option) (ReattachConfig, <-chan str
 	select {
 	case config = <-reattachCh:
 	case <-time.After(2 * time.Second):
-		return ReattachConfig{}, closeCh, fmt.Errorf("timeout waiting on reattach config")
 	}
 
 	if config == nil {
+		if proxy != nil {
+			proxy.ln.Close()
+		}
+
+		if ctx.Err() == nil {
+			return ReattachConfig{}, closeCh, fmt.Errorf("timeout waiting on reattach config")
+		}
 		return ReattachConfig{}, closeCh, fmt.Errorf("nil reattach config received")
 	}
 
-	return ReattachConfig{
+	result := ReattachConfig{
 		Protocol:        string(config.Protocol),
 		ProtocolVersion: config.ProtocolVersion,
 		Pid:             config.Pid,
@@ -177,12 +641,22 @@ func DebugServe(ctx context.Context, opts ...Option) (ReattachConfig, <-chan str
 			Network: config.Addr.Network(),
 			String:  config.Addr.String(),
 		},
-	}, closeCh, nil
+	}
+
+	if proxy != nil {
+		result.Addr = proxy.Addr()
+		go proxy.Serve(config.Addr)
+		closeCh = proxy.closeAfter(closeCh)
+	}
+
+	return result, closeCh, nil
 }
 
 // Debug starts a debug server and controls its lifecycle, printing the
 // information needed for Waypoint to connect to the plugin to stdout.
-// os.Interrupt will be captured and used to stop the server.
+// os.Interrupt will be captured and used to stop the server. If the server
+// is stopped by Waypoint, it is restarted and the new information is
+// printed, so Debug only returns when ctx is done or on interrupt.
 func Debug(ctx context.Context, pluginAddr string, opts ...Option) error {
 	ctx, cancel := context.WithCancel(ctx)
 	// Ctrl-C will stop the server
@@ -192,10 +666,6 @@ func Debug(ctx context.Context, pluginAddr string, opts ...Option) error {
 		signal.Stop(sigCh)
 		cancel()
 	}()
-	config, closeCh, err := DebugServe(ctx, opts...)
-	if err != nil {
-		return fmt.Errorf("Error launching debug server: %w", err)
-	}
 	go func() {
 		select {
 		case <-sigCh:
@@ -203,30 +673,61 @@ func Debug(ctx context.Context, pluginAddr string, opts ...Option) error {
 		case <-ctx.Done():
 		}
 	}()
-	reattachBytes, err := json.Marshal(map[string]ReattachConfig{
-		pluginAddr: config,
-	})
-	if err != nil {
-		return fmt.Errorf("Error building reattach string: %w", err)
+
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.ReattachFile != "" {
+		defer os.Remove(c.ReattachFile)
 	}
 
-	reattachStr := string(reattachBytes)
+	for {
+		config, closeCh, err := DebugServe(ctx, opts...)
+		if err != nil {
+			return fmt.Errorf("Error launching debug server: %w", err)
+		}
 
-	fmt.Printf("Plugin started, to attach Waypoint set the WP_REATTACH_PLUGINS env var:\n\n")
-	switch runtime.GOOS {
-	case "windows":
-		fmt.Printf("\tCommand Prompt:\tset \"WP_REATTACH_PLUGINS=%s\"\n", reattachStr)
-		fmt.Printf("\tPowerShell:\t$env:WP_REATTACH_PLUGINS='%s'\n", strings.ReplaceAll(reattachStr, `'`, `''`))
-	case "linux", "darwin":
-		fmt.Printf("\tWP_REATTACH_PLUGINS='%s'\n", strings.ReplaceAll(reattachStr, `'`, `'"'"'`))
-	default:
-		fmt.Println(reattachStr)
-	}
-	fmt.Println("")
+		reattachBytes, err := json.Marshal(map[string]ReattachConfig{
+			pluginAddr: config,
+		})
+		if err != nil {
+			return fmt.Errorf("Error building reattach string: %w", err)
+		}
+
+		reattachStr := string(reattachBytes)
+		if c.ReattachFile != "" {
+			if err := os.WriteFile(c.ReattachFile, reattachBytes, 0600); err != nil {
+				return fmt.Errorf("Error writing reattach file: %w", err)
+			}
+		}
+
+		fmt.Printf("Plugin started, to attach Waypoint set the WP_REATTACH_PLUGINS env var:\n\n")
+		switch runtime.GOOS {
+		case "windows":
+			fmt.Printf("\tCommand Prompt:\tset \"WP_REATTACH_PLUGINS=%s\"\n", reattachStr)
+			fmt.Printf("\tPowerShell:\t$env:WP_REATTACH_PLUGINS='%s'\n", strings.ReplaceAll(reattachStr, `'`, `''`))
+		case "linux", "darwin":
+			fmt.Printf("\tWP_REATTACH_PLUGINS='%s'\n", strings.ReplaceAll(reattachStr, `'`, `'"'"'`))
+		default:
+			fmt.Println(reattachStr)
+		}
+		if c.ReattachFile != "" {
+			fmt.Printf("\nThe value was also written to %s\n", c.ReattachFile)
+		}
+		fmt.Println("")
 
-	// wait for the server to be done
-	<-closeCh
-	return nil
+		// wait for the server to be done
+		<-closeCh
+
+		// If we weren't stopped, Waypoint stopped the server so we start
+		// another one for the next run.
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		fmt.Printf("Plugin server stopped, restarting\n\n")
+	}
 }
 
 // ReattachConfig holds the information Waypoint needs to be able to attach
@@ -25,6 +25,8 @@ const _ = grpc.SupportPackageIsVersion7
 type ExecSessionServiceClient interface {
 	Output(ctx context.Context, in *ExecSession_OutputRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Input(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (ExecSessionService_InputClient, error)
+	// Exit reports the exit status of the remote process to the client.
+	Exit(ctx context.Context, in *ExecSession_ExitRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type execSessionServiceClient struct {
@@ -76,12 +78,23 @@ func (x *execSessionServiceInputClient) Recv() (*ExecSession_InputRequest, error
 	return m, nil
 }
 
+func (c *execSessionServiceClient) Exit(ctx context.Context, in *ExecSession_ExitRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ExecSessionService/Exit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExecSessionServiceServer is the server API for ExecSessionService service.
 // All implementations must embed UnimplementedExecSessionServiceServer
 // for forward compatibility
 type ExecSessionServiceServer interface {
 	Output(context.Context, *ExecSession_OutputRequest) (*emptypb.Empty, error)
 	Input(*emptypb.Empty, ExecSessionService_InputServer) error
+	// Exit reports the exit status of the remote process to the client.
+	Exit(context.Context, *ExecSession_ExitRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedExecSessionServiceServer()
 }
 
@@ -95,6 +108,9 @@ func (UnimplementedExecSessionServiceServer) Output(context.Context, *ExecSessio
 func (UnimplementedExecSessionServiceServer) Input(*emptypb.Empty, ExecSessionService_InputServer) error {
 	return status.Errorf(codes.Unimplemented, "method Input not implemented")
 }
+func (UnimplementedExecSessionServiceServer) Exit(context.Context, *ExecSession_ExitRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exit not implemented")
+}
 func (UnimplementedExecSessionServiceServer) mustEmbedUnimplementedExecSessionServiceServer() {}
 
 // UnsafeExecSessionServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -147,6 +163,24 @@ func (x *execSessionServiceInputServer) Send(m *ExecSession_InputRequest) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ExecSessionService_Exit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecSession_ExitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecSessionServiceServer).Exit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ExecSessionService/Exit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecSessionServiceServer).Exit(ctx, req.(*ExecSession_ExitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ExecSessionService_ServiceDesc is the grpc.ServiceDesc for ExecSessionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -158,6 +192,10 @@ var ExecSessionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Output",
 			Handler:    _ExecSessionService_Output_Handler,
 		},
+		{
+			MethodName: "Exit",
+			Handler:    _ExecSessionService_Exit_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -169,11 +207,208 @@ var ExecSessionService_ServiceDesc = grpc.ServiceDesc{
 	Metadata: "plugin.proto",
 }
 
+// PortForwardServiceClient is the client API for PortForwardService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PortForwardServiceClient interface {
+	// Accept streams a message for each connection the client accepts.
+	Accept(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (PortForwardService_AcceptClient, error)
+	// Connect carries the data of a single connection in both directions.
+	// The first message the plugin sends has the conn_id of the connection
+	// and no data. Either side closes the connection by ending the stream.
+	Connect(ctx context.Context, opts ...grpc.CallOption) (PortForwardService_ConnectClient, error)
+}
+
+type portForwardServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPortForwardServiceClient(cc grpc.ClientConnInterface) PortForwardServiceClient {
+	return &portForwardServiceClient{cc}
+}
+
+func (c *portForwardServiceClient) Accept(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (PortForwardService_AcceptClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PortForwardService_ServiceDesc.Streams[0], "/hashicorp.waypoint.sdk.PortForwardService/Accept", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &portForwardServiceAcceptClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PortForwardService_AcceptClient interface {
+	Recv() (*PortForward_Conn, error)
+	grpc.ClientStream
+}
+
+type portForwardServiceAcceptClient struct {
+	grpc.ClientStream
+}
+
+func (x *portForwardServiceAcceptClient) Recv() (*PortForward_Conn, error) {
+	m := new(PortForward_Conn)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *portForwardServiceClient) Connect(ctx context.Context, opts ...grpc.CallOption) (PortForwardService_ConnectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PortForwardService_ServiceDesc.Streams[1], "/hashicorp.waypoint.sdk.PortForwardService/Connect", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &portForwardServiceConnectClient{stream}
+	return x, nil
+}
+
+type PortForwardService_ConnectClient interface {
+	Send(*PortForward_Data) error
+	Recv() (*PortForward_Data, error)
+	grpc.ClientStream
+}
+
+type portForwardServiceConnectClient struct {
+	grpc.ClientStream
+}
+
+func (x *portForwardServiceConnectClient) Send(m *PortForward_Data) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *portForwardServiceConnectClient) Recv() (*PortForward_Data, error) {
+	m := new(PortForward_Data)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PortForwardServiceServer is the server API for PortForwardService service.
+// All implementations must embed UnimplementedPortForwardServiceServer
+// for forward compatibility
+type PortForwardServiceServer interface {
+	// Accept streams a message for each connection the client accepts.
+	Accept(*emptypb.Empty, PortForwardService_AcceptServer) error
+	// Connect carries the data of a single connection in both directions.
+	// The first message the plugin sends has the conn_id of the connection
+	// and no data. Either side closes the connection by ending the stream.
+	Connect(PortForwardService_ConnectServer) error
+	mustEmbedUnimplementedPortForwardServiceServer()
+}
+
+// UnimplementedPortForwardServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPortForwardServiceServer struct {
+}
+
+func (UnimplementedPortForwardServiceServer) Accept(*emptypb.Empty, PortForwardService_AcceptServer) error {
+	return status.Errorf(codes.Unimplemented, "method Accept not implemented")
+}
+func (UnimplementedPortForwardServiceServer) Connect(PortForwardService_ConnectServer) error {
+	return status.Errorf(codes.Unimplemented, "method Connect not implemented")
+}
+func (UnimplementedPortForwardServiceServer) mustEmbedUnimplementedPortForwardServiceServer() {}
+
+// UnsafePortForwardServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PortForwardServiceServer will
+// result in compilation errors.
+type UnsafePortForwardServiceServer interface {
+	mustEmbedUnimplementedPortForwardServiceServer()
+}
+
+func RegisterPortForwardServiceServer(s grpc.ServiceRegistrar, srv PortForwardServiceServer) {
+	s.RegisterService(&PortForwardService_ServiceDesc, srv)
+}
+
+func _PortForwardService_Accept_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PortForwardServiceServer).Accept(m, &portForwardServiceAcceptServer{stream})
+}
+
+type PortForwardService_AcceptServer interface {
+	Send(*PortForward_Conn) error
+	grpc.ServerStream
+}
+
+type portForwardServiceAcceptServer struct {
+	grpc.ServerStream
+}
+
+func (x *portForwardServiceAcceptServer) Send(m *PortForward_Conn) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PortForwardService_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PortForwardServiceServer).Connect(&portForwardServiceConnectServer{stream})
+}
+
+type PortForwardService_ConnectServer interface {
+	Send(*PortForward_Data) error
+	Recv() (*PortForward_Data, error)
+	grpc.ServerStream
+}
+
+type portForwardServiceConnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *portForwardServiceConnectServer) Send(m *PortForward_Data) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *portForwardServiceConnectServer) Recv() (*PortForward_Data, error) {
+	m := new(PortForward_Data)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PortForwardService_ServiceDesc is the grpc.ServiceDesc for PortForwardService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PortForwardService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.waypoint.sdk.PortForwardService",
+	HandlerType: (*PortForwardServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Accept",
+			Handler:       _PortForwardService_Accept_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Connect",
+			Handler:       _PortForwardService_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "plugin.proto",
+}
+
 // LogViewerClient is the client API for LogViewer service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LogViewerClient interface {
+	// NextLogBatch is the legacy push-based stream where the plugin sends
+	// events as soon as they're available. This is only used by plugins
+	// built against older SDKs; new plugins use NextBatch.
 	NextLogBatch(ctx context.Context, opts ...grpc.CallOption) (LogViewer_NextLogBatchClient, error)
+	// NextBatch is a pull-based stream. The host sends a NextBatchRequest
+	// whenever it is ready for more events and the plugin replies with
+	// exactly one NextBatchResp for each request. The stream ends when the
+	// plugin closes its side after all events have been acknowledged.
+	NextBatch(ctx context.Context, opts ...grpc.CallOption) (LogViewer_NextBatchClient, error)
 }
 
 type logViewerClient struct {
@@ -218,11 +453,50 @@ func (x *logViewerNextLogBatchClient) CloseAndRecv() (*emptypb.Empty, error) {
 	return m, nil
 }
 
+func (c *logViewerClient) NextBatch(ctx context.Context, opts ...grpc.CallOption) (LogViewer_NextBatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LogViewer_ServiceDesc.Streams[1], "/hashicorp.waypoint.sdk.LogViewer/NextBatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &logViewerNextBatchClient{stream}
+	return x, nil
+}
+
+type LogViewer_NextBatchClient interface {
+	Send(*Logs_NextBatchResp) error
+	Recv() (*Logs_NextBatchRequest, error)
+	grpc.ClientStream
+}
+
+type logViewerNextBatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *logViewerNextBatchClient) Send(m *Logs_NextBatchResp) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *logViewerNextBatchClient) Recv() (*Logs_NextBatchRequest, error) {
+	m := new(Logs_NextBatchRequest)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // LogViewerServer is the server API for LogViewer service.
 // All implementations must embed UnimplementedLogViewerServer
 // for forward compatibility
 type LogViewerServer interface {
+	// NextLogBatch is the legacy push-based stream where the plugin sends
+	// events as soon as they're available. This is only used by plugins
+	// built against older SDKs; new plugins use NextBatch.
 	NextLogBatch(LogViewer_NextLogBatchServer) error
+	// NextBatch is a pull-based stream. The host sends a NextBatchRequest
+	// whenever it is ready for more events and the plugin replies with
+	// exactly one NextBatchResp for each request. The stream ends when the
+	// plugin closes its side after all events have been acknowledged.
+	NextBatch(LogViewer_NextBatchServer) error
 	mustEmbedUnimplementedLogViewerServer()
 }
 
@@ -233,6 +507,9 @@ type UnimplementedLogViewerServer struct {
 func (UnimplementedLogViewerServer) NextLogBatch(LogViewer_NextLogBatchServer) error {
 	return status.Errorf(codes.Unimplemented, "method NextLogBatch not implemented")
 }
+func (UnimplementedLogViewerServer) NextBatch(LogViewer_NextBatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method NextBatch not implemented")
+}
 func (UnimplementedLogViewerServer) mustEmbedUnimplementedLogViewerServer() {}
 
 // UnsafeLogViewerServer may be embedded to opt out of forward compatibility for this service.
@@ -272,6 +549,32 @@ func (x *logViewerNextLogBatchServer) Recv() (*Logs_NextBatchResp, error) {
 	return m, nil
 }
 
+func _LogViewer_NextBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LogViewerServer).NextBatch(&logViewerNextBatchServer{stream})
+}
+
+type LogViewer_NextBatchServer interface {
+	Send(*Logs_NextBatchRequest) error
+	Recv() (*Logs_NextBatchResp, error)
+	grpc.ServerStream
+}
+
+type logViewerNextBatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *logViewerNextBatchServer) Send(m *Logs_NextBatchRequest) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *logViewerNextBatchServer) Recv() (*Logs_NextBatchResp, error) {
+	m := new(Logs_NextBatchResp)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // LogViewer_ServiceDesc is the grpc.ServiceDesc for LogViewer service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -285,3089 +588,8290 @@ var LogViewer_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _LogViewer_NextLogBatch_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "NextBatch",
+			Handler:       _LogViewer_NextBatch_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "plugin.proto",
 }
 
-// TerminalUIServiceClient is the client API for TerminalUIService service.
+// PipeServiceClient is the client API for PipeService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type TerminalUIServiceClient interface {
-	Output(ctx context.Context, in *TerminalUI_OutputRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	Events(ctx context.Context, opts ...grpc.CallOption) (TerminalUIService_EventsClient, error)
-	IsInteractive(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TerminalUI_IsInteractiveResponse, error)
+type PipeServiceClient interface {
+	// Read streams the data of the source. Backpressure is provided by the
+	// flow control of the stream, so the source is only read as fast as the
+	// reader consumes it. Canceling the call cancels the pipe. Read can only
+	// be called once per source.
+	Read(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (PipeService_ReadClient, error)
 }
 
-type terminalUIServiceClient struct {
+type pipeServiceClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewTerminalUIServiceClient(cc grpc.ClientConnInterface) TerminalUIServiceClient {
-	return &terminalUIServiceClient{cc}
+func NewPipeServiceClient(cc grpc.ClientConnInterface) PipeServiceClient {
+	return &pipeServiceClient{cc}
 }
 
-func (c *terminalUIServiceClient) Output(ctx context.Context, in *TerminalUI_OutputRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.TerminalUIService/Output", in, out, opts...)
+func (c *pipeServiceClient) Read(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (PipeService_ReadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PipeService_ServiceDesc.Streams[0], "/hashicorp.waypoint.sdk.PipeService/Read", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *terminalUIServiceClient) Events(ctx context.Context, opts ...grpc.CallOption) (TerminalUIService_EventsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &TerminalUIService_ServiceDesc.Streams[0], "/hashicorp.waypoint.sdk.TerminalUIService/Events", opts...)
-	if err != nil {
+	x := &pipeServiceReadClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	x := &terminalUIServiceEventsClient{stream}
 	return x, nil
 }
 
-type TerminalUIService_EventsClient interface {
-	Send(*TerminalUI_Event) error
-	Recv() (*TerminalUI_Response, error)
+type PipeService_ReadClient interface {
+	Recv() (*Pipe_Chunk, error)
 	grpc.ClientStream
 }
 
-type terminalUIServiceEventsClient struct {
+type pipeServiceReadClient struct {
 	grpc.ClientStream
 }
 
-func (x *terminalUIServiceEventsClient) Send(m *TerminalUI_Event) error {
-	return x.ClientStream.SendMsg(m)
-}
-
-func (x *terminalUIServiceEventsClient) Recv() (*TerminalUI_Response, error) {
-	m := new(TerminalUI_Response)
+func (x *pipeServiceReadClient) Recv() (*Pipe_Chunk, error) {
+	m := new(Pipe_Chunk)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *terminalUIServiceClient) IsInteractive(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TerminalUI_IsInteractiveResponse, error) {
-	out := new(TerminalUI_IsInteractiveResponse)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.TerminalUIService/IsInteractive", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// PipeServiceServer is the server API for PipeService service.
+// All implementations must embed UnimplementedPipeServiceServer
+// for forward compatibility
+type PipeServiceServer interface {
+	// Read streams the data of the source. Backpressure is provided by the
+	// flow control of the stream, so the source is only read as fast as the
+	// reader consumes it. Canceling the call cancels the pipe. Read can only
+	// be called once per source.
+	Read(*emptypb.Empty, PipeService_ReadServer) error
+	mustEmbedUnimplementedPipeServiceServer()
 }
 
-// TerminalUIServiceServer is the server API for TerminalUIService service.
-// All implementations must embed UnimplementedTerminalUIServiceServer
-// for forward compatibility
-type TerminalUIServiceServer interface {
-	Output(context.Context, *TerminalUI_OutputRequest) (*emptypb.Empty, error)
-	Events(TerminalUIService_EventsServer) error
-	IsInteractive(context.Context, *emptypb.Empty) (*TerminalUI_IsInteractiveResponse, error)
-	mustEmbedUnimplementedTerminalUIServiceServer()
+// UnimplementedPipeServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPipeServiceServer struct {
 }
 
-// UnimplementedTerminalUIServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedTerminalUIServiceServer struct {
+func (UnimplementedPipeServiceServer) Read(*emptypb.Empty, PipeService_ReadServer) error {
+	return status.Errorf(codes.Unimplemented, "method Read not implemented")
 }
+func (UnimplementedPipeServiceServer) mustEmbedUnimplementedPipeServiceServer() {}
 
-func (UnimplementedTerminalUIServiceServer) Output(context.Context, *TerminalUI_OutputRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Output not implemented")
+// UnsafePipeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PipeServiceServer will
+// result in compilation errors.
+type UnsafePipeServiceServer interface {
+	mustEmbedUnimplementedPipeServiceServer()
 }
-func (UnimplementedTerminalUIServiceServer) Events(TerminalUIService_EventsServer) error {
-	return status.Errorf(codes.Unimplemented, "method Events not implemented")
+
+func RegisterPipeServiceServer(s grpc.ServiceRegistrar, srv PipeServiceServer) {
+	s.RegisterService(&PipeService_ServiceDesc, srv)
 }
-func (UnimplementedTerminalUIServiceServer) IsInteractive(context.Context, *emptypb.Empty) (*TerminalUI_IsInteractiveResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IsInteractive not implemented")
+
+func _PipeService_Read_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PipeServiceServer).Read(m, &pipeServiceReadServer{stream})
 }
-func (UnimplementedTerminalUIServiceServer) mustEmbedUnimplementedTerminalUIServiceServer() {}
 
-// UnsafeTerminalUIServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to TerminalUIServiceServer will
-// result in compilation errors.
-type UnsafeTerminalUIServiceServer interface {
-	mustEmbedUnimplementedTerminalUIServiceServer()
+type PipeService_ReadServer interface {
+	Send(*Pipe_Chunk) error
+	grpc.ServerStream
 }
 
-func RegisterTerminalUIServiceServer(s grpc.ServiceRegistrar, srv TerminalUIServiceServer) {
-	s.RegisterService(&TerminalUIService_ServiceDesc, srv)
+type pipeServiceReadServer struct {
+	grpc.ServerStream
 }
 
-func _TerminalUIService_Output_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TerminalUI_OutputRequest)
-	if err := dec(in); err != nil {
+func (x *pipeServiceReadServer) Send(m *Pipe_Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// PipeService_ServiceDesc is the grpc.ServiceDesc for PipeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PipeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.waypoint.sdk.PipeService",
+	HandlerType: (*PipeServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Read",
+			Handler:       _PipeService_Read_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "plugin.proto",
+}
+
+// FileServiceClient is the client API for FileService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FileServiceClient interface {
+	// Upload writes a file. The first message must be the header and the
+	// remaining messages are the data of the file.
+	Upload(ctx context.Context, opts ...grpc.CallOption) (FileService_UploadClient, error)
+	// Download reads a file.
+	Download(ctx context.Context, in *FileSync_DownloadRequest, opts ...grpc.CallOption) (FileService_DownloadClient, error)
+}
+
+type fileServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFileServiceClient(cc grpc.ClientConnInterface) FileServiceClient {
+	return &fileServiceClient{cc}
+}
+
+func (c *fileServiceClient) Upload(ctx context.Context, opts ...grpc.CallOption) (FileService_UploadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FileService_ServiceDesc.Streams[0], "/hashicorp.waypoint.sdk.FileService/Upload", opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(TerminalUIServiceServer).Output(ctx, in)
+	x := &fileServiceUploadClient{stream}
+	return x, nil
+}
+
+type FileService_UploadClient interface {
+	Send(*FileSync_UploadRequest) error
+	CloseAndRecv() (*emptypb.Empty, error)
+	grpc.ClientStream
+}
+
+type fileServiceUploadClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileServiceUploadClient) Send(m *FileSync_UploadRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *fileServiceUploadClient) CloseAndRecv() (*emptypb.Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.TerminalUIService/Output",
+	m := new(emptypb.Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TerminalUIServiceServer).Output(ctx, req.(*TerminalUI_OutputRequest))
+	return m, nil
+}
+
+func (c *fileServiceClient) Download(ctx context.Context, in *FileSync_DownloadRequest, opts ...grpc.CallOption) (FileService_DownloadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FileService_ServiceDesc.Streams[1], "/hashicorp.waypoint.sdk.FileService/Download", opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	x := &fileServiceDownloadClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func _TerminalUIService_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(TerminalUIServiceServer).Events(&terminalUIServiceEventsServer{stream})
+type FileService_DownloadClient interface {
+	Recv() (*FileSync_Chunk, error)
+	grpc.ClientStream
 }
 
-type TerminalUIService_EventsServer interface {
-	Send(*TerminalUI_Response) error
-	Recv() (*TerminalUI_Event, error)
+type fileServiceDownloadClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileServiceDownloadClient) Recv() (*FileSync_Chunk, error) {
+	m := new(FileSync_Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FileServiceServer is the server API for FileService service.
+// All implementations must embed UnimplementedFileServiceServer
+// for forward compatibility
+type FileServiceServer interface {
+	// Upload writes a file. The first message must be the header and the
+	// remaining messages are the data of the file.
+	Upload(FileService_UploadServer) error
+	// Download reads a file.
+	Download(*FileSync_DownloadRequest, FileService_DownloadServer) error
+	mustEmbedUnimplementedFileServiceServer()
+}
+
+// UnimplementedFileServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFileServiceServer struct {
+}
+
+func (UnimplementedFileServiceServer) Upload(FileService_UploadServer) error {
+	return status.Errorf(codes.Unimplemented, "method Upload not implemented")
+}
+func (UnimplementedFileServiceServer) Download(*FileSync_DownloadRequest, FileService_DownloadServer) error {
+	return status.Errorf(codes.Unimplemented, "method Download not implemented")
+}
+func (UnimplementedFileServiceServer) mustEmbedUnimplementedFileServiceServer() {}
+
+// UnsafeFileServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FileServiceServer will
+// result in compilation errors.
+type UnsafeFileServiceServer interface {
+	mustEmbedUnimplementedFileServiceServer()
+}
+
+func RegisterFileServiceServer(s grpc.ServiceRegistrar, srv FileServiceServer) {
+	s.RegisterService(&FileService_ServiceDesc, srv)
+}
+
+func _FileService_Upload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FileServiceServer).Upload(&fileServiceUploadServer{stream})
+}
+
+type FileService_UploadServer interface {
+	SendAndClose(*emptypb.Empty) error
+	Recv() (*FileSync_UploadRequest, error)
 	grpc.ServerStream
 }
 
-type terminalUIServiceEventsServer struct {
+type fileServiceUploadServer struct {
 	grpc.ServerStream
 }
 
-func (x *terminalUIServiceEventsServer) Send(m *TerminalUI_Response) error {
+func (x *fileServiceUploadServer) SendAndClose(m *emptypb.Empty) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *terminalUIServiceEventsServer) Recv() (*TerminalUI_Event, error) {
-	m := new(TerminalUI_Event)
+func (x *fileServiceUploadServer) Recv() (*FileSync_UploadRequest, error) {
+	m := new(FileSync_UploadRequest)
 	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func _TerminalUIService_IsInteractive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(TerminalUIServiceServer).IsInteractive(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.TerminalUIService/IsInteractive",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TerminalUIServiceServer).IsInteractive(ctx, req.(*emptypb.Empty))
+func _FileService_Download_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FileSync_DownloadRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(FileServiceServer).Download(m, &fileServiceDownloadServer{stream})
 }
 
-// TerminalUIService_ServiceDesc is the grpc.ServiceDesc for TerminalUIService service.
+type FileService_DownloadServer interface {
+	Send(*FileSync_Chunk) error
+	grpc.ServerStream
+}
+
+type fileServiceDownloadServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileServiceDownloadServer) Send(m *FileSync_Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// FileService_ServiceDesc is the grpc.ServiceDesc for FileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var TerminalUIService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "hashicorp.waypoint.sdk.TerminalUIService",
-	HandlerType: (*TerminalUIServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Output",
-			Handler:    _TerminalUIService_Output_Handler,
-		},
+var FileService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.waypoint.sdk.FileService",
+	HandlerType: (*FileServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
 		{
-			MethodName: "IsInteractive",
-			Handler:    _TerminalUIService_IsInteractive_Handler,
+			StreamName:    "Upload",
+			Handler:       _FileService_Upload_Handler,
+			ClientStreams: true,
 		},
-	},
-	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "Events",
-			Handler:       _TerminalUIService_Events_Handler,
+			StreamName:    "Download",
+			Handler:       _FileService_Download_Handler,
 			ServerStreams: true,
-			ClientStreams: true,
 		},
 	},
 	Metadata: "plugin.proto",
 }
 
-// MapperClient is the client API for Mapper service.
+// HostServiceClient is the client API for HostService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type MapperClient interface {
-	// ListMappers returns the list of mappers that this plugin supports.
-	ListMappers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Map_ListResponse, error)
-	// Map executes a mapper.
-	Map(ctx context.Context, in *Map_Request, opts ...grpc.CallOption) (*Map_Response, error)
+type HostServiceClient interface {
+	// ListDeployments returns the deployments of the app, newest first.
+	ListDeployments(ctx context.Context, in *Host_ListDeploymentsRequest, opts ...grpc.CallOption) (*Host_ListDeploymentsResponse, error)
+	// GetArtifact returns an artifact of the app by ID.
+	GetArtifact(ctx context.Context, in *Host_GetArtifactRequest, opts ...grpc.CallOption) (*Host_Artifact, error)
+	// ConfigVars returns the config variables of the project.
+	ConfigVars(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Host_ConfigVarsResponse, error)
 }
 
-type mapperClient struct {
+type hostServiceClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewMapperClient(cc grpc.ClientConnInterface) MapperClient {
-	return &mapperClient{cc}
+func NewHostServiceClient(cc grpc.ClientConnInterface) HostServiceClient {
+	return &hostServiceClient{cc}
 }
 
-func (c *mapperClient) ListMappers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Map_ListResponse, error) {
-	out := new(Map_ListResponse)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Mapper/ListMappers", in, out, opts...)
+func (c *hostServiceClient) ListDeployments(ctx context.Context, in *Host_ListDeploymentsRequest, opts ...grpc.CallOption) (*Host_ListDeploymentsResponse, error) {
+	out := new(Host_ListDeploymentsResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.HostService/ListDeployments", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *mapperClient) Map(ctx context.Context, in *Map_Request, opts ...grpc.CallOption) (*Map_Response, error) {
-	out := new(Map_Response)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Mapper/Map", in, out, opts...)
+func (c *hostServiceClient) GetArtifact(ctx context.Context, in *Host_GetArtifactRequest, opts ...grpc.CallOption) (*Host_Artifact, error) {
+	out := new(Host_Artifact)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.HostService/GetArtifact", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// MapperServer is the server API for Mapper service.
-// All implementations must embed UnimplementedMapperServer
+func (c *hostServiceClient) ConfigVars(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Host_ConfigVarsResponse, error) {
+	out := new(Host_ConfigVarsResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.HostService/ConfigVars", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HostServiceServer is the server API for HostService service.
+// All implementations must embed UnimplementedHostServiceServer
 // for forward compatibility
-type MapperServer interface {
-	// ListMappers returns the list of mappers that this plugin supports.
-	ListMappers(context.Context, *emptypb.Empty) (*Map_ListResponse, error)
-	// Map executes a mapper.
-	Map(context.Context, *Map_Request) (*Map_Response, error)
-	mustEmbedUnimplementedMapperServer()
+type HostServiceServer interface {
+	// ListDeployments returns the deployments of the app, newest first.
+	ListDeployments(context.Context, *Host_ListDeploymentsRequest) (*Host_ListDeploymentsResponse, error)
+	// GetArtifact returns an artifact of the app by ID.
+	GetArtifact(context.Context, *Host_GetArtifactRequest) (*Host_Artifact, error)
+	// ConfigVars returns the config variables of the project.
+	ConfigVars(context.Context, *emptypb.Empty) (*Host_ConfigVarsResponse, error)
+	mustEmbedUnimplementedHostServiceServer()
 }
 
-// UnimplementedMapperServer must be embedded to have forward compatible implementations.
-type UnimplementedMapperServer struct {
+// UnimplementedHostServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedHostServiceServer struct {
 }
 
-func (UnimplementedMapperServer) ListMappers(context.Context, *emptypb.Empty) (*Map_ListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListMappers not implemented")
+func (UnimplementedHostServiceServer) ListDeployments(context.Context, *Host_ListDeploymentsRequest) (*Host_ListDeploymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeployments not implemented")
 }
-func (UnimplementedMapperServer) Map(context.Context, *Map_Request) (*Map_Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Map not implemented")
+func (UnimplementedHostServiceServer) GetArtifact(context.Context, *Host_GetArtifactRequest) (*Host_Artifact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArtifact not implemented")
 }
-func (UnimplementedMapperServer) mustEmbedUnimplementedMapperServer() {}
+func (UnimplementedHostServiceServer) ConfigVars(context.Context, *emptypb.Empty) (*Host_ConfigVarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigVars not implemented")
+}
+func (UnimplementedHostServiceServer) mustEmbedUnimplementedHostServiceServer() {}
 
-// UnsafeMapperServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to MapperServer will
+// UnsafeHostServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HostServiceServer will
 // result in compilation errors.
-type UnsafeMapperServer interface {
-	mustEmbedUnimplementedMapperServer()
+type UnsafeHostServiceServer interface {
+	mustEmbedUnimplementedHostServiceServer()
 }
 
-func RegisterMapperServer(s grpc.ServiceRegistrar, srv MapperServer) {
-	s.RegisterService(&Mapper_ServiceDesc, srv)
+func RegisterHostServiceServer(s grpc.ServiceRegistrar, srv HostServiceServer) {
+	s.RegisterService(&HostService_ServiceDesc, srv)
 }
 
-func _Mapper_ListMappers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _HostService_ListDeployments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Host_ListDeploymentsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MapperServer).ListMappers(ctx, in)
+		return srv.(HostServiceServer).ListDeployments(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Mapper/ListMappers",
+		FullMethod: "/hashicorp.waypoint.sdk.HostService/ListDeployments",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MapperServer).ListMappers(ctx, req.(*emptypb.Empty))
+		return srv.(HostServiceServer).ListDeployments(ctx, req.(*Host_ListDeploymentsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Mapper_Map_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Map_Request)
+func _HostService_GetArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Host_GetArtifactRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MapperServer).Map(ctx, in)
+		return srv.(HostServiceServer).GetArtifact(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Mapper/Map",
+		FullMethod: "/hashicorp.waypoint.sdk.HostService/GetArtifact",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MapperServer).Map(ctx, req.(*Map_Request))
+		return srv.(HostServiceServer).GetArtifact(ctx, req.(*Host_GetArtifactRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// Mapper_ServiceDesc is the grpc.ServiceDesc for Mapper service.
+func _HostService_ConfigVars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostServiceServer).ConfigVars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.HostService/ConfigVars",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostServiceServer).ConfigVars(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HostService_ServiceDesc is the grpc.ServiceDesc for HostService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var Mapper_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "hashicorp.waypoint.sdk.Mapper",
-	HandlerType: (*MapperServer)(nil),
+var HostService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.waypoint.sdk.HostService",
+	HandlerType: (*HostServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "ListMappers",
-			Handler:    _Mapper_ListMappers_Handler,
+			MethodName: "ListDeployments",
+			Handler:    _HostService_ListDeployments_Handler,
 		},
 		{
-			MethodName: "Map",
-			Handler:    _Mapper_Map_Handler,
+			MethodName: "GetArtifact",
+			Handler:    _HostService_GetArtifact_Handler,
+		},
+		{
+			MethodName: "ConfigVars",
+			Handler:    _HostService_ConfigVars_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "plugin.proto",
 }
 
-// BuilderClient is the client API for Builder service.
+// TerminalUIServiceClient is the client API for TerminalUIService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type BuilderClient interface {
-	IsAuthenticator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
-	Auth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error)
-	AuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	ValidateAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	ValidateAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error)
-	Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
-	BuildSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	Build(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Build_Resp, error)
-	BuildODR(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Build_Resp, error)
-	BuildSpecODR(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+type TerminalUIServiceClient interface {
+	Output(ctx context.Context, in *TerminalUI_OutputRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Events(ctx context.Context, opts ...grpc.CallOption) (TerminalUIService_EventsClient, error)
+	IsInteractive(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TerminalUI_IsInteractiveResponse, error)
 }
 
-type builderClient struct {
+type terminalUIServiceClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewBuilderClient(cc grpc.ClientConnInterface) BuilderClient {
-	return &builderClient{cc}
+func NewTerminalUIServiceClient(cc grpc.ClientConnInterface) TerminalUIServiceClient {
+	return &terminalUIServiceClient{cc}
 }
 
-func (c *builderClient) IsAuthenticator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
-	out := new(ImplementsResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/IsAuthenticator", in, out, opts...)
+func (c *terminalUIServiceClient) Output(ctx context.Context, in *TerminalUI_OutputRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.TerminalUIService/Output", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *builderClient) Auth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error) {
-	out := new(Auth_AuthResponse)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/Auth", in, out, opts...)
+func (c *terminalUIServiceClient) Events(ctx context.Context, opts ...grpc.CallOption) (TerminalUIService_EventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TerminalUIService_ServiceDesc.Streams[0], "/hashicorp.waypoint.sdk.TerminalUIService/Events", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &terminalUIServiceEventsClient{stream}
+	return x, nil
 }
 
-func (c *builderClient) AuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
-	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/AuthSpec", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type TerminalUIService_EventsClient interface {
+	Send(*TerminalUI_Event) error
+	Recv() (*TerminalUI_Response, error)
+	grpc.ClientStream
 }
 
-func (c *builderClient) ValidateAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/ValidateAuth", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type terminalUIServiceEventsClient struct {
+	grpc.ClientStream
 }
 
-func (c *builderClient) ValidateAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
-	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/ValidateAuthSpec", in, out, opts...)
-	if err != nil {
+func (x *terminalUIServiceEventsClient) Send(m *TerminalUI_Event) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *terminalUIServiceEventsClient) Recv() (*TerminalUI_Response, error) {
+	m := new(TerminalUI_Response)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *builderClient) ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error) {
-	out := new(Config_StructResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/ConfigStruct", in, out, opts...)
+func (c *terminalUIServiceClient) IsInteractive(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TerminalUI_IsInteractiveResponse, error) {
+	out := new(TerminalUI_IsInteractiveResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.TerminalUIService/IsInteractive", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *builderClient) Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/Configure", in, out, opts...)
-	if err != nil {
+// TerminalUIServiceServer is the server API for TerminalUIService service.
+// All implementations must embed UnimplementedTerminalUIServiceServer
+// for forward compatibility
+type TerminalUIServiceServer interface {
+	Output(context.Context, *TerminalUI_OutputRequest) (*emptypb.Empty, error)
+	Events(TerminalUIService_EventsServer) error
+	IsInteractive(context.Context, *emptypb.Empty) (*TerminalUI_IsInteractiveResponse, error)
+	mustEmbedUnimplementedTerminalUIServiceServer()
+}
+
+// UnimplementedTerminalUIServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTerminalUIServiceServer struct {
+}
+
+func (UnimplementedTerminalUIServiceServer) Output(context.Context, *TerminalUI_OutputRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Output not implemented")
+}
+func (UnimplementedTerminalUIServiceServer) Events(TerminalUIService_EventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Events not implemented")
+}
+func (UnimplementedTerminalUIServiceServer) IsInteractive(context.Context, *emptypb.Empty) (*TerminalUI_IsInteractiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsInteractive not implemented")
+}
+func (UnimplementedTerminalUIServiceServer) mustEmbedUnimplementedTerminalUIServiceServer() {}
+
+// UnsafeTerminalUIServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TerminalUIServiceServer will
+// result in compilation errors.
+type UnsafeTerminalUIServiceServer interface {
+	mustEmbedUnimplementedTerminalUIServiceServer()
+}
+
+func RegisterTerminalUIServiceServer(s grpc.ServiceRegistrar, srv TerminalUIServiceServer) {
+	s.RegisterService(&TerminalUIService_ServiceDesc, srv)
+}
+
+func _TerminalUIService_Output_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TerminalUI_OutputRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(TerminalUIServiceServer).Output(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.TerminalUIService/Output",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerminalUIServiceServer).Output(ctx, req.(*TerminalUI_OutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *builderClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
-	out := new(Config_Documentation)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/Documentation", in, out, opts...)
-	if err != nil {
+func _TerminalUIService_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TerminalUIServiceServer).Events(&terminalUIServiceEventsServer{stream})
+}
+
+type TerminalUIService_EventsServer interface {
+	Send(*TerminalUI_Response) error
+	Recv() (*TerminalUI_Event, error)
+	grpc.ServerStream
+}
+
+type terminalUIServiceEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *terminalUIServiceEventsServer) Send(m *TerminalUI_Response) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *terminalUIServiceEventsServer) Recv() (*TerminalUI_Event, error) {
+	m := new(TerminalUI_Event)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *builderClient) BuildSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
-	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/BuildSpec", in, out, opts...)
-	if err != nil {
+func _TerminalUIService_IsInteractive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(TerminalUIServiceServer).IsInteractive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.TerminalUIService/IsInteractive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TerminalUIServiceServer).IsInteractive(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *builderClient) Build(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Build_Resp, error) {
-	out := new(Build_Resp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/Build", in, out, opts...)
+// TerminalUIService_ServiceDesc is the grpc.ServiceDesc for TerminalUIService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TerminalUIService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.waypoint.sdk.TerminalUIService",
+	HandlerType: (*TerminalUIServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Output",
+			Handler:    _TerminalUIService_Output_Handler,
+		},
+		{
+			MethodName: "IsInteractive",
+			Handler:    _TerminalUIService_IsInteractive_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Events",
+			Handler:       _TerminalUIService_Events_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "plugin.proto",
+}
+
+// InfoClient is the client API for Info service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type InfoClient interface {
+	// Info returns the information for the negotiated protocol version.
+	Info(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo_Resp, error)
+	// Negotiate exchanges the optional features supported by the host and
+	// the plugin. The response has the features that both support, which
+	// are the features enabled for the rest of the connection. Plugins built
+	// before negotiation existed return Unimplemented, which means no
+	// features are enabled.
+	Negotiate(ctx context.Context, in *Features_NegotiateRequest, opts ...grpc.CallOption) (*Features_NegotiateResponse, error)
+	// Components returns the components in the plugin set with their
+	// capabilities, without the details that Info computes by calling into
+	// the components, such as config digests. This is cheap enough to call
+	// when composing each component client. Plugins built before this
+	// existed return Unimplemented.
+	Components(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo_Resp, error)
+}
+
+type infoClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInfoClient(cc grpc.ClientConnInterface) InfoClient {
+	return &infoClient{cc}
+}
+
+func (c *infoClient) Info(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo_Resp, error) {
+	out := new(PluginInfo_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Info/Info", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *builderClient) BuildODR(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Build_Resp, error) {
-	out := new(Build_Resp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/BuildODR", in, out, opts...)
+func (c *infoClient) Negotiate(ctx context.Context, in *Features_NegotiateRequest, opts ...grpc.CallOption) (*Features_NegotiateResponse, error) {
+	out := new(Features_NegotiateResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Info/Negotiate", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *builderClient) BuildSpecODR(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
-	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/BuildSpecODR", in, out, opts...)
+func (c *infoClient) Components(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo_Resp, error) {
+	out := new(PluginInfo_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Info/Components", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// BuilderServer is the server API for Builder service.
-// All implementations must embed UnimplementedBuilderServer
+// InfoServer is the server API for Info service.
+// All implementations must embed UnimplementedInfoServer
 // for forward compatibility
-type BuilderServer interface {
-	IsAuthenticator(context.Context, *emptypb.Empty) (*ImplementsResp, error)
-	Auth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error)
-	AuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	ValidateAuth(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
-	ValidateAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error)
-	Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error)
-	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
-	BuildSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	Build(context.Context, *FuncSpec_Args) (*Build_Resp, error)
-	BuildODR(context.Context, *FuncSpec_Args) (*Build_Resp, error)
-	BuildSpecODR(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	mustEmbedUnimplementedBuilderServer()
+type InfoServer interface {
+	// Info returns the information for the negotiated protocol version.
+	Info(context.Context, *emptypb.Empty) (*PluginInfo_Resp, error)
+	// Negotiate exchanges the optional features supported by the host and
+	// the plugin. The response has the features that both support, which
+	// are the features enabled for the rest of the connection. Plugins built
+	// before negotiation existed return Unimplemented, which means no
+	// features are enabled.
+	Negotiate(context.Context, *Features_NegotiateRequest) (*Features_NegotiateResponse, error)
+	// Components returns the components in the plugin set with their
+	// capabilities, without the details that Info computes by calling into
+	// the components, such as config digests. This is cheap enough to call
+	// when composing each component client. Plugins built before this
+	// existed return Unimplemented.
+	Components(context.Context, *emptypb.Empty) (*PluginInfo_Resp, error)
+	mustEmbedUnimplementedInfoServer()
 }
 
-// UnimplementedBuilderServer must be embedded to have forward compatible implementations.
-type UnimplementedBuilderServer struct {
+// UnimplementedInfoServer must be embedded to have forward compatible implementations.
+type UnimplementedInfoServer struct {
 }
 
-func (UnimplementedBuilderServer) IsAuthenticator(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IsAuthenticator not implemented")
-}
-func (UnimplementedBuilderServer) Auth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Auth not implemented")
-}
-func (UnimplementedBuilderServer) AuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AuthSpec not implemented")
-}
-func (UnimplementedBuilderServer) ValidateAuth(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ValidateAuth not implemented")
-}
-func (UnimplementedBuilderServer) ValidateAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ValidateAuthSpec not implemented")
-}
-func (UnimplementedBuilderServer) ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ConfigStruct not implemented")
-}
-func (UnimplementedBuilderServer) Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
-}
-func (UnimplementedBuilderServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
-}
-func (UnimplementedBuilderServer) BuildSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BuildSpec not implemented")
+func (UnimplementedInfoServer) Info(context.Context, *emptypb.Empty) (*PluginInfo_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
 }
-func (UnimplementedBuilderServer) Build(context.Context, *FuncSpec_Args) (*Build_Resp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Build not implemented")
-}
-func (UnimplementedBuilderServer) BuildODR(context.Context, *FuncSpec_Args) (*Build_Resp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BuildODR not implemented")
+func (UnimplementedInfoServer) Negotiate(context.Context, *Features_NegotiateRequest) (*Features_NegotiateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Negotiate not implemented")
 }
-func (UnimplementedBuilderServer) BuildSpecODR(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BuildSpecODR not implemented")
+func (UnimplementedInfoServer) Components(context.Context, *emptypb.Empty) (*PluginInfo_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Components not implemented")
 }
-func (UnimplementedBuilderServer) mustEmbedUnimplementedBuilderServer() {}
+func (UnimplementedInfoServer) mustEmbedUnimplementedInfoServer() {}
 
-// UnsafeBuilderServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to BuilderServer will
+// UnsafeInfoServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InfoServer will
 // result in compilation errors.
-type UnsafeBuilderServer interface {
-	mustEmbedUnimplementedBuilderServer()
+type UnsafeInfoServer interface {
+	mustEmbedUnimplementedInfoServer()
 }
 
-func RegisterBuilderServer(s grpc.ServiceRegistrar, srv BuilderServer) {
-	s.RegisterService(&Builder_ServiceDesc, srv)
+func RegisterInfoServer(s grpc.ServiceRegistrar, srv InfoServer) {
+	s.RegisterService(&Info_ServiceDesc, srv)
 }
 
-func _Builder_IsAuthenticator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Info_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BuilderServer).IsAuthenticator(ctx, in)
+		return srv.(InfoServer).Info(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Builder/IsAuthenticator",
+		FullMethod: "/hashicorp.waypoint.sdk.Info/Info",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BuilderServer).IsAuthenticator(ctx, req.(*emptypb.Empty))
+		return srv.(InfoServer).Info(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Builder_Auth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FuncSpec_Args)
+func _Info_Negotiate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Features_NegotiateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BuilderServer).Auth(ctx, in)
+		return srv.(InfoServer).Negotiate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Builder/Auth",
+		FullMethod: "/hashicorp.waypoint.sdk.Info/Negotiate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BuilderServer).Auth(ctx, req.(*FuncSpec_Args))
+		return srv.(InfoServer).Negotiate(ctx, req.(*Features_NegotiateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Builder_AuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Info_Components_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BuilderServer).AuthSpec(ctx, in)
+		return srv.(InfoServer).Components(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Builder/AuthSpec",
+		FullMethod: "/hashicorp.waypoint.sdk.Info/Components",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BuilderServer).AuthSpec(ctx, req.(*emptypb.Empty))
+		return srv.(InfoServer).Components(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Builder_ValidateAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FuncSpec_Args)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(BuilderServer).ValidateAuth(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Builder/ValidateAuth",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BuilderServer).ValidateAuth(ctx, req.(*FuncSpec_Args))
-	}
-	return interceptor(ctx, in, info, handler)
+// Info_ServiceDesc is the grpc.ServiceDesc for Info service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Info_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.waypoint.sdk.Info",
+	HandlerType: (*InfoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Info",
+			Handler:    _Info_Info_Handler,
+		},
+		{
+			MethodName: "Negotiate",
+			Handler:    _Info_Negotiate_Handler,
+		},
+		{
+			MethodName: "Components",
+			Handler:    _Info_Components_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "plugin.proto",
 }
 
-func _Builder_ValidateAuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(BuilderServer).ValidateAuthSpec(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Builder/ValidateAuthSpec",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BuilderServer).ValidateAuthSpec(ctx, req.(*emptypb.Empty))
-	}
-	return interceptor(ctx, in, info, handler)
+// MapperClient is the client API for Mapper service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MapperClient interface {
+	// ListMappers returns the list of mappers that this plugin supports.
+	ListMappers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Map_ListResponse, error)
+	// Map executes a mapper.
+	Map(ctx context.Context, in *Map_Request, opts ...grpc.CallOption) (*Map_Response, error)
+	// MapBatch executes several mappers in one call. Each request succeeds
+	// or fails on its own, the results are in the order of the requests.
+	MapBatch(ctx context.Context, in *Map_BatchRequest, opts ...grpc.CallOption) (*Map_BatchResponse, error)
 }
 
-func _Builder_ConfigStruct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(BuilderServer).ConfigStruct(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Builder/ConfigStruct",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BuilderServer).ConfigStruct(ctx, req.(*emptypb.Empty))
-	}
-	return interceptor(ctx, in, info, handler)
+type mapperClient struct {
+	cc grpc.ClientConnInterface
 }
 
-func _Builder_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Config_ConfigureRequest)
-	if err := dec(in); err != nil {
+func NewMapperClient(cc grpc.ClientConnInterface) MapperClient {
+	return &mapperClient{cc}
+}
+
+func (c *mapperClient) ListMappers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Map_ListResponse, error) {
+	out := new(Map_ListResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Mapper/ListMappers", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(BuilderServer).Configure(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Builder/Configure",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BuilderServer).Configure(ctx, req.(*Config_ConfigureRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Builder_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
-	if err := dec(in); err != nil {
+func (c *mapperClient) Map(ctx context.Context, in *Map_Request, opts ...grpc.CallOption) (*Map_Response, error) {
+	out := new(Map_Response)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Mapper/Map", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(BuilderServer).Documentation(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Builder/Documentation",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BuilderServer).Documentation(ctx, req.(*emptypb.Empty))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Builder_BuildSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
-	if err := dec(in); err != nil {
+func (c *mapperClient) MapBatch(ctx context.Context, in *Map_BatchRequest, opts ...grpc.CallOption) (*Map_BatchResponse, error) {
+	out := new(Map_BatchResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Mapper/MapBatch", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(BuilderServer).BuildSpec(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Builder/BuildSpec",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BuilderServer).BuildSpec(ctx, req.(*emptypb.Empty))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Builder_Build_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FuncSpec_Args)
+// MapperServer is the server API for Mapper service.
+// All implementations must embed UnimplementedMapperServer
+// for forward compatibility
+type MapperServer interface {
+	// ListMappers returns the list of mappers that this plugin supports.
+	ListMappers(context.Context, *emptypb.Empty) (*Map_ListResponse, error)
+	// Map executes a mapper.
+	Map(context.Context, *Map_Request) (*Map_Response, error)
+	// MapBatch executes several mappers in one call. Each request succeeds
+	// or fails on its own, the results are in the order of the requests.
+	MapBatch(context.Context, *Map_BatchRequest) (*Map_BatchResponse, error)
+	mustEmbedUnimplementedMapperServer()
+}
+
+// UnimplementedMapperServer must be embedded to have forward compatible implementations.
+type UnimplementedMapperServer struct {
+}
+
+func (UnimplementedMapperServer) ListMappers(context.Context, *emptypb.Empty) (*Map_ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMappers not implemented")
+}
+func (UnimplementedMapperServer) Map(context.Context, *Map_Request) (*Map_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Map not implemented")
+}
+func (UnimplementedMapperServer) MapBatch(context.Context, *Map_BatchRequest) (*Map_BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MapBatch not implemented")
+}
+func (UnimplementedMapperServer) mustEmbedUnimplementedMapperServer() {}
+
+// UnsafeMapperServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MapperServer will
+// result in compilation errors.
+type UnsafeMapperServer interface {
+	mustEmbedUnimplementedMapperServer()
+}
+
+func RegisterMapperServer(s grpc.ServiceRegistrar, srv MapperServer) {
+	s.RegisterService(&Mapper_ServiceDesc, srv)
+}
+
+func _Mapper_ListMappers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BuilderServer).Build(ctx, in)
+		return srv.(MapperServer).ListMappers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Builder/Build",
+		FullMethod: "/hashicorp.waypoint.sdk.Mapper/ListMappers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BuilderServer).Build(ctx, req.(*FuncSpec_Args))
+		return srv.(MapperServer).ListMappers(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Builder_BuildODR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FuncSpec_Args)
+func _Mapper_Map_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Map_Request)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BuilderServer).BuildODR(ctx, in)
+		return srv.(MapperServer).Map(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Builder/BuildODR",
+		FullMethod: "/hashicorp.waypoint.sdk.Mapper/Map",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BuilderServer).BuildODR(ctx, req.(*FuncSpec_Args))
+		return srv.(MapperServer).Map(ctx, req.(*Map_Request))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Builder_BuildSpecODR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Mapper_MapBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Map_BatchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BuilderServer).BuildSpecODR(ctx, in)
+		return srv.(MapperServer).MapBatch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Builder/BuildSpecODR",
+		FullMethod: "/hashicorp.waypoint.sdk.Mapper/MapBatch",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BuilderServer).BuildSpecODR(ctx, req.(*emptypb.Empty))
+		return srv.(MapperServer).MapBatch(ctx, req.(*Map_BatchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// Builder_ServiceDesc is the grpc.ServiceDesc for Builder service.
+// Mapper_ServiceDesc is the grpc.ServiceDesc for Mapper service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var Builder_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "hashicorp.waypoint.sdk.Builder",
-	HandlerType: (*BuilderServer)(nil),
+var Mapper_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.waypoint.sdk.Mapper",
+	HandlerType: (*MapperServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "IsAuthenticator",
-			Handler:    _Builder_IsAuthenticator_Handler,
-		},
-		{
-			MethodName: "Auth",
-			Handler:    _Builder_Auth_Handler,
-		},
-		{
-			MethodName: "AuthSpec",
-			Handler:    _Builder_AuthSpec_Handler,
-		},
-		{
-			MethodName: "ValidateAuth",
-			Handler:    _Builder_ValidateAuth_Handler,
-		},
-		{
-			MethodName: "ValidateAuthSpec",
-			Handler:    _Builder_ValidateAuthSpec_Handler,
-		},
-		{
-			MethodName: "ConfigStruct",
-			Handler:    _Builder_ConfigStruct_Handler,
-		},
-		{
-			MethodName: "Configure",
-			Handler:    _Builder_Configure_Handler,
-		},
-		{
-			MethodName: "Documentation",
-			Handler:    _Builder_Documentation_Handler,
-		},
-		{
-			MethodName: "BuildSpec",
-			Handler:    _Builder_BuildSpec_Handler,
-		},
-		{
-			MethodName: "Build",
-			Handler:    _Builder_Build_Handler,
+			MethodName: "ListMappers",
+			Handler:    _Mapper_ListMappers_Handler,
 		},
 		{
-			MethodName: "BuildODR",
-			Handler:    _Builder_BuildODR_Handler,
+			MethodName: "Map",
+			Handler:    _Mapper_Map_Handler,
 		},
 		{
-			MethodName: "BuildSpecODR",
-			Handler:    _Builder_BuildSpecODR_Handler,
+			MethodName: "MapBatch",
+			Handler:    _Mapper_MapBatch_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "plugin.proto",
 }
 
-// PlatformClient is the client API for Platform service.
+// BuilderClient is the client API for Builder service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type PlatformClient interface {
+type BuilderClient interface {
 	IsAuthenticator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
 	Auth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error)
 	AuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
 	ValidateAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ValidateAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	// component.AuthRefresher optional implementation
+	IsAuthRefresher(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	RefreshAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	RefreshAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error)
 	ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error)
 	Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ConfigSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_SchemaResp, error)
+	ConfigureValue(ctx context.Context, in *Config_ConfigureValueRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
-	DeploySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	Deploy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Deploy_Resp, error)
-	// component.PlatformReleaser optional implementation.
-	DefaultReleaserSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	DefaultReleaser(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*DefaultReleaser_Resp, error)
+	BuildSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Build(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Build_Resp, error)
 	// component.Destroyer optional implementation
 	IsDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
 	DestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
 	Destroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Destroy_Resp, error)
+	// component.DestroyHooks optional implementation. The spec RPCs return
+	// codes.Unimplemented if the hook isn't implemented.
+	PreDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	PreDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PostDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	PostDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// component.WorkspaceDestroyer optional implementation
 	IsWorkspaceDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
 	DestroyWorkspaceSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
 	DestroyWorkspace(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	// component.Execer optional implementation
-	IsExecer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
-	ExecSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	Exec(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*ExecResult, error)
-	// component.LogViewer optional implementation
-	// IsLogPlatform returns true if this platform also implements LogPlatform.
-	IsLogPlatform(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
-	LogsSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	Logs(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	// component.Generation optional implementation
-	IsGeneration(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
-	GenerationSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	Generation(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Generation_Resp, error)
-	// component.Status optional implementation
-	IsStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
-	StatusSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	Status(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*StatusReport, error)
+	BuildODR(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Build_Resp, error)
+	BuildSpecODR(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	// component.BuilderMulti optional implementation
+	IsBuilderMulti(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	BuildManySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	BuildMany(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Build_MultiResp, error)
+	// component.Signer optional implementation
+	IsSigner(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	SignSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Sign(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Signature, error)
+	VerifySignatureSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	VerifySignature(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// component.Validator optional implementation
+	IsValidator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	ValidateSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Validate(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*ValidationResult, error)
+	// component.ConfigDefaulter optional implementation
+	IsConfigDefaulter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	DefaultsSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Defaults(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Config_Effective, error)
+	// component.ODRProfiler optional implementation
+	IsODRProfiler(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	ODRRequirements(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ODR_Requirements, error)
 }
 
-type platformClient struct {
+type builderClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewPlatformClient(cc grpc.ClientConnInterface) PlatformClient {
-	return &platformClient{cc}
+func NewBuilderClient(cc grpc.ClientConnInterface) BuilderClient {
+	return &builderClient{cc}
 }
 
-func (c *platformClient) IsAuthenticator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+func (c *builderClient) IsAuthenticator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
 	out := new(ImplementsResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsAuthenticator", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/IsAuthenticator", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) Auth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error) {
+func (c *builderClient) Auth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error) {
 	out := new(Auth_AuthResponse)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Auth", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/Auth", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) AuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *builderClient) AuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/AuthSpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/AuthSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) ValidateAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *builderClient) ValidateAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/ValidateAuth", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/ValidateAuth", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) ValidateAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *builderClient) ValidateAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/ValidateAuthSpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/ValidateAuthSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error) {
+func (c *builderClient) IsAuthRefresher(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/IsAuthRefresher", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *builderClient) RefreshAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/RefreshAuthSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *builderClient) RefreshAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error) {
+	out := new(Auth_AuthResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/RefreshAuth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *builderClient) ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error) {
 	out := new(Config_StructResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/ConfigStruct", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/ConfigStruct", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *builderClient) Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Configure", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/Configure", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
-	out := new(Config_Documentation)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Documentation", in, out, opts...)
+func (c *builderClient) ConfigSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_SchemaResp, error) {
+	out := new(Config_SchemaResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/ConfigSchema", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) DeploySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
-	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DeploySpec", in, out, opts...)
+func (c *builderClient) ConfigureValue(ctx context.Context, in *Config_ConfigureValueRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/ConfigureValue", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) Deploy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Deploy_Resp, error) {
-	out := new(Deploy_Resp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Deploy", in, out, opts...)
+func (c *builderClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
+	out := new(Config_Documentation)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/Documentation", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) DefaultReleaserSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *builderClient) BuildSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DefaultReleaserSpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/BuildSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) DefaultReleaser(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*DefaultReleaser_Resp, error) {
-	out := new(DefaultReleaser_Resp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DefaultReleaser", in, out, opts...)
+func (c *builderClient) Build(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Build_Resp, error) {
+	out := new(Build_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/Build", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) IsDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+func (c *builderClient) IsDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
 	out := new(ImplementsResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsDestroyer", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/IsDestroyer", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) DestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *builderClient) DestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DestroySpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/DestroySpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) Destroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Destroy_Resp, error) {
+func (c *builderClient) Destroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Destroy_Resp, error) {
 	out := new(Destroy_Resp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Destroy", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/Destroy", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) IsWorkspaceDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+func (c *builderClient) PreDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/PreDestroySpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *builderClient) PreDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/PreDestroy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *builderClient) PostDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/PostDestroySpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *builderClient) PostDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/PostDestroy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *builderClient) IsWorkspaceDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
 	out := new(ImplementsResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsWorkspaceDestroyer", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/IsWorkspaceDestroyer", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) DestroyWorkspaceSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *builderClient) DestroyWorkspaceSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DestroyWorkspaceSpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/DestroyWorkspaceSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) DestroyWorkspace(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *builderClient) DestroyWorkspace(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DestroyWorkspace", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/DestroyWorkspace", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) IsExecer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+func (c *builderClient) BuildODR(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Build_Resp, error) {
+	out := new(Build_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/BuildODR", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *builderClient) BuildSpecODR(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/BuildSpecODR", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *builderClient) IsBuilderMulti(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
 	out := new(ImplementsResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsExecer", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/IsBuilderMulti", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) ExecSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *builderClient) BuildManySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/ExecSpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/BuildManySpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) Exec(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*ExecResult, error) {
-	out := new(ExecResult)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Exec", in, out, opts...)
+func (c *builderClient) BuildMany(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Build_MultiResp, error) {
+	out := new(Build_MultiResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/BuildMany", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) IsLogPlatform(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+func (c *builderClient) IsSigner(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
 	out := new(ImplementsResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsLogPlatform", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/IsSigner", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) LogsSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *builderClient) SignSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/LogsSpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/SignSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) Logs(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *builderClient) Sign(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Signature, error) {
+	out := new(Signature)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/Sign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *builderClient) VerifySignatureSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/VerifySignatureSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *builderClient) VerifySignature(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Logs", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/VerifySignature", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) IsGeneration(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+func (c *builderClient) IsValidator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
 	out := new(ImplementsResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsGeneration", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/IsValidator", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) GenerationSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *builderClient) ValidateSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/GenerationSpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/ValidateSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) Generation(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Generation_Resp, error) {
-	out := new(Generation_Resp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Generation", in, out, opts...)
+func (c *builderClient) Validate(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*ValidationResult, error) {
+	out := new(ValidationResult)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/Validate", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) IsStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+func (c *builderClient) IsConfigDefaulter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
 	out := new(ImplementsResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsStatus", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/IsConfigDefaulter", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) StatusSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *builderClient) DefaultsSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/StatusSpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/DefaultsSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *platformClient) Status(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*StatusReport, error) {
-	out := new(StatusReport)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Status", in, out, opts...)
+func (c *builderClient) Defaults(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Config_Effective, error) {
+	out := new(Config_Effective)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/Defaults", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// PlatformServer is the server API for Platform service.
-// All implementations must embed UnimplementedPlatformServer
+func (c *builderClient) IsODRProfiler(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/IsODRProfiler", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *builderClient) ODRRequirements(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ODR_Requirements, error) {
+	out := new(ODR_Requirements)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Builder/ODRRequirements", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BuilderServer is the server API for Builder service.
+// All implementations must embed UnimplementedBuilderServer
 // for forward compatibility
-type PlatformServer interface {
+type BuilderServer interface {
 	IsAuthenticator(context.Context, *emptypb.Empty) (*ImplementsResp, error)
 	Auth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error)
 	AuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
 	ValidateAuth(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
 	ValidateAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	// component.AuthRefresher optional implementation
+	IsAuthRefresher(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	RefreshAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	RefreshAuth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error)
 	ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error)
 	Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error)
+	ConfigSchema(context.Context, *emptypb.Empty) (*Config_SchemaResp, error)
+	ConfigureValue(context.Context, *Config_ConfigureValueRequest) (*emptypb.Empty, error)
 	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
-	DeploySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	Deploy(context.Context, *FuncSpec_Args) (*Deploy_Resp, error)
-	// component.PlatformReleaser optional implementation.
-	DefaultReleaserSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	DefaultReleaser(context.Context, *FuncSpec_Args) (*DefaultReleaser_Resp, error)
+	BuildSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Build(context.Context, *FuncSpec_Args) (*Build_Resp, error)
 	// component.Destroyer optional implementation
 	IsDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error)
 	DestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
 	Destroy(context.Context, *FuncSpec_Args) (*Destroy_Resp, error)
+	// component.DestroyHooks optional implementation. The spec RPCs return
+	// codes.Unimplemented if the hook isn't implemented.
+	PreDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	PreDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	PostDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	PostDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
 	// component.WorkspaceDestroyer optional implementation
 	IsWorkspaceDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error)
 	DestroyWorkspaceSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
 	DestroyWorkspace(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
-	// component.Execer optional implementation
-	IsExecer(context.Context, *emptypb.Empty) (*ImplementsResp, error)
-	ExecSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	Exec(context.Context, *FuncSpec_Args) (*ExecResult, error)
-	// component.LogViewer optional implementation
-	// IsLogPlatform returns true if this platform also implements LogPlatform.
-	IsLogPlatform(context.Context, *emptypb.Empty) (*ImplementsResp, error)
-	LogsSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	Logs(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
-	// component.Generation optional implementation
-	IsGeneration(context.Context, *emptypb.Empty) (*ImplementsResp, error)
-	GenerationSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	Generation(context.Context, *FuncSpec_Args) (*Generation_Resp, error)
-	// component.Status optional implementation
-	IsStatus(context.Context, *emptypb.Empty) (*ImplementsResp, error)
-	StatusSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	Status(context.Context, *FuncSpec_Args) (*StatusReport, error)
-	mustEmbedUnimplementedPlatformServer()
+	BuildODR(context.Context, *FuncSpec_Args) (*Build_Resp, error)
+	BuildSpecODR(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	// component.BuilderMulti optional implementation
+	IsBuilderMulti(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	BuildManySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	BuildMany(context.Context, *FuncSpec_Args) (*Build_MultiResp, error)
+	// component.Signer optional implementation
+	IsSigner(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	SignSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Sign(context.Context, *FuncSpec_Args) (*Signature, error)
+	VerifySignatureSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	VerifySignature(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	// component.Validator optional implementation
+	IsValidator(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	ValidateSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Validate(context.Context, *FuncSpec_Args) (*ValidationResult, error)
+	// component.ConfigDefaulter optional implementation
+	IsConfigDefaulter(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	DefaultsSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Defaults(context.Context, *FuncSpec_Args) (*Config_Effective, error)
+	// component.ODRProfiler optional implementation
+	IsODRProfiler(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	ODRRequirements(context.Context, *emptypb.Empty) (*ODR_Requirements, error)
+	mustEmbedUnimplementedBuilderServer()
 }
 
-// UnimplementedPlatformServer must be embedded to have forward compatible implementations.
-type UnimplementedPlatformServer struct {
+// UnimplementedBuilderServer must be embedded to have forward compatible implementations.
+type UnimplementedBuilderServer struct {
 }
 
-func (UnimplementedPlatformServer) IsAuthenticator(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+func (UnimplementedBuilderServer) IsAuthenticator(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsAuthenticator not implemented")
 }
-func (UnimplementedPlatformServer) Auth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error) {
+func (UnimplementedBuilderServer) Auth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Auth not implemented")
 }
-func (UnimplementedPlatformServer) AuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+func (UnimplementedBuilderServer) AuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AuthSpec not implemented")
 }
-func (UnimplementedPlatformServer) ValidateAuth(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+func (UnimplementedBuilderServer) ValidateAuth(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ValidateAuth not implemented")
 }
-func (UnimplementedPlatformServer) ValidateAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+func (UnimplementedBuilderServer) ValidateAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ValidateAuthSpec not implemented")
 }
-func (UnimplementedPlatformServer) ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error) {
+func (UnimplementedBuilderServer) IsAuthRefresher(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsAuthRefresher not implemented")
+}
+func (UnimplementedBuilderServer) RefreshAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshAuthSpec not implemented")
+}
+func (UnimplementedBuilderServer) RefreshAuth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshAuth not implemented")
+}
+func (UnimplementedBuilderServer) ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ConfigStruct not implemented")
 }
-func (UnimplementedPlatformServer) Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error) {
+func (UnimplementedBuilderServer) Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
 }
-func (UnimplementedPlatformServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
+func (UnimplementedBuilderServer) ConfigSchema(context.Context, *emptypb.Empty) (*Config_SchemaResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigSchema not implemented")
 }
-func (UnimplementedPlatformServer) DeploySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeploySpec not implemented")
+func (UnimplementedBuilderServer) ConfigureValue(context.Context, *Config_ConfigureValueRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigureValue not implemented")
 }
-func (UnimplementedPlatformServer) Deploy(context.Context, *FuncSpec_Args) (*Deploy_Resp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Deploy not implemented")
+func (UnimplementedBuilderServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
 }
-func (UnimplementedPlatformServer) DefaultReleaserSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DefaultReleaserSpec not implemented")
+func (UnimplementedBuilderServer) BuildSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuildSpec not implemented")
 }
-func (UnimplementedPlatformServer) DefaultReleaser(context.Context, *FuncSpec_Args) (*DefaultReleaser_Resp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DefaultReleaser not implemented")
+func (UnimplementedBuilderServer) Build(context.Context, *FuncSpec_Args) (*Build_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Build not implemented")
 }
-func (UnimplementedPlatformServer) IsDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+func (UnimplementedBuilderServer) IsDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsDestroyer not implemented")
 }
-func (UnimplementedPlatformServer) DestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+func (UnimplementedBuilderServer) DestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroySpec not implemented")
 }
-func (UnimplementedPlatformServer) Destroy(context.Context, *FuncSpec_Args) (*Destroy_Resp, error) {
+func (UnimplementedBuilderServer) Destroy(context.Context, *FuncSpec_Args) (*Destroy_Resp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Destroy not implemented")
 }
-func (UnimplementedPlatformServer) IsWorkspaceDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+func (UnimplementedBuilderServer) PreDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreDestroySpec not implemented")
+}
+func (UnimplementedBuilderServer) PreDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreDestroy not implemented")
+}
+func (UnimplementedBuilderServer) PostDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostDestroySpec not implemented")
+}
+func (UnimplementedBuilderServer) PostDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostDestroy not implemented")
+}
+func (UnimplementedBuilderServer) IsWorkspaceDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsWorkspaceDestroyer not implemented")
 }
-func (UnimplementedPlatformServer) DestroyWorkspaceSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+func (UnimplementedBuilderServer) DestroyWorkspaceSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyWorkspaceSpec not implemented")
 }
-func (UnimplementedPlatformServer) DestroyWorkspace(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+func (UnimplementedBuilderServer) DestroyWorkspace(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyWorkspace not implemented")
 }
-func (UnimplementedPlatformServer) IsExecer(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IsExecer not implemented")
+func (UnimplementedBuilderServer) BuildODR(context.Context, *FuncSpec_Args) (*Build_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuildODR not implemented")
 }
-func (UnimplementedPlatformServer) ExecSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ExecSpec not implemented")
+func (UnimplementedBuilderServer) BuildSpecODR(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuildSpecODR not implemented")
 }
-func (UnimplementedPlatformServer) Exec(context.Context, *FuncSpec_Args) (*ExecResult, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
+func (UnimplementedBuilderServer) IsBuilderMulti(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsBuilderMulti not implemented")
 }
-func (UnimplementedPlatformServer) IsLogPlatform(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IsLogPlatform not implemented")
+func (UnimplementedBuilderServer) BuildManySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuildManySpec not implemented")
 }
-func (UnimplementedPlatformServer) LogsSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LogsSpec not implemented")
+func (UnimplementedBuilderServer) BuildMany(context.Context, *FuncSpec_Args) (*Build_MultiResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuildMany not implemented")
 }
-func (UnimplementedPlatformServer) Logs(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Logs not implemented")
+func (UnimplementedBuilderServer) IsSigner(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsSigner not implemented")
 }
-func (UnimplementedPlatformServer) IsGeneration(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IsGeneration not implemented")
+func (UnimplementedBuilderServer) SignSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignSpec not implemented")
 }
-func (UnimplementedPlatformServer) GenerationSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GenerationSpec not implemented")
+func (UnimplementedBuilderServer) Sign(context.Context, *FuncSpec_Args) (*Signature, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sign not implemented")
 }
-func (UnimplementedPlatformServer) Generation(context.Context, *FuncSpec_Args) (*Generation_Resp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Generation not implemented")
+func (UnimplementedBuilderServer) VerifySignatureSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifySignatureSpec not implemented")
 }
-func (UnimplementedPlatformServer) IsStatus(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IsStatus not implemented")
+func (UnimplementedBuilderServer) VerifySignature(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifySignature not implemented")
 }
-func (UnimplementedPlatformServer) StatusSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method StatusSpec not implemented")
+func (UnimplementedBuilderServer) IsValidator(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsValidator not implemented")
 }
-func (UnimplementedPlatformServer) Status(context.Context, *FuncSpec_Args) (*StatusReport, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+func (UnimplementedBuilderServer) ValidateSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateSpec not implemented")
 }
-func (UnimplementedPlatformServer) mustEmbedUnimplementedPlatformServer() {}
+func (UnimplementedBuilderServer) Validate(context.Context, *FuncSpec_Args) (*ValidationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedBuilderServer) IsConfigDefaulter(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsConfigDefaulter not implemented")
+}
+func (UnimplementedBuilderServer) DefaultsSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DefaultsSpec not implemented")
+}
+func (UnimplementedBuilderServer) Defaults(context.Context, *FuncSpec_Args) (*Config_Effective, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Defaults not implemented")
+}
+func (UnimplementedBuilderServer) IsODRProfiler(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsODRProfiler not implemented")
+}
+func (UnimplementedBuilderServer) ODRRequirements(context.Context, *emptypb.Empty) (*ODR_Requirements, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ODRRequirements not implemented")
+}
+func (UnimplementedBuilderServer) mustEmbedUnimplementedBuilderServer() {}
 
-// UnsafePlatformServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to PlatformServer will
+// UnsafeBuilderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BuilderServer will
 // result in compilation errors.
-type UnsafePlatformServer interface {
-	mustEmbedUnimplementedPlatformServer()
+type UnsafeBuilderServer interface {
+	mustEmbedUnimplementedBuilderServer()
 }
 
-func RegisterPlatformServer(s grpc.ServiceRegistrar, srv PlatformServer) {
-	s.RegisterService(&Platform_ServiceDesc, srv)
+func RegisterBuilderServer(s grpc.ServiceRegistrar, srv BuilderServer) {
+	s.RegisterService(&Builder_ServiceDesc, srv)
 }
 
-func _Platform_IsAuthenticator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_IsAuthenticator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).IsAuthenticator(ctx, in)
+		return srv.(BuilderServer).IsAuthenticator(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsAuthenticator",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/IsAuthenticator",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).IsAuthenticator(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).IsAuthenticator(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_Auth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_Auth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).Auth(ctx, in)
+		return srv.(BuilderServer).Auth(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/Auth",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/Auth",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).Auth(ctx, req.(*FuncSpec_Args))
+		return srv.(BuilderServer).Auth(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_AuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_AuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).AuthSpec(ctx, in)
+		return srv.(BuilderServer).AuthSpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/AuthSpec",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/AuthSpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).AuthSpec(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).AuthSpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_ValidateAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_ValidateAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).ValidateAuth(ctx, in)
+		return srv.(BuilderServer).ValidateAuth(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/ValidateAuth",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/ValidateAuth",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).ValidateAuth(ctx, req.(*FuncSpec_Args))
+		return srv.(BuilderServer).ValidateAuth(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_ValidateAuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_ValidateAuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).ValidateAuthSpec(ctx, in)
+		return srv.(BuilderServer).ValidateAuthSpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/ValidateAuthSpec",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/ValidateAuthSpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).ValidateAuthSpec(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).ValidateAuthSpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_ConfigStruct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_IsAuthRefresher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).ConfigStruct(ctx, in)
+		return srv.(BuilderServer).IsAuthRefresher(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/ConfigStruct",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/IsAuthRefresher",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).ConfigStruct(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).IsAuthRefresher(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Config_ConfigureRequest)
+func _Builder_RefreshAuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).Configure(ctx, in)
+		return srv.(BuilderServer).RefreshAuthSpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/Configure",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/RefreshAuthSpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).Configure(ctx, req.(*Config_ConfigureRequest))
+		return srv.(BuilderServer).RefreshAuthSpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Builder_RefreshAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).Documentation(ctx, in)
+		return srv.(BuilderServer).RefreshAuth(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/Documentation",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/RefreshAuth",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).Documentation(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).RefreshAuth(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_DeploySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_ConfigStruct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).DeploySpec(ctx, in)
+		return srv.(BuilderServer).ConfigStruct(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/DeploySpec",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/ConfigStruct",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).DeploySpec(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).ConfigStruct(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_Deploy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FuncSpec_Args)
+func _Builder_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config_ConfigureRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).Deploy(ctx, in)
+		return srv.(BuilderServer).Configure(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/Deploy",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/Configure",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).Deploy(ctx, req.(*FuncSpec_Args))
+		return srv.(BuilderServer).Configure(ctx, req.(*Config_ConfigureRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_DefaultReleaserSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_ConfigSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).DefaultReleaserSpec(ctx, in)
+		return srv.(BuilderServer).ConfigSchema(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/DefaultReleaserSpec",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/ConfigSchema",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).DefaultReleaserSpec(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).ConfigSchema(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_DefaultReleaser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FuncSpec_Args)
+func _Builder_ConfigureValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config_ConfigureValueRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).DefaultReleaser(ctx, in)
+		return srv.(BuilderServer).ConfigureValue(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/DefaultReleaser",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/ConfigureValue",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).DefaultReleaser(ctx, req.(*FuncSpec_Args))
+		return srv.(BuilderServer).ConfigureValue(ctx, req.(*Config_ConfigureValueRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_IsDestroyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).IsDestroyer(ctx, in)
+		return srv.(BuilderServer).Documentation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsDestroyer",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/Documentation",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).IsDestroyer(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).Documentation(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_DestroySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_BuildSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).DestroySpec(ctx, in)
+		return srv.(BuilderServer).BuildSpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/DestroySpec",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/BuildSpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).DestroySpec(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).BuildSpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_Destroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_Build_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).Destroy(ctx, in)
+		return srv.(BuilderServer).Build(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/Destroy",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/Build",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).Destroy(ctx, req.(*FuncSpec_Args))
+		return srv.(BuilderServer).Build(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_IsWorkspaceDestroyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_IsDestroyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).IsWorkspaceDestroyer(ctx, in)
+		return srv.(BuilderServer).IsDestroyer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsWorkspaceDestroyer",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/IsDestroyer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).IsWorkspaceDestroyer(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).IsDestroyer(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_DestroyWorkspaceSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_DestroySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).DestroyWorkspaceSpec(ctx, in)
+		return srv.(BuilderServer).DestroySpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/DestroyWorkspaceSpec",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/DestroySpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).DestroyWorkspaceSpec(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).DestroySpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_DestroyWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_Destroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).DestroyWorkspace(ctx, in)
+		return srv.(BuilderServer).Destroy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/DestroyWorkspace",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/Destroy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).DestroyWorkspace(ctx, req.(*FuncSpec_Args))
+		return srv.(BuilderServer).Destroy(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_IsExecer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_PreDestroySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).IsExecer(ctx, in)
+		return srv.(BuilderServer).PreDestroySpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsExecer",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/PreDestroySpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).IsExecer(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).PreDestroySpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_ExecSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Builder_PreDestroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).ExecSpec(ctx, in)
+		return srv.(BuilderServer).PreDestroy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/ExecSpec",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/PreDestroy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).ExecSpec(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).PreDestroy(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FuncSpec_Args)
+func _Builder_PostDestroySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).Exec(ctx, in)
+		return srv.(BuilderServer).PostDestroySpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/Exec",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/PostDestroySpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).Exec(ctx, req.(*FuncSpec_Args))
+		return srv.(BuilderServer).PostDestroySpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_IsLogPlatform_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Builder_PostDestroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).IsLogPlatform(ctx, in)
+		return srv.(BuilderServer).PostDestroy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsLogPlatform",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/PostDestroy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).IsLogPlatform(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).PostDestroy(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_LogsSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_IsWorkspaceDestroyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).LogsSpec(ctx, in)
+		return srv.(BuilderServer).IsWorkspaceDestroyer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/LogsSpec",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/IsWorkspaceDestroyer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).LogsSpec(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).IsWorkspaceDestroyer(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_Logs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FuncSpec_Args)
+func _Builder_DestroyWorkspaceSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).Logs(ctx, in)
+		return srv.(BuilderServer).DestroyWorkspaceSpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/Logs",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/DestroyWorkspaceSpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).Logs(ctx, req.(*FuncSpec_Args))
+		return srv.(BuilderServer).DestroyWorkspaceSpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_IsGeneration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Builder_DestroyWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).IsGeneration(ctx, in)
+		return srv.(BuilderServer).DestroyWorkspace(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsGeneration",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/DestroyWorkspace",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).IsGeneration(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).DestroyWorkspace(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_GenerationSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Builder_BuildODR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).GenerationSpec(ctx, in)
+		return srv.(BuilderServer).BuildODR(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/GenerationSpec",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/BuildODR",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).GenerationSpec(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).BuildODR(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_Generation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FuncSpec_Args)
+func _Builder_BuildSpecODR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).Generation(ctx, in)
+		return srv.(BuilderServer).BuildSpecODR(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/Generation",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/BuildSpecODR",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).Generation(ctx, req.(*FuncSpec_Args))
+		return srv.(BuilderServer).BuildSpecODR(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_IsStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_IsBuilderMulti_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).IsStatus(ctx, in)
+		return srv.(BuilderServer).IsBuilderMulti(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsStatus",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/IsBuilderMulti",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).IsStatus(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).IsBuilderMulti(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_StatusSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_BuildManySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).StatusSpec(ctx, in)
+		return srv.(BuilderServer).BuildManySpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/StatusSpec",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/BuildManySpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).StatusSpec(ctx, req.(*emptypb.Empty))
+		return srv.(BuilderServer).BuildManySpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Platform_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Builder_BuildMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlatformServer).Status(ctx, in)
+		return srv.(BuilderServer).BuildMany(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Platform/Status",
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/BuildMany",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlatformServer).Status(ctx, req.(*FuncSpec_Args))
+		return srv.(BuilderServer).BuildMany(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// Platform_ServiceDesc is the grpc.ServiceDesc for Platform service.
+func _Builder_IsSigner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuilderServer).IsSigner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/IsSigner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuilderServer).IsSigner(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Builder_SignSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuilderServer).SignSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/SignSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuilderServer).SignSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Builder_Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuilderServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/Sign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuilderServer).Sign(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Builder_VerifySignatureSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuilderServer).VerifySignatureSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/VerifySignatureSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuilderServer).VerifySignatureSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Builder_VerifySignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuilderServer).VerifySignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/VerifySignature",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuilderServer).VerifySignature(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Builder_IsValidator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuilderServer).IsValidator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/IsValidator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuilderServer).IsValidator(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Builder_ValidateSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuilderServer).ValidateSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/ValidateSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuilderServer).ValidateSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Builder_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuilderServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/Validate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuilderServer).Validate(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Builder_IsConfigDefaulter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuilderServer).IsConfigDefaulter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/IsConfigDefaulter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuilderServer).IsConfigDefaulter(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Builder_DefaultsSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuilderServer).DefaultsSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/DefaultsSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuilderServer).DefaultsSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Builder_Defaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuilderServer).Defaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/Defaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuilderServer).Defaults(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Builder_IsODRProfiler_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuilderServer).IsODRProfiler(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/IsODRProfiler",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuilderServer).IsODRProfiler(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Builder_ODRRequirements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuilderServer).ODRRequirements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Builder/ODRRequirements",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuilderServer).ODRRequirements(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Builder_ServiceDesc is the grpc.ServiceDesc for Builder service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var Platform_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "hashicorp.waypoint.sdk.Platform",
-	HandlerType: (*PlatformServer)(nil),
+var Builder_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.waypoint.sdk.Builder",
+	HandlerType: (*BuilderServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
 			MethodName: "IsAuthenticator",
-			Handler:    _Platform_IsAuthenticator_Handler,
+			Handler:    _Builder_IsAuthenticator_Handler,
 		},
 		{
 			MethodName: "Auth",
-			Handler:    _Platform_Auth_Handler,
+			Handler:    _Builder_Auth_Handler,
 		},
 		{
 			MethodName: "AuthSpec",
-			Handler:    _Platform_AuthSpec_Handler,
+			Handler:    _Builder_AuthSpec_Handler,
 		},
 		{
 			MethodName: "ValidateAuth",
-			Handler:    _Platform_ValidateAuth_Handler,
+			Handler:    _Builder_ValidateAuth_Handler,
 		},
 		{
 			MethodName: "ValidateAuthSpec",
-			Handler:    _Platform_ValidateAuthSpec_Handler,
+			Handler:    _Builder_ValidateAuthSpec_Handler,
+		},
+		{
+			MethodName: "IsAuthRefresher",
+			Handler:    _Builder_IsAuthRefresher_Handler,
+		},
+		{
+			MethodName: "RefreshAuthSpec",
+			Handler:    _Builder_RefreshAuthSpec_Handler,
+		},
+		{
+			MethodName: "RefreshAuth",
+			Handler:    _Builder_RefreshAuth_Handler,
 		},
 		{
 			MethodName: "ConfigStruct",
-			Handler:    _Platform_ConfigStruct_Handler,
+			Handler:    _Builder_ConfigStruct_Handler,
 		},
 		{
 			MethodName: "Configure",
-			Handler:    _Platform_Configure_Handler,
+			Handler:    _Builder_Configure_Handler,
 		},
 		{
-			MethodName: "Documentation",
-			Handler:    _Platform_Documentation_Handler,
+			MethodName: "ConfigSchema",
+			Handler:    _Builder_ConfigSchema_Handler,
 		},
 		{
-			MethodName: "DeploySpec",
-			Handler:    _Platform_DeploySpec_Handler,
+			MethodName: "ConfigureValue",
+			Handler:    _Builder_ConfigureValue_Handler,
 		},
 		{
-			MethodName: "Deploy",
-			Handler:    _Platform_Deploy_Handler,
+			MethodName: "Documentation",
+			Handler:    _Builder_Documentation_Handler,
 		},
 		{
-			MethodName: "DefaultReleaserSpec",
-			Handler:    _Platform_DefaultReleaserSpec_Handler,
+			MethodName: "BuildSpec",
+			Handler:    _Builder_BuildSpec_Handler,
 		},
 		{
-			MethodName: "DefaultReleaser",
-			Handler:    _Platform_DefaultReleaser_Handler,
+			MethodName: "Build",
+			Handler:    _Builder_Build_Handler,
 		},
 		{
 			MethodName: "IsDestroyer",
-			Handler:    _Platform_IsDestroyer_Handler,
+			Handler:    _Builder_IsDestroyer_Handler,
 		},
 		{
 			MethodName: "DestroySpec",
-			Handler:    _Platform_DestroySpec_Handler,
+			Handler:    _Builder_DestroySpec_Handler,
 		},
 		{
 			MethodName: "Destroy",
-			Handler:    _Platform_Destroy_Handler,
+			Handler:    _Builder_Destroy_Handler,
 		},
 		{
-			MethodName: "IsWorkspaceDestroyer",
-			Handler:    _Platform_IsWorkspaceDestroyer_Handler,
+			MethodName: "PreDestroySpec",
+			Handler:    _Builder_PreDestroySpec_Handler,
 		},
 		{
-			MethodName: "DestroyWorkspaceSpec",
-			Handler:    _Platform_DestroyWorkspaceSpec_Handler,
+			MethodName: "PreDestroy",
+			Handler:    _Builder_PreDestroy_Handler,
 		},
 		{
-			MethodName: "DestroyWorkspace",
-			Handler:    _Platform_DestroyWorkspace_Handler,
+			MethodName: "PostDestroySpec",
+			Handler:    _Builder_PostDestroySpec_Handler,
 		},
 		{
-			MethodName: "IsExecer",
-			Handler:    _Platform_IsExecer_Handler,
+			MethodName: "PostDestroy",
+			Handler:    _Builder_PostDestroy_Handler,
 		},
 		{
-			MethodName: "ExecSpec",
-			Handler:    _Platform_ExecSpec_Handler,
+			MethodName: "IsWorkspaceDestroyer",
+			Handler:    _Builder_IsWorkspaceDestroyer_Handler,
 		},
 		{
-			MethodName: "Exec",
-			Handler:    _Platform_Exec_Handler,
+			MethodName: "DestroyWorkspaceSpec",
+			Handler:    _Builder_DestroyWorkspaceSpec_Handler,
 		},
 		{
-			MethodName: "IsLogPlatform",
-			Handler:    _Platform_IsLogPlatform_Handler,
+			MethodName: "DestroyWorkspace",
+			Handler:    _Builder_DestroyWorkspace_Handler,
 		},
 		{
-			MethodName: "LogsSpec",
-			Handler:    _Platform_LogsSpec_Handler,
+			MethodName: "BuildODR",
+			Handler:    _Builder_BuildODR_Handler,
 		},
 		{
-			MethodName: "Logs",
-			Handler:    _Platform_Logs_Handler,
+			MethodName: "BuildSpecODR",
+			Handler:    _Builder_BuildSpecODR_Handler,
 		},
 		{
-			MethodName: "IsGeneration",
-			Handler:    _Platform_IsGeneration_Handler,
+			MethodName: "IsBuilderMulti",
+			Handler:    _Builder_IsBuilderMulti_Handler,
 		},
 		{
-			MethodName: "GenerationSpec",
-			Handler:    _Platform_GenerationSpec_Handler,
+			MethodName: "BuildManySpec",
+			Handler:    _Builder_BuildManySpec_Handler,
 		},
 		{
-			MethodName: "Generation",
-			Handler:    _Platform_Generation_Handler,
+			MethodName: "BuildMany",
+			Handler:    _Builder_BuildMany_Handler,
 		},
 		{
-			MethodName: "IsStatus",
-			Handler:    _Platform_IsStatus_Handler,
+			MethodName: "IsSigner",
+			Handler:    _Builder_IsSigner_Handler,
 		},
 		{
-			MethodName: "StatusSpec",
-			Handler:    _Platform_StatusSpec_Handler,
+			MethodName: "SignSpec",
+			Handler:    _Builder_SignSpec_Handler,
 		},
 		{
-			MethodName: "Status",
-			Handler:    _Platform_Status_Handler,
+			MethodName: "Sign",
+			Handler:    _Builder_Sign_Handler,
+		},
+		{
+			MethodName: "VerifySignatureSpec",
+			Handler:    _Builder_VerifySignatureSpec_Handler,
+		},
+		{
+			MethodName: "VerifySignature",
+			Handler:    _Builder_VerifySignature_Handler,
+		},
+		{
+			MethodName: "IsValidator",
+			Handler:    _Builder_IsValidator_Handler,
+		},
+		{
+			MethodName: "ValidateSpec",
+			Handler:    _Builder_ValidateSpec_Handler,
+		},
+		{
+			MethodName: "Validate",
+			Handler:    _Builder_Validate_Handler,
+		},
+		{
+			MethodName: "IsConfigDefaulter",
+			Handler:    _Builder_IsConfigDefaulter_Handler,
+		},
+		{
+			MethodName: "DefaultsSpec",
+			Handler:    _Builder_DefaultsSpec_Handler,
+		},
+		{
+			MethodName: "Defaults",
+			Handler:    _Builder_Defaults_Handler,
+		},
+		{
+			MethodName: "IsODRProfiler",
+			Handler:    _Builder_IsODRProfiler_Handler,
+		},
+		{
+			MethodName: "ODRRequirements",
+			Handler:    _Builder_ODRRequirements_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "plugin.proto",
 }
 
-// RegistryClient is the client API for Registry service.
+// PlatformClient is the client API for Platform service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type RegistryClient interface {
+type PlatformClient interface {
 	IsAuthenticator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
 	Auth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error)
 	AuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
 	ValidateAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ValidateAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	// component.AuthRefresher optional implementation
+	IsAuthRefresher(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	RefreshAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	RefreshAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error)
 	ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error)
 	Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ConfigSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_SchemaResp, error)
+	ConfigureValue(ctx context.Context, in *Config_ConfigureValueRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
-	PushSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	Push(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Push_Resp, error)
-	// Access and AccessSpec are used to provide the optional component.RegistryAccess
-	// interface.
+	DeploySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Deploy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Deploy_Resp, error)
+	// component.PlatformODR optional implementation, used instead of Deploy
+	// when the host runs the plugin in an on-demand runner.
+	DeploySpecODR(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	DeployODR(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Deploy_Resp, error)
+	// component.PlatformReleaser optional implementation.
+	DefaultReleaserSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	DefaultReleaser(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*DefaultReleaser_Resp, error)
+	// component.Destroyer optional implementation
+	IsDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	DestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Destroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Destroy_Resp, error)
+	// component.DestroyHooks optional implementation. The spec RPCs return
+	// codes.Unimplemented if the hook isn't implemented.
+	PreDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	PreDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PostDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	PostDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// component.WorkspaceDestroyer optional implementation
+	IsWorkspaceDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	DestroyWorkspaceSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	DestroyWorkspace(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// component.Execer optional implementation
+	IsExecer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	ExecSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Exec(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*ExecResult, error)
+	// component.Troubleshooter optional implementation
+	IsTroubleshooter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	TroubleshootSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Troubleshoot(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (Platform_TroubleshootClient, error)
+	// component.Snapshotter optional implementation. Snapshot streams the
+	// data the plugin writes and Restore streams the data to restore, after
+	// a first message with the args.
+	IsSnapshotter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	SnapshotSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Snapshot(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (Platform_SnapshotClient, error)
+	RestoreSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Restore(ctx context.Context, opts ...grpc.CallOption) (Platform_RestoreClient, error)
+	// component.PortForwarder optional implementation
+	IsPortForwarder(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	PortForwardSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	PortForward(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// component.PlatformAccess optional implementation. Like the Registry
+	// RPCs, AccessSpec returns codes.Unimplemented if it isn't implemented.
 	AccessSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
 	Access(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Access_Resp, error)
+	// component.LogViewer optional implementation
+	// IsLogPlatform returns true if this platform also implements LogPlatform.
+	IsLogPlatform(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	LogsSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Logs(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// component.Generation optional implementation
+	IsGeneration(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	GenerationSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Generation(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Generation_Resp, error)
+	// component.Status optional implementation
+	IsStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	StatusSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Status(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*StatusReport, error)
+	// component.Remediator optional implementation
+	IsRemediator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	RemediateSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Remediate(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// component.Validator optional implementation
+	IsValidator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	ValidateSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Validate(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*ValidationResult, error)
+	// component.ConfigDefaulter optional implementation
+	IsConfigDefaulter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	DefaultsSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Defaults(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Config_Effective, error)
+	// component.ODRProfiler optional implementation
+	IsODRProfiler(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	ODRRequirements(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ODR_Requirements, error)
 }
 
-type registryClient struct {
+type platformClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewRegistryClient(cc grpc.ClientConnInterface) RegistryClient {
-	return &registryClient{cc}
+func NewPlatformClient(cc grpc.ClientConnInterface) PlatformClient {
+	return &platformClient{cc}
 }
 
-func (c *registryClient) IsAuthenticator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+func (c *platformClient) IsAuthenticator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
 	out := new(ImplementsResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/IsAuthenticator", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsAuthenticator", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *registryClient) Auth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error) {
+func (c *platformClient) Auth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error) {
 	out := new(Auth_AuthResponse)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Auth", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Auth", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *registryClient) AuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *platformClient) AuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/AuthSpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/AuthSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *registryClient) ValidateAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *platformClient) ValidateAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/ValidateAuth", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/ValidateAuth", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *registryClient) ValidateAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *platformClient) ValidateAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/ValidateAuthSpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/ValidateAuthSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *registryClient) ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error) {
-	out := new(Config_StructResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/ConfigStruct", in, out, opts...)
+func (c *platformClient) IsAuthRefresher(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsAuthRefresher", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *registryClient) Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Configure", in, out, opts...)
+func (c *platformClient) RefreshAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/RefreshAuthSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *registryClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
-	out := new(Config_Documentation)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Documentation", in, out, opts...)
+func (c *platformClient) RefreshAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error) {
+	out := new(Auth_AuthResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/RefreshAuth", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *registryClient) PushSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
-	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/PushSpec", in, out, opts...)
+func (c *platformClient) ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error) {
+	out := new(Config_StructResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/ConfigStruct", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *registryClient) Push(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Push_Resp, error) {
-	out := new(Push_Resp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Push", in, out, opts...)
+func (c *platformClient) Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Configure", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *registryClient) AccessSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
-	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/AccessSpec", in, out, opts...)
+func (c *platformClient) ConfigSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_SchemaResp, error) {
+	out := new(Config_SchemaResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/ConfigSchema", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *registryClient) Access(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Access_Resp, error) {
-	out := new(Access_Resp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Access", in, out, opts...)
+func (c *platformClient) ConfigureValue(ctx context.Context, in *Config_ConfigureValueRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/ConfigureValue", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// RegistryServer is the server API for Registry service.
-// All implementations must embed UnimplementedRegistryServer
-// for forward compatibility
-type RegistryServer interface {
-	IsAuthenticator(context.Context, *emptypb.Empty) (*ImplementsResp, error)
-	Auth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error)
-	AuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	ValidateAuth(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
-	ValidateAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error)
-	Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error)
-	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
-	PushSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	Push(context.Context, *FuncSpec_Args) (*Push_Resp, error)
-	// Access and AccessSpec are used to provide the optional component.RegistryAccess
-	// interface.
-	AccessSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	Access(context.Context, *FuncSpec_Args) (*Access_Resp, error)
-	mustEmbedUnimplementedRegistryServer()
-}
-
-// UnimplementedRegistryServer must be embedded to have forward compatible implementations.
-type UnimplementedRegistryServer struct {
+func (c *platformClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
+	out := new(Config_Documentation)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Documentation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (UnimplementedRegistryServer) IsAuthenticator(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method IsAuthenticator not implemented")
-}
-func (UnimplementedRegistryServer) Auth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Auth not implemented")
-}
-func (UnimplementedRegistryServer) AuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AuthSpec not implemented")
-}
-func (UnimplementedRegistryServer) ValidateAuth(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ValidateAuth not implemented")
-}
-func (UnimplementedRegistryServer) ValidateAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ValidateAuthSpec not implemented")
-}
-func (UnimplementedRegistryServer) ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ConfigStruct not implemented")
-}
-func (UnimplementedRegistryServer) Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
-}
-func (UnimplementedRegistryServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
-}
-func (UnimplementedRegistryServer) PushSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PushSpec not implemented")
-}
-func (UnimplementedRegistryServer) Push(context.Context, *FuncSpec_Args) (*Push_Resp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
-}
-func (UnimplementedRegistryServer) AccessSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AccessSpec not implemented")
-}
-func (UnimplementedRegistryServer) Access(context.Context, *FuncSpec_Args) (*Access_Resp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Access not implemented")
+func (c *platformClient) DeploySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DeploySpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedRegistryServer) mustEmbedUnimplementedRegistryServer() {}
 
-// UnsafeRegistryServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to RegistryServer will
-// result in compilation errors.
-type UnsafeRegistryServer interface {
-	mustEmbedUnimplementedRegistryServer()
+func (c *platformClient) Deploy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Deploy_Resp, error) {
+	out := new(Deploy_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Deploy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func RegisterRegistryServer(s grpc.ServiceRegistrar, srv RegistryServer) {
-	s.RegisterService(&Registry_ServiceDesc, srv)
+func (c *platformClient) DeploySpecODR(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DeploySpecODR", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func _Registry_IsAuthenticator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
-	if err := dec(in); err != nil {
+func (c *platformClient) DeployODR(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Deploy_Resp, error) {
+	out := new(Deploy_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DeployODR", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RegistryServer).IsAuthenticator(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Registry/IsAuthenticator",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistryServer).IsAuthenticator(ctx, req.(*emptypb.Empty))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Registry_Auth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FuncSpec_Args)
-	if err := dec(in); err != nil {
+func (c *platformClient) DefaultReleaserSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DefaultReleaserSpec", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RegistryServer).Auth(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Registry/Auth",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistryServer).Auth(ctx, req.(*FuncSpec_Args))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Registry_AuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
-	if err := dec(in); err != nil {
+func (c *platformClient) DefaultReleaser(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*DefaultReleaser_Resp, error) {
+	out := new(DefaultReleaser_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DefaultReleaser", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RegistryServer).AuthSpec(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Registry/AuthSpec",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistryServer).AuthSpec(ctx, req.(*emptypb.Empty))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Registry_ValidateAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FuncSpec_Args)
-	if err := dec(in); err != nil {
+func (c *platformClient) IsDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsDestroyer", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RegistryServer).ValidateAuth(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Registry/ValidateAuth",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistryServer).ValidateAuth(ctx, req.(*FuncSpec_Args))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Registry_ValidateAuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
-	if err := dec(in); err != nil {
+func (c *platformClient) DestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DestroySpec", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RegistryServer).ValidateAuthSpec(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Registry/ValidateAuthSpec",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistryServer).ValidateAuthSpec(ctx, req.(*emptypb.Empty))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Registry_ConfigStruct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
-	if err := dec(in); err != nil {
+func (c *platformClient) Destroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Destroy_Resp, error) {
+	out := new(Destroy_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Destroy", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RegistryServer).ConfigStruct(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Registry/ConfigStruct",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistryServer).ConfigStruct(ctx, req.(*emptypb.Empty))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Registry_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Config_ConfigureRequest)
-	if err := dec(in); err != nil {
+func (c *platformClient) PreDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/PreDestroySpec", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RegistryServer).Configure(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Registry/Configure",
+	return out, nil
+}
+
+func (c *platformClient) PreDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/PreDestroy", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistryServer).Configure(ctx, req.(*Config_ConfigureRequest))
+	return out, nil
+}
+
+func (c *platformClient) PostDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/PostDestroySpec", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Registry_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
-	if err := dec(in); err != nil {
+func (c *platformClient) PostDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/PostDestroy", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RegistryServer).Documentation(ctx, in)
+	return out, nil
+}
+
+func (c *platformClient) IsWorkspaceDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsWorkspaceDestroyer", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Registry/Documentation",
+	return out, nil
+}
+
+func (c *platformClient) DestroyWorkspaceSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DestroyWorkspaceSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistryServer).Documentation(ctx, req.(*emptypb.Empty))
+	return out, nil
+}
+
+func (c *platformClient) DestroyWorkspace(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DestroyWorkspace", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Registry_PushSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
-	if err := dec(in); err != nil {
+func (c *platformClient) IsExecer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsExecer", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RegistryServer).PushSpec(ctx, in)
+	return out, nil
+}
+
+func (c *platformClient) ExecSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/ExecSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Registry/PushSpec",
+	return out, nil
+}
+
+func (c *platformClient) Exec(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*ExecResult, error) {
+	out := new(ExecResult)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Exec", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistryServer).PushSpec(ctx, req.(*emptypb.Empty))
+	return out, nil
+}
+
+func (c *platformClient) IsTroubleshooter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsTroubleshooter", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Registry_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FuncSpec_Args)
-	if err := dec(in); err != nil {
+func (c *platformClient) TroubleshootSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/TroubleshootSpec", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RegistryServer).Push(ctx, in)
+	return out, nil
+}
+
+func (c *platformClient) Troubleshoot(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (Platform_TroubleshootClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Platform_ServiceDesc.Streams[0], "/hashicorp.waypoint.sdk.Platform/Troubleshoot", opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Registry/Push",
+	x := &platformTroubleshootClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistryServer).Push(ctx, req.(*FuncSpec_Args))
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return x, nil
 }
 
-func _Registry_AccessSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
-	if err := dec(in); err != nil {
+type Platform_TroubleshootClient interface {
+	Recv() (*DiagnosticsBundle_Chunk, error)
+	grpc.ClientStream
+}
+
+type platformTroubleshootClient struct {
+	grpc.ClientStream
+}
+
+func (x *platformTroubleshootClient) Recv() (*DiagnosticsBundle_Chunk, error) {
+	m := new(DiagnosticsBundle_Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RegistryServer).AccessSpec(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Registry/AccessSpec",
+	return m, nil
+}
+
+func (c *platformClient) IsSnapshotter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsSnapshotter", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistryServer).AccessSpec(ctx, req.(*emptypb.Empty))
+	return out, nil
+}
+
+func (c *platformClient) SnapshotSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/SnapshotSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _Registry_Access_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FuncSpec_Args)
-	if err := dec(in); err != nil {
+func (c *platformClient) Snapshot(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (Platform_SnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Platform_ServiceDesc.Streams[1], "/hashicorp.waypoint.sdk.Platform/Snapshot", opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(RegistryServer).Access(ctx, in)
+	x := &platformSnapshotClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.Registry/Access",
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistryServer).Access(ctx, req.(*FuncSpec_Args))
+	return x, nil
+}
+
+type Platform_SnapshotClient interface {
+	Recv() (*Snapshot_Chunk, error)
+	grpc.ClientStream
+}
+
+type platformSnapshotClient struct {
+	grpc.ClientStream
+}
+
+func (x *platformSnapshotClient) Recv() (*Snapshot_Chunk, error) {
+	m := new(Snapshot_Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return m, nil
 }
 
-// Registry_ServiceDesc is the grpc.ServiceDesc for Registry service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Registry_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "hashicorp.waypoint.sdk.Registry",
-	HandlerType: (*RegistryServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "IsAuthenticator",
-			Handler:    _Registry_IsAuthenticator_Handler,
-		},
-		{
-			MethodName: "Auth",
-			Handler:    _Registry_Auth_Handler,
-		},
-		{
-			MethodName: "AuthSpec",
-			Handler:    _Registry_AuthSpec_Handler,
-		},
-		{
-			MethodName: "ValidateAuth",
-			Handler:    _Registry_ValidateAuth_Handler,
-		},
-		{
-			MethodName: "ValidateAuthSpec",
-			Handler:    _Registry_ValidateAuthSpec_Handler,
-		},
-		{
-			MethodName: "ConfigStruct",
-			Handler:    _Registry_ConfigStruct_Handler,
-		},
-		{
-			MethodName: "Configure",
-			Handler:    _Registry_Configure_Handler,
-		},
-		{
-			MethodName: "Documentation",
-			Handler:    _Registry_Documentation_Handler,
-		},
-		{
-			MethodName: "PushSpec",
-			Handler:    _Registry_PushSpec_Handler,
-		},
-		{
-			MethodName: "Push",
-			Handler:    _Registry_Push_Handler,
-		},
-		{
-			MethodName: "AccessSpec",
-			Handler:    _Registry_AccessSpec_Handler,
-		},
-		{
-			MethodName: "Access",
-			Handler:    _Registry_Access_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "plugin.proto",
+func (c *platformClient) RestoreSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/RestoreSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// ReleaseManagerClient is the client API for ReleaseManager service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type ReleaseManagerClient interface {
-	IsAuthenticator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
-	Auth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error)
-	AuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	ValidateAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	ValidateAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error)
-	Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
-	// component.Destroyer optional implementation
-	IsDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
-	DestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	Destroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Destroy_Resp, error)
-	// component.WorkspaceDestroyer optional implementation
-	IsWorkspaceDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
-	DestroyWorkspaceSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	DestroyWorkspace(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	ReleaseSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	Release(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Release_Resp, error)
-	// component.Status optional implementation
-	IsStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
-	StatusSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
-	Status(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*StatusReport, error)
+func (c *platformClient) Restore(ctx context.Context, opts ...grpc.CallOption) (Platform_RestoreClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Platform_ServiceDesc.Streams[2], "/hashicorp.waypoint.sdk.Platform/Restore", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &platformRestoreClient{stream}
+	return x, nil
 }
 
-type releaseManagerClient struct {
-	cc grpc.ClientConnInterface
+type Platform_RestoreClient interface {
+	Send(*Snapshot_RestoreRequest) error
+	CloseAndRecv() (*emptypb.Empty, error)
+	grpc.ClientStream
 }
 
-func NewReleaseManagerClient(cc grpc.ClientConnInterface) ReleaseManagerClient {
-	return &releaseManagerClient{cc}
+type platformRestoreClient struct {
+	grpc.ClientStream
 }
 
-func (c *releaseManagerClient) IsAuthenticator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+func (x *platformRestoreClient) Send(m *Snapshot_RestoreRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *platformRestoreClient) CloseAndRecv() (*emptypb.Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(emptypb.Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *platformClient) IsPortForwarder(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
 	out := new(ImplementsResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/IsAuthenticator", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsPortForwarder", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) Auth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error) {
-	out := new(Auth_AuthResponse)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Auth", in, out, opts...)
+func (c *platformClient) PortForwardSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/PortForwardSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) AuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *platformClient) PortForward(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/PortForward", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) AccessSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/AuthSpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/AccessSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) ValidateAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/ValidateAuth", in, out, opts...)
+func (c *platformClient) Access(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Access_Resp, error) {
+	out := new(Access_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Access", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) ValidateAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
-	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/ValidateAuthSpec", in, out, opts...)
+func (c *platformClient) IsLogPlatform(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsLogPlatform", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error) {
-	out := new(Config_StructResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/ConfigStruct", in, out, opts...)
+func (c *platformClient) LogsSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/LogsSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *platformClient) Logs(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Configure", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Logs", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
-	out := new(Config_Documentation)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Documentation", in, out, opts...)
+func (c *platformClient) IsGeneration(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsGeneration", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) IsDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+func (c *platformClient) GenerationSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/GenerationSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) Generation(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Generation_Resp, error) {
+	out := new(Generation_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Generation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) IsStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
 	out := new(ImplementsResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/IsDestroyer", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsStatus", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) DestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *platformClient) StatusSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/DestroySpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/StatusSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) Destroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Destroy_Resp, error) {
-	out := new(Destroy_Resp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Destroy", in, out, opts...)
+func (c *platformClient) Status(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*StatusReport, error) {
+	out := new(StatusReport)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Status", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) IsWorkspaceDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+func (c *platformClient) IsRemediator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
 	out := new(ImplementsResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/IsWorkspaceDestroyer", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsRemediator", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) DestroyWorkspaceSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *platformClient) RemediateSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/DestroyWorkspaceSpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/RemediateSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) DestroyWorkspace(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *platformClient) Remediate(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/DestroyWorkspace", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Remediate", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) ReleaseSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *platformClient) IsValidator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsValidator", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) ValidateSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/ReleaseSpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/ValidateSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) Release(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Release_Resp, error) {
-	out := new(Release_Resp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Release", in, out, opts...)
+func (c *platformClient) Validate(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*ValidationResult, error) {
+	out := new(ValidationResult)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Validate", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) IsStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+func (c *platformClient) IsConfigDefaulter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
 	out := new(ImplementsResp)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/IsStatus", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsConfigDefaulter", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) StatusSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+func (c *platformClient) DefaultsSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
 	out := new(FuncSpec)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/StatusSpec", in, out, opts...)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/DefaultsSpec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *releaseManagerClient) Status(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*StatusReport, error) {
-	out := new(StatusReport)
-	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Status", in, out, opts...)
+func (c *platformClient) Defaults(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Config_Effective, error) {
+	out := new(Config_Effective)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/Defaults", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// ReleaseManagerServer is the server API for ReleaseManager service.
-// All implementations must embed UnimplementedReleaseManagerServer
+func (c *platformClient) IsODRProfiler(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/IsODRProfiler", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *platformClient) ODRRequirements(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ODR_Requirements, error) {
+	out := new(ODR_Requirements)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Platform/ODRRequirements", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlatformServer is the server API for Platform service.
+// All implementations must embed UnimplementedPlatformServer
 // for forward compatibility
-type ReleaseManagerServer interface {
+type PlatformServer interface {
 	IsAuthenticator(context.Context, *emptypb.Empty) (*ImplementsResp, error)
 	Auth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error)
 	AuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
 	ValidateAuth(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
 	ValidateAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	// component.AuthRefresher optional implementation
+	IsAuthRefresher(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	RefreshAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	RefreshAuth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error)
 	ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error)
 	Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error)
+	ConfigSchema(context.Context, *emptypb.Empty) (*Config_SchemaResp, error)
+	ConfigureValue(context.Context, *Config_ConfigureValueRequest) (*emptypb.Empty, error)
 	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
+	DeploySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Deploy(context.Context, *FuncSpec_Args) (*Deploy_Resp, error)
+	// component.PlatformODR optional implementation, used instead of Deploy
+	// when the host runs the plugin in an on-demand runner.
+	DeploySpecODR(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	DeployODR(context.Context, *FuncSpec_Args) (*Deploy_Resp, error)
+	// component.PlatformReleaser optional implementation.
+	DefaultReleaserSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	DefaultReleaser(context.Context, *FuncSpec_Args) (*DefaultReleaser_Resp, error)
 	// component.Destroyer optional implementation
 	IsDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error)
 	DestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
 	Destroy(context.Context, *FuncSpec_Args) (*Destroy_Resp, error)
+	// component.DestroyHooks optional implementation. The spec RPCs return
+	// codes.Unimplemented if the hook isn't implemented.
+	PreDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	PreDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	PostDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	PostDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
 	// component.WorkspaceDestroyer optional implementation
 	IsWorkspaceDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error)
 	DestroyWorkspaceSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
 	DestroyWorkspace(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
-	ReleaseSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
-	Release(context.Context, *FuncSpec_Args) (*Release_Resp, error)
-	// component.Status optional implementation
-	IsStatus(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	// component.Execer optional implementation
+	IsExecer(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	ExecSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Exec(context.Context, *FuncSpec_Args) (*ExecResult, error)
+	// component.Troubleshooter optional implementation
+	IsTroubleshooter(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	TroubleshootSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Troubleshoot(*FuncSpec_Args, Platform_TroubleshootServer) error
+	// component.Snapshotter optional implementation. Snapshot streams the
+	// data the plugin writes and Restore streams the data to restore, after
+	// a first message with the args.
+	IsSnapshotter(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	SnapshotSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Snapshot(*FuncSpec_Args, Platform_SnapshotServer) error
+	RestoreSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Restore(Platform_RestoreServer) error
+	// component.PortForwarder optional implementation
+	IsPortForwarder(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	PortForwardSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	PortForward(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	// component.PlatformAccess optional implementation. Like the Registry
+	// RPCs, AccessSpec returns codes.Unimplemented if it isn't implemented.
+	AccessSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Access(context.Context, *FuncSpec_Args) (*Access_Resp, error)
+	// component.LogViewer optional implementation
+	// IsLogPlatform returns true if this platform also implements LogPlatform.
+	IsLogPlatform(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	LogsSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Logs(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	// component.Generation optional implementation
+	IsGeneration(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	GenerationSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Generation(context.Context, *FuncSpec_Args) (*Generation_Resp, error)
+	// component.Status optional implementation
+	IsStatus(context.Context, *emptypb.Empty) (*ImplementsResp, error)
 	StatusSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
 	Status(context.Context, *FuncSpec_Args) (*StatusReport, error)
-	mustEmbedUnimplementedReleaseManagerServer()
+	// component.Remediator optional implementation
+	IsRemediator(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	RemediateSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Remediate(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	// component.Validator optional implementation
+	IsValidator(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	ValidateSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Validate(context.Context, *FuncSpec_Args) (*ValidationResult, error)
+	// component.ConfigDefaulter optional implementation
+	IsConfigDefaulter(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	DefaultsSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Defaults(context.Context, *FuncSpec_Args) (*Config_Effective, error)
+	// component.ODRProfiler optional implementation
+	IsODRProfiler(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	ODRRequirements(context.Context, *emptypb.Empty) (*ODR_Requirements, error)
+	mustEmbedUnimplementedPlatformServer()
 }
 
-// UnimplementedReleaseManagerServer must be embedded to have forward compatible implementations.
-type UnimplementedReleaseManagerServer struct {
+// UnimplementedPlatformServer must be embedded to have forward compatible implementations.
+type UnimplementedPlatformServer struct {
 }
 
-func (UnimplementedReleaseManagerServer) IsAuthenticator(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+func (UnimplementedPlatformServer) IsAuthenticator(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsAuthenticator not implemented")
 }
-func (UnimplementedReleaseManagerServer) Auth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error) {
+func (UnimplementedPlatformServer) Auth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Auth not implemented")
 }
-func (UnimplementedReleaseManagerServer) AuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+func (UnimplementedPlatformServer) AuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AuthSpec not implemented")
 }
-func (UnimplementedReleaseManagerServer) ValidateAuth(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+func (UnimplementedPlatformServer) ValidateAuth(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ValidateAuth not implemented")
 }
-func (UnimplementedReleaseManagerServer) ValidateAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+func (UnimplementedPlatformServer) ValidateAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ValidateAuthSpec not implemented")
 }
-func (UnimplementedReleaseManagerServer) ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error) {
+func (UnimplementedPlatformServer) IsAuthRefresher(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsAuthRefresher not implemented")
+}
+func (UnimplementedPlatformServer) RefreshAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshAuthSpec not implemented")
+}
+func (UnimplementedPlatformServer) RefreshAuth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshAuth not implemented")
+}
+func (UnimplementedPlatformServer) ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ConfigStruct not implemented")
 }
-func (UnimplementedReleaseManagerServer) Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error) {
+func (UnimplementedPlatformServer) Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
 }
-func (UnimplementedReleaseManagerServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
+func (UnimplementedPlatformServer) ConfigSchema(context.Context, *emptypb.Empty) (*Config_SchemaResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigSchema not implemented")
+}
+func (UnimplementedPlatformServer) ConfigureValue(context.Context, *Config_ConfigureValueRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigureValue not implemented")
+}
+func (UnimplementedPlatformServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
 }
-func (UnimplementedReleaseManagerServer) IsDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+func (UnimplementedPlatformServer) DeploySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeploySpec not implemented")
+}
+func (UnimplementedPlatformServer) Deploy(context.Context, *FuncSpec_Args) (*Deploy_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deploy not implemented")
+}
+func (UnimplementedPlatformServer) DeploySpecODR(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeploySpecODR not implemented")
+}
+func (UnimplementedPlatformServer) DeployODR(context.Context, *FuncSpec_Args) (*Deploy_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeployODR not implemented")
+}
+func (UnimplementedPlatformServer) DefaultReleaserSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DefaultReleaserSpec not implemented")
+}
+func (UnimplementedPlatformServer) DefaultReleaser(context.Context, *FuncSpec_Args) (*DefaultReleaser_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DefaultReleaser not implemented")
+}
+func (UnimplementedPlatformServer) IsDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsDestroyer not implemented")
 }
-func (UnimplementedReleaseManagerServer) DestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+func (UnimplementedPlatformServer) DestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroySpec not implemented")
 }
-func (UnimplementedReleaseManagerServer) Destroy(context.Context, *FuncSpec_Args) (*Destroy_Resp, error) {
+func (UnimplementedPlatformServer) Destroy(context.Context, *FuncSpec_Args) (*Destroy_Resp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Destroy not implemented")
 }
-func (UnimplementedReleaseManagerServer) IsWorkspaceDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+func (UnimplementedPlatformServer) PreDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreDestroySpec not implemented")
+}
+func (UnimplementedPlatformServer) PreDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreDestroy not implemented")
+}
+func (UnimplementedPlatformServer) PostDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostDestroySpec not implemented")
+}
+func (UnimplementedPlatformServer) PostDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostDestroy not implemented")
+}
+func (UnimplementedPlatformServer) IsWorkspaceDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsWorkspaceDestroyer not implemented")
 }
-func (UnimplementedReleaseManagerServer) DestroyWorkspaceSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+func (UnimplementedPlatformServer) DestroyWorkspaceSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyWorkspaceSpec not implemented")
 }
-func (UnimplementedReleaseManagerServer) DestroyWorkspace(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+func (UnimplementedPlatformServer) DestroyWorkspace(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyWorkspace not implemented")
 }
-func (UnimplementedReleaseManagerServer) ReleaseSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ReleaseSpec not implemented")
+func (UnimplementedPlatformServer) IsExecer(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsExecer not implemented")
 }
-func (UnimplementedReleaseManagerServer) Release(context.Context, *FuncSpec_Args) (*Release_Resp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Release not implemented")
+func (UnimplementedPlatformServer) ExecSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecSpec not implemented")
 }
-func (UnimplementedReleaseManagerServer) IsStatus(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+func (UnimplementedPlatformServer) Exec(context.Context, *FuncSpec_Args) (*ExecResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedPlatformServer) IsTroubleshooter(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsTroubleshooter not implemented")
+}
+func (UnimplementedPlatformServer) TroubleshootSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TroubleshootSpec not implemented")
+}
+func (UnimplementedPlatformServer) Troubleshoot(*FuncSpec_Args, Platform_TroubleshootServer) error {
+	return status.Errorf(codes.Unimplemented, "method Troubleshoot not implemented")
+}
+func (UnimplementedPlatformServer) IsSnapshotter(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsSnapshotter not implemented")
+}
+func (UnimplementedPlatformServer) SnapshotSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotSpec not implemented")
+}
+func (UnimplementedPlatformServer) Snapshot(*FuncSpec_Args, Platform_SnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method Snapshot not implemented")
+}
+func (UnimplementedPlatformServer) RestoreSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreSpec not implemented")
+}
+func (UnimplementedPlatformServer) Restore(Platform_RestoreServer) error {
+	return status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedPlatformServer) IsPortForwarder(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsPortForwarder not implemented")
+}
+func (UnimplementedPlatformServer) PortForwardSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PortForwardSpec not implemented")
+}
+func (UnimplementedPlatformServer) PortForward(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PortForward not implemented")
+}
+func (UnimplementedPlatformServer) AccessSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccessSpec not implemented")
+}
+func (UnimplementedPlatformServer) Access(context.Context, *FuncSpec_Args) (*Access_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Access not implemented")
+}
+func (UnimplementedPlatformServer) IsLogPlatform(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsLogPlatform not implemented")
+}
+func (UnimplementedPlatformServer) LogsSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogsSpec not implemented")
+}
+func (UnimplementedPlatformServer) Logs(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logs not implemented")
+}
+func (UnimplementedPlatformServer) IsGeneration(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsGeneration not implemented")
+}
+func (UnimplementedPlatformServer) GenerationSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerationSpec not implemented")
+}
+func (UnimplementedPlatformServer) Generation(context.Context, *FuncSpec_Args) (*Generation_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Generation not implemented")
+}
+func (UnimplementedPlatformServer) IsStatus(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsStatus not implemented")
 }
-func (UnimplementedReleaseManagerServer) StatusSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+func (UnimplementedPlatformServer) StatusSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StatusSpec not implemented")
 }
-func (UnimplementedReleaseManagerServer) Status(context.Context, *FuncSpec_Args) (*StatusReport, error) {
+func (UnimplementedPlatformServer) Status(context.Context, *FuncSpec_Args) (*StatusReport, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
-func (UnimplementedReleaseManagerServer) mustEmbedUnimplementedReleaseManagerServer() {}
+func (UnimplementedPlatformServer) IsRemediator(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsRemediator not implemented")
+}
+func (UnimplementedPlatformServer) RemediateSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemediateSpec not implemented")
+}
+func (UnimplementedPlatformServer) Remediate(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remediate not implemented")
+}
+func (UnimplementedPlatformServer) IsValidator(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsValidator not implemented")
+}
+func (UnimplementedPlatformServer) ValidateSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateSpec not implemented")
+}
+func (UnimplementedPlatformServer) Validate(context.Context, *FuncSpec_Args) (*ValidationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedPlatformServer) IsConfigDefaulter(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsConfigDefaulter not implemented")
+}
+func (UnimplementedPlatformServer) DefaultsSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DefaultsSpec not implemented")
+}
+func (UnimplementedPlatformServer) Defaults(context.Context, *FuncSpec_Args) (*Config_Effective, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Defaults not implemented")
+}
+func (UnimplementedPlatformServer) IsODRProfiler(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsODRProfiler not implemented")
+}
+func (UnimplementedPlatformServer) ODRRequirements(context.Context, *emptypb.Empty) (*ODR_Requirements, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ODRRequirements not implemented")
+}
+func (UnimplementedPlatformServer) mustEmbedUnimplementedPlatformServer() {}
 
-// UnsafeReleaseManagerServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ReleaseManagerServer will
+// UnsafePlatformServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PlatformServer will
 // result in compilation errors.
-type UnsafeReleaseManagerServer interface {
-	mustEmbedUnimplementedReleaseManagerServer()
+type UnsafePlatformServer interface {
+	mustEmbedUnimplementedPlatformServer()
 }
 
-func RegisterReleaseManagerServer(s grpc.ServiceRegistrar, srv ReleaseManagerServer) {
-	s.RegisterService(&ReleaseManager_ServiceDesc, srv)
+func RegisterPlatformServer(s grpc.ServiceRegistrar, srv PlatformServer) {
+	s.RegisterService(&Platform_ServiceDesc, srv)
+}
+
+func _Platform_IsAuthenticator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsAuthenticator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsAuthenticator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsAuthenticator(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Auth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).Auth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/Auth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).Auth(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_AuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).AuthSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/AuthSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).AuthSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_ValidateAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).ValidateAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/ValidateAuth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).ValidateAuth(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_ValidateAuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).ValidateAuthSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/ValidateAuthSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).ValidateAuthSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_IsAuthRefresher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsAuthRefresher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsAuthRefresher",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsAuthRefresher(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_RefreshAuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).RefreshAuthSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/RefreshAuthSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).RefreshAuthSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_RefreshAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).RefreshAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/RefreshAuth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).RefreshAuth(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_ConfigStruct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).ConfigStruct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/ConfigStruct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).ConfigStruct(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config_ConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).Configure(ctx, req.(*Config_ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_ConfigSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).ConfigSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/ConfigSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).ConfigSchema(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_ConfigureValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config_ConfigureValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).ConfigureValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/ConfigureValue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).ConfigureValue(ctx, req.(*Config_ConfigureValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).Documentation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/Documentation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).Documentation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_DeploySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).DeploySpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/DeploySpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).DeploySpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Deploy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).Deploy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/Deploy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).Deploy(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_DeploySpecODR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).DeploySpecODR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/DeploySpecODR",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).DeploySpecODR(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_DeployODR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).DeployODR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/DeployODR",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).DeployODR(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_DefaultReleaserSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).DefaultReleaserSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/DefaultReleaserSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).DefaultReleaserSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_DefaultReleaser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).DefaultReleaser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/DefaultReleaser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).DefaultReleaser(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_IsDestroyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsDestroyer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsDestroyer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsDestroyer(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_DestroySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).DestroySpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/DestroySpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).DestroySpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Destroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).Destroy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/Destroy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).Destroy(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_PreDestroySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).PreDestroySpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/PreDestroySpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).PreDestroySpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_PreDestroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).PreDestroy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/PreDestroy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).PreDestroy(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_PostDestroySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).PostDestroySpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/PostDestroySpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).PostDestroySpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_PostDestroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).PostDestroy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/PostDestroy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).PostDestroy(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_IsWorkspaceDestroyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsWorkspaceDestroyer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsWorkspaceDestroyer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsWorkspaceDestroyer(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_DestroyWorkspaceSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).DestroyWorkspaceSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/DestroyWorkspaceSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).DestroyWorkspaceSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_DestroyWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).DestroyWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/DestroyWorkspace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).DestroyWorkspace(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_IsExecer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsExecer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsExecer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsExecer(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_ExecSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).ExecSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/ExecSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).ExecSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).Exec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/Exec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).Exec(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_IsTroubleshooter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsTroubleshooter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsTroubleshooter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsTroubleshooter(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_TroubleshootSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).TroubleshootSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/TroubleshootSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).TroubleshootSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Troubleshoot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FuncSpec_Args)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlatformServer).Troubleshoot(m, &platformTroubleshootServer{stream})
+}
+
+type Platform_TroubleshootServer interface {
+	Send(*DiagnosticsBundle_Chunk) error
+	grpc.ServerStream
+}
+
+type platformTroubleshootServer struct {
+	grpc.ServerStream
+}
+
+func (x *platformTroubleshootServer) Send(m *DiagnosticsBundle_Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Platform_IsSnapshotter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsSnapshotter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsSnapshotter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsSnapshotter(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_SnapshotSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).SnapshotSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/SnapshotSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).SnapshotSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Snapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FuncSpec_Args)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlatformServer).Snapshot(m, &platformSnapshotServer{stream})
+}
+
+type Platform_SnapshotServer interface {
+	Send(*Snapshot_Chunk) error
+	grpc.ServerStream
+}
+
+type platformSnapshotServer struct {
+	grpc.ServerStream
+}
+
+func (x *platformSnapshotServer) Send(m *Snapshot_Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Platform_RestoreSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).RestoreSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/RestoreSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).RestoreSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Restore_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PlatformServer).Restore(&platformRestoreServer{stream})
+}
+
+type Platform_RestoreServer interface {
+	SendAndClose(*emptypb.Empty) error
+	Recv() (*Snapshot_RestoreRequest, error)
+	grpc.ServerStream
+}
+
+type platformRestoreServer struct {
+	grpc.ServerStream
+}
+
+func (x *platformRestoreServer) SendAndClose(m *emptypb.Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *platformRestoreServer) Recv() (*Snapshot_RestoreRequest, error) {
+	m := new(Snapshot_RestoreRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Platform_IsPortForwarder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsPortForwarder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsPortForwarder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsPortForwarder(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_PortForwardSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).PortForwardSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/PortForwardSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).PortForwardSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_PortForward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).PortForward(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/PortForward",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).PortForward(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_AccessSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).AccessSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/AccessSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).AccessSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Access_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).Access(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/Access",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).Access(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_IsLogPlatform_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsLogPlatform(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsLogPlatform",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsLogPlatform(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_LogsSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).LogsSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/LogsSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).LogsSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Logs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).Logs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/Logs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).Logs(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_IsGeneration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsGeneration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsGeneration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsGeneration(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_GenerationSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).GenerationSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/GenerationSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).GenerationSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Generation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).Generation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/Generation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).Generation(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_IsStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_StatusSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).StatusSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/StatusSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).StatusSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).Status(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_IsRemediator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsRemediator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsRemediator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsRemediator(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_RemediateSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).RemediateSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/RemediateSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).RemediateSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Remediate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).Remediate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/Remediate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).Remediate(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_IsValidator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsValidator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsValidator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsValidator(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_ValidateSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).ValidateSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/ValidateSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).ValidateSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/Validate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).Validate(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_IsConfigDefaulter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsConfigDefaulter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsConfigDefaulter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsConfigDefaulter(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_DefaultsSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).DefaultsSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/DefaultsSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).DefaultsSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_Defaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).Defaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/Defaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).Defaults(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_IsODRProfiler_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).IsODRProfiler(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/IsODRProfiler",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).IsODRProfiler(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Platform_ODRRequirements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlatformServer).ODRRequirements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Platform/ODRRequirements",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlatformServer).ODRRequirements(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Platform_ServiceDesc is the grpc.ServiceDesc for Platform service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Platform_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.waypoint.sdk.Platform",
+	HandlerType: (*PlatformServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IsAuthenticator",
+			Handler:    _Platform_IsAuthenticator_Handler,
+		},
+		{
+			MethodName: "Auth",
+			Handler:    _Platform_Auth_Handler,
+		},
+		{
+			MethodName: "AuthSpec",
+			Handler:    _Platform_AuthSpec_Handler,
+		},
+		{
+			MethodName: "ValidateAuth",
+			Handler:    _Platform_ValidateAuth_Handler,
+		},
+		{
+			MethodName: "ValidateAuthSpec",
+			Handler:    _Platform_ValidateAuthSpec_Handler,
+		},
+		{
+			MethodName: "IsAuthRefresher",
+			Handler:    _Platform_IsAuthRefresher_Handler,
+		},
+		{
+			MethodName: "RefreshAuthSpec",
+			Handler:    _Platform_RefreshAuthSpec_Handler,
+		},
+		{
+			MethodName: "RefreshAuth",
+			Handler:    _Platform_RefreshAuth_Handler,
+		},
+		{
+			MethodName: "ConfigStruct",
+			Handler:    _Platform_ConfigStruct_Handler,
+		},
+		{
+			MethodName: "Configure",
+			Handler:    _Platform_Configure_Handler,
+		},
+		{
+			MethodName: "ConfigSchema",
+			Handler:    _Platform_ConfigSchema_Handler,
+		},
+		{
+			MethodName: "ConfigureValue",
+			Handler:    _Platform_ConfigureValue_Handler,
+		},
+		{
+			MethodName: "Documentation",
+			Handler:    _Platform_Documentation_Handler,
+		},
+		{
+			MethodName: "DeploySpec",
+			Handler:    _Platform_DeploySpec_Handler,
+		},
+		{
+			MethodName: "Deploy",
+			Handler:    _Platform_Deploy_Handler,
+		},
+		{
+			MethodName: "DeploySpecODR",
+			Handler:    _Platform_DeploySpecODR_Handler,
+		},
+		{
+			MethodName: "DeployODR",
+			Handler:    _Platform_DeployODR_Handler,
+		},
+		{
+			MethodName: "DefaultReleaserSpec",
+			Handler:    _Platform_DefaultReleaserSpec_Handler,
+		},
+		{
+			MethodName: "DefaultReleaser",
+			Handler:    _Platform_DefaultReleaser_Handler,
+		},
+		{
+			MethodName: "IsDestroyer",
+			Handler:    _Platform_IsDestroyer_Handler,
+		},
+		{
+			MethodName: "DestroySpec",
+			Handler:    _Platform_DestroySpec_Handler,
+		},
+		{
+			MethodName: "Destroy",
+			Handler:    _Platform_Destroy_Handler,
+		},
+		{
+			MethodName: "PreDestroySpec",
+			Handler:    _Platform_PreDestroySpec_Handler,
+		},
+		{
+			MethodName: "PreDestroy",
+			Handler:    _Platform_PreDestroy_Handler,
+		},
+		{
+			MethodName: "PostDestroySpec",
+			Handler:    _Platform_PostDestroySpec_Handler,
+		},
+		{
+			MethodName: "PostDestroy",
+			Handler:    _Platform_PostDestroy_Handler,
+		},
+		{
+			MethodName: "IsWorkspaceDestroyer",
+			Handler:    _Platform_IsWorkspaceDestroyer_Handler,
+		},
+		{
+			MethodName: "DestroyWorkspaceSpec",
+			Handler:    _Platform_DestroyWorkspaceSpec_Handler,
+		},
+		{
+			MethodName: "DestroyWorkspace",
+			Handler:    _Platform_DestroyWorkspace_Handler,
+		},
+		{
+			MethodName: "IsExecer",
+			Handler:    _Platform_IsExecer_Handler,
+		},
+		{
+			MethodName: "ExecSpec",
+			Handler:    _Platform_ExecSpec_Handler,
+		},
+		{
+			MethodName: "Exec",
+			Handler:    _Platform_Exec_Handler,
+		},
+		{
+			MethodName: "IsTroubleshooter",
+			Handler:    _Platform_IsTroubleshooter_Handler,
+		},
+		{
+			MethodName: "TroubleshootSpec",
+			Handler:    _Platform_TroubleshootSpec_Handler,
+		},
+		{
+			MethodName: "IsSnapshotter",
+			Handler:    _Platform_IsSnapshotter_Handler,
+		},
+		{
+			MethodName: "SnapshotSpec",
+			Handler:    _Platform_SnapshotSpec_Handler,
+		},
+		{
+			MethodName: "RestoreSpec",
+			Handler:    _Platform_RestoreSpec_Handler,
+		},
+		{
+			MethodName: "IsPortForwarder",
+			Handler:    _Platform_IsPortForwarder_Handler,
+		},
+		{
+			MethodName: "PortForwardSpec",
+			Handler:    _Platform_PortForwardSpec_Handler,
+		},
+		{
+			MethodName: "PortForward",
+			Handler:    _Platform_PortForward_Handler,
+		},
+		{
+			MethodName: "AccessSpec",
+			Handler:    _Platform_AccessSpec_Handler,
+		},
+		{
+			MethodName: "Access",
+			Handler:    _Platform_Access_Handler,
+		},
+		{
+			MethodName: "IsLogPlatform",
+			Handler:    _Platform_IsLogPlatform_Handler,
+		},
+		{
+			MethodName: "LogsSpec",
+			Handler:    _Platform_LogsSpec_Handler,
+		},
+		{
+			MethodName: "Logs",
+			Handler:    _Platform_Logs_Handler,
+		},
+		{
+			MethodName: "IsGeneration",
+			Handler:    _Platform_IsGeneration_Handler,
+		},
+		{
+			MethodName: "GenerationSpec",
+			Handler:    _Platform_GenerationSpec_Handler,
+		},
+		{
+			MethodName: "Generation",
+			Handler:    _Platform_Generation_Handler,
+		},
+		{
+			MethodName: "IsStatus",
+			Handler:    _Platform_IsStatus_Handler,
+		},
+		{
+			MethodName: "StatusSpec",
+			Handler:    _Platform_StatusSpec_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _Platform_Status_Handler,
+		},
+		{
+			MethodName: "IsRemediator",
+			Handler:    _Platform_IsRemediator_Handler,
+		},
+		{
+			MethodName: "RemediateSpec",
+			Handler:    _Platform_RemediateSpec_Handler,
+		},
+		{
+			MethodName: "Remediate",
+			Handler:    _Platform_Remediate_Handler,
+		},
+		{
+			MethodName: "IsValidator",
+			Handler:    _Platform_IsValidator_Handler,
+		},
+		{
+			MethodName: "ValidateSpec",
+			Handler:    _Platform_ValidateSpec_Handler,
+		},
+		{
+			MethodName: "Validate",
+			Handler:    _Platform_Validate_Handler,
+		},
+		{
+			MethodName: "IsConfigDefaulter",
+			Handler:    _Platform_IsConfigDefaulter_Handler,
+		},
+		{
+			MethodName: "DefaultsSpec",
+			Handler:    _Platform_DefaultsSpec_Handler,
+		},
+		{
+			MethodName: "Defaults",
+			Handler:    _Platform_Defaults_Handler,
+		},
+		{
+			MethodName: "IsODRProfiler",
+			Handler:    _Platform_IsODRProfiler_Handler,
+		},
+		{
+			MethodName: "ODRRequirements",
+			Handler:    _Platform_ODRRequirements_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Troubleshoot",
+			Handler:       _Platform_Troubleshoot_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Snapshot",
+			Handler:       _Platform_Snapshot_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Restore",
+			Handler:       _Platform_Restore_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "plugin.proto",
+}
+
+// RegistryClient is the client API for Registry service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RegistryClient interface {
+	IsAuthenticator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	Auth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error)
+	AuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	ValidateAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ValidateAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	// component.AuthRefresher optional implementation
+	IsAuthRefresher(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	RefreshAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	RefreshAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error)
+	ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error)
+	Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ConfigSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_SchemaResp, error)
+	ConfigureValue(ctx context.Context, in *Config_ConfigureValueRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
+	PushSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Push(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Push_Resp, error)
+	// component.Destroyer optional implementation
+	IsDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	DestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Destroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Destroy_Resp, error)
+	// component.DestroyHooks optional implementation. The spec RPCs return
+	// codes.Unimplemented if the hook isn't implemented.
+	PreDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	PreDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PostDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	PostDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// component.WorkspaceDestroyer optional implementation
+	IsWorkspaceDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	DestroyWorkspaceSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	DestroyWorkspace(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// component.RegistryODR optional implementation, used instead of Push
+	// when the host runs the plugin in an on-demand runner.
+	PushSpecODR(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	PushODR(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Push_Resp, error)
+	// component.Status optional implementation
+	IsStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	StatusSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Status(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*StatusReport, error)
+	// Access and AccessSpec are used to provide the optional component.RegistryAccess
+	// interface.
+	AccessSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Access(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Access_Resp, error)
+	// Pull, PullSpec, Verify, and VerifySpec are used to provide the optional
+	// component.RegistryPuller interface.
+	IsRegistryPuller(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	PullSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Pull(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Pull_Resp, error)
+	VerifySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Verify(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// component.Signer optional implementation
+	IsSigner(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	SignSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Sign(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Signature, error)
+	VerifySignatureSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	VerifySignature(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// component.Validator optional implementation
+	IsValidator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	ValidateSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Validate(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*ValidationResult, error)
+	// component.ConfigDefaulter optional implementation
+	IsConfigDefaulter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	DefaultsSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Defaults(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Config_Effective, error)
+	// component.ODRProfiler optional implementation
+	IsODRProfiler(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	ODRRequirements(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ODR_Requirements, error)
+}
+
+type registryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRegistryClient(cc grpc.ClientConnInterface) RegistryClient {
+	return &registryClient{cc}
+}
+
+func (c *registryClient) IsAuthenticator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/IsAuthenticator", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) Auth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error) {
+	out := new(Auth_AuthResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Auth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) AuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/AuthSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ValidateAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/ValidateAuth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ValidateAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/ValidateAuthSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) IsAuthRefresher(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/IsAuthRefresher", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) RefreshAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/RefreshAuthSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) RefreshAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error) {
+	out := new(Auth_AuthResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/RefreshAuth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error) {
+	out := new(Config_StructResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/ConfigStruct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Configure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ConfigSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_SchemaResp, error) {
+	out := new(Config_SchemaResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/ConfigSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ConfigureValue(ctx context.Context, in *Config_ConfigureValueRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/ConfigureValue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
+	out := new(Config_Documentation)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Documentation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) PushSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/PushSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) Push(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Push_Resp, error) {
+	out := new(Push_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Push", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) IsDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/IsDestroyer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) DestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/DestroySpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) Destroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Destroy_Resp, error) {
+	out := new(Destroy_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Destroy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) PreDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/PreDestroySpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) PreDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/PreDestroy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) PostDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/PostDestroySpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) PostDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/PostDestroy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) IsWorkspaceDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/IsWorkspaceDestroyer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) DestroyWorkspaceSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/DestroyWorkspaceSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) DestroyWorkspace(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/DestroyWorkspace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) PushSpecODR(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/PushSpecODR", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) PushODR(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Push_Resp, error) {
+	out := new(Push_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/PushODR", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) IsStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/IsStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) StatusSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/StatusSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) Status(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*StatusReport, error) {
+	out := new(StatusReport)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) AccessSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/AccessSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) Access(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Access_Resp, error) {
+	out := new(Access_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Access", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) IsRegistryPuller(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/IsRegistryPuller", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) PullSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/PullSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) Pull(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Pull_Resp, error) {
+	out := new(Pull_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Pull", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) VerifySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/VerifySpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) Verify(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Verify", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) IsSigner(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/IsSigner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) SignSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/SignSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) Sign(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Signature, error) {
+	out := new(Signature)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Sign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) VerifySignatureSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/VerifySignatureSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) VerifySignature(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/VerifySignature", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) IsValidator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/IsValidator", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ValidateSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/ValidateSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) Validate(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*ValidationResult, error) {
+	out := new(ValidationResult)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Validate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) IsConfigDefaulter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/IsConfigDefaulter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) DefaultsSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/DefaultsSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) Defaults(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Config_Effective, error) {
+	out := new(Config_Effective)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/Defaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) IsODRProfiler(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/IsODRProfiler", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ODRRequirements(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ODR_Requirements, error) {
+	out := new(ODR_Requirements)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.Registry/ODRRequirements", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RegistryServer is the server API for Registry service.
+// All implementations must embed UnimplementedRegistryServer
+// for forward compatibility
+type RegistryServer interface {
+	IsAuthenticator(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	Auth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error)
+	AuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	ValidateAuth(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	ValidateAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	// component.AuthRefresher optional implementation
+	IsAuthRefresher(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	RefreshAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	RefreshAuth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error)
+	ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error)
+	Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error)
+	ConfigSchema(context.Context, *emptypb.Empty) (*Config_SchemaResp, error)
+	ConfigureValue(context.Context, *Config_ConfigureValueRequest) (*emptypb.Empty, error)
+	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
+	PushSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Push(context.Context, *FuncSpec_Args) (*Push_Resp, error)
+	// component.Destroyer optional implementation
+	IsDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	DestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Destroy(context.Context, *FuncSpec_Args) (*Destroy_Resp, error)
+	// component.DestroyHooks optional implementation. The spec RPCs return
+	// codes.Unimplemented if the hook isn't implemented.
+	PreDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	PreDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	PostDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	PostDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	// component.WorkspaceDestroyer optional implementation
+	IsWorkspaceDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	DestroyWorkspaceSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	DestroyWorkspace(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	// component.RegistryODR optional implementation, used instead of Push
+	// when the host runs the plugin in an on-demand runner.
+	PushSpecODR(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	PushODR(context.Context, *FuncSpec_Args) (*Push_Resp, error)
+	// component.Status optional implementation
+	IsStatus(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	StatusSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Status(context.Context, *FuncSpec_Args) (*StatusReport, error)
+	// Access and AccessSpec are used to provide the optional component.RegistryAccess
+	// interface.
+	AccessSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Access(context.Context, *FuncSpec_Args) (*Access_Resp, error)
+	// Pull, PullSpec, Verify, and VerifySpec are used to provide the optional
+	// component.RegistryPuller interface.
+	IsRegistryPuller(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	PullSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Pull(context.Context, *FuncSpec_Args) (*Pull_Resp, error)
+	VerifySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Verify(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	// component.Signer optional implementation
+	IsSigner(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	SignSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Sign(context.Context, *FuncSpec_Args) (*Signature, error)
+	VerifySignatureSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	VerifySignature(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	// component.Validator optional implementation
+	IsValidator(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	ValidateSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Validate(context.Context, *FuncSpec_Args) (*ValidationResult, error)
+	// component.ConfigDefaulter optional implementation
+	IsConfigDefaulter(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	DefaultsSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Defaults(context.Context, *FuncSpec_Args) (*Config_Effective, error)
+	// component.ODRProfiler optional implementation
+	IsODRProfiler(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	ODRRequirements(context.Context, *emptypb.Empty) (*ODR_Requirements, error)
+	mustEmbedUnimplementedRegistryServer()
+}
+
+// UnimplementedRegistryServer must be embedded to have forward compatible implementations.
+type UnimplementedRegistryServer struct {
+}
+
+func (UnimplementedRegistryServer) IsAuthenticator(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsAuthenticator not implemented")
+}
+func (UnimplementedRegistryServer) Auth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Auth not implemented")
+}
+func (UnimplementedRegistryServer) AuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthSpec not implemented")
+}
+func (UnimplementedRegistryServer) ValidateAuth(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateAuth not implemented")
+}
+func (UnimplementedRegistryServer) ValidateAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateAuthSpec not implemented")
+}
+func (UnimplementedRegistryServer) IsAuthRefresher(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsAuthRefresher not implemented")
+}
+func (UnimplementedRegistryServer) RefreshAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshAuthSpec not implemented")
+}
+func (UnimplementedRegistryServer) RefreshAuth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshAuth not implemented")
+}
+func (UnimplementedRegistryServer) ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigStruct not implemented")
+}
+func (UnimplementedRegistryServer) Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
+}
+func (UnimplementedRegistryServer) ConfigSchema(context.Context, *emptypb.Empty) (*Config_SchemaResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigSchema not implemented")
+}
+func (UnimplementedRegistryServer) ConfigureValue(context.Context, *Config_ConfigureValueRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigureValue not implemented")
+}
+func (UnimplementedRegistryServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
+}
+func (UnimplementedRegistryServer) PushSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushSpec not implemented")
+}
+func (UnimplementedRegistryServer) Push(context.Context, *FuncSpec_Args) (*Push_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
+}
+func (UnimplementedRegistryServer) IsDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsDestroyer not implemented")
+}
+func (UnimplementedRegistryServer) DestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DestroySpec not implemented")
+}
+func (UnimplementedRegistryServer) Destroy(context.Context, *FuncSpec_Args) (*Destroy_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Destroy not implemented")
+}
+func (UnimplementedRegistryServer) PreDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreDestroySpec not implemented")
+}
+func (UnimplementedRegistryServer) PreDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreDestroy not implemented")
+}
+func (UnimplementedRegistryServer) PostDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostDestroySpec not implemented")
+}
+func (UnimplementedRegistryServer) PostDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostDestroy not implemented")
+}
+func (UnimplementedRegistryServer) IsWorkspaceDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsWorkspaceDestroyer not implemented")
+}
+func (UnimplementedRegistryServer) DestroyWorkspaceSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DestroyWorkspaceSpec not implemented")
+}
+func (UnimplementedRegistryServer) DestroyWorkspace(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DestroyWorkspace not implemented")
+}
+func (UnimplementedRegistryServer) PushSpecODR(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushSpecODR not implemented")
+}
+func (UnimplementedRegistryServer) PushODR(context.Context, *FuncSpec_Args) (*Push_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushODR not implemented")
+}
+func (UnimplementedRegistryServer) IsStatus(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsStatus not implemented")
+}
+func (UnimplementedRegistryServer) StatusSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatusSpec not implemented")
+}
+func (UnimplementedRegistryServer) Status(context.Context, *FuncSpec_Args) (*StatusReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedRegistryServer) AccessSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccessSpec not implemented")
+}
+func (UnimplementedRegistryServer) Access(context.Context, *FuncSpec_Args) (*Access_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Access not implemented")
+}
+func (UnimplementedRegistryServer) IsRegistryPuller(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsRegistryPuller not implemented")
+}
+func (UnimplementedRegistryServer) PullSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PullSpec not implemented")
+}
+func (UnimplementedRegistryServer) Pull(context.Context, *FuncSpec_Args) (*Pull_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pull not implemented")
+}
+func (UnimplementedRegistryServer) VerifySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifySpec not implemented")
+}
+func (UnimplementedRegistryServer) Verify(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Verify not implemented")
+}
+func (UnimplementedRegistryServer) IsSigner(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsSigner not implemented")
+}
+func (UnimplementedRegistryServer) SignSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignSpec not implemented")
+}
+func (UnimplementedRegistryServer) Sign(context.Context, *FuncSpec_Args) (*Signature, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sign not implemented")
+}
+func (UnimplementedRegistryServer) VerifySignatureSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifySignatureSpec not implemented")
+}
+func (UnimplementedRegistryServer) VerifySignature(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifySignature not implemented")
+}
+func (UnimplementedRegistryServer) IsValidator(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsValidator not implemented")
+}
+func (UnimplementedRegistryServer) ValidateSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateSpec not implemented")
+}
+func (UnimplementedRegistryServer) Validate(context.Context, *FuncSpec_Args) (*ValidationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedRegistryServer) IsConfigDefaulter(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsConfigDefaulter not implemented")
+}
+func (UnimplementedRegistryServer) DefaultsSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DefaultsSpec not implemented")
+}
+func (UnimplementedRegistryServer) Defaults(context.Context, *FuncSpec_Args) (*Config_Effective, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Defaults not implemented")
+}
+func (UnimplementedRegistryServer) IsODRProfiler(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsODRProfiler not implemented")
+}
+func (UnimplementedRegistryServer) ODRRequirements(context.Context, *emptypb.Empty) (*ODR_Requirements, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ODRRequirements not implemented")
+}
+func (UnimplementedRegistryServer) mustEmbedUnimplementedRegistryServer() {}
+
+// UnsafeRegistryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RegistryServer will
+// result in compilation errors.
+type UnsafeRegistryServer interface {
+	mustEmbedUnimplementedRegistryServer()
+}
+
+func RegisterRegistryServer(s grpc.ServiceRegistrar, srv RegistryServer) {
+	s.RegisterService(&Registry_ServiceDesc, srv)
+}
+
+func _Registry_IsAuthenticator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).IsAuthenticator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/IsAuthenticator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).IsAuthenticator(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_Auth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).Auth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/Auth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).Auth(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_AuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).AuthSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/AuthSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).AuthSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ValidateAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ValidateAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/ValidateAuth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ValidateAuth(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ValidateAuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ValidateAuthSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/ValidateAuthSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ValidateAuthSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_IsAuthRefresher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).IsAuthRefresher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/IsAuthRefresher",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).IsAuthRefresher(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_RefreshAuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).RefreshAuthSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/RefreshAuthSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).RefreshAuthSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_RefreshAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).RefreshAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/RefreshAuth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).RefreshAuth(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ConfigStruct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ConfigStruct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/ConfigStruct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ConfigStruct(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config_ConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).Configure(ctx, req.(*Config_ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ConfigSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ConfigSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/ConfigSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ConfigSchema(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ConfigureValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config_ConfigureValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ConfigureValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/ConfigureValue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ConfigureValue(ctx, req.(*Config_ConfigureValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).Documentation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/Documentation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).Documentation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_PushSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).PushSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/PushSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).PushSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).Push(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/Push",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).Push(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_IsDestroyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).IsDestroyer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/IsDestroyer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).IsDestroyer(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_DestroySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).DestroySpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/DestroySpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).DestroySpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_Destroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).Destroy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/Destroy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).Destroy(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_PreDestroySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).PreDestroySpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/PreDestroySpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).PreDestroySpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_PreDestroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).PreDestroy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/PreDestroy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).PreDestroy(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_PostDestroySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).PostDestroySpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/PostDestroySpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).PostDestroySpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_PostDestroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).PostDestroy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/PostDestroy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).PostDestroy(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_IsWorkspaceDestroyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).IsWorkspaceDestroyer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/IsWorkspaceDestroyer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).IsWorkspaceDestroyer(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_DestroyWorkspaceSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).DestroyWorkspaceSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/DestroyWorkspaceSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).DestroyWorkspaceSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_DestroyWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).DestroyWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/DestroyWorkspace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).DestroyWorkspace(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_PushSpecODR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).PushSpecODR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/PushSpecODR",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).PushSpecODR(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_PushODR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).PushODR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/PushODR",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).PushODR(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_IsStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).IsStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/IsStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).IsStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_StatusSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).StatusSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/StatusSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).StatusSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).Status(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_AccessSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).AccessSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/AccessSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).AccessSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_Access_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).Access(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/Access",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).Access(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_IsRegistryPuller_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).IsRegistryPuller(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/IsRegistryPuller",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).IsRegistryPuller(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_PullSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).PullSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/PullSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).PullSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_Pull_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).Pull(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/Pull",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).Pull(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_VerifySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).VerifySpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/VerifySpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).VerifySpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_Verify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).Verify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/Verify",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).Verify(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_IsSigner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).IsSigner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/IsSigner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).IsSigner(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_SignSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).SignSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/SignSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).SignSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/Sign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).Sign(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_VerifySignatureSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).VerifySignatureSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/VerifySignatureSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).VerifySignatureSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_VerifySignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).VerifySignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/VerifySignature",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).VerifySignature(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_IsValidator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).IsValidator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/IsValidator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).IsValidator(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ValidateSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ValidateSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/ValidateSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ValidateSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/Validate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).Validate(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_IsConfigDefaulter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).IsConfigDefaulter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/IsConfigDefaulter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).IsConfigDefaulter(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_DefaultsSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).DefaultsSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/DefaultsSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).DefaultsSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_Defaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).Defaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/Defaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).Defaults(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_IsODRProfiler_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).IsODRProfiler(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/IsODRProfiler",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).IsODRProfiler(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ODRRequirements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ODRRequirements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.Registry/ODRRequirements",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ODRRequirements(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Registry_ServiceDesc is the grpc.ServiceDesc for Registry service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Registry_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.waypoint.sdk.Registry",
+	HandlerType: (*RegistryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IsAuthenticator",
+			Handler:    _Registry_IsAuthenticator_Handler,
+		},
+		{
+			MethodName: "Auth",
+			Handler:    _Registry_Auth_Handler,
+		},
+		{
+			MethodName: "AuthSpec",
+			Handler:    _Registry_AuthSpec_Handler,
+		},
+		{
+			MethodName: "ValidateAuth",
+			Handler:    _Registry_ValidateAuth_Handler,
+		},
+		{
+			MethodName: "ValidateAuthSpec",
+			Handler:    _Registry_ValidateAuthSpec_Handler,
+		},
+		{
+			MethodName: "IsAuthRefresher",
+			Handler:    _Registry_IsAuthRefresher_Handler,
+		},
+		{
+			MethodName: "RefreshAuthSpec",
+			Handler:    _Registry_RefreshAuthSpec_Handler,
+		},
+		{
+			MethodName: "RefreshAuth",
+			Handler:    _Registry_RefreshAuth_Handler,
+		},
+		{
+			MethodName: "ConfigStruct",
+			Handler:    _Registry_ConfigStruct_Handler,
+		},
+		{
+			MethodName: "Configure",
+			Handler:    _Registry_Configure_Handler,
+		},
+		{
+			MethodName: "ConfigSchema",
+			Handler:    _Registry_ConfigSchema_Handler,
+		},
+		{
+			MethodName: "ConfigureValue",
+			Handler:    _Registry_ConfigureValue_Handler,
+		},
+		{
+			MethodName: "Documentation",
+			Handler:    _Registry_Documentation_Handler,
+		},
+		{
+			MethodName: "PushSpec",
+			Handler:    _Registry_PushSpec_Handler,
+		},
+		{
+			MethodName: "Push",
+			Handler:    _Registry_Push_Handler,
+		},
+		{
+			MethodName: "IsDestroyer",
+			Handler:    _Registry_IsDestroyer_Handler,
+		},
+		{
+			MethodName: "DestroySpec",
+			Handler:    _Registry_DestroySpec_Handler,
+		},
+		{
+			MethodName: "Destroy",
+			Handler:    _Registry_Destroy_Handler,
+		},
+		{
+			MethodName: "PreDestroySpec",
+			Handler:    _Registry_PreDestroySpec_Handler,
+		},
+		{
+			MethodName: "PreDestroy",
+			Handler:    _Registry_PreDestroy_Handler,
+		},
+		{
+			MethodName: "PostDestroySpec",
+			Handler:    _Registry_PostDestroySpec_Handler,
+		},
+		{
+			MethodName: "PostDestroy",
+			Handler:    _Registry_PostDestroy_Handler,
+		},
+		{
+			MethodName: "IsWorkspaceDestroyer",
+			Handler:    _Registry_IsWorkspaceDestroyer_Handler,
+		},
+		{
+			MethodName: "DestroyWorkspaceSpec",
+			Handler:    _Registry_DestroyWorkspaceSpec_Handler,
+		},
+		{
+			MethodName: "DestroyWorkspace",
+			Handler:    _Registry_DestroyWorkspace_Handler,
+		},
+		{
+			MethodName: "PushSpecODR",
+			Handler:    _Registry_PushSpecODR_Handler,
+		},
+		{
+			MethodName: "PushODR",
+			Handler:    _Registry_PushODR_Handler,
+		},
+		{
+			MethodName: "IsStatus",
+			Handler:    _Registry_IsStatus_Handler,
+		},
+		{
+			MethodName: "StatusSpec",
+			Handler:    _Registry_StatusSpec_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _Registry_Status_Handler,
+		},
+		{
+			MethodName: "AccessSpec",
+			Handler:    _Registry_AccessSpec_Handler,
+		},
+		{
+			MethodName: "Access",
+			Handler:    _Registry_Access_Handler,
+		},
+		{
+			MethodName: "IsRegistryPuller",
+			Handler:    _Registry_IsRegistryPuller_Handler,
+		},
+		{
+			MethodName: "PullSpec",
+			Handler:    _Registry_PullSpec_Handler,
+		},
+		{
+			MethodName: "Pull",
+			Handler:    _Registry_Pull_Handler,
+		},
+		{
+			MethodName: "VerifySpec",
+			Handler:    _Registry_VerifySpec_Handler,
+		},
+		{
+			MethodName: "Verify",
+			Handler:    _Registry_Verify_Handler,
+		},
+		{
+			MethodName: "IsSigner",
+			Handler:    _Registry_IsSigner_Handler,
+		},
+		{
+			MethodName: "SignSpec",
+			Handler:    _Registry_SignSpec_Handler,
+		},
+		{
+			MethodName: "Sign",
+			Handler:    _Registry_Sign_Handler,
+		},
+		{
+			MethodName: "VerifySignatureSpec",
+			Handler:    _Registry_VerifySignatureSpec_Handler,
+		},
+		{
+			MethodName: "VerifySignature",
+			Handler:    _Registry_VerifySignature_Handler,
+		},
+		{
+			MethodName: "IsValidator",
+			Handler:    _Registry_IsValidator_Handler,
+		},
+		{
+			MethodName: "ValidateSpec",
+			Handler:    _Registry_ValidateSpec_Handler,
+		},
+		{
+			MethodName: "Validate",
+			Handler:    _Registry_Validate_Handler,
+		},
+		{
+			MethodName: "IsConfigDefaulter",
+			Handler:    _Registry_IsConfigDefaulter_Handler,
+		},
+		{
+			MethodName: "DefaultsSpec",
+			Handler:    _Registry_DefaultsSpec_Handler,
+		},
+		{
+			MethodName: "Defaults",
+			Handler:    _Registry_Defaults_Handler,
+		},
+		{
+			MethodName: "IsODRProfiler",
+			Handler:    _Registry_IsODRProfiler_Handler,
+		},
+		{
+			MethodName: "ODRRequirements",
+			Handler:    _Registry_ODRRequirements_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "plugin.proto",
+}
+
+// ReleaseManagerClient is the client API for ReleaseManager service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReleaseManagerClient interface {
+	IsAuthenticator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	Auth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error)
+	AuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	ValidateAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ValidateAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	// component.AuthRefresher optional implementation
+	IsAuthRefresher(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	RefreshAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	RefreshAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error)
+	ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error)
+	Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ConfigSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_SchemaResp, error)
+	ConfigureValue(ctx context.Context, in *Config_ConfigureValueRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
+	// component.Destroyer optional implementation
+	IsDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	DestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Destroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Destroy_Resp, error)
+	// component.DestroyHooks optional implementation. The spec RPCs return
+	// codes.Unimplemented if the hook isn't implemented.
+	PreDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	PreDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PostDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	PostDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// component.WorkspaceDestroyer optional implementation
+	IsWorkspaceDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	DestroyWorkspaceSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	DestroyWorkspace(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ReleaseSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Release(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Release_Resp, error)
+	// component.Status optional implementation
+	IsStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	StatusSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Status(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*StatusReport, error)
+	// component.Remediator optional implementation
+	IsRemediator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	RemediateSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Remediate(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// component.Validator optional implementation
+	IsValidator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	ValidateSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Validate(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*ValidationResult, error)
+	// component.ConfigDefaulter optional implementation
+	IsConfigDefaulter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	DefaultsSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Defaults(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Config_Effective, error)
+}
+
+type releaseManagerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReleaseManagerClient(cc grpc.ClientConnInterface) ReleaseManagerClient {
+	return &releaseManagerClient{cc}
+}
+
+func (c *releaseManagerClient) IsAuthenticator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/IsAuthenticator", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) Auth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error) {
+	out := new(Auth_AuthResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Auth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) AuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/AuthSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) ValidateAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/ValidateAuth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) ValidateAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/ValidateAuthSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) IsAuthRefresher(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/IsAuthRefresher", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) RefreshAuthSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/RefreshAuthSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) RefreshAuth(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Auth_AuthResponse, error) {
+	out := new(Auth_AuthResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/RefreshAuth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error) {
+	out := new(Config_StructResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/ConfigStruct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Configure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) ConfigSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_SchemaResp, error) {
+	out := new(Config_SchemaResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/ConfigSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) ConfigureValue(ctx context.Context, in *Config_ConfigureValueRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/ConfigureValue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
+	out := new(Config_Documentation)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Documentation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) IsDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/IsDestroyer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) DestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/DestroySpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) Destroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Destroy_Resp, error) {
+	out := new(Destroy_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Destroy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) PreDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/PreDestroySpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) PreDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/PreDestroy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) PostDestroySpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/PostDestroySpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) PostDestroy(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/PostDestroy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) IsWorkspaceDestroyer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/IsWorkspaceDestroyer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) DestroyWorkspaceSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/DestroyWorkspaceSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) DestroyWorkspace(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/DestroyWorkspace", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) ReleaseSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/ReleaseSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) Release(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Release_Resp, error) {
+	out := new(Release_Resp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Release", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) IsStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/IsStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) StatusSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/StatusSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) Status(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*StatusReport, error) {
+	out := new(StatusReport)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) IsRemediator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/IsRemediator", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) RemediateSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/RemediateSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) Remediate(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Remediate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) IsValidator(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/IsValidator", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) ValidateSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/ValidateSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) Validate(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*ValidationResult, error) {
+	out := new(ValidationResult)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Validate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) IsConfigDefaulter(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/IsConfigDefaulter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) DefaultsSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/DefaultsSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseManagerClient) Defaults(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*Config_Effective, error) {
+	out := new(Config_Effective)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ReleaseManager/Defaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReleaseManagerServer is the server API for ReleaseManager service.
+// All implementations must embed UnimplementedReleaseManagerServer
+// for forward compatibility
+type ReleaseManagerServer interface {
+	IsAuthenticator(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	Auth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error)
+	AuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	ValidateAuth(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	ValidateAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	// component.AuthRefresher optional implementation
+	IsAuthRefresher(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	RefreshAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	RefreshAuth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error)
+	ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error)
+	Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error)
+	ConfigSchema(context.Context, *emptypb.Empty) (*Config_SchemaResp, error)
+	ConfigureValue(context.Context, *Config_ConfigureValueRequest) (*emptypb.Empty, error)
+	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
+	// component.Destroyer optional implementation
+	IsDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	DestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Destroy(context.Context, *FuncSpec_Args) (*Destroy_Resp, error)
+	// component.DestroyHooks optional implementation. The spec RPCs return
+	// codes.Unimplemented if the hook isn't implemented.
+	PreDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	PreDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	PostDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	PostDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	// component.WorkspaceDestroyer optional implementation
+	IsWorkspaceDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	DestroyWorkspaceSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	DestroyWorkspace(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	ReleaseSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Release(context.Context, *FuncSpec_Args) (*Release_Resp, error)
+	// component.Status optional implementation
+	IsStatus(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	StatusSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Status(context.Context, *FuncSpec_Args) (*StatusReport, error)
+	// component.Remediator optional implementation
+	IsRemediator(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	RemediateSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Remediate(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	// component.Validator optional implementation
+	IsValidator(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	ValidateSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Validate(context.Context, *FuncSpec_Args) (*ValidationResult, error)
+	// component.ConfigDefaulter optional implementation
+	IsConfigDefaulter(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	DefaultsSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Defaults(context.Context, *FuncSpec_Args) (*Config_Effective, error)
+	mustEmbedUnimplementedReleaseManagerServer()
+}
+
+// UnimplementedReleaseManagerServer must be embedded to have forward compatible implementations.
+type UnimplementedReleaseManagerServer struct {
+}
+
+func (UnimplementedReleaseManagerServer) IsAuthenticator(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsAuthenticator not implemented")
+}
+func (UnimplementedReleaseManagerServer) Auth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Auth not implemented")
+}
+func (UnimplementedReleaseManagerServer) AuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthSpec not implemented")
+}
+func (UnimplementedReleaseManagerServer) ValidateAuth(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateAuth not implemented")
+}
+func (UnimplementedReleaseManagerServer) ValidateAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateAuthSpec not implemented")
+}
+func (UnimplementedReleaseManagerServer) IsAuthRefresher(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsAuthRefresher not implemented")
+}
+func (UnimplementedReleaseManagerServer) RefreshAuthSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshAuthSpec not implemented")
+}
+func (UnimplementedReleaseManagerServer) RefreshAuth(context.Context, *FuncSpec_Args) (*Auth_AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshAuth not implemented")
+}
+func (UnimplementedReleaseManagerServer) ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigStruct not implemented")
+}
+func (UnimplementedReleaseManagerServer) Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
+}
+func (UnimplementedReleaseManagerServer) ConfigSchema(context.Context, *emptypb.Empty) (*Config_SchemaResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigSchema not implemented")
+}
+func (UnimplementedReleaseManagerServer) ConfigureValue(context.Context, *Config_ConfigureValueRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigureValue not implemented")
+}
+func (UnimplementedReleaseManagerServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
+}
+func (UnimplementedReleaseManagerServer) IsDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsDestroyer not implemented")
+}
+func (UnimplementedReleaseManagerServer) DestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DestroySpec not implemented")
+}
+func (UnimplementedReleaseManagerServer) Destroy(context.Context, *FuncSpec_Args) (*Destroy_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Destroy not implemented")
+}
+func (UnimplementedReleaseManagerServer) PreDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreDestroySpec not implemented")
+}
+func (UnimplementedReleaseManagerServer) PreDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreDestroy not implemented")
+}
+func (UnimplementedReleaseManagerServer) PostDestroySpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostDestroySpec not implemented")
+}
+func (UnimplementedReleaseManagerServer) PostDestroy(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostDestroy not implemented")
+}
+func (UnimplementedReleaseManagerServer) IsWorkspaceDestroyer(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsWorkspaceDestroyer not implemented")
+}
+func (UnimplementedReleaseManagerServer) DestroyWorkspaceSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DestroyWorkspaceSpec not implemented")
+}
+func (UnimplementedReleaseManagerServer) DestroyWorkspace(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DestroyWorkspace not implemented")
+}
+func (UnimplementedReleaseManagerServer) ReleaseSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseSpec not implemented")
+}
+func (UnimplementedReleaseManagerServer) Release(context.Context, *FuncSpec_Args) (*Release_Resp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Release not implemented")
+}
+func (UnimplementedReleaseManagerServer) IsStatus(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsStatus not implemented")
+}
+func (UnimplementedReleaseManagerServer) StatusSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatusSpec not implemented")
+}
+func (UnimplementedReleaseManagerServer) Status(context.Context, *FuncSpec_Args) (*StatusReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedReleaseManagerServer) IsRemediator(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsRemediator not implemented")
+}
+func (UnimplementedReleaseManagerServer) RemediateSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemediateSpec not implemented")
+}
+func (UnimplementedReleaseManagerServer) Remediate(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remediate not implemented")
+}
+func (UnimplementedReleaseManagerServer) IsValidator(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsValidator not implemented")
+}
+func (UnimplementedReleaseManagerServer) ValidateSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateSpec not implemented")
+}
+func (UnimplementedReleaseManagerServer) Validate(context.Context, *FuncSpec_Args) (*ValidationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedReleaseManagerServer) IsConfigDefaulter(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsConfigDefaulter not implemented")
+}
+func (UnimplementedReleaseManagerServer) DefaultsSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DefaultsSpec not implemented")
+}
+func (UnimplementedReleaseManagerServer) Defaults(context.Context, *FuncSpec_Args) (*Config_Effective, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Defaults not implemented")
+}
+func (UnimplementedReleaseManagerServer) mustEmbedUnimplementedReleaseManagerServer() {}
+
+// UnsafeReleaseManagerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReleaseManagerServer will
+// result in compilation errors.
+type UnsafeReleaseManagerServer interface {
+	mustEmbedUnimplementedReleaseManagerServer()
+}
+
+func RegisterReleaseManagerServer(s grpc.ServiceRegistrar, srv ReleaseManagerServer) {
+	s.RegisterService(&ReleaseManager_ServiceDesc, srv)
+}
+
+func _ReleaseManager_IsAuthenticator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).IsAuthenticator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/IsAuthenticator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).IsAuthenticator(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_Auth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).Auth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Auth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).Auth(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_AuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).AuthSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/AuthSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).AuthSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_ValidateAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).ValidateAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/ValidateAuth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).ValidateAuth(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_ValidateAuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).ValidateAuthSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/ValidateAuthSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).ValidateAuthSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_IsAuthRefresher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).IsAuthRefresher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/IsAuthRefresher",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).IsAuthRefresher(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_RefreshAuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).RefreshAuthSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/RefreshAuthSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).RefreshAuthSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_RefreshAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).RefreshAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/RefreshAuth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).RefreshAuth(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_ConfigStruct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).ConfigStruct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/ConfigStruct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).ConfigStruct(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config_ConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).Configure(ctx, req.(*Config_ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_ConfigSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).ConfigSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/ConfigSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).ConfigSchema(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_ConfigureValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config_ConfigureValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).ConfigureValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/ConfigureValue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).ConfigureValue(ctx, req.(*Config_ConfigureValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).Documentation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Documentation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).Documentation(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_IsDestroyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).IsDestroyer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/IsDestroyer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).IsDestroyer(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_DestroySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).DestroySpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/DestroySpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).DestroySpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_Destroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).Destroy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Destroy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).Destroy(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_IsAuthenticator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_PreDestroySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).IsAuthenticator(ctx, in)
+		return srv.(ReleaseManagerServer).PreDestroySpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/IsAuthenticator",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/PreDestroySpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).IsAuthenticator(ctx, req.(*emptypb.Empty))
+		return srv.(ReleaseManagerServer).PreDestroySpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_Auth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_PreDestroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).Auth(ctx, in)
+		return srv.(ReleaseManagerServer).PreDestroy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Auth",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/PreDestroy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).Auth(ctx, req.(*FuncSpec_Args))
+		return srv.(ReleaseManagerServer).PreDestroy(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_AuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_PostDestroySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).AuthSpec(ctx, in)
+		return srv.(ReleaseManagerServer).PostDestroySpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/AuthSpec",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/PostDestroySpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).AuthSpec(ctx, req.(*emptypb.Empty))
+		return srv.(ReleaseManagerServer).PostDestroySpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_ValidateAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_PostDestroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).ValidateAuth(ctx, in)
+		return srv.(ReleaseManagerServer).PostDestroy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/ValidateAuth",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/PostDestroy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).ValidateAuth(ctx, req.(*FuncSpec_Args))
+		return srv.(ReleaseManagerServer).PostDestroy(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_ValidateAuthSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_IsWorkspaceDestroyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).ValidateAuthSpec(ctx, in)
+		return srv.(ReleaseManagerServer).IsWorkspaceDestroyer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/ValidateAuthSpec",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/IsWorkspaceDestroyer",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).ValidateAuthSpec(ctx, req.(*emptypb.Empty))
+		return srv.(ReleaseManagerServer).IsWorkspaceDestroyer(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_ConfigStruct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_DestroyWorkspaceSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).ConfigStruct(ctx, in)
+		return srv.(ReleaseManagerServer).DestroyWorkspaceSpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/ConfigStruct",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/DestroyWorkspaceSpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).ConfigStruct(ctx, req.(*emptypb.Empty))
+		return srv.(ReleaseManagerServer).DestroyWorkspaceSpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Config_ConfigureRequest)
+func _ReleaseManager_DestroyWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).Configure(ctx, in)
+		return srv.(ReleaseManagerServer).DestroyWorkspace(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Configure",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/DestroyWorkspace",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).Configure(ctx, req.(*Config_ConfigureRequest))
+		return srv.(ReleaseManagerServer).DestroyWorkspace(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_ReleaseSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).Documentation(ctx, in)
+		return srv.(ReleaseManagerServer).ReleaseSpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Documentation",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/ReleaseSpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).Documentation(ctx, req.(*emptypb.Empty))
+		return srv.(ReleaseManagerServer).ReleaseSpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_IsDestroyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_Release_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).Release(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Release",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).Release(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_IsStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).IsDestroyer(ctx, in)
+		return srv.(ReleaseManagerServer).IsStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/IsDestroyer",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/IsStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).IsDestroyer(ctx, req.(*emptypb.Empty))
+		return srv.(ReleaseManagerServer).IsStatus(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_DestroySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_StatusSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).DestroySpec(ctx, in)
+		return srv.(ReleaseManagerServer).StatusSpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/DestroySpec",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/StatusSpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).DestroySpec(ctx, req.(*emptypb.Empty))
+		return srv.(ReleaseManagerServer).StatusSpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_Destroy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).Destroy(ctx, in)
+		return srv.(ReleaseManagerServer).Status(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Destroy",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Status",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).Destroy(ctx, req.(*FuncSpec_Args))
+		return srv.(ReleaseManagerServer).Status(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_IsWorkspaceDestroyer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_IsRemediator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).IsWorkspaceDestroyer(ctx, in)
+		return srv.(ReleaseManagerServer).IsRemediator(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/IsWorkspaceDestroyer",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/IsRemediator",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).IsWorkspaceDestroyer(ctx, req.(*emptypb.Empty))
+		return srv.(ReleaseManagerServer).IsRemediator(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_DestroyWorkspaceSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_RemediateSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).DestroyWorkspaceSpec(ctx, in)
+		return srv.(ReleaseManagerServer).RemediateSpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/DestroyWorkspaceSpec",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/RemediateSpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).DestroyWorkspaceSpec(ctx, req.(*emptypb.Empty))
+		return srv.(ReleaseManagerServer).RemediateSpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_DestroyWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_Remediate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).DestroyWorkspace(ctx, in)
+		return srv.(ReleaseManagerServer).Remediate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/DestroyWorkspace",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Remediate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).DestroyWorkspace(ctx, req.(*FuncSpec_Args))
+		return srv.(ReleaseManagerServer).Remediate(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_ReleaseSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_IsValidator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).ReleaseSpec(ctx, in)
+		return srv.(ReleaseManagerServer).IsValidator(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/ReleaseSpec",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/IsValidator",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).ReleaseSpec(ctx, req.(*emptypb.Empty))
+		return srv.(ReleaseManagerServer).IsValidator(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_Release_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_ValidateSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseManagerServer).ValidateSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/ValidateSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseManagerServer).ValidateSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseManager_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).Release(ctx, in)
+		return srv.(ReleaseManagerServer).Validate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Release",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Validate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).Release(ctx, req.(*FuncSpec_Args))
+		return srv.(ReleaseManagerServer).Validate(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_IsStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_IsConfigDefaulter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).IsStatus(ctx, in)
+		return srv.(ReleaseManagerServer).IsConfigDefaulter(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/IsStatus",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/IsConfigDefaulter",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).IsStatus(ctx, req.(*emptypb.Empty))
+		return srv.(ReleaseManagerServer).IsConfigDefaulter(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_StatusSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_DefaultsSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).StatusSpec(ctx, in)
+		return srv.(ReleaseManagerServer).DefaultsSpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/StatusSpec",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/DefaultsSpec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).StatusSpec(ctx, req.(*emptypb.Empty))
+		return srv.(ReleaseManagerServer).DefaultsSpec(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ReleaseManager_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ReleaseManager_Defaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FuncSpec_Args)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ReleaseManagerServer).Status(ctx, in)
+		return srv.(ReleaseManagerServer).Defaults(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Status",
+		FullMethod: "/hashicorp.waypoint.sdk.ReleaseManager/Defaults",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ReleaseManagerServer).Status(ctx, req.(*FuncSpec_Args))
+		return srv.(ReleaseManagerServer).Defaults(ctx, req.(*FuncSpec_Args))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -3399,6 +8903,18 @@ var ReleaseManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ValidateAuthSpec",
 			Handler:    _ReleaseManager_ValidateAuthSpec_Handler,
 		},
+		{
+			MethodName: "IsAuthRefresher",
+			Handler:    _ReleaseManager_IsAuthRefresher_Handler,
+		},
+		{
+			MethodName: "RefreshAuthSpec",
+			Handler:    _ReleaseManager_RefreshAuthSpec_Handler,
+		},
+		{
+			MethodName: "RefreshAuth",
+			Handler:    _ReleaseManager_RefreshAuth_Handler,
+		},
 		{
 			MethodName: "ConfigStruct",
 			Handler:    _ReleaseManager_ConfigStruct_Handler,
@@ -3407,6 +8923,14 @@ var ReleaseManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Configure",
 			Handler:    _ReleaseManager_Configure_Handler,
 		},
+		{
+			MethodName: "ConfigSchema",
+			Handler:    _ReleaseManager_ConfigSchema_Handler,
+		},
+		{
+			MethodName: "ConfigureValue",
+			Handler:    _ReleaseManager_ConfigureValue_Handler,
+		},
 		{
 			MethodName: "Documentation",
 			Handler:    _ReleaseManager_Documentation_Handler,
@@ -3423,6 +8947,22 @@ var ReleaseManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Destroy",
 			Handler:    _ReleaseManager_Destroy_Handler,
 		},
+		{
+			MethodName: "PreDestroySpec",
+			Handler:    _ReleaseManager_PreDestroySpec_Handler,
+		},
+		{
+			MethodName: "PreDestroy",
+			Handler:    _ReleaseManager_PreDestroy_Handler,
+		},
+		{
+			MethodName: "PostDestroySpec",
+			Handler:    _ReleaseManager_PostDestroySpec_Handler,
+		},
+		{
+			MethodName: "PostDestroy",
+			Handler:    _ReleaseManager_PostDestroy_Handler,
+		},
 		{
 			MethodName: "IsWorkspaceDestroyer",
 			Handler:    _ReleaseManager_IsWorkspaceDestroyer_Handler,
@@ -3455,6 +8995,42 @@ var ReleaseManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _ReleaseManager_Status_Handler,
 		},
+		{
+			MethodName: "IsRemediator",
+			Handler:    _ReleaseManager_IsRemediator_Handler,
+		},
+		{
+			MethodName: "RemediateSpec",
+			Handler:    _ReleaseManager_RemediateSpec_Handler,
+		},
+		{
+			MethodName: "Remediate",
+			Handler:    _ReleaseManager_Remediate_Handler,
+		},
+		{
+			MethodName: "IsValidator",
+			Handler:    _ReleaseManager_IsValidator_Handler,
+		},
+		{
+			MethodName: "ValidateSpec",
+			Handler:    _ReleaseManager_ValidateSpec_Handler,
+		},
+		{
+			MethodName: "Validate",
+			Handler:    _ReleaseManager_Validate_Handler,
+		},
+		{
+			MethodName: "IsConfigDefaulter",
+			Handler:    _ReleaseManager_IsConfigDefaulter_Handler,
+		},
+		{
+			MethodName: "DefaultsSpec",
+			Handler:    _ReleaseManager_DefaultsSpec_Handler,
+		},
+		{
+			MethodName: "Defaults",
+			Handler:    _ReleaseManager_Defaults_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "plugin.proto",
@@ -3466,11 +9042,18 @@ var ReleaseManager_ServiceDesc = grpc.ServiceDesc{
 type ConfigSourcerClient interface {
 	ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error)
 	Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ConfigSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_SchemaResp, error)
+	ConfigureValue(ctx context.Context, in *Config_ConfigureValueRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
 	ReadSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
 	Read(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*ConfigSource_ReadResponse, error)
 	StopSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
 	Stop(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Watch is optional. A sourcer that implements it pushes updated values
+	// over a ConfigWatcher rather than waiting for Read to be polled.
+	IsWatcher(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error)
+	WatchSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
+	Watch(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type configSourcerClient struct {
@@ -3499,6 +9082,24 @@ func (c *configSourcerClient) Configure(ctx context.Context, in *Config_Configur
 	return out, nil
 }
 
+func (c *configSourcerClient) ConfigSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_SchemaResp, error) {
+	out := new(Config_SchemaResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ConfigSourcer/ConfigSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configSourcerClient) ConfigureValue(ctx context.Context, in *Config_ConfigureValueRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ConfigSourcer/ConfigureValue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *configSourcerClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
 	out := new(Config_Documentation)
 	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ConfigSourcer/Documentation", in, out, opts...)
@@ -3544,17 +9145,51 @@ func (c *configSourcerClient) Stop(ctx context.Context, in *FuncSpec_Args, opts
 	return out, nil
 }
 
+func (c *configSourcerClient) IsWatcher(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ImplementsResp, error) {
+	out := new(ImplementsResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ConfigSourcer/IsWatcher", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configSourcerClient) WatchSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error) {
+	out := new(FuncSpec)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ConfigSourcer/WatchSpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *configSourcerClient) Watch(ctx context.Context, in *FuncSpec_Args, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.ConfigSourcer/Watch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ConfigSourcerServer is the server API for ConfigSourcer service.
 // All implementations must embed UnimplementedConfigSourcerServer
 // for forward compatibility
 type ConfigSourcerServer interface {
 	ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error)
 	Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error)
+	ConfigSchema(context.Context, *emptypb.Empty) (*Config_SchemaResp, error)
+	ConfigureValue(context.Context, *Config_ConfigureValueRequest) (*emptypb.Empty, error)
 	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
 	ReadSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
 	Read(context.Context, *FuncSpec_Args) (*ConfigSource_ReadResponse, error)
 	StopSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
 	Stop(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
+	// Watch is optional. A sourcer that implements it pushes updated values
+	// over a ConfigWatcher rather than waiting for Read to be polled.
+	IsWatcher(context.Context, *emptypb.Empty) (*ImplementsResp, error)
+	WatchSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
+	Watch(context.Context, *FuncSpec_Args) (*emptypb.Empty, error)
 	mustEmbedUnimplementedConfigSourcerServer()
 }
 
@@ -3568,6 +9203,12 @@ func (UnimplementedConfigSourcerServer) ConfigStruct(context.Context, *emptypb.E
 func (UnimplementedConfigSourcerServer) Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
 }
+func (UnimplementedConfigSourcerServer) ConfigSchema(context.Context, *emptypb.Empty) (*Config_SchemaResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigSchema not implemented")
+}
+func (UnimplementedConfigSourcerServer) ConfigureValue(context.Context, *Config_ConfigureValueRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigureValue not implemented")
+}
 func (UnimplementedConfigSourcerServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
 }
@@ -3583,6 +9224,15 @@ func (UnimplementedConfigSourcerServer) StopSpec(context.Context, *emptypb.Empty
 func (UnimplementedConfigSourcerServer) Stop(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
+func (UnimplementedConfigSourcerServer) IsWatcher(context.Context, *emptypb.Empty) (*ImplementsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsWatcher not implemented")
+}
+func (UnimplementedConfigSourcerServer) WatchSpec(context.Context, *emptypb.Empty) (*FuncSpec, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WatchSpec not implemented")
+}
+func (UnimplementedConfigSourcerServer) Watch(context.Context, *FuncSpec_Args) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
 func (UnimplementedConfigSourcerServer) mustEmbedUnimplementedConfigSourcerServer() {}
 
 // UnsafeConfigSourcerServer may be embedded to opt out of forward compatibility for this service.
@@ -3614,20 +9264,56 @@ func _ConfigSourcer_ConfigStruct_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ConfigSourcer_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Config_ConfigureRequest)
+func _ConfigSourcer_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config_ConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigSourcerServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ConfigSourcer/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigSourcerServer).Configure(ctx, req.(*Config_ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigSourcer_ConfigSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigSourcerServer).ConfigSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ConfigSourcer/ConfigSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigSourcerServer).ConfigSchema(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigSourcer_ConfigureValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config_ConfigureValueRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ConfigSourcerServer).Configure(ctx, in)
+		return srv.(ConfigSourcerServer).ConfigureValue(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/hashicorp.waypoint.sdk.ConfigSourcer/Configure",
+		FullMethod: "/hashicorp.waypoint.sdk.ConfigSourcer/ConfigureValue",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ConfigSourcerServer).Configure(ctx, req.(*Config_ConfigureRequest))
+		return srv.(ConfigSourcerServer).ConfigureValue(ctx, req.(*Config_ConfigureValueRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -3722,6 +9408,60 @@ func _ConfigSourcer_Stop_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ConfigSourcer_IsWatcher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigSourcerServer).IsWatcher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ConfigSourcer/IsWatcher",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigSourcerServer).IsWatcher(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigSourcer_WatchSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigSourcerServer).WatchSpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ConfigSourcer/WatchSpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigSourcerServer).WatchSpec(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConfigSourcer_Watch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FuncSpec_Args)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigSourcerServer).Watch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.ConfigSourcer/Watch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigSourcerServer).Watch(ctx, req.(*FuncSpec_Args))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ConfigSourcer_ServiceDesc is the grpc.ServiceDesc for ConfigSourcer service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -3737,6 +9477,14 @@ var ConfigSourcer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Configure",
 			Handler:    _ConfigSourcer_Configure_Handler,
 		},
+		{
+			MethodName: "ConfigSchema",
+			Handler:    _ConfigSourcer_ConfigSchema_Handler,
+		},
+		{
+			MethodName: "ConfigureValue",
+			Handler:    _ConfigSourcer_ConfigureValue_Handler,
+		},
 		{
 			MethodName: "Documentation",
 			Handler:    _ConfigSourcer_Documentation_Handler,
@@ -3757,17 +9505,151 @@ var ConfigSourcer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Stop",
 			Handler:    _ConfigSourcer_Stop_Handler,
 		},
+		{
+			MethodName: "IsWatcher",
+			Handler:    _ConfigSourcer_IsWatcher_Handler,
+		},
+		{
+			MethodName: "WatchSpec",
+			Handler:    _ConfigSourcer_WatchSpec_Handler,
+		},
+		{
+			MethodName: "Watch",
+			Handler:    _ConfigSourcer_Watch_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "plugin.proto",
 }
 
+// ConfigWatcherClient is the client API for ConfigWatcher service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ConfigWatcherClient interface {
+	Update(ctx context.Context, opts ...grpc.CallOption) (ConfigWatcher_UpdateClient, error)
+}
+
+type configWatcherClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConfigWatcherClient(cc grpc.ClientConnInterface) ConfigWatcherClient {
+	return &configWatcherClient{cc}
+}
+
+func (c *configWatcherClient) Update(ctx context.Context, opts ...grpc.CallOption) (ConfigWatcher_UpdateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ConfigWatcher_ServiceDesc.Streams[0], "/hashicorp.waypoint.sdk.ConfigWatcher/Update", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &configWatcherUpdateClient{stream}
+	return x, nil
+}
+
+type ConfigWatcher_UpdateClient interface {
+	Send(*ConfigSource_WatchUpdate) error
+	CloseAndRecv() (*emptypb.Empty, error)
+	grpc.ClientStream
+}
+
+type configWatcherUpdateClient struct {
+	grpc.ClientStream
+}
+
+func (x *configWatcherUpdateClient) Send(m *ConfigSource_WatchUpdate) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *configWatcherUpdateClient) CloseAndRecv() (*emptypb.Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(emptypb.Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ConfigWatcherServer is the server API for ConfigWatcher service.
+// All implementations must embed UnimplementedConfigWatcherServer
+// for forward compatibility
+type ConfigWatcherServer interface {
+	Update(ConfigWatcher_UpdateServer) error
+	mustEmbedUnimplementedConfigWatcherServer()
+}
+
+// UnimplementedConfigWatcherServer must be embedded to have forward compatible implementations.
+type UnimplementedConfigWatcherServer struct {
+}
+
+func (UnimplementedConfigWatcherServer) Update(ConfigWatcher_UpdateServer) error {
+	return status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedConfigWatcherServer) mustEmbedUnimplementedConfigWatcherServer() {}
+
+// UnsafeConfigWatcherServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConfigWatcherServer will
+// result in compilation errors.
+type UnsafeConfigWatcherServer interface {
+	mustEmbedUnimplementedConfigWatcherServer()
+}
+
+func RegisterConfigWatcherServer(s grpc.ServiceRegistrar, srv ConfigWatcherServer) {
+	s.RegisterService(&ConfigWatcher_ServiceDesc, srv)
+}
+
+func _ConfigWatcher_Update_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConfigWatcherServer).Update(&configWatcherUpdateServer{stream})
+}
+
+type ConfigWatcher_UpdateServer interface {
+	SendAndClose(*emptypb.Empty) error
+	Recv() (*ConfigSource_WatchUpdate, error)
+	grpc.ServerStream
+}
+
+type configWatcherUpdateServer struct {
+	grpc.ServerStream
+}
+
+func (x *configWatcherUpdateServer) SendAndClose(m *emptypb.Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *configWatcherUpdateServer) Recv() (*ConfigSource_WatchUpdate, error) {
+	m := new(ConfigSource_WatchUpdate)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ConfigWatcher_ServiceDesc is the grpc.ServiceDesc for ConfigWatcher service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ConfigWatcher_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.waypoint.sdk.ConfigWatcher",
+	HandlerType: (*ConfigWatcherServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Update",
+			Handler:       _ConfigWatcher_Update_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "plugin.proto",
+}
+
 // TaskLauncherClient is the client API for TaskLauncher service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TaskLauncherClient interface {
 	ConfigStruct(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_StructResp, error)
 	Configure(ctx context.Context, in *Config_ConfigureRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ConfigSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_SchemaResp, error)
+	ConfigureValue(ctx context.Context, in *Config_ConfigureValueRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error)
 	StartSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
 	StopSpec(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FuncSpec, error)
@@ -3803,6 +9685,24 @@ func (c *taskLauncherClient) Configure(ctx context.Context, in *Config_Configure
 	return out, nil
 }
 
+func (c *taskLauncherClient) ConfigSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_SchemaResp, error) {
+	out := new(Config_SchemaResp)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.TaskLauncher/ConfigSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskLauncherClient) ConfigureValue(ctx context.Context, in *Config_ConfigureValueRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.TaskLauncher/ConfigureValue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *taskLauncherClient) Documentation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Config_Documentation, error) {
 	out := new(Config_Documentation)
 	err := c.cc.Invoke(ctx, "/hashicorp.waypoint.sdk.TaskLauncher/Documentation", in, out, opts...)
@@ -3872,6 +9772,8 @@ func (c *taskLauncherClient) WatchTask(ctx context.Context, in *FuncSpec_Args, o
 type TaskLauncherServer interface {
 	ConfigStruct(context.Context, *emptypb.Empty) (*Config_StructResp, error)
 	Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error)
+	ConfigSchema(context.Context, *emptypb.Empty) (*Config_SchemaResp, error)
+	ConfigureValue(context.Context, *Config_ConfigureValueRequest) (*emptypb.Empty, error)
 	Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error)
 	StartSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
 	StopSpec(context.Context, *emptypb.Empty) (*FuncSpec, error)
@@ -3892,6 +9794,12 @@ func (UnimplementedTaskLauncherServer) ConfigStruct(context.Context, *emptypb.Em
 func (UnimplementedTaskLauncherServer) Configure(context.Context, *Config_ConfigureRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
 }
+func (UnimplementedTaskLauncherServer) ConfigSchema(context.Context, *emptypb.Empty) (*Config_SchemaResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigSchema not implemented")
+}
+func (UnimplementedTaskLauncherServer) ConfigureValue(context.Context, *Config_ConfigureValueRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigureValue not implemented")
+}
 func (UnimplementedTaskLauncherServer) Documentation(context.Context, *emptypb.Empty) (*Config_Documentation, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Documentation not implemented")
 }
@@ -3962,6 +9870,42 @@ func _TaskLauncher_Configure_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskLauncher_ConfigSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskLauncherServer).ConfigSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.TaskLauncher/ConfigSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskLauncherServer).ConfigSchema(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskLauncher_ConfigureValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config_ConfigureValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskLauncherServer).ConfigureValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.waypoint.sdk.TaskLauncher/ConfigureValue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskLauncherServer).ConfigureValue(ctx, req.(*Config_ConfigureValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TaskLauncher_Documentation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -4103,6 +10047,14 @@ var TaskLauncher_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Configure",
 			Handler:    _TaskLauncher_Configure_Handler,
 		},
+		{
+			MethodName: "ConfigSchema",
+			Handler:    _TaskLauncher_ConfigSchema_Handler,
+		},
+		{
+			MethodName: "ConfigureValue",
+			Handler:    _TaskLauncher_ConfigureValue_Handler,
+		},
 		{
 			MethodName: "Documentation",
 			Handler:    _TaskLauncher_Documentation_Handler,
@@ -4135,3 +10087,127 @@ var TaskLauncher_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "plugin.proto",
 }
+
+// TaskWatchServiceClient is the client API for TaskWatchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TaskWatchServiceClient interface {
+	// Watch streams the output and state changes of a task for as long as
+	// the task is watched.
+	Watch(ctx context.Context, opts ...grpc.CallOption) (TaskWatchService_WatchClient, error)
+}
+
+type taskWatchServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTaskWatchServiceClient(cc grpc.ClientConnInterface) TaskWatchServiceClient {
+	return &taskWatchServiceClient{cc}
+}
+
+func (c *taskWatchServiceClient) Watch(ctx context.Context, opts ...grpc.CallOption) (TaskWatchService_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TaskWatchService_ServiceDesc.Streams[0], "/hashicorp.waypoint.sdk.TaskWatchService/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &taskWatchServiceWatchClient{stream}
+	return x, nil
+}
+
+type TaskWatchService_WatchClient interface {
+	Send(*TaskWatch_Event) error
+	CloseAndRecv() (*emptypb.Empty, error)
+	grpc.ClientStream
+}
+
+type taskWatchServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *taskWatchServiceWatchClient) Send(m *TaskWatch_Event) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *taskWatchServiceWatchClient) CloseAndRecv() (*emptypb.Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(emptypb.Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TaskWatchServiceServer is the server API for TaskWatchService service.
+// All implementations must embed UnimplementedTaskWatchServiceServer
+// for forward compatibility
+type TaskWatchServiceServer interface {
+	// Watch streams the output and state changes of a task for as long as
+	// the task is watched.
+	Watch(TaskWatchService_WatchServer) error
+	mustEmbedUnimplementedTaskWatchServiceServer()
+}
+
+// UnimplementedTaskWatchServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTaskWatchServiceServer struct {
+}
+
+func (UnimplementedTaskWatchServiceServer) Watch(TaskWatchService_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedTaskWatchServiceServer) mustEmbedUnimplementedTaskWatchServiceServer() {}
+
+// UnsafeTaskWatchServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TaskWatchServiceServer will
+// result in compilation errors.
+type UnsafeTaskWatchServiceServer interface {
+	mustEmbedUnimplementedTaskWatchServiceServer()
+}
+
+func RegisterTaskWatchServiceServer(s grpc.ServiceRegistrar, srv TaskWatchServiceServer) {
+	s.RegisterService(&TaskWatchService_ServiceDesc, srv)
+}
+
+func _TaskWatchService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TaskWatchServiceServer).Watch(&taskWatchServiceWatchServer{stream})
+}
+
+type TaskWatchService_WatchServer interface {
+	SendAndClose(*emptypb.Empty) error
+	Recv() (*TaskWatch_Event, error)
+	grpc.ServerStream
+}
+
+type taskWatchServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *taskWatchServiceWatchServer) SendAndClose(m *emptypb.Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *taskWatchServiceWatchServer) Recv() (*TaskWatch_Event, error) {
+	m := new(TaskWatch_Event)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TaskWatchService_ServiceDesc is the grpc.ServiceDesc for TaskWatchService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TaskWatchService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.waypoint.sdk.TaskWatchService",
+	HandlerType: (*TaskWatchServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _TaskWatchService_Watch_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "plugin.proto",
+}
@@ -0,0 +1,18 @@
+// Package sdktest helps plugin authors test their plugins the way
+// Waypoint runs them. A Harness serves the plugin with sdk.DebugServe,
+// connects to it over gRPC and calls its components with fakes for the
+// terminal UI, data directories and log viewer:
+//
+//	func TestPlatform(t *testing.T) {
+//		h := sdktest.New(t, sdk.WithComponents(&Platform{}))
+//		deployment := h.Lifecycle(component.PlatformType,
+//			`region = "us-east-1"`, &Image{Name: "app"})
+//
+//		var d Deployment
+//		require.NoError(t, component.ProtoAnyUnmarshal(deployment, &d))
+//		require.Contains(t, h.UI.String(), "Deployment created")
+//	}
+//
+// Since the plugin is served in the test process, tests can also check
+// the state of the components directly.
+package sdktest
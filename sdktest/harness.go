@@ -0,0 +1,388 @@
+package sdktest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-argmapper"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/opaqueany"
+
+	sdk "github.com/hashicorp/waypoint-plugin-sdk"
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/datadir"
+	"github.com/hashicorp/waypoint-plugin-sdk/docs"
+	"github.com/hashicorp/waypoint-plugin-sdk/host"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal-shared/pluginclient"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal-shared/protomappers"
+)
+
+// Harness serves a plugin with sdk.DebugServe and connects to it like
+// Waypoint does, so tests exercise the plugin through the real gRPC
+// boundary. Create a Harness with New.
+//
+// The exported fields are the arguments given to every operation. Tests
+// can change them before calling an operation.
+type Harness struct {
+	// UI is the terminal.UI given to operations. It records the output.
+	UI *UI
+
+	// Logger is the hclog.Logger given to operations.
+	Logger hclog.Logger
+
+	// Source, JobInfo and Context describe the app the operations are
+	// for. The app is named "test" in the "default" workspace of the
+	// "test" project and its path is a temporary directory.
+	Source  *component.Source
+	JobInfo *component.JobInfo
+	Context *component.Context
+
+	// Project is the project data directory given to operations, in a
+	// temporary directory. The app and component data directories are
+	// created in it for each operation.
+	Project *datadir.Project
+
+	// DeclaredResources and DestroyedResources are given to operations
+	// that declare or destroy resources. They are reset before every
+	// operation so tests can check them after.
+	DeclaredResources  *component.DeclaredResourcesResp
+	DestroyedResources *component.DestroyedResourcesResp
+
+	// Host is given to operations that request a *host.Client, also
+	// known as *sdk.HostClient. This is nil by default, so operations
+	// that request one fail until it is set.
+	Host host.Service
+
+	t       testing.TB
+	client  *plugin.Client
+	mappers []*argmapper.Func
+}
+
+// New serves a plugin with the given options, the same options given to
+// sdk.Main, and returns a Harness connected to it. The plugin is stopped
+// when the test ends.
+func New(t testing.TB, opts ...sdk.Option) *Harness {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	config, closeCh, err := sdk.DebugServe(ctx, opts...)
+	if err != nil {
+		cancel()
+		t.Fatalf("error serving plugin: %s", err)
+	}
+
+	addr, err := reattachAddr(config.Addr)
+	if err != nil {
+		cancel()
+		t.Fatalf("error connecting to plugin: %s", err)
+	}
+
+	logger := hclog.L().Named("sdktest")
+	clientConfig := pluginclient.ClientConfig(logger, false)
+	clientConfig.Managed = false
+	clientConfig.Reattach = &plugin.ReattachConfig{
+		Protocol:        plugin.Protocol(config.Protocol),
+		ProtocolVersion: config.ProtocolVersion,
+		Pid:             config.Pid,
+		Test:            config.Test,
+		Addr:            addr,
+	}
+
+	// go-plugin only selects the plugin set for the protocol version when
+	// it starts the plugin, so we select it for the reattached plugin.
+	clientConfig.Plugins = clientConfig.VersionedPlugins[config.ProtocolVersion]
+	client := plugin.NewClient(clientConfig)
+
+	t.Cleanup(func() {
+		if rpcClient, err := client.Client(); err == nil {
+			rpcClient.Close()
+		}
+		cancel()
+
+		select {
+		case <-closeCh:
+		case <-time.After(5 * time.Second):
+			t.Errorf("timeout waiting for the plugin to stop")
+		}
+	})
+
+	// The host mappers along with the mappers the plugin serves
+	var mappers []*argmapper.Func
+	for _, raw := range protomappers.All {
+		f, err := argmapper.NewFunc(raw)
+		if err != nil {
+			t.Fatalf("error creating mapper: %s", err)
+		}
+
+		mappers = append(mappers, f)
+	}
+
+	pluginMappers, err := pluginclient.Mappers(client)
+	if err != nil {
+		t.Fatalf("error getting the plugin mappers: %s", err)
+	}
+	mappers = append(mappers, pluginMappers...)
+
+	dir := t.TempDir()
+	project, err := datadir.NewProject(dir + "/data")
+	if err != nil {
+		t.Fatalf("error creating data directory: %s", err)
+	}
+
+	jobInfo := &component.JobInfo{
+		Id:        "test",
+		Local:     true,
+		Workspace: "default",
+		Project:   "test",
+		App:       "test",
+	}
+
+	return &Harness{
+		UI:      &UI{},
+		Logger:  logger,
+		Source:  &component.Source{App: "test", Path: dir},
+		JobInfo: jobInfo,
+		Context: &component.Context{JobInfo: *jobInfo},
+		Project: project,
+
+		t:       t,
+		client:  client,
+		mappers: mappers,
+	}
+}
+
+// Dispense returns the component of the given type that the plugin serves,
+// such as a component.Platform for component.PlatformType. The test fails
+// if the plugin doesn't serve it.
+func (h *Harness) Dispense(typ component.Type) interface{} {
+	h.t.Helper()
+
+	raw, err := pluginclient.Dispense(h.client, typ, "")
+	if err != nil {
+		h.t.Fatalf("error dispensing %s: %s", typ, err)
+	}
+
+	return raw
+}
+
+// Configure configures the component with the given HCL configuration,
+// such as the body of a deploy stanza. The test fails if the configuration
+// is invalid.
+func (h *Harness) Configure(c interface{}, config string) {
+	h.t.Helper()
+
+	f, diags := hclparse.NewParser().ParseHCL([]byte(config), "config.hcl")
+	if diags.HasErrors() {
+		h.t.Fatalf("error parsing configuration: %s", diags.Error())
+	}
+
+	if diags := component.Configure(c, f.Body, nil); diags.HasErrors() {
+		h.t.Fatalf("error configuring %T: %s", c, diags.Error())
+	}
+}
+
+// Documentation returns the documentation of the component. This returns
+// nil if the component doesn't implement component.Documented. The test
+// fails if the plugin returns an error.
+func (h *Harness) Documentation(c interface{}) *docs.Documentation {
+	h.t.Helper()
+
+	d, ok := c.(component.Documented)
+	if !ok {
+		return nil
+	}
+
+	result, err := d.Documentation()
+	if err != nil {
+		h.t.Fatalf("error getting documentation: %s", err)
+	}
+
+	return result
+}
+
+// Call calls an operation function of a dispensed component, such as the
+// result of DeployFunc. The fields of the Harness are available to the
+// function along with args, such as the artifact to deploy. args can be
+// the values returned by earlier calls.
+//
+// Call returns the first result of the function, which is nil if the
+// function only returns an error.
+func (h *Harness) Call(f interface{}, args ...interface{}) (interface{}, error) {
+	h.t.Helper()
+
+	fn, ok := f.(*argmapper.Func)
+	if !ok {
+		h.t.Fatalf("expected a function of a dispensed component, got %T", f)
+	}
+
+	callArgs, err := h.args()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, arg := range args {
+		callArgs = append(callArgs, typed(arg))
+	}
+
+	result := fn.Call(callArgs...)
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+	if result.Len() == 0 {
+		return nil, nil
+	}
+
+	return result.Out(0), nil
+}
+
+// Lifecycle runs the standard lifecycle of a component: it is dispensed,
+// configured with config if it isn't empty, its documentation is
+// requested and its operation runs with args. If the component is a
+// component.Destroyer, the result of the operation is destroyed.
+//
+// The operation is the build, push, deploy or release for the builder,
+// registry, platform and release manager types respectively. Lifecycle
+// returns the result of the operation. The test fails if any step fails.
+func (h *Harness) Lifecycle(typ component.Type, config string, args ...interface{}) interface{} {
+	h.t.Helper()
+
+	c := h.Dispense(typ)
+	if config != "" {
+		h.Configure(c, config)
+	}
+	h.Documentation(c)
+
+	var f interface{}
+	switch typ {
+	case component.BuilderType:
+		f = c.(component.Builder).BuildFunc()
+	case component.RegistryType:
+		f = c.(component.Registry).PushFunc()
+	case component.PlatformType:
+		f = c.(component.Platform).DeployFunc()
+	case component.ReleaseManagerType:
+		f = c.(component.ReleaseManager).ReleaseFunc()
+	default:
+		h.t.Fatalf("Lifecycle doesn't support %s components", typ)
+	}
+
+	result, err := h.Call(f, args...)
+	if err != nil {
+		h.t.Fatalf("error running %s: %s", typ, err)
+	}
+
+	if d, ok := c.(component.Destroyer); ok {
+		if destroy := d.DestroyFunc(); destroy != nil {
+			if _, err := h.Call(destroy, append(args, result)...); err != nil {
+				h.t.Fatalf("error destroying %s: %s", typ, err)
+			}
+		}
+	}
+
+	return result
+}
+
+// Logs calls the LogsFunc of a component.LogPlatform with args, such as
+// the deployment, and returns the log events it sends.
+func (h *Harness) Logs(c interface{}, args ...interface{}) ([]component.LogEvent, error) {
+	h.t.Helper()
+
+	lp, ok := c.(component.LogPlatform)
+	if !ok {
+		h.t.Fatalf("expected a component.LogPlatform, got %T", c)
+	}
+
+	lv := &component.LogViewer{Output: make(chan component.LogEvent)}
+	type callResult struct {
+		err error
+	}
+	doneCh := make(chan callResult, 1)
+	go func() {
+		_, err := h.Call(lp.LogsFunc(), append(args, lv)...)
+		doneCh <- callResult{err: err}
+	}()
+
+	var events []component.LogEvent
+	for {
+		select {
+		case ev := <-lv.Output:
+			events = append(events, ev)
+
+		case result := <-doneCh:
+			// Collect the events that were sent before the call returned
+			for {
+				select {
+				case ev := <-lv.Output:
+					events = append(events, ev)
+				default:
+					return events, result.err
+				}
+			}
+		}
+	}
+}
+
+// args returns the arguments available to every operation.
+func (h *Harness) args() ([]argmapper.Arg, error) {
+	app, err := h.Project.App(h.Source.App)
+	if err != nil {
+		return nil, err
+	}
+
+	comp, err := app.Component("test", "test")
+	if err != nil {
+		return nil, err
+	}
+
+	h.DeclaredResources = &component.DeclaredResourcesResp{}
+	h.DestroyedResources = &component.DestroyedResourcesResp{}
+
+	args := []argmapper.Arg{
+		argmapper.ConverterFunc(h.mappers...),
+		argmapper.Typed(context.Background()),
+		argmapper.Typed(h.Logger),
+		argmapper.Typed(h.UI),
+		argmapper.Typed(h.Source),
+		argmapper.Typed(h.JobInfo),
+		argmapper.Typed(h.Context),
+		argmapper.Typed(h.Project),
+		argmapper.Typed(app),
+		argmapper.Typed(comp),
+		argmapper.Typed(h.DeclaredResources),
+		argmapper.Typed(h.DestroyedResources),
+	}
+	if h.Host != nil {
+		args = append(args, argmapper.Typed(h.Host))
+	}
+
+	return args, nil
+}
+
+// typed returns the argument for v. Results of earlier calls are sent as
+// the message they contain.
+func typed(v interface{}) argmapper.Arg {
+	if pm, ok := v.(component.ProtoMarshaler); ok {
+		if a, ok := pm.Proto().(*opaqueany.Any); ok && a != nil {
+			return argmapper.TypedSubtype(a, string(a.MessageName()))
+		}
+	}
+
+	return argmapper.Typed(v)
+}
+
+// reattachAddr returns the net.Addr for the address of a ReattachConfig.
+func reattachAddr(addr sdk.ReattachConfigAddr) (net.Addr, error) {
+	switch addr.Network {
+	case "unix":
+		return net.ResolveUnixAddr(addr.Network, addr.String)
+	case "tcp":
+		return net.ResolveTCPAddr(addr.Network, addr.String)
+	default:
+		return nil, fmt.Errorf("unknown network: %s", addr.Network)
+	}
+}
@@ -0,0 +1,232 @@
+package sdktest
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/hashicorp/waypoint-plugin-sdk"
+	"github.com/hashicorp/waypoint-plugin-sdk/component"
+	"github.com/hashicorp/waypoint-plugin-sdk/datadir"
+	"github.com/hashicorp/waypoint-plugin-sdk/docs"
+	"github.com/hashicorp/waypoint-plugin-sdk/host"
+	"github.com/hashicorp/waypoint-plugin-sdk/internal/testproto"
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+)
+
+type testPlatform struct {
+	config struct {
+		Name string `hcl:"name"`
+	}
+
+	destroyed []string
+	dataDir   string
+}
+
+func (p *testPlatform) Config() (interface{}, error) {
+	return &p.config, nil
+}
+
+func (p *testPlatform) Documentation() (*docs.Documentation, error) {
+	return docs.New(docs.FromConfig(&p.config))
+}
+
+func (p *testPlatform) DeployFunc() interface{} {
+	return func(
+		ui terminal.UI,
+		src *component.Source,
+		dir *datadir.Component,
+		dcr *component.DeclaredResourcesResp,
+		a *testproto.A,
+	) *testproto.Data {
+		sg := ui.StepGroup()
+		step := sg.Add("Deploying %s", p.config.Name)
+		step.Done()
+		sg.Wait()
+
+		ui.Output("deployed %s for %s", p.config.Name, src.App)
+		p.dataDir = dir.DataDir()
+		return &testproto.Data{Value: p.config.Name, Number: a.Value}
+	}
+}
+
+func (p *testPlatform) DestroyFunc() interface{} {
+	return func(ui terminal.UI, d *testproto.Data) error {
+		ui.Output("destroyed %s", d.Value)
+		p.destroyed = append(p.destroyed, d.Value)
+		return nil
+	}
+}
+
+func (p *testPlatform) LogsFunc() interface{} {
+	return func(lv *component.LogViewer, d *testproto.Data) error {
+		for _, msg := range []string{"starting " + d.Value, "ready"} {
+			lv.Output <- component.LogEvent{
+				Partition: "web",
+				Timestamp: time.Now(),
+				Message:   msg,
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestHarnessLifecycle(t *testing.T) {
+	require := require.New(t)
+
+	p := &testPlatform{}
+	h := New(t, sdk.WithComponents(p))
+
+	result := h.Lifecycle(component.PlatformType, `name = "web"`, &testproto.A{Value: 42})
+
+	var d testproto.Data
+	require.NoError(component.ProtoAnyUnmarshal(result, &d))
+	require.Equal("web", d.Value)
+	require.Equal(int32(42), d.Number)
+
+	// The deployment was destroyed
+	require.Equal([]string{"web"}, p.destroyed)
+
+	// The output was recorded
+	require.Equal([]string{"deployed web for test", "destroyed web"}, h.UI.Lines())
+	require.Equal([]UIStep{{
+		Message: "Deploying web",
+		Status:  terminal.StatusOK,
+		Done:    true,
+	}}, h.UI.Steps())
+
+	// The plugin got a data directory in the project
+	require.NotEmpty(p.dataDir)
+	require.DirExists(p.dataDir)
+}
+
+func TestHarnessCall(t *testing.T) {
+	require := require.New(t)
+
+	p := &testPlatform{}
+	h := New(t, sdk.WithComponents(p))
+
+	c := h.Dispense(component.PlatformType)
+	h.Configure(c, `name = "api"`)
+
+	docs := h.Documentation(c)
+	require.NotNil(docs)
+	require.Len(docs.Fields(), 1)
+	require.Equal("name", docs.Fields()[0].Field)
+
+	// Deploy, then use the result in other operations
+	deployment, err := h.Call(c.(component.Platform).DeployFunc(), &testproto.A{Value: 1})
+	require.NoError(err)
+
+	events, err := h.Logs(c, deployment)
+	require.NoError(err)
+	require.Len(events, 2)
+	require.Equal("starting api", events[0].Message)
+
+	_, err = h.Call(c.(component.Destroyer).DestroyFunc(), deployment)
+	require.NoError(err)
+	require.Equal([]string{"api"}, p.destroyed)
+
+	// Missing arguments are an error
+	_, err = h.Call(c.(component.Platform).DeployFunc())
+	require.Error(err)
+}
+
+func TestHarnessHost(t *testing.T) {
+	require := require.New(t)
+
+	h := New(t, sdk.WithComponents(&testHostPlatform{}))
+	h.Host = &testHost{}
+
+	c := h.Dispense(component.PlatformType)
+	result, err := h.Call(c.(component.Platform).DeployFunc())
+	require.NoError(err)
+
+	var d testproto.Data
+	require.NoError(component.ProtoAnyUnmarshal(result, &d))
+	require.Equal("after d1", d.Value)
+}
+
+func TestHarnessReplay(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "calls.jsonl")
+
+	// Record a deploy that uses the host
+	h := New(t, sdk.WithComponents(&testHostPlatform{}), sdk.WithRecording(path))
+	h.Host = &testHost{}
+	c := h.Dispense(component.PlatformType)
+	_, err := h.Call(c.(component.Platform).DeployFunc())
+	require.NoError(err)
+
+	// The replayed deploy doesn't reach the platform
+	h = New(t, sdk.WithComponents(&testErrorPlatform{}), sdk.WithReplay(path))
+	c = h.Dispense(component.PlatformType)
+	result, err := h.Call(c.(component.Platform).DeployFunc())
+	require.NoError(err)
+
+	var d testproto.Data
+	require.NoError(component.ProtoAnyUnmarshal(result, &d))
+	require.Equal("after d1", d.Value)
+
+	// Only the recorded calls are replayed
+	_, err = h.Call(c.(component.Platform).DeployFunc())
+	require.Error(err)
+	require.Contains(err.Error(), "no more recorded calls")
+}
+
+func TestUIInput(t *testing.T) {
+	require := require.New(t)
+
+	ui := &UI{Answers: []string{"yes"}}
+
+	v, err := ui.Input(&terminal.Input{Prompt: "Continue?"})
+	require.NoError(err)
+	require.Equal("yes", v)
+
+	_, err = ui.Input(&terminal.Input{Prompt: "Continue?"})
+	require.Error(err)
+}
+
+type testHostPlatform struct{}
+
+func (p *testHostPlatform) DeployFunc() interface{} {
+	return func(ctx context.Context, hc *sdk.HostClient) (*testproto.Data, error) {
+		prev, err := hc.PreviousDeployment(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return &testproto.Data{Value: "after " + prev.Id}, nil
+	}
+}
+
+type testErrorPlatform struct{}
+
+func (p *testErrorPlatform) DeployFunc() interface{} {
+	return func() (*testproto.Data, error) {
+		return nil, errors.New("deploy should be replayed")
+	}
+}
+
+type testHost struct{}
+
+func (h *testHost) ListDeployments(
+	ctx context.Context,
+	opts *host.ListDeploymentsOptions,
+) ([]*host.Deployment, error) {
+	return []*host.Deployment{{Id: "d1", State: host.StateSuccess}}, nil
+}
+
+func (h *testHost) Artifact(ctx context.Context, id string) (*host.Artifact, error) {
+	return nil, host.ErrNotFound
+}
+
+func (h *testHost) ConfigVars(ctx context.Context) (map[string]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,264 @@
+package sdktest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/waypoint-plugin-sdk/terminal"
+)
+
+// UI is a terminal.UI that records everything the plugin outputs so tests
+// can check it. The zero value is ready to use.
+//
+// Input returns the values of Answers in order and fails once they are
+// used up. Set Answers before the operation that prompts.
+type UI struct {
+	// Answers are returned by Input in order.
+	Answers []string
+
+	mu     sync.Mutex
+	lines  []string
+	steps  []*UIStep
+	stdout bytes.Buffer
+	stderr bytes.Buffer
+}
+
+// UIStep is a step of a step group that was added to the UI.
+type UIStep struct {
+	// Message is the last message of the step.
+	Message string
+
+	// Status is the last status of the step, such as terminal.StatusOK.
+	// This is terminal.StatusAbort if the step was aborted.
+	Status string
+
+	// Done is true if the step finished, either with Done or Abort.
+	Done bool
+
+	// Output is the data written to the TermOutput of the step.
+	Output string
+
+	ui     *UI
+	output bytes.Buffer
+}
+
+// Lines returns the lines written with Output, NamedValues, Table and
+// Status, in order.
+func (u *UI) Lines() []string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return append([]string(nil), u.lines...)
+}
+
+// String returns all the lines written to the UI joined with newlines.
+// This is for checking that the output contains a message.
+func (u *UI) String() string {
+	return strings.Join(u.Lines(), "\n")
+}
+
+// Steps returns copies of the steps added with StepGroup, in order.
+func (u *UI) Steps() []UIStep {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	result := make([]UIStep, len(u.steps))
+	for i, s := range u.steps {
+		result[i] = UIStep{
+			Message: s.Message,
+			Status:  s.Status,
+			Done:    s.Done,
+			Output:  s.output.String(),
+		}
+	}
+
+	return result
+}
+
+// Stdout and Stderr return the data written to the writers returned by
+// OutputWriters.
+func (u *UI) Stdout() string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.stdout.String()
+}
+
+func (u *UI) Stderr() string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.stderr.String()
+}
+
+// Input implements terminal.UI
+func (u *UI) Input(input *terminal.Input) (string, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if len(u.Answers) == 0 {
+		return "", fmt.Errorf("sdktest: no answer for prompt %q", input.Prompt)
+	}
+
+	answer := u.Answers[0]
+	u.Answers = u.Answers[1:]
+	return answer, nil
+}
+
+// Interactive implements terminal.UI
+func (u *UI) Interactive() bool {
+	return true
+}
+
+// Output implements terminal.UI
+func (u *UI) Output(msg string, raw ...interface{}) {
+	msg, _, _ = terminal.Interpret(msg, raw...)
+	u.addLines(msg)
+}
+
+// NamedValues implements terminal.UI
+func (u *UI) NamedValues(rows []terminal.NamedValue, opts ...terminal.Option) {
+	for _, row := range rows {
+		u.addLines(fmt.Sprintf("%s: %v", row.Name, row.Value))
+	}
+}
+
+// OutputWriters implements terminal.UI
+func (u *UI) OutputWriters() (io.Writer, io.Writer, error) {
+	return &lockedWriter{mu: &u.mu, w: &u.stdout}, &lockedWriter{mu: &u.mu, w: &u.stderr}, nil
+}
+
+// Status implements terminal.UI
+func (u *UI) Status() terminal.Status {
+	return &uiStatus{ui: u}
+}
+
+// Table implements terminal.UI
+func (u *UI) Table(tbl *terminal.Table, opts ...terminal.Option) {
+	u.addLines(strings.Join(tbl.Headers, "\t"))
+	for _, row := range tbl.Rows {
+		cols := make([]string, len(row))
+		for i, entry := range row {
+			cols[i] = entry.Value
+		}
+
+		u.addLines(strings.Join(cols, "\t"))
+	}
+}
+
+// StepGroup implements terminal.UI
+func (u *UI) StepGroup() terminal.StepGroup {
+	return &uiStepGroup{ui: u}
+}
+
+func (u *UI) addLines(msg string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.lines = append(u.lines, strings.Split(msg, "\n")...)
+}
+
+type uiStatus struct {
+	ui *UI
+}
+
+func (s *uiStatus) Update(msg string) {}
+
+func (s *uiStatus) Step(status, msg string) {
+	s.ui.addLines(msg)
+}
+
+func (s *uiStatus) Close() error {
+	return nil
+}
+
+type uiStepGroup struct {
+	ui *UI
+	wg sync.WaitGroup
+}
+
+func (g *uiStepGroup) Add(msg string, args ...interface{}) terminal.Step {
+	g.wg.Add(1)
+
+	s := &UIStep{Message: sprintf(msg, args...), ui: g.ui}
+	g.ui.mu.Lock()
+	g.ui.steps = append(g.ui.steps, s)
+	g.ui.mu.Unlock()
+
+	return &uiStep{step: s, wg: &g.wg}
+}
+
+func (g *uiStepGroup) Wait() {
+	g.wg.Wait()
+}
+
+type uiStep struct {
+	step *UIStep
+	wg   *sync.WaitGroup
+}
+
+func (s *uiStep) TermOutput() io.Writer {
+	return &lockedWriter{mu: &s.step.ui.mu, w: &s.step.output}
+}
+
+func (s *uiStep) Update(msg string, args ...interface{}) {
+	s.step.ui.mu.Lock()
+	defer s.step.ui.mu.Unlock()
+	s.step.Message = sprintf(msg, args...)
+}
+
+func (s *uiStep) Status(status string) {
+	s.step.ui.mu.Lock()
+	defer s.step.ui.mu.Unlock()
+	s.step.Status = status
+}
+
+func (s *uiStep) Done() {
+	s.finish(terminal.StatusOK)
+}
+
+func (s *uiStep) Abort() {
+	s.finish(terminal.StatusAbort)
+}
+
+func (s *uiStep) finish(status string) {
+	s.step.ui.mu.Lock()
+	defer s.step.ui.mu.Unlock()
+
+	if s.step.Done {
+		return
+	}
+
+	s.step.Done = true
+	if status == terminal.StatusAbort || s.step.Status == "" {
+		s.step.Status = status
+	}
+	s.wg.Done()
+}
+
+// sprintf formats msg with args if there are any.
+func sprintf(msg string, args ...interface{}) string {
+	if len(args) == 0 {
+		return msg
+	}
+
+	return fmt.Sprintf(msg, args...)
+}
+
+// lockedWriter writes to w while holding mu.
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+var (
+	_ terminal.UI        = (*UI)(nil)
+	_ terminal.Status    = (*uiStatus)(nil)
+	_ terminal.StepGroup = (*uiStepGroup)(nil)
+	_ terminal.Step      = (*uiStep)(nil)
+)
@@ -182,13 +182,25 @@ func (ui *glintUI) Table(tbl *Table, opts ...Option) {
 		opt(cfg)
 	}
 
+	ui.d.Append(glint.Finalize(glint.Text(renderTable(tbl, cfg.Style))))
+}
+
+// LiveTable implements LiveTableUI
+func (ui *glintUI) LiveTable(headers ...string) LiveTable {
+	t := &glintLiveTable{rows: newLiveTableRows(headers)}
+	ui.d.Append(t)
+	return t
+}
+
+// renderTable renders the table as text with the given style.
+func renderTable(tbl *Table, style string) string {
 	var buf bytes.Buffer
 	table := tablewriter.NewWriter(&buf)
 	table.SetHeader(tbl.Headers)
 	table.SetBorder(false)
 	table.SetAutoWrapText(true)
 
-	if cfg.Style == "Simple" {
+	if style == "Simple" {
 		// Format the table without borders, simple output
 
 		table.SetAutoFormatHeaders(true)
@@ -220,5 +232,5 @@ func (ui *glintUI) Table(tbl *Table, opts ...Option) {
 
 	table.Render()
 
-	ui.d.Append(glint.Finalize(glint.Text(buf.String())))
+	return buf.String()
 }
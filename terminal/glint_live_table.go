@@ -0,0 +1,49 @@
+package terminal
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mitchellh/go-glint"
+)
+
+// glintLiveTable implements LiveTable by re-rendering the table on
+// every frame until it is closed.
+type glintLiveTable struct {
+	rows *liveTableRows
+
+	mu     sync.Mutex
+	closed bool
+}
+
+func (t *glintLiveTable) SetRow(key string, row ...TableEntry) {
+	t.rows.set(key, row)
+}
+
+func (t *glintLiveTable) DeleteRow(key string) {
+	t.rows.delete(key)
+}
+
+func (t *glintLiveTable) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closed = true
+	return nil
+}
+
+func (t *glintLiveTable) Body(context.Context) glint.Component {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var c glint.Component = glint.Text(renderTable(t.rows.table(), ""))
+	if t.closed {
+		c = glint.Finalize(c)
+	}
+
+	return c
+}
+
+var (
+	_ LiveTable   = (*glintLiveTable)(nil)
+	_ LiveTableUI = (*glintUI)(nil)
+)
@@ -0,0 +1,367 @@
+package terminal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// OutputFormat is the format of the output of a UI.
+type OutputFormat string
+
+const (
+	// OutputFormatText is colored text meant for humans. This is the
+	// format of every UI that doesn't implement OutputFormatUI.
+	OutputFormatText OutputFormat = "text"
+
+	// OutputFormatJSON is a stream of JSONEvent values, one per line,
+	// meant for machines such as CI systems. See JSONUI.
+	OutputFormatJSON OutputFormat = "json"
+)
+
+// OutputFormatUI is implemented by UIs that may output a format other
+// than text. Plugins should use FormatOf rather than checking for this
+// interface.
+type OutputFormatUI interface {
+	UI
+
+	// OutputFormat returns the format of the output.
+	OutputFormat() OutputFormat
+}
+
+// FormatOf returns the output format of ui. Plugins can use this to tailor
+// what they output, for example to skip decorative output that is only
+// useful to humans when the output is JSON.
+func FormatOf(ui UI) OutputFormat {
+	if f, ok := ui.(OutputFormatUI); ok {
+		if v := f.OutputFormat(); v != "" {
+			return v
+		}
+	}
+
+	return OutputFormatText
+}
+
+// The types of JSONEvent.
+const (
+	JSONEventOutput      = "output"
+	JSONEventNamedValues = "named_values"
+	JSONEventTable       = "table"
+	JSONEventStatus      = "status"
+	JSONEventStep        = "step"
+)
+
+// JSONEvent is a single event output by JSONUI. Only the fields relevant
+// to the event type are set.
+type JSONEvent struct {
+	// Type is the type of event, such as JSONEventOutput.
+	Type string `json:"type"`
+
+	// Time is when the event happened.
+	Time time.Time `json:"time"`
+
+	// Message is the message for output, status and step events.
+	Message string `json:"message,omitempty"`
+
+	// Style is the style of output events, such as ErrorStyle.
+	Style string `json:"style,omitempty"`
+
+	// Stream is "stdout" or "stderr" for output written to the writers
+	// returned by OutputWriters.
+	Stream string `json:"stream,omitempty"`
+
+	// Status is the status of status and step events, such as StatusOK.
+	// This is empty while a status or step is in progress.
+	Status string `json:"status,omitempty"`
+
+	// Step identifies the step of step events. Steps are numbered from 1
+	// in the order they were added.
+	Step int `json:"step,omitempty"`
+
+	// Done is true for the final event of a step.
+	Done bool `json:"done,omitempty"`
+
+	// Output is the terminal output of a step.
+	Output string `json:"output,omitempty"`
+
+	// Values are the values of named_values events.
+	Values []JSONNamedValue `json:"values,omitempty"`
+
+	// Table is the table of table events.
+	Table *JSONTable `json:"table,omitempty"`
+}
+
+// JSONNamedValue is a single NamedValue of a named_values JSONEvent.
+type JSONNamedValue struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// JSONTable is the Table of a table JSONEvent.
+type JSONTable struct {
+	Headers []string   `json:"headers"`
+	Rows    [][]string `json:"rows"`
+}
+
+type jsonUI struct {
+	mu    sync.Mutex
+	enc   *json.Encoder
+	steps int
+	now   func() time.Time
+}
+
+// JSONUI returns a UI that writes every call as a JSONEvent to w, one
+// event per line. This is used by hosts to produce machine-readable
+// output. The UI is not interactive.
+func JSONUI(ctx context.Context, w io.Writer) UI {
+	return &jsonUI{
+		enc: json.NewEncoder(w),
+		now: time.Now,
+	}
+}
+
+func (ui *jsonUI) emit(ev *JSONEvent) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ev.Time = ui.now()
+
+	// There is nowhere to report the error; the output is gone either way.
+	_ = ui.enc.Encode(ev)
+}
+
+// OutputFormat implements OutputFormatUI
+func (ui *jsonUI) OutputFormat() OutputFormat {
+	return OutputFormatJSON
+}
+
+// Input implements UI
+func (ui *jsonUI) Input(input *Input) (string, error) {
+	return "", ErrNonInteractive
+}
+
+// Interactive implements UI
+func (ui *jsonUI) Interactive() bool {
+	return false
+}
+
+// Output implements UI
+func (ui *jsonUI) Output(msg string, raw ...interface{}) {
+	msg, style, _ := Interpret(msg, raw...)
+	ui.emit(&JSONEvent{
+		Type:    JSONEventOutput,
+		Message: msg,
+		Style:   style,
+	})
+}
+
+// NamedValues implements UI
+func (ui *jsonUI) NamedValues(rows []NamedValue, opts ...Option) {
+	values := make([]JSONNamedValue, len(rows))
+	for i, row := range rows {
+		v := row.Value
+		if _, err := json.Marshal(v); err != nil {
+			v = fmt.Sprintf("%v", v)
+		}
+
+		values[i] = JSONNamedValue{Name: row.Name, Value: v}
+	}
+
+	ui.emit(&JSONEvent{
+		Type:   JSONEventNamedValues,
+		Values: values,
+	})
+}
+
+// OutputWriters implements UI
+func (ui *jsonUI) OutputWriters() (io.Writer, io.Writer, error) {
+	return &jsonWriter{ui: ui, ev: JSONEvent{Type: JSONEventOutput, Stream: "stdout"}},
+		&jsonWriter{ui: ui, ev: JSONEvent{Type: JSONEventOutput, Stream: "stderr"}},
+		nil
+}
+
+// Status implements UI
+func (ui *jsonUI) Status() Status {
+	return &jsonStatus{ui: ui}
+}
+
+// Table implements UI
+func (ui *jsonUI) Table(tbl *Table, opts ...Option) {
+	result := &JSONTable{
+		Headers: tbl.Headers,
+		Rows:    make([][]string, len(tbl.Rows)),
+	}
+	for i, row := range tbl.Rows {
+		result.Rows[i] = make([]string, len(row))
+		for j, ent := range row {
+			result.Rows[i][j] = ent.Value
+		}
+	}
+
+	ui.emit(&JSONEvent{
+		Type:  JSONEventTable,
+		Table: result,
+	})
+}
+
+// StepGroup implements UI
+func (ui *jsonUI) StepGroup() StepGroup {
+	return &jsonStepGroup{ui: ui}
+}
+
+type jsonStatus struct {
+	ui *jsonUI
+}
+
+func (s *jsonStatus) Update(msg string) {
+	s.ui.emit(&JSONEvent{Type: JSONEventStatus, Message: msg})
+}
+
+func (s *jsonStatus) Step(status, msg string) {
+	s.ui.emit(&JSONEvent{Type: JSONEventStatus, Message: msg, Status: status})
+}
+
+func (s *jsonStatus) Close() error {
+	return nil
+}
+
+type jsonStepGroup struct {
+	ui     *jsonUI
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+	closed bool
+}
+
+// Add implements StepGroup
+func (f *jsonStepGroup) Add(str string, args ...interface{}) Step {
+	f.ui.mu.Lock()
+	f.ui.steps++
+	id := f.ui.steps
+	f.ui.mu.Unlock()
+
+	step := &jsonStep{ui: f.ui, id: id}
+	step.Update(str, args...)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Like the other UIs, steps added after Wait are not waited on.
+	if !f.closed {
+		step.wg = &f.wg
+		f.wg.Add(1)
+	}
+
+	return step
+}
+
+// Wait implements StepGroup
+func (f *jsonStepGroup) Wait() {
+	f.mu.Lock()
+	f.closed = true
+	f.mu.Unlock()
+
+	f.wg.Wait()
+}
+
+type jsonStep struct {
+	ui *jsonUI
+	id int
+	wg *sync.WaitGroup
+
+	mu     sync.Mutex
+	msg    string
+	status string
+	done   bool
+}
+
+func (s *jsonStep) TermOutput() io.Writer {
+	return &jsonWriter{ui: s.ui, ev: JSONEvent{Type: JSONEventStep, Step: s.id}}
+}
+
+func (s *jsonStep) Update(str string, args ...interface{}) {
+	s.mu.Lock()
+	s.msg = fmt.Sprintf(str, args...)
+	ev := s.event()
+	s.mu.Unlock()
+
+	s.ui.emit(ev)
+}
+
+func (s *jsonStep) Status(status string) {
+	s.mu.Lock()
+	s.status = status
+	ev := s.event()
+	s.mu.Unlock()
+
+	s.ui.emit(ev)
+}
+
+func (s *jsonStep) Done() {
+	s.finish("")
+}
+
+func (s *jsonStep) Abort() {
+	s.finish(StatusAbort)
+}
+
+func (s *jsonStep) finish(status string) {
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		return
+	}
+
+	s.done = true
+	if status != "" {
+		s.status = status
+	}
+	ev := s.event()
+	s.mu.Unlock()
+
+	s.ui.emit(ev)
+	if s.wg != nil {
+		s.wg.Done()
+	}
+}
+
+// event returns the event for the current state. This must be called with
+// the lock held.
+func (s *jsonStep) event() *JSONEvent {
+	return &JSONEvent{
+		Type:    JSONEventStep,
+		Step:    s.id,
+		Message: s.msg,
+		Status:  s.status,
+		Done:    s.done,
+	}
+}
+
+// jsonWriter emits every write as an event based on ev with the written
+// data as the message or step output.
+type jsonWriter struct {
+	ui *jsonUI
+	ev JSONEvent
+}
+
+func (w *jsonWriter) Write(p []byte) (int, error) {
+	ev := w.ev
+	data := reAnsi.ReplaceAllString(string(p), "")
+	if ev.Type == JSONEventStep {
+		ev.Output = data
+	} else {
+		ev.Message = data
+	}
+
+	w.ui.emit(&ev)
+	return len(p), nil
+}
+
+var (
+	_ OutputFormatUI = (*jsonUI)(nil)
+	_ Status         = (*jsonStatus)(nil)
+	_ StepGroup      = (*jsonStepGroup)(nil)
+	_ Step           = (*jsonStep)(nil)
+)
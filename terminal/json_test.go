@@ -0,0 +1,78 @@
+package terminal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONUI(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	ui := JSONUI(context.Background(), &buf)
+	require.Equal(OutputFormatJSON, FormatOf(ui))
+	require.False(ui.Interactive())
+
+	ui.Output("hello %s", "world", WithErrorStyle())
+	ui.NamedValues([]NamedValue{{Name: "count", Value: 2}})
+	ui.Table(&Table{
+		Headers: []string{"Name"},
+		Rows:    [][]TableEntry{{{Value: "a", Color: Green}}},
+	})
+
+	st := ui.Status()
+	st.Update("working")
+	st.Step(StatusOK, "worked")
+	require.NoError(st.Close())
+
+	sg := ui.StepGroup()
+	step := sg.Add("step %d", 1)
+	fmt.Fprint(step.TermOutput(), "\x1b[31mred\x1b[0m")
+	step.Status(StatusWarn)
+	step.Done()
+	sg.Add("step 2").Abort()
+	sg.Wait()
+
+	var events []*JSONEvent
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var ev JSONEvent
+		require.NoError(dec.Decode(&ev))
+		require.False(ev.Time.IsZero())
+		events = append(events, &ev)
+	}
+
+	// Ignore the times for the comparison
+	ts := events[0].Time
+	for _, ev := range events {
+		ev.Time = ts
+	}
+
+	require.Equal([]*JSONEvent{
+		{Type: JSONEventOutput, Time: ts, Message: "hello world", Style: ErrorStyle},
+		{Type: JSONEventNamedValues, Time: ts, Values: []JSONNamedValue{{Name: "count", Value: float64(2)}}},
+		{Type: JSONEventTable, Time: ts, Table: &JSONTable{Headers: []string{"Name"}, Rows: [][]string{{"a"}}}},
+		{Type: JSONEventStatus, Time: ts, Message: "working"},
+		{Type: JSONEventStatus, Time: ts, Message: "worked", Status: StatusOK},
+		{Type: JSONEventStep, Time: ts, Step: 1, Message: "step 1"},
+		{Type: JSONEventStep, Time: ts, Step: 1, Output: "red"},
+		{Type: JSONEventStep, Time: ts, Step: 1, Message: "step 1", Status: StatusWarn},
+		{Type: JSONEventStep, Time: ts, Step: 1, Message: "step 1", Status: StatusWarn, Done: true},
+		{Type: JSONEventStep, Time: ts, Step: 2, Message: "step 2"},
+		{Type: JSONEventStep, Time: ts, Step: 2, Message: "step 2", Status: StatusAbort, Done: true},
+	}, events)
+}
+
+func TestFormatOf(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(OutputFormatText, FormatOf(NonInteractiveUI(context.Background())))
+
+	ui := JSONUI(context.Background(), &bytes.Buffer{})
+	require.Equal(OutputFormatJSON, FormatOf(LocalInputUI(ui, false)))
+}
@@ -0,0 +1,134 @@
+package terminal
+
+import (
+	"sync"
+)
+
+// LiveTable is a table that is displayed while its rows are added and
+// updated in place, such as a list of pods with their status and restart
+// count. Rows are identified by a key and are displayed in the order they
+// were first added. A LiveTable can be driven by concurrent goroutines
+// safely.
+type LiveTable interface {
+	// SetRow adds the row with the given key or replaces it if it
+	// already exists.
+	SetRow(key string, row ...TableEntry)
+
+	// DeleteRow removes the row with the given key.
+	DeleteRow(key string)
+
+	// Close should be called when the live updating is complete. The
+	// final state of the table is left in the output.
+	Close() error
+}
+
+// LiveTableUI is implemented by UIs that can display a LiveTable. Plugins
+// should use NewLiveTable rather than checking for this interface.
+type LiveTableUI interface {
+	UI
+
+	// LiveTable returns a table with the given headers that is updated
+	// in place. While a LiveTable is live (Close isn't called), other
+	// methods on UI should NOT be called.
+	LiveTable(headers ...string) LiveTable
+}
+
+// NewLiveTable returns a LiveTable with the given headers that is displayed
+// by ui. If ui can't update output in place, such as the non-interactive
+// UI, the rows are kept and the final table is output with UI.Table when
+// the LiveTable is closed.
+func NewLiveTable(ui UI, headers ...string) LiveTable {
+	if lt, ok := ui.(LiveTableUI); ok {
+		return lt.LiveTable(headers...)
+	}
+
+	return &bufferedLiveTable{
+		ui:   ui,
+		rows: newLiveTableRows(headers),
+	}
+}
+
+// liveTableRows is the state of a live table shared by the
+// implementations.
+type liveTableRows struct {
+	mu      sync.Mutex
+	headers []string
+	keys    []string
+	rows    map[string][]TableEntry
+}
+
+func newLiveTableRows(headers []string) *liveTableRows {
+	return &liveTableRows{
+		headers: headers,
+		rows:    map[string][]TableEntry{},
+	}
+}
+
+func (t *liveTableRows) set(key string, row []TableEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.rows[key]; !ok {
+		t.keys = append(t.keys, key)
+	}
+	t.rows[key] = row
+}
+
+func (t *liveTableRows) delete(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.rows[key]; !ok {
+		return
+	}
+
+	delete(t.rows, key)
+	for i, k := range t.keys {
+		if k == key {
+			t.keys = append(t.keys[:i], t.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// table returns the current state as a Table.
+func (t *liveTableRows) table() *Table {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tbl := NewTable(t.headers...)
+	for _, k := range t.keys {
+		tbl.Rows = append(tbl.Rows, t.rows[k])
+	}
+
+	return tbl
+}
+
+// bufferedLiveTable implements LiveTable for UIs that don't implement
+// LiveTableUI by outputting the final table on Close.
+type bufferedLiveTable struct {
+	ui   UI
+	rows *liveTableRows
+
+	closeOnce sync.Once
+}
+
+func (t *bufferedLiveTable) SetRow(key string, row ...TableEntry) {
+	t.rows.set(key, row)
+}
+
+func (t *bufferedLiveTable) DeleteRow(key string) {
+	t.rows.delete(key)
+}
+
+func (t *bufferedLiveTable) Close() error {
+	t.closeOnce.Do(func() {
+		t.ui.Table(t.rows.table())
+	})
+
+	return nil
+}
+
+var (
+	_ LiveTable = (*bufferedLiveTable)(nil)
+)
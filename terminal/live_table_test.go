@@ -0,0 +1,63 @@
+package terminal
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// tableUI records the tables output to it.
+type tableUI struct {
+	UI
+	tables []*Table
+}
+
+func (ui *tableUI) Table(tbl *Table, opts ...Option) {
+	ui.tables = append(ui.tables, tbl)
+}
+
+func TestLiveTable_fallback(t *testing.T) {
+	require := require.New(t)
+
+	ui := &tableUI{UI: NonInteractiveUI(context.Background())}
+	lt := NewLiveTable(ui, "Name", "Status")
+
+	lt.SetRow("a", TableEntry{Value: "a"}, TableEntry{Value: "Pending"})
+	lt.SetRow("b", TableEntry{Value: "b"}, TableEntry{Value: "Pending"})
+	lt.SetRow("c", TableEntry{Value: "c"}, TableEntry{Value: "Pending"})
+	lt.SetRow("a", TableEntry{Value: "a"}, TableEntry{Value: "Running", Color: Green})
+	lt.DeleteRow("b")
+
+	// Nothing is output until the table is closed
+	require.Empty(ui.tables)
+	require.NoError(lt.Close())
+	require.NoError(lt.Close())
+
+	require.Len(ui.tables, 1)
+	require.Equal(&Table{
+		Headers: []string{"Name", "Status"},
+		Rows: [][]TableEntry{
+			{{Value: "a"}, {Value: "Running", Color: Green}},
+			{{Value: "c"}, {Value: "Pending"}},
+		},
+	}, ui.tables[0])
+}
+
+func TestLiveTable_glint(t *testing.T) {
+	require := require.New(t)
+
+	lt := &glintLiveTable{rows: newLiveTableRows([]string{"Name", "Status"})}
+	lt.SetRow("a", TableEntry{Value: "pod-a"}, TableEntry{Value: "Pending"})
+
+	render := func() string {
+		return renderTable(lt.rows.table(), "")
+	}
+	require.True(strings.Contains(render(), "Pending"))
+
+	lt.SetRow("a", TableEntry{Value: "pod-a"}, TableEntry{Value: "Running"})
+	require.True(strings.Contains(render(), "Running"))
+	require.False(strings.Contains(render(), "Pending"))
+	require.NotNil(lt.Body(context.Background()))
+}
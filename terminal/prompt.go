@@ -0,0 +1,107 @@
+package terminal
+
+import (
+	"strings"
+)
+
+// ConfirmUI is implemented by UIs that can ask the user a yes or no
+// question natively. Plugins should use Confirm rather than checking for
+// this interface.
+type ConfirmUI interface {
+	UI
+
+	// Confirm asks the user to confirm the prompt and returns true if
+	// they did. ErrNonInteractive is returned if the UI isn't interactive.
+	Confirm(prompt string) (bool, error)
+}
+
+// Prompt asks the user for a single line of input. If secret is true the
+// input is masked. ErrNonInteractive is returned if ui isn't interactive.
+func Prompt(ui UI, prompt string, secret bool) (string, error) {
+	if !ui.Interactive() {
+		return "", ErrNonInteractive
+	}
+
+	return ui.Input(&Input{
+		Prompt: prompt,
+		Secret: secret,
+	})
+}
+
+// Confirm asks the user a yes or no question and returns true if they
+// answered yes. If ui doesn't implement ConfirmUI, the question is asked
+// using Input and "y" or "yes" in any case is a yes. ErrNonInteractive is
+// returned if ui isn't interactive.
+func Confirm(ui UI, prompt string) (bool, error) {
+	if !ui.Interactive() {
+		return false, ErrNonInteractive
+	}
+
+	if c, ok := ui.(ConfirmUI); ok {
+		return c.Confirm(prompt)
+	}
+
+	answer, err := ui.Input(&Input{
+		Prompt: prompt + " [y/N]",
+	})
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// LocalInputUI returns ui if local is true. Otherwise it returns a UI that
+// reports that it isn't interactive and returns ErrNonInteractive for any
+// input, while output is sent to ui as usual. This should be used with
+// component.JobInfo.Local so that plugins only prompt when the operation
+// is running alongside the user, such as during `waypoint auth`:
+//
+//	ui = terminal.LocalInputUI(ui, ji.Local)
+func LocalInputUI(ui UI, local bool) UI {
+	if local {
+		return ui
+	}
+
+	return &noInputUI{UI: ui}
+}
+
+// noInputUI wraps a UI to disable input. See LocalInputUI.
+type noInputUI struct {
+	UI
+}
+
+func (ui *noInputUI) Input(*Input) (string, error) {
+	return "", ErrNonInteractive
+}
+
+func (ui *noInputUI) Interactive() bool {
+	return false
+}
+
+func (ui *noInputUI) Confirm(string) (bool, error) {
+	return false, ErrNonInteractive
+}
+
+// LiveTable implements LiveTableUI so that wrapping a UI doesn't disable
+// live tables.
+func (ui *noInputUI) LiveTable(headers ...string) LiveTable {
+	return NewLiveTable(ui.UI, headers...)
+}
+
+// OutputFormat implements OutputFormatUI so that wrapping a UI keeps its
+// output format.
+func (ui *noInputUI) OutputFormat() OutputFormat {
+	return FormatOf(ui.UI)
+}
+
+var (
+	_ ConfirmUI      = (*noInputUI)(nil)
+	_ LiveTableUI    = (*noInputUI)(nil)
+	_ OutputFormatUI = (*noInputUI)(nil)
+)
@@ -0,0 +1,55 @@
+package terminal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// answerUI is an interactive UI that answers every input with answer.
+type answerUI struct {
+	UI
+
+	answer string
+}
+
+func (ui *answerUI) Interactive() bool { return true }
+
+func (ui *answerUI) Input(*Input) (string, error) { return ui.answer, nil }
+
+func TestConfirm(t *testing.T) {
+	cases := map[string]bool{
+		"y":     true,
+		"YES":   true,
+		" yes ": true,
+		"":      false,
+		"n":     false,
+		"yep":   false,
+	}
+
+	for answer, expected := range cases {
+		t.Run(answer, func(t *testing.T) {
+			ui := &answerUI{UI: NonInteractiveUI(context.Background()), answer: answer}
+			ok, err := Confirm(ui, "Continue?")
+			require.NoError(t, err)
+			require.Equal(t, expected, ok)
+		})
+	}
+}
+
+func TestLocalInputUI(t *testing.T) {
+	require := require.New(t)
+
+	ui := &answerUI{UI: NonInteractiveUI(context.Background()), answer: "y"}
+	require.Equal(UI(ui), LocalInputUI(ui, true))
+
+	remote := LocalInputUI(ui, false)
+	require.False(remote.Interactive())
+	_, err := Prompt(remote, "Token:", true)
+	require.Equal(ErrNonInteractive, err)
+	_, err = Confirm(remote, "Continue?")
+	require.Equal(ErrNonInteractive, err)
+	_, err = remote.Input(&Input{Prompt: "Token:"})
+	require.Equal(ErrNonInteractive, err)
+}